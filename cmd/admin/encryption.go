@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"product-management/config"
+	"product-management/internal/services"
+	"product-management/pkg/fieldcrypt"
+
+	"github.com/spf13/cobra"
+)
+
+func newReencryptCommand() *cobra.Command {
+	var batchSize int
+	cmd := &cobra.Command{
+		Use:   "reencrypt",
+		Short: "Re-encrypt sensitive fields with the active encryption key",
+		Long: `Rewrite every encrypted field still under an old key or in plaintext with the
+active key of ENCRYPTION_KEYS, a batch at a time. Rows changed by the servers
+meanwhile are skipped.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reencrypt(cfg, batchSize)
+		},
+	}
+	cmd.Flags().IntVar(&batchSize, "batch", 500, "rows read and rewritten at a time")
+	return cmd
+}
+
+func reencrypt(cfg *config.Config, batchSize int) error {
+	if batchSize < 1 {
+		return errors.New("--batch must be positive")
+	}
+	results, err := services.NewEncryptionService().Reencrypt(batchSize)
+	for _, result := range results {
+		fmt.Printf("%s: re-encrypted %d of %d rows with key %s", result.Table, result.Reencrypted, result.Rows, fieldcrypt.Default.ActiveKey())
+		if result.Skipped > 0 {
+			fmt.Printf(", %d changed meanwhile", result.Skipped)
+		}
+		fmt.Println()
+	}
+	return err
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/pkg/geoip"
+
+	"github.com/spf13/cobra"
+)
+
+func newCheckIntegrityCommand() *cobra.Command {
+	var repair bool
+	var only []string
+	cmd := &cobra.Command{
+		Use:   "check-integrity",
+		Short: "Report records with broken relationships, and repair them with --repair",
+		Long: `Report how many records each integrity check finds, with samples. With
+--repair, repair the records of every check, or of the --checks given, and
+record the repair in the audit log.`,
+		Example: "  admin check-integrity --repair --checks negative_stock",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkIntegrity(repair, only)
+		},
+	}
+	cmd.Flags().BoolVar(&repair, "repair", false, "repair the broken records found")
+	cmd.Flags().StringSliceVar(&only, "checks", nil, "comma-separated checks to repair, all when omitted: "+strings.Join(services.IntegrityCheckNames(), ", "))
+	return cmd
+}
+
+func checkIntegrity(repair bool, checks []string) error {
+	integrityService := services.NewIntegrityService()
+	report, err := integrityService.Check()
+	if err != nil {
+		return err
+	}
+	for _, check := range report.Checks {
+		fmt.Printf("%s: %d\n", check.Check, check.Count)
+		for _, sample := range check.Samples {
+			fmt.Printf("  %s\n", sample)
+		}
+		if check.Count > int64(len(check.Samples)) {
+			fmt.Printf("  and %d more\n", check.Count-int64(len(check.Samples)))
+		}
+	}
+	if !repair {
+		if report.Issues > 0 {
+			fmt.Println("Run with --repair to repair them")
+		}
+		return nil
+	}
+
+	// Actor 0 marks actions taken by an operator rather than a user
+	if err := services.NewAuditService().Record(0, geoip.Location{}, models.AuditIntegrityRepair, map[string]interface{}{
+		"checks": checks,
+		"source": "admin-cli",
+	}); err != nil {
+		return fmt.Errorf("failed to record audit log: %w", err)
+	}
+	result, err := integrityService.Repair(checks, 0)
+	if err != nil {
+		return err
+	}
+	for _, check := range result.Checks {
+		fmt.Printf("Repaired %d %s\n", check.Repaired, check.Check)
+	}
+	return nil
+}
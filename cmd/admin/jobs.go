@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"product-management/config"
+	"product-management/internal/repositories"
+	"product-management/internal/services"
+	"product-management/pkg/database"
+	"product-management/pkg/mailer"
+	"product-management/pkg/seeder"
+	"product-management/pkg/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// barcodeCachePrefix is where generated barcode images are cached in storage
+const barcodeCachePrefix = "barcodes/"
+
+// jobs are the background jobs of the server that can be run once on demand
+var jobs = map[string]func(cfg *config.Config) error{
+	"weekly-digest":     sendWeeklyDigests,
+	"storage-lifecycle": applyStorageLifecycle,
+	"retention":         applyRetention,
+	"reporting-refresh": refreshReporting,
+	"category-counts":   reconcileCategoryCounts,
+	"sandbox-reset":     resetSandbox,
+}
+
+func jobNames() []string {
+	names := make([]string, 0, len(jobs))
+	for name := range jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func newRunJobCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "run-job <job>",
+		Short: "Run a background job once: " + strings.Join(jobNames(), ", "),
+		Long: `Run a background job of the server once, now. sandbox-reset refuses to run
+unless SANDBOX_MODE=true.
+
+Jobs: ` + strings.Join(jobNames(), ", "),
+		Example:   "  admin run-job weekly-digest",
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: jobNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return jobs[args[0]](cfg)
+		},
+	}
+}
+
+func newClearCacheCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear-cache",
+		Short: "Delete cached barcode images from storage",
+		Long: `Delete the barcode images cached in storage. Responses cached in the memory
+of running servers expire on their own within CACHE_TTL.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return clearCache()
+		},
+	}
+}
+
+// clearCache deletes the barcode images cached in storage
+func clearCache() error {
+	ctx := context.Background()
+	objects, err := storage.Store.List(ctx, barcodeCachePrefix)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		if err := storage.Store.Delete(ctx, object.Key); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Deleted %d cached barcode images\n", len(objects))
+	return nil
+}
+
+// sendWeeklyDigests sends the weekly digests and waits for the mail queue to
+// drain before returning
+func sendWeeklyDigests(cfg *config.Config) error {
+	sender, err := mailer.NewSender(cfg.MailDriver, cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword)
+	if err != nil {
+		return err
+	}
+	mailer.Default = mailer.New(sender, mailer.NewTemplates(cfg.MailTemplateDir), repositories.NewEmailSuppressionRepository(database.DB),
+		cfg.MailFrom, cfg.MailQueueSize, cfg.MailMaxAttempts)
+	stopMailer := mailer.Default.Start(cfg.MailWorkers)
+	defer stopMailer()
+
+	if err := services.NewDigestService(cfg.LowStockThreshold, cfg.AppURL).SendWeeklyDigests(time.Now()); err != nil {
+		return err
+	}
+	mailer.Default.Wait()
+	fmt.Println("Sent weekly digests")
+	return nil
+}
+
+func applyStorageLifecycle(cfg *config.Config) error {
+	if len(cfg.StorageLifecycleRules) == 0 {
+		return errors.New("no STORAGE_LIFECYCLE_RULES are configured")
+	}
+	for prefix, age := range cfg.StorageLifecycleRules {
+		deleted, err := storage.DeleteOlderThan(context.Background(), storage.Store, prefix, age)
+		if err != nil {
+			return fmt.Errorf("%s: %w", prefix, err)
+		}
+		fmt.Printf("Deleted %d objects under %s older than %v\n", deleted, prefix, age)
+	}
+	return nil
+}
+
+func applyRetention(cfg *config.Config) error {
+	if len(cfg.RetentionRules) == 0 {
+		return errors.New("no RETENTION_RULES are configured")
+	}
+	if err := services.CheckRetentionRules(cfg.RetentionRules); err != nil {
+		return err
+	}
+	if err := services.NewRetentionService(cfg.RetentionRules, cfg.RetentionInterval).Run(); err != nil {
+		return err
+	}
+	fmt.Println("Applied retention rules")
+	return nil
+}
+
+func refreshReporting(cfg *config.Config) error {
+	if err := services.NewReportingService(cfg.ReportingRefreshInterval).Run(); err != nil {
+		return err
+	}
+	fmt.Println("Refreshed reporting views")
+	return nil
+}
+
+func reconcileCategoryCounts(cfg *config.Config) error {
+	corrected, err := services.NewCategoryCountService(cfg.CategoryCountReconcileInterval).Run()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Corrected the product counts of %d categories\n", corrected)
+	return nil
+}
+
+func resetSandbox(cfg *config.Config) error {
+	// Outside sandbox mode the database holds real data
+	if !cfg.SandboxMode {
+		return errors.New("SANDBOX_MODE is not enabled")
+	}
+	if err := seeder.ResetSandbox(database.DB); err != nil {
+		return err
+	}
+	fmt.Println("Reset sandbox data")
+	return nil
+}
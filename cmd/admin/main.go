@@ -0,0 +1,105 @@
+// Command admin runs operational tasks directly against the database and
+// services, for operators who need to act without the HTTP API:
+//
+//	admin create-admin --username ops --email ops@example.com < password.txt
+//	admin reset-password --email john@example.com --password newsecret
+//	admin anonymize-users -i forget.txt
+//	admin reindex-search
+//	admin rebuild-cache
+//	admin clear-cache
+//	admin run-job weekly-digest
+//	admin export -o products.csv
+//	admin import -i products.csv
+//	admin export-storefront
+//	admin reencrypt
+//	admin check-integrity --repair
+//	admin warehouse-schema
+//	admin warehouse-backfill --from 2025-01-01
+//
+// Configuration is read from the environment like the server's.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"product-management/config"
+	"product-management/pkg/database"
+	"product-management/pkg/fieldcrypt"
+	"product-management/pkg/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// cfg is the configuration commands run with, loaded before any of them runs
+var cfg *config.Config
+
+func main() {
+	cmd, err := newRootCommand().ExecuteC()
+	if database.DB != nil {
+		database.Close()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd.Name(), err)
+		os.Exit(1)
+	}
+}
+
+// newRootCommand creates the admin command with every subcommand
+func newRootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "admin",
+		Short: "Run operational tasks against the database and services",
+		Long: `admin runs operational tasks directly against the database and services,
+for operators who need to act without the HTTP API. Configuration is read from
+the environment like the server's.`,
+		PersistentPreRunE: setup,
+		SilenceErrors:     true,
+	}
+	// Completion would connect to the database like any other command
+	root.CompletionOptions.DisableDefaultCmd = true
+	root.AddCommand(
+		newCreateAdminCommand(),
+		newResetPasswordCommand(),
+		newAnonymizeUsersCommand(),
+		newReindexSearchCommand(),
+		newRebuildCacheCommand(),
+		newClearCacheCommand(),
+		newRunJobCommand(),
+		newExportCommand(),
+		newImportCommand(),
+		newExportStorefrontCommand(),
+		newReencryptCommand(),
+		newCheckIntegrityCommand(),
+		newWarehouseSchemaCommand(),
+		newWarehouseBackfillCommand(),
+	)
+	return root
+}
+
+// setup loads the configuration and connects to the database and storage
+// before a command runs. Cobra only checks required flags after this, so they
+// are checked first; failures from there on aren't followed by the usage.
+func setup(cmd *cobra.Command, args []string) error {
+	if err := cmd.ValidateRequiredFlags(); err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	var err error
+	if cfg, err = config.LoadConfig(); err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	if fieldcrypt.Default, err = fieldcrypt.NewStatic(cfg.EncryptionKeys, cfg.EncryptionActiveKey); err != nil {
+		return fmt.Errorf("invalid ENCRYPTION_KEYS: %v", err)
+	}
+	if err := database.Connect(cfg); err != nil {
+		return fmt.Errorf("failed to connect to database: %v", err)
+	}
+	store, err := storage.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %v", err)
+	}
+	storage.Store = store
+	return nil
+}
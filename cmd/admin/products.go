@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"product-management/config"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/internal/services"
+	"product-management/pkg/cdn"
+	"product-management/pkg/database"
+
+	"github.com/spf13/cobra"
+)
+
+// productColumns are the CSV columns written by export and read by import
+var productColumns = []string{"id", "name", "sku", "description", "price", "stock_quantity", "status", "categories"}
+
+// exportBatchSize is how many products are loaded at a time while exporting
+const exportBatchSize = 500
+
+func newExportCommand() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export products as CSV",
+		Long: `Write every product as a CSV row with the columns ` + strings.Join(productColumns, ", ") + `,
+which import reads back. Categories are separated by semicolons.`,
+		Example: "  admin export -o products.csv",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exportProducts(output)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "file to write, stdout when omitted")
+	return cmd
+}
+
+func exportProducts(output string) error {
+	out := io.Writer(os.Stdout)
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	writer := csv.NewWriter(out)
+	if err := writer.Write(productColumns); err != nil {
+		return err
+	}
+	count := 0
+	err := repositories.NewProductRepository(database.DB).EachProduct(exportBatchSize, func(product models.Product) error {
+		categories := make([]string, len(product.Categories))
+		for i, category := range product.Categories {
+			categories[i] = category.Name
+		}
+		count++
+		return writer.Write([]string{
+			strconv.FormatUint(uint64(product.ID), 10),
+			product.Name,
+			product.SKUValue(),
+			product.Description,
+			strconv.FormatFloat(product.Price, 'f', 2, 64),
+			strconv.Itoa(product.StockQuantity),
+			string(product.Status),
+			strings.Join(categories, ";"),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d products\n", count)
+	return nil
+}
+
+// importRow is a validated product row of an import
+type importRow struct {
+	line       int
+	product    *models.Product
+	categories []models.Category
+}
+
+func newImportCommand() *cobra.Command {
+	var input string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create or update products from CSV",
+		Long: `Create the products of a CSV file in the format of export without an id, and
+update those with one. Every row is validated before anything is saved, so a
+bad file changes nothing.`,
+		Example: "  admin import -i products.csv --dry-run",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return importProducts(input, dryRun)
+		},
+	}
+	cmd.Flags().StringVarP(&input, "input", "i", "", "file to read, stdin when omitted")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "validate the rows without saving them")
+	return cmd
+}
+
+func importProducts(input string, dryRun bool) error {
+	in := io.Reader(os.Stdin)
+	if input != "" {
+		file, err := os.Open(input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	rows, err := readProductRows(in)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		fmt.Printf("%d rows are valid\n", len(rows))
+		return nil
+	}
+
+	// Imports are made by the system rather than a user
+	productService := services.NewProductService()
+	created, updated := 0, 0
+	for _, row := range rows {
+		if row.product.ID == 0 {
+			err = productService.CreateProduct(row.product, row.categories, 0)
+			created++
+		} else {
+			categoryIDs := make([]uint, len(row.categories))
+			for i, category := range row.categories {
+				categoryIDs[i] = category.ID
+			}
+			err = productService.UpdateProduct(row.product, categoryIDs, 0)
+			updated++
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w", row.line, err)
+		}
+	}
+	fmt.Printf("Created %d and updated %d products\n", created, updated)
+	return nil
+}
+
+// readProductRows reads and validates every row before anything is saved, so a
+// bad file changes nothing
+func readProductRows(in io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(in)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range productColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	categories, err := repositories.NewCategoryRepository(database.DB).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	categoriesByName := make(map[string]models.Category, len(categories))
+	for _, category := range categories {
+		categoriesByName[strings.ToLower(category.Name)] = category
+	}
+
+	productRepo := repositories.NewProductRepository(database.DB)
+	productService := services.NewProductService()
+	var rows []importRow
+	var problems []string
+	skus := make(map[string]int)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string { return strings.TrimSpace(record[columns[name]]) }
+
+		row, err := parseProductRow(field, categoriesByName)
+		if err == nil && row.product.ID != 0 {
+			var existing *models.Product
+			if existing, err = productRepo.GetByID(row.product.ID); err == nil && existing == nil {
+				err = fmt.Errorf("product %d does not exist", row.product.ID)
+			} else if err == nil {
+				// The file has no columns for markets, the location or the booking mode, so keep the current ones
+				row.product.AllowedCountries = existing.AllowedCountries
+				row.product.BlockedCountries = existing.BlockedCountries
+				row.product.Location = existing.Location
+				row.product.Bookable = existing.Bookable
+			}
+		}
+		if err == nil && row.product.SKU != nil {
+			sku := *row.product.SKU
+			if previous, ok := skus[sku]; ok {
+				err = fmt.Errorf("sku %q is also on line %d", sku, previous)
+			} else if err = productService.CheckSKU(sku, row.product.ID); err == nil {
+				skus[sku] = line
+			}
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+		row.line = line
+		rows = append(rows, *row)
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.New("invalid rows, nothing was imported:\n" + strings.Join(problems, "\n"))
+	}
+	return rows, nil
+}
+
+// parseProductRow converts the fields of a row to a product and its categories
+func parseProductRow(field func(name string) string, categoriesByName map[string]models.Category) (*importRow, error) {
+	product := &models.Product{
+		Name:        field("name"),
+		Description: field("description"),
+		SKU:         models.OptionalSKU(field("sku")),
+		Status:      models.ProductStatus(field("status")),
+	}
+	if id := field("id"); id != "" {
+		parsed, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %q", id)
+		}
+		product.ID = uint(parsed)
+	}
+	if product.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if sku := product.SKUValue(); len(sku) > 64 || strings.IndexFunc(sku, func(r rune) bool { return r < ' ' || r > '~' }) >= 0 {
+		return nil, errors.New("sku must be at most 64 printable ASCII characters")
+	}
+
+	var err error
+	if product.Price, err = strconv.ParseFloat(field("price"), 64); err != nil || product.Price <= 0 {
+		return nil, fmt.Errorf("invalid price %q", field("price"))
+	}
+	if product.StockQuantity, err = strconv.Atoi(field("stock_quantity")); err != nil || product.StockQuantity < 0 {
+		return nil, fmt.Errorf("invalid stock quantity %q", field("stock_quantity"))
+	}
+	switch product.Status {
+	case "":
+		product.Status = models.StatusActive
+	case models.StatusActive, models.StatusInactive, models.StatusDraft:
+	default:
+		return nil, fmt.Errorf("invalid status %q", product.Status)
+	}
+
+	row := &importRow{product: product}
+	seen := make(map[uint]bool)
+	for _, name := range strings.Split(field("categories"), ";") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		category, ok := categoriesByName[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("category %q does not exist", name)
+		}
+		if !seen[category.ID] {
+			seen[category.ID] = true
+			row.categories = append(row.categories, category)
+		}
+	}
+	if len(row.categories) == 0 {
+		return nil, errors.New("at least one category is required")
+	}
+	return row, nil
+}
+
+func newExportStorefrontCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export-storefront",
+		Short: "Write the active catalog to storage as static JSON for the storefront",
+		Long: `Write the active products and categories under storefront/ in storage, and
+remove the files of products no longer active.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exportStorefront(cfg)
+		},
+	}
+}
+
+func exportStorefront(cfg *config.Config) error {
+	cdn.BaseURL = cfg.CDNBaseURL
+	result, err := services.NewStorefrontExportService().Export(context.Background())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %d products and %d categories under %s, removed %d inactive products\n",
+		result.Products, result.Categories, result.Prefix, result.Removed)
+	return nil
+}
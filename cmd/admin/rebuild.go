@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"product-management/config"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"product-management/pkg/geoip"
+	"product-management/pkg/lock"
+
+	"github.com/spf13/cobra"
+)
+
+func newReindexSearchCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reindex-search",
+		Short: "Rebuild the product data search and sorting rely on, without downtime",
+		Long: `Rebuild the product rating summaries search sorts and filters by. Servers
+keep serving the current summaries until the rebuilt ones are swapped in.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reindexSearch(cfg)
+		},
+	}
+}
+
+func newRebuildCacheCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rebuild-cache",
+		Short: "Recompute the cached categories and hot products in the shared Redis cache",
+		Long: `Recompute the cached categories and hot products in place and start a new
+generation of cached product listings. Only CACHE_BACKEND=redis can be rebuilt
+from here, since a memory cache lives in each server.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return rebuildCache(cfg)
+		},
+	}
+}
+
+// reindexSearch rebuilds the product rating summaries search sorts and filters
+// by, which are the only data it reads beyond the products' own columns.
+// Servers keep serving the current summaries until the rebuilt ones are
+// swapped in.
+func reindexSearch(cfg *config.Config) error {
+	return rebuild(cfg, models.RebuildSearch)
+}
+
+// rebuildCache recomputes the cached categories and hot products in the
+// shared cache of the servers
+func rebuildCache(cfg *config.Config) error {
+	if cfg.CacheBackend != "redis" {
+		return errors.New("the memory cache lives in each server, so only CACHE_BACKEND=redis can be rebuilt from here; use POST /api/v1/admin/rebuilds on each instance instead")
+	}
+	prefix := cfg.CacheRedisPrefix
+	if cfg.SandboxMode {
+		prefix += database.SandboxSchema + ":"
+	}
+	var err error
+	if cache.Store, err = cache.New(cfg.CacheBackend, cfg.CacheMemoryEntries, cfg.CacheRedisAddr, cfg.CacheRedisPassword, cfg.CacheRedisDB, prefix); err != nil {
+		return err
+	}
+	cache.TTL = cfg.CacheTTL
+	return rebuild(cfg, models.RebuildCache)
+}
+
+// rebuild runs a rebuild of target, printing its progress, under the same
+// lock as the servers' so it never overlaps one they started
+func rebuild(cfg *config.Config, target models.RebuildTarget) error {
+	var err error
+	if lock.Default, err = lock.New(cfg.LockBackend, database.DB, cfg.LockRedisAddr, cfg.LockRedisPassword); err != nil {
+		return err
+	}
+
+	// Actor 0 marks actions taken by an operator rather than a user
+	if err := services.NewAuditService().Record(0, geoip.Location{}, models.AuditRebuildStart, map[string]interface{}{
+		"target": target,
+		"source": "admin-cli",
+	}); err != nil {
+		return fmt.Errorf("failed to record audit log: %w", err)
+	}
+
+	run, err := services.NewRebuildService(cfg.CacheWarmupTopProducts).Run(string(target), func(run *models.RebuildRun) {
+		fmt.Printf("%s: %d/%d\n", run.Target, run.Processed, run.Total)
+	})
+	if err != nil {
+		return err
+	}
+	if run.Target == models.RebuildSearch {
+		fmt.Printf("Rebuilt %d rating summaries, %d of which changed\n", run.Processed, run.Changed)
+	} else {
+		fmt.Println("Rebuilt the cache")
+	}
+	return nil
+}
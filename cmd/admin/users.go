@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/internal/services"
+	"product-management/pkg/database"
+	"product-management/pkg/geoip"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+// minPasswordLength matches the registration endpoint
+const minPasswordLength = 6
+
+// createAdminOptions are the flags of create-admin
+type createAdminOptions struct {
+	username string
+	email    string
+	fullName string
+	password string
+}
+
+func newCreateAdminCommand() *cobra.Command {
+	var opts createAdminOptions
+	cmd := &cobra.Command{
+		Use:     "create-admin",
+		Short:   "Create an admin user",
+		Long:    "Create a user with the admin role. The password is read from the first line of stdin unless --password is given.",
+		Example: "  admin create-admin --username ops --email ops@example.com < password.txt",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return createAdmin(opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.username, "username", "", "username")
+	cmd.Flags().StringVar(&opts.email, "email", "", "email address")
+	cmd.Flags().StringVar(&opts.fullName, "name", "", "full name")
+	cmd.Flags().StringVar(&opts.password, "password", "", "password, read from the first line of stdin when omitted")
+	cmd.MarkFlagRequired("username")
+	cmd.MarkFlagRequired("email")
+	return cmd
+}
+
+func createAdmin(opts createAdminOptions) error {
+	secret, err := readPassword(opts.password)
+	if err != nil {
+		return err
+	}
+
+	userRepo := repositories.NewUserRepository(database.DB)
+	if existing, err := userRepo.GetByUsername2(opts.username); err != nil {
+		return err
+	} else if existing != nil {
+		return fmt.Errorf("username %q is taken", opts.username)
+	}
+	if existing, err := userRepo.GetByEmail2(opts.email); err != nil {
+		return err
+	} else if existing != nil {
+		return fmt.Errorf("email %q is taken", opts.email)
+	}
+
+	user := &models.User{
+		Username: opts.username,
+		Email:    opts.email,
+		FullName: opts.fullName,
+		Password: secret,
+		Role:     models.RoleAdmin,
+	}
+	if err := userRepo.Create(user); err != nil {
+		return err
+	}
+	fmt.Printf("Created admin %s (id %d)\n", user.Username, user.ID)
+	return nil
+}
+
+func newResetPasswordCommand() *cobra.Command {
+	var email, password string
+	cmd := &cobra.Command{
+		Use:   "reset-password",
+		Short: "Set a user's password and sign them out everywhere",
+		Long: `Set the password of a user and revoke the tokens issued with the old one. The
+password is read from the first line of stdin unless --password is given.`,
+		Example: "  admin reset-password --email john@example.com --password newsecret",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return resetPassword(email, password)
+		},
+	}
+	cmd.Flags().StringVar(&email, "email", "", "email address of the user")
+	cmd.Flags().StringVar(&password, "password", "", "new password, read from the first line of stdin when omitted")
+	cmd.MarkFlagRequired("email")
+	return cmd
+}
+
+func resetPassword(email, password string) error {
+	secret, err := readPassword(password)
+	if err != nil {
+		return err
+	}
+
+	userRepo := repositories.NewUserRepository(database.DB)
+	user, err := userRepo.GetByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("no user with email %q", email)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Revoke the tokens issued with the old password. Servers notice within
+	// the lifetime of their cached token versions.
+	user.Password = secret
+	user.TokenVersion++
+	if err := userRepo.Update(user); err != nil {
+		return err
+	}
+	fmt.Printf("Reset the password of %s and revoked their sessions\n", user.Username)
+	return nil
+}
+
+func newAnonymizeUsersCommand() *cobra.Command {
+	var input string
+	cmd := &cobra.Command{
+		Use:   "anonymize-users [user ID...]",
+		Short: "Irreversibly erase the personal data of users, by ID",
+		Long: `Anonymize users like the anonymize endpoint, given as arguments or one ID per
+line in the --input file. Each is recorded in the audit log with actor 0.`,
+		Example: "  admin anonymize-users 12 15\n  admin anonymize-users -i forget.txt",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return anonymizeUsers(args, input)
+		},
+	}
+	cmd.Flags().StringVarP(&input, "input", "i", "", "file with one user ID per line, - for stdin")
+	return cmd
+}
+
+func anonymizeUsers(ids []string, input string) error {
+	if input != "" {
+		var r io.Reader = os.Stdin
+		if input != "-" {
+			file, err := os.Open(input)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			r = file
+		}
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				ids = append(ids, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+	if len(ids) == 0 {
+		return errors.New("expected user IDs as arguments or with --input")
+	}
+
+	// Check every ID before erasing anything
+	userIDs := make([]uint, len(ids))
+	for i, id := range ids {
+		userID, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid user ID %q", id)
+		}
+		userIDs[i] = uint(userID)
+	}
+
+	authService := services.NewAuthService()
+	auditService := services.NewAuditService()
+	failed := 0
+	for _, userID := range userIDs {
+		// Actor 0 marks actions taken by an operator rather than a user
+		if err := auditService.Record(0, geoip.Location{}, models.AuditUserAnonymize, map[string]interface{}{
+			"user_id": userID,
+			"source":  "admin-cli",
+		}); err != nil {
+			return fmt.Errorf("failed to record audit log: %w", err)
+		}
+		user, err := authService.AnonymizeUser(userID)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			fmt.Fprintf(os.Stderr, "user %d: not found\n", userID)
+			failed++
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "user %d: %v\n", userID, err)
+			failed++
+		default:
+			fmt.Printf("Anonymized user %d as %s\n", user.ID, user.Username)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d users were not anonymized", failed, len(userIDs))
+	}
+	return nil
+}
+
+// readPassword returns the password given as a flag, or else the first line of stdin
+func readPassword(flagValue string) (string, error) {
+	password := flagValue
+	if password == "" {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", errors.New("no password given with --password or on stdin")
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+	if len(password) < minPasswordLength {
+		return "", fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+	return password, nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"product-management/config"
+	"product-management/internal/services"
+	"product-management/pkg/warehouse"
+
+	"github.com/spf13/cobra"
+)
+
+// openWarehouse returns the exporter of the configured warehouse, which events
+// are queued for while it is set
+func openWarehouse(cfg *config.Config) (*services.WarehouseService, error) {
+	if cfg.WarehouseDriver == "" {
+		return nil, errors.New("WAREHOUSE_DRIVER is not set")
+	}
+	store, err := warehouse.Open(cfg.WarehouseDriver, cfg.WarehouseURL, cfg.WarehouseDatabase,
+		cfg.WarehouseUsername, cfg.WarehousePassword, cfg.WarehouseTimeout)
+	if err != nil {
+		return nil, err
+	}
+	warehouse.Default = store
+	return services.NewWarehouseService(store, cfg.WarehouseBatchSize, cfg.WarehouseExportInterval), nil
+}
+
+func newWarehouseSchemaCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "warehouse-schema",
+		Short: "Create or upgrade the warehouse tables",
+		Long:  "Create the missing tables and columns of the warehouse set by WAREHOUSE_DRIVER.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return migrateWarehouse(cfg)
+		},
+	}
+}
+
+func migrateWarehouse(cfg *config.Config) error {
+	exporter, err := openWarehouse(cfg)
+	if err != nil {
+		return err
+	}
+	if err := exporter.Migrate(); err != nil {
+		return err
+	}
+	fmt.Printf("Migrated %d warehouse tables\n", len(warehouse.Tables))
+	return nil
+}
+
+func newWarehouseBackfillCommand() *cobra.Command {
+	var from, to string
+	cmd := &cobra.Command{
+		Use:   "warehouse-backfill",
+		Short: "Export the orders accepted in a date range to the warehouse",
+		Long: `Queue the orders accepted from --from to --to, UTC days included, for the
+warehouse. Orders exported before are merged rather than counted twice.`,
+		Example: "  admin warehouse-backfill --from 2025-01-01 --to 2025-01-31",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return backfillWarehouse(cfg, from, to)
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "", "first day of the orders to export, YYYY-MM-DD")
+	cmd.Flags().StringVar(&to, "to", "", "last day of the orders to export, YYYY-MM-DD, defaults to today")
+	cmd.MarkFlagRequired("from")
+	return cmd
+}
+
+func backfillWarehouse(cfg *config.Config, from, to string) error {
+	start, err := time.Parse(time.DateOnly, from)
+	if err != nil {
+		return fmt.Errorf("invalid --from: %v", err)
+	}
+	end := time.Now().UTC()
+	if to != "" {
+		if end, err = time.Parse(time.DateOnly, to); err != nil {
+			return fmt.Errorf("invalid --to: %v", err)
+		}
+	}
+	end = time.Date(end.Year(), end.Month(), end.Day()+1, 0, 0, 0, 0, time.UTC)
+	if !start.Before(end) {
+		return errors.New("--from must not be after --to")
+	}
+
+	exporter, err := openWarehouse(cfg)
+	if err != nil {
+		return err
+	}
+	if err := exporter.Migrate(); err != nil {
+		return err
+	}
+	queued, err := exporter.Backfill(start, end)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Queued %d orders for the warehouse\n", queued)
+	return nil
+}
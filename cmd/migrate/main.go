@@ -1,17 +1,67 @@
+// Command migrate applies the versioned SQL migrations in migrations/:
+//
+//	migrate up        apply every pending migration (the default)
+//	migrate down [N]  roll back the last N migrations, 1 by default
+//	migrate status    list the migrations and which are applied
+//	migrate force V   mark the database clean at version V, 0 for none, after
+//	                  repairing a failed migration by hand
+//
+// Configuration is read from the environment like the server's.
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
 	"product-management/config"
-	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/lock"
 	"strconv"
 
+	"github.com/golang-migrate/migrate/v4"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 func main() {
+	command := "up"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+	steps := 1
+	version := 0
+	switch command {
+	case "up", "status":
+		if len(os.Args) > 2 {
+			usage()
+		}
+	case "down":
+		if len(os.Args) > 3 {
+			usage()
+		}
+		if len(os.Args) == 3 {
+			n, err := strconv.Atoi(os.Args[2])
+			if err != nil || n < 1 {
+				usage()
+			}
+			steps = n
+		}
+	case "force":
+		if len(os.Args) != 3 {
+			usage()
+		}
+		v, err := strconv.Atoi(os.Args[2])
+		if err != nil || v < 0 {
+			usage()
+		}
+		version = v
+	default:
+		usage()
+	}
+
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -27,18 +77,86 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
-	// Auto migrate models
-	err = db.AutoMigrate(
-		&models.User{},
-		&models.Product{},
-		&models.Category{},
-		&models.Review{},
-		&models.Wishlist{},
-		&models.ProductCategory{},
-	)
+	ctx := context.Background()
+
+	if command == "status" {
+		migrator, err := database.NewMigrator(dsn)
+		if err != nil {
+			log.Fatalf("Failed to load migrations: %v", err)
+		}
+		defer migrator.Close()
+		list, err := database.Migrations()
+		if err != nil {
+			log.Fatalf("Failed to load migrations: %v", err)
+		}
+		current, dirty, err := migrator.Version()
+		if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		for _, migration := range list {
+			state := "pending"
+			if migration.Version <= current {
+				state = "applied"
+			}
+			fmt.Printf("%06d_%-40s %s\n", migration.Version, migration.Name, state)
+		}
+		fmt.Printf("Database version: %d", current)
+		if dirty {
+			fmt.Print(" (dirty)")
+		}
+		fmt.Println()
+		return
+	}
+
+	// Wait for servers migrating the same database
+	migrationLock, err := lock.Acquire(ctx, lock.NewPostgres(db), database.MigrationLock)
 	if err != nil {
-		log.Fatalf("Failed to auto migrate: %v", err)
+		log.Fatalf("Failed to lock migrations: %v", err)
+	}
+	defer migrationLock.Release()
+
+	switch command {
+	case "down":
+		rolledBack, err := database.RollbackSchema(dsn, steps)
+		if err != nil {
+			log.Fatalf("Failed to roll back: %v", err)
+		}
+		log.Printf("Rolled back %d migrations", rolledBack)
+		return
+	case "force":
+		migrator, err := database.NewMigrator(dsn)
+		if err != nil {
+			log.Fatalf("Failed to load migrations: %v", err)
+		}
+		defer migrator.Close()
+		// golang-migrate records no migration applied as version -1
+		forced := version
+		if forced == 0 {
+			forced = -1
+		}
+		if err := migrator.Force(forced); err != nil {
+			log.Fatalf("Failed to force version: %v", err)
+		}
+		log.Printf("Database marked clean at version %d", version)
+		return
 	}
 
-	log.Println("Auto migration completed successfully")
+	if err := database.MigrateSchema(dsn); err != nil {
+		log.Fatal(err)
+	}
+	if err := database.MigrateReporting(db); err != nil {
+		log.Fatalf("Failed to migrate reporting views: %v", err)
+	}
+
+	// Backfill cached product rating summaries
+	if err := repositories.NewReviewRepository(db).RecalculateAllRatings(); err != nil {
+		log.Fatalf("Failed to recalculate product ratings: %v", err)
+	}
+
+	log.Println("Migrations completed successfully")
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: migrate [up | down [N] | status | force V]")
+	os.Exit(2)
 }
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"product-management/docs"
+	"product-management/pkg/openapi"
+)
+
+// openapi converts the generated Swagger 2.0 docs into an OpenAPI 3.0 document.
+// The output is embedded into the server binary and used for client SDK generation.
+func main() {
+	output := flag.String("o", "docs/openapi.json", "output file for the OpenAPI 3 document")
+	flag.Parse()
+
+	spec, err := openapi.Convert([]byte(docs.SwaggerInfo.ReadDoc()))
+	if err != nil {
+		log.Fatalf("Failed to convert swagger docs: %v", err)
+	}
+
+	if err := os.WriteFile(*output, spec, 0644); err != nil {
+		log.Fatalf("Failed to write %s: %v", *output, err)
+	}
+
+	log.Printf("OpenAPI 3 document written to %s", *output)
+}
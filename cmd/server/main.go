@@ -1,19 +1,49 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
 	"product-management/config"
 	"product-management/docs"
 	"product-management/internal/middleware"
+	"product-management/internal/repositories"
 	"product-management/internal/routes"
+	"product-management/internal/services"
+	"product-management/pkg/botguard"
+	"product-management/pkg/cache"
+	"product-management/pkg/cdn"
 	"product-management/pkg/database"
+	"product-management/pkg/events"
+	"product-management/pkg/fieldcrypt"
+	"product-management/pkg/geoip"
+	"product-management/pkg/lock"
+	"product-management/pkg/logger"
+	"product-management/pkg/mailer"
+	"product-management/pkg/mtls"
+	"product-management/pkg/notifier"
+	"product-management/pkg/reqctx"
+	"product-management/pkg/reqstats"
+	"product-management/pkg/scheduler"
 	"product-management/pkg/seeder"
+	"product-management/pkg/storage"
+	"product-management/pkg/utils"
+	"product-management/pkg/warehouse"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+// seedLockTimeout bounds the wait for another instance seeding initial data
+const seedLockTimeout = time.Minute
+
 // @title           Product Management API
 // @version         1.0
 // @description     A RESTful API for managing products in an online store.
@@ -34,30 +64,281 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 func main() {
+	selfTest := flag.Bool("selftest", false, "check the database, cache, storage, mail, search and lock backends, then exit")
+	flag.Parse()
+
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Put the reloadable configuration in effect
+	config.SetRuntime(cfg.Runtime)
+	logger.SetLevel(cfg.Runtime.Level())
+
+	// Apply pagination limits
+	utils.SetPaginationConfig(utils.PaginationConfig{
+		DefaultLimit:       cfg.DefaultPageSize,
+		MaxLimit:           cfg.MaxPageSize,
+		MaxLimitByEndpoint: cfg.EndpointMaxPageSizes,
+	})
+
+	// Encrypt sensitive fields at rest
+	if fieldcrypt.Default, err = fieldcrypt.NewStatic(cfg.EncryptionKeys, cfg.EncryptionActiveKey); err != nil {
+		log.Fatalf("Invalid ENCRYPTION_KEYS: %v", err)
+	}
+
 	// Initialize database connection
 	if err := database.Connect(cfg); err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer database.Close()
 
-	// Seed products initial data
-	if err := seeder.SeedProducts(database.DB); err != nil {
-		log.Printf("Warning: Failed to seed initial data: %v", err)
+	// Monitor database health and reconnect after outages
+	stopHealthMonitor := database.StartHealthMonitor(cfg.DBHealthCheckInterval)
+	defer stopHealthMonitor()
+
+	// Coordinate seeding and scheduled jobs with the other instances
+	if lock.Default, err = lock.New(cfg.LockBackend, database.DB, cfg.LockRedisAddr, cfg.LockRedisPassword); err != nil {
+		log.Fatalf("Invalid LOCK_BACKEND: %v", err)
+	}
+
+	// Configure cache
+	prefix := cfg.CacheRedisPrefix
+	if cfg.SandboxMode {
+		// Keep sandbox entries apart from production's on a shared server
+		prefix += database.SandboxSchema + ":"
+	}
+	if cache.Store, err = cache.New(cfg.CacheBackend, cfg.CacheMemoryEntries, cfg.CacheRedisAddr, cfg.CacheRedisPassword, cfg.CacheRedisDB, prefix); err != nil {
+		log.Fatalf("Invalid CACHE_BACKEND: %v", err)
+	}
+	cache.TTL = cfg.CacheTTL
+
+	// Locales, currencies and time zones requests and user profiles may choose
+	reqctx.Default = cfg.Localization
+
+	// Open object storage
+	store, err := storage.Open(cfg)
+	if err != nil {
+		log.Fatalf("Failed to open storage: %v", err)
+	}
+	storage.Store = store
+
+	// Send email from a background queue
+	sender, err := mailer.NewSender(cfg.MailDriver, cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword)
+	if err != nil {
+		log.Fatalf("Failed to configure mail: %v", err)
+	}
+	mailer.Default = mailer.New(sender, mailer.NewTemplates(cfg.MailTemplateDir), repositories.NewEmailSuppressionRepository(database.DB),
+		cfg.MailFrom, cfg.MailQueueSize, cfg.MailMaxAttempts)
+	stopMailer := mailer.Default.Start(cfg.MailWorkers)
+	defer stopMailer()
+
+	// With --selftest, check every backend and exit instead of serving
+	if *selfTest {
+		report := services.NewSelfTestService(cfg.StorageBackend, cfg.MailDriver).Run(context.Background())
+		for _, check := range report.Checks {
+			if check.OK {
+				log.Printf("✅ %s (%dms)", check.Name, check.DurationMs)
+			} else {
+				log.Printf("❌ %s: %s. Hint: %s", check.Name, check.Error, check.Hint)
+			}
+		}
+		if !report.OK {
+			log.Fatalf("Self-test failed")
+		}
+		log.Println("Self-test passed")
+		return
+	}
+
+	// Apply the storage retention rules
+	if len(cfg.StorageLifecycleRules) > 0 {
+		stopLifecycle := storage.StartLifecycle(store, cfg.StorageLifecycleRules, cfg.StorageLifecycleInterval)
+		defer stopLifecycle()
+	}
+
+	// Delete records past their retention period
+	if err := services.CheckRetentionRules(cfg.RetentionRules); err != nil {
+		log.Fatalf("Invalid RETENTION_RULES: %v", err)
+	}
+	if len(cfg.RetentionRules) > 0 {
+		stopRetention := services.NewRetentionService(cfg.RetentionRules, cfg.RetentionInterval).Start()
+		defer stopRetention()
+	}
+
+	// Sync the catalog with legacy systems
+	if err := services.CheckConnectors(cfg.Connectors); err != nil {
+		log.Fatalf("Invalid CONNECTORS: %v", err)
+	}
+	if len(cfg.Connectors) > 0 {
+		stopConnectors := services.NewConnectorService(cfg.Connectors).Start()
+		defer stopConnectors()
+	}
+
+	// Look up competitor prices through external price comparison APIs
+	if err := services.CheckMarketPriceProviders(cfg.MarketPriceProviders); err != nil {
+		log.Fatalf("Invalid MARKET_PRICE_PROVIDERS: %v", err)
+	}
+
+	// Resolve callers' countries from their IP when no proxy header tells it
+	var geoDatabase *geoip.Reader
+	if cfg.GeoIPDatabase != "" {
+		if geoDatabase, err = geoip.Open(cfg.GeoIPDatabase); err != nil {
+			log.Fatalf("Failed to open GeoIP database: %v", err)
+		}
+	}
+
+	// Serve assets through the CDN and purge it when products change
+	cdn.BaseURL = cfg.CDNBaseURL
+	purger, err := cdn.New(cfg.CDNProvider, cfg.CDNZoneID, cfg.CDNAPIToken)
+	if err != nil {
+		log.Fatalf("Failed to configure CDN: %v", err)
+	}
+	if purger != nil {
+		cdn.SubscribePurges(events.Default, purger)
+	}
+
+	// Drop cached product listings as the catalog changes
+	services.SubscribeProductListInvalidation(events.Default)
+
+	// Regenerate the static storefront bundle as the catalog changes
+	if cfg.StorefrontExport {
+		services.NewStorefrontExportService().Subscribe(events.Default)
+	}
+
+	// Deliver product events to integration webhooks
+	services.NewWebhookService().Subscribe(events.Default)
+
+	// Alert on low stock
+	if cfg.MailAlertTo != "" {
+		services.SubscribeLowStockAlerts(events.Default, cfg.LowStockThreshold, cfg.MailAlertTo)
 	}
-	// Seed users initial data
-	if err := seeder.SeedUsers(database.DB); err != nil {
+	if cfg.DigestEnabled {
+		digestService := services.NewDigestService(cfg.LowStockThreshold, cfg.AppURL)
+		stopDigests := scheduler.Start("weekly digest", cfg.DigestSchedule, digestService.SendWeeklyDigests)
+		defer stopDigests()
+	}
+	if len(cfg.LatencyBudgets) > 0 {
+		stopLatencyBudgets := services.NewLatencyBudgetService(cfg.LatencyBudgets, cfg.LatencyBudgetWindow,
+			cfg.MailAlertTo, cfg.LatencyAlertWebhookURL, cfg.LatencyAlertWebhookSecret).Start()
+		defer stopLatencyBudgets()
+	}
+
+	// Deliver notifications over SMS and push when their providers are configured
+	if cfg.TwilioAccountSID != "" {
+		notifier.SMS = notifier.NewTwilio(cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.TwilioFromNumber)
+	}
+	if cfg.FCMProjectID != "" {
+		push, err := notifier.NewFCM(cfg.FCMProjectID, cfg.FCMCredentialsFile)
+		if err != nil {
+			log.Fatalf("Failed to configure push notifications: %v", err)
+		}
+		notifier.Push = push
+	}
+
+	// Screen public forms for bots
+	captcha, err := botguard.NewCaptcha(cfg.CaptchaProvider, cfg.CaptchaSecret)
+	if err != nil {
+		log.Fatalf("Failed to configure CAPTCHA: %v", err)
+	}
+	botguard.Default = &botguard.Guard{
+		Routes:         cfg.BotChecks,
+		HoneypotField:  cfg.BotHoneypotField,
+		Tokens:         botguard.NewFormTokens(cfg.BotFormTokenKey, cfg.BotMinFormTime, cfg.BotMaxFormAge),
+		Captcha:        captcha,
+		CaptchaSiteKey: cfg.CaptchaSiteKey,
+	}
+
+	// Alert admins of suspicious activity and block its source when enabled
+	services.NewSecurityService(services.SecurityPolicy{
+		FailedLoginAccounts: cfg.SecurityFailedLoginAccounts,
+		FailedLoginWindow:   cfg.SecurityFailedLoginWindow,
+		BulkDeleteThreshold: cfg.SecurityBulkDeleteThreshold,
+		BulkDeleteWindow:    cfg.SecurityBulkDeleteWindow,
+		AutoBlock:           cfg.SecurityAutoBlock,
+		BlockDuration:       cfg.SecurityBlockDuration,
+	}, services.NewNotificationService()).Subscribe(events.Default)
+
+	// Reload the runtime configuration on SIGHUP, applying it to the
+	// subsystems that keep their own copy
+	configService := services.NewConfigService(events.Default)
+	configService.Subscribe(events.Default)
+	stopReloads := reloadOnSignal(configService)
+	defer stopReloads()
+
+	// Publish the events recorded in the outbox, now that every subscriber is in place
+	stopOutbox := services.NewOutboxService(events.Default, cfg.OutboxPollInterval, cfg.OutboxMaxAttempts).Start()
+	defer stopOutbox()
+
+	// Run the sagas placing orders, resuming those another instance left midway
+	stopSagas := services.NewSagaService(cfg.SagaPollInterval, cfg.SagaMaxAttempts).Start()
+	defer stopSagas()
+
+	// Start and end promotions on schedule
+	stopPromotions := services.NewPromotionService(cfg.PromotionPollInterval).Start()
+	defer stopPromotions()
+
+	// Export analytics events to the warehouse in batches
+	if cfg.WarehouseDriver != "" {
+		store, err := warehouse.Open(cfg.WarehouseDriver, cfg.WarehouseURL, cfg.WarehouseDatabase,
+			cfg.WarehouseUsername, cfg.WarehousePassword, cfg.WarehouseTimeout)
+		if err != nil {
+			log.Fatalf("Failed to configure the warehouse: %v", err)
+		}
+		warehouse.Default = store
+		warehouseService := services.NewWarehouseService(store, cfg.WarehouseBatchSize, cfg.WarehouseExportInterval)
+		if err := warehouseService.Migrate(); err != nil {
+			log.Printf("Warning: failed to migrate the warehouse schema: %v", err)
+		}
+		stopWarehouse := warehouseService.Start()
+		defer stopWarehouse()
+	}
+
+	// Correct category product counts that drifted from their links
+	stopCategoryCounts := services.NewCategoryCountService(cfg.CategoryCountReconcileInterval).Start()
+	defer stopCategoryCounts()
+
+	// Keep the reporting views heavy analytics read up to date
+	if cfg.ReportingMode == "materialized" {
+		stopReporting := services.NewReportingService(cfg.ReportingRefreshInterval).Start()
+		defer stopReporting()
+	}
+
+	// Seed initial data, one instance at a time so replicas starting together
+	// find each other's data instead of seeding it twice
+	seedCtx, cancelSeed := context.WithTimeout(context.Background(), seedLockTimeout)
+	if seedLock, err := lock.Acquire(seedCtx, lock.Default, "seed"); err != nil {
 		log.Printf("Warning: Failed to seed initial data: %v", err)
+	} else {
+		// Seed products initial data
+		if err := seeder.SeedProducts(database.DB); err != nil {
+			log.Printf("Warning: Failed to seed initial data: %v", err)
+		}
+		// Seed users initial data
+		if err := seeder.SeedUsers(database.DB); err != nil {
+			log.Printf("Warning: Failed to seed initial data: %v", err)
+		}
+		seedLock.Release()
+	}
+	cancelSeed()
+
+	// Periodically reset sandbox data
+	if cfg.SandboxMode {
+		log.Printf("Sandbox mode enabled, data resets every %v", cfg.SandboxResetInterval)
+		stopSandboxReset := seeder.StartSandboxReset(database.DB, cfg.SandboxResetInterval)
+		defer stopSandboxReset()
 	}
 
 	// Create Gin router
 	router := gin.Default()
 
+	// Only trust forwarding headers set by known proxies, so clients cannot pick
+	// the IP that blocks, rate limits and logs see
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("Failed to set trusted proxies: %v", err)
+	}
+
 	// Swagger documentation
 	docs.SwaggerInfo.Title = "Product Management API"
 	docs.SwaggerInfo.Description = "A RESTful API for managing products in an online store"
@@ -69,20 +350,116 @@ func main() {
 	// Swagger endpoint
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// OpenAPI 3.0 document
+	router.GET("/openapi.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", docs.OpenAPIJSON)
+	})
+
+	// Record request statistics for the activity dashboard
+	var requestStats *reqstats.Recorder
+	if cfg.ActivityBucket > 0 {
+		requestStats = reqstats.New(cfg.ActivityBucket)
+		stopRequestStats := services.NewActivityService(cfg.ActivityBucket).Start(requestStats)
+		defer stopRequestStats()
+	}
+
 	// Add middleware
-	router.Use(gin.Recovery())
-	router.Use(middleware.AutoLogger())
-	router.Use(middleware.ErrorHandlerMiddleware())
+	useMiddleware(router, cfg, geoDatabase, requestStats)
+	binding.EnableDecoderDisallowUnknownFields = cfg.StrictJSON
 	// temporary comment auth middleware
 	// router.Use(middleware.AuthMiddleware())
 
+	// Serve the admin routes on a separate listener requiring client certificates
+	var internalRouter *gin.Engine
+	if cfg.InternalAddr != "" {
+		internalRouter = gin.New()
+		if err := internalRouter.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+			log.Fatalf("Failed to set trusted proxies: %v", err)
+		}
+		useMiddleware(internalRouter, cfg, geoDatabase, requestStats)
+	}
+
 	// Setup all routes
-	routes.SetupRoutes(database.DB, router)
+	routes.SetupRoutes(cfg, database.DB, router, internalRouter)
+
+	// Warm the cache before accepting traffic
+	if cfg.CacheWarmup {
+		start := time.Now()
+		if err := services.WarmCache(cfg.CacheWarmupTopProducts); err != nil {
+			log.Printf("Warning: Failed to warm cache: %v", err)
+		} else {
+			log.Printf("Cache warmed in %v", time.Since(start))
+		}
+	}
 
-	// Start server
+	// Start servers
+	if internalRouter != nil {
+		tlsConfig, err := mtls.ServerConfig(cfg.InternalClientCA)
+		if err != nil {
+			log.Fatalf("Failed to configure internal listener: %v", err)
+		}
+		internalServer := &http.Server{Addr: cfg.InternalAddr, Handler: internalRouter, TLSConfig: tlsConfig}
+		go func() {
+			log.Printf("Internal server starting on %s with mutual TLS...", cfg.InternalAddr)
+			if err := internalServer.ListenAndServeTLS(cfg.InternalTLSCert, cfg.InternalTLSKey); err != nil {
+				log.Fatalf("Failed to start internal server: %v", err)
+			}
+		}()
+	}
 	log.Printf("Server starting on port 8080...")
 	log.Printf("Swagger documentation available at http://localhost:8080/swagger/index.html")
+	log.Printf("OpenAPI 3 document available at http://localhost:8080/openapi.json")
 	if err := router.Run(":8080"); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// useMiddleware adds the middleware every request passes through
+func useMiddleware(router *gin.Engine, cfg *config.Config, geoDatabase *geoip.Reader, requestStats *reqstats.Recorder) {
+	if requestStats != nil {
+		// Runs first so rejected and panicking requests are counted too
+		router.Use(middleware.RequestStats(requestStats))
+	}
+	router.Use(gin.Recovery())
+	if cfg.SecurityAutoBlock {
+		router.Use(middleware.IPBlock())
+	}
+	// Region runs before the logger so request logs carry the location
+	router.Use(middleware.Region(cfg.RegionHeader, geoDatabase))
+	router.Use(middleware.RequestContext(cfg.Localization))
+	router.Use(middleware.AutoLogger(middleware.BodyLogging{
+		MaxBytes:     cfg.LogBodyMaxBytes,
+		RedactFields: cfg.LogRedactFields,
+		SkipRoutes:   cfg.LogBodySkipRoutes,
+	}))
+	router.Use(middleware.ErrorHandlerMiddleware(cfg.ProblemJSON))
+	router.Use(middleware.Maintenance(func() (bool, string) {
+		runtime := config.CurrentRuntime()
+		return runtime.MaintenanceMode, runtime.MaintenanceMessage
+	}))
+	router.Use(middleware.Sandbox(cfg.SandboxMode, cfg.SandboxURL))
+	router.Use(middleware.StrictJSON(cfg.StrictJSON, routes.ReviewImportPath, routes.ProductImageUploadPath))
+}
+
+// reloadOnSignal reloads the runtime configuration whenever the process
+// receives SIGHUP, and returns a function that stops listening
+func reloadOnSignal(configService *services.ConfigService) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	stop := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-signals:
+				// Failures are logged by the service, keeping the configuration in effect
+				configService.Reload("SIGHUP")
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(stop)
+	}
+}
@@ -1,10 +1,33 @@
 package config
 
 import (
+	"fmt"
+	"net"
 	"os"
+	"product-management/pkg/botguard"
+	"product-management/pkg/connectors"
+	"product-management/pkg/marketprices"
+	"product-management/pkg/ratelimit"
+	"product-management/pkg/reqctx"
+	"product-management/pkg/resilience"
+	"product-management/pkg/scheduler"
+	"product-management/pkg/webhook"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// defaultRateLimitTiers are the requests per window of each tier unless overridden
+var defaultRateLimitTiers = map[string]int{
+	ratelimit.TierAnonymous: 60,
+	ratelimit.TierUser:      300,
+	ratelimit.TierVendor:    600,
+	ratelimit.TierAdmin:     1200,
+}
+
+// botGuardRoutes are the routes bot checks can be configured for
+var botGuardRoutes = []string{"register", "login", "review"}
+
 // Config holds all configuration for the application
 type Config struct {
 	DBHost           string
@@ -14,6 +37,209 @@ type Config struct {
 	DBName           string
 	JWTSecret        string
 	JWTRefreshSecret string
+
+	// DBHealthCheckInterval is how often the database connection is pinged
+	DBHealthCheckInterval time.Duration
+	// DBSlowQueryThreshold is how long a query takes before it is logged and
+	// sampled in latency budget alerts; 0 disables both
+	DBSlowQueryThreshold time.Duration
+
+	// Locks coordinating instances on migrations, seeding and scheduled jobs
+	LockBackend       string // postgres, redis or local (a single instance)
+	LockRedisAddr     string // e.g. localhost:6379, for the redis backend
+	LockRedisPassword string
+
+	// Internal listener serving the admin routes over mutual TLS
+	InternalAddr        string   // e.g. ":8443"; empty serves admin routes on the public listener
+	InternalTLSCert     string   // Server certificate of the internal listener, PEM
+	InternalTLSKey      string   // Its private key, PEM
+	InternalClientCA    string   // CAs client certificates must be signed by, PEM
+	InternalClientNames []string // Certificate names allowed in; empty allows any certificate the CAs signed
+
+	// Encryption of sensitive fields at rest
+	EncryptionKeys      map[string]string // Base64 AES-256 keys by ID; none stores fields as plaintext
+	EncryptionActiveKey string            // ID of the key new values are encrypted with
+
+	// Cache settings
+	CacheBackend           string // memory (per instance) or redis (shared)
+	CacheMemoryEntries     int    // Most entries the memory backend holds before evicting the least recently used
+	CacheRedisAddr         string // e.g. localhost:6379, for the redis backend
+	CacheRedisPassword     string
+	CacheRedisDB           int
+	CacheRedisPrefix       string // Prepended to every key
+	CacheTTL               time.Duration
+	CacheWarmup            bool // Preload hot data into the cache before serving traffic
+	CacheWarmupTopProducts int
+
+	// Sandbox settings for integration partners
+	SandboxMode          bool          // Serve from the isolated sandbox schema
+	SandboxResetInterval time.Duration // How often sandbox data is wiped and reseeded
+	SandboxURL           string        // Public URL of the sandbox deployment
+
+	// Object storage
+	StorageBackend           string                   // "local", "s3" or "gcs"
+	StorageLocalDir          string                   // Root directory of the local backend
+	StoragePublicURL         string                   // Base URL of local signed URLs
+	StorageSigningKey        string                   // HMAC key of local signed URLs
+	StorageBucket            string                   // Bucket of the s3 and gcs backends
+	StorageRegion            string                   // Region of the s3 backend
+	StorageEndpoint          string                   // Custom endpoint of an S3-compatible service
+	StorageAccessKey         string                   // Access key ID, or GCS HMAC key ID
+	StorageSecretKey         string                   // Secret key, or GCS HMAC secret
+	StorageLifecycleRules    map[string]time.Duration // Maximum object age per key prefix
+	StorageLifecycleInterval time.Duration            // How often lifecycle rules are applied
+
+	// CDN
+	CDNProvider string // "none", "cloudflare" or "fastly"
+	CDNBaseURL  string // Public URL stored assets are served from
+	CDNAPIToken string // API token used to purge cache tags
+	CDNZoneID   string // Cloudflare zone ID or Fastly service ID
+
+	// StorefrontFeaturedProducts is how many products the storefront bootstrap features
+	StorefrontFeaturedProducts int
+
+	// StorefrontExport keeps the static storefront bundle in storage up to date
+	// as the catalog changes
+	StorefrontExport bool
+
+	// Email
+	MailDriver        string // "log" or "smtp"
+	MailFrom          string
+	MailTemplateDir   string // Directory of per-tenant template overrides
+	MailWorkers       int
+	MailQueueSize     int
+	MailMaxAttempts   int
+	MailAlertTo       string // Recipient of operational alerts such as low stock
+	SMTPHost          string
+	SMTPPort          int
+	SMTPUsername      string
+	SMTPPassword      string
+	LowStockThreshold int
+	DigestEnabled     bool
+	DigestSchedule    scheduler.Weekly // When weekly digests and reports are sent, in UTC
+	AppURL            string           // Public URL of the API, used in email links
+
+	// TrustedProxies are the proxy IPs or CIDRs whose X-Forwarded-For and
+	// X-Real-IP headers are believed; empty trusts none and uses the peer address
+	TrustedProxies []string
+
+	// Region resolution
+	RegionHeader  string // Header with the caller's country set by a trusted proxy or CDN, e.g. CF-IPCountry
+	GeoIPDatabase string // MaxMind DB file countries are looked up in when the header is absent
+
+	// Locale, currency and time zone of requests
+	Localization *reqctx.Resolver
+
+	// Data retention
+	RetentionRules    map[string]time.Duration // Maximum age of records per entity, e.g. audit_logs
+	RetentionInterval time.Duration            // How often retention rules are enforced
+
+	// ActivityBucket is the period request statistics are aggregated over before
+	// they are stored for the activity dashboard; 0 stops recording them
+	ActivityBucket time.Duration
+
+	// Request and response bodies in request logs
+	LogBodyMaxBytes   int      // Bodies are cut to this many bytes; 0 logs none
+	LogRedactFields   []string // JSON and form fields masked besides the built-in credentials
+	LogBodySkipRoutes []string // Routes whose bodies are never logged, e.g. POST /api/v1/auth/login
+
+	// Latency budgets, checked against the stored request statistics
+	LatencyBudgets            map[string]time.Duration // Maximum p95 latency per route group, e.g. products
+	LatencyBudgetWindow       time.Duration            // Period the p95 is computed over and checked every
+	LatencyAlertWebhookURL    string                   // Receives budget alerts besides MAIL_ALERT_TO
+	LatencyAlertWebhookSecret string                   // Signs budget alert webhooks
+
+	// Suspicious activity detection
+	SecurityFailedLoginAccounts int           // Accounts failing to sign in from one IP within the window that raise an alert
+	SecurityFailedLoginWindow   time.Duration // Window failed logins are counted over
+	SecurityBulkDeleteThreshold int           // Products one user deletes within the window that raise an alert
+	SecurityBulkDeleteWindow    time.Duration // Window product deletions are counted over
+	SecurityAutoBlock           bool          // Block the IP of failed logins and bulk deletions once alerted
+	SecurityBlockDuration       time.Duration // How long an automatic block lasts
+
+	// Bot mitigation on public forms
+	BotChecks        map[string][]string // Checks per route: honeypot, timing and captcha
+	BotHoneypotField string              // Body field people never see and bots fill in
+	BotMinFormTime   time.Duration       // Forms submitted sooner after their token was issued are rejected
+	BotMaxFormAge    time.Duration       // Form tokens older than this are rejected
+	BotFormTokenKey  string              // HMAC key of form tokens
+	CaptchaProvider  string              // "none", "hcaptcha" or "turnstile"
+	CaptchaSecret    string              // Secret key verifying CAPTCHA responses
+	CaptchaSiteKey   string              // Site key the CAPTCHA widget is rendered with, returned to clients
+
+	// Referral program
+	ReferralRewardAmount float64 // Store credit a referrer earns per referred first order; 0 disables rewards
+
+	// SMS and push notifications
+	TwilioAccountSID   string
+	TwilioAuthToken    string
+	TwilioFromNumber   string
+	FCMProjectID       string
+	FCMCredentialsFile string // Service account JSON key file
+
+	// Rate limiting
+	RateLimitWindow time.Duration
+
+	// Runtime is the configuration loaded at startup that can be reloaded
+	// later: log level, rate limits, feature flags and maintenance mode
+	Runtime *Runtime
+
+	// Backpressure settings
+	MaxInFlightRequests int           // Requests processed at once, 0 disables the limit
+	RequestQueueDepth   int           // Requests waiting for a slot before new ones are shed
+	RequestQueueTimeout time.Duration // How long a request waits for a slot
+
+	// StrictJSON rejects non-JSON request bodies and unknown JSON fields
+	StrictJSON bool
+
+	// ProblemJSON sends every error as application/problem+json, not only to
+	// clients that ask for it
+	ProblemJSON bool
+
+	// AdminUI serves the embedded admin console under /admin-ui
+	AdminUI bool
+
+	// Connectors syncing the catalog with legacy systems
+	Connectors []connectors.Config
+
+	// Competitor prices from external price comparison APIs
+	MarketPriceProviders []marketprices.Config
+	MarketPriceSKUs      []string      // SKUs whose market prices are looked up; empty allows every SKU
+	MarketPriceCacheTTL  time.Duration // How long fetched prices are reused
+	MarketPriceTimeout   time.Duration // How long a lookup waits for the providers, rate limits included
+
+	// Transactional outbox relay
+	OutboxPollInterval time.Duration // How often the relay looks for events committed by other instances or due for a retry
+	OutboxMaxAttempts  int           // Attempts to publish an event before it is marked failed
+
+	// Saga runner
+	SagaPollInterval time.Duration // How often the runner looks for sagas started by other instances or due for a retry
+	SagaMaxAttempts  int           // Attempts at a step before the saga compensates, or at a compensation before it is marked failed
+
+	// Promotion scheduler
+	PromotionPollInterval time.Duration // How often the scheduler starts and ends promotions that are due
+
+	// Reporting
+	ReportingMode            string        // "live" computes analytics from the tables, "materialized" reads the reporting views
+	ReportingRefreshInterval time.Duration // How often the reporting views are refreshed in materialized mode
+
+	// Category product counts
+	CategoryCountReconcileInterval time.Duration // How often stored counts are checked against the links
+
+	// Warehouse export
+	WarehouseDriver         string // "log" or "clickhouse", empty to export nothing
+	WarehouseURL            string // HTTP endpoint of the ClickHouse server
+	WarehouseDatabase       string // Database the warehouse tables are created in
+	WarehouseUsername       string
+	WarehousePassword       string
+	WarehouseBatchSize      int           // Events loaded per insert
+	WarehouseExportInterval time.Duration // How often queued events are exported
+	WarehouseTimeout        time.Duration // Bounds a request to the warehouse
+
+	// Pagination limits
+	DefaultPageSize      int
+	MaxPageSize          int
+	EndpointMaxPageSizes map[string]int // Per-endpoint overrides of MaxPageSize
 }
 
 // LoadConfig loads configuration from environment variables
@@ -23,6 +249,398 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	dbHealthCheckInterval, err := time.ParseDuration(getEnv("DB_HEALTH_CHECK_INTERVAL", "10s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_HEALTH_CHECK_INTERVAL: %v", err)
+	}
+
+	dbSlowQueryThreshold, err := time.ParseDuration(getEnv("DB_SLOW_QUERY_THRESHOLD", "200ms"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_SLOW_QUERY_THRESHOLD: %v", err)
+	}
+
+	cacheTTL, err := time.ParseDuration(getEnv("CACHE_TTL", "5m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CACHE_TTL: %v", err)
+	}
+	cacheMemoryEntries, err := strconv.Atoi(getEnv("CACHE_MEMORY_MAX_ENTRIES", "10000"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CACHE_MEMORY_MAX_ENTRIES: %v", err)
+	}
+	if cacheMemoryEntries <= 0 {
+		return nil, fmt.Errorf("invalid CACHE_MEMORY_MAX_ENTRIES: must be positive")
+	}
+	cacheRedisDB, err := strconv.Atoi(getEnv("CACHE_REDIS_DB", "0"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CACHE_REDIS_DB: %v", err)
+	}
+	cacheWarmup, err := strconv.ParseBool(getEnv("CACHE_WARMUP", "false"))
+	if err != nil {
+		return nil, err
+	}
+	cacheWarmupTopProducts, err := strconv.Atoi(getEnv("CACHE_WARMUP_TOP_PRODUCTS", "50"))
+	if err != nil {
+		return nil, err
+	}
+
+	sandboxMode, err := strconv.ParseBool(getEnv("SANDBOX_MODE", "false"))
+	if err != nil {
+		return nil, err
+	}
+	sandboxResetInterval, err := time.ParseDuration(getEnv("SANDBOX_RESET_INTERVAL", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SANDBOX_RESET_INTERVAL: %v", err)
+	}
+
+	storageLifecycleRules, err := parseDurationMap(getEnv("STORAGE_LIFECYCLE_RULES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid STORAGE_LIFECYCLE_RULES: %v", err)
+	}
+	storageLifecycleInterval, err := time.ParseDuration(getEnv("STORAGE_LIFECYCLE_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid STORAGE_LIFECYCLE_INTERVAL: %v", err)
+	}
+
+	var internalClientNames []string
+	for _, name := range strings.Split(getEnv("INTERNAL_CLIENT_NAMES", ""), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			internalClientNames = append(internalClientNames, name)
+		}
+	}
+
+	var trustedProxies []string
+	for _, proxy := range strings.Split(getEnv("TRUSTED_PROXIES", ""), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %q is not an IP address or CIDR", proxy)
+		}
+		trustedProxies = append(trustedProxies, proxy)
+	}
+
+	internalAddr := getEnv("INTERNAL_ADDR", "")
+	if internalAddr != "" && (getEnv("INTERNAL_TLS_CERT", "") == "" || getEnv("INTERNAL_TLS_KEY", "") == "" || getEnv("INTERNAL_CLIENT_CA", "") == "") {
+		return nil, fmt.Errorf("INTERNAL_ADDR requires INTERNAL_TLS_CERT, INTERNAL_TLS_KEY and INTERNAL_CLIENT_CA")
+	}
+
+	encryptionKeys, err := parseKeyMap(getEnv("ENCRYPTION_KEYS", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ENCRYPTION_KEYS: %v", err)
+	}
+
+	retentionRules, err := parseDurationMap(getEnv("RETENTION_RULES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RETENTION_RULES: %v", err)
+	}
+	retentionInterval, err := time.ParseDuration(getEnv("RETENTION_INTERVAL", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RETENTION_INTERVAL: %v", err)
+	}
+
+	activityBucket, err := time.ParseDuration(getEnv("ACTIVITY_BUCKET", "5m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACTIVITY_BUCKET: %v", err)
+	}
+	if activityBucket < 0 {
+		return nil, fmt.Errorf("invalid ACTIVITY_BUCKET: must not be negative")
+	}
+
+	logBodyMaxBytes, err := strconv.Atoi(getEnv("LOG_BODY_MAX_BYTES", "4096"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOG_BODY_MAX_BYTES: %v", err)
+	}
+	if logBodyMaxBytes < 0 {
+		return nil, fmt.Errorf("invalid LOG_BODY_MAX_BYTES: must not be negative")
+	}
+	var logRedactFields, logBodySkipRoutes []string
+	for _, field := range strings.Split(getEnv("LOG_REDACT_FIELDS", ""), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			logRedactFields = append(logRedactFields, field)
+		}
+	}
+	for _, route := range strings.Split(getEnv("LOG_BODY_SKIP_ROUTES", ""), ",") {
+		if route = strings.Join(strings.Fields(route), " "); route != "" {
+			logBodySkipRoutes = append(logBodySkipRoutes, route)
+		}
+	}
+
+	latencyBudgets, err := parseDurationMap(getEnv("LATENCY_BUDGETS", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LATENCY_BUDGETS: %v", err)
+	}
+	latencyBudgetWindow, err := time.ParseDuration(getEnv("LATENCY_BUDGET_WINDOW", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LATENCY_BUDGET_WINDOW: %v", err)
+	}
+	if len(latencyBudgets) > 0 && (activityBucket == 0 || latencyBudgetWindow < activityBucket) {
+		return nil, fmt.Errorf("invalid LATENCY_BUDGET_WINDOW: latency budgets need ACTIVITY_BUCKET set and no longer than the window")
+	}
+	if alertURL := getEnv("LATENCY_ALERT_WEBHOOK_URL", ""); alertURL != "" {
+		if err := webhook.CheckURL(alertURL); err != nil {
+			return nil, fmt.Errorf("invalid LATENCY_ALERT_WEBHOOK_URL: %v", err)
+		}
+	}
+
+	securityFailedLoginAccounts, err := strconv.Atoi(getEnv("SECURITY_FAILED_LOGIN_ACCOUNTS", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SECURITY_FAILED_LOGIN_ACCOUNTS: %v", err)
+	}
+	securityFailedLoginWindow, err := time.ParseDuration(getEnv("SECURITY_FAILED_LOGIN_WINDOW", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SECURITY_FAILED_LOGIN_WINDOW: %v", err)
+	}
+	securityBulkDeleteThreshold, err := strconv.Atoi(getEnv("SECURITY_BULK_DELETE_THRESHOLD", "20"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SECURITY_BULK_DELETE_THRESHOLD: %v", err)
+	}
+	securityBulkDeleteWindow, err := time.ParseDuration(getEnv("SECURITY_BULK_DELETE_WINDOW", "10m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SECURITY_BULK_DELETE_WINDOW: %v", err)
+	}
+	securityAutoBlock, err := strconv.ParseBool(getEnv("SECURITY_AUTO_BLOCK", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SECURITY_AUTO_BLOCK: %v", err)
+	}
+	securityBlockDuration, err := time.ParseDuration(getEnv("SECURITY_BLOCK_DURATION", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SECURITY_BLOCK_DURATION: %v", err)
+	}
+
+	botChecks, err := parseBotChecks(getEnv("BOT_CHECKS", "register=honeypot,login=honeypot,review=honeypot"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOT_CHECKS: %v", err)
+	}
+	captchaProvider := getEnv("CAPTCHA_PROVIDER", "none")
+	for route, checks := range botChecks {
+		for _, check := range checks {
+			if check == botguard.CheckCaptcha && captchaProvider == "none" {
+				return nil, fmt.Errorf("invalid BOT_CHECKS: %s requires a captcha but CAPTCHA_PROVIDER is none", route)
+			}
+		}
+	}
+	botMinFormTime, err := time.ParseDuration(getEnv("BOT_MIN_FORM_TIME", "3s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOT_MIN_FORM_TIME: %v", err)
+	}
+	botMaxFormAge, err := time.ParseDuration(getEnv("BOT_MAX_FORM_AGE", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOT_MAX_FORM_AGE: %v", err)
+	}
+
+	rateLimitWindow, err := time.ParseDuration(getEnv("RATE_WINDOW", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_WINDOW: %v", err)
+	}
+	runtime, err := LoadRuntime()
+	if err != nil {
+		return nil, err
+	}
+
+	maxInFlightRequests, err := strconv.Atoi(getEnv("MAX_IN_FLIGHT_REQUESTS", "100"))
+	if err != nil {
+		return nil, err
+	}
+	requestQueueDepth, err := strconv.Atoi(getEnv("REQUEST_QUEUE_DEPTH", "200"))
+	if err != nil {
+		return nil, err
+	}
+	requestQueueTimeout, err := time.ParseDuration(getEnv("REQUEST_QUEUE_TIMEOUT", "2s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REQUEST_QUEUE_TIMEOUT: %v", err)
+	}
+
+	mailWorkers, err := strconv.Atoi(getEnv("MAIL_WORKERS", "2"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAIL_WORKERS: %v", err)
+	}
+	mailQueueSize, err := strconv.Atoi(getEnv("MAIL_QUEUE_SIZE", "100"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAIL_QUEUE_SIZE: %v", err)
+	}
+	mailMaxAttempts, err := strconv.Atoi(getEnv("MAIL_MAX_ATTEMPTS", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAIL_MAX_ATTEMPTS: %v", err)
+	}
+	smtpPort, err := strconv.Atoi(getEnv("SMTP_PORT", "587"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP_PORT: %v", err)
+	}
+	lowStockThreshold, err := strconv.Atoi(getEnv("LOW_STOCK_THRESHOLD", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOW_STOCK_THRESHOLD: %v", err)
+	}
+	referralRewardAmount, err := strconv.ParseFloat(getEnv("REFERRAL_REWARD_AMOUNT", "10"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REFERRAL_REWARD_AMOUNT: %v", err)
+	}
+	if referralRewardAmount < 0 {
+		return nil, fmt.Errorf("invalid REFERRAL_REWARD_AMOUNT: must not be negative")
+	}
+	digestEnabled, err := strconv.ParseBool(getEnv("DIGEST_ENABLED", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DIGEST_ENABLED: %v", err)
+	}
+	digestSchedule, err := scheduler.ParseWeekly(getEnv("DIGEST_SCHEDULE", "monday 08:00"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DIGEST_SCHEDULE: %v", err)
+	}
+
+	strictJSON, err := strconv.ParseBool(getEnv("STRICT_JSON", "true"))
+	if err != nil {
+		return nil, err
+	}
+
+	problemJSON, err := strconv.ParseBool(getEnv("PROBLEM_JSON", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PROBLEM_JSON: %v", err)
+	}
+
+	storefrontFeaturedProducts, err := strconv.Atoi(getEnv("STOREFRONT_FEATURED_PRODUCTS", "8"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid STOREFRONT_FEATURED_PRODUCTS: %v", err)
+	}
+
+	storefrontExport, err := strconv.ParseBool(getEnv("STOREFRONT_EXPORT", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid STOREFRONT_EXPORT: %v", err)
+	}
+
+	adminUI, err := strconv.ParseBool(getEnv("ADMIN_UI", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ADMIN_UI: %v", err)
+	}
+
+	connectorConfigs, err := parseConnectors(getEnv("CONNECTORS", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	marketPriceProviders, err := parseMarketPriceProviders(getEnv("MARKET_PRICE_PROVIDERS", ""))
+	if err != nil {
+		return nil, err
+	}
+	var marketPriceSKUs []string
+	for _, sku := range strings.Split(getEnv("MARKET_PRICE_SKUS", ""), ",") {
+		if sku = strings.TrimSpace(sku); sku != "" {
+			marketPriceSKUs = append(marketPriceSKUs, sku)
+		}
+	}
+	marketPriceCacheTTL, err := time.ParseDuration(getEnv("MARKET_PRICE_CACHE_TTL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MARKET_PRICE_CACHE_TTL: %v", err)
+	}
+	marketPriceTimeout, err := time.ParseDuration(getEnv("MARKET_PRICE_TIMEOUT", "15s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MARKET_PRICE_TIMEOUT: %v", err)
+	}
+
+	outboxPollInterval, err := time.ParseDuration(getEnv("OUTBOX_POLL_INTERVAL", "1s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OUTBOX_POLL_INTERVAL: %v", err)
+	}
+	if outboxPollInterval <= 0 {
+		return nil, fmt.Errorf("invalid OUTBOX_POLL_INTERVAL: must be positive")
+	}
+	outboxMaxAttempts, err := strconv.Atoi(getEnv("OUTBOX_MAX_ATTEMPTS", "10"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OUTBOX_MAX_ATTEMPTS: %v", err)
+	}
+	if outboxMaxAttempts < 1 {
+		return nil, fmt.Errorf("invalid OUTBOX_MAX_ATTEMPTS: must be at least 1")
+	}
+
+	sagaPollInterval, err := time.ParseDuration(getEnv("SAGA_POLL_INTERVAL", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SAGA_POLL_INTERVAL: %v", err)
+	}
+	if sagaPollInterval <= 0 {
+		return nil, fmt.Errorf("invalid SAGA_POLL_INTERVAL: must be positive")
+	}
+	promotionPollInterval, err := time.ParseDuration(getEnv("PROMOTION_POLL_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PROMOTION_POLL_INTERVAL: %v", err)
+	}
+	if promotionPollInterval <= 0 {
+		return nil, fmt.Errorf("invalid PROMOTION_POLL_INTERVAL: must be positive")
+	}
+
+	reportingMode := getEnv("REPORTING_MODE", "live")
+	if reportingMode != "live" && reportingMode != "materialized" {
+		return nil, fmt.Errorf("invalid REPORTING_MODE: must be live or materialized")
+	}
+	reportingRefreshInterval, err := time.ParseDuration(getEnv("REPORTING_REFRESH_INTERVAL", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REPORTING_REFRESH_INTERVAL: %v", err)
+	}
+	if reportingRefreshInterval <= 0 {
+		return nil, fmt.Errorf("invalid REPORTING_REFRESH_INTERVAL: must be positive")
+	}
+
+	categoryCountReconcileInterval, err := time.ParseDuration(getEnv("CATEGORY_COUNT_RECONCILE_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CATEGORY_COUNT_RECONCILE_INTERVAL: %v", err)
+	}
+	if categoryCountReconcileInterval <= 0 {
+		return nil, fmt.Errorf("invalid CATEGORY_COUNT_RECONCILE_INTERVAL: must be positive")
+	}
+
+	warehouseBatchSize, err := strconv.Atoi(getEnv("WAREHOUSE_BATCH_SIZE", "500"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid WAREHOUSE_BATCH_SIZE: %v", err)
+	}
+	if warehouseBatchSize < 1 {
+		return nil, fmt.Errorf("invalid WAREHOUSE_BATCH_SIZE: must be at least 1")
+	}
+	warehouseExportInterval, err := time.ParseDuration(getEnv("WAREHOUSE_EXPORT_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid WAREHOUSE_EXPORT_INTERVAL: %v", err)
+	}
+	if warehouseExportInterval <= 0 {
+		return nil, fmt.Errorf("invalid WAREHOUSE_EXPORT_INTERVAL: must be positive")
+	}
+	warehouseTimeout, err := time.ParseDuration(getEnv("WAREHOUSE_TIMEOUT", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid WAREHOUSE_TIMEOUT: %v", err)
+	}
+
+	sagaMaxAttempts, err := strconv.Atoi(getEnv("SAGA_MAX_ATTEMPTS", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SAGA_MAX_ATTEMPTS: %v", err)
+	}
+	if sagaMaxAttempts < 1 {
+		return nil, fmt.Errorf("invalid SAGA_MAX_ATTEMPTS: must be at least 1")
+	}
+
+	var supportedLocales []string
+	for _, tag := range strings.Split(getEnv("SUPPORTED_LOCALES", ""), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			supportedLocales = append(supportedLocales, tag)
+		}
+	}
+	currencyRates, err := parseFloatMap(getEnv("CURRENCY_RATES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CURRENCY_RATES: %v", err)
+	}
+	localization, err := reqctx.NewResolver(getEnv("DEFAULT_LOCALE", "en-US"), supportedLocales,
+		getEnv("BASE_CURRENCY", "USD"), currencyRates, getEnv("DEFAULT_TIMEZONE", "UTC"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid localization settings: %v", err)
+	}
+
+	defaultPageSize, err := strconv.Atoi(getEnv("PAGINATION_DEFAULT_PAGE_SIZE", "10"))
+	if err != nil {
+		return nil, err
+	}
+	maxPageSize, err := strconv.Atoi(getEnv("PAGINATION_MAX_PAGE_SIZE", "100"))
+	if err != nil {
+		return nil, err
+	}
+	endpointMaxPageSizes, err := parseIntMap(getEnv("PAGINATION_ENDPOINT_MAX_PAGE_SIZES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PAGINATION_ENDPOINT_MAX_PAGE_SIZES: %v", err)
+	}
+
 	return &Config{
 		DBHost:           getEnv("DB_HOST", "localhost"),
 		DBPort:           dbPort,
@@ -31,6 +649,161 @@ func LoadConfig() (*Config, error) {
 		DBName:           getEnv("DB_NAME", "product_management"),
 		JWTSecret:        getEnv("JWT_SECRET", "01964c7b_9461_735b_82af_c02f626b7066"),
 		JWTRefreshSecret: getEnv("JWT_REFRESH_SECRET", "01964c7b_9461_735b_82af_c02f626b7066SASS"),
+
+		DBHealthCheckInterval: dbHealthCheckInterval,
+		DBSlowQueryThreshold:  dbSlowQueryThreshold,
+
+		LockBackend:       getEnv("LOCK_BACKEND", "postgres"),
+		LockRedisAddr:     getEnv("LOCK_REDIS_ADDR", ""),
+		LockRedisPassword: getEnv("LOCK_REDIS_PASSWORD", ""),
+
+		InternalAddr:        internalAddr,
+		InternalTLSCert:     getEnv("INTERNAL_TLS_CERT", ""),
+		InternalTLSKey:      getEnv("INTERNAL_TLS_KEY", ""),
+		InternalClientCA:    getEnv("INTERNAL_CLIENT_CA", ""),
+		InternalClientNames: internalClientNames,
+
+		EncryptionKeys:      encryptionKeys,
+		EncryptionActiveKey: getEnv("ENCRYPTION_ACTIVE_KEY", ""),
+
+		CacheBackend:           getEnv("CACHE_BACKEND", "memory"),
+		CacheMemoryEntries:     cacheMemoryEntries,
+		CacheRedisAddr:         getEnv("CACHE_REDIS_ADDR", ""),
+		CacheRedisPassword:     getEnv("CACHE_REDIS_PASSWORD", ""),
+		CacheRedisDB:           cacheRedisDB,
+		CacheRedisPrefix:       getEnv("CACHE_REDIS_PREFIX", "product-management:"),
+		CacheTTL:               cacheTTL,
+		CacheWarmup:            cacheWarmup,
+		CacheWarmupTopProducts: cacheWarmupTopProducts,
+
+		SandboxMode:          sandboxMode,
+		SandboxResetInterval: sandboxResetInterval,
+		SandboxURL:           getEnv("SANDBOX_URL", ""),
+
+		StorageBackend:           getEnv("STORAGE_BACKEND", "local"),
+		StorageLocalDir:          getEnv("STORAGE_LOCAL_DIR", "./storage"),
+		StoragePublicURL:         getEnv("STORAGE_PUBLIC_URL", "http://localhost:8080"),
+		StorageSigningKey:        getEnv("STORAGE_SIGNING_KEY", getEnv("JWT_SECRET", "01964c7b_9461_735b_82af_c02f626b7066")),
+		StorageBucket:            getEnv("STORAGE_BUCKET", ""),
+		StorageRegion:            getEnv("STORAGE_REGION", "us-east-1"),
+		StorageEndpoint:          getEnv("STORAGE_ENDPOINT", ""),
+		StorageAccessKey:         getEnv("STORAGE_ACCESS_KEY", ""),
+		StorageSecretKey:         getEnv("STORAGE_SECRET_KEY", ""),
+		StorageLifecycleRules:    storageLifecycleRules,
+		StorageLifecycleInterval: storageLifecycleInterval,
+		CDNProvider:              getEnv("CDN_PROVIDER", "none"),
+		CDNBaseURL:               getEnv("CDN_BASE_URL", ""),
+		CDNAPIToken:              getEnv("CDN_API_TOKEN", ""),
+		CDNZoneID:                getEnv("CDN_ZONE_ID", ""),
+
+		StorefrontFeaturedProducts: storefrontFeaturedProducts,
+		StorefrontExport:           storefrontExport,
+
+		MailDriver:        getEnv("MAIL_DRIVER", "log"),
+		MailFrom:          getEnv("MAIL_FROM", "no-reply@example.com"),
+		MailTemplateDir:   getEnv("MAIL_TEMPLATE_DIR", ""),
+		MailWorkers:       mailWorkers,
+		MailQueueSize:     mailQueueSize,
+		MailMaxAttempts:   mailMaxAttempts,
+		MailAlertTo:       getEnv("MAIL_ALERT_TO", ""),
+		SMTPHost:          getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:          smtpPort,
+		SMTPUsername:      getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:      getEnv("SMTP_PASSWORD", ""),
+		LowStockThreshold: lowStockThreshold,
+		DigestEnabled:     digestEnabled,
+		DigestSchedule:    digestSchedule,
+		AppURL:            getEnv("APP_URL", "http://localhost:8080"),
+
+		TrustedProxies: trustedProxies,
+		RegionHeader:   getEnv("REGION_HEADER", ""),
+		GeoIPDatabase:  getEnv("GEOIP_DATABASE", ""),
+
+		Localization: localization,
+
+		RetentionRules:    retentionRules,
+		RetentionInterval: retentionInterval,
+
+		ActivityBucket: activityBucket,
+
+		LogBodyMaxBytes:   logBodyMaxBytes,
+		LogRedactFields:   logRedactFields,
+		LogBodySkipRoutes: logBodySkipRoutes,
+
+		LatencyBudgets:            latencyBudgets,
+		LatencyBudgetWindow:       latencyBudgetWindow,
+		LatencyAlertWebhookURL:    getEnv("LATENCY_ALERT_WEBHOOK_URL", ""),
+		LatencyAlertWebhookSecret: getEnv("LATENCY_ALERT_WEBHOOK_SECRET", ""),
+
+		SecurityFailedLoginAccounts: securityFailedLoginAccounts,
+		SecurityFailedLoginWindow:   securityFailedLoginWindow,
+		SecurityBulkDeleteThreshold: securityBulkDeleteThreshold,
+		SecurityBulkDeleteWindow:    securityBulkDeleteWindow,
+		SecurityAutoBlock:           securityAutoBlock,
+		SecurityBlockDuration:       securityBlockDuration,
+
+		BotChecks:        botChecks,
+		BotHoneypotField: getEnv("BOT_HONEYPOT_FIELD", "website"),
+		BotMinFormTime:   botMinFormTime,
+		BotMaxFormAge:    botMaxFormAge,
+		BotFormTokenKey:  getEnv("BOT_FORM_TOKEN_KEY", getEnv("JWT_SECRET", "01964c7b_9461_735b_82af_c02f626b7066")),
+		CaptchaProvider:  captchaProvider,
+		CaptchaSecret:    getEnv("CAPTCHA_SECRET", ""),
+		CaptchaSiteKey:   getEnv("CAPTCHA_SITE_KEY", ""),
+
+		ReferralRewardAmount: referralRewardAmount,
+
+		TwilioAccountSID:   getEnv("TWILIO_ACCOUNT_SID", ""),
+		TwilioAuthToken:    getEnv("TWILIO_AUTH_TOKEN", ""),
+		TwilioFromNumber:   getEnv("TWILIO_FROM_NUMBER", ""),
+		FCMProjectID:       getEnv("FCM_PROJECT_ID", ""),
+		FCMCredentialsFile: getEnv("FCM_CREDENTIALS_FILE", ""),
+
+		RateLimitWindow: rateLimitWindow,
+		Runtime:         runtime,
+
+		MaxInFlightRequests: maxInFlightRequests,
+		RequestQueueDepth:   requestQueueDepth,
+		RequestQueueTimeout: requestQueueTimeout,
+
+		StrictJSON: strictJSON,
+
+		ProblemJSON: problemJSON,
+
+		AdminUI: adminUI,
+
+		Connectors: connectorConfigs,
+
+		MarketPriceProviders: marketPriceProviders,
+		MarketPriceSKUs:      marketPriceSKUs,
+		MarketPriceCacheTTL:  marketPriceCacheTTL,
+		MarketPriceTimeout:   marketPriceTimeout,
+
+		OutboxPollInterval: outboxPollInterval,
+		OutboxMaxAttempts:  outboxMaxAttempts,
+
+		SagaPollInterval: sagaPollInterval,
+		SagaMaxAttempts:  sagaMaxAttempts,
+
+		PromotionPollInterval: promotionPollInterval,
+
+		ReportingMode:            reportingMode,
+		ReportingRefreshInterval: reportingRefreshInterval,
+
+		CategoryCountReconcileInterval: categoryCountReconcileInterval,
+
+		WarehouseDriver:         getEnv("WAREHOUSE_DRIVER", ""),
+		WarehouseURL:            getEnv("WAREHOUSE_URL", ""),
+		WarehouseDatabase:       getEnv("WAREHOUSE_DATABASE", "analytics"),
+		WarehouseUsername:       getEnv("WAREHOUSE_USERNAME", ""),
+		WarehousePassword:       getEnv("WAREHOUSE_PASSWORD", ""),
+		WarehouseBatchSize:      warehouseBatchSize,
+		WarehouseExportInterval: warehouseExportInterval,
+		WarehouseTimeout:        warehouseTimeout,
+
+		DefaultPageSize:      defaultPageSize,
+		MaxPageSize:          maxPageSize,
+		EndpointMaxPageSizes: endpointMaxPageSizes,
 	}, nil
 }
 
@@ -42,3 +815,225 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// parseConnectors reads the configuration of each connector named in a
+// comma-separated list. Connector erp is configured by CONNECTOR_ERP_TYPE,
+// CONNECTOR_ERP_INTERVAL and, for its settings, every other CONNECTOR_ERP_*
+// variable, e.g. CONNECTOR_ERP_BASE_URL sets base_url.
+func parseConnectors(names string) ([]connectors.Config, error) {
+	var configs []connectors.Config
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		prefix := "CONNECTOR_" + strings.ToUpper(name) + "_"
+		interval, err := time.ParseDuration(getEnv(prefix+"INTERVAL", "1h"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %sINTERVAL: %v", prefix, err)
+		}
+		cfg := connectors.Config{
+			Name:     name,
+			Type:     getEnv(prefix+"TYPE", ""),
+			Interval: interval,
+			Settings: make(map[string]string),
+		}
+		for _, variable := range os.Environ() {
+			key, value, _ := strings.Cut(variable, "=")
+			if !strings.HasPrefix(key, prefix) || key == prefix+"TYPE" || key == prefix+"INTERVAL" {
+				continue
+			}
+			cfg.Settings[strings.ToLower(strings.TrimPrefix(key, prefix))] = value
+		}
+		configs = append(configs, cfg)
+	}
+	return configs, nil
+}
+
+// parseMarketPriceProviders reads the configuration of each market price
+// provider named in a comma-separated list. Provider compare is configured by
+// MARKET_PRICE_COMPARE_TYPE, MARKET_PRICE_COMPARE_RATE_LIMIT calls per
+// MARKET_PRICE_COMPARE_RATE_WINDOW, MARKET_PRICE_COMPARE_FAILURE_THRESHOLD
+// consecutive failures before calls stop for MARKET_PRICE_COMPARE_COOLDOWN
+// and, for its settings, every other MARKET_PRICE_COMPARE_* variable, e.g.
+// MARKET_PRICE_COMPARE_BASE_URL sets base_url.
+func parseMarketPriceProviders(names string) ([]marketprices.Config, error) {
+	var configs []marketprices.Config
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		prefix := "MARKET_PRICE_" + strings.ToUpper(name) + "_"
+		rate, err := strconv.Atoi(getEnv(prefix+"RATE_LIMIT", "60"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %sRATE_LIMIT: %v", prefix, err)
+		}
+		window, err := time.ParseDuration(getEnv(prefix+"RATE_WINDOW", "1m"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %sRATE_WINDOW: %v", prefix, err)
+		}
+		threshold, err := strconv.Atoi(getEnv(prefix+"FAILURE_THRESHOLD", "5"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %sFAILURE_THRESHOLD: %v", prefix, err)
+		}
+		cooldown, err := time.ParseDuration(getEnv(prefix+"COOLDOWN", "1m"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %sCOOLDOWN: %v", prefix, err)
+		}
+		reserved := map[string]bool{"TYPE": true, "RATE_LIMIT": true, "RATE_WINDOW": true, "FAILURE_THRESHOLD": true, "COOLDOWN": true}
+		cfg := marketprices.Config{
+			Name: name,
+			Type: getEnv(prefix+"TYPE", "rest"),
+			Limits: resilience.Policy{
+				Rate:             rate,
+				Window:           window,
+				FailureThreshold: threshold,
+				Cooldown:         cooldown,
+			},
+			Settings: make(map[string]string),
+		}
+		for _, variable := range os.Environ() {
+			key, value, _ := strings.Cut(variable, "=")
+			if !strings.HasPrefix(key, prefix) || reserved[strings.TrimPrefix(key, prefix)] {
+				continue
+			}
+			cfg.Settings[strings.ToLower(strings.TrimPrefix(key, prefix))] = value
+		}
+		configs = append(configs, cfg)
+	}
+	return configs, nil
+}
+
+// parseIntMap parses a "key=value,key=value" list into a map of integers
+func parseIntMap(value string) (map[string]int, error) {
+	result := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, raw, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected key=value, got %q", pair)
+		}
+		number, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, err
+		}
+		result[strings.TrimSpace(key)] = number
+	}
+	return result, nil
+}
+
+// parseFloatMap parses a "key=value,key=value" list of decimal numbers
+func parseFloatMap(value string) (map[string]float64, error) {
+	result := make(map[string]float64)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, raw, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected key=value, got %q", pair)
+		}
+		number, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return nil, err
+		}
+		result[strings.TrimSpace(key)] = number
+	}
+	return result, nil
+}
+
+// parseBotChecks parses a "route=check+check,route=check" list of the bot
+// checks of each route
+func parseBotChecks(value string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		route, raw, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected route=check+check, got %q", pair)
+		}
+		route = strings.TrimSpace(route)
+		known := false
+		for _, name := range botGuardRoutes {
+			known = known || name == route
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown route %q, expected one of %s", route, strings.Join(botGuardRoutes, ", "))
+		}
+		for _, check := range strings.Split(raw, "+") {
+			check = strings.TrimSpace(check)
+			if check == "" || check == "none" {
+				continue
+			}
+			if !botguard.IsCheck(check) {
+				return nil, fmt.Errorf("unknown check %q for %s", check, route)
+			}
+			result[route] = append(result[route], check)
+		}
+	}
+	return result, nil
+}
+
+// parseDurationMap parses a "key=duration,key=duration" list into a map of durations
+func parseDurationMap(value string) (map[string]time.Duration, error) {
+	result := make(map[string]time.Duration)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, raw, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected key=duration, got %q", pair)
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, err
+		}
+		result[strings.TrimSpace(key)] = duration
+	}
+	return result, nil
+}
+
+// parseKeyMap parses an "id:value,id:value" list. The separator is a colon
+// since base64 values end in "=".
+func parseKeyMap(value string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		id, key, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("expected id:key, got %q", pair)
+		}
+		result[strings.TrimSpace(id)] = strings.TrimSpace(key)
+	}
+	return result, nil
+}
+
+// parseAPIKeys parses a "name:key=limit,name:key=limit" list into API key quotas keyed by the key
+func parseAPIKeys(value string) (map[string]ratelimit.APIKey, error) {
+	limits, err := parseIntMap(value)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]ratelimit.APIKey, len(limits))
+	for entry, limit := range limits {
+		name, key, ok := strings.Cut(entry, ":")
+		if !ok || name == "" || key == "" {
+			return nil, fmt.Errorf("expected name:key=limit, got %q", entry)
+		}
+		keys[key] = ratelimit.APIKey{Name: name, Limit: limit}
+	}
+	return keys, nil
+}
@@ -0,0 +1,253 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"os"
+	"product-management/pkg/ratelimit"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Feature flags that can be switched without a restart
+const (
+	// FeatureProductChangeApproval routes product edits by non-admins through admin review
+	FeatureProductChangeApproval = "product_change_approval"
+)
+
+// featureDefaults are the known feature flags and the variables setting them
+// before FEATURE_FLAGS overrides them
+var featureDefaults = map[string]string{
+	FeatureProductChangeApproval: "PRODUCT_CHANGE_APPROVAL",
+}
+
+// defaultMaintenanceMessage is the error sent while maintenance mode is on
+// without MAINTENANCE_MESSAGE
+const defaultMaintenanceMessage = "The service is down for maintenance, please try again later"
+
+// Runtime is the configuration that can be reloaded while the server runs, on
+// SIGHUP or through the admin API, so operational tweaks need no restart
+type Runtime struct {
+	LogLevel           string
+	RateLimitPolicy    ratelimit.Policy
+	FeatureFlags       map[string]bool
+	MaintenanceMode    bool // Rejects API requests with 503, except admin and auth routes
+	MaintenanceMessage string
+	LoadedAt           time.Time
+	Source             string // CONFIG_FILE it was read from, empty for the environment only
+}
+
+// current is the runtime configuration in effect
+var current atomic.Pointer[Runtime]
+
+// reloadMu serializes reloads so each one is compared with the last
+var reloadMu sync.Mutex
+
+// CurrentRuntime returns the runtime configuration in effect
+func CurrentRuntime() *Runtime {
+	if r := current.Load(); r != nil {
+		return r
+	}
+	return &Runtime{LogLevel: logrus.InfoLevel.String(), MaintenanceMessage: defaultMaintenanceMessage}
+}
+
+// SetRuntime puts a runtime configuration in effect
+func SetRuntime(r *Runtime) {
+	current.Store(r)
+}
+
+// ReloadRuntime loads the runtime configuration again and puts it in effect,
+// returning the variables that changed. An invalid configuration is rejected
+// and the one in effect is kept.
+func ReloadRuntime() ([]string, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	next, err := LoadRuntime()
+	if err != nil {
+		return nil, err
+	}
+	changed := next.Changes(CurrentRuntime())
+	SetRuntime(next)
+	return changed, nil
+}
+
+// Feature reports whether a feature flag is on
+func (r *Runtime) Feature(name string) bool {
+	return r.FeatureFlags[name]
+}
+
+// Level returns the base log level
+func (r *Runtime) Level() logrus.Level {
+	level, err := logrus.ParseLevel(r.LogLevel)
+	if err != nil {
+		return logrus.InfoLevel
+	}
+	return level
+}
+
+// Changes returns the variables whose values differ from another runtime
+// configuration, sorted
+func (r *Runtime) Changes(prev *Runtime) []string {
+	var changed []string
+	if r.LogLevel != prev.LogLevel {
+		changed = append(changed, "LOG_LEVEL")
+	}
+	if !maps.Equal(r.RateLimitPolicy.Tiers, prev.RateLimitPolicy.Tiers) {
+		changed = append(changed, "RATE_LIMIT_TIERS")
+	}
+	if !maps.Equal(r.RateLimitPolicy.Groups, prev.RateLimitPolicy.Groups) {
+		changed = append(changed, "RATE_LIMIT_GROUPS")
+	}
+	if !reflect.DeepEqual(r.RateLimitPolicy.APIKeys, prev.RateLimitPolicy.APIKeys) {
+		changed = append(changed, "RATE_LIMIT_API_KEYS")
+	}
+	if !maps.Equal(r.FeatureFlags, prev.FeatureFlags) {
+		changed = append(changed, "FEATURE_FLAGS")
+	}
+	if r.MaintenanceMode != prev.MaintenanceMode {
+		changed = append(changed, "MAINTENANCE_MODE")
+	}
+	if r.MaintenanceMessage != prev.MaintenanceMessage {
+		changed = append(changed, "MAINTENANCE_MESSAGE")
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// LoadRuntime loads the runtime configuration from environment variables,
+// overridden by the KEY=VALUE lines of CONFIG_FILE when set. Environment
+// variables only change with a restart, so reloads pick up edits to the file.
+func LoadRuntime() (*Runtime, error) {
+	source := os.Getenv("CONFIG_FILE")
+	file, err := readConfigFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CONFIG_FILE: %v", err)
+	}
+	get := func(key, defaultValue string) string {
+		if value, ok := file[key]; ok && value != "" {
+			return value
+		}
+		return getEnv(key, defaultValue)
+	}
+
+	logLevel, err := logrus.ParseLevel(get("LOG_LEVEL", "info"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOG_LEVEL: %v", err)
+	}
+
+	rateLimitTiers, err := parseIntMap(get("RATE_LIMIT_TIERS", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_TIERS: %v", err)
+	}
+	for tier, limit := range defaultRateLimitTiers {
+		if _, ok := rateLimitTiers[tier]; !ok {
+			rateLimitTiers[tier] = limit
+		}
+	}
+	rateLimitGroups, err := parseIntMap(get("RATE_LIMIT_GROUPS", "auth.anonymous=20"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_GROUPS: %v", err)
+	}
+	rateLimitAPIKeys, err := parseAPIKeys(get("RATE_LIMIT_API_KEYS", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_API_KEYS: %v", err)
+	}
+	for _, limits := range []map[string]int{rateLimitTiers, rateLimitGroups} {
+		for name, limit := range limits {
+			if limit < 0 {
+				return nil, fmt.Errorf("invalid rate limit of %s: %d is negative", name, limit)
+			}
+		}
+	}
+
+	featureFlags := make(map[string]bool, len(featureDefaults))
+	for name, key := range featureDefaults {
+		if featureFlags[name], err = strconv.ParseBool(get(key, "false")); err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", key, err)
+		}
+	}
+	if err := parseFeatureFlags(get("FEATURE_FLAGS", ""), featureFlags); err != nil {
+		return nil, fmt.Errorf("invalid FEATURE_FLAGS: %v", err)
+	}
+
+	maintenanceMode, err := strconv.ParseBool(get("MAINTENANCE_MODE", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAINTENANCE_MODE: %v", err)
+	}
+
+	return &Runtime{
+		LogLevel: logLevel.String(),
+		RateLimitPolicy: ratelimit.Policy{
+			Tiers:   rateLimitTiers,
+			Groups:  rateLimitGroups,
+			APIKeys: rateLimitAPIKeys,
+		},
+		FeatureFlags:       featureFlags,
+		MaintenanceMode:    maintenanceMode,
+		MaintenanceMessage: get("MAINTENANCE_MESSAGE", defaultMaintenanceMessage),
+		LoadedAt:           time.Now(),
+		Source:             source,
+	}, nil
+}
+
+// readConfigFile reads the KEY=VALUE lines of a file, skipping blank lines and
+// # comments. An empty path reads nothing.
+func readConfigFile(path string) (map[string]string, error) {
+	values := make(map[string]string)
+	if path == "" {
+		return values, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(text, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", line)
+		}
+		values[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return values, scanner.Err()
+}
+
+// parseFeatureFlags sets the flags of a "name=true,name=false" list, rejecting
+// names that are not known
+func parseFeatureFlags(value string, flags map[string]bool) error {
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, raw, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("expected name=bool, got %q", entry)
+		}
+		name = strings.TrimSpace(name)
+		if _, known := featureDefaults[name]; !known {
+			return fmt.Errorf("unknown feature flag %q", name)
+		}
+		on, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("feature flag %s: %v", name, err)
+		}
+		flags[name] = on
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// Package contracts guards the JSON contract of API responses. Its tests derive
+// a JSON schema from every documented response type and compare it with the
+// golden schemas in schemas/, then validate responses recorded from the router
+// and the payloads in payloads/ against those schemas. Renaming or removing a
+// field therefore fails the tests until the golden files are updated on purpose
+// with `go test ./contracts -update`.
+package contracts
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"product-management/internal/dto"
+	"product-management/internal/types"
+	"product-management/pkg/jsonschema"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden schemas and recorded payloads")
+
+// contracts maps a contract name to the response type documented for it
+var contracts = map[string]interface{}{
+	"api_response":                   types.APIResponse{},
+	"error_response":                 types.ErrorResponse{},
+	"problem_details":                types.ProblemDetails{},
+	"success_response":               types.SuccessResponse{},
+	"paginated_response":             types.PaginatedResponse{},
+	"register_response":              dto.RegisterResponse{},
+	"login_response":                 types.DataResponse[types.LoginResponse]{},
+	"form_token_response":            types.DataResponse[dto.FormTokenResponse]{},
+	"user_response":                  types.DataResponse[dto.UserResponse]{},
+	"user_list_response":             types.DataResponse[types.UserListResponse]{},
+	"test_token_response":            types.DataResponse[dto.TestTokenResponse]{},
+	"anonymized_user_response":       types.DataResponse[dto.AnonymizedUserResponse]{},
+	"rate_limit_usage_response":      types.DataResponse[dto.RateLimitUsageResponse]{},
+	"notification_settings_response": types.DataResponse[dto.NotificationSettingsResponse]{},
+	"webhook_create_response":        types.DataResponse[dto.CreateWebhookResponse]{},
+	"webhook_list_response":          types.DataResponse[[]dto.WebhookSubscriptionResponse]{},
+	"webhook_delivery_response":      dto.WebhookDeliveryResponse{},
+	"gift_card_response":             types.DataResponse[dto.GiftCardResponse]{},
+	"gift_card_redemption_response":  types.DataResponse[dto.GiftCardRedemptionResponse]{},
+	"store_credit_balance_response":  types.DataResponse[dto.StoreCreditBalanceResponse]{},
+	"referrals_response":             types.DataResponse[dto.ReferralsResponse]{},
+	"segment_response":               types.DataResponse[dto.SegmentResponse]{},
+	"notify_segment_response":        types.DataResponse[dto.NotifySegmentResponse]{},
+	"price_list_response":            types.DataResponse[dto.PriceListResponse]{},
+	"quote_response":                 types.DataResponse[dto.QuoteResponse]{},
+	"order_tracking_response":        types.DataResponse[dto.OrderTrackingResponse]{},
+	"shipment_response":              types.DataResponse[dto.ShipmentResponse]{},
+	"pick_list_response":             types.DataResponse[dto.PickListResponse]{},
+	"availability_response":          types.DataResponse[dto.AvailabilityResponse]{},
+	"booking_response":               types.DataResponse[dto.BookingResponse]{},
+	"supplier_response":              types.DataResponse[dto.SupplierResponse]{},
+	"purchase_order_response":        types.DataResponse[dto.PurchaseOrderResponse]{},
+	"product_price_response":         types.DataResponse[dto.ProductPriceResponse]{},
+	"store_credit_entry_response":    dto.StoreCreditEntryResponse{},
+	"product_response":               types.DataResponse[dto.ProductResponse]{},
+	"product_list_response":          types.ProductListResponse{},
+	"product_image_list_response":    types.DataResponse[[]dto.ProductImageResponse]{},
+	"product_merge_response":         types.DataResponse[dto.MergeProductsResponse]{},
+	"wishlist_response":              types.WishlistResponse{},
+	"wishlist_count_response":        types.DataResponse[dto.WishlistCountResponse]{},
+	"category_response":              types.DataResponse[dto.CategoryResponse]{},
+	"product_batch_response":         types.DataResponse[dto.ProductBatchResponse]{},
+	"category_batch_response":        types.DataResponse[dto.CategoryBatchResponse]{},
+	"category_list_response":         types.DataResponse[[]dto.CategoryResponse]{},
+	"category_products_response":     types.DataResponse[[]dto.ProductResponse]{},
+	"category_distribution":          types.DataResponse[[]dto.CategoryDistributionResponse]{},
+	"review_response":                dto.ReviewResponse{},
+	"review_list_response":           dto.ReviewListResponse{},
+	"review_count_response":          types.DataResponse[dto.ReviewCountResponse]{},
+	"user_review_stats_response":     types.DataResponse[dto.UserReviewStatsResponse]{},
+	"review_analytics_response":      types.DataResponse[dto.ReviewAnalyticsResponse]{},
+	"category_analytics_response":    types.DataResponse[dto.CategoryAnalyticsResponse]{},
+	"conversion_analytics_response":  types.DataResponse[dto.ConversionAnalyticsResponse]{},
+	"activity_response":              types.DataResponse[dto.ActivityResponse]{},
+	"report_list_response":           types.DataResponse[[]dto.ReportDefinitionResponse]{},
+	"report_response":                types.DataResponse[dto.ReportResponse]{},
+	"reconciliation_response":        types.DataResponse[dto.ReconciliationResponse]{},
+	"retention_response":             types.DataResponse[dto.RetentionResponse]{},
+	"integrity_report_response":      types.DataResponse[dto.IntegrityReportResponse]{},
+	"integrity_repair_response":      types.DataResponse[dto.RepairIntegrityResponse]{},
+	"rebuild_run_response":           types.DataResponse[dto.RebuildRunResponse]{},
+	"security_alert_response":        dto.SecurityAlertResponse{},
+	"ip_block_list_response":         types.DataResponse[[]dto.IPBlockResponse]{},
+	"verbose_logging_response":       types.DataResponse[dto.VerboseLoggingResponse]{},
+	"selftest_response":              types.DataResponse[dto.SelfTestResponse]{},
+	"runtime_config_response":        types.DataResponse[dto.RuntimeConfigResponse]{},
+	"reload_config_response":         types.DataResponse[dto.ReloadConfigResponse]{},
+	"storefront_export_response":     types.DataResponse[dto.StorefrontExportResponse]{},
+	"storefront_bootstrap_response":  types.DataResponse[dto.StorefrontBootstrapResponse]{},
+	"setting_response":               types.DataResponse[dto.SettingResponse]{},
+	"market_prices_response":         types.DataResponse[dto.MarketPricesResponse]{},
+	"pricing_rule_response":          types.DataResponse[dto.PricingRuleResponse]{},
+	"pricing_simulation_response":    types.DataResponse[dto.PricingSimulationResponse]{},
+	"promotion_response":             types.DataResponse[dto.PromotionResponse]{},
+	"experiment_response":            types.DataResponse[dto.ExperimentResponse]{},
+	"experiment_assignment_list":     types.DataResponse[[]dto.ExperimentAssignment]{},
+	"experiment_results_response":    types.DataResponse[dto.ExperimentResultsResponse]{},
+	"storefront_product":             dto.StorefrontProduct{},
+	"storefront_category":            dto.StorefrontCategory{},
+	"storefront_manifest":            dto.StorefrontManifest{},
+	"featured_product_response":      types.DataResponse[dto.FeaturedProductResponse]{},
+	"featured_product_list_response": types.DataResponse[[]dto.FeaturedProductResponse]{},
+	"content_block_response":         types.DataResponse[dto.ContentBlockResponse]{},
+	"public_content_block_response":  types.DataResponse[dto.PublicContentBlockResponse]{},
+	"custom_field_list_response":     types.DataResponse[[]dto.CustomFieldResponse]{},
+	"form_schema_response":           types.DataResponse[dto.FormSchemaResponse]{},
+	"public_review_response":         dto.PublicReviewResponse{},
+	"review_import_response":         types.DataResponse[dto.ReviewImportResponse]{},
+	"connector_list_response":        types.DataResponse[[]dto.ConnectorResponse]{},
+	"connector_sync_run_response":    types.DataResponse[dto.ConnectorSyncRunResponse]{},
+	"outbox_event_response":          dto.OutboxEventResponse{},
+	"reprocess_outbox_response":      types.DataResponse[dto.ReprocessOutboxResponse]{},
+	"saga_response":                  types.DataResponse[dto.SagaResponse]{},
+	"catalog_diff_response":          types.DataResponse[dto.CatalogDiffResponse]{},
+	"sync_changes_response":          types.DataResponse[dto.SyncChangesResponse]{},
+	"change_request_response":        types.DataResponse[dto.ProductChangeRequestResponse]{},
+	"product_revision_response":      dto.ProductRevisionResponse{},
+	"stock_movement_response":        dto.StockMovementResponse{},
+	"product_snapshot":               dto.ProductSnapshot{},
+	"user_output":                    dto.UserOutput{},
+	"category_distribution_output":   dto.CategoryDistributionResponse{},
+}
+
+// contractNames returns the contract names in order
+func contractNames() []string {
+	names := make([]string, 0, len(contracts))
+	for name := range contracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TestSchemas compares the schema of every contract with its golden file, or
+// rewrites the golden files with -update
+func TestSchemas(t *testing.T) {
+	for _, name := range contractNames() {
+		generated, err := json.MarshalIndent(jsonschema.Generate(contracts[name]), "", "  ")
+		if err != nil {
+			t.Fatalf("failed to encode schema %s: %v", name, err)
+		}
+		generated = append(generated, '\n')
+		path := filepath.Join("schemas", name+".json")
+
+		if *update {
+			if err := os.WriteFile(path, generated, 0o644); err != nil {
+				t.Fatalf("failed to write %s: %v", path, err)
+			}
+			continue
+		}
+
+		golden, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("missing golden schema for %s: %v", name, err)
+			continue
+		}
+		if !bytes.Equal(golden, generated) {
+			t.Errorf("schema changed for %s; review the field changes and run with -update if intended", name)
+		}
+	}
+}
+
+// TestPayloads validates every golden payload against the contract with the
+// same file name
+func TestPayloads(t *testing.T) {
+	payloads, err := filepath.Glob(filepath.Join("payloads", "*.json"))
+	if err != nil {
+		t.Fatalf("failed to list payloads: %v", err)
+	}
+	for _, path := range payloads {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		contract, ok := contracts[name]
+		if !ok {
+			t.Errorf("payload %s has no matching contract", path)
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		var payload interface{}
+		if err := json.Unmarshal(data, &payload); err != nil {
+			t.Errorf("payload %s is not valid JSON: %v", path, err)
+			continue
+		}
+		for _, violation := range jsonschema.Validate(jsonschema.Generate(contract), payload) {
+			t.Errorf("%s: %s", path, violation)
+		}
+	}
+}
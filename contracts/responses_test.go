@@ -0,0 +1,291 @@
+package contracts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"product-management/config"
+	"product-management/internal/models"
+	"product-management/internal/routes"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"product-management/pkg/jsonschema"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recordedUserID is the user the recording token is issued for
+const recordedUserID = 1
+
+// recordedPassword is the password of the recorded user
+const recordedPassword = "Secret123!"
+
+// recordedAt is the timestamp of every recorded row
+var recordedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// recording is a request to the real router whose response must satisfy a
+// contract. The database answers the queries the handler runs, in order.
+type recording struct {
+	contract string
+	method   string
+	target   string
+	body     string
+	status   int
+	queries  []query
+}
+
+// query answers the next database query containing sql with rows, or with an
+// empty result when rows is nil. Statements without a result set are expected
+// with exec; begin and commit stand for the transaction around them.
+type query struct {
+	sql  string
+	rows *sqlmock.Rows
+	exec bool
+}
+
+var (
+	begin  = query{sql: "BEGIN"}
+	commit = query{sql: "COMMIT"}
+)
+
+// recordings builds the requests to record. The rows are built per run
+// because sqlmock consumes them.
+func recordings(t *testing.T) []recording {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(recordedPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash the recorded password: %v", err)
+	}
+	product := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "description", "sku", "location", "price", "stock_quantity", "status", "rating_average", "rating_count", "metadata"}).
+			AddRow(1, recordedAt, recordedAt, "SmartWatch Pro", "Advanced smartwatch with fitness tracking.", "SW-PRO-01", "A-03-2", 290.0, 60, "active", 4.5, 2, `{"color":"black","battery_hours":36}`)
+	}
+	category := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "description", "products_count"}).
+			AddRow(1, recordedAt, recordedAt, "Electronics", "Devices and gadgets", 1)
+	}
+	image := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "created_at", "product_id", "key", "alt", "position", "content_type", "size"}).
+			AddRow(7, recordedAt, 1, "products/1/7.jpg", "SmartWatch Pro, front view", 0, "image/jpeg", 184320)
+	}
+	user := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "email", "full_name", "password", "role", "last_login", "custom_fields"}).
+			AddRow(recordedUserID, recordedAt, recordedAt, "johndoe", "john@example.com", "John Doe", string(passwordHash), "user", recordedAt, `{"company":"Acme"}`)
+	}
+	return []recording{
+		{
+			contract: "error_response",
+			method:   http.MethodGet,
+			target:   "/api/v1/products/abc",
+			status:   http.StatusBadRequest,
+		},
+		{
+			contract: "product_response",
+			method:   http.MethodGet,
+			target:   "/api/v1/products/1",
+			status:   http.StatusOK,
+			queries: []query{
+				{sql: `FROM "products"`, rows: product()},
+				{sql: `FROM "product_categories"`, rows: sqlmock.NewRows([]string{"product_id", "category_id"}).AddRow(1, 1)},
+				{sql: `FROM "categories"`, rows: category()},
+				{sql: `FROM "product_images"`, rows: image()},
+				{sql: `FROM "reviews"`},
+				{sql: `FROM "promotions"`},
+				{sql: `FROM "pricing_rules"`},
+				{sql: `FROM "users"`, rows: sqlmock.NewRows([]string{"id", "price_list_id"}).AddRow(recordedUserID, nil)},
+			},
+		},
+		{
+			contract: "product_list_response",
+			method:   http.MethodGet,
+			target:   "/api/v1/products?page=1&limit=10",
+			status:   http.StatusOK,
+			queries: []query{
+				{sql: `SELECT count(*) FROM "products"`, rows: sqlmock.NewRows([]string{"count"}).AddRow(1)},
+				{sql: `SELECT * FROM "products"`, rows: product()},
+				{sql: `FROM "product_categories"`, rows: sqlmock.NewRows([]string{"product_id", "category_id"}).AddRow(1, 1)},
+				{sql: `FROM "categories"`, rows: category()},
+				{sql: `FROM "product_images"`, rows: image()},
+			},
+		},
+		{
+			contract: "category_list_response",
+			method:   http.MethodGet,
+			target:   "/api/v1/categories",
+			status:   http.StatusOK,
+			queries: []query{
+				{sql: `FROM "categories"`, rows: category()},
+			},
+		},
+		{
+			contract: "user_response",
+			method:   http.MethodGet,
+			target:   "/api/v1/auth/me",
+			status:   http.StatusOK,
+			queries: []query{
+				{sql: `FROM "users"`, rows: user()},
+			},
+		},
+		{
+			contract: "wishlist_response",
+			method:   http.MethodGet,
+			target:   "/api/v1/products/wishlist",
+			status:   http.StatusOK,
+			queries: []query{
+				{sql: `SELECT count(*) FROM "wishlists"`, rows: sqlmock.NewRows([]string{"count"}).AddRow(1)},
+				{sql: `FROM "wishlists"`, rows: sqlmock.NewRows([]string{"id", "created_at", "updated_at", "user_id", "product_id"}).AddRow(3, recordedAt, recordedAt, recordedUserID, 1)},
+				{sql: `FROM "products"`, rows: product()},
+				{sql: `FROM "product_categories"`, rows: sqlmock.NewRows([]string{"product_id", "category_id"}).AddRow(1, 1)},
+				{sql: `FROM "categories"`, rows: category()},
+				{sql: `FROM "product_images"`, rows: image()},
+			},
+		},
+		{
+			contract: "review_list_response",
+			method:   http.MethodGet,
+			target:   "/api/v1/reviews/?sort_by=created_at&order=desc",
+			status:   http.StatusOK,
+			queries: []query{
+				{sql: `SELECT count(*) FROM "reviews"`, rows: sqlmock.NewRows([]string{"count"}).AddRow(1)},
+				{sql: `FROM "reviews"`, rows: sqlmock.NewRows([]string{"id", "created_at", "updated_at", "product_id", "user_id", "rating", "comment"}).AddRow(5, recordedAt, recordedAt, 1, recordedUserID, 5, "Great battery life.")},
+				{sql: `FROM "products"`, rows: product()},
+				{sql: `FROM "users"`, rows: user()},
+			},
+		},
+		// Last, since the login records the device in the background
+		{
+			contract: "login_response",
+			method:   http.MethodPost,
+			target:   "/api/v1/auth/login",
+			body:     `{"email":"john@example.com","password":"` + recordedPassword + `"}`,
+			status:   http.StatusOK,
+			queries: []query{
+				{sql: `FROM "users"`, rows: user()},
+				begin,
+				{sql: `INSERT INTO "refresh_tokens"`, rows: sqlmock.NewRows([]string{"id"}).AddRow(1)},
+				commit,
+				begin,
+				{sql: `UPDATE "users"`, exec: true},
+				commit,
+			},
+		},
+	}
+}
+
+// TestRecordedResponses sends each recording to the router with a mocked
+// database and validates the response body against its contract. With
+// -update the bodies are written to payloads/, which TestPayloads checks.
+func TestRecordedResponses(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("failed to load configuration: %v", err)
+	}
+	sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherFunc(func(expected, actual string) error {
+		if !strings.Contains(actual, expected) {
+			return fmt.Errorf("query %q does not contain %q", actual, expected)
+		}
+		return nil
+	})))
+	if err != nil {
+		t.Fatalf("failed to create the database mock: %v", err)
+	}
+	defer sqlDB.Close()
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open the database mock: %v", err)
+	}
+
+	// Services read the shared connection when they are created
+	previous := database.DB
+	database.DB = db
+	defer func() { database.DB = previous }()
+	router := gin.New()
+	router.Use(gin.RecoveryWithWriter(io.Discard))
+	routes.SetupRoutes(cfg, db, router, nil)
+
+	// Handlers cache what they read, so every run starts from an empty cache.
+	// Within a run, only the first product request loads the prices.
+	previousCache := cache.Store
+	cache.Store = cache.NewMemoryCache(cache.DefaultMemoryEntries)
+	defer func() { cache.Store = previousCache }()
+	cache.Store.Set(cache.TokenVersionKey(recordedUserID), uint(0), time.Hour)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id":       recordedUserID,
+		"role":          models.RoleUser,
+		"token_version": 0,
+		"exp":           time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(cfg.JWTSecret))
+	if err != nil {
+		t.Fatalf("failed to sign the recording token: %v", err)
+	}
+
+	for _, rec := range recordings(t) {
+		name := rec.method + " " + rec.target
+		for _, q := range rec.queries {
+			switch {
+			case q == begin:
+				mock.ExpectBegin()
+			case q == commit:
+				mock.ExpectCommit()
+			case q.exec:
+				mock.ExpectExec(q.sql).WillReturnResult(sqlmock.NewResult(0, 1))
+			case q.rows == nil:
+				mock.ExpectQuery(q.sql).WillReturnRows(sqlmock.NewRows(nil))
+			default:
+				mock.ExpectQuery(q.sql).WillReturnRows(q.rows)
+			}
+		}
+
+		req := httptest.NewRequest(rec.method, rec.target, strings.NewReader(rec.body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		if rec.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, req)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if response.Code != rec.status {
+			t.Errorf("%s: status %d, want %d: %s", name, response.Code, rec.status, response.Body.String())
+			continue
+		}
+		var payload interface{}
+		if err := json.Unmarshal(response.Body.Bytes(), &payload); err != nil {
+			t.Errorf("%s: response is not JSON: %v", name, err)
+			continue
+		}
+		for _, violation := range jsonschema.Validate(jsonschema.Generate(contracts[rec.contract]), payload) {
+			t.Errorf("%s: %s", name, violation)
+		}
+
+		if *update {
+			var indented bytes.Buffer
+			if err := json.Indent(&indented, response.Body.Bytes(), "", "  "); err != nil {
+				t.Fatalf("failed to indent %s: %v", name, err)
+			}
+			indented.WriteByte('\n')
+			path := filepath.Join("payloads", rec.contract+".json")
+			if err := os.WriteFile(path, indented.Bytes(), 0o644); err != nil {
+				t.Fatalf("failed to write %s: %v", path, err)
+			}
+		}
+	}
+}
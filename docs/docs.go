@@ -1,4 +1,5 @@
-// Package docs Code generated by swaggo/swag. DO NOT EDIT
+// Code generated by swaggo/swag. DO NOT EDIT.
+
 package docs
 
 import "github.com/swaggo/swag"
@@ -24,66 +25,105 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Authenticate user and return JWT tokens",
-                "consumes": [
-                    "application/json"
+        "/admin/analytics/activity": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Get request volumes, status code distributions and latency percentiles (p50, p95, p99) over time and per endpoint, from the request statistics the servers store every ACTIVITY_BUCKET. Each endpoint carries its request count per period of the series, for heatmaps. Requests of the bucket in progress are not included yet. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Login user",
+                "summary": "API activity dashboard",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.LoginRequest"
-                        }
+                        "type": "integer",
+                        "default": 24,
+                        "description": "Window length in hours (1-720)",
+                        "name": "hours",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "1h",
+                        "description": "Period of the series as a multiple of ACTIVITY_BUCKET, e.g. 15m or 1h",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "GET",
+                            "POST",
+                            "PUT",
+                            "PATCH",
+                            "DELETE"
+                        ],
+                        "type": "string",
+                        "description": "Only requests with this method",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only requests to this route pattern, e.g. /api/v1/products/:id",
+                        "name": "route",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of endpoints, busiest first (1-100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ActivityResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/auth/me": {
+        "/admin/analytics/categories": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get information of the currently logged-in user",
+                "description": "Get the product count of every category with the number of times its products were added to wishlists between two dates, inclusive. Revenue per category is on the sales analytics dashboard. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -91,94 +131,141 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
+                ],
+                "summary": "Category analytics dashboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD), defaults to 29 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD), defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone days are interpreted in, e.g. Asia/Ho_Chi_Minh, defaults to the request time zone",
+                        "name": "tz",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get current user information",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_CategoryAnalyticsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/analytics/conversion": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update information of the currently logged-in user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the most viewed products between two UTC days, inclusive, with their unique viewers, wishlist adds, accepted quotes and units over the same days, and the wishlist adds and orders per view. Views come from POST /products/{id}/view. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Update user information",
+                "summary": "Conversion analytics dashboard",
                 "parameters": [
                     {
-                        "description": "User update details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateUserRequest"
-                        }
+                        "type": "string",
+                        "description": "First UTC day (YYYY-MM-DD), defaults to 29 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last UTC day (YYYY-MM-DD), defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of products, most viewed first (1-100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.SuccessResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ConversionAnalyticsResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/auth/password": {
-            "put": {
+        "/admin/analytics/reviews": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update the password of the currently logged-in user",
+                "description": "Get review volume and average rating over time, and the products whose average rating dropped the most compared with the preceding window of the same length. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -186,159 +273,187 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Update user password",
+                "summary": "Review analytics dashboard",
                 "parameters": [
                     {
-                        "description": "Password update details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdatePasswordRequest"
-                        }
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Window length in days (1-365)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Volume bucket size",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of dropping products (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 3,
+                        "description": "Reviews a product needs in each window",
+                        "name": "min_reviews",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone periods are aligned to, e.g. Asia/Ho_Chi_Minh, defaults to the request time zone. Ignored with REPORTING_MODE=materialized, which aligns them to UTC.",
+                        "name": "tz",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.SuccessResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ReviewAnalyticsResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Register a new user with the provided information",
-                "consumes": [
-                    "application/json"
+        "/admin/analytics/sales": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Get the accepted quotes, units and revenue of every UTC day between two dates, inclusive, with totals and the revenue per category. A product in several categories counts toward each. With REPORTING_MODE=materialized the figures come from the reporting views, as of their last refresh. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Register a new user",
+                "summary": "Sales analytics dashboard",
                 "parameters": [
                     {
-                        "description": "User registration details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.RegisterRequest"
-                        }
+                        "type": "string",
+                        "description": "First UTC day (YYYY-MM-DD), defaults to 29 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last UTC day (YYYY-MM-DD), defaults to today",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.RegisterResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_SalesAnalyticsResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/auth/users": {
+        "/admin/bookings": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get a paginated list of users with search and filter options",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get a paginated list of every customer's bookings, newest first, optionally of one product (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "List users",
+                "summary": "List bookings",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
+                        "description": "Filter by product",
+                        "name": "product_id",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of items per page (default: 10, max: 100)",
-                        "name": "page_size",
+                        "type": "string",
+                        "description": "Filter by status (confirmed, cancelled)",
+                        "name": "status",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Search by username or email",
-                        "name": "search",
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by role (user/admin)",
-                        "name": "role",
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
                         "in": "query"
                     }
                 ],
@@ -346,52 +461,55 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/auth/users/{id}": {
-            "get": {
+        "/admin/bookings/{id}/cancel": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get information of a user by their ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Cancel any customer's booking before its slot starts, freeing its units (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Get user information by ID",
+                "summary": "Cancel a booking",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Booking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -401,52 +519,69 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_BookingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/catalog/diff": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Soft delete a user (only admin can do this)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the products and categories created, updated or deleted since a time, with the fields each update changed, for downstream systems to sync incrementally. Pass the until of a response as from to get the next changes; some changes may show up in both. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Delete a user",
+                "summary": "Diff the catalog",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "RFC 3339 time, e.g. 2021-01-01T00:00:00Z",
+                        "name": "from",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -454,50 +589,44 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.SuccessResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_CatalogDiffResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/auth/users/{id}/role": {
-            "put": {
+        "/admin/change-requests": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update the role of a user (only admin can do this)",
+                "description": "Get a paginated list of product change requests (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -505,75 +634,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Update user role",
+                "summary": "List product change requests",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status (pending, approved, rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "Role update details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateUserRoleRequest"
-                        }
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.SuccessResponse"
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/categories": {
+        "/admin/change-requests/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get all categories",
+                "description": "Get a product change request with its proposed diff (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -581,31 +702,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "admin"
+                ],
+                "summary": "Get a product change request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Change request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List categories",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ProductChangeRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/change-requests/{id}/approve": {
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create a new category with name and optional description",
+                "description": "Apply the proposed product change and mark the request as approved (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -613,84 +757,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "admin"
                 ],
-                "summary": "Create a new category",
+                "summary": "Approve a product change request",
                 "parameters": [
                     {
-                        "description": "Category details",
+                        "type": "integer",
+                        "description": "Change request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review note",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateCategoryRequest"
+                            "$ref": "#/definitions/product-management_internal_dto.ReviewChangeRequestRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ProductChangeRequestResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/categories/distribution": {
-            "get": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Get the distribution of products across categories",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "categories"
-                ],
-                "summary": "Get category distribution",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/categories/{id}": {
-            "get": {
+        "/admin/change-requests/{id}/reject": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get a category by its ID",
+                "description": "Mark the request as rejected without changing the product (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -698,385 +826,369 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "admin"
                 ],
-                "summary": "Get a category",
+                "summary": "Reject a product change request",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
+                        "description": "Change request ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Review note",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.ReviewChangeRequestRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ProductChangeRequestResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/config": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update an existing category with name and optional description",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the configuration that can be reloaded without a restart, as in effect on the instance serving the request: log level, rate limits, feature flags and maintenance mode. API keys are listed by name only. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
-                ],
-                "summary": "Update a category",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Category details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateCategoryRequest"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "Get the runtime configuration",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_RuntimeConfigResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/config/reload": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Delete a category by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Read the log level, rate limits, feature flags and maintenance mode again from CONFIG_FILE and the environment, as SIGHUP does, and apply them without a restart. An invalid configuration is rejected with 422 and the one in effect is kept. Only the instance serving the request reloads. Recorded in the audit log. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
-                ],
-                "summary": "Delete a category",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "Reload the runtime configuration",
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.SuccessResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ReloadConfigResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/categories/{id}/products": {
+        "/admin/connectors": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get all products in a specific category",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the connectors configured to sync products, stock and orders with legacy systems, with how often each syncs and its latest run. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
-                ],
-                "summary": "Get category products",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "List connectors",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_ConnectorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/categories/{id}/products/{productId}": {
-            "post": {
+        "/admin/connectors/{name}/runs": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Add a product to a specific category",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the log of a connector's sync runs, newest first, with what each pulled, changed and pushed and the error of any step that failed. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "admin"
                 ],
-                "summary": "Add product to category",
+                "summary": "List connector sync runs",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Connector name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "productId",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.SuccessResponse"
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/connectors/{name}/sync": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Remove a product from a specific category",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Start a sync of a connector in the background without waiting for its schedule, and return its run. Follow it in the run log. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "admin"
                 ],
-                "summary": "Remove product from category",
+                "summary": "Sync a connector now",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "productId",
+                        "type": "string",
+                        "description": "Connector name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/types.SuccessResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ConnectorSyncRunResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/products": {
+        "/admin/content-blocks": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get a paginated list of products with optional filters",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List every content block ordered by key, including scheduled and expired ones (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
-                ],
-                "summary": "List products",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Filter by category ID",
-                        "name": "categoryId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search term",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort field (name, price, created_at)",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Filter by statuses",
-                        "name": "statuses",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "List content blocks",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.ProductListResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_ContentBlockResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
@@ -1087,7 +1199,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Create a new product with categories",
+                "description": "Add a content block under a unique key, optionally shown only between starts_at and ends_at (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1095,17 +1207,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "admin"
                 ],
-                "summary": "Create a product",
+                "summary": "Create a content block",
                 "parameters": [
                     {
-                        "description": "Product details",
-                        "name": "product",
+                        "description": "Content block",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateProductRequest"
+                            "$ref": "#/definitions/product-management_internal_dto.ContentBlockRequest"
                         }
                     }
                 ],
@@ -1113,114 +1225,112 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ContentBlockResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/products/wishlist": {
+        "/admin/content-blocks/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get the user's wishlist",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get a content block by ID whatever its schedule (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "admin"
                 ],
-                "summary": "Get wishlist",
+                "summary": "Get a content block by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Content block ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.WishlistResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ContentBlockResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/products/wishlist/count": {
-            "get": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Get the total number of wishlist items",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "products"
-                ],
-                "summary": "Get total wishlist count",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/products/wishlist/{product_id}": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Add a product to the user's wishlist if it's not already added",
+                "description": "Replace the key, content and schedule of a content block (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1228,35 +1338,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "admin"
                 ],
-                "summary": "Add to wishlist",
+                "summary": "Update a content block",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "product_id",
+                        "description": "Content block ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Content block",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.ContentBlockRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ContentBlockResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
@@ -1267,22 +1410,19 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Remove a product from the user's wishlist",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete a content block, freeing its key (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "admin"
                 ],
-                "summary": "Remove from wishlist",
+                "summary": "Delete a content block",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "product_id",
+                        "description": "Content block ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1291,85 +1431,109 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.SuccessResponse"
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/products/{id}": {
+        "/admin/custom-fields": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get a product by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the custom fields of every form, or of one, by form and position (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "admin"
                 ],
-                "summary": "Get a product",
+                "summary": "List custom form fields",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "enum": [
+                            "registration",
+                            "checkout"
+                        ],
+                        "type": "string",
+                        "description": "Filter by form",
+                        "name": "form",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_CustomFieldResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update an existing product",
+                "description": "Add a field to the registration or checkout form under a key unique to the form. Select fields need options; only text fields take a pattern and lengths, and only number fields a min and max (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1377,55 +1541,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "admin"
                 ],
-                "summary": "Update a product",
+                "summary": "Create a custom form field",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Product details to update",
-                        "name": "product",
+                        "description": "Custom field",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateProductRequest"
+                            "$ref": "#/definitions/product-management_internal_dto.CustomFieldRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_CustomFieldResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/custom-fields/{id}": {
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Delete a product by its ID",
+                "description": "Replace the definition of a custom field. Values already submitted are kept as they are (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1433,933 +1610,19732 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "admin"
                 ],
-                "summary": "Delete a product",
+                "summary": "Update a custom form field",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Custom field ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Custom field",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.CustomFieldRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.SuccessResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_CustomFieldResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/reviews": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create a new review for a product",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove a field from its form, freeing its key. Values already submitted for it are kept (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
+                    "admin"
                 ],
-                "summary": "Create a new review",
+                "summary": "Delete a custom form field",
                 "parameters": [
                     {
-                        "description": "Review data",
-                        "name": "review",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateReviewRequest"
-                        }
+                        "type": "integer",
+                        "description": "Custom field ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ReviewResponse"
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/reviews/": {
+        "/admin/debug/goroutines": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Search reviews with pagination, product name filter, and sorting",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the stack trace of every goroutine of the instance as text, to find leaks and deadlocks. Admin only.",
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "reviews"
-                ],
-                "summary": "Search reviews",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Page size",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Product name to filter by",
-                        "name": "product_name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "created_at",
-                        "description": "Field to sort by (created_at, rating)",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "desc",
-                        "description": "Sort order (asc, desc)",
-                        "name": "order",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "Goroutine dump",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ReviewListResponse"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/reviews/count": {
+        "/admin/debug/pprof/{name}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get the total number of reviews for all products",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Serve the pprof profiles of the instance: an index without a name, or cmdline, profile (CPU, for ?seconds=30), symbol, trace, heap, allocs, goroutine, block, mutex or threadcreate. Use with go tool pprof. Admin only.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "reviews"
+                    "admin"
+                ],
+                "summary": "Runtime profiles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile name, e.g. heap",
+                        "name": "name",
+                        "in": "path"
+                    }
                 ],
-                "summary": "Get total review count",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "type": "file"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/reviews/{id}": {
+        "/admin/debug/vars": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get a review by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the expvar variables of the instance: memory and GC stats (memstats), command line, goroutine count and database pool stats. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
-                ],
-                "summary": "Get a review",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Review ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "Runtime stats",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Review"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/debug/verbose-logging": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Delete a review by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get whether the instance logs verbosely and until when. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
+                    "admin"
                 ],
-                "summary": "Delete a review",
+                "summary": "Get verbose logging",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_VerboseLoggingResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Log at debug level, with request headers and every SQL statement, for a duration of at most 1h, after which the instance goes back to its configured level. A duration of 0 turns it off. Only the instance serving the request is affected. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Toggle verbose logging",
+                "parameters": [
+                    {
+                        "description": "Duration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.VerboseLoggingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_VerboseLoggingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/experiments": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get experiments, newest first, optionally only those with a status (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List experiments",
                 "parameters": [
+                    {
+                        "enum": [
+                            "draft",
+                            "running",
+                            "stopped"
+                        ],
+                        "type": "string",
+                        "description": "Experiment status",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Review ID",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Define a draft experiment with its variants, whose weights add up to 100, and the percentage of subjects taking part. Keys are unique, deleted experiments included. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Define an experiment",
+                "parameters": [
+                    {
+                        "description": "Experiment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.ExperimentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ExperimentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/experiments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get an experiment with its variants by ID (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get an experiment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Experiment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.SuccessResponse"
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ExperimentResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Replace the settings and variants of a draft experiment. Experiments that started can't change, since that would move subjects between variants. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Update an experiment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Experiment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Experiment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.ExperimentRequest"
                         }
                     }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ExperimentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete an experiment, stopping it at once when it runs. Its key stays taken. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete an experiment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Experiment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/experiments/{id}/results": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Compare the variants of an experiment: the subjects exposed to each, those who converted after their first exposure, the conversion rate and the total conversion value. Conversions without an exposure are not counted. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get experiment results",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Experiment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count conversions reaching this goal",
+                        "name": "goal",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ExperimentResultsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/experiments/{id}/start": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Start assigning subjects to the variants of a draft experiment (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Start an experiment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Experiment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ExperimentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/experiments/{id}/stop": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Stop a running experiment. Subjects get no variant anymore and events are no longer recorded; the results are kept. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Stop an experiment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Experiment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ExperimentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/featured-products": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List every entry of the curated featured list in display order, including scheduled and expired ones (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List the featured list",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_FeaturedProductResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Add a product to the featured list at a position, optionally only between starts_at and ends_at (admin only). A product is featured at most once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Feature a product",
+                "parameters": [
+                    {
+                        "description": "Featured entry",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.FeaturedProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_FeaturedProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/featured-products/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Replace the product, position and schedule of a featured list entry (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Update a featured entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Featured entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Featured entry",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.FeaturedProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_FeaturedProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Remove an entry from the featured list (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Unfeature a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Featured entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/finance/reconciliation": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Reconcile the orders placed on every UTC day between two dates, inclusive, with the gift card and store credit ledgers that paid for them. Each day totals its orders, the gift card and store credit payments, the refunds of voided orders and what is left to settle outside the system. An order mismatches when a ledger's net for it differs from what its placement recorded, or from zero once its payment was voided. Payments of orders that were never placed are listed as unmatched. There is no payment provider yet, so what is due outside the system is reported, not checked. The CSV export has one row per order. Every run is recorded in the audit log. Admin only.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Payment reconciliation report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First UTC day (YYYY-MM-DD), defaults to to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last UTC day (YYYY-MM-DD), defaults to yesterday; at most 31 days after from",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ReconciliationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/gift-cards": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create a gift card with a random code and the given balance (admin only). Every issue is recorded in the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Issue a gift card",
+                "parameters": [
+                    {
+                        "description": "Gift card",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.IssueGiftCardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_GiftCardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/gift-cards/{code}/redeem": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Apply a gift card to an amount due outside checkout, such as an in-store purchase (admin only). The card covers as much as its balance allows and the rest is reported as still due. Every redemption is recorded in the card's ledger and the audit log. Checkout applies cards itself when a quote is accepted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Redeem a gift card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gift card code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount due",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.RedeemGiftCardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_GiftCardRedemptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/integrity": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Run every integrity check without changing anything: category links without a product or live category, reviews of deleted products, negative stock and wishlist entries of users that no longer exist. Each check comes with how its repair works, the number of broken records and the first 20 of them. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Check data integrity",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_IntegrityReportResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/integrity/repair": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Repair the records broken according to the given checks, or to every check when the body or checks is empty, by the rule each check reports. Recorded in the audit log. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Repair data integrity",
+                "parameters": [
+                    {
+                        "description": "Checks to repair",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.RepairIntegrityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_RepairIntegrityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/outbox": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the events recorded in the outbox with the changes they report, newest first, with their delivery status, attempts and last error. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List outbox events",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "published",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event topic, e.g. product.changed",
+                        "name": "topic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/outbox/reprocess": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Queue events for the relay again with a fresh set of attempts: the given ones whatever their status, or every failed one when ids is empty. Published events are delivered again, so consumers see them twice. Recorded in the audit log. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reprocess outbox events",
+                "parameters": [
+                    {
+                        "description": "Events to reprocess",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.ReprocessOutboxRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ReprocessOutboxResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/pick-lists": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List the products to pick for a batch of open orders, grouped by the warehouse location of each product in walking order, with products without a location last. Each line has the units not shipped yet across the orders and how they split between them. Open orders are placed orders with units left to ship; without quote IDs the oldest 100 are taken. Responds with JSON, or a printable PDF when the format is pdf. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Generate a pick list",
+                "parameters": [
+                    {
+                        "description": "Orders to pick and format",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.PickListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PickListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/price-lists": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List every B2B price list without its items (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List price lists",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_PriceListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create an empty B2B price list (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create a price list",
+                "parameters": [
+                    {
+                        "description": "Price list",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.PriceListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PriceListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/price-lists/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a B2B price list with the quantity breaks of every product in it (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a price list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Price list ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PriceListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Rename or redescribe a B2B price list (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Update a price list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Price list ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Price list",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.PriceListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PriceListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a B2B price list; the customers assigned to it pay list prices again (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete a price list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Price list ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/price-lists/{id}/products/{productId}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Replace the quantity breaks of a product in a B2B price list. Each break is a unit price from a minimum quantity on; quantities below the lowest break pay the list price. An empty list removes the product (admin only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Set product prices",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Price list ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Quantity breaks",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.SetProductPricesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/pricing-rules": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List every pricing rule in the order they are tried: highest priority first, then oldest first. Disabled, scheduled and ended rules are included. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List pricing rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_PricingRuleResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Add a rule lowering the price of the products matching its conditions, such as 10% off a category for a weekend, or a clearance price for products with more than min_stock units and no order in no_sales_days days. Rules apply to prices served to customers and to quotes when they are requested. When several rules match a product the one with the highest priority that lowers its price applies; a customer's price list wins when it is lower still. Recorded in the audit log. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create a pricing rule",
+                "parameters": [
+                    {
+                        "description": "Pricing rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.PricingRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PricingRuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/pricing-rules/simulate": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Price products at a time with the enabled rules, and again with a draft rule added, or replacing rule_id to preview an edit, without saving anything. rule_id without a rule previews deleting that rule. Without product_ids, up to 200 active products of the draft rule's category, or of the store, are priced. Price lists are left out. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Preview pricing rules",
+                "parameters": [
+                    {
+                        "description": "Draft rule and products to price",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.SimulatePricingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PricingSimulationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/pricing-rules/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a pricing rule by ID (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a pricing rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Pricing rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PricingRuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Replace the conditions, adjustment, priority and schedule of a pricing rule. Recorded in the audit log. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Update a pricing rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Pricing rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pricing rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.PricingRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PricingRuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a pricing rule, so prices no longer follow it. Quote items it priced keep naming it. Recorded in the audit log. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete a pricing rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Pricing rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/pricing-rules/{id}/applications": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the quote items a rule set the price of when their quote was requested, newest first, to audit what a rule was applied to. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List the quote items a pricing rule priced",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Pricing rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Scan the catalog for pairs of products that were likely entered twice, most likely first. Pairs are reported when their names share at least min_similarity of their trigrams, or when their SKUs match apart from case, spaces and punctuation. The score combines name similarity, a matching SKU and an identical price. The scan runs on every request. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List likely duplicate products",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "default": 0.6,
+                        "description": "Name similarity from 0.3 to 1 from which pairs are reported",
+                        "name": "min_similarity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products/{id}/market-prices": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Look up the prices competitors sell a product at, by its SKU, with every configured price comparison provider, cheapest first. Prices are cached per provider for MARKET_PRICE_CACHE_TTL; refresh=true asks the providers again. Calls stay within each provider's rate limit, and a provider that fails, is rate limited or is suspended after repeated failures is listed in errors while the others are still returned. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get competitor prices of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Bypass the cache",
+                        "name": "refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_MarketPricesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Consolidate a duplicate onto the product in the path, which survives, and delete the duplicate. Its reviews and wishlist entries move to the surviving product, except those of users who already reviewed or wished for it, which are deleted, and the surviving product joins its categories. The surviving product's rating is recomputed and a revision recorded. Recorded in the audit log. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Merge a duplicate product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Surviving product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Duplicate to merge",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.MergeProductsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_MergeProductsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products/{id}/slots": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Add up to 200 availability slots to a bookable product, each with the units it can be booked for. Slots must start in the future, end after they start and overlap neither each other nor the product's existing slots. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Add availability slots",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Slots",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.CreateSlotsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_SlotResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/promotions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get promotions, latest start first, optionally only those with a status (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List promotions",
+                "parameters": [
+                    {
+                        "enum": [
+                            "scheduled",
+                            "active",
+                            "ended"
+                        ],
+                        "type": "string",
+                        "description": "Promotion status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Schedule a discount off the list price of a product, or of every product in a category, between starts_at and ends_at. The scheduler starts and ends it; while active, product responses show the original and promotional price. A promotion overlapping another one of the same products in time is rejected with 409. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Schedule a promotion",
+                "parameters": [
+                    {
+                        "description": "Promotion",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.PromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/promotions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a promotion by ID (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Replace the scope, discount and schedule of a promotion that has not ended. The scheduler moves it to the status of its new schedule. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Update a promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promotion",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.PromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a promotion, ending it at once when it is active (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete a promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/purchase-orders": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a paginated list of purchase orders, newest first, to track open, partially received and received orders (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List purchase orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status (open, partial, received, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by supplier",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Order products from a supplier to restock them (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create a purchase order",
+                "parameters": [
+                    {
+                        "description": "Purchase order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.CreatePurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/purchase-orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a purchase order with the ordered and received quantity of each product (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a purchase order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/purchase-orders/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Close an open or partially received purchase order whose remaining items will not be delivered (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Cancel a purchase order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/purchase-orders/{id}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Add delivered quantities of a purchase order to stock, recorded as purchase_order stock movements referencing it. The order becomes partial until every item is received in full (admin only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Receive a delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delivered quantities",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.ReceivePurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/quotes": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a paginated list of every customer's quotes, newest first (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List quotes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status (requested, quoted, accepted, declined)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/quotes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get any customer's quote (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a quote",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_QuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/quotes/{id}/invoice": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Render the invoice of the order placed from any customer's quote as a PDF, as the customer gets it (admin only)",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Download an invoice",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF invoice",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/quotes/{id}/packing-slip": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Render the packing slip of the order placed from a quote as a PDF: the products and quantities to pack with their SKU, which to gift wrap and the gift messages to enclose. It shows no prices. Admin only.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Download a packing slip",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF packing slip",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/quotes/{id}/respond": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Offer a unit price for every product of a requested quote, valid until a date (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Respond to a quote",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Quoted prices",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.RespondToQuoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_QuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/quotes/{id}/shipments": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Record a parcel of the order placed from an accepted quote as handed to a carrier, and email the customer its tracking number with the order's tracking link. An order may ship in several parcels: list the products and quantities in this one, or omit the items to ship everything not shipped yet. Orders still being placed or cancelled can't ship. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Record a shipment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Carrier, tracking number and items",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.CreateShipmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ShipmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/rate-limits/{principal}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the quota usage of a principal in every route group it used in the current window. Principals are \"user:\u003cid\u003e\", \"ip:\u003caddress\u003e\" or \"key:\u003capi key name\u003e\". Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Inspect a principal's rate limit quota",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Principal, e.g. user:42",
+                        "name": "principal",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_RateLimitUsageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Clear the quota usage of a principal in every route group, e.g. after a client bug caused a burst. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reset a principal's rate limit quota",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Principal, e.g. user:42",
+                        "name": "principal",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/rebuilds": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the log of rebuild runs, newest first, with their progress and the error of those that failed. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List rebuild runs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Rebuild derived data in the background and return its run. search recomputes the rating summary of every product into a staging table, then swaps the summaries that changed in one transaction; cache recomputes the cached categories with their product counts and the hot products, overwriting them in place, then starts a new generation of cached listings. Live data keeps being served until the swap. One rebuild of each target runs at a time. Recorded in the audit log. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Start a rebuild",
+                "parameters": [
+                    {
+                        "description": "Target to rebuild",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.StartRebuildRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_RebuildRunResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/rebuilds/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a rebuild run with its progress, to follow a rebuild until it succeeds or fails. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a rebuild run",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rebuild run ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_RebuildRunResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List the predefined reports with their parameters. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List reports",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_ReportDefinitionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Run a predefined report in a read-only transaction and return up to 10000 rows as JSON or CSV. Report parameters are passed as query parameters named as in the report list; omitted ones take their defaults. Dates start at midnight in the request time zone. Every run is recorded in the audit log. Admin only.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Run a report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Report name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/retention": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List the entities retention rules can apply to, the maximum age configured for each and the statistics of its last cleanup run. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get data retention policies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-dto_RetentionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews/import": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Import up to 5000 reviews exported from another store platform, as a JSON array of rows or a CSV file whose header names the same columns (admin only). Each external user gets a placeholder account that cannot sign in, reused across imports from the same source. Reviews are flagged as imported and keep their original dates. Invalid rows, including reviews the placeholder already wrote, are listed in errors while the other rows are still imported. With dry_run nothing is saved.",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Import reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source platform, e.g. shopify",
+                        "name": "source",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the rows without saving them",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Reviews",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/product-management_internal_dto.ReviewImportRow"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ReviewImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/sagas": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the sagas placing orders, newest first, with the state of each step and why a saga is compensating or failed. Filter by reference, e.g. Q-12, to follow the order of a quote. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List sagas",
+                "parameters": [
+                    {
+                        "enum": [
+                            "running",
+                            "completed",
+                            "compensating",
+                            "compensated",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Saga status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "order_placement"
+                        ],
+                        "type": "string",
+                        "description": "Saga type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "What the saga acts on, e.g. Q-12",
+                        "name": "reference",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/sagas/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a saga with its state and the state of each step. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a saga",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_SagaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/sagas/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Resume undoing the completed steps of a saga whose compensation kept failing, with a fresh set of attempts, once its cause is fixed. Recorded in the audit log. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Retry a failed saga",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_SagaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/security/alerts": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the suspicious activity detected so far, newest first: many accounts failing to sign in from one IP, bulk product deletions and promotions to admin. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List security alerts",
+                "parameters": [
+                    {
+                        "enum": [
+                            "failed_logins",
+                            "bulk_deletion",
+                            "privilege_escalation"
+                        ],
+                        "type": "string",
+                        "description": "Alert kind",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/security/blocks": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the IP addresses blocked automatically after suspicious activity whose block has not expired. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List blocked IPs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_IPBlockResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/security/blocks/{ip}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lift the block of an IP address. Other instances may keep rejecting it for up to 30 seconds. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Unblock an IP",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IP address",
+                        "name": "ip",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/segments": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List every customer segment (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List segments",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_SegmentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Define a customer segment as rules that members must all match (admin only). Rules filter on role, wishlist_size, review_count, days_since_signup and days_since_last_login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create a segment",
+                "parameters": [
+                    {
+                        "description": "Segment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.SegmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_SegmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/segments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a customer segment by ID (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a segment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Segment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_SegmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Replace the name, description and rules of a customer segment (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Update a segment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Segment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Segment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.SegmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_SegmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a customer segment (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete a segment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Segment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/segments/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a paginated list of the users currently matching a segment's rules (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List segment members",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Segment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_types_UserListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/segments/{id}/notify": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Send a promotion to every member of a segment by email and push, in the background (admin only). Members who turned off promotions in their notification settings are skipped. Every send is recorded in the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Notify a segment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Segment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Notification",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.NotifySegmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_NotifySegmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/selftest": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Check that the instance can use the database, cache, storage, mail server, product search and locks, writing and deleting a probe where needed. Failed checks come with a hint of what to look at. Responds 503 when a check fails. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Check backends",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_SelfTestResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_SelfTestResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/settings": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List every store setting with its type, default and value in effect (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List store settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_SettingResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/settings/{key}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a store setting with its type, default and value in effect (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a store setting",
+                "parameters": [
+                    {
+                        "enum": [
+                            "store_name",
+                            "logo_url",
+                            "support_email",
+                            "currency",
+                            "order_number_prefix"
+                        ],
+                        "type": "string",
+                        "description": "Setting key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_SettingResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Set the value of a store setting, validated against its type: text up to 100 characters, an http or https URL, an email address, a supported currency code, or a prefix of up to 10 letters, digits and dashes. URLs and email addresses may be empty (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Set a store setting",
+                "parameters": [
+                    {
+                        "enum": [
+                            "store_name",
+                            "logo_url",
+                            "support_email",
+                            "currency",
+                            "order_number_prefix"
+                        ],
+                        "type": "string",
+                        "description": "Setting key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Value",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.UpdateSettingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_SettingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Remove the value set for a store setting, so its default applies again (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reset a store setting",
+                "parameters": [
+                    {
+                        "enum": [
+                            "store_name",
+                            "logo_url",
+                            "support_email",
+                            "currency",
+                            "order_number_prefix"
+                        ],
+                        "type": "string",
+                        "description": "Setting key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/shipments/{id}/packing-slip": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Render the packing slip of one parcel of an order as a PDF: only the products and quantities it holds, with the carrier and tracking number, which to gift wrap and the gift messages to enclose. It shows no prices. Admin only.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Download the packing slip of a shipment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shipment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF packing slip",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/slots/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete an availability slot nobody holds a booking of; cancel its bookings first (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete an availability slot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/storefront/export": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Write every active product, its images and the categories to storage as static JSON files under storefront/, and delete the files of products that are no longer active. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Regenerate the storefront bundle",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-dto_StorefrontExportResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/suppliers": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List every supplier (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List suppliers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_SupplierResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create a supplier to order stock from (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create a supplier",
+                "parameters": [
+                    {
+                        "description": "Supplier",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.SupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_SupplierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/suppliers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a supplier by ID (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_SupplierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Replace a supplier's details (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Update a supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Supplier",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.SupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_SupplierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a supplier that has no open or partially received purchase orders (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete a supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/test-tokens": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mint a short-lived access token restricted to the given scopes, to paste into Swagger UI for testing without real credentials. Scoped tokens are rejected on routes outside their scopes. Every mint is recorded in the audit log. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Mint a scoped test token",
+                "parameters": [
+                    {
+                        "description": "Scopes, role and lifetime of the token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.CreateTestTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_TestTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/export": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Stream the users matching the same search and role filters as the user list as a CSV file. Every export is recorded in the audit log. Admin only.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Export users as CSV",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by username or email",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by role (user/admin)",
+                        "name": "role",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV with columns id, username, email, role, last_login, created_at",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/anonymize": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Irreversibly erase a user's personal data to honor a right-to-be-forgotten request (admin only). The username and email are replaced with a random pseudonym, the full name, password, time zone, phone number, push token, known devices and quote notes are erased, and the account is soft-deleted. Reviews, wishlists and quotes keep referencing the same user ID. Soft-deleted users can be anonymized too. Every anonymization is recorded in the audit log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Anonymize a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_AnonymizedUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/api-key": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Make an integration API key act for a customer, so its webhooks cover the customer's orders and the products they ordered, or unlink the customer's key with a null api_key_name (admin only). A key acts for one customer at most.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Link an API key to a customer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "API key",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.LinkAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/price-list": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Make a customer pay the prices of a B2B price list, or list prices again with a null price_list_id (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Assign a price list to a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Price list",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.AssignPriceListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/sessions": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Revoke every access and refresh token of a user at once, so they must log in again everywhere (admin only). Every revocation is recorded in the audit log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Revoke a user's sessions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/store-credit": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Add store credit to a user's balance (admin only). Every grant is recorded in the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Grant store credit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credit",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.GrantStoreCreditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_StoreCreditEntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/form-token": {
+            "get": {
+                "description": "Issue the token to send as form_token with the register, login and review forms when their timing check is enabled. Fetch it when the form is shown: submissions within BOT_MIN_FORM_TIME of issuing, or after BOT_MAX_FORM_AGE, are rejected. Also returns the CAPTCHA site key when a provider is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get a form token",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_FormTokenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate user and return JWT tokens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_types_LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Revoke the access token of the request at once, and the refresh token in the body when given. With all_sessions, every access and refresh token of the user is revoked instead. The body is optional.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Logout user",
+                "parameters": [
+                    {
+                        "description": "Tokens to revoke",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get information of the currently logged-in user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get current user information",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update information of the currently logged-in user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Update user information",
+                "parameters": [
+                    {
+                        "description": "User update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/notification-settings": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the channels security alerts and order updates are delivered on for the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get notification settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_NotificationSettingsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Choose the channels (email, SMS, push) of each kind of notification and set the phone number and push token they are sent to. Omitted fields are unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Update notification settings",
+                "parameters": [
+                    {
+                        "description": "Notification settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.UpdateNotificationSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_NotificationSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update the password of the currently logged-in user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Update user password",
+                "parameters": [
+                    {
+                        "description": "Password update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.UpdatePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/referrals": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the current user's referral code, created on first use, and the users who signed up with it. The referrer earns store credit when a referred user completes their first order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get referrals",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ReferralsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and a new refresh token. Each refresh token can be exchanged once; exchanging one again revokes every token of the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh tokens",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_types_LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user with the provided information. Values of the registration form's custom fields are sent as custom_fields.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User registration details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.RegisterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/store-credit": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the store credit balance of the current user, applied automatically at payment time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get store credit balance",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_StoreCreditBalanceResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/store-credit/entries": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the store credit ledger of the current user, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List store credit entries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/unsubscribe": {
+            "get": {
+                "description": "Turn off the weekly wishlist digest or inventory report. The token comes from the unsubscribe link in the email, so no login is needed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Unsubscribe from digest emails",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unsubscribe token from the email link",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/users": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a paginated list of users with search and filter options",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (capped at the configured maximum)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by username or email",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by role (user/admin)",
+                        "name": "role",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_types_UserListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get information of a user by their ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get user information by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Soft delete a user (only admin can do this)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update the role of a user (only admin can do this)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Update user role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.UpdateUserRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a paginated list of the current user's bookings, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "List my bookings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status (confirmed, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Cancel one of the current user's bookings before its slot starts, freeing its units for others to book",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Cancel a booking",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_BookingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get all categories",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "List categories",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_CategoryResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create a new category with name and optional description. metadata_schema defines the metadata keys of its products, with their type and whether they are required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Create a new category",
+                "parameters": [
+                    {
+                        "description": "Category details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.CreateCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/batch": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get up to 100 categories by ID in one call, in the order requested. IDs that don't exist are listed in missing.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Get several categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated category IDs, e.g. 1,2,3",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_CategoryBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/distribution": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the distribution of products across categories",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Get category distribution",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_CategoryDistributionResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a category by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Get a category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update an existing category with name, optional description and metadata schema. Products already in the category are checked against a changed schema on their next edit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Update a category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.UpdateCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a category by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Delete a category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}/products": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get all products in a specific category. Customers with a price list also get their price and quantity breaks. Products not available in the caller's country are left out, except for admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Get category products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}/products/{productId}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Add a product to a specific category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Add product to category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Remove a product from a specific category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Remove product from category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/content/{key}": {
+            "get": {
+                "description": "Get a live content block, such as a homepage banner, by its key. No login is needed. Blocks outside their schedule are not found. The body is HTML as the admin wrote it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Get a content block",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Content block key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_PublicContentBlockResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/experiments/assignments": {
+            "get": {
+                "description": "Get the caller's variant in every running experiment they take part in, or in those with the given keys. Signed-in callers send their token and are assigned as the user; others send the anonymous ID their client keeps. The same caller always gets the same variant.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "experiments"
+                ],
+                "summary": "Get experiment assignments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Anonymous visitor ID, required when not signed in",
+                        "name": "anonymous_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated experiment keys",
+                        "name": "keys",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_ExperimentAssignment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/experiments/events": {
+            "post": {
+                "description": "Record that the caller was shown their variant of a running experiment (exposure), or reached a goal (conversion) with an optional value. The variant is assigned again on the server, so an event always counts toward the caller's own variant. Callers not taking part get 409.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "experiments"
+                ],
+                "summary": "Record an experiment event",
+                "parameters": [
+                    {
+                        "description": "Event",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.ExperimentEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ExperimentEventResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/forms/{name}/schema": {
+            "get": {
+                "description": "Get the custom fields of the registration or checkout form in display order, with their type and validation, so clients can render them as they see fit. No login is needed. Values are sent as custom_fields when registering or accepting a quote.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "forms"
+                ],
+                "summary": "Get the schema of a form",
+                "parameters": [
+                    {
+                        "enum": [
+                            "registration",
+                            "checkout"
+                        ],
+                        "type": "string",
+                        "description": "Form name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_FormSchemaResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/gift-cards/{code}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the remaining balance and expiry of a gift card. Codes are matched case-insensitively, with or without dashes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gift-cards"
+                ],
+                "summary": "Check a gift card balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gift card code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_GiftCardResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/integrations/webhooks": {
+            "get": {
+                "description": "List the webhook subscriptions of the calling integration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "summary": "List webhook subscriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Integration API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_WebhookSubscriptionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Subscribe an endpoint of the calling integration to events about the orders of the customer its key is linked to and the products that customer ordered, optionally limited to some of those products. The URL must be https and resolve to a public address. Deliveries are signed with the returned secret, which is not shown again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "summary": "Subscribe to events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Integration API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Subscription",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_CreateWebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/integrations/webhooks/{id}": {
+            "delete": {
+                "description": "Stop delivering events to a subscription of the calling integration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "summary": "Delete a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Integration API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/integrations/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Get the paginated delivery log of a subscription of the calling integration, newest first. Each retry of an event is a separate entry sharing its event_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Integration API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/track/{token}": {
+            "get": {
+                "description": "Get the status, items and shipments of an order. The token comes from the tracking link in the order emails, so no login is needed and the link can be shared with a gift recipient. Prices and the customer's details are never shown. An invalid token reads as an unknown order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Track an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tracking token from the order email link",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_OrderTrackingResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a paginated list of products with optional filters. Customers with a price list also get their price and quantity breaks. Products not available in the caller's country are left out, except for admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by category ID",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field (name, price, rating, created_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by statuses",
+                        "name": "statuses",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by a metadata value, e.g. meta.color=red; repeat for any of several values",
+                        "name": "meta.{key}",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ProductListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create a new product with categories",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Create a product",
+                "parameters": [
+                    {
+                        "description": "Product details",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.CreateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/batch": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get up to 100 products by ID in one call, in the order requested. IDs that don't exist or whose products are not available in the caller's country are listed in missing. Customers with a price list also get their prices and quantity breaks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get several products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated product IDs, e.g. 1,2,3",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ProductBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/featured": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the products curated for the storefront homepage, in display order. Only entries whose schedule covers the current time and active products sold in the caller's country are returned. Customers with a price list also get their prices and quantity breaks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get featured products",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_ProductResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/labels": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Render products as a print-ready A4 PDF: a spec sheet page per product with its name, price, barcode, description and key attributes, or 70 x 37 mm shelf labels with name, price and barcode, 24 per sheet. Products without an SKU are printed without a barcode. Prices are converted to the request currency and formatted for its language, and the print date is in its time zone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Print product labels",
+                "parameters": [
+                    {
+                        "description": "Products and template",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.ProductLabelsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/trending": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the products viewed the most over the last days, most viewed first. Only active products sold in the caller's country are returned. Rankings are cached for a few minutes. Customers with a price list also get their prices and quantity breaks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get trending products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 7,
+                        "description": "Views of the last days that count (1-30)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of products (1-50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/wishlist": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the user's wishlist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get wishlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.WishlistResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/wishlist/count": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the total number of wishlist items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get total wishlist count",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_WishlistCountResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/wishlist/{product_id}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Add a product to the user's wishlist if it's not already added",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Add to wishlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Remove a product from the user's wishlist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Remove from wishlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a product by its ID. Customers with a price list also get their price and quantity breaks. Products not available in the caller's country are not found, except for admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update an existing product. When change approval is enabled, edits by non-admins are stored as a pending change request instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product details to update",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.UpdateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ProductResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ProductChangeRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a product by its ID. While change approval is enabled, only admins can delete products, since deletions are not reviewed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/also-viewed": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the other products viewed over the last days by those who viewed a product, the ones with the most such viewers first. Viewers are signed-in users and anonymous IDs. Only active products sold in the caller's country are returned. Rankings are cached for a few minutes. Customers with a price list also get their prices and quantity breaks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get products also viewed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 7,
+                        "description": "Views of the last days that count (1-30)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of products (1-50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/availability": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the availability slots of a bookable product for a month, grouped by day in the request's time zone, with the units left to book in each slot and each day. Every day of the month is listed, including those without slots. Slots that already started have none left.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Get a product's availability calendar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Month as YYYY-MM, the current one by default",
+                        "name": "month",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_AvailabilityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/barcode": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Render the product's SKU as a Code 128 barcode or QR code for label printing. Rendered images are cached in storage until the SKU changes.",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get a product barcode",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "code128",
+                        "description": "Barcode format (code128, qr)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "png",
+                        "description": "Image type (png, svg)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 3,
+                        "description": "Pixels per module, 1 to 20",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/bookings": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Reserve units of an availability slot of a bookable product, 1 by default. Units are taken atomically, so a slot is never booked beyond its capacity. Slots that already started or don't have enough units left return 409.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Book a slot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Slot and quantity",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.CreateBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_BookingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the images of a product in display order, the first being the main one. Products not available in the caller's country are not found, except for admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List product images",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_ProductImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Upload a JPEG, PNG, WebP or GIF image of up to 10 MB as the image field of a multipart form. It is added after the product's other images, up to 20. The type is detected from the content. While change approval is enabled, only admins can change images.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Upload a product image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative text",
+                        "name": "alt",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ProductImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images/order": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Set the display order of a product's images. Every image of the product must be listed once; the first becomes the main image. While change approval is enabled, only admins can change images.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Reorder product images",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Image IDs in display order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.ProductImageOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-array_product-management_internal_dto_ProductImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images/{imageId}": {
+            "get": {
+                "description": "Redirect to a short-lived signed URL of a product image. This is the url of images in product responses when no CDN is configured, and works without login so it can be used in img tags. Products not available in the caller's country are not found.",
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get a product image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete an image of a product and its file. While change approval is enabled, only admins can change images.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete a product image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/metadata": {
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Change some metadata keys of a product as a JSON merge patch: keys set to null are removed, others are added or replaced, and keys left out are kept. The result is checked against the metadata schemas of the product's categories. When change approval is enabled, patches by non-admins are stored as a pending change request instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Patch product metadata",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Metadata keys to change, e.g. {\\",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ProductResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ProductChangeRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/price": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the unit price and total the current user pays for a quantity of a product, after pricing rules, their price list and quantity breaks, with the pricing rule that set the price",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Price a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantity (default: 1)",
+                        "name": "quantity",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ProductPriceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the revision history of a product, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List product revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/revisions/{rev}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Restore a product to the state recorded by a revision, except its stock quantity, which only moves through the stock ledger. The restore itself is recorded as a new revision, or submitted for approval when change approval is enabled.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Restore a product revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ProductResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ProductChangeRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/stock-adjustments": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Record a stock movement made by hand and move the product's stock by it: a sale outside the store's orders, a restock, a customer return or another adjustment. The movement is added to the stock ledger with its resulting quantity. Stock never goes below zero, so removing more than is left returns 409. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Adjust product stock",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Units and reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.AdjustStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_StockMovementResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/stock-history": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the ledger of stock movements for a product, newest first (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get product stock history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/view": {
+            "post": {
+                "description": "Record that a product page was viewed, for trending products, related products and conversion analytics. Signed-in callers send their token and are recorded as the viewer; others may send an anonymous ID their client keeps. The body is optional, and the referrer defaults to the Referer header. Products not available in the caller's country are not found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Record a product view",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Anonymous ID and referrer",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.ProductViewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a paginated list of the current user's quotes, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "List my quotes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status (requested, quoted, accepted, declined)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Ask for a custom price on active products in bulk quantities. An admin answers with prices valid until a date. Products not available in the caller's country can't be quoted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "Request a quote",
+                "parameters": [
+                    {
+                        "description": "Products and quantities",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.CreateQuoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_QuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get one of the current user's quotes with its status and quoted prices",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "Get my quote",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_QuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Accept the prices of one of the current user's quotes before it expires, placing it as an order: its quantities are reserved in stock, the gift card given as gift_card_code and then store credit pay for what they can, and the customer is notified. Values of the checkout form's custom fields are sent as custom_fields and stored on the quote. Products can be gift wrapped, at the gift_wrap_fee store setting per unit added to the total, given a gift message printed on the packing slip, or have their price left off the invoice.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "Accept a quote",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Checkout form values, gift options and gift card",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.AcceptQuoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_QuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes/{id}/decline": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Decline one of the current user's quotes, or withdraw it before it is priced",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "Decline a quote",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_QuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes/{id}/invoice": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Render the invoice of the order placed from one of the current user's quotes as a PDF: the items with their quoted prices and gift wrapping, and the totals. Items whose price the customer chose to hide are listed without it, and the totals are then left off too. Prices are converted to the request currency and formatted for its language, and dates are in its time zone.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "Download an invoice",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF invoice",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create a new review for a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Create a new review",
+                "parameters": [
+                    {
+                        "description": "Review data",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.CreateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Search reviews with pagination, product name filter, and sorting",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Search reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product name to filter by",
+                        "name": "product_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Field to sort by (created_at, rating)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order (asc, desc)",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.ReviewListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/count": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the total number of reviews for all products",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Get total review count",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_ReviewCountResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/user/{userId}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a paginated list of reviews written by a user. Reviews of inactive products are only visible to the user themselves or an admin; other callers get a trimmed response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "List a user's reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a review by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Get a review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_dto.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a review by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Delete a review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/storefront/bootstrap": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the store settings, categories, featured products, current user, cart count and wishlist count in one call. Sections are loaded concurrently; one that fails is null and its error is listed in errors, while the others are still returned. Featured products are the live curated featured products sold in the caller's country, or the highest-rated active ones while none are live, priced for the user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "storefront"
+                ],
+                "summary": "Load the storefront's first page",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-dto_StorefrontBootstrapResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sync/changes": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the changes to the catalog and to the current user's wishlist after a cursor, oldest first, for offline-capable clients to sync incrementally. Each record appears once, with its latest operation and its current state as the payload; products no longer available in the user's market come as deletions. Without a cursor, no changes are returned, only the cursor to sync from after a full refresh. Sync again with the returned cursor, right away while has_more is true. Changes from the last few seconds are held back until no earlier transaction can still commit. There are no carts to sync. Responds 410 when the changes after the cursor were removed by retention, in which case refresh fully and sync without a cursor.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Sync changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor returned by the previous sync",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_SyncChangesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/review-stats": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the review count, average rating given and rating distribution of a user. Reviews of inactive products only count for the user themselves or an admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Get a user's review statistics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.DataResponse-product-management_internal_dto_UserReviewStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/product-management_internal_types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "product-management_internal_dto.AcceptQuoteRequest": {
+            "type": "object",
+            "properties": {
+                "custom_fields": {
+                    "description": "Values of the checkout form's custom fields, see GET /forms/checkout/schema",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "gift_card_code": {
+                    "description": "Gift card paying for the order before store credit",
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "K7QM-2XRP-9TWD-HC4N"
+                },
+                "gifts": {
+                    "description": "Gift options of products of the quote, each listed at most once",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.GiftOptionRequest"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.ActivityPoint": {
+            "type": "object",
+            "properties": {
+                "client_errors": {
+                    "description": "4xx responses",
+                    "type": "integer"
+                },
+                "p50_ms": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "p95_ms": {
+                    "type": "number",
+                    "example": 80
+                },
+                "p99_ms": {
+                    "type": "number",
+                    "example": 240
+                },
+                "period": {
+                    "description": "Start of the period, in UTC",
+                    "type": "string"
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "server_errors": {
+                    "description": "5xx responses",
+                    "type": "integer"
+                }
+            }
+        },
+        "product-management_internal_dto.ActivityResponse": {
+            "type": "object",
+            "properties": {
+                "endpoints": {
+                    "description": "Busiest routes first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.EndpointActivity"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "p50_ms": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "p95_ms": {
+                    "type": "number",
+                    "example": 80
+                },
+                "p99_ms": {
+                    "type": "number",
+                    "example": 240
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "series": {
+                    "description": "Every period of the window, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ActivityPoint"
+                    }
+                },
+                "status_codes": {
+                    "description": "Requests per status code",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "product-management_internal_dto.AdjustStockRequest": {
+            "type": "object",
+            "required": [
+                "delta",
+                "reason"
+            ],
+            "properties": {
+                "delta": {
+                    "description": "Units added, or removed when negative",
+                    "type": "integer",
+                    "example": -2
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Sold at the Hanoi shop"
+                },
+                "reason": {
+                    "description": "sale removes and restock and return add units; adjustment does either",
+                    "type": "string",
+                    "enum": [
+                        "sale",
+                        "restock",
+                        "return",
+                        "adjustment"
+                    ],
+                    "example": "sale"
+                },
+                "reference": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "shop-receipt-881"
+                }
+            }
+        },
+        "product-management_internal_dto.AnonymizedUserResponse": {
+            "type": "object",
+            "properties": {
+                "anonymized_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "username": {
+                    "description": "Random pseudonym replacing the username and email",
+                    "type": "string",
+                    "example": "deleted-5f2c9a1b3e7d4086"
+                }
+            }
+        },
+        "product-management_internal_dto.AppliedPricingRule": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Weekend audio sale"
+                }
+            }
+        },
+        "product-management_internal_dto.AssignPriceListRequest": {
+            "type": "object",
+            "properties": {
+                "price_list_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "product-management_internal_dto.AvailabilityDayResponse": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "description": "Units left to book across the day's slots",
+                    "type": "integer",
+                    "example": 3
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-11-02"
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.SlotResponse"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.AvailabilityResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "description": "Every day of the month, including those without slots",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.AvailabilityDayResponse"
+                    }
+                },
+                "month": {
+                    "type": "string",
+                    "example": "2026-11"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Ho_Chi_Minh"
+                }
+            }
+        },
+        "product-management_internal_dto.BookingResponse": {
+            "type": "object",
+            "properties": {
+                "cancelled_at": {
+                    "type": "string",
+                    "example": "2026-10-20T08:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-10-16T08:00:00Z"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-11-02T10:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Kayak rental"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "slot_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-11-02T09:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "confirmed"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "product-management_internal_dto.CatalogDiffResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "$ref": "#/definitions/product-management_internal_dto.CategoryDiff"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "products": {
+                    "$ref": "#/definitions/product-management_internal_dto.ProductDiff"
+                },
+                "until": {
+                    "description": "Pass as from to get the changes made since",
+                    "type": "string",
+                    "example": "2021-01-02T00:00:00Z"
+                }
+            }
+        },
+        "product-management_internal_dto.CategoryAnalyticsItem": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "product_count": {
+                    "type": "integer"
+                },
+                "wishlist_adds": {
+                    "description": "Products of the category added to wishlists in the range",
+                    "type": "integer"
+                },
+                "wishlist_adds_per_product": {
+                    "description": "Wishlist adds relative to the category size",
+                    "type": "number"
+                }
+            }
+        },
+        "product-management_internal_dto.CategoryAnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "description": "Ordered by wishlist adds, most first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.CategoryAnalyticsItem"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "product-management_internal_dto.CategoryBatchResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Categories found, in the order requested",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.CategoryResponse"
+                    }
+                },
+                "missing": {
+                    "description": "Requested IDs that were not found",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        9
+                    ]
+                }
+            }
+        },
+        "product-management_internal_dto.CategoryDiff": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.CategoryDiffEntry"
+                    }
+                },
+                "deleted": {
+                    "description": "IDs of the categories deleted",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3
+                    ]
+                },
+                "updated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.CategoryDiffEntry"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.CategoryDiffEntry": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Fields changed, omitted for an update when no revision predates the time",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/product-management_internal_dto.FieldChange"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "snapshot": {
+                    "description": "Current state",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.CategorySnapshot"
+                        }
+                    ]
+                }
+            }
+        },
+        "product-management_internal_dto.CategoryDistributionResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "product_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "product-management_internal_dto.CategoryOutput": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Category ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "Category name",
+                    "type": "string",
+                    "example": "Electronics"
+                }
+            }
+        },
+        "product-management_internal_dto.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "metadata_schema": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.MetadataField"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "product_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "product-management_internal_dto.CategoryRevenueItem": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "units": {
+                    "type": "integer"
+                }
+            }
+        },
+        "product-management_internal_dto.CategorySnapshot": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "product-management_internal_dto.ConnectorResponse": {
+            "type": "object",
+            "properties": {
+                "interval": {
+                    "type": "string",
+                    "example": "1h0m0s"
+                },
+                "last_run": {
+                    "description": "Missing until it first syncs",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ConnectorSyncRunResponse"
+                        }
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "erp"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "rest",
+                        "sftp-csv"
+                    ],
+                    "example": "rest"
+                }
+            }
+        },
+        "product-management_internal_dto.ConnectorSyncRunResponse": {
+            "type": "object",
+            "properties": {
+                "connector": {
+                    "type": "string",
+                    "example": "erp"
+                },
+                "error": {
+                    "description": "Set when a step failed",
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:05Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "orders_pushed": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "orders_through": {
+                    "description": "Acceptance time of the last quote pushed as an order",
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "products_created": {
+                    "description": "Unknown SKUs, created as drafts",
+                    "type": "integer",
+                    "example": 2
+                },
+                "products_pulled": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "products_updated": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "skipped": {
+                    "description": "Pulled records without an SKU, invalid or for an unknown or deleted product",
+                    "type": "integer",
+                    "example": 1
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "running",
+                        "succeeded",
+                        "failed"
+                    ],
+                    "example": "succeeded"
+                },
+                "stock_pulled": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "stock_updated": {
+                    "type": "integer",
+                    "example": 37
+                },
+                "triggered_by": {
+                    "description": "Admin who started it, missing for scheduled runs",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "product-management_internal_dto.ContentBlockRequest": {
+            "type": "object",
+            "required": [
+                "key"
+            ],
+            "properties": {
+                "body": {
+                    "description": "HTML",
+                    "type": "string",
+                    "example": "\u003cp\u003eUp to 50% off sunglasses\u003c/p\u003e"
+                },
+                "ends_at": {
+                    "description": "Shown until removed when omitted",
+                    "type": "string",
+                    "example": "2025-07-01T00:00:00Z"
+                },
+                "image_url": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "https://cdn.example.com/banners/summer.jpg"
+                },
+                "key": {
+                    "description": "Lowercase letters, digits, dots, dashes and underscores",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "homepage-hero"
+                },
+                "starts_at": {
+                    "description": "Shown at once when omitted",
+                    "type": "string",
+                    "example": "2025-06-01T00:00:00Z"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Summer sale"
+                }
+            }
+        },
+        "product-management_internal_dto.ContentBlockResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "\u003cp\u003eUp to 50% off sunglasses\u003c/p\u003e"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-07-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/banners/summer.jpg"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "homepage-hero"
+                },
+                "live": {
+                    "description": "Whether its schedule covers the current time",
+                    "type": "boolean",
+                    "example": true
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-06-01T00:00:00Z"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Summer sale"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "updated_by": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "product-management_internal_dto.ConversionAnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "products": {
+                    "description": "Most viewed first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ProductConversionItem"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "product-management_internal_dto.CreateBookingRequest": {
+            "type": "object",
+            "required": [
+                "slot_id"
+            ],
+            "properties": {
+                "quantity": {
+                    "description": "1 when omitted",
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "slot_id": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "product-management_internal_dto.CreateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "metadata_schema": {
+                    "description": "Metadata keys of its products",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.MetadataField"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "product-management_internal_dto.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "categories",
+                "name",
+                "price",
+                "quantity"
+            ],
+            "properties": {
+                "allowed_countries": {
+                    "description": "Only markets the product is sold in, all when empty",
+                    "type": "array",
+                    "maxItems": 250,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "VN",
+                        "TH"
+                    ]
+                },
+                "blocked_countries": {
+                    "description": "Markets the product is withheld from",
+                    "type": "array",
+                    "maxItems": 250,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "US"
+                    ]
+                },
+                "bookable": {
+                    "description": "Reserved for availability slots, e.g. rentals and services",
+                    "type": "boolean",
+                    "example": false
+                },
+                "categories": {
+                    "description": "Category IDs",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "description": {
+                    "description": "Product description",
+                    "type": "string",
+                    "example": "Advanced smartwatch"
+                },
+                "location": {
+                    "description": "Warehouse location pick lists are grouped by",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "A-03-2"
+                },
+                "metadata": {
+                    "description": "Custom attributes, checked against the schemas of its categories",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "name": {
+                    "description": "Product name",
+                    "type": "string",
+                    "example": "SmartWatch Pro"
+                },
+                "price": {
+                    "description": "Product price",
+                    "type": "number",
+                    "example": 299.99
+                },
+                "quantity": {
+                    "description": "Stock quantity",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100
+                },
+                "sku": {
+                    "description": "Stock keeping unit",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "SW-PRO-BLK"
+                }
+            }
+        },
+        "product-management_internal_dto.CreatePurchaseOrderRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "supplier_id"
+            ],
+            "properties": {
+                "expected_at": {
+                    "type": "string",
+                    "example": "2021-01-15T00:00:00Z"
+                },
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.PurchaseOrderItemRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Spring restock"
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "product-management_internal_dto.CreateQuoteRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.QuoteItemRequest"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Delivery to our Berlin warehouse in March"
+                }
+            }
+        },
+        "product-management_internal_dto.CreateReviewRequest": {
+            "type": "object",
+            "required": [
+                "comment",
+                "product_id",
+                "rating"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 1
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                }
+            }
+        },
+        "product-management_internal_dto.CreateShipmentRequest": {
+            "type": "object",
+            "required": [
+                "carrier",
+                "tracking_number"
+            ],
+            "properties": {
+                "carrier": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "DHL"
+                },
+                "items": {
+                    "description": "Everything not shipped yet when omitted",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ShipmentItemRequest"
+                    }
+                },
+                "shipped_at": {
+                    "description": "Now when omitted",
+                    "type": "string",
+                    "example": "2021-01-04T00:00:00Z"
+                },
+                "tracking_number": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "JD014600006281234567"
+                },
+                "tracking_url": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "https://www.dhl.com/track?id=JD014600006281234567"
+                }
+            }
+        },
+        "product-management_internal_dto.CreateSlotsRequest": {
+            "type": "object",
+            "required": [
+                "slots"
+            ],
+            "properties": {
+                "slots": {
+                    "type": "array",
+                    "maxItems": 200,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.SlotRequest"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.CreateTestTokenRequest": {
+            "type": "object",
+            "required": [
+                "scopes"
+            ],
+            "properties": {
+                "role": {
+                    "description": "Defaults to user",
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "admin"
+                    ],
+                    "example": "user"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "catalog:read"
+                    ]
+                },
+                "ttl_minutes": {
+                    "description": "Defaults to 15 minutes",
+                    "type": "integer",
+                    "maximum": 60,
+                    "minimum": 1,
+                    "example": 15
+                }
+            }
+        },
+        "product-management_internal_dto.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "product_ids": {
+                    "description": "Limits product events to these products, which the key's customer must have ordered",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "url": {
+                    "description": "Public https endpoint",
+                    "type": "string",
+                    "example": "https://erp.example.com/hooks/catalog"
+                }
+            }
+        },
+        "product-management_internal_dto.CreateWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "whsec_4f1c..."
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://erp.example.com/hooks/catalog"
+                }
+            }
+        },
+        "product-management_internal_dto.CustomFieldRequest": {
+            "type": "object",
+            "required": [
+                "form",
+                "key",
+                "label",
+                "options",
+                "type"
+            ],
+            "properties": {
+                "form": {
+                    "type": "string",
+                    "enum": [
+                        "registration",
+                        "checkout"
+                    ],
+                    "example": "registration"
+                },
+                "help_text": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Only for business customers"
+                },
+                "key": {
+                    "description": "Lowercase letters, digits and underscores, starting with a letter",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "vat_number"
+                },
+                "label": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "VAT number"
+                },
+                "max": {
+                    "description": "Of number values",
+                    "type": "number",
+                    "example": 100
+                },
+                "max_length": {
+                    "description": "Of text values, 1000 when omitted",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1,
+                    "example": 14
+                },
+                "min": {
+                    "description": "Of number values",
+                    "type": "number",
+                    "example": 0
+                },
+                "min_length": {
+                    "description": "Of text values",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 0
+                },
+                "options": {
+                    "description": "Choices of select fields, required for them only",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pattern": {
+                    "description": "Regular expression text values must match",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "^[A-Z]{2}[0-9A-Z]{8,12}$"
+                },
+                "position": {
+                    "description": "Fields are listed in ascending position",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "number",
+                        "boolean",
+                        "select",
+                        "date",
+                        "email"
+                    ],
+                    "example": "text"
+                }
+            }
+        },
+        "product-management_internal_dto.CustomFieldResponse": {
+            "type": "object",
+            "properties": {
+                "form": {
+                    "type": "string",
+                    "example": "registration"
+                },
+                "help_text": {
+                    "type": "string",
+                    "example": "Only for business customers"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "key": {
+                    "type": "string",
+                    "example": "vat_number"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "VAT number"
+                },
+                "max": {
+                    "type": "number",
+                    "example": 100
+                },
+                "max_length": {
+                    "type": "integer",
+                    "example": 14
+                },
+                "min": {
+                    "type": "number",
+                    "example": 0
+                },
+                "min_length": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pattern": {
+                    "type": "string",
+                    "example": "^[A-Z]{2}[0-9A-Z]{8,12}$"
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "type": {
+                    "type": "string",
+                    "example": "text"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                }
+            }
+        },
+        "product-management_internal_dto.EndpointActivity": {
+            "type": "object",
+            "properties": {
+                "average_ms": {
+                    "type": "number"
+                },
+                "client_errors": {
+                    "type": "integer"
+                },
+                "max_ms": {
+                    "type": "number"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "GET"
+                },
+                "p50_ms": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "p95_ms": {
+                    "type": "number",
+                    "example": 80
+                },
+                "p99_ms": {
+                    "type": "number",
+                    "example": 240
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "route": {
+                    "type": "string",
+                    "example": "/api/v1/products/:id"
+                },
+                "server_errors": {
+                    "type": "integer"
+                },
+                "status_codes": {
+                    "description": "Requests per status code",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "volume": {
+                    "description": "Requests per period of the series, for heatmaps",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.ExperimentAssignment": {
+            "type": "object",
+            "properties": {
+                "experiment": {
+                    "type": "string",
+                    "example": "checkout_discount"
+                },
+                "variant": {
+                    "type": "string",
+                    "example": "discount_10"
+                }
+            }
+        },
+        "product-management_internal_dto.ExperimentEventRequest": {
+            "type": "object",
+            "required": [
+                "experiment",
+                "kind"
+            ],
+            "properties": {
+                "anonymous_id": {
+                    "description": "Identifies a visitor who is not signed in; ignored for signed-in users",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "3f2a9c1e-7b44-4d8e-9a51-0c6f2d8b1e77"
+                },
+                "experiment": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "checkout_discount"
+                },
+                "goal": {
+                    "description": "What a conversion reached",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "order"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "exposure",
+                        "conversion"
+                    ],
+                    "example": "conversion"
+                },
+                "value": {
+                    "description": "Value of a conversion, such as the order total",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 49.99
+                }
+            }
+        },
+        "product-management_internal_dto.ExperimentEventResponse": {
+            "type": "object",
+            "properties": {
+                "variant": {
+                    "description": "Variant the event was attributed to",
+                    "type": "string",
+                    "example": "discount_10"
+                }
+            }
+        },
+        "product-management_internal_dto.ExperimentRequest": {
+            "type": "object",
+            "required": [
+                "key",
+                "name",
+                "variants"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Does a 10% discount at checkout raise orders?"
+                },
+                "key": {
+                    "description": "Used by clients and server code to look the experiment up",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "checkout_discount"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Checkout discount"
+                },
+                "traffic": {
+                    "description": "Percentage of subjects taking part, 100 by default",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 100
+                },
+                "variants": {
+                    "description": "Weights add up to 100",
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ExperimentVariantRequest"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.ExperimentResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Does a 10% discount at checkout raise orders?"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "key": {
+                    "type": "string",
+                    "example": "checkout_discount"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Checkout discount"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "running",
+                        "stopped"
+                    ],
+                    "example": "running"
+                },
+                "stopped_at": {
+                    "type": "string",
+                    "example": "2025-02-01T00:00:00Z"
+                },
+                "traffic": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "updated_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ExperimentVariantResponse"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.ExperimentResultsResponse": {
+            "type": "object",
+            "properties": {
+                "experiment_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "goal": {
+                    "type": "string",
+                    "example": "order"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "checkout_discount"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "running",
+                        "stopped"
+                    ],
+                    "example": "running"
+                },
+                "variants": {
+                    "description": "In the order the variants were defined",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ExperimentVariantResult"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.ExperimentVariantRequest": {
+            "type": "object",
+            "required": [
+                "key"
+            ],
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "discount_10"
+                },
+                "weight": {
+                    "description": "Percentage of the subjects taking part assigned to it",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 50
+                }
+            }
+        },
+        "product-management_internal_dto.ExperimentVariantResponse": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "discount_10"
+                },
+                "weight": {
+                    "type": "integer",
+                    "example": 50
+                }
+            }
+        },
+        "product-management_internal_dto.ExperimentVariantResult": {
+            "type": "object",
+            "properties": {
+                "conversion_rate": {
+                    "description": "Converted subjects per exposed subject",
+                    "type": "number",
+                    "example": 0.07
+                },
+                "conversions": {
+                    "description": "Their conversions, repeat ones included",
+                    "type": "integer",
+                    "example": 97
+                },
+                "converted": {
+                    "description": "Exposed subjects who converted after their first exposure",
+                    "type": "integer",
+                    "example": 84
+                },
+                "exposed": {
+                    "description": "Subjects exposed to the variant",
+                    "type": "integer",
+                    "example": 1200
+                },
+                "value": {
+                    "description": "Total value of the conversions",
+                    "type": "number",
+                    "example": 4321.5
+                },
+                "variant": {
+                    "type": "string",
+                    "example": "discount_10"
+                },
+                "weight": {
+                    "type": "integer",
+                    "example": 50
+                }
+            }
+        },
+        "product-management_internal_dto.FeaturedProductRequest": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
+                "ends_at": {
+                    "description": "Shown until removed when omitted",
+                    "type": "string",
+                    "example": "2025-02-01T00:00:00Z"
+                },
+                "position": {
+                    "description": "Lower positions come first",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "starts_at": {
+                    "description": "Shown at once when omitted",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                }
+            }
+        },
+        "product-management_internal_dto.FeaturedProductResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-02-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "live": {
+                    "description": "Whether its schedule covers the current time",
+                    "type": "boolean",
+                    "example": true
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                }
+            }
+        },
+        "product-management_internal_dto.FieldChange": {
+            "type": "object",
+            "properties": {
+                "new": {},
+                "old": {}
+            }
+        },
+        "product-management_internal_dto.FormSchemaResponse": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.CustomFieldResponse"
+                    }
+                },
+                "form": {
+                    "type": "string",
+                    "example": "checkout"
+                }
+            }
+        },
+        "product-management_internal_dto.FormTokenResponse": {
+            "type": "object",
+            "properties": {
+                "captcha_site_key": {
+                    "description": "Set when a CAPTCHA provider is configured",
+                    "type": "string"
+                },
+                "form_token": {
+                    "description": "Sent back as form_token in the form body",
+                    "type": "string",
+                    "example": "1700000000000.3f2a9c"
+                }
+            }
+        },
+        "product-management_internal_dto.GiftCardRedemptionResponse": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "description": "Taken from the card",
+                    "type": "number",
+                    "example": 50
+                },
+                "balance": {
+                    "description": "Left on the card",
+                    "type": "number",
+                    "example": 0
+                },
+                "code": {
+                    "type": "string",
+                    "example": "K7QM-2XRP-9TWD-HC4N"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "POS-2024-0117"
+                },
+                "remaining_due": {
+                    "description": "Left to pay another way",
+                    "type": "number",
+                    "example": 30
+                }
+            }
+        },
+        "product-management_internal_dto.GiftCardResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number",
+                    "example": 32.5
+                },
+                "code": {
+                    "type": "string",
+                    "example": "K7QM-2XRP-9TWD-HC4N"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "expired": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "initial_balance": {
+                    "type": "number",
+                    "example": 50
+                }
+            }
+        },
+        "product-management_internal_dto.GiftOptionRequest": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
+                "gift_message": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Happy birthday, Anna!"
+                },
+                "gift_wrap": {
+                    "description": "Charged the gift_wrap_fee store setting per unit",
+                    "type": "boolean",
+                    "example": true
+                },
+                "hide_price": {
+                    "description": "Leave the price off the invoice, and with it the totals",
+                    "type": "boolean",
+                    "example": true
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "product-management_internal_dto.GrantStoreCreditRequest": {
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "product-management_internal_dto.IPBlockResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2021-01-01T01:00:00Z"
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "5 accounts failed to sign in from IP 203.0.113.7 within 15m0s"
+                }
+            }
+        },
+        "product-management_internal_dto.IntegrityCheckResponse": {
+            "type": "object",
+            "properties": {
+                "check": {
+                    "type": "string",
+                    "example": "negative_stock"
+                },
+                "count": {
+                    "description": "Broken records found",
+                    "type": "integer",
+                    "example": 2
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Products whose stock quantity is below zero"
+                },
+                "repair": {
+                    "description": "What repairing the check does",
+                    "type": "string",
+                    "example": "Set the stock to zero, recording an adjustment in the stock ledger"
+                },
+                "samples": {
+                    "description": "The first 20 of them",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "product 7 (stock -3)"
+                    ]
+                }
+            }
+        },
+        "product-management_internal_dto.IntegrityRepairResponse": {
+            "type": "object",
+            "properties": {
+                "check": {
+                    "type": "string",
+                    "example": "negative_stock"
+                },
+                "repaired": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "product-management_internal_dto.IntegrityReportResponse": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.IntegrityCheckResponse"
+                    }
+                },
+                "issues": {
+                    "description": "Broken records found by all checks",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "product-management_internal_dto.IssueGiftCardRequest": {
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "expires_at": {
+                    "description": "Never expires when omitted",
+                    "type": "string"
+                }
+            }
+        },
+        "product-management_internal_dto.LinkAPIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "api_key_name": {
+                    "description": "Name of the key in RATE_LIMIT_API_KEYS",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "acme-erp"
+                }
+            }
+        },
+        "product-management_internal_dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "password123"
+                }
+            }
+        },
+        "product-management_internal_dto.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "all_sessions": {
+                    "description": "Revoke every token of the user instead",
+                    "type": "boolean",
+                    "example": false
+                },
+                "refresh_token": {
+                    "description": "Revoked along with the access token",
+                    "type": "string"
+                }
+            }
+        },
+        "product-management_internal_dto.MarketPriceQuote": {
+            "type": "object",
+            "properties": {
+                "competitor": {
+                    "type": "string",
+                    "example": "Example Shop"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "difference_percent": {
+                    "description": "How much cheaper (negative) or dearer than our price, in percent; omitted for other currencies",
+                    "type": "number",
+                    "example": -5.5
+                },
+                "fetched_at": {
+                    "description": "When it was fetched from the provider",
+                    "type": "string",
+                    "example": "2025-01-01T00:05:00Z"
+                },
+                "in_stock": {
+                    "description": "Omitted when the provider doesn't tell",
+                    "type": "boolean",
+                    "example": true
+                },
+                "observed_at": {
+                    "description": "When the provider saw the price",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 94.5
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "compare"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://shop.example.com/p/123"
+                }
+            }
+        },
+        "product-management_internal_dto.MarketPricesResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Currency of our price",
+                    "type": "string",
+                    "example": "USD"
+                },
+                "errors": {
+                    "description": "Providers that failed, with their errors",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "lowest": {
+                    "description": "Cheapest quote in our currency",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.MarketPriceQuote"
+                        }
+                    ]
+                },
+                "price": {
+                    "description": "Our list price",
+                    "type": "number",
+                    "example": 99.99
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "quotes": {
+                    "description": "Cheapest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.MarketPriceQuote"
+                    }
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "ACME-1001"
+                }
+            }
+        },
+        "product-management_internal_dto.MergeProductsRequest": {
+            "type": "object",
+            "required": [
+                "duplicate_id"
+            ],
+            "properties": {
+                "duplicate_id": {
+                    "description": "Product deleted once its reviews, wishlist entries and categories are moved",
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "product-management_internal_dto.MergeProductsResponse": {
+            "type": "object",
+            "properties": {
+                "categories_added": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "duplicate_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "reviews_dropped": {
+                    "description": "By users who had reviewed the surviving product too",
+                    "type": "integer",
+                    "example": 1
+                },
+                "reviews_moved": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "wishlists_dropped": {
+                    "description": "Of users who had both products in their wishlist",
+                    "type": "integer",
+                    "example": 2
+                },
+                "wishlists_moved": {
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "product-management_internal_dto.MetadataField": {
+            "type": "object",
+            "required": [
+                "key",
+                "type"
+            ],
+            "properties": {
+                "key": {
+                    "description": "Metadata key",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "color"
+                },
+                "required": {
+                    "description": "Whether products of the category must have it",
+                    "type": "boolean",
+                    "example": true
+                },
+                "type": {
+                    "description": "Type of its values",
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "number",
+                        "boolean"
+                    ],
+                    "example": "string"
+                }
+            }
+        },
+        "product-management_internal_dto.NotificationChannels": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "boolean"
+                },
+                "push": {
+                    "type": "boolean"
+                },
+                "sms": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_dto.NotificationSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "has_push_token": {
+                    "type": "boolean"
+                },
+                "order_updates": {
+                    "$ref": "#/definitions/product-management_internal_dto.NotificationChannels"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "promotions": {
+                    "type": "boolean"
+                },
+                "security_alerts": {
+                    "$ref": "#/definitions/product-management_internal_dto.NotificationChannels"
+                },
+                "weekly_digest": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_dto.NotifySegmentRequest": {
+            "type": "object",
+            "required": [
+                "message",
+                "title"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Everything on your wishlist is 20% off this week."
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Spring sale"
+                }
+            }
+        },
+        "product-management_internal_dto.NotifySegmentResponse": {
+            "type": "object",
+            "properties": {
+                "recipients": {
+                    "description": "Segment members at the time of sending, before opt-outs",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "product-management_internal_dto.OrderTrackingResponse": {
+            "type": "object",
+            "properties": {
+                "item_count": {
+                    "description": "Units across all items",
+                    "type": "integer",
+                    "example": 2
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.TrackedItemResponse"
+                    }
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "ORD-12"
+                },
+                "placed_at": {
+                    "type": "string",
+                    "example": "2021-01-03T00:00:00Z"
+                },
+                "shipments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ShipmentResponse"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "processing",
+                        "confirmed",
+                        "shipped",
+                        "cancelled"
+                    ],
+                    "example": "shipped"
+                }
+            }
+        },
+        "product-management_internal_dto.PickLineResponse": {
+            "type": "object",
+            "properties": {
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.PickOrderQuantity"
+                    }
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "SmartWatch Pro"
+                },
+                "quantity": {
+                    "description": "Units not shipped yet, across the orders",
+                    "type": "integer",
+                    "example": 3
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SW-PRO-BLK"
+                }
+            }
+        },
+        "product-management_internal_dto.PickListOrderResponse": {
+            "type": "object",
+            "properties": {
+                "order_number": {
+                    "type": "string",
+                    "example": "ORD-12"
+                },
+                "quote_id": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "product-management_internal_dto.PickListRequest": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "description": "json by default",
+                    "type": "string",
+                    "enum": [
+                        "json",
+                        "pdf"
+                    ],
+                    "example": "json"
+                },
+                "quote_ids": {
+                    "description": "Open orders to pick, by quote ID; the oldest 100 when omitted",
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        12,
+                        14
+                    ]
+                }
+            }
+        },
+        "product-management_internal_dto.PickListResponse": {
+            "type": "object",
+            "properties": {
+                "generated_at": {
+                    "type": "string",
+                    "example": "2021-01-04T00:00:00Z"
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.PickLocationResponse"
+                    }
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.PickListOrderResponse"
+                    }
+                },
+                "units": {
+                    "description": "Units to pick across every location",
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "product-management_internal_dto.PickLocationResponse": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.PickLineResponse"
+                    }
+                },
+                "location": {
+                    "description": "Empty for products without a location",
+                    "type": "string",
+                    "example": "A-03-2"
+                }
+            }
+        },
+        "product-management_internal_dto.PickOrderQuantity": {
+            "type": "object",
+            "properties": {
+                "order_number": {
+                    "type": "string",
+                    "example": "ORD-12"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "product-management_internal_dto.PriceBreak": {
+            "type": "object",
+            "required": [
+                "min_quantity",
+                "price"
+            ],
+            "properties": {
+                "min_quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 10
+                },
+                "price": {
+                    "type": "number",
+                    "example": 249.99
+                }
+            }
+        },
+        "product-management_internal_dto.PriceListItemResponse": {
+            "type": "object",
+            "properties": {
+                "min_quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "price": {
+                    "type": "number",
+                    "example": 249.99
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "product-management_internal_dto.PriceListRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Resellers with yearly contracts"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Wholesale tier A"
+                }
+            }
+        },
+        "product-management_internal_dto.PriceListResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Resellers with yearly contracts"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.PriceListItemResponse"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Wholesale tier A"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                }
+            }
+        },
+        "product-management_internal_dto.PricingRuleRequest": {
+            "type": "object",
+            "required": [
+                "adjustment",
+                "name",
+                "value"
+            ],
+            "properties": {
+                "adjustment": {
+                    "type": "string",
+                    "enum": [
+                        "percent_off",
+                        "amount_off",
+                        "fixed_price"
+                    ],
+                    "example": "percent_off"
+                },
+                "category_id": {
+                    "description": "Only products in the category",
+                    "type": "integer",
+                    "example": 3
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "10% off headphones this weekend"
+                },
+                "enabled": {
+                    "description": "Enabled when omitted",
+                    "type": "boolean",
+                    "example": true
+                },
+                "ends_at": {
+                    "description": "Applies until removed when omitted",
+                    "type": "string",
+                    "example": "2025-06-09T00:00:00Z"
+                },
+                "min_stock": {
+                    "description": "Only products with more units in stock",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 50
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Weekend audio sale"
+                },
+                "no_sales_days": {
+                    "description": "Only products listed and not ordered for that many days",
+                    "type": "integer",
+                    "maximum": 3650,
+                    "minimum": 1,
+                    "example": 60
+                },
+                "priority": {
+                    "description": "Higher wins when several rules match a product",
+                    "type": "integer",
+                    "example": 10
+                },
+                "starts_at": {
+                    "description": "Applies at once when omitted",
+                    "type": "string",
+                    "example": "2025-06-07T00:00:00Z"
+                },
+                "value": {
+                    "description": "Percentage, amount or price, depending on the adjustment",
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "product-management_internal_dto.PricingRuleResponse": {
+            "type": "object",
+            "properties": {
+                "adjustment": {
+                    "type": "string",
+                    "enum": [
+                        "percent_off",
+                        "amount_off",
+                        "fixed_price"
+                    ],
+                    "example": "percent_off"
+                },
+                "category_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "10% off headphones this weekend"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-06-09T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "live": {
+                    "description": "Whether it is enabled and its schedule covers the current time",
+                    "type": "boolean",
+                    "example": false
+                },
+                "min_stock": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Weekend audio sale"
+                },
+                "no_sales_days": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-06-07T00:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "updated_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "value": {
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "product-management_internal_dto.PricingSimulationItem": {
+            "type": "object",
+            "properties": {
+                "current_price": {
+                    "description": "With the rules as they are",
+                    "type": "number",
+                    "example": 199.99
+                },
+                "current_rule": {
+                    "$ref": "#/definitions/product-management_internal_dto.AppliedPricingRule"
+                },
+                "list_price": {
+                    "type": "number",
+                    "example": 199.99
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Studio Headphones"
+                },
+                "simulated_price": {
+                    "description": "With the draft rule",
+                    "type": "number",
+                    "example": 179.99
+                },
+                "simulated_rule": {
+                    "$ref": "#/definitions/product-management_internal_dto.AppliedPricingRule"
+                }
+            }
+        },
+        "product-management_internal_dto.PricingSimulationResponse": {
+            "type": "object",
+            "properties": {
+                "affected": {
+                    "description": "Products whose price the draft rule changes",
+                    "type": "integer",
+                    "example": 1
+                },
+                "at": {
+                    "type": "string",
+                    "example": "2025-06-08T12:00:00Z"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.PricingSimulationItem"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.ProductBatchResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Products found, in the order requested",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ProductResponse"
+                    }
+                },
+                "missing": {
+                    "description": "Requested IDs that were not found",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        4,
+                        7
+                    ]
+                }
+            }
+        },
+        "product-management_internal_dto.ProductChangeRequestResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/product-management_internal_dto.FieldChange"
+                    }
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "requested_by": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "review_note": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "reviewed_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "rejected"
+                    ],
+                    "example": "pending"
+                }
+            }
+        },
+        "product-management_internal_dto.ProductConversionItem": {
+            "type": "object",
+            "properties": {
+                "conversion_rate": {
+                    "description": "Orders per view",
+                    "type": "number"
+                },
+                "orders": {
+                    "description": "Quotes accepted for the product",
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "unique_viewers": {
+                    "description": "Distinct users and anonymous IDs; views with neither count toward none",
+                    "type": "integer"
+                },
+                "units": {
+                    "type": "integer"
+                },
+                "views": {
+                    "type": "integer"
+                },
+                "wishlist_adds": {
+                    "type": "integer"
+                },
+                "wishlist_rate": {
+                    "description": "Wishlist adds per view",
+                    "type": "number"
+                }
+            }
+        },
+        "product-management_internal_dto.ProductDiff": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ProductDiffEntry"
+                    }
+                },
+                "deleted": {
+                    "description": "IDs of the products deleted",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        4,
+                        9
+                    ]
+                },
+                "updated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ProductDiffEntry"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.ProductDiffEntry": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Fields changed, omitted for an update when no revision predates the time",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/product-management_internal_dto.FieldChange"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "snapshot": {
+                    "description": "Current state",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ProductSnapshot"
+                        }
+                    ]
+                }
+            }
+        },
+        "product-management_internal_dto.ProductImageOrderRequest": {
+            "type": "object",
+            "required": [
+                "image_ids"
+            ],
+            "properties": {
+                "image_ids": {
+                    "description": "Every image ID of the product, in the new display order",
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        7,
+                        3,
+                        5
+                    ]
+                }
+            }
+        },
+        "product-management_internal_dto.ProductImageResponse": {
+            "type": "object",
+            "properties": {
+                "alt": {
+                    "description": "Alternative text",
+                    "type": "string",
+                    "example": "SmartWatch Pro, front view"
+                },
+                "content_type": {
+                    "description": "Image type",
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "created_at": {
+                    "description": "Upload time",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "id": {
+                    "description": "Image ID",
+                    "type": "integer",
+                    "example": 7
+                },
+                "position": {
+                    "description": "Display position, ascending",
+                    "type": "integer",
+                    "example": 0
+                },
+                "size": {
+                    "description": "File size in bytes",
+                    "type": "integer",
+                    "example": 184320
+                },
+                "url": {
+                    "description": "Where the image can be fetched",
+                    "type": "string",
+                    "example": "/api/v1/products/1/images/7"
+                }
+            }
+        },
+        "product-management_internal_dto.ProductLabelsRequest": {
+            "type": "object",
+            "required": [
+                "product_ids",
+                "template"
+            ],
+            "properties": {
+                "barcode": {
+                    "description": "Barcode format for products with an SKU, code128 by default",
+                    "type": "string",
+                    "enum": [
+                        "code128",
+                        "qr"
+                    ],
+                    "example": "code128"
+                },
+                "product_ids": {
+                    "description": "Products in print order",
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "template": {
+                    "description": "spec_sheet (a page per product) or shelf_label (70 x 37 mm, 24 per A4 sheet)",
+                    "type": "string",
+                    "enum": [
+                        "spec_sheet",
+                        "shelf_label"
+                    ],
+                    "example": "shelf_label"
+                }
+            }
+        },
+        "product-management_internal_dto.ProductPriceResponse": {
+            "type": "object",
+            "properties": {
+                "list_price": {
+                    "type": "number",
+                    "example": 299.99
+                },
+                "pricing_rule": {
+                    "description": "Rule that set unit_price, if any",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.AppliedPricingRule"
+                        }
+                    ]
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "total": {
+                    "type": "number",
+                    "example": 2499.9
+                },
+                "unit_price": {
+                    "description": "After pricing rules, the customer's price list and quantity breaks",
+                    "type": "number",
+                    "example": 249.99
+                }
+            }
+        },
+        "product-management_internal_dto.ProductPromotion": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-12-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Black Friday audio"
+                },
+                "original_price": {
+                    "type": "number",
+                    "example": 299.99
+                },
+                "promotional_price": {
+                    "type": "number",
+                    "example": 239.99
+                }
+            }
+        },
+        "product-management_internal_dto.ProductResponse": {
+            "type": "object",
+            "properties": {
+                "allowed_countries": {
+                    "description": "Only markets the product is sold in, all when empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "VN",
+                        "TH"
+                    ]
+                },
+                "blocked_countries": {
+                    "description": "Markets the product is withheld from",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "US"
+                    ]
+                },
+                "bookable": {
+                    "description": "Reserved for availability slots rather than sold from stock",
+                    "type": "boolean",
+                    "example": false
+                },
+                "categories": {
+                    "description": "Associated categories",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.CategoryOutput"
+                    }
+                },
+                "created_at": {
+                    "description": "Creation time",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "customer_price": {
+                    "description": "Unit price after promotions, pricing rules and the current user's price list",
+                    "type": "number",
+                    "example": 279.99
+                },
+                "description": {
+                    "description": "Product description",
+                    "type": "string",
+                    "example": "Advanced smartwatch"
+                },
+                "id": {
+                    "description": "Product ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "images": {
+                    "description": "Images in display order, the first being the main one",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ProductImageResponse"
+                    }
+                },
+                "location": {
+                    "description": "Warehouse location",
+                    "type": "string",
+                    "example": "A-03-2"
+                },
+                "metadata": {
+                    "description": "Custom attributes",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "name": {
+                    "description": "Product name",
+                    "type": "string",
+                    "example": "SmartWatch Pro"
+                },
+                "price": {
+                    "description": "Product price",
+                    "type": "number",
+                    "example": 299.99
+                },
+                "price_breaks": {
+                    "description": "Quantity breaks from the current user's price list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.PriceBreak"
+                    }
+                },
+                "pricing_rule": {
+                    "description": "Rule that set customer_price, if any",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.AppliedPricingRule"
+                        }
+                    ]
+                },
+                "promotion": {
+                    "description": "Active promotion, with the original and promotional price",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ProductPromotion"
+                        }
+                    ]
+                },
+                "quantity": {
+                    "description": "Stock quantity",
+                    "type": "integer",
+                    "example": 100
+                },
+                "rating_average": {
+                    "description": "Average review rating",
+                    "type": "number",
+                    "example": 4.5
+                },
+                "rating_count": {
+                    "description": "Number of reviews",
+                    "type": "integer",
+                    "example": 12
+                },
+                "sku": {
+                    "description": "Stock keeping unit",
+                    "type": "string",
+                    "example": "SW-PRO-BLK"
+                },
+                "status": {
+                    "description": "Product status",
+                    "type": "string",
+                    "example": "active"
+                },
+                "updated_at": {
+                    "description": "Last update time",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                }
+            }
+        },
+        "product-management_internal_dto.ProductSnapshot": {
+            "type": "object",
+            "properties": {
+                "allowed_countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "blocked_countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "bookable": {
+                    "type": "boolean"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "stock_quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "product-management_internal_dto.ProductViewRequest": {
+            "type": "object",
+            "properties": {
+                "anonymous_id": {
+                    "description": "Identifies a visitor who is not signed in, generated and kept by the client",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "3f2a9c1e-7b44-4d8e-9a51-0c6f2d8b1e77"
+                },
+                "referrer": {
+                    "description": "Page the visitor came from, the Referer header by default",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "https://example.com/search?q=lamp"
+                }
+            }
+        },
+        "product-management_internal_dto.PromotionRequest": {
+            "type": "object",
+            "required": [
+                "discount",
+                "name",
+                "value"
+            ],
+            "properties": {
+                "category_id": {
+                    "description": "Discounts every product in this category",
+                    "type": "integer",
+                    "example": 3
+                },
+                "discount": {
+                    "type": "string",
+                    "enum": [
+                        "percent_off",
+                        "amount_off"
+                    ],
+                    "example": "percent_off"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-12-01T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Black Friday audio"
+                },
+                "product_id": {
+                    "description": "Discounts this product",
+                    "type": "integer",
+                    "example": 1
+                },
+                "starts_at": {
+                    "description": "At once when omitted",
+                    "type": "string",
+                    "example": "2025-11-28T00:00:00Z"
+                },
+                "value": {
+                    "description": "Percentage or amount off the list price",
+                    "type": "number",
+                    "example": 20
+                }
+            }
+        },
+        "product-management_internal_dto.PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "discount": {
+                    "type": "string",
+                    "enum": [
+                        "percent_off",
+                        "amount_off"
+                    ],
+                    "example": "percent_off"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-12-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Black Friday audio"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-11-28T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "active",
+                        "ended"
+                    ],
+                    "example": "scheduled"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "updated_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "value": {
+                    "type": "number",
+                    "example": 20
+                }
+            }
+        },
+        "product-management_internal_dto.PublicContentBlockResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "\u003cp\u003eUp to 50% off sunglasses\u003c/p\u003e"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/banners/summer.jpg"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "homepage-hero"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Summer sale"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                }
+            }
+        },
+        "product-management_internal_dto.PurchaseOrderItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 500
+                },
+                "unit_cost": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 120.5
+                }
+            }
+        },
+        "product-management_internal_dto.PurchaseOrderItemResponse": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "SmartWatch Pro"
+                },
+                "quantity_ordered": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "quantity_received": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "unit_cost": {
+                    "type": "number",
+                    "example": 120.5
+                }
+            }
+        },
+        "product-management_internal_dto.PurchaseOrderResponse": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string",
+                    "example": "2021-01-20T00:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "expected_at": {
+                    "type": "string",
+                    "example": "2021-01-15T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.PurchaseOrderItemResponse"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Spring restock"
+                },
+                "reference": {
+                    "description": "Reference of its stock movements",
+                    "type": "string",
+                    "example": "PO-1"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "partial",
+                        "received",
+                        "cancelled"
+                    ],
+                    "example": "partial"
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "supplier_name": {
+                    "type": "string",
+                    "example": "Acme Components"
+                },
+                "total_cost": {
+                    "type": "number",
+                    "example": 60250
+                }
+            }
+        },
+        "product-management_internal_dto.QuoteItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 250
+                }
+            }
+        },
+        "product-management_internal_dto.QuoteItemResponse": {
+            "type": "object",
+            "properties": {
+                "gift_message": {
+                    "type": "string",
+                    "example": "Happy birthday, Anna!"
+                },
+                "gift_wrap": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "gift_wrap_fee": {
+                    "description": "For wrapping every unit, set when accepted",
+                    "type": "number",
+                    "example": 0
+                },
+                "hide_price": {
+                    "description": "Left off the invoice",
+                    "type": "boolean",
+                    "example": false
+                },
+                "pricing_rule_id": {
+                    "description": "Pricing rule that set unit_price, if any",
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "SmartWatch Pro"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "quoted_price": {
+                    "description": "Unit price offered, once quoted",
+                    "type": "number",
+                    "example": 239.5
+                },
+                "unit_price": {
+                    "description": "Price without the quote when it was requested",
+                    "type": "number",
+                    "example": 299.99
+                }
+            }
+        },
+        "product-management_internal_dto.QuoteResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "custom_fields": {
+                    "description": "Values of the checkout form's custom fields",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "decided_at": {
+                    "type": "string",
+                    "example": "2021-01-03T00:00:00Z"
+                },
+                "expired": {
+                    "description": "Quoted but past valid_until, so it can no longer be accepted",
+                    "type": "boolean",
+                    "example": false
+                },
+                "gift_wrap_fees": {
+                    "description": "Set when accepted",
+                    "type": "number",
+                    "example": 0
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.QuoteItemResponse"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Delivery to our Berlin warehouse in March"
+                },
+                "responded_at": {
+                    "type": "string",
+                    "example": "2021-01-02T00:00:00Z"
+                },
+                "response_note": {
+                    "type": "string",
+                    "example": "Includes free shipping"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "requested",
+                        "quoted",
+                        "accepted",
+                        "declined"
+                    ],
+                    "example": "quoted"
+                },
+                "subtotal": {
+                    "description": "Quoted price of the items, zero until quoted",
+                    "type": "number",
+                    "example": 59875
+                },
+                "total": {
+                    "description": "Quoted total with gift wrapping, zero until quoted",
+                    "type": "number",
+                    "example": 59875
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "valid_until": {
+                    "type": "string",
+                    "example": "2021-02-01T00:00:00Z"
+                }
+            }
+        },
+        "product-management_internal_dto.QuotedPriceRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "unit_price"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "unit_price": {
+                    "type": "number",
+                    "example": 239.5
+                }
+            }
+        },
+        "product-management_internal_dto.RateLimitGroupUsage": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string",
+                    "example": "products"
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 300
+                },
+                "remaining": {
+                    "type": "integer",
+                    "example": 258
+                },
+                "reset_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:01:00Z"
+                },
+                "tier": {
+                    "type": "string",
+                    "enum": [
+                        "anonymous",
+                        "user",
+                        "vendor",
+                        "admin",
+                        "api_key"
+                    ],
+                    "example": "user"
+                },
+                "used": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "product-management_internal_dto.RateLimitUsageResponse": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "description": "Groups used in the current window, by name",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.RateLimitGroupUsage"
+                    }
+                },
+                "principal": {
+                    "type": "string",
+                    "example": "user:42"
+                }
+            }
+        },
+        "product-management_internal_dto.RatingDropResponse": {
+            "type": "object",
+            "properties": {
+                "current_average": {
+                    "type": "number"
+                },
+                "current_count": {
+                    "type": "integer"
+                },
+                "drop": {
+                    "type": "number"
+                },
+                "previous_average": {
+                    "type": "number"
+                },
+                "previous_count": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "product-management_internal_dto.RebuildRunResponse": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "description": "Rating summaries that differed from the live ones when swapped in",
+                    "type": "integer",
+                    "example": 12
+                },
+                "error": {
+                    "description": "Set when the run failed",
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:05Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "processed": {
+                    "description": "Items rebuilt so far",
+                    "type": "integer",
+                    "example": 4500
+                },
+                "progress": {
+                    "description": "Percentage of the items rebuilt",
+                    "type": "integer",
+                    "example": 37
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "running",
+                        "succeeded",
+                        "failed"
+                    ],
+                    "example": "running"
+                },
+                "target": {
+                    "type": "string",
+                    "enum": [
+                        "search",
+                        "cache"
+                    ],
+                    "example": "search"
+                },
+                "total": {
+                    "description": "Items to rebuild, 0 until counted",
+                    "type": "integer",
+                    "example": 12000
+                },
+                "triggered_by": {
+                    "description": "Admin who started it, missing for runs of the admin command",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "product-management_internal_dto.ReceivePurchaseOrderRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ReceivedItemRequest"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Two pallets, one damaged box returned"
+                }
+            }
+        },
+        "product-management_internal_dto.ReceivedItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 200
+                }
+            }
+        },
+        "product-management_internal_dto.ReconciliationDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2024-01-16"
+                },
+                "external_due": {
+                    "description": "Left to settle outside the system for orders charged and not voided",
+                    "type": "number",
+                    "example": 1285.5
+                },
+                "gift_card_payments": {
+                    "description": "Redeemed from gift cards",
+                    "type": "number",
+                    "example": 150
+                },
+                "mismatches": {
+                    "description": "Orders whose ledgers disagree with what they recorded",
+                    "type": "integer",
+                    "example": 0
+                },
+                "order_total": {
+                    "type": "number",
+                    "example": 1480.5
+                },
+                "orders": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "refunds": {
+                    "description": "Gift card restores and store credit refunds of voided orders",
+                    "type": "number",
+                    "example": 20
+                },
+                "store_credit_payments": {
+                    "description": "Spent from store credit",
+                    "type": "number",
+                    "example": 45
+                }
+            }
+        },
+        "product-management_internal_dto.ReconciliationOrder": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2024-01-16"
+                },
+                "external_due": {
+                    "description": "Left to settle outside the system",
+                    "type": "number",
+                    "example": 50
+                },
+                "gift_card_applied": {
+                    "description": "Recorded by the order",
+                    "type": "number",
+                    "example": 50
+                },
+                "gift_card_ledger": {
+                    "description": "Net of the gift card ledger",
+                    "type": "number",
+                    "example": 50
+                },
+                "mismatch": {
+                    "type": "string",
+                    "example": "store credit ledger 20.00, order recorded 0.00"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "ORD-42"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "Q-42"
+                },
+                "status": {
+                    "description": "Of the order placement saga",
+                    "type": "string",
+                    "enum": [
+                        "running",
+                        "completed",
+                        "compensating",
+                        "compensated",
+                        "failed"
+                    ],
+                    "example": "completed"
+                },
+                "store_credit_applied": {
+                    "description": "Recorded by the order",
+                    "type": "number",
+                    "example": 20
+                },
+                "store_credit_ledger": {
+                    "description": "Net of the store credit ledger",
+                    "type": "number",
+                    "example": 20
+                },
+                "total": {
+                    "type": "number",
+                    "example": 120
+                }
+            }
+        },
+        "product-management_internal_dto.ReconciliationResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "description": "Every day of the range, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ReconciliationDay"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-16"
+                },
+                "orders": {
+                    "description": "Orders placed in the range, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ReconciliationOrder"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-01-16"
+                },
+                "unmatched": {
+                    "description": "Payments and refunds of orders without a placement",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.UnmatchedPaymentResponse"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.RedeemGiftCardRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "reference"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 80
+                },
+                "reference": {
+                    "description": "What the card pays for, e.g. an in-store receipt",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "POS-2024-0117"
+                }
+            }
+        },
+        "product-management_internal_dto.ReferralResponse": {
+            "type": "object",
+            "properties": {
+                "joined_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "rewarded": {
+                    "description": "Whether their first order earned the reward",
+                    "type": "boolean",
+                    "example": true
+                },
+                "username": {
+                    "type": "string",
+                    "example": "janedoe"
+                }
+            }
+        },
+        "product-management_internal_dto.ReferralsResponse": {
+            "type": "object",
+            "properties": {
+                "referral_code": {
+                    "type": "string",
+                    "example": "K7QM2XRP"
+                },
+                "referrals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ReferralResponse"
+                    }
+                },
+                "reward_amount": {
+                    "description": "Store credit earned per referred first order",
+                    "type": "number",
+                    "example": 10
+                },
+                "total_rewarded": {
+                    "type": "number",
+                    "example": 20
+                }
+            }
+        },
+        "product-management_internal_dto.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "product-management_internal_dto.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "confirm_password",
+                "email",
+                "full_name",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "confirm_password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "custom_fields": {
+                    "description": "Values of the registration form's custom fields, see GET /forms/registration/schema",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "password123"
+                },
+                "referral_code": {
+                    "type": "string",
+                    "maxLength": 12,
+                    "example": "K7QM2XRP"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "admin"
+                    ],
+                    "example": "user"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3,
+                    "example": "johndoe"
+                }
+            }
+        },
+        "product-management_internal_dto.RegisterResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "user registered successfully"
+                },
+                "user": {
+                    "$ref": "#/definitions/product-management_internal_dto.UserOutput"
+                }
+            }
+        },
+        "product-management_internal_dto.ReloadConfigResponse": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "description": "Variables whose values changed",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "LOG_LEVEL",
+                        "MAINTENANCE_MODE"
+                    ]
+                },
+                "config": {
+                    "$ref": "#/definitions/product-management_internal_dto.RuntimeConfigResponse"
+                }
+            }
+        },
+        "product-management_internal_dto.RepairIntegrityRequest": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "description": "Checks to repair, all of them when empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "negative_stock"
+                    ]
+                }
+            }
+        },
+        "product-management_internal_dto.RepairIntegrityResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.IntegrityRepairResponse"
+                    }
+                },
+                "repaired": {
+                    "description": "Records repaired by all checks",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "product-management_internal_dto.ReportDefinitionResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "params": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ReportParamResponse"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.ReportParamResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "int",
+                        "date"
+                    ]
+                }
+            }
+        },
+        "product-management_internal_dto.ReportResponse": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rows": {
+                    "description": "Values in the order of columns",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {}
+                    }
+                },
+                "truncated": {
+                    "description": "Whether rows beyond the row limit were left out",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_dto.ReprocessOutboxRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "description": "Events to publish again whatever their status; every failed event when empty",
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        12,
+                        13
+                    ]
+                }
+            }
+        },
+        "product-management_internal_dto.ReprocessOutboxResponse": {
+            "type": "object",
+            "properties": {
+                "queued": {
+                    "description": "Events queued for the relay",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "product-management_internal_dto.RespondToQuoteRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.QuotedPriceRequest"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Includes free shipping"
+                },
+                "valid_until": {
+                    "type": "string",
+                    "example": "2021-02-01T00:00:00Z"
+                }
+            }
+        },
+        "product-management_internal_dto.RetentionPolicyResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Audit log entries, by when they were recorded"
+                },
+                "enabled": {
+                    "description": "Whether RETENTION_RULES sets a maximum age",
+                    "type": "boolean",
+                    "example": true
+                },
+                "entity": {
+                    "type": "string",
+                    "example": "audit_logs"
+                },
+                "last_run": {
+                    "description": "Missing until the rule first runs",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.RetentionRunResponse"
+                        }
+                    ]
+                },
+                "max_age": {
+                    "description": "Records older than this are deleted",
+                    "type": "string",
+                    "example": "17520h0m0s"
+                },
+                "max_age_days": {
+                    "description": "MaxAge in days",
+                    "type": "number",
+                    "example": 730
+                }
+            }
+        },
+        "product-management_internal_dto.RetentionResponse": {
+            "type": "object",
+            "properties": {
+                "interval": {
+                    "type": "string",
+                    "example": "24h0m0s"
+                },
+                "policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.RetentionPolicyResponse"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.RetentionRunResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "description": "Records deleted by the last run",
+                    "type": "integer",
+                    "example": 120
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "error": {
+                    "description": "Set when the last run failed",
+                    "type": "string"
+                },
+                "ran_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "total_deleted": {
+                    "description": "Records deleted by every run so far",
+                    "type": "integer",
+                    "example": 4800
+                }
+            }
+        },
+        "product-management_internal_dto.ReviewAnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer"
+                },
+                "dropping_products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.RatingDropResponse"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "volume": {
+                    "description": "Review count and average rating per period, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ReviewVolumePoint"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.ReviewChangeRequestRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Price confirmed with supplier"
+                }
+            }
+        },
+        "product-management_internal_dto.ReviewCountResponse": {
+            "type": "object",
+            "properties": {
+                "my_review_count": {
+                    "type": "integer"
+                },
+                "total_reviews": {
+                    "type": "integer"
+                }
+            }
+        },
+        "product-management_internal_dto.ReviewImportError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "rating must be between 1 and 5"
+                },
+                "row": {
+                    "description": "Position of the row in the file, starting at 1 and not counting the CSV header",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "product-management_internal_dto.ReviewImportResponse": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ReviewImportError"
+                    }
+                },
+                "imported": {
+                    "description": "Reviews created, or that would be on a dry run",
+                    "type": "integer",
+                    "example": 118
+                },
+                "rows": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "source": {
+                    "type": "string",
+                    "example": "shopify"
+                },
+                "users_created": {
+                    "type": "integer",
+                    "example": 95
+                }
+            }
+        },
+        "product-management_internal_dto.ReviewImportRow": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Fits perfectly"
+                },
+                "created_at": {
+                    "description": "When the review was written, RFC 3339 or YYYY-MM-DD; now when omitted",
+                    "type": "string",
+                    "example": "2023-04-01T12:00:00Z"
+                },
+                "external_user_id": {
+                    "description": "Reviewer's ID on the source platform",
+                    "type": "string",
+                    "example": "customer-8812"
+                },
+                "product_id": {
+                    "description": "Product reviewed; sku may be given instead",
+                    "type": "integer",
+                    "example": 1
+                },
+                "rating": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "sku": {
+                    "description": "Product reviewed, when product_id is not given",
+                    "type": "string",
+                    "example": "TSHIRT-RED-M"
+                },
+                "user_name": {
+                    "description": "Reviewer's display name on the source platform",
+                    "type": "string",
+                    "example": "Jane D."
+                }
+            }
+        },
+        "product-management_internal_dto.ReviewListResponse": {
+            "type": "object",
+            "properties": {
+                "default_page_size": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ReviewResponse"
+                    }
+                },
+                "max_page_size": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "product-management_internal_dto.ReviewResponse": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "imported": {
+                    "description": "Migrated from another store platform",
+                    "type": "boolean"
+                },
+                "product": {
+                    "$ref": "#/definitions/product-management_internal_dto.ProductResponse"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "Platform an imported review came from",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/product-management_internal_dto.UserOutput"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "product-management_internal_dto.ReviewVolumePoint": {
+            "type": "object",
+            "properties": {
+                "average_rating": {
+                    "type": "number"
+                },
+                "period": {
+                    "description": "Start of the period in the requested time zone, serialized in UTC",
+                    "type": "string"
+                },
+                "review_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "product-management_internal_dto.RuntimeConfigAPIKey": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 5000
+                },
+                "name": {
+                    "type": "string",
+                    "example": "partner"
+                }
+            }
+        },
+        "product-management_internal_dto.RuntimeConfigResponse": {
+            "type": "object",
+            "properties": {
+                "feature_flags": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "loaded_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "log_level": {
+                    "type": "string",
+                    "enum": [
+                        "panic",
+                        "fatal",
+                        "error",
+                        "warning",
+                        "info",
+                        "debug",
+                        "trace"
+                    ],
+                    "example": "info"
+                },
+                "maintenance_message": {
+                    "type": "string",
+                    "example": "The service is down for maintenance, please try again later"
+                },
+                "maintenance_mode": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "rate_limit_api_keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.RuntimeConfigAPIKey"
+                    }
+                },
+                "rate_limit_groups": {
+                    "description": "Per route group overrides, keyed \"group.tier\"",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "rate_limit_tiers": {
+                    "description": "Requests per window for each tier",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "source": {
+                    "description": "CONFIG_FILE it was read from",
+                    "type": "string",
+                    "example": "/etc/product-management/runtime.env"
+                }
+            }
+        },
+        "product-management_internal_dto.SagaResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Failed attempts at the current step or compensation",
+                    "type": "integer",
+                    "example": 0
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "data": {
+                    "description": "JSON state the steps read and write",
+                    "type": "string",
+                    "example": "{\"quote_id\":12,\"user_id\":4,\"total\":90,\"quantities\":{\"7\":3}}"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "reserve_stock: not enough stock: Widget has 2 left"
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:01Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "next_attempt_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "reference": {
+                    "description": "What the saga acts on",
+                    "type": "string",
+                    "example": "Q-12"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "running",
+                        "completed",
+                        "compensating",
+                        "compensated",
+                        "failed"
+                    ],
+                    "example": "compensated"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.SagaStepResponse"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "order_placement"
+                    ],
+                    "example": "order_placement"
+                }
+            }
+        },
+        "product-management_internal_dto.SagaStepResponse": {
+            "type": "object",
+            "properties": {
+                "compensated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:01Z"
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "error": {
+                    "description": "Last failure of the step or its compensation",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "reserve_stock"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "completed",
+                        "failed",
+                        "compensated"
+                    ],
+                    "example": "failed"
+                }
+            }
+        },
+        "product-management_internal_dto.SalesAnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "accepted_quotes": {
+                    "type": "integer"
+                },
+                "categories": {
+                    "description": "Categories with sales, highest revenue first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.CategoryRevenueItem"
+                    }
+                },
+                "days": {
+                    "description": "Days with sales, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.SalesDayPoint"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "source": {
+                    "description": "Whether the figures come from the live tables or the reporting views",
+                    "type": "string",
+                    "enum": [
+                        "live",
+                        "materialized"
+                    ]
+                },
+                "to": {
+                    "type": "string"
+                },
+                "units": {
+                    "type": "integer"
+                }
+            }
+        },
+        "product-management_internal_dto.SalesDayPoint": {
+            "type": "object",
+            "properties": {
+                "accepted_quotes": {
+                    "type": "integer"
+                },
+                "day": {
+                    "type": "string"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "units": {
+                    "type": "integer"
+                }
+            }
+        },
+        "product-management_internal_dto.SegmentRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "rules"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Users with five or more wishlisted products"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Engaged wishlisters"
+                },
+                "rules": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.SegmentRule"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.SegmentResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Users with five or more wishlisted products"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Engaged wishlisters"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.SegmentRule"
+                    }
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                }
+            }
+        },
+        "product-management_internal_dto.SegmentRule": {
+            "type": "object",
+            "required": [
+                "field",
+                "operator",
+                "value"
+            ],
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "enum": [
+                        "role",
+                        "wishlist_size",
+                        "review_count",
+                        "days_since_signup",
+                        "days_since_last_login"
+                    ],
+                    "example": "wishlist_size"
+                },
+                "operator": {
+                    "type": "string",
+                    "enum": [
+                        "eq",
+                        "ne",
+                        "gt",
+                        "gte",
+                        "lt",
+                        "lte"
+                    ],
+                    "example": "gte"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "5"
+                }
+            }
+        },
+        "product-management_internal_dto.SelfTestCheck": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "error": {
+                    "description": "Set when the check failed",
+                    "type": "string"
+                },
+                "hint": {
+                    "description": "What to look at when the check failed",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "enum": [
+                        "database",
+                        "cache",
+                        "storage",
+                        "mail",
+                        "search",
+                        "locks"
+                    ],
+                    "example": "database"
+                },
+                "ok": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "product-management_internal_dto.SelfTestResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.SelfTestCheck"
+                    }
+                },
+                "ok": {
+                    "description": "Whether every check passed",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "product-management_internal_dto.SetProductPricesRequest": {
+            "type": "object",
+            "properties": {
+                "breaks": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.PriceBreak"
+                    }
+                }
+            }
+        },
+        "product-management_internal_dto.SettingResponse": {
+            "type": "object",
+            "properties": {
+                "custom": {
+                    "description": "Whether an admin set a value",
+                    "type": "boolean",
+                    "example": true
+                },
+                "default": {
+                    "type": "string",
+                    "example": "Product Management"
+                },
+                "key": {
+                    "type": "string",
+                    "enum": [
+                        "store_name",
+                        "logo_url",
+                        "support_email",
+                        "currency",
+                        "order_number_prefix",
+                        "gift_wrap_fee"
+                    ],
+                    "example": "store_name"
+                },
+                "type": {
+                    "description": "What values are accepted",
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "url",
+                        "email",
+                        "currency",
+                        "prefix",
+                        "amount"
+                    ],
+                    "example": "text"
+                },
+                "updated_at": {
+                    "description": "When the value was set",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "updated_by": {
+                    "description": "Admin who set the value",
+                    "type": "integer",
+                    "example": 1
+                },
+                "value": {
+                    "description": "In effect: the value set, otherwise the default",
+                    "type": "string",
+                    "example": "Acme Store"
+                }
+            }
+        },
+        "product-management_internal_dto.ShipmentItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                }
+            }
+        },
+        "product-management_internal_dto.ShipmentResponse": {
+            "type": "object",
+            "properties": {
+                "carrier": {
+                    "type": "string",
+                    "example": "DHL"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.TrackedItemResponse"
+                    }
+                },
+                "shipped_at": {
+                    "type": "string",
+                    "example": "2021-01-04T00:00:00Z"
+                },
+                "tracking_number": {
+                    "type": "string",
+                    "example": "JD014600006281234567"
+                },
+                "tracking_url": {
+                    "type": "string",
+                    "example": "https://www.dhl.com/track?id=JD014600006281234567"
+                }
+            }
+        },
+        "product-management_internal_dto.SimulatePricingRequest": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "description": "Now when omitted",
+                    "type": "string",
+                    "example": "2025-06-08T12:00:00Z"
+                },
+                "product_ids": {
+                    "description": "Active products of the rule's category, or of the store, when omitted",
+                    "type": "array",
+                    "maxItems": 200,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "rule": {
+                    "$ref": "#/definitions/product-management_internal_dto.PricingRuleRequest"
+                },
+                "rule_id": {
+                    "description": "Existing rule the draft replaces, to preview an edit",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "product-management_internal_dto.SlotRequest": {
+            "type": "object",
+            "required": [
+                "capacity",
+                "ends_at",
+                "starts_at"
+            ],
+            "properties": {
+                "capacity": {
+                    "description": "Units that can be booked, e.g. 1 for a single rental item",
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-11-02T10:00:00Z"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-11-02T09:00:00Z"
+                }
+            }
+        },
+        "product-management_internal_dto.SlotResponse": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "description": "Units left to book, none once the slot started",
+                    "type": "integer",
+                    "example": 1
+                },
+                "capacity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-11-02T10:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-11-02T09:00:00Z"
+                }
+            }
+        },
+        "product-management_internal_dto.StartRebuildRequest": {
+            "type": "object",
+            "required": [
+                "target"
+            ],
+            "properties": {
+                "target": {
+                    "type": "string",
+                    "enum": [
+                        "search",
+                        "cache"
+                    ],
+                    "example": "search"
+                }
+            }
+        },
+        "product-management_internal_dto.StockMovementResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "delta": {
+                    "type": "integer",
+                    "example": -2
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "example": "product update"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "order-1001"
+                },
+                "resulting_quantity": {
+                    "type": "integer",
+                    "example": 98
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "order",
+                        "sale",
+                        "restock",
+                        "return",
+                        "adjustment",
+                        "import",
+                        "purchase_order",
+                        "sync"
+                    ],
+                    "example": "adjustment"
+                }
+            }
+        },
+        "product-management_internal_dto.StoreCreditBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number",
+                    "example": 15
+                }
+            }
+        },
+        "product-management_internal_dto.StoreCreditEntryResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "delta": {
+                    "type": "number",
+                    "example": 15
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "example": "delayed delivery"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "order-1001"
+                },
+                "resulting_balance": {
+                    "type": "number",
+                    "example": 15
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "grant",
+                        "payment",
+                        "refund",
+                        "referral"
+                    ],
+                    "example": "grant"
+                }
+            }
+        },
+        "product-management_internal_dto.StoreSettings": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Currency the storefront shows prices in",
+                    "type": "string",
+                    "example": "USD"
+                },
+                "gift_wrap_fee": {
+                    "description": "Charged per unit gift wrapped, in the base currency",
+                    "type": "number",
+                    "example": 4.5
+                },
+                "logo_url": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/logo.png"
+                },
+                "order_number_prefix": {
+                    "type": "string",
+                    "example": "ORD-"
+                },
+                "store_name": {
+                    "type": "string",
+                    "example": "Acme Store"
+                },
+                "support_email": {
+                    "type": "string",
+                    "example": "support@example.com"
+                }
+            }
+        },
+        "product-management_internal_dto.StorefrontBootstrapResponse": {
+            "type": "object",
+            "properties": {
+                "cart_count": {
+                    "description": "Items in the user's cart",
+                    "type": "integer",
+                    "example": 0
+                },
+                "categories": {
+                    "description": "Every category with its product count",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.CategoryResponse"
+                    }
+                },
+                "errors": {
+                    "description": "Sections that failed to load, with their errors",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "featured_products": {
+                    "description": "Highest-rated active products sold in the caller's country",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ProductResponse"
+                    }
+                },
+                "settings": {
+                    "description": "Store name, logo, support email, currency and order number prefix",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.StoreSettings"
+                        }
+                    ]
+                },
+                "user": {
+                    "description": "The signed-in user",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.StorefrontUserSummary"
+                        }
+                    ]
+                },
+                "wishlist_count": {
+                    "description": "Products in the user's wishlist",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "product-management_internal_dto.StorefrontExportResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "description": "Categories exported",
+                    "type": "integer",
+                    "example": 8
+                },
+                "generated_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "prefix": {
+                    "description": "Storage key prefix of the bundle",
+                    "type": "string",
+                    "example": "storefront/"
+                },
+                "products": {
+                    "description": "Product files written",
+                    "type": "integer",
+                    "example": 120
+                },
+                "removed": {
+                    "description": "Product files deleted because their products are no longer active",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "product-management_internal_dto.StorefrontUserSummary": {
+            "type": "object",
+            "properties": {
+                "full_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "role": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "john"
+                }
+            }
+        },
+        "product-management_internal_dto.SupplierRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "contact_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Jane Doe"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "orders@acme.example"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Acme Components"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Ships on Tuesdays"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "example": "+14155550123"
+                }
+            }
+        },
+        "product-management_internal_dto.SupplierResponse": {
+            "type": "object",
+            "properties": {
+                "contact_name": {
+                    "type": "string",
+                    "example": "Jane Doe"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "orders@acme.example"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Acme Components"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Ships on Tuesdays"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+14155550123"
+                }
+            }
+        },
+        "product-management_internal_dto.SyncCategory": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Phones, watches and accessories"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Electronics"
+                }
+            }
+        },
+        "product-management_internal_dto.SyncChangeResponse": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "description": "Cursor to sync from once this change is applied",
+                    "type": "string",
+                    "example": "1040"
+                },
+                "entity_id": {
+                    "description": "Product, category, or product of a wishlist item",
+                    "type": "integer",
+                    "example": 7
+                },
+                "entity_type": {
+                    "type": "string",
+                    "enum": [
+                        "product",
+                        "category",
+                        "wishlist"
+                    ],
+                    "example": "product"
+                },
+                "operation": {
+                    "type": "string",
+                    "enum": [
+                        "upsert",
+                        "delete"
+                    ],
+                    "example": "upsert"
+                },
+                "payload": {
+                    "description": "Current state of the record, absent when deleted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.SyncPayload"
+                        }
+                    ]
+                }
+            }
+        },
+        "product-management_internal_dto.SyncChangesResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Latest change of each record, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.SyncChangeResponse"
+                    }
+                },
+                "cursor": {
+                    "description": "Cursor to sync from next",
+                    "type": "string",
+                    "example": "1042"
+                },
+                "has_more": {
+                    "description": "Whether to sync again right away for more changes",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "product-management_internal_dto.SyncPayload": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/product-management_internal_dto.SyncCategory"
+                },
+                "product": {
+                    "$ref": "#/definitions/product-management_internal_dto.ProductResponse"
+                },
+                "wishlist_item": {
+                    "$ref": "#/definitions/product-management_internal_dto.WishlistItemResponse"
+                }
+            }
+        },
+        "product-management_internal_dto.TestTokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:15:00Z"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "catalog:read"
+                    ]
+                }
+            }
+        },
+        "product-management_internal_dto.TrackedItemResponse": {
+            "type": "object",
+            "properties": {
+                "product_name": {
+                    "type": "string",
+                    "example": "SmartWatch Pro"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "product-management_internal_dto.UnmatchedPaymentResponse": {
+            "type": "object",
+            "properties": {
+                "first_at": {
+                    "type": "string",
+                    "example": "2024-01-16T09:30:00Z"
+                },
+                "ledger": {
+                    "type": "string",
+                    "enum": [
+                        "store_credit",
+                        "gift_card"
+                    ],
+                    "example": "store_credit"
+                },
+                "net": {
+                    "description": "Paid minus given back",
+                    "type": "number",
+                    "example": 15
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "Q-17"
+                }
+            }
+        },
+        "product-management_internal_dto.UpdateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "metadata_schema": {
+                    "description": "Metadata keys of its products",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.MetadataField"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "product-management_internal_dto.UpdateNotificationSettingsRequest": {
+            "type": "object",
+            "properties": {
+                "order_updates": {
+                    "$ref": "#/definitions/product-management_internal_dto.NotificationChannels"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "promotions": {
+                    "type": "boolean"
+                },
+                "push_token": {
+                    "type": "string"
+                },
+                "security_alerts": {
+                    "$ref": "#/definitions/product-management_internal_dto.NotificationChannels"
+                },
+                "weekly_digest": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_dto.UpdatePasswordRequest": {
+            "type": "object",
+            "required": [
+                "confirm_new_password",
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "confirm_new_password": {
+                    "type": "string"
+                },
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "product-management_internal_dto.UpdateProductRequest": {
+            "type": "object",
+            "required": [
+                "categories",
+                "name",
+                "price",
+                "quantity",
+                "status"
+            ],
+            "properties": {
+                "allowed_countries": {
+                    "description": "Only markets the product is sold in, all when empty",
+                    "type": "array",
+                    "maxItems": 250,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "VN",
+                        "TH"
+                    ]
+                },
+                "blocked_countries": {
+                    "description": "Markets the product is withheld from",
+                    "type": "array",
+                    "maxItems": 250,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "US"
+                    ]
+                },
+                "bookable": {
+                    "description": "Reserved for availability slots, e.g. rentals and services",
+                    "type": "boolean",
+                    "example": false
+                },
+                "categories": {
+                    "description": "Category IDs",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "description": {
+                    "description": "Product description",
+                    "type": "string",
+                    "example": "Updated smartwatch features"
+                },
+                "location": {
+                    "description": "Warehouse location pick lists are grouped by",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "A-03-2"
+                },
+                "metadata": {
+                    "description": "Custom attributes, checked against the schemas of its categories",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "name": {
+                    "description": "Product name",
+                    "type": "string",
+                    "example": "SmartWatch Pro 2"
+                },
+                "price": {
+                    "description": "Product price",
+                    "type": "number",
+                    "example": 349.99
+                },
+                "quantity": {
+                    "description": "Stock quantity",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 150
+                },
+                "sku": {
+                    "description": "Stock keeping unit",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "SW-PRO2-BLK"
+                },
+                "status": {
+                    "description": "Product status",
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "inactive",
+                        "draft"
+                    ],
+                    "example": "active"
+                }
+            }
+        },
+        "product-management_internal_dto.UpdateSettingRequest": {
+            "type": "object",
+            "required": [
+                "value"
+            ],
+            "properties": {
+                "value": {
+                    "description": "Empty clears optional settings such as logo_url",
+                    "type": "string",
+                    "example": "Acme Store"
+                }
+            }
+        },
+        "product-management_internal_dto.UpdateUserRequest": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Preferred currency, one of the supported currencies",
+                    "type": "string",
+                    "example": "VND"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "locale": {
+                    "description": "Preferred language, one of the supported locales",
+                    "type": "string",
+                    "maxLength": 16,
+                    "example": "vi-VN"
+                },
+                "timezone": {
+                    "description": "Preferred IANA time zone",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Asia/Ho_Chi_Minh"
+                },
+                "username": {
+                    "type": "string",
+                    "minLength": 3
+                }
+            }
+        },
+        "product-management_internal_dto.UpdateUserRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "product-management_internal_dto.UserOutput": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_login": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "admin"
+                    ],
+                    "example": "user"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "product-management_internal_dto.UserResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Preferred currency, empty for the default",
+                    "type": "string"
+                },
+                "custom_fields": {
+                    "description": "Values of the registration form's custom fields",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_login": {
+                    "type": "string"
+                },
+                "locale": {
+                    "description": "Preferred language, empty for the default",
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Preferred time zone, empty for the default",
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "product-management_internal_dto.UserReviewStatsResponse": {
+            "type": "object",
+            "properties": {
+                "average_rating": {
+                    "type": "number"
+                },
+                "rating_distribution": {
+                    "description": "Review count per rating, \"1\" to \"5\"",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "review_count": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "product-management_internal_dto.VerboseLoggingRequest": {
+            "type": "object",
+            "required": [
+                "duration"
+            ],
+            "properties": {
+                "duration": {
+                    "description": "How long to log verbosely, at most 1h; 0 turns it off",
+                    "type": "string",
+                    "example": "15m"
+                }
+            }
+        },
+        "product-management_internal_dto.VerboseLoggingResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "until": {
+                    "description": "When the log level goes back to the configured one",
+                    "type": "string",
+                    "example": "2025-01-01T00:15:00Z"
+                }
+            }
+        },
+        "product-management_internal_dto.WebhookSubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://erp.example.com/hooks/catalog"
+                }
+            }
+        },
+        "product-management_internal_dto.WishlistCountResponse": {
+            "type": "object",
+            "properties": {
+                "my_wishlist_count": {
+                    "description": "Wishlist items of the current user",
+                    "type": "integer",
+                    "example": 3
+                },
+                "total_wishlist_count": {
+                    "description": "Wishlist items across all users",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "product-management_internal_dto.WishlistItemResponse": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "description": "Time the product was added",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "id": {
+                    "description": "Wishlist item ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "product": {
+                    "description": "Wishlisted product",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ProductResponse"
+                        }
+                    ]
+                },
+                "product_id": {
+                    "description": "Product ID",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "product-management_internal_types.APIResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data"
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_CategoryDistributionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.CategoryDistributionResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.CategoryResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_ConnectorResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ConnectorResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_ContentBlockResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ContentBlockResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_CustomFieldResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.CustomFieldResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_ExperimentAssignment": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ExperimentAssignment"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_FeaturedProductResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.FeaturedProductResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_IPBlockResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.IPBlockResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_PriceListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.PriceListResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_PricingRuleResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.PricingRuleResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_ProductImageResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ProductImageResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_ProductResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ProductResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_ReportDefinitionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.ReportDefinitionResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_SegmentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.SegmentResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_SettingResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.SettingResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_SlotResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.SlotResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_SupplierResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.SupplierResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-array_product-management_internal_dto_WebhookSubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.WebhookSubscriptionResponse"
+                    }
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-dto_RetentionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.RetentionResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-dto_StorefrontBootstrapResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.StorefrontBootstrapResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-dto_StorefrontExportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.StorefrontExportResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ActivityResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ActivityResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_AnonymizedUserResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.AnonymizedUserResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_AvailabilityResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.AvailabilityResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_BookingResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.BookingResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_CatalogDiffResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.CatalogDiffResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_CategoryAnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.CategoryAnalyticsResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_CategoryBatchResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.CategoryBatchResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.CategoryResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ConnectorSyncRunResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ConnectorSyncRunResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ContentBlockResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ContentBlockResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ConversionAnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ConversionAnalyticsResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_CreateWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.CreateWebhookResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_CustomFieldResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.CustomFieldResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ExperimentEventResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ExperimentEventResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ExperimentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ExperimentResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ExperimentResultsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ExperimentResultsResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_FeaturedProductResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.FeaturedProductResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_FormSchemaResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.FormSchemaResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_FormTokenResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.FormTokenResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_GiftCardRedemptionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.GiftCardRedemptionResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_GiftCardResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.GiftCardResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_IntegrityReportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.IntegrityReportResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_MarketPricesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.MarketPricesResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_MergeProductsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.MergeProductsResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_NotificationSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.NotificationSettingsResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_NotifySegmentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.NotifySegmentResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_OrderTrackingResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.OrderTrackingResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_PickListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.PickListResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_PriceListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.PriceListResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_PricingRuleResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.PricingRuleResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_PricingSimulationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.PricingSimulationResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ProductBatchResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ProductBatchResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ProductChangeRequestResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ProductChangeRequestResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ProductImageResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ProductImageResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ProductPriceResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ProductPriceResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ProductResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ProductResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.PromotionResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_PublicContentBlockResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.PublicContentBlockResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_PurchaseOrderResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.PurchaseOrderResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_QuoteResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.QuoteResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_RateLimitUsageResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.RateLimitUsageResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_RebuildRunResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.RebuildRunResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ReconciliationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ReconciliationResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.CategoryOutput": {
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ReferralsResponse": {
             "type": "object",
             "properties": {
-                "id": {
-                    "description": "Category ID",
-                    "type": "integer",
-                    "example": 1
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ReferralsResponse"
+                        }
+                    ]
                 },
-                "name": {
-                    "description": "Category name",
-                    "type": "string",
-                    "example": "Electronics"
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.CreateCategoryRequest": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ReloadConfigResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "description": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ReloadConfigResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
                     "type": "string"
                 },
-                "name": {
+                "message": {
+                    "description": "Optional message",
                     "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.CreateProductRequest": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_RepairIntegrityResponse": {
             "type": "object",
-            "required": [
-                "categories",
-                "name",
-                "price",
-                "quantity"
-            ],
             "properties": {
-                "categories": {
-                    "description": "Category IDs",
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "integer"
-                    },
-                    "example": [
-                        1,
-                        2,
-                        3
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.RepairIntegrityResponse"
+                        }
                     ]
                 },
-                "description": {
-                    "description": "Product description",
-                    "type": "string",
-                    "example": "Advanced smartwatch"
-                },
-                "name": {
-                    "description": "Product name",
-                    "type": "string",
-                    "example": "SmartWatch Pro"
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
                 },
-                "price": {
-                    "description": "Product price",
-                    "type": "number",
-                    "example": 299.99
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
                 },
-                "quantity": {
-                    "description": "Stock quantity",
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 100
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.CreateReviewRequest": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ReportResponse": {
             "type": "object",
-            "required": [
-                "comment",
-                "product_id",
-                "rating"
-            ],
             "properties": {
-                "comment": {
-                    "type": "string",
-                    "maxLength": 500,
-                    "minLength": 1
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ReportResponse"
+                        }
+                    ]
                 },
-                "product_id": {
-                    "type": "integer"
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
                 },
-                "rating": {
-                    "type": "integer",
-                    "maximum": 5,
-                    "minimum": 1
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.LoginRequest": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ReprocessOutboxResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "john@example.com"
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ReprocessOutboxResponse"
+                        }
+                    ]
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6,
-                    "example": "password123"
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.ProductResponse": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ReviewAnalyticsResponse": {
             "type": "object",
             "properties": {
-                "categories": {
-                    "description": "Associated categories",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.CategoryOutput"
-                    }
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ReviewAnalyticsResponse"
+                        }
+                    ]
                 },
-                "description": {
-                    "description": "Product description",
-                    "type": "string",
-                    "example": "Advanced smartwatch"
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
                 },
-                "id": {
-                    "description": "Product ID",
-                    "type": "integer",
-                    "example": 1
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
                 },
-                "name": {
-                    "description": "Product name",
-                    "type": "string",
-                    "example": "SmartWatch Pro"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ReviewCountResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ReviewCountResponse"
+                        }
+                    ]
                 },
-                "price": {
-                    "description": "Product price",
-                    "type": "number",
-                    "example": 299.99
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
                 },
-                "quantity": {
-                    "description": "Stock quantity",
-                    "type": "integer",
-                    "example": 100
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
                 },
-                "status": {
-                    "description": "Product status",
-                    "type": "string",
-                    "example": "active"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.RegisterRequest": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ReviewImportResponse": {
             "type": "object",
-            "required": [
-                "confirm_password",
-                "email",
-                "full_name",
-                "password",
-                "username"
-            ],
             "properties": {
-                "confirm_password": {
-                    "type": "string",
-                    "example": "password123"
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ReviewImportResponse"
+                        }
+                    ]
                 },
-                "email": {
-                    "type": "string",
-                    "example": "john@example.com"
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
                 },
-                "full_name": {
-                    "type": "string",
-                    "example": "John Doe"
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6,
-                    "example": "password123"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_RuntimeConfigResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.RuntimeConfigResponse"
+                        }
+                    ]
                 },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "user",
-                        "admin"
-                    ],
-                    "example": "user"
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
                 },
-                "username": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 3,
-                    "example": "johndoe"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.RegisterResponse": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_SagaResponse": {
             "type": "object",
             "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.SagaResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
                 "message": {
-                    "type": "string",
-                    "example": "user registered successfully"
+                    "description": "Optional message",
+                    "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/dto.UserOutput"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.ReviewListResponse": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_SalesAnalyticsResponse": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.ReviewResponse"
-                    }
-                },
-                "page": {
-                    "type": "integer"
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.SalesAnalyticsResponse"
+                        }
+                    ]
                 },
-                "page_size": {
-                    "type": "integer"
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
                 },
-                "total_pages": {
-                    "type": "integer"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.ReviewResponse": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_SegmentResponse": {
             "type": "object",
             "properties": {
-                "comment": {
-                    "type": "string"
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.SegmentResponse"
+                        }
+                    ]
                 },
-                "created_at": {
+                "error": {
+                    "description": "Error message if success is false",
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "product": {
-                    "$ref": "#/definitions/dto.ProductResponse"
-                },
-                "product_id": {
-                    "type": "integer"
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
                 },
-                "rating": {
-                    "type": "integer"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_SelfTestResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.SelfTestResponse"
+                        }
+                    ]
                 },
-                "updated_at": {
+                "error": {
+                    "description": "Error message if success is false",
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/dto.UserOutput"
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.UpdateCategoryRequest": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_SettingResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "description": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.SettingResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
                     "type": "string"
                 },
-                "name": {
+                "message": {
+                    "description": "Optional message",
                     "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.UpdatePasswordRequest": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_ShipmentResponse": {
             "type": "object",
-            "required": [
-                "confirm_new_password",
-                "current_password",
-                "new_password"
-            ],
             "properties": {
-                "confirm_new_password": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.ShipmentResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
                     "type": "string"
                 },
-                "current_password": {
+                "message": {
+                    "description": "Optional message",
                     "type": "string"
                 },
-                "new_password": {
-                    "type": "string",
-                    "minLength": 6
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.UpdateProductRequest": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_StockMovementResponse": {
             "type": "object",
-            "required": [
-                "categories",
-                "name",
-                "price",
-                "quantity",
-                "status"
-            ],
             "properties": {
-                "categories": {
-                    "description": "Category IDs",
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "integer"
-                    },
-                    "example": [
-                        1,
-                        2,
-                        3
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.StockMovementResponse"
+                        }
                     ]
                 },
-                "description": {
-                    "description": "Product description",
-                    "type": "string",
-                    "example": "Updated smartwatch features"
-                },
-                "name": {
-                    "description": "Product name",
-                    "type": "string",
-                    "example": "SmartWatch Pro 2"
-                },
-                "price": {
-                    "description": "Product price",
-                    "type": "number",
-                    "example": 349.99
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
                 },
-                "quantity": {
-                    "description": "Stock quantity",
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 150
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
                 },
-                "status": {
-                    "description": "Product status",
-                    "type": "string",
-                    "enum": [
-                        "active",
-                        "inactive",
-                        "draft"
-                    ],
-                    "example": "active"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.UpdateUserRequest": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_StoreCreditBalanceResponse": {
             "type": "object",
             "properties": {
-                "email": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.StoreCreditBalanceResponse"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
                     "type": "string"
                 },
-                "full_name": {
+                "message": {
+                    "description": "Optional message",
                     "type": "string"
                 },
-                "username": {
-                    "type": "string",
-                    "minLength": 3
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.UpdateUserRoleRequest": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_StoreCreditEntryResponse": {
             "type": "object",
-            "required": [
-                "role"
-            ],
             "properties": {
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "user",
-                        "admin"
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.StoreCreditEntryResponse"
+                        }
                     ]
+                },
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.UserOutput": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_SupplierResponse": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "john@example.com"
-                },
-                "full_name": {
-                    "type": "string",
-                    "example": "John Doe"
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.SupplierResponse"
+                        }
+                    ]
                 },
-                "last_login": {
-                    "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+                "error": {
+                    "description": "Error message if success is false",
+                    "type": "string"
                 },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "user",
-                        "admin"
-                    ],
-                    "example": "user"
+                "message": {
+                    "description": "Optional message",
+                    "type": "string"
                 },
-                "username": {
-                    "type": "string",
-                    "example": "johndoe"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "models.Category": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_SyncChangesResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.SyncChangesResponse"
+                        }
+                    ]
                 },
-                "description": {
+                "error": {
+                    "description": "Error message if success is false",
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
+                "message": {
+                    "description": "Optional message",
                     "type": "string"
                 },
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Product"
-                    }
-                },
-                "updated_at": {
-                    "type": "string"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "models.Product": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_TestTokenResponse": {
             "type": "object",
             "properties": {
-                "categories": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Category"
-                    }
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.TestTokenResponse"
+                        }
+                    ]
                 },
-                "created_at": {
+                "error": {
+                    "description": "Error message if success is false",
                     "type": "string"
                 },
-                "description": {
+                "message": {
+                    "description": "Optional message",
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_UserResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.UserResponse"
+                        }
+                    ]
                 },
-                "name": {
+                "error": {
+                    "description": "Error message if success is false",
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
-                },
-                "reviews": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Review"
-                    }
-                },
-                "status": {
-                    "$ref": "#/definitions/models.ProductStatus"
-                },
-                "stock_quantity": {
-                    "type": "integer"
-                },
-                "updated_at": {
+                "message": {
+                    "description": "Optional message",
                     "type": "string"
                 },
-                "wishlists": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Wishlist"
-                    }
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "models.ProductStatus": {
-            "type": "string",
-            "enum": [
-                "active",
-                "inactive",
-                "draft"
-            ],
-            "x-enum-varnames": [
-                "StatusActive",
-                "StatusInactive",
-                "StatusDraft"
-            ]
-        },
-        "models.Review": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_UserReviewStatsResponse": {
             "type": "object",
             "properties": {
-                "comment": {
-                    "type": "string"
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.UserReviewStatsResponse"
+                        }
+                    ]
                 },
-                "created_at": {
+                "error": {
+                    "description": "Error message if success is false",
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "product": {
-                    "$ref": "#/definitions/models.Product"
-                },
-                "product_id": {
-                    "type": "integer"
-                },
-                "rating": {
-                    "type": "integer"
-                },
-                "updated_at": {
+                "message": {
+                    "description": "Optional message",
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/models.User"
-                },
-                "user_id": {
-                    "type": "integer"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "models.Role": {
-            "type": "string",
-            "enum": [
-                "admin",
-                "user"
-            ],
-            "x-enum-varnames": [
-                "RoleAdmin",
-                "RoleUser"
-            ]
-        },
-        "models.User": {
+        "product-management_internal_types.DataResponse-product-management_internal_dto_VerboseLoggingResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.VerboseLoggingResponse"
+                        }
+                    ]
                 },
-                "email": {
+                "error": {
+                    "description": "Error message if success is false",
                     "type": "string"
                 },
-                "full_name": {
+                "message": {
+                    "description": "Optional message",
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "product-management_internal_types.DataResponse-product-management_internal_dto_WishlistCountResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_dto.WishlistCountResponse"
+                        }
+                    ]
                 },
-                "last_login": {
+                "error": {
+                    "description": "Error message if success is false",
                     "type": "string"
                 },
-                "reviews": {
-                    "description": "One-to-many relationship with Review",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Review"
-                    }
-                },
-                "role": {
-                    "$ref": "#/definitions/models.Role"
-                },
-                "updated_at": {
+                "message": {
+                    "description": "Optional message",
                     "type": "string"
                 },
-                "username": {
-                    "type": "string"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "models.Wishlist": {
+        "product-management_internal_types.DataResponse-product-management_internal_types_LoginResponse": {
             "type": "object",
             "properties": {
-                "added_at": {
-                    "type": "string"
+                "data": {
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_types.LoginResponse"
+                        }
+                    ]
                 },
-                "created_at": {
+                "error": {
+                    "description": "Error message if success is false",
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "product": {
-                    "$ref": "#/definitions/models.Product"
-                },
-                "product_id": {
-                    "type": "integer"
-                },
-                "updated_at": {
+                "message": {
+                    "description": "Optional message",
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "success": {
+                    "description": "Whether the request was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "types.APIResponse": {
+        "product-management_internal_types.DataResponse-product-management_internal_types_UserListResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "description": "Response data"
+                    "description": "Response data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product-management_internal_types.UserListResponse"
+                        }
+                    ]
                 },
                 "error": {
                     "description": "Error message if success is false",
@@ -2375,7 +21351,7 @@ const docTemplate = `{
                 }
             }
         },
-        "types.ErrorResponse": {
+        "product-management_internal_types.ErrorResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2392,16 +21368,70 @@ const docTemplate = `{
                 }
             }
         },
-        "types.ProductListResponse": {
+        "product-management_internal_types.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/product-management_internal_dto.UserOutput"
+                }
+            }
+        },
+        "product-management_internal_types.PaginatedResponse": {
             "type": "object",
             "properties": {
+                "default_page_size": {
+                    "description": "Page size used when none is requested",
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "List of items"
+                },
+                "max_page_size": {
+                    "description": "Largest page size allowed for this endpoint",
+                    "type": "integer"
+                },
+                "page": {
+                    "description": "Current page number",
+                    "type": "integer"
+                },
+                "page_size": {
+                    "description": "Number of items per page",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total number of items",
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "description": "Total number of pages",
+                    "type": "integer"
+                }
+            }
+        },
+        "product-management_internal_types.ProductListResponse": {
+            "type": "object",
+            "properties": {
+                "default_page_size": {
+                    "description": "Page size used when none is requested",
+                    "type": "integer"
+                },
                 "items": {
                     "description": "Override Items with specific type",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Product"
+                        "$ref": "#/definitions/product-management_internal_dto.ProductResponse"
                     }
                 },
+                "max_page_size": {
+                    "description": "Largest page size allowed for this endpoint",
+                    "type": "integer"
+                },
                 "page": {
                     "description": "Current page number",
                     "type": "integer"
@@ -2420,7 +21450,7 @@ const docTemplate = `{
                 }
             }
         },
-        "types.SuccessResponse": {
+        "product-management_internal_types.SuccessResponse": {
             "type": "object",
             "properties": {
                 "message": {
@@ -2429,16 +21459,60 @@ const docTemplate = `{
                 }
             }
         },
-        "types.WishlistResponse": {
+        "product-management_internal_types.UserListResponse": {
+            "type": "object",
+            "properties": {
+                "default_page_size": {
+                    "description": "Page size used when none is requested",
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "Override Items with specific type",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product-management_internal_dto.UserResponse"
+                    }
+                },
+                "max_page_size": {
+                    "description": "Largest page size allowed for this endpoint",
+                    "type": "integer"
+                },
+                "page": {
+                    "description": "Current page number",
+                    "type": "integer"
+                },
+                "page_size": {
+                    "description": "Number of items per page",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total number of items",
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "description": "Total number of pages",
+                    "type": "integer"
+                }
+            }
+        },
+        "product-management_internal_types.WishlistResponse": {
             "type": "object",
             "properties": {
+                "default_page_size": {
+                    "description": "Page size used when none is requested",
+                    "type": "integer"
+                },
                 "items": {
                     "description": "Override Items with specific type",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Wishlist"
+                        "$ref": "#/definitions/product-management_internal_dto.WishlistItemResponse"
                     }
                 },
+                "max_page_size": {
+                    "description": "Largest page size allowed for this endpoint",
+                    "type": "integer"
+                },
                 "page": {
                     "description": "Current page number",
                     "type": "integer"
@@ -0,0 +1,8 @@
+package docs
+
+import _ "embed"
+
+// OpenAPIJSON is the OpenAPI 3.0 document generated from the Swagger docs by `make openapi`
+//
+//go:embed openapi.json
+var OpenAPIJSON []byte
@@ -0,0 +1,20 @@
+package docs
+
+import (
+	"bytes"
+	"testing"
+
+	"product-management/pkg/openapi"
+)
+
+// TestOpenAPIUpToDate fails when the embedded OpenAPI 3 document no longer
+// matches the Swagger docs, so SDKs aren't generated from a stale document
+func TestOpenAPIUpToDate(t *testing.T) {
+	spec, err := openapi.Convert([]byte(SwaggerInfo.ReadDoc()))
+	if err != nil {
+		t.Fatalf("failed to convert swagger docs: %v", err)
+	}
+	if !bytes.Equal(spec, OpenAPIJSON) {
+		t.Fatal("docs/openapi.json is stale; run `make openapi`")
+	}
+}
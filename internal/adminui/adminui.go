@@ -0,0 +1,48 @@
+// Package adminui embeds a small single-page admin console that manages the
+// store through the public API, for deployments without a separate frontend.
+package adminui
+
+import (
+	"embed"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PathPrefix is where the console is served
+const PathPrefix = "/admin-ui/"
+
+//go:embed static
+var embedded embed.FS
+
+var static, _ = fs.Sub(embedded, "static")
+
+// Serve serves the console's assets. Paths that are not assets get the page
+// itself, which routes them in the browser. The console only runs its own
+// script, styles and API calls.
+func Serve(c *gin.Context) {
+	name := strings.TrimPrefix(path.Clean(c.Param("path")), "/")
+	if name == "" || name == "." {
+		name = "index.html"
+	}
+	if _, err := fs.Stat(static, name); err != nil {
+		name = "index.html"
+	}
+
+	c.Header("Content-Security-Policy", "default-src 'self'; frame-ancestors 'none'")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Referrer-Policy", "no-referrer")
+	if name == "index.html" {
+		c.Header("Cache-Control", "no-cache")
+	}
+	content, err := fs.ReadFile(static, name)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	c.Data(http.StatusOK, mime.TypeByExtension(path.Ext(name)), content)
+}
@@ -0,0 +1,398 @@
+// Admin console for the store API. It keeps the access token of the signed-in
+// admin in session storage and renders one page per location hash.
+"use strict";
+
+const API = "/api/v1";
+const PAGE_SIZE = 20;
+const TOKEN_KEY = "admin-ui-token";
+
+const main = document.getElementById("main");
+const nav = document.getElementById("nav");
+const message = document.getElementById("message");
+
+// el creates an element. Strings become text nodes, so API data is never
+// parsed as HTML.
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  for (const [name, value] of Object.entries(attrs || {})) {
+    if (name.startsWith("on")) {
+      node.addEventListener(name.slice(2), value);
+    } else if (value === true) {
+      node.setAttribute(name, "");
+    } else if (value !== false && value != null) {
+      node.setAttribute(name, value);
+    }
+  }
+  for (const child of children.flat()) {
+    if (child != null) {
+      node.append(child instanceof Node ? child : String(child));
+    }
+  }
+  return node;
+}
+
+function show(text, isError) {
+  message.textContent = text;
+  message.className = isError ? "error" : "";
+  message.hidden = !text;
+}
+
+// api calls the API with the admin's token and returns the decoded body. It
+// signs out when the token is rejected.
+async function api(method, path, body) {
+  const headers = { Accept: "application/json" };
+  const token = sessionStorage.getItem(TOKEN_KEY);
+  if (token) {
+    headers.Authorization = "Bearer " + token;
+  }
+  if (body !== undefined) {
+    headers["Content-Type"] = "application/json";
+  }
+  const response = await fetch(API + path, {
+    method,
+    headers,
+    body: body === undefined ? undefined : JSON.stringify(body),
+  });
+  const data = await response.json().catch(() => ({}));
+  if (response.status === 401 && token) {
+    signOut();
+    throw new Error("Your session expired, please sign in again.");
+  }
+  if (!response.ok) {
+    throw new Error(data.error || data.detail || response.status + " " + response.statusText);
+  }
+  return data;
+}
+
+// run shows the error of a failed action instead of throwing it away
+async function run(action) {
+  try {
+    await action();
+  } catch (err) {
+    show(err.message, true);
+  }
+}
+
+function signOut(text) {
+  sessionStorage.removeItem(TOKEN_KEY);
+  nav.hidden = true;
+  renderLogin();
+  show(text || "", true);
+}
+
+function renderLogin() {
+  const email = el("input", { type: "email", name: "email", required: true, autocomplete: "username" });
+  const password = el("input", { type: "password", name: "password", required: true, autocomplete: "current-password" });
+  main.replaceChildren(
+    el("h2", {}, "Sign in"),
+    el("form", {
+      onsubmit: (event) => {
+        event.preventDefault();
+        run(async () => {
+          const response = await api("POST", "/auth/login", { email: email.value, password: password.value });
+          if (response.data.user.role !== "admin") {
+            throw new Error("Only admins can use the console.");
+          }
+          sessionStorage.setItem(TOKEN_KEY, response.data.access_token);
+          show("");
+          route();
+        });
+      },
+    },
+      el("label", {}, "Email", email),
+      el("label", {}, "Password", password),
+      el("button", { type: "submit" }, "Sign in"),
+    ),
+  );
+}
+
+// pager renders previous and next links for a paginated response
+function pager(response, page, go) {
+  return el("div", { class: "toolbar" },
+    el("button", { type: "button", disabled: page <= 1, onclick: () => go(page - 1) }, "Previous"),
+    el("span", {}, `Page ${response.page} of ${response.total_pages} (${response.total} total)`),
+    el("button", { type: "button", disabled: page >= response.total_pages, onclick: () => go(page + 1) }, "Next"),
+  );
+}
+
+async function renderProducts(page = 1, search = "") {
+  const query = new URLSearchParams({ page, page_size: PAGE_SIZE, sort: "created_at" });
+  if (search) {
+    query.set("search", search);
+  }
+  const [products, categories] = await Promise.all([
+    api("GET", "/products?" + query),
+    api("GET", "/categories"),
+  ]);
+
+  const searchInput = el("input", { type: "search", value: search, placeholder: "Search products" });
+  main.replaceChildren(
+    el("h2", {}, "Products"),
+    el("div", { class: "toolbar" },
+      searchInput,
+      el("button", { type: "button", onclick: () => run(() => renderProducts(1, searchInput.value)) }, "Search"),
+      el("button", { type: "button", onclick: () => productForm(null, categories.data) }, "New product"),
+    ),
+    el("div", { id: "editor" }),
+    el("table", {},
+      el("thead", {}, el("tr", {}, ["ID", "Name", "SKU", "Price", "Stock", "Status", "Categories", ""].map((h) => el("th", {}, h)))),
+      el("tbody", {}, products.items.map((product) => el("tr", {},
+        el("td", {}, product.id),
+        el("td", {}, product.name),
+        el("td", {}, product.sku || ""),
+        el("td", {}, product.price.toFixed(2)),
+        el("td", {}, product.quantity),
+        el("td", {}, product.status),
+        el("td", {}, product.categories.map((category) => category.name).join(", ")),
+        el("td", { class: "actions" },
+          el("button", { type: "button", onclick: () => productForm(product, categories.data) }, "Edit"),
+          el("button", {
+            type: "button",
+            onclick: () => confirm(`Delete ${product.name}?`) && run(async () => {
+              await api("DELETE", `/products/${product.id}`);
+              show(`Deleted ${product.name}.`);
+              await renderProducts(page, search);
+            }),
+          }, "Delete"),
+        ),
+      ))),
+    ),
+    pager(products, page, (next) => run(() => renderProducts(next, search))),
+  );
+}
+
+// productForm edits a product, or creates one when product is null
+function productForm(product, categories) {
+  const value = (field, fallback) => (product ? product[field] : fallback);
+  const name = el("input", { required: true, value: value("name", "") });
+  const description = el("textarea", {}, value("description", ""));
+  const sku = el("input", { value: value("sku", "") || "" });
+  const price = el("input", { type: "number", step: "0.01", min: "0.01", required: true, value: value("price", "") });
+  const quantity = el("input", { type: "number", min: "0", required: true, value: value("quantity", 0) });
+  const status = el("select", {}, ["active", "inactive", "draft"].map((s) => el("option", { selected: value("status", "active") === s }, s)));
+  const selected = new Set((product ? product.categories : []).map((category) => category.id));
+  const categorySelect = el("select", { multiple: true, required: true },
+    categories.map((category) => el("option", { value: category.id, selected: selected.has(category.id) }, category.name)));
+
+  document.getElementById("editor").replaceChildren(el("form", {
+    onsubmit: (event) => {
+      event.preventDefault();
+      run(async () => {
+        const body = {
+          name: name.value,
+          description: description.value,
+          sku: sku.value,
+          price: Number(price.value),
+          quantity: Number(quantity.value),
+          categories: [...categorySelect.selectedOptions].map((option) => Number(option.value)),
+        };
+        if (product) {
+          body.status = status.value;
+          body.allowed_countries = product.allowed_countries || [];
+          body.blocked_countries = product.blocked_countries || [];
+          await api("PUT", `/products/${product.id}`, body);
+          show(`Saved ${body.name}.`);
+        } else {
+          await api("POST", "/products", body);
+          show(`Created ${body.name}.`);
+        }
+        await renderProducts();
+      });
+    },
+  },
+    el("h3", {}, product ? `Edit product ${product.id}` : "New product"),
+    el("label", {}, "Name", name),
+    el("label", {}, "Description", description),
+    el("label", {}, "SKU", sku),
+    el("label", {}, "Price", price),
+    el("label", {}, "Stock", quantity),
+    product ? el("label", {}, "Status", status) : null,
+    el("label", {}, "Categories", categorySelect),
+    el("div", { class: "toolbar" },
+      el("button", { type: "submit" }, "Save"),
+      el("button", { type: "button", onclick: () => document.getElementById("editor").replaceChildren() }, "Cancel"),
+    ),
+  ));
+}
+
+async function renderCategories() {
+  const categories = await api("GET", "/categories");
+  const editor = el("div", {});
+
+  const categoryForm = (category) => {
+    const name = el("input", { required: true, value: category ? category.name : "" });
+    const description = el("textarea", {}, category ? category.description : "");
+    editor.replaceChildren(el("form", {
+      onsubmit: (event) => {
+        event.preventDefault();
+        run(async () => {
+          const body = { name: name.value, description: description.value };
+          if (category) {
+            await api("PUT", `/categories/${category.id}`, body);
+          } else {
+            await api("POST", "/categories", body);
+          }
+          show(`Saved ${body.name}.`);
+          await renderCategories();
+        });
+      },
+    },
+      el("h3", {}, category ? `Edit category ${category.id}` : "New category"),
+      el("label", {}, "Name", name),
+      el("label", {}, "Description", description),
+      el("div", { class: "toolbar" },
+        el("button", { type: "submit" }, "Save"),
+        el("button", { type: "button", onclick: () => editor.replaceChildren() }, "Cancel"),
+      ),
+    ));
+  };
+
+  main.replaceChildren(
+    el("h2", {}, "Categories"),
+    el("div", { class: "toolbar" }, el("button", { type: "button", onclick: () => categoryForm(null) }, "New category")),
+    editor,
+    el("table", {},
+      el("thead", {}, el("tr", {}, ["ID", "Name", "Description", "Products", ""].map((h) => el("th", {}, h)))),
+      el("tbody", {}, categories.data.map((category) => el("tr", {},
+        el("td", {}, category.id),
+        el("td", {}, category.name),
+        el("td", {}, category.description),
+        el("td", {}, category.product_count),
+        el("td", { class: "actions" },
+          el("button", { type: "button", onclick: () => categoryForm(category) }, "Edit"),
+          el("button", {
+            type: "button",
+            onclick: () => confirm(`Delete ${category.name}?`) && run(async () => {
+              await api("DELETE", `/categories/${category.id}`);
+              show(`Deleted ${category.name}.`);
+              await renderCategories();
+            }),
+          }, "Delete"),
+        ),
+      ))),
+    ),
+  );
+}
+
+async function renderUsers(page = 1, search = "") {
+  const query = new URLSearchParams({ page, page_size: PAGE_SIZE });
+  if (search) {
+    query.set("search", search);
+  }
+  const users = (await api("GET", "/auth/users?" + query)).data;
+
+  const searchInput = el("input", { type: "search", value: search, placeholder: "Search users" });
+  main.replaceChildren(
+    el("h2", {}, "Users"),
+    el("div", { class: "toolbar" },
+      searchInput,
+      el("button", { type: "button", onclick: () => run(() => renderUsers(1, searchInput.value)) }, "Search"),
+    ),
+    el("table", {},
+      el("thead", {}, el("tr", {}, ["ID", "Username", "Email", "Name", "Role", ""].map((h) => el("th", {}, h)))),
+      el("tbody", {}, users.items.map((user) => el("tr", {},
+        el("td", {}, user.id),
+        el("td", {}, user.username),
+        el("td", {}, user.email),
+        el("td", {}, user.full_name),
+        el("td", {}, el("select", {
+          onchange: (event) => run(async () => {
+            await api("PUT", `/auth/users/${user.id}/role`, { role: event.target.value });
+            show(`${user.username} is now ${event.target.value}.`);
+          }),
+        }, ["user", "admin"].map((role) => el("option", { selected: user.role === role }, role)))),
+        el("td", { class: "actions" },
+          el("button", {
+            type: "button",
+            onclick: () => confirm(`Delete ${user.username}?`) && run(async () => {
+              await api("DELETE", `/auth/users/${user.id}`);
+              show(`Deleted ${user.username}.`);
+              await renderUsers(page, search);
+            }),
+          }, "Delete"),
+        ),
+      ))),
+    ),
+    pager(users, page, (next) => run(() => renderUsers(next, search))),
+  );
+}
+
+async function renderStats() {
+  const card = (label, value) => el("div", { class: "card" }, el("strong", {}, value), label);
+  const [products, categories, activity] = await Promise.all([
+    api("GET", "/products?page_size=1"),
+    api("GET", "/categories/distribution"),
+    // Admin routes are missing when they are served on the internal listener
+    api("GET", "/admin/analytics/activity?hours=24&limit=10").catch((err) => ({ error: err.message })),
+  ]);
+
+  const sections = [
+    el("h2", {}, "Stats"),
+    el("div", { class: "cards" },
+      card("products", products.total),
+      card("categories", categories.data.length),
+    ),
+    el("h3", {}, "Products per category"),
+    el("table", {},
+      el("thead", {}, el("tr", {}, el("th", {}, "Category"), el("th", {}, "Products"))),
+      el("tbody", {}, categories.data.map((category) => el("tr", {}, el("td", {}, category.name), el("td", {}, category.product_count)))),
+    ),
+    el("h3", {}, "API activity, last 24 hours"),
+  ];
+
+  if (activity.error) {
+    sections.push(el("p", {}, `Activity is unavailable: ${activity.error}`));
+  } else {
+    const stats = activity.data;
+    const errors = Object.entries(stats.status_codes).filter(([code]) => code >= "500").reduce((sum, [, count]) => sum + count, 0);
+    sections.push(
+      el("div", { class: "cards" },
+        card("requests", stats.requests),
+        card("server errors", errors),
+        card("p50 latency (ms)", stats.latency_percentiles.p50_ms),
+        card("p95 latency (ms)", stats.latency_percentiles.p95_ms),
+        card("p99 latency (ms)", stats.latency_percentiles.p99_ms),
+      ),
+      el("table", {},
+        el("thead", {}, el("tr", {}, ["Endpoint", "Requests", "4xx", "5xx", "p95 (ms)"].map((h) => el("th", {}, h)))),
+        el("tbody", {}, stats.endpoints.map((endpoint) => el("tr", {},
+          el("td", {}, `${endpoint.method} ${endpoint.route}`),
+          el("td", {}, endpoint.requests),
+          el("td", {}, endpoint.client_errors),
+          el("td", {}, endpoint.server_errors),
+          el("td", {}, endpoint.latency_percentiles.p95_ms),
+        ))),
+      ),
+    );
+  }
+  main.replaceChildren(...sections);
+}
+
+const pages = {
+  "#/products": renderProducts,
+  "#/categories": renderCategories,
+  "#/users": renderUsers,
+  "#/stats": renderStats,
+};
+
+function route() {
+  if (!sessionStorage.getItem(TOKEN_KEY)) {
+    nav.hidden = true;
+    renderLogin();
+    return;
+  }
+  nav.hidden = false;
+  const hash = pages[location.hash] ? location.hash : "#/products";
+  for (const link of nav.querySelectorAll("a")) {
+    link.classList.toggle("active", link.getAttribute("href") === hash);
+  }
+  main.replaceChildren(el("p", {}, "Loading…"));
+  run(() => pages[hash]());
+}
+
+document.getElementById("logout").addEventListener("click", () => signOut());
+window.addEventListener("hashchange", () => {
+  show("");
+  route();
+});
+route();
@@ -0,0 +1,176 @@
+package dto
+
+// ReviewAnalyticsRequest represents the query parameters of the review analytics dashboard
+type ReviewAnalyticsRequest struct {
+	Days       int    `form:"days" binding:"omitempty,min=1,max=365"`            // Length of the analysed window in days
+	Interval   string `form:"interval" binding:"omitempty,oneof=day week month"` // Bucket size of the volume series
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=100"`           // Number of dropping products to return
+	MinReviews int    `form:"min_reviews" binding:"omitempty,min=1"`             // Reviews a product needs in each window to be compared
+	TZ         string `form:"tz"`                                                // IANA time zone the periods are aligned to, the request time zone by default
+}
+
+// ReviewVolumePoint represents the reviews created in one period
+type ReviewVolumePoint struct {
+	Period        Time    `json:"period"` // Start of the period in the requested time zone, serialized in UTC
+	ReviewCount   int64   `json:"review_count"`
+	AverageRating float64 `json:"average_rating"`
+}
+
+// RatingDropResponse represents a product whose average rating fell between the
+// previous window and the current one
+type RatingDropResponse struct {
+	ProductID       uint    `json:"product_id"`
+	ProductName     string  `json:"product_name"`
+	PreviousAverage float64 `json:"previous_average"`
+	CurrentAverage  float64 `json:"current_average"`
+	Drop            float64 `json:"drop"`
+	PreviousCount   int64   `json:"previous_count"`
+	CurrentCount    int64   `json:"current_count"`
+}
+
+// ReviewAnalyticsResponse represents the review analytics dashboard
+type ReviewAnalyticsResponse struct {
+	From             Time                 `json:"from"`
+	To               Time                 `json:"to"`
+	Days             int                  `json:"days"`
+	Interval         string               `json:"interval"`
+	Timezone         string               `json:"timezone"`
+	Volume           []ReviewVolumePoint  `json:"volume"` // Review count and average rating per period, oldest first
+	DroppingProducts []RatingDropResponse `json:"dropping_products"`
+}
+
+// CategoryAnalyticsRequest represents the date range of the category analytics dashboard
+type CategoryAnalyticsRequest struct {
+	From string `form:"from" binding:"omitempty,datetime=2006-01-02"` // First day of the range, inclusive
+	To   string `form:"to" binding:"omitempty,datetime=2006-01-02"`   // Last day of the range, inclusive
+	TZ   string `form:"tz"`                                           // IANA time zone the days are interpreted in, the request time zone by default
+}
+
+// CategoryAnalyticsItem represents the product count and wishlist activity of a category
+type CategoryAnalyticsItem struct {
+	CategoryID             uint    `json:"category_id"`
+	Name                   string  `json:"name"`
+	ProductCount           int64   `json:"product_count"`
+	WishlistAdds           int64   `json:"wishlist_adds"`             // Products of the category added to wishlists in the range
+	WishlistAddsPerProduct float64 `json:"wishlist_adds_per_product"` // Wishlist adds relative to the category size
+}
+
+// CategoryAnalyticsResponse represents the category analytics dashboard
+type CategoryAnalyticsResponse struct {
+	From       string                  `json:"from"`
+	To         string                  `json:"to"`
+	Timezone   string                  `json:"timezone"`
+	Categories []CategoryAnalyticsItem `json:"categories"` // Ordered by wishlist adds, most first
+}
+
+// SalesAnalyticsRequest represents the date range of the sales analytics dashboard
+type SalesAnalyticsRequest struct {
+	From string `form:"from" binding:"omitempty,datetime=2006-01-02"` // First UTC day of the range, inclusive
+	To   string `form:"to" binding:"omitempty,datetime=2006-01-02"`   // Last UTC day of the range, inclusive
+}
+
+// SalesDayPoint represents the accepted quotes of one UTC day
+type SalesDayPoint struct {
+	Day            Time    `json:"day"`
+	AcceptedQuotes int64   `json:"accepted_quotes"`
+	Units          int64   `json:"units"`
+	Revenue        float64 `json:"revenue"`
+}
+
+// CategoryRevenueItem represents the units and revenue of the products of a category
+type CategoryRevenueItem struct {
+	CategoryID uint    `json:"category_id"`
+	Name       string  `json:"name"`
+	Units      int64   `json:"units"`
+	Revenue    float64 `json:"revenue"`
+}
+
+// SalesAnalyticsResponse represents the sales analytics dashboard
+type SalesAnalyticsResponse struct {
+	From           string                `json:"from"`
+	To             string                `json:"to"`
+	Source         string                `json:"source" enums:"live,materialized"` // Whether the figures come from the live tables or the reporting views
+	AcceptedQuotes int64                 `json:"accepted_quotes"`
+	Units          int64                 `json:"units"`
+	Revenue        float64               `json:"revenue"`
+	Days           []SalesDayPoint       `json:"days"`       // Days with sales, oldest first
+	Categories     []CategoryRevenueItem `json:"categories"` // Categories with sales, highest revenue first
+}
+
+// ConversionAnalyticsRequest represents the query parameters of the conversion analytics dashboard
+type ConversionAnalyticsRequest struct {
+	From  string `form:"from" binding:"omitempty,datetime=2006-01-02"` // First UTC day of the range, inclusive
+	To    string `form:"to" binding:"omitempty,datetime=2006-01-02"`   // Last UTC day of the range, inclusive
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`      // Number of products to return
+}
+
+// ProductConversionItem represents the views of a product and what followed them
+type ProductConversionItem struct {
+	ProductID      uint    `json:"product_id"`
+	ProductName    string  `json:"product_name"`
+	Views          int64   `json:"views"`
+	UniqueViewers  int64   `json:"unique_viewers"` // Distinct users and anonymous IDs; views with neither count toward none
+	WishlistAdds   int64   `json:"wishlist_adds"`
+	Orders         int64   `json:"orders"` // Quotes accepted for the product
+	Units          int64   `json:"units"`
+	WishlistRate   float64 `json:"wishlist_rate"`   // Wishlist adds per view
+	ConversionRate float64 `json:"conversion_rate"` // Orders per view
+}
+
+// ConversionAnalyticsResponse represents the conversion analytics dashboard
+type ConversionAnalyticsResponse struct {
+	From     string                  `json:"from"`
+	To       string                  `json:"to"`
+	Products []ProductConversionItem `json:"products"` // Most viewed first
+}
+
+// ActivityRequest represents the query parameters of the API activity dashboard
+type ActivityRequest struct {
+	Hours    int    `form:"hours" binding:"omitempty,min=1,max=720"`                    // Length of the analysed window in hours
+	Interval string `form:"interval"`                                                   // Period of the time series as a Go duration, e.g. 15m or 1h
+	Method   string `form:"method" binding:"omitempty,oneof=GET POST PUT PATCH DELETE"` // Only requests with this method
+	Route    string `form:"route"`                                                      // Only requests to this route pattern, e.g. /api/v1/products/:id
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`                    // Number of endpoints to return
+}
+
+// LatencyPercentiles represents estimated request latencies in milliseconds
+type LatencyPercentiles struct {
+	P50 float64 `json:"p50_ms" example:"12.5"`
+	P95 float64 `json:"p95_ms" example:"80"`
+	P99 float64 `json:"p99_ms" example:"240"`
+}
+
+// ActivityPoint represents the requests completed in one period
+type ActivityPoint struct {
+	Period       Time  `json:"period"` // Start of the period, in UTC
+	Requests     int64 `json:"requests"`
+	ClientErrors int64 `json:"client_errors"` // 4xx responses
+	ServerErrors int64 `json:"server_errors"` // 5xx responses
+	LatencyPercentiles
+}
+
+// EndpointActivity represents the requests to one route
+type EndpointActivity struct {
+	Method       string           `json:"method" example:"GET"`
+	Route        string           `json:"route" example:"/api/v1/products/:id"`
+	Requests     int64            `json:"requests"`
+	StatusCodes  map[string]int64 `json:"status_codes"` // Requests per status code
+	ClientErrors int64            `json:"client_errors"`
+	ServerErrors int64            `json:"server_errors"`
+	AverageMs    float64          `json:"average_ms"`
+	MaxMs        float64          `json:"max_ms"`
+	LatencyPercentiles
+	Volume []int64 `json:"volume"` // Requests per period of the series, for heatmaps
+}
+
+// ActivityResponse represents the API activity dashboard
+type ActivityResponse struct {
+	From        Time             `json:"from"`
+	To          Time             `json:"to"`
+	Interval    string           `json:"interval"`
+	Requests    int64            `json:"requests"`
+	StatusCodes map[string]int64 `json:"status_codes"` // Requests per status code
+	LatencyPercentiles
+	Series    []ActivityPoint    `json:"series"`    // Every period of the window, oldest first
+	Endpoints []EndpointActivity `json:"endpoints"` // Busiest routes first
+}
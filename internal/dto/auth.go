@@ -1,7 +1,5 @@
 package dto
 
-import "time"
-
 // RegisterRequest represents the request body for user registration
 type RegisterRequest struct {
 	Username        string `json:"username" binding:"required,min=3,max=50" example:"johndoe"`
@@ -10,6 +8,9 @@ type RegisterRequest struct {
 	Password        string `json:"password" binding:"required,min=6" example:"password123"`
 	ConfirmPassword string `json:"confirm_password" binding:"required" example:"password123"`
 	Role            string `json:"role,omitempty" example:"user" enums:"user,admin"`
+	ReferralCode    string `json:"referral_code,omitempty" binding:"omitempty,max=12" example:"K7QM2XRP"`
+
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"` // Values of the registration form's custom fields, see GET /forms/registration/schema
 }
 
 // RegisterResponse represents the response for successful registration
@@ -20,12 +21,12 @@ type RegisterResponse struct {
 
 // UserOutput represents the user data to be returned in responses
 type UserOutput struct {
-	ID        uint      `json:"id" example:"1"`
-	Username  string    `json:"username" example:"johndoe"`
-	Email     string    `json:"email" example:"john@example.com"`
-	FullName  string    `json:"full_name" example:"John Doe"`
-	Role      string    `json:"role" example:"user" enums:"user,admin"`
-	LastLogin time.Time `json:"last_login" example:"2021-01-01T00:00:00Z"`
+	ID        uint   `json:"id" example:"1"`
+	Username  string `json:"username" example:"johndoe"`
+	Email     string `json:"email" example:"john@example.com"`
+	FullName  string `json:"full_name" example:"John Doe"`
+	Role      string `json:"role" example:"user" enums:"user,admin"`
+	LastLogin Time   `json:"last_login" example:"2021-01-01T00:00:00Z"`
 }
 
 // LoginRequest represents the request body for user login
@@ -33,3 +34,57 @@ type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email" example:"john@example.com"`
 	Password string `json:"password" binding:"required,min=6" example:"password123"`
 }
+
+// RefreshTokenRequest represents the request body for exchanging a refresh token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutRequest represents the optional request body for logging out
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`                // Revoked along with the access token
+	AllSessions  bool   `json:"all_sessions,omitempty" example:"false"` // Revoke every token of the user instead
+}
+
+// FormTokenResponse represents the token a public form is submitted with
+type FormTokenResponse struct {
+	FormToken      string `json:"form_token" example:"1700000000000.3f2a9c"` // Sent back as form_token in the form body
+	CaptchaSiteKey string `json:"captcha_site_key,omitempty"`                // Set when a CAPTCHA provider is configured
+}
+
+// CreateTestTokenRequest represents the request body for minting a scoped test token
+type CreateTestTokenRequest struct {
+	Scopes     []string `json:"scopes" binding:"required,min=1,dive,oneof=catalog:read catalog:write reviews:read reviews:write admin:read" example:"catalog:read"`
+	Role       string   `json:"role,omitempty" binding:"omitempty,oneof=user admin" example:"user" enums:"user,admin"` // Defaults to user
+	TTLMinutes int      `json:"ttl_minutes,omitempty" binding:"omitempty,min=1,max=60" example:"15"`                   // Defaults to 15 minutes
+}
+
+// TestTokenResponse represents a minted scoped test token
+type TestTokenResponse struct {
+	AccessToken string   `json:"access_token"`
+	Scopes      []string `json:"scopes" example:"catalog:read"`
+	Role        string   `json:"role" example:"user"`
+	ExpiresAt   Time     `json:"expires_at" example:"2021-01-01T00:15:00Z"`
+}
+
+// AnonymizedUserResponse represents a user whose personal data was erased
+type AnonymizedUserResponse struct {
+	ID           uint   `json:"id" example:"2"`
+	Username     string `json:"username" example:"deleted-5f2c9a1b3e7d4086"` // Random pseudonym replacing the username and email
+	AnonymizedAt Time   `json:"anonymized_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// ReferralResponse represents a user who signed up with the current user's referral code
+type ReferralResponse struct {
+	Username string `json:"username" example:"janedoe"`
+	JoinedAt Time   `json:"joined_at" example:"2021-01-01T00:00:00Z"`
+	Rewarded bool   `json:"rewarded" example:"true"` // Whether their first order earned the reward
+}
+
+// ReferralsResponse represents the current user's referral code and the users it brought in
+type ReferralsResponse struct {
+	ReferralCode  string             `json:"referral_code" example:"K7QM2XRP"`
+	RewardAmount  float64            `json:"reward_amount" example:"10"` // Store credit earned per referred first order
+	TotalRewarded float64            `json:"total_rewarded" example:"20"`
+	Referrals     []ReferralResponse `json:"referrals"`
+}
@@ -0,0 +1,72 @@
+package dto
+
+// SlotRequest represents a period a bookable product can be reserved for
+type SlotRequest struct {
+	StartsAt Time `json:"starts_at" binding:"required" example:"2026-11-02T09:00:00Z"`
+	EndsAt   Time `json:"ends_at" binding:"required" example:"2026-11-02T10:00:00Z"`
+	Capacity int  `json:"capacity" binding:"required,min=1,max=10000" example:"1"` // Units that can be booked, e.g. 1 for a single rental item
+}
+
+// CreateSlotsRequest represents the request body for adding availability slots to a product
+type CreateSlotsRequest struct {
+	Slots []SlotRequest `json:"slots" binding:"required,min=1,max=200,dive"`
+}
+
+// AvailabilityRequest represents the query parameters of a product's availability calendar
+type AvailabilityRequest struct {
+	Month string `form:"month" binding:"omitempty,datetime=2006-01" example:"2026-11"` // The current month when omitted
+}
+
+// SlotResponse represents an availability slot of a product
+type SlotResponse struct {
+	ID        uint `json:"id" example:"7"`
+	StartsAt  Time `json:"starts_at" example:"2026-11-02T09:00:00Z"`
+	EndsAt    Time `json:"ends_at" example:"2026-11-02T10:00:00Z"`
+	Capacity  int  `json:"capacity" example:"1"`
+	Available int  `json:"available" example:"1"` // Units left to book, none once the slot started
+}
+
+// AvailabilityDayResponse represents a day of a product's availability calendar
+type AvailabilityDayResponse struct {
+	Date      string         `json:"date" example:"2026-11-02"`
+	Available int            `json:"available" example:"3"` // Units left to book across the day's slots
+	Slots     []SlotResponse `json:"slots"`
+}
+
+// AvailabilityResponse represents the availability calendar of a bookable
+// product for a month, with days in the request's time zone
+type AvailabilityResponse struct {
+	ProductID uint                      `json:"product_id" example:"1"`
+	Month     string                    `json:"month" example:"2026-11"`
+	Timezone  string                    `json:"timezone" example:"Asia/Ho_Chi_Minh"`
+	Days      []AvailabilityDayResponse `json:"days"` // Every day of the month, including those without slots
+}
+
+// CreateBookingRequest represents the request body for booking a slot of a product
+type CreateBookingRequest struct {
+	SlotID   uint `json:"slot_id" binding:"required" example:"7"`
+	Quantity int  `json:"quantity" binding:"omitempty,min=1,max=10000" example:"1"` // 1 when omitted
+}
+
+// ListBookingsRequest represents the query parameters for listing bookings
+type ListBookingsRequest struct {
+	ProductID uint   `form:"product_id" example:"1"` // Admin listing only
+	Status    string `form:"status" binding:"omitempty,oneof=confirmed cancelled"`
+	Page      int    `form:"page" binding:"omitempty,min=1"`
+	PageSize  int    `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// BookingResponse represents a reservation of units of a slot
+type BookingResponse struct {
+	ID          uint   `json:"id" example:"12"`
+	ProductID   uint   `json:"product_id" example:"1"`
+	ProductName string `json:"product_name" example:"Kayak rental"`
+	UserID      uint   `json:"user_id" example:"5"`
+	SlotID      uint   `json:"slot_id" example:"7"`
+	StartsAt    Time   `json:"starts_at" example:"2026-11-02T09:00:00Z"`
+	EndsAt      Time   `json:"ends_at" example:"2026-11-02T10:00:00Z"`
+	Quantity    int    `json:"quantity" example:"1"`
+	Status      string `json:"status" example:"confirmed"`
+	CancelledAt *Time  `json:"cancelled_at,omitempty" example:"2026-10-20T08:00:00Z"`
+	CreatedAt   Time   `json:"created_at" example:"2026-10-16T08:00:00Z"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+// CatalogDiffRequest represents the query parameters of a catalog diff
+type CatalogDiffRequest struct {
+	From string `form:"from" binding:"required,datetime=2006-01-02T15:04:05Z07:00"` // RFC 3339 time, usually the until of the previous diff
+}
+
+// CatalogDiffResponse represents the changes to the catalog since a time
+type CatalogDiffResponse struct {
+	From       Time         `json:"from" example:"2021-01-01T00:00:00Z"`
+	Until      Time         `json:"until" example:"2021-01-02T00:00:00Z"` // Pass as from to get the changes made since
+	Products   ProductDiff  `json:"products"`
+	Categories CategoryDiff `json:"categories"`
+}
+
+// ProductDiff represents the products created, updated and deleted since a time
+type ProductDiff struct {
+	Created []ProductDiffEntry `json:"created"`
+	Updated []ProductDiffEntry `json:"updated"`
+	Deleted []uint             `json:"deleted" example:"4,9"` // IDs of the products deleted
+}
+
+// ProductDiffEntry represents a product created or updated since a time
+type ProductDiffEntry struct {
+	ID       uint                   `json:"id" example:"1"`
+	Snapshot ProductSnapshot        `json:"snapshot"`          // Current state
+	Changes  map[string]FieldChange `json:"changes,omitempty"` // Fields changed, omitted for an update when no revision predates the time
+}
+
+// CategoryDiff represents the categories created, updated and deleted since a time
+type CategoryDiff struct {
+	Created []CategoryDiffEntry `json:"created"`
+	Updated []CategoryDiffEntry `json:"updated"`
+	Deleted []uint              `json:"deleted" example:"3"` // IDs of the categories deleted
+}
+
+// CategoryDiffEntry represents a category created or updated since a time
+type CategoryDiffEntry struct {
+	ID       uint                   `json:"id" example:"2"`
+	Snapshot CategorySnapshot       `json:"snapshot"`          // Current state
+	Changes  map[string]FieldChange `json:"changes,omitempty"` // Fields changed, omitted for an update when no revision predates the time
+}
@@ -2,22 +2,44 @@ package dto
 
 // CreateCategoryRequest represents the request body for creating a category
 type CreateCategoryRequest struct {
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description"`
+	Name           string          `json:"name" binding:"required"`
+	Description    string          `json:"description"`
+	MetadataSchema []MetadataField `json:"metadata_schema" binding:"omitempty,max=50,dive"` // Metadata keys of its products
 }
 
 // UpdateCategoryRequest represents the request body for updating a category
 type UpdateCategoryRequest struct {
-	Name        string `json:"name" binding:"required"`
+	Name           string          `json:"name" binding:"required"`
+	Description    string          `json:"description"`
+	MetadataSchema []MetadataField `json:"metadata_schema" binding:"omitempty,max=50,dive"` // Metadata keys of its products
+}
+
+// MetadataField defines a metadata key of the products of a category
+type MetadataField struct {
+	Key      string `json:"key" binding:"required,max=50" example:"color"`                        // Metadata key
+	Type     string `json:"type" binding:"required,oneof=string number boolean" example:"string"` // Type of its values
+	Required bool   `json:"required" example:"true"`                                              // Whether products of the category must have it
+}
+
+// CategorySnapshot represents the editable state of a category, as recorded by revisions
+type CategorySnapshot struct {
+	Name        string `json:"name"`
 	Description string `json:"description"`
 }
 
 // CategoryResponse represents the response for category operations
 type CategoryResponse struct {
-	ID           uint   `json:"id"`
-	Name         string `json:"name"`
-	Description  string `json:"description"`
-	ProductCount int    `json:"product_count"`
+	ID             uint            `json:"id"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	MetadataSchema []MetadataField `json:"metadata_schema"`
+	ProductCount   int             `json:"product_count"`
+}
+
+// CategoryBatchResponse represents categories looked up in a batch
+type CategoryBatchResponse struct {
+	Items   []CategoryResponse `json:"items"`               // Categories found, in the order requested
+	Missing []uint             `json:"missing" example:"9"` // Requested IDs that were not found
 }
 
 // CategoryDistributionResponse represents the distribution of products across categories
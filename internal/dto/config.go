@@ -0,0 +1,27 @@
+package dto
+
+// RuntimeConfigAPIKey represents an API key granted its own quota, without the key itself
+type RuntimeConfigAPIKey struct {
+	Name  string `json:"name" example:"partner"`
+	Limit int    `json:"limit" example:"5000"`
+}
+
+// RuntimeConfigResponse represents the reloadable configuration in effect on
+// the instance serving the request
+type RuntimeConfigResponse struct {
+	LogLevel           string                `json:"log_level" example:"info" enums:"panic,fatal,error,warning,info,debug,trace"`
+	RateLimitTiers     map[string]int        `json:"rate_limit_tiers"`  // Requests per window for each tier
+	RateLimitGroups    map[string]int        `json:"rate_limit_groups"` // Per route group overrides, keyed "group.tier"
+	RateLimitAPIKeys   []RuntimeConfigAPIKey `json:"rate_limit_api_keys"`
+	FeatureFlags       map[string]bool       `json:"feature_flags"`
+	MaintenanceMode    bool                  `json:"maintenance_mode" example:"false"`
+	MaintenanceMessage string                `json:"maintenance_message" example:"The service is down for maintenance, please try again later"`
+	Source             string                `json:"source,omitempty" example:"/etc/product-management/runtime.env"` // CONFIG_FILE it was read from
+	LoadedAt           Time                  `json:"loaded_at" example:"2025-01-01T00:00:00Z"`
+}
+
+// ReloadConfigResponse represents the outcome of reloading the configuration
+type ReloadConfigResponse struct {
+	Changed []string              `json:"changed" example:"LOG_LEVEL,MAINTENANCE_MODE"` // Variables whose values changed
+	Config  RuntimeConfigResponse `json:"config"`
+}
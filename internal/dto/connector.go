@@ -0,0 +1,34 @@
+package dto
+
+// ListConnectorRunsRequest represents the query parameters for listing the sync runs of a connector
+type ListConnectorRunsRequest struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// ConnectorSyncRunResponse represents a sync run of a connector
+type ConnectorSyncRunResponse struct {
+	ID              uint   `json:"id" example:"1"`
+	Connector       string `json:"connector" example:"erp"`
+	Status          string `json:"status" example:"succeeded" enums:"running,succeeded,failed"`
+	TriggeredBy     *uint  `json:"triggered_by,omitempty" example:"1"` // Admin who started it, missing for scheduled runs
+	StartedAt       Time   `json:"started_at" example:"2021-01-01T00:00:00Z"`
+	FinishedAt      *Time  `json:"finished_at,omitempty" example:"2021-01-01T00:00:05Z"`
+	ProductsPulled  int    `json:"products_pulled" example:"120"`
+	ProductsCreated int    `json:"products_created" example:"2"` // Unknown SKUs, created as drafts
+	ProductsUpdated int    `json:"products_updated" example:"15"`
+	StockPulled     int    `json:"stock_pulled" example:"120"`
+	StockUpdated    int    `json:"stock_updated" example:"37"`
+	Skipped         int    `json:"skipped" example:"1"` // Pulled records without an SKU, invalid or for an unknown or deleted product
+	OrdersPushed    int    `json:"orders_pushed" example:"4"`
+	OrdersThrough   *Time  `json:"orders_through,omitempty" example:"2021-01-01T00:00:00Z"` // Acceptance time of the last quote pushed as an order
+	Error           string `json:"error,omitempty"`                                         // Set when a step failed
+}
+
+// ConnectorResponse represents a configured connector with its latest run
+type ConnectorResponse struct {
+	Name     string                    `json:"name" example:"erp"`
+	Type     string                    `json:"type" example:"rest" enums:"rest,sftp-csv"`
+	Interval string                    `json:"interval" example:"1h0m0s"`
+	LastRun  *ConnectorSyncRunResponse `json:"last_run,omitempty"` // Missing until it first syncs
+}
@@ -0,0 +1,37 @@
+package dto
+
+// ContentBlockRequest represents the request body for creating or replacing a
+// content block
+type ContentBlockRequest struct {
+	Key      string `json:"key" binding:"required,max=100" example:"homepage-hero"` // Lowercase letters, digits, dots, dashes and underscores
+	Title    string `json:"title" binding:"max=200" example:"Summer sale"`
+	Body     string `json:"body" example:"<p>Up to 50% off sunglasses</p>"` // HTML
+	ImageURL string `json:"image_url" binding:"omitempty,url,max=500" example:"https://cdn.example.com/banners/summer.jpg"`
+	StartsAt *Time  `json:"starts_at" example:"2025-06-01T00:00:00Z"` // Shown at once when omitted
+	EndsAt   *Time  `json:"ends_at" example:"2025-07-01T00:00:00Z"`   // Shown until removed when omitted
+}
+
+// ContentBlockResponse represents a content block for admins
+type ContentBlockResponse struct {
+	ID        uint   `json:"id" example:"1"`
+	Key       string `json:"key" example:"homepage-hero"`
+	Title     string `json:"title" example:"Summer sale"`
+	Body      string `json:"body" example:"<p>Up to 50% off sunglasses</p>"`
+	ImageURL  string `json:"image_url" example:"https://cdn.example.com/banners/summer.jpg"`
+	StartsAt  *Time  `json:"starts_at" example:"2025-06-01T00:00:00Z"`
+	EndsAt    *Time  `json:"ends_at" example:"2025-07-01T00:00:00Z"`
+	Live      bool   `json:"live" example:"true"` // Whether its schedule covers the current time
+	UpdatedBy uint   `json:"updated_by" example:"1"`
+	CreatedAt Time   `json:"created_at" example:"2025-01-01T00:00:00Z"`
+	UpdatedAt Time   `json:"updated_at" example:"2025-01-01T00:00:00Z"`
+}
+
+// PublicContentBlockResponse represents a live content block as the storefront
+// renders it
+type PublicContentBlockResponse struct {
+	Key       string `json:"key" example:"homepage-hero"`
+	Title     string `json:"title" example:"Summer sale"`
+	Body      string `json:"body" example:"<p>Up to 50% off sunglasses</p>"`
+	ImageURL  string `json:"image_url" example:"https://cdn.example.com/banners/summer.jpg"`
+	UpdatedAt Time   `json:"updated_at" example:"2025-01-01T00:00:00Z"`
+}
@@ -0,0 +1,45 @@
+package dto
+
+// CustomFieldRequest represents the request body for creating or replacing a
+// custom form field
+type CustomFieldRequest struct {
+	Form      string   `json:"form" binding:"required,oneof=registration checkout" example:"registration"`
+	Key       string   `json:"key" binding:"required,max=50" example:"vat_number"` // Lowercase letters, digits and underscores, starting with a letter
+	Label     string   `json:"label" binding:"required,max=100" example:"VAT number"`
+	HelpText  string   `json:"help_text" binding:"max=255" example:"Only for business customers"`
+	Type      string   `json:"type" binding:"required,oneof=text number boolean select date email" example:"text"`
+	Required  bool     `json:"required" example:"false"`
+	Options   []string `json:"options" binding:"omitempty,max=50,dive,required,max=100"`     // Choices of select fields, required for them only
+	Pattern   string   `json:"pattern" binding:"max=255" example:"^[A-Z]{2}[0-9A-Z]{8,12}$"` // Regular expression text values must match
+	MinLength *int     `json:"min_length" binding:"omitempty,min=0,max=1000" example:"0"`    // Of text values
+	MaxLength *int     `json:"max_length" binding:"omitempty,min=1,max=1000" example:"14"`   // Of text values, 1000 when omitted
+	Min       *float64 `json:"min" example:"0"`                                              // Of number values
+	Max       *float64 `json:"max" example:"100"`                                            // Of number values
+	Position  int      `json:"position" binding:"min=0" example:"0"`                         // Fields are listed in ascending position
+}
+
+// CustomFieldResponse represents a custom form field
+type CustomFieldResponse struct {
+	ID        uint     `json:"id" example:"1"`
+	Form      string   `json:"form" example:"registration"`
+	Key       string   `json:"key" example:"vat_number"`
+	Label     string   `json:"label" example:"VAT number"`
+	HelpText  string   `json:"help_text,omitempty" example:"Only for business customers"`
+	Type      string   `json:"type" example:"text"`
+	Required  bool     `json:"required" example:"false"`
+	Options   []string `json:"options,omitempty"`
+	Pattern   string   `json:"pattern,omitempty" example:"^[A-Z]{2}[0-9A-Z]{8,12}$"`
+	MinLength *int     `json:"min_length,omitempty" example:"0"`
+	MaxLength *int     `json:"max_length,omitempty" example:"14"`
+	Min       *float64 `json:"min,omitempty" example:"0"`
+	Max       *float64 `json:"max,omitempty" example:"100"`
+	Position  int      `json:"position" example:"0"`
+	UpdatedAt Time     `json:"updated_at" example:"2025-01-01T00:00:00Z"`
+}
+
+// FormSchemaResponse represents the custom fields of a form, in the order
+// clients should show them
+type FormSchemaResponse struct {
+	Form   string                `json:"form" example:"checkout"`
+	Fields []CustomFieldResponse `json:"fields"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+// VerboseLoggingRequest represents the request to turn verbose logging on or off
+type VerboseLoggingRequest struct {
+	Duration string `json:"duration" binding:"required" example:"15m"` // How long to log verbosely, at most 1h; 0 turns it off
+}
+
+// VerboseLoggingResponse represents the verbose logging state of the instance
+type VerboseLoggingResponse struct {
+	Enabled bool  `json:"enabled" example:"true"`
+	Until   *Time `json:"until,omitempty" example:"2025-01-01T00:15:00Z"` // When the log level goes back to the configured one
+}
+
+// SelfTestCheck represents the outcome of checking one backend
+type SelfTestCheck struct {
+	Name       string `json:"name" example:"database" enums:"database,cache,storage,mail,search,locks"`
+	OK         bool   `json:"ok" example:"true"`
+	DurationMs int64  `json:"duration_ms" example:"3"`
+	Error      string `json:"error,omitempty"` // Set when the check failed
+	Hint       string `json:"hint,omitempty"`  // What to look at when the check failed
+}
+
+// SelfTestResponse represents the outcome of checking every backend
+type SelfTestResponse struct {
+	OK     bool            `json:"ok" example:"true"` // Whether every check passed
+	Checks []SelfTestCheck `json:"checks"`
+}
@@ -0,0 +1,96 @@
+package dto
+
+// ExperimentVariantRequest represents a variant of an experiment and its share of the traffic
+type ExperimentVariantRequest struct {
+	Key    string `json:"key" binding:"required,max=50" example:"discount_10"`
+	Weight int    `json:"weight" binding:"min=0,max=100" example:"50"` // Percentage of the subjects taking part assigned to it
+}
+
+// ExperimentRequest represents the request body for defining or replacing a draft experiment
+type ExperimentRequest struct {
+	Key         string                     `json:"key" binding:"required,max=100" example:"checkout_discount"` // Used by clients and server code to look the experiment up
+	Name        string                     `json:"name" binding:"required,max=100" example:"Checkout discount"`
+	Description string                     `json:"description" example:"Does a 10% discount at checkout raise orders?"`
+	Traffic     *int                       `json:"traffic" binding:"omitempty,min=1,max=100" example:"100"` // Percentage of subjects taking part, 100 by default
+	Variants    []ExperimentVariantRequest `json:"variants" binding:"required,min=2,max=10,dive"`           // Weights add up to 100
+}
+
+// ListExperimentsRequest represents the query parameters for listing experiments
+type ListExperimentsRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=draft running stopped"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// ExperimentVariantResponse represents a variant of an experiment
+type ExperimentVariantResponse struct {
+	Key    string `json:"key" example:"discount_10"`
+	Weight int    `json:"weight" example:"50"`
+}
+
+// ExperimentResponse represents an experiment
+type ExperimentResponse struct {
+	ID          uint                        `json:"id" example:"1"`
+	Key         string                      `json:"key" example:"checkout_discount"`
+	Name        string                      `json:"name" example:"Checkout discount"`
+	Description string                      `json:"description" example:"Does a 10% discount at checkout raise orders?"`
+	Traffic     int                         `json:"traffic" example:"100"`
+	Status      string                      `json:"status" example:"running" enums:"draft,running,stopped"`
+	Variants    []ExperimentVariantResponse `json:"variants"`
+	StartedAt   *Time                       `json:"started_at,omitempty" example:"2025-01-01T00:00:00Z"`
+	StoppedAt   *Time                       `json:"stopped_at,omitempty" example:"2025-02-01T00:00:00Z"`
+	UpdatedBy   uint                        `json:"updated_by" example:"1"`
+	CreatedAt   Time                        `json:"created_at" example:"2025-01-01T00:00:00Z"`
+	UpdatedAt   Time                        `json:"updated_at" example:"2025-01-01T00:00:00Z"`
+}
+
+// ExperimentAssignmentsRequest represents the query parameters for looking up experiment assignments
+type ExperimentAssignmentsRequest struct {
+	AnonymousID string `form:"anonymous_id" binding:"omitempty,max=64"` // Identifies a visitor who is not signed in; ignored for signed-in users
+	Keys        string `form:"keys"`                                    // Comma-separated experiment keys, every running experiment by default
+}
+
+// ExperimentAssignment represents the variant a subject is assigned in a running experiment
+type ExperimentAssignment struct {
+	Experiment string `json:"experiment" example:"checkout_discount"`
+	Variant    string `json:"variant" example:"discount_10"`
+}
+
+// ExperimentEventRequest represents the request body for recording an exposure or a conversion
+type ExperimentEventRequest struct {
+	Experiment  string  `json:"experiment" binding:"required,max=100" example:"checkout_discount"`
+	Kind        string  `json:"kind" binding:"required,oneof=exposure conversion" example:"conversion"`
+	AnonymousID string  `json:"anonymous_id" binding:"omitempty,max=64" example:"3f2a9c1e-7b44-4d8e-9a51-0c6f2d8b1e77"` // Identifies a visitor who is not signed in; ignored for signed-in users
+	Goal        string  `json:"goal" binding:"omitempty,max=50" example:"order"`                                        // What a conversion reached
+	Value       float64 `json:"value" binding:"omitempty,min=0" example:"49.99"`                                        // Value of a conversion, such as the order total
+}
+
+// ExperimentEventResponse represents a recorded experiment event
+type ExperimentEventResponse struct {
+	Variant string `json:"variant" example:"discount_10"` // Variant the event was attributed to
+}
+
+// ExperimentResultsRequest represents the query parameters of an experiment's results
+type ExperimentResultsRequest struct {
+	Goal string `form:"goal" binding:"omitempty,max=50"` // Only count conversions reaching this goal
+}
+
+// ExperimentVariantResult represents the exposures and conversions of a variant
+type ExperimentVariantResult struct {
+	Variant        string  `json:"variant" example:"discount_10"`
+	Weight         int     `json:"weight" example:"50"`
+	Exposed        int64   `json:"exposed" example:"1200"`         // Subjects exposed to the variant
+	Converted      int64   `json:"converted" example:"84"`         // Exposed subjects who converted after their first exposure
+	Conversions    int64   `json:"conversions" example:"97"`       // Their conversions, repeat ones included
+	ConversionRate float64 `json:"conversion_rate" example:"0.07"` // Converted subjects per exposed subject
+	Value          float64 `json:"value" example:"4321.5"`         // Total value of the conversions
+}
+
+// ExperimentResultsResponse represents the results of an experiment per variant
+type ExperimentResultsResponse struct {
+	ExperimentID uint                      `json:"experiment_id" example:"1"`
+	Key          string                    `json:"key" example:"checkout_discount"`
+	Status       string                    `json:"status" example:"running" enums:"draft,running,stopped"`
+	Goal         string                    `json:"goal,omitempty" example:"order"`
+	Variants     []ExperimentVariantResult `json:"variants"` // In the order the variants were defined
+}
@@ -0,0 +1,23 @@
+package dto
+
+// FeaturedProductRequest represents the request body for featuring a product or
+// changing its entry
+type FeaturedProductRequest struct {
+	ProductID uint  `json:"product_id" binding:"required,gt=0" example:"1"`
+	Position  int   `json:"position" binding:"gte=0" example:"0"`     // Lower positions come first
+	StartsAt  *Time `json:"starts_at" example:"2025-01-01T00:00:00Z"` // Shown at once when omitted
+	EndsAt    *Time `json:"ends_at" example:"2025-02-01T00:00:00Z"`   // Shown until removed when omitted
+}
+
+// FeaturedProductResponse represents an entry of the curated featured list
+type FeaturedProductResponse struct {
+	ID        uint  `json:"id" example:"1"`
+	ProductID uint  `json:"product_id" example:"1"`
+	Position  int   `json:"position" example:"0"`
+	StartsAt  *Time `json:"starts_at" example:"2025-01-01T00:00:00Z"`
+	EndsAt    *Time `json:"ends_at" example:"2025-02-01T00:00:00Z"`
+	Live      bool  `json:"live" example:"true"` // Whether its schedule covers the current time
+	CreatedBy uint  `json:"created_by" example:"1"`
+	CreatedAt Time  `json:"created_at" example:"2025-01-01T00:00:00Z"`
+	UpdatedAt Time  `json:"updated_at" example:"2025-01-01T00:00:00Z"`
+}
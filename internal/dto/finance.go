@@ -0,0 +1,52 @@
+package dto
+
+// ReconciliationRequest represents the query parameters of the reconciliation report
+type ReconciliationRequest struct {
+	From   string `form:"from" binding:"omitempty,datetime=2006-01-02"` // First UTC day of the range, inclusive
+	To     string `form:"to" binding:"omitempty,datetime=2006-01-02"`   // Last UTC day of the range, inclusive
+	Format string `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+// ReconciliationDay represents the orders placed on one UTC day and how they were paid
+type ReconciliationDay struct {
+	Date                string  `json:"date" example:"2024-01-16"`
+	Orders              int     `json:"orders" example:"12"`
+	OrderTotal          float64 `json:"order_total" example:"1480.5"`
+	GiftCardPayments    float64 `json:"gift_card_payments" example:"150"`   // Redeemed from gift cards
+	StoreCreditPayments float64 `json:"store_credit_payments" example:"45"` // Spent from store credit
+	Refunds             float64 `json:"refunds" example:"20"`               // Gift card restores and store credit refunds of voided orders
+	ExternalDue         float64 `json:"external_due" example:"1285.5"`      // Left to settle outside the system for orders charged and not voided
+	Mismatches          int     `json:"mismatches" example:"0"`             // Orders whose ledgers disagree with what they recorded
+}
+
+// ReconciliationOrder represents what an order recorded as paid against the ledgers
+type ReconciliationOrder struct {
+	Date               string  `json:"date" example:"2024-01-16"`
+	Reference          string  `json:"reference" example:"Q-42"`
+	OrderNumber        string  `json:"order_number" example:"ORD-42"`
+	Status             string  `json:"status" example:"completed" enums:"running,completed,compensating,compensated,failed"` // Of the order placement saga
+	Total              float64 `json:"total" example:"120"`
+	GiftCardApplied    float64 `json:"gift_card_applied" example:"50"`    // Recorded by the order
+	GiftCardLedger     float64 `json:"gift_card_ledger" example:"50"`     // Net of the gift card ledger
+	StoreCreditApplied float64 `json:"store_credit_applied" example:"20"` // Recorded by the order
+	StoreCreditLedger  float64 `json:"store_credit_ledger" example:"20"`  // Net of the store credit ledger
+	ExternalDue        float64 `json:"external_due" example:"50"`         // Left to settle outside the system
+	Mismatch           string  `json:"mismatch,omitempty" example:"store credit ledger 20.00, order recorded 0.00"`
+}
+
+// UnmatchedPaymentResponse represents ledger entries for an order that does not exist
+type UnmatchedPaymentResponse struct {
+	Ledger    string  `json:"ledger" example:"store_credit" enums:"store_credit,gift_card"`
+	Reference string  `json:"reference" example:"Q-17"`
+	Net       float64 `json:"net" example:"15"` // Paid minus given back
+	FirstAt   Time    `json:"first_at" example:"2024-01-16T09:30:00Z"`
+}
+
+// ReconciliationResponse represents the reconciliation report of a range of days
+type ReconciliationResponse struct {
+	From      string                     `json:"from" example:"2024-01-16"`
+	To        string                     `json:"to" example:"2024-01-16"`
+	Days      []ReconciliationDay        `json:"days"`      // Every day of the range, oldest first
+	Orders    []ReconciliationOrder      `json:"orders"`    // Orders placed in the range, oldest first
+	Unmatched []UnmatchedPaymentResponse `json:"unmatched"` // Payments and refunds of orders without a placement
+}
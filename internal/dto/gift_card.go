@@ -0,0 +1,62 @@
+package dto
+
+// IssueGiftCardRequest represents the request body for issuing a gift card
+type IssueGiftCardRequest struct {
+	Amount    float64 `json:"amount" binding:"required,gt=0"`
+	ExpiresAt *Time   `json:"expires_at"` // Never expires when omitted
+}
+
+// GiftCardResponse represents a gift card and its remaining balance
+type GiftCardResponse struct {
+	Code           string  `json:"code" example:"K7QM-2XRP-9TWD-HC4N"`
+	InitialBalance float64 `json:"initial_balance" example:"50"`
+	Balance        float64 `json:"balance" example:"32.5"`
+	ExpiresAt      *Time   `json:"expires_at" example:"2022-01-01T00:00:00Z"`
+	Expired        bool    `json:"expired" example:"false"`
+	CreatedAt      Time    `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// RedeemGiftCardRequest represents the request body for applying a gift card
+// to an amount due
+type RedeemGiftCardRequest struct {
+	Amount    float64 `json:"amount" binding:"required,gt=0" example:"80"`
+	Reference string  `json:"reference" binding:"required,max=100" example:"POS-2024-0117"` // What the card pays for, e.g. an in-store receipt
+}
+
+// GiftCardRedemptionResponse represents the part of an amount due a gift card covered
+type GiftCardRedemptionResponse struct {
+	Code         string  `json:"code" example:"K7QM-2XRP-9TWD-HC4N"`
+	Reference    string  `json:"reference" example:"POS-2024-0117"`
+	Applied      float64 `json:"applied" example:"50"`       // Taken from the card
+	RemainingDue float64 `json:"remaining_due" example:"30"` // Left to pay another way
+	Balance      float64 `json:"balance" example:"0"`        // Left on the card
+}
+
+// GrantStoreCreditRequest represents the request body for granting store credit
+type GrantStoreCreditRequest struct {
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+	Note   string  `json:"note" binding:"max=500"`
+}
+
+// StoreCreditBalanceResponse represents a user's store credit balance
+type StoreCreditBalanceResponse struct {
+	Balance float64 `json:"balance" example:"15"`
+}
+
+// ListStoreCreditRequest represents the query parameters for listing store credit entries
+type ListStoreCreditRequest struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// StoreCreditEntryResponse represents an entry in a user's store credit ledger
+type StoreCreditEntryResponse struct {
+	ID               uint    `json:"id" example:"1"`
+	Source           string  `json:"source" example:"grant" enums:"grant,payment,refund,referral"`
+	Reference        string  `json:"reference,omitempty" example:"order-1001"`
+	Delta            float64 `json:"delta" example:"15"`
+	ResultingBalance float64 `json:"resulting_balance" example:"15"`
+	ActorID          uint    `json:"actor_id" example:"1"`
+	Note             string  `json:"note,omitempty" example:"delayed delivery"`
+	CreatedAt        Time    `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
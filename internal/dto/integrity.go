@@ -0,0 +1,34 @@
+package dto
+
+// IntegrityCheckResponse represents what an integrity check found
+type IntegrityCheckResponse struct {
+	Check       string   `json:"check" example:"negative_stock"`
+	Description string   `json:"description" example:"Products whose stock quantity is below zero"`
+	Repair      string   `json:"repair" example:"Set the stock to zero, recording an adjustment in the stock ledger"` // What repairing the check does
+	Count       int64    `json:"count" example:"2"`                                                                   // Broken records found
+	Samples     []string `json:"samples" example:"product 7 (stock -3)"`                                              // The first 20 of them
+}
+
+// IntegrityReportResponse represents the outcome of every integrity check
+type IntegrityReportResponse struct {
+	CheckedAt Time                     `json:"checked_at" example:"2021-01-01T00:00:00Z"`
+	Issues    int64                    `json:"issues" example:"2"` // Broken records found by all checks
+	Checks    []IntegrityCheckResponse `json:"checks"`
+}
+
+// RepairIntegrityRequest represents a request to repair integrity issues
+type RepairIntegrityRequest struct {
+	Checks []string `json:"checks" example:"negative_stock"` // Checks to repair, all of them when empty
+}
+
+// IntegrityRepairResponse represents the records a check repaired
+type IntegrityRepairResponse struct {
+	Check    string `json:"check" example:"negative_stock"`
+	Repaired int64  `json:"repaired" example:"2"`
+}
+
+// RepairIntegrityResponse represents the outcome of an integrity repair
+type RepairIntegrityResponse struct {
+	Repaired int64                     `json:"repaired" example:"2"` // Records repaired by all checks
+	Checks   []IntegrityRepairResponse `json:"checks"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+// MarketPricesRequest represents the query of a market price lookup
+type MarketPricesRequest struct {
+	Refresh bool `form:"refresh"` // Ask the providers again instead of using cached prices
+}
+
+// MarketPriceQuote represents the price a competitor sells a product at
+type MarketPriceQuote struct {
+	Provider   string   `json:"provider" example:"compare"`
+	Competitor string   `json:"competitor" example:"Example Shop"`
+	Price      float64  `json:"price" example:"94.5"`
+	Currency   string   `json:"currency" example:"USD"`
+	URL        string   `json:"url,omitempty" example:"https://shop.example.com/p/123"`
+	InStock    *bool    `json:"in_stock,omitempty" example:"true"`           // Omitted when the provider doesn't tell
+	Difference *float64 `json:"difference_percent,omitempty" example:"-5.5"` // How much cheaper (negative) or dearer than our price, in percent; omitted for other currencies
+	ObservedAt Time     `json:"observed_at" example:"2025-01-01T00:00:00Z"`  // When the provider saw the price
+	FetchedAt  Time     `json:"fetched_at" example:"2025-01-01T00:05:00Z"`   // When it was fetched from the provider
+}
+
+// MarketPricesResponse represents the competitor prices of a product
+type MarketPricesResponse struct {
+	ProductID uint               `json:"product_id" example:"42"`
+	SKU       string             `json:"sku" example:"ACME-1001"`
+	Price     float64            `json:"price" example:"99.99"`  // Our list price
+	Currency  string             `json:"currency" example:"USD"` // Currency of our price
+	Quotes    []MarketPriceQuote `json:"quotes"`                 // Cheapest first
+	Lowest    *MarketPriceQuote  `json:"lowest,omitempty"`       // Cheapest quote in our currency
+	Errors    map[string]string  `json:"errors,omitempty"`       // Providers that failed, with their errors
+}
@@ -0,0 +1,85 @@
+package dto
+
+// ShipmentItemRequest represents a quantity of a product of an order packed in a shipment
+type ShipmentItemRequest struct {
+	ProductID uint `json:"product_id" binding:"required" example:"1"`
+	Quantity  int  `json:"quantity" binding:"required,min=1" example:"2"`
+}
+
+// CreateShipmentRequest represents the request body for recording a shipment of an order
+type CreateShipmentRequest struct {
+	Carrier        string                `json:"carrier" binding:"required,max=50" example:"DHL"`
+	TrackingNumber string                `json:"tracking_number" binding:"required,max=100" example:"JD014600006281234567"`
+	TrackingURL    string                `json:"tracking_url" binding:"omitempty,url,max=500" example:"https://www.dhl.com/track?id=JD014600006281234567"`
+	ShippedAt      *Time                 `json:"shipped_at" example:"2021-01-04T00:00:00Z"` // Now when omitted
+	Items          []ShipmentItemRequest `json:"items" binding:"omitempty,max=50,dive"`     // Everything not shipped yet when omitted
+}
+
+// ShipmentResponse represents a parcel of an order handed to a carrier
+type ShipmentResponse struct {
+	ID             uint                  `json:"id" example:"1"`
+	Carrier        string                `json:"carrier" example:"DHL"`
+	TrackingNumber string                `json:"tracking_number" example:"JD014600006281234567"`
+	TrackingURL    string                `json:"tracking_url,omitempty" example:"https://www.dhl.com/track?id=JD014600006281234567"`
+	ShippedAt      Time                  `json:"shipped_at" example:"2021-01-04T00:00:00Z"`
+	Items          []TrackedItemResponse `json:"items"`
+}
+
+// TrackedItemResponse represents an item of a tracked order, without its price
+type TrackedItemResponse struct {
+	ProductName string `json:"product_name" example:"SmartWatch Pro"`
+	Quantity    int    `json:"quantity" example:"2"`
+}
+
+// OrderTrackingResponse represents what the tracking page of an order shows
+// to anyone holding its link
+type OrderTrackingResponse struct {
+	OrderNumber string                `json:"order_number" example:"ORD-12"`
+	Status      string                `json:"status" example:"shipped" enums:"processing,confirmed,shipped,cancelled"`
+	PlacedAt    *Time                 `json:"placed_at,omitempty" example:"2021-01-03T00:00:00Z"`
+	Items       []TrackedItemResponse `json:"items"`
+	ItemCount   int                   `json:"item_count" example:"2"` // Units across all items
+	Shipments   []ShipmentResponse    `json:"shipments"`
+}
+
+// PickListRequest represents the request body for generating a pick list
+type PickListRequest struct {
+	QuoteIDs []uint `json:"quote_ids" binding:"omitempty,max=100" example:"12,14"`    // Open orders to pick, by quote ID; the oldest 100 when omitted
+	Format   string `json:"format" binding:"omitempty,oneof=json pdf" example:"json"` // json by default
+}
+
+// PickListOrderResponse represents an order picked by a pick list
+type PickListOrderResponse struct {
+	QuoteID     uint   `json:"quote_id" example:"12"`
+	OrderNumber string `json:"order_number" example:"ORD-12"`
+}
+
+// PickOrderQuantity represents the units of a pick list line going to one order
+type PickOrderQuantity struct {
+	OrderNumber string `json:"order_number" example:"ORD-12"`
+	Quantity    int    `json:"quantity" example:"2"`
+}
+
+// PickLineResponse represents a product to pick for a batch of orders
+type PickLineResponse struct {
+	ProductID   uint                `json:"product_id" example:"1"`
+	ProductName string              `json:"product_name" example:"SmartWatch Pro"`
+	SKU         string              `json:"sku,omitempty" example:"SW-PRO-BLK"`
+	Quantity    int                 `json:"quantity" example:"3"` // Units not shipped yet, across the orders
+	Orders      []PickOrderQuantity `json:"orders"`
+}
+
+// PickLocationResponse represents the products to pick at a warehouse location
+type PickLocationResponse struct {
+	Location string             `json:"location" example:"A-03-2"` // Empty for products without a location
+	Lines    []PickLineResponse `json:"lines"`
+}
+
+// PickListResponse represents the products to pick for a batch of open
+// orders, grouped by warehouse location in walking order
+type PickListResponse struct {
+	GeneratedAt Time                    `json:"generated_at" example:"2021-01-04T00:00:00Z"`
+	Orders      []PickListOrderResponse `json:"orders"`
+	Locations   []PickLocationResponse  `json:"locations"`
+	Units       int                     `json:"units" example:"5"` // Units to pick across every location
+}
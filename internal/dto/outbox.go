@@ -0,0 +1,32 @@
+package dto
+
+// ListOutboxEventsRequest represents the query parameters for listing outbox events
+type ListOutboxEventsRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending published failed"`
+	Topic    string `form:"topic"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// ReprocessOutboxRequest represents a request to publish outbox events again
+type ReprocessOutboxRequest struct {
+	IDs []uint `json:"ids" binding:"omitempty,max=1000" example:"12,13"` // Events to publish again whatever their status; every failed event when empty
+}
+
+// ReprocessOutboxResponse represents the outcome of a reprocessing request
+type ReprocessOutboxResponse struct {
+	Queued int64 `json:"queued" example:"2"` // Events queued for the relay
+}
+
+// OutboxEventResponse represents an event recorded in the outbox
+type OutboxEventResponse struct {
+	ID            uint   `json:"id" example:"12"`
+	Topic         string `json:"topic" example:"product.changed"`
+	Payload       string `json:"payload" example:"{\"ProductID\":1,\"Deleted\":false}"` // JSON of the event
+	Status        string `json:"status" example:"failed" enums:"pending,published,failed"`
+	Attempts      int    `json:"attempts" example:"10"`
+	NextAttemptAt Time   `json:"next_attempt_at" example:"2021-01-01T00:00:00Z"` // When a pending event is next attempted
+	LastError     string `json:"last_error,omitempty"`
+	PublishedAt   *Time  `json:"published_at,omitempty" example:"2021-01-01T00:00:01Z"`
+	CreatedAt     Time   `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
@@ -0,0 +1,57 @@
+package dto
+
+// PriceListRequest represents the request body for creating or updating a price list
+type PriceListRequest struct {
+	Name        string `json:"name" binding:"required,max=100" example:"Wholesale tier A"`
+	Description string `json:"description" binding:"max=500" example:"Resellers with yearly contracts"`
+}
+
+// PriceBreak is a unit price that applies from a minimum quantity on
+type PriceBreak struct {
+	MinQuantity int     `json:"min_quantity" binding:"required,min=1" example:"10"`
+	Price       float64 `json:"price" binding:"required,gt=0" example:"249.99"`
+}
+
+// PriceListItemResponse represents the unit price of a product in a price list from a minimum quantity on
+type PriceListItemResponse struct {
+	ProductID   uint    `json:"product_id" example:"1"`
+	MinQuantity int     `json:"min_quantity" example:"10"`
+	Price       float64 `json:"price" example:"249.99"`
+}
+
+// PriceListResponse represents a price list. Items are only included when getting a single list.
+type PriceListResponse struct {
+	ID          uint                    `json:"id" example:"1"`
+	Name        string                  `json:"name" example:"Wholesale tier A"`
+	Description string                  `json:"description" example:"Resellers with yearly contracts"`
+	Items       []PriceListItemResponse `json:"items,omitempty"`
+	CreatedAt   Time                    `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt   Time                    `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// SetProductPricesRequest represents the request body for setting the prices of a
+// product in a price list. An empty list of breaks removes the product.
+type SetProductPricesRequest struct {
+	Breaks []PriceBreak `json:"breaks" binding:"max=20,dive"`
+}
+
+// AssignPriceListRequest represents the request body for assigning a price list
+// to a user. A null price_list_id moves the user back to list prices.
+type AssignPriceListRequest struct {
+	PriceListID *uint `json:"price_list_id" example:"1"`
+}
+
+// ProductPriceRequest represents the query parameters for pricing a quantity of a product
+type ProductPriceRequest struct {
+	Quantity int `form:"quantity" binding:"omitempty,min=1"`
+}
+
+// ProductPriceResponse represents the price of a quantity of a product for the current user
+type ProductPriceResponse struct {
+	ProductID   uint                `json:"product_id" example:"1"`
+	Quantity    int                 `json:"quantity" example:"10"`
+	ListPrice   float64             `json:"list_price" example:"299.99"`
+	UnitPrice   float64             `json:"unit_price" example:"249.99"` // After pricing rules, the customer's price list and quantity breaks
+	PricingRule *AppliedPricingRule `json:"pricing_rule,omitempty"`      // Rule that set unit_price, if any
+	Total       float64             `json:"total" example:"2499.9"`
+}
@@ -0,0 +1,90 @@
+package dto
+
+// PricingRuleRequest represents the request body for creating or replacing a
+// pricing rule. Conditions left out match every product.
+type PricingRuleRequest struct {
+	Name        string  `json:"name" binding:"required,max=100" example:"Weekend audio sale"`
+	Description string  `json:"description" binding:"max=500" example:"10% off headphones this weekend"`
+	Priority    int     `json:"priority" example:"10"`                                         // Higher wins when several rules match a product
+	Enabled     *bool   `json:"enabled" example:"true"`                                        // Enabled when omitted
+	StartsAt    *Time   `json:"starts_at" example:"2025-06-07T00:00:00Z"`                      // Applies at once when omitted
+	EndsAt      *Time   `json:"ends_at" example:"2025-06-09T00:00:00Z"`                        // Applies until removed when omitted
+	CategoryID  *uint   `json:"category_id" example:"3"`                                       // Only products in the category
+	MinStock    *int    `json:"min_stock" binding:"omitempty,min=0" example:"50"`              // Only products with more units in stock
+	NoSalesDays *int    `json:"no_sales_days" binding:"omitempty,min=1,max=3650" example:"60"` // Only products listed and not ordered for that many days
+	Adjustment  string  `json:"adjustment" binding:"required,oneof=percent_off amount_off fixed_price" example:"percent_off"`
+	Value       float64 `json:"value" binding:"required,gt=0" example:"10"` // Percentage, amount or price, depending on the adjustment
+}
+
+// PricingRuleResponse represents a pricing rule
+type PricingRuleResponse struct {
+	ID          uint    `json:"id" example:"1"`
+	Name        string  `json:"name" example:"Weekend audio sale"`
+	Description string  `json:"description" example:"10% off headphones this weekend"`
+	Priority    int     `json:"priority" example:"10"`
+	Enabled     bool    `json:"enabled" example:"true"`
+	Live        bool    `json:"live" example:"false"` // Whether it is enabled and its schedule covers the current time
+	StartsAt    *Time   `json:"starts_at" example:"2025-06-07T00:00:00Z"`
+	EndsAt      *Time   `json:"ends_at" example:"2025-06-09T00:00:00Z"`
+	CategoryID  *uint   `json:"category_id" example:"3"`
+	MinStock    *int    `json:"min_stock" example:"50"`
+	NoSalesDays *int    `json:"no_sales_days" example:"60"`
+	Adjustment  string  `json:"adjustment" example:"percent_off" enums:"percent_off,amount_off,fixed_price"`
+	Value       float64 `json:"value" example:"10"`
+	UpdatedBy   uint    `json:"updated_by" example:"1"`
+	CreatedAt   Time    `json:"created_at" example:"2025-01-01T00:00:00Z"`
+	UpdatedAt   Time    `json:"updated_at" example:"2025-01-01T00:00:00Z"`
+}
+
+// AppliedPricingRule names the pricing rule that set a price
+type AppliedPricingRule struct {
+	ID   uint   `json:"id" example:"1"`
+	Name string `json:"name" example:"Weekend audio sale"`
+}
+
+// SimulatePricingRequest represents the request body for previewing prices.
+// Without a rule it shows the prices the live rules give at a time; with one
+// it also shows the prices once the rule is added, or replaces rule_id.
+type SimulatePricingRequest struct {
+	Rule       *PricingRuleRequest `json:"rule"`
+	RuleID     *uint               `json:"rule_id" example:"1"`                         // Existing rule the draft replaces, to preview an edit
+	At         *Time               `json:"at" example:"2025-06-08T12:00:00Z"`           // Now when omitted
+	ProductIDs []uint              `json:"product_ids" binding:"max=200" example:"1,2"` // Active products of the rule's category, or of the store, when omitted
+}
+
+// PricingSimulationItem represents the price of a product before and after a
+// simulated change to the pricing rules
+type PricingSimulationItem struct {
+	ProductID      uint                `json:"product_id" example:"1"`
+	ProductName    string              `json:"product_name" example:"Studio Headphones"`
+	ListPrice      float64             `json:"list_price" example:"199.99"`
+	CurrentPrice   float64             `json:"current_price" example:"199.99"` // With the rules as they are
+	CurrentRule    *AppliedPricingRule `json:"current_rule,omitempty"`
+	SimulatedPrice float64             `json:"simulated_price" example:"179.99"` // With the draft rule
+	SimulatedRule  *AppliedPricingRule `json:"simulated_rule,omitempty"`
+}
+
+// PricingSimulationResponse represents a preview of product prices at a time
+type PricingSimulationResponse struct {
+	At       Time                    `json:"at" example:"2025-06-08T12:00:00Z"`
+	Affected int                     `json:"affected" example:"1"` // Products whose price the draft rule changes
+	Products []PricingSimulationItem `json:"products"`
+}
+
+// ListPricingRuleApplicationsRequest represents the query parameters for
+// listing the quote items a pricing rule priced
+type ListPricingRuleApplicationsRequest struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// PricingRuleApplicationResponse represents a quote item priced by a rule when
+// the quote was requested
+type PricingRuleApplicationResponse struct {
+	QuoteID     uint    `json:"quote_id" example:"12"`
+	ProductID   uint    `json:"product_id" example:"1"`
+	ProductName string  `json:"product_name" example:"Studio Headphones"`
+	Quantity    int     `json:"quantity" example:"5"`
+	UnitPrice   float64 `json:"unit_price" example:"179.99"`
+	AppliedAt   Time    `json:"applied_at" example:"2025-06-08T12:00:00Z"`
+}
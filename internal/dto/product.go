@@ -2,32 +2,59 @@ package dto
 
 // CreateProductRequest represents the request body for creating a new product
 type CreateProductRequest struct {
-	Name        string  `json:"name" binding:"required" example:"SmartWatch Pro"`    // Product name
-	Description string  `json:"description" example:"Advanced smartwatch"`           // Product description
-	Price       float64 `json:"price" binding:"required,gt=0" example:"299.99"`      // Product price
-	Quantity    int     `json:"quantity" binding:"required,gte=0" example:"100"`     // Stock quantity
-	Categories  []uint  `json:"categories" binding:"required,min=1" example:"1,2,3"` // Category IDs
+	Name             string                 `json:"name" binding:"required" example:"SmartWatch Pro"`                               // Product name
+	Description      string                 `json:"description" example:"Advanced smartwatch"`                                      // Product description
+	SKU              string                 `json:"sku" binding:"omitempty,max=64,printascii" example:"SW-PRO-BLK"`                 // Stock keeping unit
+	Location         string                 `json:"location" binding:"omitempty,max=50,printascii" example:"A-03-2"`                // Warehouse location pick lists are grouped by
+	Bookable         bool                   `json:"bookable" example:"false"`                                                       // Reserved for availability slots, e.g. rentals and services
+	Price            float64                `json:"price" binding:"required,gt=0" example:"299.99"`                                 // Product price
+	Quantity         int                    `json:"quantity" binding:"required,gte=0" example:"100"`                                // Stock quantity
+	Categories       []uint                 `json:"categories" binding:"required,min=1" example:"1,2,3"`                            // Category IDs
+	AllowedCountries []string               `json:"allowed_countries" binding:"omitempty,max=250,dive,len=2,alpha" example:"VN,TH"` // Only markets the product is sold in, all when empty
+	BlockedCountries []string               `json:"blocked_countries" binding:"omitempty,max=250,dive,len=2,alpha" example:"US"`    // Markets the product is withheld from
+	Metadata         map[string]interface{} `json:"metadata"`                                                                       // Custom attributes, checked against the schemas of its categories
 }
 
 // UpdateProductRequest represents the request body for updating a product
 type UpdateProductRequest struct {
-	Name        string  `json:"name" binding:"required" example:"SmartWatch Pro 2"`                     // Product name
-	Description string  `json:"description" example:"Updated smartwatch features"`                      // Product description
-	Price       float64 `json:"price" binding:"required,gt=0" example:"349.99"`                         // Product price
-	Quantity    int     `json:"quantity" binding:"required,gte=0" example:"150"`                        // Stock quantity
-	Categories  []uint  `json:"categories" binding:"required,min=1" example:"1,2,3"`                    // Category IDs
-	Status      string  `json:"status" binding:"required,oneof=active inactive draft" example:"active"` // Product status
+	Name             string                 `json:"name" binding:"required" example:"SmartWatch Pro 2"`                             // Product name
+	Description      string                 `json:"description" example:"Updated smartwatch features"`                              // Product description
+	SKU              string                 `json:"sku" binding:"omitempty,max=64,printascii" example:"SW-PRO2-BLK"`                // Stock keeping unit
+	Location         string                 `json:"location" binding:"omitempty,max=50,printascii" example:"A-03-2"`                // Warehouse location pick lists are grouped by
+	Bookable         bool                   `json:"bookable" example:"false"`                                                       // Reserved for availability slots, e.g. rentals and services
+	Price            float64                `json:"price" binding:"required,gt=0" example:"349.99"`                                 // Product price
+	Quantity         int                    `json:"quantity" binding:"required,gte=0" example:"150"`                                // Stock quantity
+	Categories       []uint                 `json:"categories" binding:"required,min=1" example:"1,2,3"`                            // Category IDs
+	Status           string                 `json:"status" binding:"required,oneof=active inactive draft" example:"active"`         // Product status
+	AllowedCountries []string               `json:"allowed_countries" binding:"omitempty,max=250,dive,len=2,alpha" example:"VN,TH"` // Only markets the product is sold in, all when empty
+	BlockedCountries []string               `json:"blocked_countries" binding:"omitempty,max=250,dive,len=2,alpha" example:"US"`    // Markets the product is withheld from
+	Metadata         map[string]interface{} `json:"metadata"`                                                                       // Custom attributes, checked against the schemas of its categories
 }
 
 // ProductResponse represents the response for product operations
 type ProductResponse struct {
-	ID          uint             `json:"id" example:"1"`                            // Product ID
-	Name        string           `json:"name" example:"SmartWatch Pro"`             // Product name
-	Description string           `json:"description" example:"Advanced smartwatch"` // Product description
-	Price       float64          `json:"price" example:"299.99"`                    // Product price
-	Quantity    int              `json:"quantity" example:"100"`                    // Stock quantity
-	Status      string           `json:"status" example:"active"`                   // Product status
-	Categories  []CategoryOutput `json:"categories"`                                // Associated categories
+	ID               uint                   `json:"id" example:"1"`                              // Product ID
+	Name             string                 `json:"name" example:"SmartWatch Pro"`               // Product name
+	Description      string                 `json:"description" example:"Advanced smartwatch"`   // Product description
+	SKU              string                 `json:"sku,omitempty" example:"SW-PRO-BLK"`          // Stock keeping unit
+	Location         string                 `json:"location,omitempty" example:"A-03-2"`         // Warehouse location
+	Bookable         bool                   `json:"bookable" example:"false"`                    // Reserved for availability slots rather than sold from stock
+	Price            float64                `json:"price" example:"299.99"`                      // Product price
+	Quantity         int                    `json:"quantity" example:"100"`                      // Stock quantity
+	Status           string                 `json:"status" example:"active"`                     // Product status
+	RatingAverage    float64                `json:"rating_average" example:"4.5"`                // Average review rating
+	RatingCount      int                    `json:"rating_count" example:"12"`                   // Number of reviews
+	AllowedCountries []string               `json:"allowed_countries,omitempty" example:"VN,TH"` // Only markets the product is sold in, all when empty
+	BlockedCountries []string               `json:"blocked_countries,omitempty" example:"US"`    // Markets the product is withheld from
+	Categories       []CategoryOutput       `json:"categories"`                                  // Associated categories
+	Images           []ProductImageResponse `json:"images"`                                      // Images in display order, the first being the main one
+	Metadata         map[string]interface{} `json:"metadata,omitempty"`                          // Custom attributes
+	CustomerPrice    *float64               `json:"customer_price,omitempty" example:"279.99"`   // Unit price after promotions, pricing rules and the current user's price list
+	PricingRule      *AppliedPricingRule    `json:"pricing_rule,omitempty"`                      // Rule that set customer_price, if any
+	Promotion        *ProductPromotion      `json:"promotion,omitempty"`                         // Active promotion, with the original and promotional price
+	PriceBreaks      []PriceBreak           `json:"price_breaks,omitempty"`                      // Quantity breaks from the current user's price list
+	CreatedAt        Time                   `json:"created_at" example:"2025-01-01T00:00:00Z"`   // Creation time
+	UpdatedAt        Time                   `json:"updated_at" example:"2025-01-01T00:00:00Z"`   // Last update time
 }
 
 // CategoryOutput represents the category data in product responses
@@ -44,12 +71,199 @@ type ProductListResponse struct {
 	PageSize int               `json:"page_size" example:"10"` // Number of items per page
 }
 
+// BatchRequest represents the query of a batch lookup by ID
+type BatchRequest struct {
+	IDs string `form:"ids" binding:"required" example:"1,2,3"` // Comma-separated IDs, at most 100
+}
+
+// ProductBatchResponse represents products looked up in a batch
+type ProductBatchResponse struct {
+	Items   []ProductResponse `json:"items"`                 // Products found, in the order requested
+	Missing []uint            `json:"missing" example:"4,7"` // Requested IDs that were not found
+}
+
+// WishlistItemResponse represents a product in a user's wishlist
+type WishlistItemResponse struct {
+	ID        uint            `json:"id" example:"1"`                          // Wishlist item ID
+	ProductID uint            `json:"product_id" example:"1"`                  // Product ID
+	Product   ProductResponse `json:"product"`                                 // Wishlisted product
+	AddedAt   Time            `json:"added_at" example:"2025-01-01T00:00:00Z"` // Time the product was added
+}
+
 // ProductSearchRequest represents the request for searching products
 type ProductSearchRequest struct {
-	Search     string   `form:"search"`               // Search query
-	CategoryID uint     `form:"category"`             // Filter by category ID
-	Statuses   []string `form:"status"`               // Filter by statuses
-	Sort       string   `form:"sort"`                 // Sort field
-	Page       int      `form:"page,default=1"`       // Page number
-	PageSize   int      `form:"page_size,default=10"` // Items per page
+	Search     string   `form:"search"`    // Search query
+	CategoryID uint     `form:"category"`  // Filter by category ID
+	Statuses   []string `form:"status"`    // Filter by statuses
+	Sort       string   `form:"sort"`      // Sort field
+	Page       int      `form:"page"`      // Page number
+	PageSize   int      `form:"page_size"` // Items per page
+}
+
+// WishlistCountResponse represents the wishlist item counts
+type WishlistCountResponse struct {
+	TotalWishlistCount int64 `json:"total_wishlist_count" example:"42"` // Wishlist items across all users
+	MyWishlistCount    int64 `json:"my_wishlist_count" example:"3"`     // Wishlist items of the current user
+}
+
+// FieldChange represents the old and new value of a changed field
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// ProductSnapshot represents the editable state of a product, as proposed by change requests and recorded by revisions
+type ProductSnapshot struct {
+	Name             string                 `json:"name"`
+	Description      string                 `json:"description"`
+	SKU              string                 `json:"sku,omitempty"`
+	Location         string                 `json:"location,omitempty"`
+	Bookable         bool                   `json:"bookable,omitempty"`
+	Price            float64                `json:"price"`
+	StockQuantity    int                    `json:"stock_quantity"`
+	Status           string                 `json:"status"`
+	Categories       []uint                 `json:"categories"`
+	AllowedCountries []string               `json:"allowed_countries,omitempty"`
+	BlockedCountries []string               `json:"blocked_countries,omitempty"`
+	Metadata         map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// ProductChangeRequestResponse represents a product change request
+type ProductChangeRequestResponse struct {
+	ID          uint                   `json:"id" example:"1"`
+	ProductID   uint                   `json:"product_id" example:"1"`
+	RequestedBy uint                   `json:"requested_by" example:"2"`
+	Status      string                 `json:"status" example:"pending" enums:"pending,approved,rejected"`
+	Changes     map[string]FieldChange `json:"changes"`
+	ReviewedBy  *uint                  `json:"reviewed_by,omitempty" example:"1"`
+	ReviewedAt  *Time                  `json:"reviewed_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	ReviewNote  string                 `json:"review_note,omitempty"`
+	CreatedAt   Time                   `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// ListChangeRequestsRequest represents the query parameters for listing change requests
+type ListChangeRequestsRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// ReviewChangeRequestRequest represents the request body for approving or rejecting a change request
+type ReviewChangeRequestRequest struct {
+	Note string `json:"note" binding:"max=500" example:"Price confirmed with supplier"`
+}
+
+// ProductRevisionResponse represents a recorded product revision
+type ProductRevisionResponse struct {
+	ID        uint            `json:"id" example:"1"`
+	ProductID uint            `json:"product_id" example:"1"`
+	Revision  int             `json:"revision" example:"3"`
+	EditedBy  uint            `json:"edited_by" example:"1"`
+	Snapshot  ProductSnapshot `json:"snapshot"`
+	CreatedAt Time            `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// StockMovementResponse represents an entry in a product's stock ledger
+type StockMovementResponse struct {
+	ID                uint   `json:"id" example:"1"`
+	Source            string `json:"source" example:"adjustment" enums:"order,sale,restock,return,adjustment,import,purchase_order,sync"`
+	Reference         string `json:"reference,omitempty" example:"order-1001"`
+	Delta             int    `json:"delta" example:"-2"`
+	ResultingQuantity int    `json:"resulting_quantity" example:"98"`
+	ActorID           uint   `json:"actor_id" example:"1"`
+	Note              string `json:"note,omitempty" example:"product update"`
+	CreatedAt         Time   `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// AdjustStockRequest represents the request body for recording a stock
+// movement by hand
+type AdjustStockRequest struct {
+	Delta     int    `json:"delta" binding:"required" example:"-2"`                                         // Units added, or removed when negative
+	Reason    string `json:"reason" binding:"required,oneof=sale restock return adjustment" example:"sale"` // sale removes and restock and return add units; adjustment does either
+	Reference string `json:"reference" binding:"omitempty,max=100" example:"shop-receipt-881"`
+	Note      string `json:"note" binding:"omitempty,max=500" example:"Sold at the Hanoi shop"`
+}
+
+// BarcodeRequest represents the query parameters for rendering a product barcode
+type BarcodeRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=code128 qr"` // Barcode format, code128 by default
+	Type   string `form:"type" binding:"omitempty,oneof=png svg"`      // Image type, png by default
+	Size   int    `form:"size" binding:"omitempty,min=1,max=20"`       // Pixels per module, 3 by default
+}
+
+// ProductLabelsRequest represents the request body for printing product labels
+type ProductLabelsRequest struct {
+	ProductIDs []uint `json:"product_ids" binding:"required,min=1,max=100,dive,gt=0" example:"1,2,3"`         // Products in print order
+	Template   string `json:"template" binding:"required,oneof=spec_sheet shelf_label" example:"shelf_label"` // spec_sheet (a page per product) or shelf_label (70 x 37 mm, 24 per A4 sheet)
+	Barcode    string `json:"barcode" binding:"omitempty,oneof=code128 qr" example:"code128"`                 // Barcode format for products with an SKU, code128 by default
+}
+
+// ProductViewRequest represents the optional request body for recording a product view
+type ProductViewRequest struct {
+	AnonymousID string `json:"anonymous_id" binding:"omitempty,max=64" example:"3f2a9c1e-7b44-4d8e-9a51-0c6f2d8b1e77"` // Identifies a visitor who is not signed in, generated and kept by the client
+	Referrer    string `json:"referrer" binding:"omitempty,max=500" example:"https://example.com/search?q=lamp"`       // Page the visitor came from, the Referer header by default
+}
+
+// ProductRankingRequest represents the query parameters of the product rankings built from views
+type ProductRankingRequest struct {
+	Days  int `form:"days" binding:"omitempty,min=1,max=30"`  // Views of the last days that count, 7 by default
+	Limit int `form:"limit" binding:"omitempty,min=1,max=50"` // Number of products, 10 by default
+}
+
+// ProductImageResponse represents an image of a product
+type ProductImageResponse struct {
+	ID          uint   `json:"id" example:"7"`                            // Image ID
+	URL         string `json:"url" example:"/api/v1/products/1/images/7"` // Where the image can be fetched
+	Alt         string `json:"alt" example:"SmartWatch Pro, front view"`  // Alternative text
+	Position    int    `json:"position" example:"0"`                      // Display position, ascending
+	ContentType string `json:"content_type" example:"image/jpeg"`         // Image type
+	Size        int64  `json:"size" example:"184320"`                     // File size in bytes
+	CreatedAt   Time   `json:"created_at" example:"2025-01-01T00:00:00Z"` // Upload time
+}
+
+// ProductImageOrderRequest represents the request body for reordering a product's images
+type ProductImageOrderRequest struct {
+	ImageIDs []uint `json:"image_ids" binding:"required,min=1,max=20" example:"7,3,5"` // Every image ID of the product, in the new display order
+}
+
+// ListDuplicatesRequest represents the query parameters for listing likely duplicate products
+type ListDuplicatesRequest struct {
+	MinSimilarity float64 `form:"min_similarity" binding:"omitempty,min=0.3,max=1"` // Name similarity from which pairs are reported, 0.6 by default
+	Page          int     `form:"page" binding:"omitempty,min=1"`
+	PageSize      int     `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// DuplicateProductResponse represents a product of a likely duplicate pair
+type DuplicateProductResponse struct {
+	ID     uint    `json:"id" example:"3"`
+	Name   string  `json:"name" example:"SmartWatch Pro"`
+	SKU    string  `json:"sku,omitempty" example:"SW-PRO-01"`
+	Price  float64 `json:"price" example:"199.99"`
+	Status string  `json:"status" example:"active"`
+}
+
+// DuplicateCandidateResponse represents a pair of products that are likely the same one
+type DuplicateCandidateResponse struct {
+	Product        DuplicateProductResponse `json:"product"`                        // The older of the two
+	Duplicate      DuplicateProductResponse `json:"duplicate"`                      // The newer of the two
+	Score          float64                  `json:"score" example:"0.92"`           // From 0 to 1, how likely the products are the same
+	NameSimilarity float64                  `json:"name_similarity" example:"0.82"` // Share of name trigrams in common
+	SameSKU        bool                     `json:"same_sku" example:"false"`       // SKUs match apart from case, spaces and punctuation
+	SamePrice      bool                     `json:"same_price" example:"true"`
+}
+
+// MergeProductsRequest represents the request body for merging a duplicate into a product
+type MergeProductsRequest struct {
+	DuplicateID uint `json:"duplicate_id" binding:"required" example:"7"` // Product deleted once its reviews, wishlist entries and categories are moved
+}
+
+// MergeProductsResponse represents what a merge moved onto the surviving product
+type MergeProductsResponse struct {
+	ProductID        uint  `json:"product_id" example:"3"`
+	DuplicateID      uint  `json:"duplicate_id" example:"7"`
+	ReviewsMoved     int64 `json:"reviews_moved" example:"12"`
+	ReviewsDropped   int64 `json:"reviews_dropped" example:"1"` // By users who had reviewed the surviving product too
+	WishlistsMoved   int64 `json:"wishlists_moved" example:"30"`
+	WishlistsDropped int64 `json:"wishlists_dropped" example:"2"` // Of users who had both products in their wishlist
+	CategoriesAdded  int64 `json:"categories_added" example:"1"`
 }
@@ -0,0 +1,46 @@
+package dto
+
+// PromotionRequest represents the request body for scheduling or replacing a
+// promotion of either a product or a category
+type PromotionRequest struct {
+	Name       string  `json:"name" binding:"required,max=100" example:"Black Friday audio"`
+	ProductID  *uint   `json:"product_id" example:"1"`  // Discounts this product
+	CategoryID *uint   `json:"category_id" example:"3"` // Discounts every product in this category
+	Discount   string  `json:"discount" binding:"required,oneof=percent_off amount_off" example:"percent_off"`
+	Value      float64 `json:"value" binding:"required,gt=0" example:"20"` // Percentage or amount off the list price
+	StartsAt   *Time   `json:"starts_at" example:"2025-11-28T00:00:00Z"`   // At once when omitted
+	EndsAt     Time    `json:"ends_at" example:"2025-12-01T00:00:00Z"`
+}
+
+// ListPromotionsRequest represents the query parameters for listing promotions
+type ListPromotionsRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=scheduled active ended"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// PromotionResponse represents a promotion
+type PromotionResponse struct {
+	ID         uint    `json:"id" example:"1"`
+	Name       string  `json:"name" example:"Black Friday audio"`
+	ProductID  *uint   `json:"product_id" example:"1"`
+	CategoryID *uint   `json:"category_id" example:"3"`
+	Discount   string  `json:"discount" example:"percent_off" enums:"percent_off,amount_off"`
+	Value      float64 `json:"value" example:"20"`
+	StartsAt   Time    `json:"starts_at" example:"2025-11-28T00:00:00Z"`
+	EndsAt     Time    `json:"ends_at" example:"2025-12-01T00:00:00Z"`
+	Status     string  `json:"status" example:"scheduled" enums:"scheduled,active,ended"`
+	UpdatedBy  uint    `json:"updated_by" example:"1"`
+	CreatedAt  Time    `json:"created_at" example:"2025-01-01T00:00:00Z"`
+	UpdatedAt  Time    `json:"updated_at" example:"2025-01-01T00:00:00Z"`
+}
+
+// ProductPromotion represents the active promotion of a product, with the
+// original price to strike through next to the promotional one
+type ProductPromotion struct {
+	ID               uint    `json:"id" example:"1"`
+	Name             string  `json:"name" example:"Black Friday audio"`
+	OriginalPrice    float64 `json:"original_price" example:"299.99"`
+	PromotionalPrice float64 `json:"promotional_price" example:"239.99"`
+	EndsAt           Time    `json:"ends_at" example:"2025-12-01T00:00:00Z"`
+}
@@ -0,0 +1,81 @@
+package dto
+
+// SupplierRequest represents the request body for creating or updating a supplier
+type SupplierRequest struct {
+	Name        string `json:"name" binding:"required,max=100" example:"Acme Components"`
+	ContactName string `json:"contact_name" binding:"max=100" example:"Jane Doe"`
+	Email       string `json:"email" binding:"omitempty,email,max=255" example:"orders@acme.example"`
+	Phone       string `json:"phone" binding:"max=30" example:"+14155550123"`
+	Notes       string `json:"notes" binding:"max=1000" example:"Ships on Tuesdays"`
+}
+
+// SupplierResponse represents a supplier
+type SupplierResponse struct {
+	ID          uint   `json:"id" example:"1"`
+	Name        string `json:"name" example:"Acme Components"`
+	ContactName string `json:"contact_name" example:"Jane Doe"`
+	Email       string `json:"email" example:"orders@acme.example"`
+	Phone       string `json:"phone" example:"+14155550123"`
+	Notes       string `json:"notes" example:"Ships on Tuesdays"`
+	CreatedAt   Time   `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// PurchaseOrderItemRequest represents a product and quantity to order from a supplier
+type PurchaseOrderItemRequest struct {
+	ProductID uint    `json:"product_id" binding:"required" example:"1"`
+	Quantity  int     `json:"quantity" binding:"required,min=1" example:"500"`
+	UnitCost  float64 `json:"unit_cost" binding:"gte=0" example:"120.5"`
+}
+
+// CreatePurchaseOrderRequest represents the request body for creating a purchase order
+type CreatePurchaseOrderRequest struct {
+	SupplierID uint                       `json:"supplier_id" binding:"required" example:"1"`
+	Items      []PurchaseOrderItemRequest `json:"items" binding:"required,min=1,max=100,dive"`
+	ExpectedAt *Time                      `json:"expected_at" example:"2021-01-15T00:00:00Z"`
+	Notes      string                     `json:"notes" binding:"max=1000" example:"Spring restock"`
+}
+
+// ReceivedItemRequest represents a quantity of a product delivered
+type ReceivedItemRequest struct {
+	ProductID uint `json:"product_id" binding:"required" example:"1"`
+	Quantity  int  `json:"quantity" binding:"required,min=1" example:"200"`
+}
+
+// ReceivePurchaseOrderRequest represents the request body for receiving a delivery
+type ReceivePurchaseOrderRequest struct {
+	Items []ReceivedItemRequest `json:"items" binding:"required,min=1,max=100,dive"`
+	Note  string                `json:"note" binding:"max=500" example:"Two pallets, one damaged box returned"`
+}
+
+// ListPurchaseOrdersRequest represents the query parameters for listing purchase orders
+type ListPurchaseOrdersRequest struct {
+	Status     string `form:"status" binding:"omitempty,oneof=open partial received cancelled"`
+	SupplierID uint   `form:"supplier_id"`
+	Page       int    `form:"page" binding:"omitempty,min=1"`
+	PageSize   int    `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// PurchaseOrderItemResponse represents a product ordered from a supplier and how much of it arrived
+type PurchaseOrderItemResponse struct {
+	ProductID        uint    `json:"product_id" example:"1"`
+	ProductName      string  `json:"product_name" example:"SmartWatch Pro"`
+	QuantityOrdered  int     `json:"quantity_ordered" example:"500"`
+	QuantityReceived int     `json:"quantity_received" example:"200"`
+	UnitCost         float64 `json:"unit_cost" example:"120.5"`
+}
+
+// PurchaseOrderResponse represents a purchase order and its delivery status
+type PurchaseOrderResponse struct {
+	ID           uint                        `json:"id" example:"1"`
+	Reference    string                      `json:"reference" example:"PO-1"` // Reference of its stock movements
+	SupplierID   uint                        `json:"supplier_id" example:"1"`
+	SupplierName string                      `json:"supplier_name" example:"Acme Components"`
+	Status       string                      `json:"status" example:"partial" enums:"open,partial,received,cancelled"`
+	Items        []PurchaseOrderItemResponse `json:"items"`
+	TotalCost    float64                     `json:"total_cost" example:"60250"`
+	ExpectedAt   *Time                       `json:"expected_at,omitempty" example:"2021-01-15T00:00:00Z"`
+	Notes        string                      `json:"notes,omitempty" example:"Spring restock"`
+	CreatedBy    uint                        `json:"created_by" example:"1"`
+	ClosedAt     *Time                       `json:"closed_at,omitempty" example:"2021-01-20T00:00:00Z"`
+	CreatedAt    Time                        `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
@@ -0,0 +1,82 @@
+package dto
+
+// QuoteItemRequest represents a product and quantity in a request for quote
+type QuoteItemRequest struct {
+	ProductID uint `json:"product_id" binding:"required" example:"1"`
+	Quantity  int  `json:"quantity" binding:"required,min=1" example:"250"`
+}
+
+// CreateQuoteRequest represents the request body for requesting a quote
+type CreateQuoteRequest struct {
+	Items []QuoteItemRequest `json:"items" binding:"required,min=1,max=50,dive"`
+	Note  string             `json:"note" binding:"max=1000" example:"Delivery to our Berlin warehouse in March"`
+}
+
+// QuotedPriceRequest represents the unit price offered for a product of a quote
+type QuotedPriceRequest struct {
+	ProductID uint    `json:"product_id" binding:"required" example:"1"`
+	UnitPrice float64 `json:"unit_price" binding:"required,gt=0" example:"239.5"`
+}
+
+// RespondToQuoteRequest represents the request body for pricing a quote. Every
+// product of the quote must be priced.
+type RespondToQuoteRequest struct {
+	Items      []QuotedPriceRequest `json:"items" binding:"required,min=1,max=50,dive"`
+	ValidUntil Time                 `json:"valid_until" example:"2021-02-01T00:00:00Z"`
+	Note       string               `json:"note" binding:"max=1000" example:"Includes free shipping"`
+}
+
+// GiftOptionRequest represents the gift options chosen for a product of a quote
+type GiftOptionRequest struct {
+	ProductID   uint   `json:"product_id" binding:"required" example:"1"`
+	GiftWrap    bool   `json:"gift_wrap" example:"true"` // Charged the gift_wrap_fee store setting per unit
+	GiftMessage string `json:"gift_message" binding:"max=500" example:"Happy birthday, Anna!"`
+	HidePrice   bool   `json:"hide_price" example:"true"` // Leave the price off the invoice, and with it the totals
+}
+
+// AcceptQuoteRequest represents the optional request body for accepting a quote
+type AcceptQuoteRequest struct {
+	CustomFields map[string]interface{} `json:"custom_fields"`                                                 // Values of the checkout form's custom fields, see GET /forms/checkout/schema
+	Gifts        []GiftOptionRequest    `json:"gifts" binding:"omitempty,max=50,dive"`                         // Gift options of products of the quote, each listed at most once
+	GiftCardCode string                 `json:"gift_card_code" binding:"max=32" example:"K7QM-2XRP-9TWD-HC4N"` // Gift card paying for the order before store credit
+}
+
+// ListQuotesRequest represents the query parameters for listing quotes
+type ListQuotesRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=requested quoted accepted declined"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// QuoteItemResponse represents a product and quantity in a quote
+type QuoteItemResponse struct {
+	ProductID     uint     `json:"product_id" example:"1"`
+	ProductName   string   `json:"product_name" example:"SmartWatch Pro"`
+	Quantity      int      `json:"quantity" example:"250"`
+	UnitPrice     float64  `json:"unit_price" example:"299.99"`            // Price without the quote when it was requested
+	PricingRuleID *uint    `json:"pricing_rule_id,omitempty" example:"1"`  // Pricing rule that set unit_price, if any
+	QuotedPrice   *float64 `json:"quoted_price,omitempty" example:"239.5"` // Unit price offered, once quoted
+	GiftWrap      bool     `json:"gift_wrap" example:"false"`
+	GiftMessage   string   `json:"gift_message,omitempty" example:"Happy birthday, Anna!"`
+	HidePrice     bool     `json:"hide_price" example:"false"` // Left off the invoice
+	GiftWrapFee   float64  `json:"gift_wrap_fee" example:"0"`  // For wrapping every unit, set when accepted
+}
+
+// QuoteResponse represents a quote and its status
+type QuoteResponse struct {
+	ID           uint                   `json:"id" example:"1"`
+	UserID       uint                   `json:"user_id" example:"2"`
+	Status       string                 `json:"status" example:"quoted" enums:"requested,quoted,accepted,declined"`
+	Expired      bool                   `json:"expired" example:"false"` // Quoted but past valid_until, so it can no longer be accepted
+	Items        []QuoteItemResponse    `json:"items"`
+	Subtotal     float64                `json:"subtotal" example:"59875"`   // Quoted price of the items, zero until quoted
+	GiftWrapFees float64                `json:"gift_wrap_fees" example:"0"` // Set when accepted
+	Total        float64                `json:"total" example:"59875"`      // Quoted total with gift wrapping, zero until quoted
+	Note         string                 `json:"note,omitempty" example:"Delivery to our Berlin warehouse in March"`
+	ResponseNote string                 `json:"response_note,omitempty" example:"Includes free shipping"`
+	ValidUntil   *Time                  `json:"valid_until,omitempty" example:"2021-02-01T00:00:00Z"`
+	RespondedAt  *Time                  `json:"responded_at,omitempty" example:"2021-01-02T00:00:00Z"`
+	DecidedAt    *Time                  `json:"decided_at,omitempty" example:"2021-01-03T00:00:00Z"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"` // Values of the checkout form's custom fields
+	CreatedAt    Time                   `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+// RateLimitGroupUsage represents a principal's quota usage in one route group
+type RateLimitGroupUsage struct {
+	Group     string `json:"group" example:"products"`
+	Tier      string `json:"tier" example:"user" enums:"anonymous,user,vendor,admin,api_key"`
+	Limit     int    `json:"limit" example:"300"`
+	Used      int    `json:"used" example:"42"`
+	Remaining int    `json:"remaining" example:"258"`
+	ResetAt   Time   `json:"reset_at" example:"2025-01-01T00:01:00Z"`
+}
+
+// RateLimitUsageResponse represents a principal's quota usage across route groups
+type RateLimitUsageResponse struct {
+	Principal string                `json:"principal" example:"user:42"`
+	Groups    []RateLimitGroupUsage `json:"groups"` // Groups used in the current window, by name
+}
@@ -0,0 +1,27 @@
+package dto
+
+// StartRebuildRequest represents the request body for starting a rebuild
+type StartRebuildRequest struct {
+	Target string `json:"target" binding:"required" example:"search" enums:"search,cache"`
+}
+
+// ListRebuildRunsRequest represents the query parameters for listing rebuild runs
+type ListRebuildRunsRequest struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// RebuildRunResponse represents a rebuild run with its progress
+type RebuildRunResponse struct {
+	ID          uint   `json:"id" example:"1"`
+	Target      string `json:"target" example:"search" enums:"search,cache"`
+	Status      string `json:"status" example:"running" enums:"running,succeeded,failed"`
+	TriggeredBy *uint  `json:"triggered_by,omitempty" example:"1"` // Admin who started it, missing for runs of the admin command
+	StartedAt   Time   `json:"started_at" example:"2021-01-01T00:00:00Z"`
+	FinishedAt  *Time  `json:"finished_at,omitempty" example:"2021-01-01T00:00:05Z"`
+	Total       int    `json:"total" example:"12000"`    // Items to rebuild, 0 until counted
+	Processed   int    `json:"processed" example:"4500"` // Items rebuilt so far
+	Progress    int    `json:"progress" example:"37"`    // Percentage of the items rebuilt
+	Changed     int    `json:"changed" example:"12"`     // Rating summaries that differed from the live ones when swapped in
+	Error       string `json:"error,omitempty"`          // Set when the run failed
+}
@@ -0,0 +1,24 @@
+package dto
+
+// ReportParamResponse describes a parameter of a report
+type ReportParamResponse struct {
+	Name        string `json:"name"`
+	Type        string `json:"type" enums:"int,date"`
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+}
+
+// ReportDefinitionResponse describes a report that can be run
+type ReportDefinitionResponse struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Params      []ReportParamResponse `json:"params"`
+}
+
+// ReportResponse represents the result of a report
+type ReportResponse struct {
+	Name      string          `json:"name"`
+	Columns   []string        `json:"columns"`
+	Rows      [][]interface{} `json:"rows"`      // Values in the order of columns
+	Truncated bool            `json:"truncated"` // Whether rows beyond the row limit were left out
+}
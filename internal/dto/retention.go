@@ -0,0 +1,26 @@
+package dto
+
+// RetentionRunResponse represents the outcome of the last cleanup of an entity
+type RetentionRunResponse struct {
+	RanAt        Time   `json:"ran_at" example:"2021-01-01T00:00:00Z"`
+	Deleted      int64  `json:"deleted" example:"120"`        // Records deleted by the last run
+	TotalDeleted int64  `json:"total_deleted" example:"4800"` // Records deleted by every run so far
+	DurationMs   int64  `json:"duration_ms" example:"35"`
+	Error        string `json:"error,omitempty"` // Set when the last run failed
+}
+
+// RetentionPolicyResponse represents the retention rule of an entity
+type RetentionPolicyResponse struct {
+	Entity      string                `json:"entity" example:"audit_logs"`
+	Description string                `json:"description" example:"Audit log entries, by when they were recorded"`
+	Enabled     bool                  `json:"enabled" example:"true"`                 // Whether RETENTION_RULES sets a maximum age
+	MaxAge      string                `json:"max_age,omitempty" example:"17520h0m0s"` // Records older than this are deleted
+	MaxAgeDays  float64               `json:"max_age_days,omitempty" example:"730"`   // MaxAge in days
+	LastRun     *RetentionRunResponse `json:"last_run,omitempty"`                     // Missing until the rule first runs
+}
+
+// RetentionResponse represents the retention policies and how often they are enforced
+type RetentionResponse struct {
+	Interval string                    `json:"interval" example:"24h0m0s"`
+	Policies []RetentionPolicyResponse `json:"policies"`
+}
@@ -14,16 +14,18 @@ type ReviewResponse struct {
 	UserID    uint             `json:"user_id"`
 	Rating    int              `json:"rating"`
 	Comment   string           `json:"comment"`
-	CreatedAt string           `json:"created_at"`
-	UpdatedAt string           `json:"updated_at"`
+	Imported  bool             `json:"imported"`         // Migrated from another store platform
+	Source    string           `json:"source,omitempty"` // Platform an imported review came from
+	CreatedAt Time             `json:"created_at"`
+	UpdatedAt Time             `json:"updated_at"`
 	User      *UserOutput      `json:"user,omitempty"`
 	Product   *ProductResponse `json:"product,omitempty"`
 }
 
 // ReviewSearchRequest represents the request parameters for searching reviews
 type ReviewSearchRequest struct {
-	Page        int    `form:"page" binding:"min=1" default:"1"`
-	PageSize    int    `form:"page_size" binding:"min=1,max=100" default:"10"`
+	Page        int    `form:"page" binding:"omitempty,min=1"`
+	PageSize    int    `form:"page_size" binding:"omitempty,min=1"`
 	ProductName string `form:"product_name"`
 	SortBy      string `form:"sort_by" binding:"oneof=created_at rating" default:"created_at"`
 	Order       string `form:"order" binding:"oneof=asc desc" default:"desc"`
@@ -31,9 +33,42 @@ type ReviewSearchRequest struct {
 
 // ReviewListResponse represents the response for a list of reviews
 type ReviewListResponse struct {
-	Items      []ReviewResponse `json:"items"`
-	Total      int64            `json:"total"`
-	Page       int              `json:"page"`
-	PageSize   int              `json:"page_size"`
-	TotalPages int              `json:"total_pages"`
+	Items           []ReviewResponse `json:"items"`
+	Total           int64            `json:"total"`
+	Page            int              `json:"page"`
+	PageSize        int              `json:"page_size"`
+	TotalPages      int              `json:"total_pages"`
+	DefaultPageSize int              `json:"default_page_size"`
+	MaxPageSize     int              `json:"max_page_size"`
+}
+
+// ReviewCountResponse represents the review counts
+type ReviewCountResponse struct {
+	TotalReviews  int64 `json:"total_reviews"`
+	MyReviewCount int64 `json:"my_review_count"`
+}
+
+// UserReviewStatsResponse represents the review statistics of a user
+type UserReviewStatsResponse struct {
+	UserID             uint             `json:"user_id"`
+	ReviewCount        int64            `json:"review_count"`
+	AverageRating      float64          `json:"average_rating"`
+	RatingDistribution map[string]int64 `json:"rating_distribution"` // Review count per rating, "1" to "5"
+}
+
+// UserReviewListRequest represents the query parameters for listing a user's reviews
+type UserReviewListRequest struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// PublicReviewResponse represents a review as seen by other users
+type PublicReviewResponse struct {
+	ID          uint   `json:"id"`
+	ProductID   uint   `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Rating      int    `json:"rating"`
+	Comment     string `json:"comment"`
+	Imported    bool   `json:"imported"` // Migrated from another store platform
+	CreatedAt   Time   `json:"created_at"`
 }
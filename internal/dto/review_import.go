@@ -0,0 +1,35 @@
+package dto
+
+// ReviewImportRequest represents the query parameters of a review import
+type ReviewImportRequest struct {
+	Source string `form:"source" binding:"required" example:"shopify"` // Platform the reviews come from: lowercase letters, digits, dashes and underscores
+	DryRun bool   `form:"dry_run"`                                     // Validate the rows without saving them
+}
+
+// ReviewImportRow is one review of an import, a JSON array element or a CSV
+// row with these column names
+type ReviewImportRow struct {
+	ProductID      uint   `json:"product_id" example:"1"`                   // Product reviewed; sku may be given instead
+	SKU            string `json:"sku" example:"TSHIRT-RED-M"`               // Product reviewed, when product_id is not given
+	ExternalUserID string `json:"external_user_id" example:"customer-8812"` // Reviewer's ID on the source platform
+	UserName       string `json:"user_name" example:"Jane D."`              // Reviewer's display name on the source platform
+	Rating         int    `json:"rating" example:"5"`
+	Comment        string `json:"comment" example:"Fits perfectly"`
+	CreatedAt      string `json:"created_at" example:"2023-04-01T12:00:00Z"` // When the review was written, RFC 3339 or YYYY-MM-DD; now when omitted
+}
+
+// ReviewImportError reports why a row of an import was not imported
+type ReviewImportError struct {
+	Row   int    `json:"row" example:"3"` // Position of the row in the file, starting at 1 and not counting the CSV header
+	Error string `json:"error" example:"rating must be between 1 and 5"`
+}
+
+// ReviewImportResponse summarizes a review import
+type ReviewImportResponse struct {
+	Source       string              `json:"source" example:"shopify"`
+	DryRun       bool                `json:"dry_run" example:"false"`
+	Rows         int                 `json:"rows" example:"120"`
+	Imported     int                 `json:"imported" example:"118"` // Reviews created, or that would be on a dry run
+	UsersCreated int                 `json:"users_created" example:"95"`
+	Errors       []ReviewImportError `json:"errors"`
+}
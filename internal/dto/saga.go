@@ -0,0 +1,34 @@
+package dto
+
+// ListSagasRequest represents the query parameters for listing sagas
+type ListSagasRequest struct {
+	Status    string `form:"status" binding:"omitempty,oneof=running completed compensating compensated failed"`
+	Type      string `form:"type" binding:"omitempty,oneof=order_placement"`
+	Reference string `form:"reference"`
+	Page      int    `form:"page" binding:"omitempty,min=1"`
+	PageSize  int    `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// SagaResponse represents a saga with the state of its steps
+type SagaResponse struct {
+	ID            uint               `json:"id" example:"3"`
+	Type          string             `json:"type" example:"order_placement" enums:"order_placement"`
+	Reference     string             `json:"reference" example:"Q-12"` // What the saga acts on
+	Status        string             `json:"status" example:"compensated" enums:"running,completed,compensating,compensated,failed"`
+	Data          string             `json:"data" example:"{\"quote_id\":12,\"user_id\":4,\"total\":90,\"quantities\":{\"7\":3}}"` // JSON state the steps read and write
+	Attempts      int                `json:"attempts" example:"0"`                                                                 // Failed attempts at the current step or compensation
+	NextAttemptAt *Time              `json:"next_attempt_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	Error         string             `json:"error,omitempty" example:"reserve_stock: not enough stock: Widget has 2 left"`
+	Steps         []SagaStepResponse `json:"steps"`
+	CreatedAt     Time               `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	FinishedAt    *Time              `json:"finished_at,omitempty" example:"2021-01-01T00:00:01Z"`
+}
+
+// SagaStepResponse represents the state of a step of a saga
+type SagaStepResponse struct {
+	Name          string `json:"name" example:"reserve_stock"`
+	Status        string `json:"status" example:"failed" enums:"pending,completed,failed,compensated"`
+	Error         string `json:"error,omitempty"` // Last failure of the step or its compensation
+	CompletedAt   *Time  `json:"completed_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	CompensatedAt *Time  `json:"compensated_at,omitempty" example:"2021-01-01T00:00:01Z"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+// ListSecurityAlertsRequest represents the query parameters for listing security alerts
+type ListSecurityAlertsRequest struct {
+	Kind     string `form:"kind" binding:"omitempty,oneof=failed_logins bulk_deletion privilege_escalation"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// SecurityAlertResponse represents suspicious activity raised to the admins
+type SecurityAlertResponse struct {
+	ID        uint   `json:"id" example:"1"`
+	Kind      string `json:"kind" example:"failed_logins"`
+	IP        string `json:"ip,omitempty" example:"203.0.113.7"`
+	ActorID   *uint  `json:"actor_id,omitempty" example:"12"` // User behind the activity, when known
+	Details   string `json:"details" example:"5 accounts failed to sign in from IP 203.0.113.7 within 15m0s"`
+	Blocked   bool   `json:"blocked" example:"true"` // Whether the IP was blocked automatically
+	CreatedAt Time   `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// IPBlockResponse represents an IP address blocked after suspicious activity
+type IPBlockResponse struct {
+	IP        string `json:"ip" example:"203.0.113.7"`
+	Reason    string `json:"reason" example:"5 accounts failed to sign in from IP 203.0.113.7 within 15m0s"`
+	CreatedAt Time   `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	ExpiresAt Time   `json:"expires_at" example:"2021-01-01T01:00:00Z"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+// SegmentRule represents one condition of a customer segment. Value is always
+// a string; numeric fields take a number such as "5".
+type SegmentRule struct {
+	Field    string `json:"field" binding:"required" example:"wishlist_size" enums:"role,wishlist_size,review_count,days_since_signup,days_since_last_login"`
+	Operator string `json:"operator" binding:"required" example:"gte" enums:"eq,ne,gt,gte,lt,lte"`
+	Value    string `json:"value" binding:"required" example:"5"`
+}
+
+// SegmentRequest represents the request body for creating or replacing a segment
+type SegmentRequest struct {
+	Name        string        `json:"name" binding:"required,max=100" example:"Engaged wishlisters"`
+	Description string        `json:"description" binding:"max=500" example:"Users with five or more wishlisted products"`
+	Rules       []SegmentRule `json:"rules" binding:"required,min=1,max=10,dive"`
+}
+
+// SegmentResponse represents a customer segment
+type SegmentResponse struct {
+	ID          uint          `json:"id" example:"1"`
+	Name        string        `json:"name" example:"Engaged wishlisters"`
+	Description string        `json:"description" example:"Users with five or more wishlisted products"`
+	Rules       []SegmentRule `json:"rules"`
+	CreatedBy   uint          `json:"created_by" example:"1"`
+	CreatedAt   Time          `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt   Time          `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// ListSegmentMembersRequest represents the query parameters for listing segment members
+type ListSegmentMembersRequest struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// NotifySegmentRequest represents the request body for notifying a segment
+type NotifySegmentRequest struct {
+	Title   string `json:"title" binding:"required,max=100" example:"Spring sale"`
+	Message string `json:"message" binding:"required,max=1000" example:"Everything on your wishlist is 20% off this week."`
+}
+
+// NotifySegmentResponse represents a segment notification queued for delivery
+type NotifySegmentResponse struct {
+	Recipients int64 `json:"recipients" example:"42"` // Segment members at the time of sending, before opt-outs
+}
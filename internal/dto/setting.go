@@ -0,0 +1,27 @@
+package dto
+
+// UpdateSettingRequest represents the request to set a store setting
+type UpdateSettingRequest struct {
+	Value *string `json:"value" binding:"required" example:"Acme Store"` // Empty clears optional settings such as logo_url
+}
+
+// SettingResponse represents a store setting with its value in effect
+type SettingResponse struct {
+	Key       string `json:"key" example:"store_name" enums:"store_name,logo_url,support_email,currency,order_number_prefix,gift_wrap_fee"`
+	Type      string `json:"type" example:"text" enums:"text,url,email,currency,prefix,amount"` // What values are accepted
+	Value     string `json:"value" example:"Acme Store"`                                        // In effect: the value set, otherwise the default
+	Default   string `json:"default" example:"Product Management"`
+	Custom    bool   `json:"custom" example:"true"`                               // Whether an admin set a value
+	UpdatedBy *uint  `json:"updated_by,omitempty" example:"1"`                    // Admin who set the value
+	UpdatedAt *Time  `json:"updated_at,omitempty" example:"2025-01-01T00:00:00Z"` // When the value was set
+}
+
+// StoreSettings represents the store settings a storefront renders with
+type StoreSettings struct {
+	StoreName         string  `json:"store_name" example:"Acme Store"`
+	LogoURL           string  `json:"logo_url" example:"https://cdn.example.com/logo.png"`
+	SupportEmail      string  `json:"support_email" example:"support@example.com"`
+	Currency          string  `json:"currency" example:"USD"` // Currency the storefront shows prices in
+	OrderNumberPrefix string  `json:"order_number_prefix" example:"ORD-"`
+	GiftWrapFee       float64 `json:"gift_wrap_fee" example:"4.5"` // Charged per unit gift wrapped, in the base currency
+}
@@ -0,0 +1,60 @@
+package dto
+
+// StorefrontImage represents an image of a product in the storefront bundle
+type StorefrontImage struct {
+	Key       string `json:"key" example:"products/42/cover.jpg"`                         // Storage key
+	URL       string `json:"url" example:"https://cdn.example.com/products/42/cover.jpg"` // Public URL, through the CDN when configured
+	Size      int64  `json:"size" example:"48213"`                                        // Size in bytes
+	UpdatedAt Time   `json:"updated_at" example:"2025-01-01T00:00:00Z"`                   // Last modification time
+}
+
+// StorefrontProduct represents a product file of the storefront bundle
+type StorefrontProduct struct {
+	ProductResponse
+	Images []StorefrontImage `json:"images"` // Product images, ordered by key
+}
+
+// StorefrontCategory represents a category in the storefront bundle
+type StorefrontCategory struct {
+	ID          uint   `json:"id" example:"1"`
+	Name        string `json:"name" example:"Electronics"`
+	Description string `json:"description" example:"Phones, watches and accessories"`
+	ProductIDs  []uint `json:"product_ids" example:"1,2,3"` // Active products in the category
+}
+
+// StorefrontManifest represents the manifest of the storefront bundle
+type StorefrontManifest struct {
+	GeneratedAt Time `json:"generated_at" example:"2025-01-01T00:00:00Z"`
+	Products    int  `json:"products" example:"120"` // Active products exported
+	Categories  int  `json:"categories" example:"8"` // Categories exported
+}
+
+// StorefrontExportResponse represents the outcome of regenerating the storefront bundle
+type StorefrontExportResponse struct {
+	Prefix      string `json:"prefix" example:"storefront/"` // Storage key prefix of the bundle
+	GeneratedAt Time   `json:"generated_at" example:"2025-01-01T00:00:00Z"`
+	Products    int    `json:"products" example:"120"` // Product files written
+	Categories  int    `json:"categories" example:"8"` // Categories exported
+	Removed     int    `json:"removed" example:"2"`    // Product files deleted because their products are no longer active
+}
+
+// StorefrontUserSummary represents the signed-in user in the storefront bootstrap
+type StorefrontUserSummary struct {
+	ID       uint   `json:"id" example:"42"`
+	Username string `json:"username" example:"john"`
+	FullName string `json:"full_name" example:"John Doe"`
+	Role     string `json:"role" example:"user"`
+}
+
+// StorefrontBootstrapResponse represents everything a storefront renders on its
+// first page. A section that failed to load is null and its error is listed in
+// errors.
+type StorefrontBootstrapResponse struct {
+	Settings         *StoreSettings         `json:"settings"`                   // Store name, logo, support email, currency and order number prefix
+	Categories       []CategoryResponse     `json:"categories"`                 // Every category with its product count
+	FeaturedProducts []ProductResponse      `json:"featured_products"`          // Highest-rated active products sold in the caller's country
+	User             *StorefrontUserSummary `json:"user"`                       // The signed-in user
+	CartCount        *int64                 `json:"cart_count" example:"0"`     // Items in the user's cart
+	WishlistCount    *int64                 `json:"wishlist_count" example:"3"` // Products in the user's wishlist
+	Errors           map[string]string      `json:"errors,omitempty"`           // Sections that failed to load, with their errors
+}
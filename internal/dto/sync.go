@@ -0,0 +1,37 @@
+package dto
+
+// SyncChangesRequest represents the query parameters of a delta sync
+type SyncChangesRequest struct {
+	Cursor string `form:"cursor" binding:"omitempty,numeric,max=20"` // Cursor returned by the previous sync, empty after a full refresh
+}
+
+// SyncChangesResponse represents the changes after a sync cursor
+type SyncChangesResponse struct {
+	Changes []SyncChangeResponse `json:"changes"`                  // Latest change of each record, oldest first
+	Cursor  string               `json:"cursor" example:"1042"`    // Cursor to sync from next
+	HasMore bool                 `json:"has_more" example:"false"` // Whether to sync again right away for more changes
+}
+
+// SyncChangeResponse represents a change to a record since the last sync
+type SyncChangeResponse struct {
+	EntityType string       `json:"entity_type" example:"product" enums:"product,category,wishlist"`
+	EntityID   uint         `json:"entity_id" example:"7"` // Product, category, or product of a wishlist item
+	Operation  string       `json:"operation" example:"upsert" enums:"upsert,delete"`
+	Cursor     string       `json:"cursor" example:"1040"` // Cursor to sync from once this change is applied
+	Payload    *SyncPayload `json:"payload,omitempty"`     // Current state of the record, absent when deleted
+}
+
+// SyncPayload holds the current state of a changed record, under its entity type
+type SyncPayload struct {
+	Product      *ProductResponse      `json:"product,omitempty"`
+	Category     *SyncCategory         `json:"category,omitempty"`
+	WishlistItem *WishlistItemResponse `json:"wishlist_item,omitempty"`
+}
+
+// SyncCategory represents a category in a sync payload. Products list their
+// categories, so category membership syncs with the products.
+type SyncCategory struct {
+	ID          uint   `json:"id" example:"1"`
+	Name        string `json:"name" example:"Electronics"`
+	Description string `json:"description" example:"Phones, watches and accessories"`
+}
@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"product-management/pkg/jsonschema"
+)
+
+// Time is a timestamp that is always serialized as an RFC 3339 string in UTC,
+// whatever the location of the underlying time.Time. The zero time is
+// serialized as null.
+type Time struct {
+	time.Time
+}
+
+// NewTime wraps a time.Time for serialization
+func NewTime(t time.Time) Time {
+	return Time{Time: t}
+}
+
+// NewTimePtr wraps an optional time.Time, returning nil when it is unset
+func NewTimePtr(t *time.Time) *Time {
+	if t == nil {
+		return nil
+	}
+	wrapped := NewTime(*t)
+	return &wrapped
+}
+
+// MarshalJSON encodes the time as a UTC RFC 3339 string, or null when zero
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.UTC().Format(time.RFC3339))
+}
+
+// UnmarshalJSON decodes an RFC 3339 string in any offset, or null, and stores it in UTC
+func (t *Time) UnmarshalJSON(data []byte) error {
+	var value *string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value == nil {
+		t.Time = time.Time{}
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed.UTC()
+	return nil
+}
+
+// JSONSchema describes the encoded form of Time for response contracts
+func (Time) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{Type: jsonschema.Types{"string", "null"}, Format: "date-time"}
+}
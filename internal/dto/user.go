@@ -12,6 +12,9 @@ type UpdateUserRequest struct {
 	Username string `json:"username" binding:"omitempty,min=3"`
 	Email    string `json:"email" binding:"omitempty,email"`
 	FullName string `json:"full_name" binding:"omitempty"`
+	Locale   string `json:"locale" binding:"omitempty,max=16" example:"vi-VN"`              // Preferred language, one of the supported locales
+	Currency string `json:"currency" binding:"omitempty,len=3" example:"VND"`               // Preferred currency, one of the supported currencies
+	Timezone string `json:"timezone" binding:"omitempty,max=64" example:"Asia/Ho_Chi_Minh"` // Preferred IANA time zone
 }
 
 // UserResponse represents the response for user information
@@ -21,13 +24,24 @@ type UserResponse struct {
 	Email     string `json:"email"`
 	FullName  string `json:"full_name"`
 	Role      string `json:"role"`
-	LastLogin string `json:"last_login"`
+	LastLogin Time   `json:"last_login"`
+	Locale    string `json:"locale"`   // Preferred language, empty for the default
+	Currency  string `json:"currency"` // Preferred currency, empty for the default
+	Timezone  string `json:"timezone"` // Preferred time zone, empty for the default
+
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"` // Values of the registration form's custom fields
+}
+
+// ExportUsersRequest represents the filters of a user CSV export, matching ListUsersRequest
+type ExportUsersRequest struct {
+	Search string `form:"search" json:"search,omitempty" binding:"omitempty"`
+	Role   string `form:"role" json:"role,omitempty" binding:"omitempty,oneof=user admin"`
 }
 
 // ListUsersRequest represents the request parameters for listing users
 type ListUsersRequest struct {
 	Page     int    `form:"page" binding:"omitempty,min=1"`
-	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1"`
 	Search   string `form:"search" binding:"omitempty"`
 	Role     string `form:"role" binding:"omitempty,oneof=user admin"`
 }
@@ -36,3 +50,32 @@ type ListUsersRequest struct {
 type UpdateUserRoleRequest struct {
 	Role string `json:"role" binding:"required,oneof=user admin"`
 }
+
+// NotificationChannels selects the channels a kind of notification is delivered on
+type NotificationChannels struct {
+	Email bool `json:"email"`
+	SMS   bool `json:"sms"`
+	Push  bool `json:"push"`
+}
+
+// NotificationSettingsResponse represents a user's notification preferences
+type NotificationSettingsResponse struct {
+	SecurityAlerts NotificationChannels `json:"security_alerts"`
+	OrderUpdates   NotificationChannels `json:"order_updates"`
+	PhoneNumber    string               `json:"phone_number"`
+	HasPushToken   bool                 `json:"has_push_token"`
+	WeeklyDigest   bool                 `json:"weekly_digest"`
+	Promotions     bool                 `json:"promotions"`
+}
+
+// UpdateNotificationSettingsRequest represents the request body for updating
+// notification preferences. Omitted fields are left unchanged; an empty phone
+// number or push token removes it.
+type UpdateNotificationSettingsRequest struct {
+	SecurityAlerts *NotificationChannels `json:"security_alerts"`
+	OrderUpdates   *NotificationChannels `json:"order_updates"`
+	PhoneNumber    *string               `json:"phone_number" binding:"omitempty,e164|len=0"`
+	PushToken      *string               `json:"push_token"`
+	WeeklyDigest   *bool                 `json:"weekly_digest"`
+	Promotions     *bool                 `json:"promotions"`
+}
@@ -0,0 +1,49 @@
+package dto
+
+// CreateWebhookRequest represents the request body for subscribing to events
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required,url" example:"https://erp.example.com/hooks/catalog"` // Public https endpoint
+	Events     []string `json:"events" binding:"required,min=1,dive,oneof=product.changed product.stock_changed order.status_changed"`
+	ProductIDs []uint   `json:"product_ids" binding:"omitempty,dive,min=1"` // Limits product events to these products, which the key's customer must have ordered
+}
+
+// WebhookSubscriptionResponse represents a webhook subscription
+type WebhookSubscriptionResponse struct {
+	ID         uint     `json:"id" example:"1"`
+	URL        string   `json:"url" example:"https://erp.example.com/hooks/catalog"`
+	Events     []string `json:"events"`
+	ProductIDs []uint   `json:"product_ids"`
+	CreatedAt  Time     `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// CreateWebhookResponse represents a new subscription with its signing secret,
+// which is only ever returned here
+type CreateWebhookResponse struct {
+	WebhookSubscriptionResponse
+	Secret string `json:"secret" example:"whsec_4f1c..."`
+}
+
+// ListWebhookDeliveriesRequest represents the query parameters for listing deliveries
+type ListWebhookDeliveriesRequest struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// WebhookDeliveryResponse represents an attempt to deliver an event
+type WebhookDeliveryResponse struct {
+	ID         uint   `json:"id" example:"1"`
+	EventID    string `json:"event_id" example:"0b8e5d4c-3c1f-4a51-9d62-8f3f7c4b2e1a"`
+	Event      string `json:"event" example:"product.changed"`
+	Attempt    int    `json:"attempt" example:"1"`
+	StatusCode int    `json:"status_code" example:"200"`
+	Succeeded  bool   `json:"succeeded" example:"true"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms" example:"85"`
+	CreatedAt  Time   `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// LinkAPIKeyRequest represents the request body for linking an integration API
+// key to the customer it acts for. A null api_key_name unlinks the customer's key.
+type LinkAPIKeyRequest struct {
+	APIKeyName *string `json:"api_key_name" binding:"omitempty,min=1,max=100" example:"acme-erp"` // Name of the key in RATE_LIMIT_API_KEYS
+}
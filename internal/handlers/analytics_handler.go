@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/reqctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsHandler handles the admin analytics dashboards
+type AnalyticsHandler struct {
+	analyticsService *services.AnalyticsService
+	activityService  *services.ActivityService
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(analyticsService *services.AnalyticsService, activityService *services.ActivityService) *AnalyticsHandler {
+	return &AnalyticsHandler{analyticsService: analyticsService, activityService: activityService}
+}
+
+// GetReviewAnalytics godoc
+// @Summary      Review analytics dashboard
+// @Description  Get review volume and average rating over time, and the products whose average rating dropped the most compared with the preceding window of the same length. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        days         query     int     false  "Window length in days (1-365)" default(30)
+// @Param        interval     query     string  false  "Volume bucket size" Enums(day, week, month) default(day)
+// @Param        limit        query     int     false  "Number of dropping products (1-100)" default(10)
+// @Param        min_reviews  query     int     false  "Reviews a product needs in each window" default(3)
+// @Param        tz           query     string  false  "IANA time zone periods are aligned to, e.g. Asia/Ho_Chi_Minh, defaults to the request time zone. Ignored with REPORTING_MODE=materialized, which aligns them to UTC."
+// @Success      200  {object}  types.DataResponse[dto.ReviewAnalyticsResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/analytics/reviews [get]
+func (h *AnalyticsHandler) GetReviewAnalytics(c *gin.Context) {
+	var req dto.ReviewAnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid query parameters"})
+		return
+	}
+
+	rc, ok := analyticsContext(c, req.TZ)
+	if !ok {
+		return
+	}
+
+	analytics, err := h.analyticsService.GetReviewAnalytics(rc, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "Failed to get review analytics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    analytics,
+	})
+}
+
+// GetCategoryAnalytics godoc
+// @Summary      Category analytics dashboard
+// @Description  Get the product count of every category with the number of times its products were added to wishlists between two dates, inclusive. Revenue per category is on the sales analytics dashboard. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        from  query     string  false  "First day (YYYY-MM-DD), defaults to 29 days before to"
+// @Param        to    query     string  false  "Last day (YYYY-MM-DD), defaults to today"
+// @Param        tz    query     string  false  "IANA time zone days are interpreted in, e.g. Asia/Ho_Chi_Minh, defaults to the request time zone"
+// @Success      200  {object}  types.DataResponse[dto.CategoryAnalyticsResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/analytics/categories [get]
+func (h *AnalyticsHandler) GetCategoryAnalytics(c *gin.Context) {
+	var req dto.CategoryAnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid query parameters"})
+		return
+	}
+	if req.From != "" && req.To != "" && req.From > req.To {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "from must not be after to"})
+		return
+	}
+
+	rc, ok := analyticsContext(c, req.TZ)
+	if !ok {
+		return
+	}
+
+	analytics, err := h.analyticsService.GetCategoryAnalytics(rc, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "Failed to get category analytics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    analytics,
+	})
+}
+
+// GetSalesAnalytics godoc
+// @Summary      Sales analytics dashboard
+// @Description  Get the accepted quotes, units and revenue of every UTC day between two dates, inclusive, with totals and the revenue per category. A product in several categories counts toward each. With REPORTING_MODE=materialized the figures come from the reporting views, as of their last refresh. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        from  query     string  false  "First UTC day (YYYY-MM-DD), defaults to 29 days before to"
+// @Param        to    query     string  false  "Last UTC day (YYYY-MM-DD), defaults to today"
+// @Success      200  {object}  types.DataResponse[dto.SalesAnalyticsResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/analytics/sales [get]
+func (h *AnalyticsHandler) GetSalesAnalytics(c *gin.Context) {
+	var req dto.SalesAnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid query parameters"})
+		return
+	}
+	if req.From != "" && req.To != "" && req.From > req.To {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "from must not be after to"})
+		return
+	}
+
+	analytics, err := h.analyticsService.GetSalesAnalytics(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "Failed to get sales analytics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    analytics,
+	})
+}
+
+// GetConversionAnalytics godoc
+// @Summary      Conversion analytics dashboard
+// @Description  Get the most viewed products between two UTC days, inclusive, with their unique viewers, wishlist adds, accepted quotes and units over the same days, and the wishlist adds and orders per view. Views come from POST /products/{id}/view. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        from   query     string  false  "First UTC day (YYYY-MM-DD), defaults to 29 days before to"
+// @Param        to     query     string  false  "Last UTC day (YYYY-MM-DD), defaults to today"
+// @Param        limit  query     int     false  "Number of products, most viewed first (1-100)" default(10)
+// @Success      200  {object}  types.DataResponse[dto.ConversionAnalyticsResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/analytics/conversion [get]
+func (h *AnalyticsHandler) GetConversionAnalytics(c *gin.Context) {
+	var req dto.ConversionAnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid query parameters"})
+		return
+	}
+	if req.From != "" && req.To != "" && req.From > req.To {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "from must not be after to"})
+		return
+	}
+
+	analytics, err := h.analyticsService.GetConversionAnalytics(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "Failed to get conversion analytics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    analytics,
+	})
+}
+
+// GetActivity godoc
+// @Summary      API activity dashboard
+// @Description  Get request volumes, status code distributions and latency percentiles (p50, p95, p99) over time and per endpoint, from the request statistics the servers store every ACTIVITY_BUCKET. Each endpoint carries its request count per period of the series, for heatmaps. Requests of the bucket in progress are not included yet. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        hours     query     int     false  "Window length in hours (1-720)" default(24)
+// @Param        interval  query     string  false  "Period of the series as a multiple of ACTIVITY_BUCKET, e.g. 15m or 1h" default(1h)
+// @Param        method    query     string  false  "Only requests with this method" Enums(GET, POST, PUT, PATCH, DELETE)
+// @Param        route     query     string  false  "Only requests to this route pattern, e.g. /api/v1/products/:id"
+// @Param        limit     query     int     false  "Number of endpoints, busiest first (1-100)" default(20)
+// @Success      200  {object}  types.DataResponse[dto.ActivityResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/analytics/activity [get]
+func (h *AnalyticsHandler) GetActivity(c *gin.Context) {
+	var req dto.ActivityRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid query parameters"})
+		return
+	}
+
+	activity, err := h.activityService.GetActivity(req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidActivityInterval) {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "Failed to get API activity"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    activity,
+	})
+}
+
+// analyticsContext returns the request context with the time zone of the tz
+// query parameter, when given, responding 400 when it is unknown
+func analyticsContext(c *gin.Context, tz string) (reqctx.Context, bool) {
+	rc := requestContext(c)
+	if tz == "" {
+		return rc, true
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid time zone: " + tz})
+		return rc, false
+	}
+	rc.Location = loc
+	return rc, true
+}
@@ -1,13 +1,23 @@
 package handlers
 
 import (
+	"encoding/csv"
 	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/middleware"
 	"product-management/internal/models"
 	"product-management/internal/repositories"
 	"product-management/internal/services"
 	"product-management/internal/types"
+	"product-management/pkg/events"
+	"product-management/pkg/geoip"
+	"product-management/pkg/mailer"
+	"product-management/pkg/reqctx"
+	"product-management/pkg/utils"
 	"strconv"
 	"strings"
 	"time"
@@ -18,38 +28,42 @@ import (
 
 // AuthHandler handles authentication-related HTTP requests
 type AuthHandler struct {
-	userRepo    *repositories.UserRepository
-	authService *services.AuthService
+	userRepo            *repositories.UserRepository
+	authService         *services.AuthService
+	auditService        *services.AuditService
+	notificationService *services.NotificationService
+	referralService     *services.ReferralService
+	customFieldService  *services.CustomFieldService
 }
 
 // NewAuthHandler creates a new auth handler
-func NewAuthHandler(userRepo *repositories.UserRepository, authService *services.AuthService) *AuthHandler {
-	return &AuthHandler{userRepo: userRepo, authService: authService}
+func NewAuthHandler(userRepo *repositories.UserRepository, authService *services.AuthService, auditService *services.AuditService, notificationService *services.NotificationService, referralService *services.ReferralService, customFieldService *services.CustomFieldService) *AuthHandler {
+	return &AuthHandler{userRepo: userRepo, authService: authService, auditService: auditService, notificationService: notificationService, referralService: referralService, customFieldService: customFieldService}
 }
 
 // Register handles user registration
 // @Summary Register a new user
-// @Description Register a new user with the provided information
+// @Description Register a new user with the provided information. Values of the registration form's custom fields are sent as custom_fields.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body dto.RegisterRequest true "User registration details"
 // @Success 201 {object} dto.RegisterResponse
-// @Failure 400 {object} map[string]string
-// @Failure 403 {object} map[string]string
-// @Failure 409 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
 // @Router /auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req dto.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
 		return
 	}
 
 	// Validate passwords match
 	if req.Password != req.ConfirmPassword {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "passwords do not match"})
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "passwords do not match"})
 		return
 	}
 
@@ -59,7 +73,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	req.FullName = strings.TrimSpace(req.FullName)
 
 	if req.Username == "" || req.Email == "" || req.FullName == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "username, email and full name cannot be empty"})
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "username, email and full name cannot be empty"})
 		return
 	}
 
@@ -74,46 +88,68 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		case models.RoleAdmin:
 			// Here you might want to add additional checks to ensure only authorized users can create admin accounts
 			// For example, check if the request comes from an existing admin user
-			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized to create admin account"})
+			c.JSON(http.StatusForbidden, types.ErrorResponse{Error: "unauthorized to create admin account"})
 			return
 		default:
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid role"})
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "invalid role"})
 			return
 		}
 	}
 
+	customFields, err := h.customFieldService.CheckValues(models.FormRegistration, req.CustomFields)
+	if err != nil {
+		respondCustomFieldError(c, err)
+		return
+	}
+
 	// Create user
 	user := &models.User{
-		Username: req.Username,
-		Email:    req.Email,
-		FullName: req.FullName,
-		Password: req.Password,
-		Role:     userRole,
+		Username:     req.Username,
+		Email:        req.Email,
+		FullName:     req.FullName,
+		Password:     req.Password,
+		Role:         userRole,
+		CustomFields: customFields,
+	}
+
+	// Record who referred the user, so their first order can reward the referrer
+	if req.ReferralCode != "" {
+		referrer, err := h.referralService.ResolveReferrer(req.ReferralCode)
+		if errors.Is(err, services.ErrInvalidReferralCode) {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to create user"})
+			return
+		}
+		user.ReferredByID = &referrer.ID
 	}
 
 	if err := h.userRepo.Create(user); err != nil {
 		if strings.Contains(err.Error(), "username already exists") {
-			c.JSON(http.StatusConflict, gin.H{"error": "username already exists"})
+			c.JSON(http.StatusConflict, types.ErrorResponse{Error: "username already exists"})
 			return
 		}
 		if strings.Contains(err.Error(), "email already exists") {
-			c.JSON(http.StatusConflict, gin.H{"error": "email already exists"})
+			c.JSON(http.StatusConflict, types.ErrorResponse{Error: "email already exists"})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create user"})
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to create user"})
 		return
 	}
 
+	if err := mailer.Default.Enqueue(mailer.DefaultTenant, mailer.TemplateWelcome, user.Email, map[string]string{
+		"Name":     user.FullName,
+		"Username": user.Username,
+	}); err != nil {
+		log.Printf("Warning: failed to queue welcome email to %s: %v", user.Email, err)
+	}
+
 	// Create response
 	response := dto.RegisterResponse{
 		Message: "user registered successfully",
-		User: dto.UserOutput{
-			ID:       user.ID,
-			Username: user.Username,
-			Email:    user.Email,
-			FullName: user.FullName,
-			Role:     string(user.Role),
-		},
+		User:    mappers.ToUserOutput(user),
 	}
 
 	c.JSON(http.StatusCreated, response)
@@ -126,7 +162,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        credentials  body      dto.LoginRequest  true  "Login credentials"
-// @Success      200         {object}   types.APIResponse
+// @Success      200         {object}   types.DataResponse[types.LoginResponse]
 // @Failure      400         {object}   types.ErrorResponse
 // @Failure      401         {object}   types.ErrorResponse
 // @Failure      500         {object}   types.ErrorResponse
@@ -140,19 +176,16 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	user, accessToken, refreshToken, err := h.authService.Login(req)
 	if err != nil {
+		events.Publish(events.LoginFailed{Email: strings.ToLower(strings.TrimSpace(req.Email)), IP: c.ClientIP()})
 		c.JSON(http.StatusUnauthorized, types.ErrorResponse{Error: err.Error()})
 		return
 	}
 
+	// Alert the user in the background when the login comes from a new device
+	go h.notificationService.CheckLoginDevice(user, c.Request.UserAgent(), c.ClientIP(), requestLocation(c))
+
 	// Create user output without sensitive data
-	userOutput := dto.UserOutput{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		FullName:  user.FullName,
-		Role:      string(user.Role),
-		LastLogin: user.LastLogin,
-	}
+	userOutput := mappers.ToUserOutput(user)
 
 	c.JSON(http.StatusOK, types.APIResponse{
 		Success: true,
@@ -164,6 +197,75 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	})
 }
 
+// RefreshToken godoc
+// @Summary      Refresh tokens
+// @Description  Exchange a refresh token for a new access token and a new refresh token. Each refresh token can be exchanged once; exchanging one again revokes every token of the user.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RefreshTokenRequest  true  "Refresh token"
+// @Success      200      {object}  types.DataResponse[types.LoginResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /auth/refresh [post]
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	user, accessToken, refreshToken, err := h.authService.Refresh(req.RefreshToken)
+	if errors.Is(err, services.ErrInvalidRefreshToken) {
+		c.JSON(http.StatusUnauthorized, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to refresh tokens"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data: types.LoginResponse{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+			User:         mappers.ToUserOutput(user),
+		},
+	})
+}
+
+// Logout godoc
+// @Summary      Logout user
+// @Description  Revoke the access token of the request at once, and the refresh token in the body when given. With all_sessions, every access and refresh token of the user is revoked instead. The body is optional.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.LogoutRequest  false  "Tokens to revoke"
+// @Success      200      {object}  types.SuccessResponse
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	if err := h.authService.Logout(c.GetUint("userID"), c.GetString("tokenID"), c.GetTime("tokenExpiresAt"), req.RefreshToken, req.AllSessions); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to log out"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "logged out successfully"})
+}
+
 // GetCurrentUser godoc
 // @Summary      Get current user information
 // @Description  Get information of the currently logged-in user
@@ -171,7 +273,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     Bearer
-// @Success      200  {object}  types.APIResponse
+// @Success      200  {object}  types.DataResponse[dto.UserResponse]
 // @Failure      401  {object}  types.ErrorResponse
 // @Failure      500  {object}  types.ErrorResponse
 // @Router       /auth/me [get]
@@ -184,14 +286,7 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 		return
 	}
 
-	response := dto.UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		FullName:  user.FullName,
-		Role:      string(user.Role),
-		LastLogin: user.LastLogin.Format(time.RFC3339),
-	}
+	response := mappers.ToUserResponse(user)
 
 	c.JSON(http.StatusOK, types.APIResponse{
 		Success: true,
@@ -207,7 +302,8 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 // @Produce      json
 // @Security     Bearer
 // @Param        id   path      int  true  "User ID"
-// @Success      200  {object}  types.APIResponse
+// @Success      200  {object}  types.DataResponse[dto.UserResponse]
+// @Failure      400  {object}  types.ErrorResponse
 // @Failure      401  {object}  types.ErrorResponse
 // @Failure      404  {object}  types.ErrorResponse
 // @Failure      500  {object}  types.ErrorResponse
@@ -229,14 +325,7 @@ func (h *AuthHandler) GetUserByID(c *gin.Context) {
 		return
 	}
 
-	response := dto.UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		FullName:  user.FullName,
-		Role:      string(user.Role),
-		LastLogin: user.LastLogin.Format(time.RFC3339),
-	}
+	response := mappers.ToUserResponse(user)
 
 	c.JSON(http.StatusOK, types.APIResponse{
 		Success: true,
@@ -347,10 +436,10 @@ func (h *AuthHandler) UpdateUser(c *gin.Context) {
 // @Produce      json
 // @Security     Bearer
 // @Param        page      query     int     false  "Page number (default: 1)"
-// @Param        page_size query     int     false  "Number of items per page (default: 10, max: 100)"
+// @Param        page_size query     int     false  "Number of items per page (capped at the configured maximum)"
 // @Param        search    query     string  false  "Search by username or email"
 // @Param        role      query     string  false  "Filter by role (user/admin)"
-// @Success      200      {object}   types.APIResponse
+// @Success      200      {object}   types.DataResponse[types.UserListResponse]
 // @Failure      400      {object}   types.ErrorResponse
 // @Failure      401      {object}   types.ErrorResponse
 // @Failure      500      {object}   types.ErrorResponse
@@ -362,13 +451,7 @@ func (h *AuthHandler) ListUsers(c *gin.Context) {
 		return
 	}
 
-	// Set default values
-	if req.Page == 0 {
-		req.Page = 1
-	}
-	if req.PageSize == 0 {
-		req.PageSize = 10
-	}
+	pagination := utils.NormalizePagination("users", req.Page, req.PageSize)
 
 	// Convert role string to models.Role
 	var role models.Role
@@ -376,37 +459,156 @@ func (h *AuthHandler) ListUsers(c *gin.Context) {
 		role = models.Role(req.Role)
 	}
 
-	users, total, err := h.userRepo.ListUsers(req.Page, req.PageSize, req.Search, role)
+	users, total, err := h.userRepo.ListUsers(pagination.Page, pagination.Limit, req.Search, role)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
 		return
 	}
 
 	// Convert users to response format
-	userResponses := make([]dto.UserResponse, len(users))
-	for i, user := range users {
-		userResponses[i] = dto.UserResponse{
-			ID:        user.ID,
-			Username:  user.Username,
-			Email:     user.Email,
-			FullName:  user.FullName,
-			Role:      string(user.Role),
-			LastLogin: user.LastLogin.Format(time.RFC3339),
-		}
-	}
+	userResponses := mappers.ToUserResponses(users)
 
 	c.JSON(http.StatusOK, types.APIResponse{
 		Success: true,
-		Data: types.PaginatedResponse{
-			Items:      userResponses,
-			Total:      total,
-			Page:       req.Page,
-			PageSize:   req.PageSize,
-			TotalPages: int((total + int64(req.PageSize) - 1) / int64(req.PageSize)),
+		Data:    types.NewUserListResponse(userResponses, total, pagination),
+	})
+}
+
+// defaultTestTokenTTL is the lifetime of a test token when none is requested
+const defaultTestTokenTTL = 15 * time.Minute
+
+// CreateTestToken godoc
+// @Summary      Mint a scoped test token
+// @Description  Mint a short-lived access token restricted to the given scopes, to paste into Swagger UI for testing without real credentials. Scoped tokens are rejected on routes outside their scopes. Every mint is recorded in the audit log. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.CreateTestTokenRequest  true  "Scopes, role and lifetime of the token"
+// @Success      201      {object}  types.DataResponse[dto.TestTokenResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/test-tokens [post]
+func (h *AuthHandler) CreateTestToken(c *gin.Context) {
+	var req dto.CreateTestTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if req.Role == "" {
+		req.Role = string(models.RoleUser)
+	}
+	ttl := defaultTestTokenTTL
+	if req.TTLMinutes > 0 {
+		ttl = time.Duration(req.TTLMinutes) * time.Minute
+	}
+
+	userID := c.GetUint("userID")
+	if err := h.auditService.Record(userID, requestLocation(c), models.AuditTestTokenMint, req); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	token, expiresAt, err := h.authService.GenerateTestToken(userID, models.Role(req.Role), req.Scopes, ttl)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to generate token"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Data: dto.TestTokenResponse{
+			AccessToken: token,
+			Scopes:      req.Scopes,
+			Role:        req.Role,
+			ExpiresAt:   dto.NewTime(expiresAt),
 		},
 	})
 }
 
+// exportBatchSize is the number of users loaded from the database at a time during an export
+const exportBatchSize = 500
+
+// ExportUsers godoc
+// @Summary      Export users as CSV
+// @Description  Stream the users matching the same search and role filters as the user list as a CSV file. Every export is recorded in the audit log. Admin only.
+// @Tags         admin
+// @Produce      text/csv
+// @Security     Bearer
+// @Param        search    query     string  false  "Search by username or email"
+// @Param        role      query     string  false  "Filter by role (user/admin)"
+// @Success      200      {file}     file    "CSV with columns id, username, email, role, last_login, created_at"
+// @Failure      400      {object}   types.ErrorResponse
+// @Failure      401      {object}   types.ErrorResponse
+// @Failure      403      {object}   types.ErrorResponse
+// @Failure      500      {object}   types.ErrorResponse
+// @Router       /admin/users/export [get]
+func (h *AuthHandler) ExportUsers(c *gin.Context) {
+	var req dto.ExportUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// Refuse to export anything that could not be audited
+	if err := h.auditService.Record(c.GetUint("userID"), requestLocation(c), models.AuditUserExport, req); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	filename := fmt.Sprintf("users-%s.csv", time.Now().UTC().Format("20060102-150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	writer.Write([]string{"id", "username", "email", "role", "last_login", "created_at"})
+
+	err := h.userRepo.EachUser(req.Search, models.Role(req.Role), exportBatchSize, func(user models.User) error {
+		lastLogin := ""
+		if !user.LastLogin.IsZero() {
+			lastLogin = user.LastLogin.UTC().Format(time.RFC3339)
+		}
+		writer.Write([]string{
+			strconv.FormatUint(uint64(user.ID), 10),
+			csvSafe(user.Username),
+			csvSafe(user.Email),
+			string(user.Role),
+			lastLogin,
+			user.CreatedAt.UTC().Format(time.RFC3339),
+		})
+		writer.Flush()
+		return writer.Error()
+	})
+	writer.Flush()
+	if err != nil {
+		// Headers are already sent, so the truncated file is all the client gets
+		c.Error(err)
+	}
+}
+
+// csvSafe neutralizes values that spreadsheet applications would evaluate as formulas
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// requestLocation returns the country and city the region middleware resolved
+// for the request, for audit entries and logins
+func requestLocation(c *gin.Context) geoip.Location {
+	return geoip.Location{Country: c.GetString("country"), City: c.GetString("city")}
+}
+
+// requestContext returns the locale, currency and time zone the request is
+// served in, for the services pricing, formatting and aggregating for the caller
+func requestContext(c *gin.Context) reqctx.Context {
+	return middleware.CurrentRequestContext(c)
+}
+
 // UpdateUserRole godoc
 // @Summary      Update user role
 // @Description  Update the role of a user (only admin can do this)
@@ -459,7 +661,8 @@ func (h *AuthHandler) UpdateUserRole(c *gin.Context) {
 	}
 
 	// Update user role
-	if err := h.authService.UpdateUserRole(uint(userID), models.Role(req.Role)); err != nil {
+	previous, err := h.authService.UpdateUserRole(uint(userID), models.Role(req.Role))
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "user not found"})
 			return
@@ -467,6 +670,13 @@ func (h *AuthHandler) UpdateUserRole(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
 		return
 	}
+	events.Publish(events.RoleChanged{
+		ActorID: currentUserID,
+		UserID:  uint(userID),
+		From:    string(previous),
+		To:      req.Role,
+		IP:      c.ClientIP(),
+	})
 
 	c.JSON(http.StatusOK, types.SuccessResponse{Message: "user role updated successfully"})
 }
@@ -524,3 +734,95 @@ func (h *AuthHandler) DeleteUser(c *gin.Context) {
 
 	c.JSON(http.StatusOK, types.SuccessResponse{Message: "user deleted successfully"})
 }
+
+// RevokeUserSessions godoc
+// @Summary      Revoke a user's sessions
+// @Description  Revoke every access and refresh token of a user at once, so they must log in again everywhere (admin only). Every revocation is recorded in the audit log.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  types.SuccessResponse
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/users/{id}/sessions [delete]
+func (h *AuthHandler) RevokeUserSessions(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "invalid user ID"})
+		return
+	}
+
+	if err := h.authService.RevokeSessions(uint(userID)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "user not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.auditService.Record(c.GetUint("userID"), requestLocation(c), models.AuditUserSessionsEnd, map[string]interface{}{
+		"user_id": userID,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "sessions revoked successfully"})
+}
+
+// AnonymizeUser godoc
+// @Summary      Anonymize a user
+// @Description  Irreversibly erase a user's personal data to honor a right-to-be-forgotten request (admin only). The username and email are replaced with a random pseudonym, the full name, password, time zone, phone number, push token, known devices and quote notes are erased, and the account is soft-deleted. Reviews, wishlists and quotes keep referencing the same user ID. Soft-deleted users can be anonymized too. Every anonymization is recorded in the audit log.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  types.DataResponse[dto.AnonymizedUserResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/users/{id}/anonymize [delete]
+func (h *AuthHandler) AnonymizeUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "invalid user ID"})
+		return
+	}
+
+	// Recorded first, since the erasure can't be undone
+	if err := h.auditService.Record(c.GetUint("userID"), requestLocation(c), models.AuditUserAnonymize, map[string]interface{}{
+		"user_id": userID,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	user, err := h.authService.AnonymizeUser(uint(userID))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "user not found"})
+		case errors.Is(err, services.ErrCannotAnonymizeAdmin):
+			c.JSON(http.StatusForbidden, types.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, services.ErrUserAlreadyAnonymized):
+			c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "User anonymized",
+		Data:    mappers.ToAnonymizedUserResponse(user),
+	})
+}
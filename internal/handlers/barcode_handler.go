@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/barcode"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// defaultBarcodeScale is the module width in pixels when no size is requested
+const defaultBarcodeScale = 3
+
+// BarcodeHandler handles barcode requests for label printing
+type BarcodeHandler struct {
+	barcodeService *services.BarcodeService
+}
+
+// NewBarcodeHandler creates a new barcode handler
+func NewBarcodeHandler(barcodeService *services.BarcodeService) *BarcodeHandler {
+	return &BarcodeHandler{barcodeService: barcodeService}
+}
+
+// GetProductBarcode godoc
+// @Summary      Get a product barcode
+// @Description  Render the product's SKU as a Code 128 barcode or QR code for label printing. Rendered images are cached in storage until the SKU changes.
+// @Tags         products
+// @Produce      png
+// @Produce      image/svg+xml
+// @Security     Bearer
+// @Param        id      path      int     true   "Product ID"
+// @Param        format  query     string  false  "Barcode format (code128, qr)" default(code128)
+// @Param        type    query     string  false  "Image type (png, svg)" default(png)
+// @Param        size    query     int     false  "Pixels per module, 1 to 20" default(3)
+// @Success      200     {file}    binary
+// @Failure      400     {object}  types.ErrorResponse
+// @Failure      401     {object}  types.ErrorResponse
+// @Failure      404     {object}  types.ErrorResponse
+// @Failure      409     {object}  types.ErrorResponse
+// @Failure      500     {object}  types.ErrorResponse
+// @Router       /products/{id}/barcode [get]
+func (h *BarcodeHandler) GetProductBarcode(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid product ID"})
+		return
+	}
+	var req dto.BarcodeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if req.Format == "" {
+		req.Format = services.BarcodeCode128
+	}
+	if req.Type == "" {
+		req.Type = services.BarcodePNG
+	}
+	if req.Size == 0 {
+		req.Size = defaultBarcodeScale
+	}
+
+	image, err := h.barcodeService.ProductBarcode(c.Request.Context(), uint(id), req.Format, req.Type, req.Size)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Product not found"})
+		case errors.Is(err, services.ErrNoSKU):
+			c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, barcode.ErrInvalidCharacter), errors.Is(err, barcode.ErrTooLong):
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.Data(http.StatusOK, image.ContentType, image.Data)
+}
@@ -0,0 +1,336 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BookingHandler handles the availability calendars of bookable products and
+// their bookings
+type BookingHandler struct {
+	bookingService *services.BookingService
+}
+
+// NewBookingHandler creates a new booking handler
+func NewBookingHandler(bookingService *services.BookingService) *BookingHandler {
+	return &BookingHandler{bookingService: bookingService}
+}
+
+// GetAvailability godoc
+// @Summary      Get a product's availability calendar
+// @Description  Get the availability slots of a bookable product for a month, grouped by day in the request's time zone, with the units left to book in each slot and each day. Every day of the month is listed, including those without slots. Slots that already started have none left.
+// @Tags         bookings
+// @Produce      json
+// @Security     Bearer
+// @Param        id     path      int     true   "Product ID"
+// @Param        month  query     string  false  "Month as YYYY-MM, the current one by default"
+// @Success      200    {object}  types.DataResponse[dto.AvailabilityResponse]
+// @Failure      400    {object}  types.ErrorResponse
+// @Failure      401    {object}  types.ErrorResponse
+// @Failure      404    {object}  types.ErrorResponse
+// @Failure      409    {object}  types.ErrorResponse
+// @Failure      500    {object}  types.ErrorResponse
+// @Router       /products/{id}/availability [get]
+func (h *BookingHandler) GetAvailability(c *gin.Context) {
+	id, ok := parseProductID(c)
+	if !ok {
+		return
+	}
+	var req dto.AvailabilityRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	calendar, err := h.bookingService.Availability(requestContext(c), id, c.GetString("country"), req.Month)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    calendar,
+	})
+}
+
+// CreateBooking godoc
+// @Summary      Book a slot
+// @Description  Reserve units of an availability slot of a bookable product, 1 by default. Units are taken atomically, so a slot is never booked beyond its capacity. Slots that already started or don't have enough units left return 409.
+// @Tags         bookings
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                       true  "Product ID"
+// @Param        request  body      dto.CreateBookingRequest  true  "Slot and quantity"
+// @Success      201      {object}  types.DataResponse[dto.BookingResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /products/{id}/bookings [post]
+func (h *BookingHandler) CreateBooking(c *gin.Context) {
+	id, ok := parseProductID(c)
+	if !ok {
+		return
+	}
+	var req dto.CreateBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	booking, err := h.bookingService.Book(id, c.GetUint("userID"), c.GetString("country"), req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Booking confirmed",
+		Data:    mappers.ToBookingResponse(booking),
+	})
+}
+
+// ListMyBookings godoc
+// @Summary      List my bookings
+// @Description  Get a paginated list of the current user's bookings, newest first
+// @Tags         bookings
+// @Produce      json
+// @Security     Bearer
+// @Param        status     query     string  false  "Filter by status (confirmed, cancelled)"
+// @Param        page       query     int     false  "Page number" default(1)
+// @Param        page_size  query     int     false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /bookings [get]
+func (h *BookingHandler) ListMyBookings(c *gin.Context) {
+	h.listBookings(c, c.GetUint("userID"))
+}
+
+// CancelMyBooking godoc
+// @Summary      Cancel a booking
+// @Description  Cancel one of the current user's bookings before its slot starts, freeing its units for others to book
+// @Tags         bookings
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Booking ID"
+// @Success      200  {object}  types.DataResponse[dto.BookingResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /bookings/{id}/cancel [post]
+func (h *BookingHandler) CancelMyBooking(c *gin.Context) {
+	h.cancelBooking(c, c.GetUint("userID"))
+}
+
+// ListBookings godoc
+// @Summary      List bookings
+// @Description  Get a paginated list of every customer's bookings, newest first, optionally of one product (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        product_id  query     int     false  "Filter by product"
+// @Param        status      query     string  false  "Filter by status (confirmed, cancelled)"
+// @Param        page        query     int     false  "Page number" default(1)
+// @Param        page_size   query     int     false  "Page size" default(10)
+// @Success      200         {object}  types.PaginatedResponse
+// @Failure      400         {object}  types.ErrorResponse
+// @Failure      401         {object}  types.ErrorResponse
+// @Failure      403         {object}  types.ErrorResponse
+// @Failure      500         {object}  types.ErrorResponse
+// @Router       /admin/bookings [get]
+func (h *BookingHandler) ListBookings(c *gin.Context) {
+	h.listBookings(c, 0)
+}
+
+// CancelBooking godoc
+// @Summary      Cancel a booking
+// @Description  Cancel any customer's booking before its slot starts, freeing its units (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Booking ID"
+// @Success      200  {object}  types.DataResponse[dto.BookingResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/bookings/{id}/cancel [post]
+func (h *BookingHandler) CancelBooking(c *gin.Context) {
+	h.cancelBooking(c, 0)
+}
+
+// CreateSlots godoc
+// @Summary      Add availability slots
+// @Description  Add up to 200 availability slots to a bookable product, each with the units it can be booked for. Slots must start in the future, end after they start and overlap neither each other nor the product's existing slots. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                     true  "Product ID"
+// @Param        request  body      dto.CreateSlotsRequest  true  "Slots"
+// @Success      201      {object}  types.DataResponse[[]dto.SlotResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/products/{id}/slots [post]
+func (h *BookingHandler) CreateSlots(c *gin.Context) {
+	id, ok := parseProductID(c)
+	if !ok {
+		return
+	}
+	var req dto.CreateSlotsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	slots, err := h.bookingService.CreateSlots(id, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Slots added",
+		Data:    mappers.ToSlotResponses(slots, time.Now()),
+	})
+}
+
+// DeleteSlot godoc
+// @Summary      Delete an availability slot
+// @Description  Delete an availability slot nobody holds a booking of; cancel its bookings first (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Slot ID"
+// @Success      200  {object}  types.APIResponse
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/slots/{id} [delete]
+func (h *BookingHandler) DeleteSlot(c *gin.Context) {
+	id, ok := parseSlotID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.bookingService.DeleteSlot(id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Slot deleted",
+	})
+}
+
+// listBookings responds with a page of bookings, limited to a user's unless userID is zero
+func (h *BookingHandler) listBookings(c *gin.Context, userID uint) {
+	var req dto.ListBookingsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if userID != 0 {
+		req.ProductID = 0
+	}
+	pagination := utils.NormalizePagination("bookings", req.Page, req.PageSize)
+
+	bookings, total, err := h.bookingService.ListBookings(userID, req.ProductID, models.BookingStatus(req.Status), pagination.Page, pagination.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(mappers.ToBookingResponses(bookings), total, pagination))
+}
+
+// cancelBooking cancels a booking, limited to a user's unless userID is zero
+func (h *BookingHandler) cancelBooking(c *gin.Context, userID uint) {
+	id, ok := parseBookingID(c)
+	if !ok {
+		return
+	}
+
+	booking, err := h.bookingService.CancelBooking(id, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Booking cancelled",
+		Data:    mappers.ToBookingResponse(booking),
+	})
+}
+
+// respondError maps a booking service error to its HTTP response
+func (h *BookingHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrBookingProduct):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Product not found"})
+	case errors.Is(err, services.ErrSlotNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Slot not found"})
+	case errors.Is(err, services.ErrBookingNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Booking not found"})
+	case errors.Is(err, services.ErrInvalidSlot):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrProductNotBookable), errors.Is(err, repositories.ErrSlotOverlap),
+		errors.Is(err, repositories.ErrSlotStarted), errors.Is(err, repositories.ErrSlotFull),
+		errors.Is(err, repositories.ErrSlotBooked), errors.Is(err, repositories.ErrBookingOver):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// parseSlotID reads the slot ID path parameter, responding 400 when invalid
+func parseSlotID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid slot ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// parseBookingID reads the booking ID path parameter, responding 400 when invalid
+func parseBookingID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid booking ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
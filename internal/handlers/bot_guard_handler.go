@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"product-management/internal/dto"
+	"product-management/internal/types"
+	"product-management/pkg/botguard"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BotGuardHandler serves what clients need to pass the bot checks of public forms
+type BotGuardHandler struct {
+	guard *botguard.Guard
+}
+
+// NewBotGuardHandler creates a new bot guard handler
+func NewBotGuardHandler(guard *botguard.Guard) *BotGuardHandler {
+	return &BotGuardHandler{guard: guard}
+}
+
+// GetFormToken godoc
+// @Summary      Get a form token
+// @Description  Issue the token to send as form_token with the register, login and review forms when their timing check is enabled. Fetch it when the form is shown: submissions within BOT_MIN_FORM_TIME of issuing, or after BOT_MAX_FORM_AGE, are rejected. Also returns the CAPTCHA site key when a provider is configured.
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  types.DataResponse[dto.FormTokenResponse]
+// @Failure      404  {object}  types.ErrorResponse
+// @Router       /auth/form-token [get]
+func (h *BotGuardHandler) GetFormToken(c *gin.Context) {
+	if h.guard == nil || h.guard.Tokens == nil {
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "form tokens are not enabled"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data: dto.FormTokenResponse{
+			FormToken:      h.guard.Tokens.Issue(),
+			CaptchaSiteKey: h.guard.CaptchaSiteKey,
+		},
+	})
+}
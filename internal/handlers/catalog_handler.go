@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CatalogHandler handles views of the catalog as a whole
+type CatalogHandler struct {
+	catalogService *services.CatalogService
+}
+
+// NewCatalogHandler creates a new catalog handler
+func NewCatalogHandler(catalogService *services.CatalogService) *CatalogHandler {
+	return &CatalogHandler{catalogService: catalogService}
+}
+
+// GetDiff godoc
+// @Summary      Diff the catalog
+// @Description  Get the products and categories created, updated or deleted since a time, with the fields each update changed, for downstream systems to sync incrementally. Pass the until of a response as from to get the next changes; some changes may show up in both. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        from  query     string  true  "RFC 3339 time, e.g. 2021-01-01T00:00:00Z"
+// @Success      200   {object}  types.DataResponse[dto.CatalogDiffResponse]
+// @Failure      400   {object}  types.ErrorResponse
+// @Failure      401   {object}  types.ErrorResponse
+// @Failure      403   {object}  types.ErrorResponse
+// @Failure      500   {object}  types.ErrorResponse
+// @Router       /admin/catalog/diff [get]
+func (h *CatalogHandler) GetDiff(c *gin.Context) {
+	var req dto.CatalogDiffRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	from, err := time.Parse(time.RFC3339, req.From)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "from must be an RFC 3339 time"})
+		return
+	}
+
+	diff, err := h.catalogService.Diff(from)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    diff,
+	})
+}
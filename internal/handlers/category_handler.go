@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"product-management/internal/dto"
+	"product-management/internal/mappers"
 	"product-management/internal/services"
 	"product-management/internal/types"
 
@@ -13,23 +15,24 @@ import (
 
 // CategoryHandler handles category-related HTTP requests
 type CategoryHandler struct {
-	categoryService *services.CategoryService
+	categoryService  *services.CategoryService
+	priceListService *services.PriceListService
 }
 
 // NewCategoryHandler creates a new category handler
-func NewCategoryHandler(categoryService *services.CategoryService) *CategoryHandler {
-	return &CategoryHandler{categoryService: categoryService}
+func NewCategoryHandler(categoryService *services.CategoryService, priceListService *services.PriceListService) *CategoryHandler {
+	return &CategoryHandler{categoryService: categoryService, priceListService: priceListService}
 }
 
 // CreateCategory godoc
 // @Summary      Create a new category
-// @Description  Create a new category with name and optional description
+// @Description  Create a new category with name and optional description. metadata_schema defines the metadata keys of its products, with their type and whether they are required.
 // @Tags         categories
 // @Accept       json
 // @Produce      json
 // @Security     Bearer
 // @Param        request  body      dto.CreateCategoryRequest  true  "Category details"
-// @Success      201     {object}   types.APIResponse
+// @Success      201     {object}   types.DataResponse[dto.CategoryResponse]
 // @Failure      400     {object}   types.ErrorResponse
 // @Failure      500     {object}   types.ErrorResponse
 // @Router       /categories [post]
@@ -42,15 +45,15 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 
 	category, err := h.categoryService.CreateCategory(req)
 	if err != nil {
+		if errors.Is(err, services.ErrMetadataSchema) {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	response := dto.CategoryResponse{
-		ID:          category.ID,
-		Name:        category.Name,
-		Description: category.Description,
-	}
+	response := mappers.ToCategoryResponse(category)
 
 	c.JSON(http.StatusCreated, types.APIResponse{
 		Success: true,
@@ -66,9 +69,10 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "Category ID"
-// @Success      200  {object}  types.APIResponse
+// @Success      200  {object}  types.DataResponse[dto.CategoryResponse]
 // @Failure      400  {object}  types.ErrorResponse
 // @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
 // @Security     Bearer
 // @Router       /categories/{id} [get]
 func (h *CategoryHandler) GetCategoryByID(c *gin.Context) {
@@ -86,7 +90,52 @@ func (h *CategoryHandler) GetCategoryByID(c *gin.Context) {
 
 	c.JSON(http.StatusOK, types.APIResponse{
 		Success: true,
-		Data:    category,
+		Data:    mappers.ToCategoryResponse(category),
+	})
+}
+
+// GetCategoriesBatch godoc
+// @Summary      Get several categories
+// @Description  Get up to 100 categories by ID in one call, in the order requested. IDs that don't exist are listed in missing.
+// @Tags         categories
+// @Produce      json
+// @Param        ids  query     string  true  "Comma-separated category IDs, e.g. 1,2,3"
+// @Success      200  {object}  types.DataResponse[dto.CategoryBatchResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Security     Bearer
+// @Router       /categories/batch [get]
+func (h *CategoryHandler) GetCategoriesBatch(c *gin.Context) {
+	ids, ok := batchIDs(c)
+	if !ok {
+		return
+	}
+
+	// The full list is cached with product counts, so picking from it is
+	// cheaper than querying the categories and counting their products
+	categories, err := h.categoryService.GetAllCategories()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	byID := make(map[uint]dto.CategoryResponse, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	response := dto.CategoryBatchResponse{Items: []dto.CategoryResponse{}, Missing: []uint{}}
+	for _, id := range ids {
+		category, ok := byID[id]
+		if !ok {
+			response.Missing = append(response.Missing, id)
+			continue
+		}
+		response.Items = append(response.Items, category)
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
 	})
 }
 
@@ -96,7 +145,7 @@ func (h *CategoryHandler) GetCategoryByID(c *gin.Context) {
 // @Tags         categories
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  types.APIResponse
+// @Success      200  {object}  types.DataResponse[[]dto.CategoryResponse]
 // @Failure      500  {object}  types.ErrorResponse
 // @Security     Bearer
 // @Router       /categories [get]
@@ -115,14 +164,14 @@ func (h *CategoryHandler) GetAllCategories(c *gin.Context) {
 
 // UpdateCategory godoc
 // @Summary      Update a category
-// @Description  Update an existing category with name and optional description
+// @Description  Update an existing category with name, optional description and metadata schema. Products already in the category are checked against a changed schema on their next edit.
 // @Tags         categories
 // @Accept       json
 // @Produce      json
 // @Security     Bearer
 // @Param        id       path      int                        true  "Category ID"
 // @Param        request  body      dto.UpdateCategoryRequest  true  "Category details"
-// @Success      200     {object}   types.APIResponse
+// @Success      200     {object}   types.DataResponse[dto.CategoryResponse]
 // @Failure      400     {object}   types.ErrorResponse
 // @Failure      404     {object}   types.ErrorResponse
 // @Failure      500     {object}   types.ErrorResponse
@@ -146,15 +195,15 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: err.Error()})
 			return
 		}
+		if errors.Is(err, services.ErrMetadataSchema) {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	response := dto.CategoryResponse{
-		ID:          category.ID,
-		Name:        category.Name,
-		Description: category.Description,
-	}
+	response := mappers.ToCategoryResponse(category)
 
 	c.JSON(http.StatusOK, types.APIResponse{
 		Success: true,
@@ -170,7 +219,7 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "Category ID"
-// @Success      204  {object}  types.SuccessResponse
+// @Success      200  {object}  types.SuccessResponse
 // @Failure      400  {object}  types.ErrorResponse
 // @Failure      500  {object}  types.ErrorResponse
 // @Security     Bearer
@@ -196,12 +245,12 @@ func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 
 // GetProductsByCategoryID godoc
 // @Summary      Get category products
-// @Description  Get all products in a specific category
+// @Description  Get all products in a specific category. Customers with a price list also get their price and quantity breaks. Products not available in the caller's country are left out, except for admins.
 // @Tags         categories
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "Category ID"
-// @Success      200  {object}  types.APIResponse
+// @Success      200  {object}  types.DataResponse[[]dto.ProductResponse]
 // @Failure      400  {object}  types.ErrorResponse
 // @Failure      500  {object}  types.ErrorResponse
 // @Security     Bearer
@@ -218,10 +267,25 @@ func (h *CategoryHandler) GetProductsByCategoryID(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
 		return
 	}
+	// Leave out products not available in the caller's country
+	available := products[:0]
+	for i := range products {
+		if availableToCaller(c, &products[i]) {
+			available = append(available, products[i])
+		}
+	}
+	products = available
+
+	pricing, ok := customerPricing(c, h.priceListService)
+	if !ok {
+		return
+	}
+	responses := mappers.ToProductResponses(products)
+	pricing.Apply(responses)
 
 	c.JSON(http.StatusOK, types.APIResponse{
 		Success: true,
-		Data:    products,
+		Data:    responses,
 	})
 }
 
@@ -233,7 +297,7 @@ func (h *CategoryHandler) GetProductsByCategoryID(c *gin.Context) {
 // @Produce      json
 // @Param        id         path      int  true  "Category ID"
 // @Param        productId  path      int  true  "Product ID"
-// @Success      204        {object}  types.SuccessResponse
+// @Success      200        {object}  types.SuccessResponse
 // @Failure      400        {object}  types.ErrorResponse
 // @Failure      500        {object}  types.ErrorResponse
 // @Security     Bearer
@@ -267,7 +331,7 @@ func (h *CategoryHandler) AddProductToCategory(c *gin.Context) {
 // @Produce      json
 // @Param        id         path      int  true  "Category ID"
 // @Param        productId  path      int  true  "Product ID"
-// @Success      204        {object}  types.SuccessResponse
+// @Success      200        {object}  types.SuccessResponse
 // @Failure      400        {object}  types.ErrorResponse
 // @Failure      500        {object}  types.ErrorResponse
 // @Security     Bearer
@@ -299,7 +363,7 @@ func (h *CategoryHandler) RemoveProductFromCategory(c *gin.Context) {
 // @Tags         categories
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  types.APIResponse
+// @Success      200  {object}  types.DataResponse[[]dto.CategoryDistributionResponse]
 // @Failure      500  {object}  types.ErrorResponse
 // @Security     Bearer
 // @Router       /categories/distribution [get]
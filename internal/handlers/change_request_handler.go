@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ChangeRequestHandler handles admin review of product change requests
+type ChangeRequestHandler struct {
+	changeService *services.ProductChangeService
+}
+
+// NewChangeRequestHandler creates a new change request handler
+func NewChangeRequestHandler(changeService *services.ProductChangeService) *ChangeRequestHandler {
+	return &ChangeRequestHandler{changeService: changeService}
+}
+
+// ListChangeRequests godoc
+// @Summary      List product change requests
+// @Description  Get a paginated list of product change requests (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        status     query     string  false  "Filter by status (pending, approved, rejected)"
+// @Param        page       query     int     false  "Page number" default(1)
+// @Param        page_size  query     int     false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /admin/change-requests [get]
+func (h *ChangeRequestHandler) ListChangeRequests(c *gin.Context) {
+	var req dto.ListChangeRequestsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	pagination := utils.NormalizePagination("change_requests", req.Page, req.PageSize)
+
+	changeRequests, total, err := h.changeService.ListChangeRequests(models.ChangeRequestStatus(req.Status), pagination.Page, pagination.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.ProductChangeRequestResponse, len(changeRequests))
+	for i := range changeRequests {
+		items[i] = mappers.ToChangeRequestResponse(&changeRequests[i])
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(items, total, pagination))
+}
+
+// GetChangeRequest godoc
+// @Summary      Get a product change request
+// @Description  Get a product change request with its proposed diff (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Change request ID"
+// @Success      200  {object}  types.DataResponse[dto.ProductChangeRequestResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/change-requests/{id} [get]
+func (h *ChangeRequestHandler) GetChangeRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid change request ID"})
+		return
+	}
+
+	changeRequest, err := h.changeService.GetChangeRequest(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Change request not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToChangeRequestResponse(changeRequest),
+	})
+}
+
+// ApproveChangeRequest godoc
+// @Summary      Approve a product change request
+// @Description  Apply the proposed product change and mark the request as approved (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                             true   "Change request ID"
+// @Param        request  body      dto.ReviewChangeRequestRequest  false  "Review note"
+// @Success      200      {object}  types.DataResponse[dto.ProductChangeRequestResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/change-requests/{id}/approve [post]
+func (h *ChangeRequestHandler) ApproveChangeRequest(c *gin.Context) {
+	h.resolveChangeRequest(c, h.changeService.ApproveChangeRequest, "Change request approved")
+}
+
+// RejectChangeRequest godoc
+// @Summary      Reject a product change request
+// @Description  Mark the request as rejected without changing the product (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                             true   "Change request ID"
+// @Param        request  body      dto.ReviewChangeRequestRequest  false  "Review note"
+// @Success      200      {object}  types.DataResponse[dto.ProductChangeRequestResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/change-requests/{id}/reject [post]
+func (h *ChangeRequestHandler) RejectChangeRequest(c *gin.Context) {
+	h.resolveChangeRequest(c, h.changeService.RejectChangeRequest, "Change request rejected")
+}
+
+// resolveChangeRequest parses the request and runs the given approve/reject action
+func (h *ChangeRequestHandler) resolveChangeRequest(c *gin.Context, resolve func(id, reviewerID uint, note string) (*models.ProductChangeRequest, error), message string) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid change request ID"})
+		return
+	}
+
+	var req dto.ReviewChangeRequestRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	changeRequest, err := resolve(uint(id), c.GetUint("userID"), req.Note)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Change request not found"})
+		case errors.Is(err, repositories.ErrChangeRequestResolved), errors.Is(err, services.ErrChangeRequestOutdated):
+			c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    mappers.ToChangeRequestResponse(changeRequest),
+	})
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+
+	"product-management/config"
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConfigHandler shows and reloads the runtime configuration of the instance
+// serving the request
+type ConfigHandler struct {
+	configService *services.ConfigService
+	auditService  *services.AuditService
+}
+
+// NewConfigHandler creates a new config handler
+func NewConfigHandler(configService *services.ConfigService, auditService *services.AuditService) *ConfigHandler {
+	return &ConfigHandler{configService: configService, auditService: auditService}
+}
+
+// GetConfig godoc
+// @Summary      Get the runtime configuration
+// @Description  Get the configuration that can be reloaded without a restart, as in effect on the instance serving the request: log level, rate limits, feature flags and maintenance mode. API keys are listed by name only. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[dto.RuntimeConfigResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Router       /admin/config [get]
+func (h *ConfigHandler) GetConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    runtimeConfigResponse(h.configService.Current()),
+	})
+}
+
+// ReloadConfig godoc
+// @Summary      Reload the runtime configuration
+// @Description  Read the log level, rate limits, feature flags and maintenance mode again from CONFIG_FILE and the environment, as SIGHUP does, and apply them without a restart. An invalid configuration is rejected with 422 and the one in effect is kept. Only the instance serving the request reloads. Recorded in the audit log. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[dto.ReloadConfigResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      422  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/config/reload [post]
+func (h *ConfigHandler) ReloadConfig(c *gin.Context) {
+	if err := h.auditService.Record(c.GetUint("userID"), requestLocation(c), models.AuditConfigReload, map[string]interface{}{
+		"config_file": h.configService.Current().Source,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	changed, err := h.configService.Reload("admin API")
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if changed == nil {
+		changed = []string{}
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Configuration reloaded",
+		Data: dto.ReloadConfigResponse{
+			Changed: changed,
+			Config:  runtimeConfigResponse(h.configService.Current()),
+		},
+	})
+}
+
+func runtimeConfigResponse(runtime *config.Runtime) dto.RuntimeConfigResponse {
+	apiKeys := make([]dto.RuntimeConfigAPIKey, 0, len(runtime.RateLimitPolicy.APIKeys))
+	for _, key := range runtime.RateLimitPolicy.APIKeys {
+		apiKeys = append(apiKeys, dto.RuntimeConfigAPIKey{Name: key.Name, Limit: key.Limit})
+	}
+	sort.Slice(apiKeys, func(i, j int) bool { return apiKeys[i].Name < apiKeys[j].Name })
+
+	return dto.RuntimeConfigResponse{
+		LogLevel:           runtime.LogLevel,
+		RateLimitTiers:     runtime.RateLimitPolicy.Tiers,
+		RateLimitGroups:    runtime.RateLimitPolicy.Groups,
+		RateLimitAPIKeys:   apiKeys,
+		FeatureFlags:       runtime.FeatureFlags,
+		MaintenanceMode:    runtime.MaintenanceMode,
+		MaintenanceMessage: runtime.MaintenanceMessage,
+		Source:             runtime.Source,
+		LoadedAt:           dto.NewTime(runtime.LoadedAt),
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConnectorHandler handles the connectors syncing with legacy systems
+type ConnectorHandler struct {
+	connectorService *services.ConnectorService
+}
+
+// NewConnectorHandler creates a new connector handler
+func NewConnectorHandler(connectorService *services.ConnectorService) *ConnectorHandler {
+	return &ConnectorHandler{connectorService: connectorService}
+}
+
+// ListConnectors godoc
+// @Summary      List connectors
+// @Description  List the connectors configured to sync products, stock and orders with legacy systems, with how often each syncs and its latest run. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[[]dto.ConnectorResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/connectors [get]
+func (h *ConnectorHandler) ListConnectors(c *gin.Context) {
+	list, err := h.connectorService.ListConnectors()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    list,
+	})
+}
+
+// ListRuns godoc
+// @Summary      List connector sync runs
+// @Description  Get the log of a connector's sync runs, newest first, with what each pulled, changed and pushed and the error of any step that failed. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        name       path      string  true   "Connector name"
+// @Param        page       query     int     false  "Page number" default(1)
+// @Param        page_size  query     int     false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Failure      404        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /admin/connectors/{name}/runs [get]
+func (h *ConnectorHandler) ListRuns(c *gin.Context) {
+	var req dto.ListConnectorRunsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	pagination := utils.NormalizePagination("connector_runs", req.Page, req.PageSize)
+
+	runs, total, err := h.connectorService.ListRuns(c.Param("name"), pagination.Page, pagination.Limit)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	items := make([]dto.ConnectorSyncRunResponse, len(runs))
+	for i := range runs {
+		items[i] = mappers.ToConnectorSyncRunResponse(&runs[i])
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(items, total, pagination))
+}
+
+// SyncConnector godoc
+// @Summary      Sync a connector now
+// @Description  Start a sync of a connector in the background without waiting for its schedule, and return its run. Follow it in the run log. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        name  path      string  true  "Connector name"
+// @Success      202   {object}  types.DataResponse[dto.ConnectorSyncRunResponse]
+// @Failure      401   {object}  types.ErrorResponse
+// @Failure      403   {object}  types.ErrorResponse
+// @Failure      404   {object}  types.ErrorResponse
+// @Failure      409   {object}  types.ErrorResponse
+// @Failure      500   {object}  types.ErrorResponse
+// @Router       /admin/connectors/{name}/sync [post]
+func (h *ConnectorHandler) SyncConnector(c *gin.Context) {
+	run, err := h.connectorService.Trigger(c.Param("name"), c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, types.APIResponse{
+		Success: true,
+		Message: "Sync started",
+		Data:    mappers.ToConnectorSyncRunResponse(run),
+	})
+}
+
+// respondError maps a connector service error to its HTTP response
+func (h *ConnectorHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrConnectorNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Connector not found"})
+	case errors.Is(err, services.ErrConnectorSyncActive):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// contentMaxAge is how long clients and CDNs may reuse a public content block.
+// It is short so that edits and schedules show up quickly.
+const contentMaxAge = "public, max-age=60"
+
+// ContentBlockHandler handles content block requests
+type ContentBlockHandler struct {
+	contentService *services.ContentBlockService
+}
+
+// NewContentBlockHandler creates a new content block handler
+func NewContentBlockHandler(contentService *services.ContentBlockService) *ContentBlockHandler {
+	return &ContentBlockHandler{contentService: contentService}
+}
+
+// GetContent godoc
+// @Summary      Get a content block
+// @Description  Get a live content block, such as a homepage banner, by its key. No login is needed. Blocks outside their schedule are not found. The body is HTML as the admin wrote it.
+// @Tags         content
+// @Produce      json
+// @Param        key  path      string  true  "Content block key"
+// @Success      200  {object}  types.DataResponse[dto.PublicContentBlockResponse]
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /content/{key} [get]
+func (h *ContentBlockHandler) GetContent(c *gin.Context) {
+	block, err := h.contentService.LiveBlock(c.Param("key"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", contentMaxAge)
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToPublicContentBlockResponse(block),
+	})
+}
+
+// ListContentBlocks godoc
+// @Summary      List content blocks
+// @Description  List every content block ordered by key, including scheduled and expired ones (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[[]dto.ContentBlockResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/content-blocks [get]
+func (h *ContentBlockHandler) ListContentBlocks(c *gin.Context) {
+	blocks, err := h.contentService.ListBlocks()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.ContentBlockResponse, len(blocks))
+	for i := range blocks {
+		items[i] = mappers.ToContentBlockResponse(&blocks[i])
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    items,
+	})
+}
+
+// GetContentBlock godoc
+// @Summary      Get a content block by ID
+// @Description  Get a content block by ID whatever its schedule (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Content block ID"
+// @Success      200  {object}  types.DataResponse[dto.ContentBlockResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/content-blocks/{id} [get]
+func (h *ContentBlockHandler) GetContentBlock(c *gin.Context) {
+	id, ok := parseContentBlockID(c)
+	if !ok {
+		return
+	}
+
+	block, err := h.contentService.GetBlock(id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToContentBlockResponse(block),
+	})
+}
+
+// CreateContentBlock godoc
+// @Summary      Create a content block
+// @Description  Add a content block under a unique key, optionally shown only between starts_at and ends_at (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.ContentBlockRequest  true  "Content block"
+// @Success      201      {object}  types.DataResponse[dto.ContentBlockResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/content-blocks [post]
+func (h *ContentBlockHandler) CreateContentBlock(c *gin.Context) {
+	var req dto.ContentBlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	block, err := h.contentService.CreateBlock(req, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Content block created",
+		Data:    mappers.ToContentBlockResponse(block),
+	})
+}
+
+// UpdateContentBlock godoc
+// @Summary      Update a content block
+// @Description  Replace the key, content and schedule of a content block (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                      true  "Content block ID"
+// @Param        request  body      dto.ContentBlockRequest  true  "Content block"
+// @Success      200      {object}  types.DataResponse[dto.ContentBlockResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/content-blocks/{id} [put]
+func (h *ContentBlockHandler) UpdateContentBlock(c *gin.Context) {
+	id, ok := parseContentBlockID(c)
+	if !ok {
+		return
+	}
+	var req dto.ContentBlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	block, err := h.contentService.UpdateBlock(id, req, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Content block updated",
+		Data:    mappers.ToContentBlockResponse(block),
+	})
+}
+
+// DeleteContentBlock godoc
+// @Summary      Delete a content block
+// @Description  Delete a content block, freeing its key (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Content block ID"
+// @Success      200  {object}  types.SuccessResponse
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/content-blocks/{id} [delete]
+func (h *ContentBlockHandler) DeleteContentBlock(c *gin.Context) {
+	id, ok := parseContentBlockID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.contentService.DeleteBlock(id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Content block deleted"})
+}
+
+// respondError maps a content block service error to its HTTP response
+func (h *ContentBlockHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Content block not found"})
+	case errors.Is(err, services.ErrInvalidContentKey), errors.Is(err, services.ErrContentBlockSchedule):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrContentKeyTaken):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// parseContentBlockID reads the content block ID path parameter, responding
+// 400 when invalid
+func parseContentBlockID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid content block ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
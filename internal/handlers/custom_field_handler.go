@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// formSchemaMaxAge is how long clients and CDNs may reuse a form schema. It is
+// short so that new fields show up quickly.
+const formSchemaMaxAge = "public, max-age=60"
+
+// CustomFieldHandler handles custom form field requests
+type CustomFieldHandler struct {
+	customFieldService *services.CustomFieldService
+}
+
+// NewCustomFieldHandler creates a new custom field handler
+func NewCustomFieldHandler(customFieldService *services.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{customFieldService: customFieldService}
+}
+
+// GetFormSchema godoc
+// @Summary      Get the schema of a form
+// @Description  Get the custom fields of the registration or checkout form in display order, with their type and validation, so clients can render them as they see fit. No login is needed. Values are sent as custom_fields when registering or accepting a quote.
+// @Tags         forms
+// @Produce      json
+// @Param        name  path      string  true  "Form name" Enums(registration, checkout)
+// @Success      200   {object}  types.DataResponse[dto.FormSchemaResponse]
+// @Failure      404   {object}  types.ErrorResponse
+// @Failure      429   {object}  types.ErrorResponse
+// @Failure      500   {object}  types.ErrorResponse
+// @Router       /forms/{name}/schema [get]
+func (h *CustomFieldHandler) GetFormSchema(c *gin.Context) {
+	form, err := services.ParseForm(c.Param("name"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Form not found"})
+		return
+	}
+
+	fields, err := h.customFieldService.FormFields(form)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", formSchemaMaxAge)
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data: dto.FormSchemaResponse{
+			Form:   string(form),
+			Fields: mappers.ToCustomFieldResponses(fields),
+		},
+	})
+}
+
+// ListCustomFields godoc
+// @Summary      List custom form fields
+// @Description  List the custom fields of every form, or of one, by form and position (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        form  query     string  false  "Filter by form" Enums(registration, checkout)
+// @Success      200   {object}  types.DataResponse[[]dto.CustomFieldResponse]
+// @Failure      400   {object}  types.ErrorResponse
+// @Failure      401   {object}  types.ErrorResponse
+// @Failure      403   {object}  types.ErrorResponse
+// @Failure      500   {object}  types.ErrorResponse
+// @Router       /admin/custom-fields [get]
+func (h *CustomFieldHandler) ListCustomFields(c *gin.Context) {
+	var form models.CustomForm
+	if name := c.Query("form"); name != "" {
+		var err error
+		if form, err = services.ParseForm(name); err != nil {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	fields, err := h.customFieldService.ListFields(form)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToCustomFieldResponses(fields),
+	})
+}
+
+// CreateCustomField godoc
+// @Summary      Create a custom form field
+// @Description  Add a field to the registration or checkout form under a key unique to the form. Select fields need options; only text fields take a pattern and lengths, and only number fields a min and max (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.CustomFieldRequest  true  "Custom field"
+// @Success      201      {object}  types.DataResponse[dto.CustomFieldResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/custom-fields [post]
+func (h *CustomFieldHandler) CreateCustomField(c *gin.Context) {
+	var req dto.CustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	field, err := h.customFieldService.CreateField(req, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Custom field created",
+		Data:    mappers.ToCustomFieldResponse(field),
+	})
+}
+
+// UpdateCustomField godoc
+// @Summary      Update a custom form field
+// @Description  Replace the definition of a custom field. Values already submitted are kept as they are (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                     true  "Custom field ID"
+// @Param        request  body      dto.CustomFieldRequest  true  "Custom field"
+// @Success      200      {object}  types.DataResponse[dto.CustomFieldResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/custom-fields/{id} [put]
+func (h *CustomFieldHandler) UpdateCustomField(c *gin.Context) {
+	id, ok := parseCustomFieldID(c)
+	if !ok {
+		return
+	}
+	var req dto.CustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	field, err := h.customFieldService.UpdateField(id, req, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Custom field updated",
+		Data:    mappers.ToCustomFieldResponse(field),
+	})
+}
+
+// DeleteCustomField godoc
+// @Summary      Delete a custom form field
+// @Description  Remove a field from its form, freeing its key. Values already submitted for it are kept (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Custom field ID"
+// @Success      200  {object}  types.SuccessResponse
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/custom-fields/{id} [delete]
+func (h *CustomFieldHandler) DeleteCustomField(c *gin.Context) {
+	id, ok := parseCustomFieldID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.customFieldService.DeleteField(id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Custom field deleted"})
+}
+
+// respondError maps a custom field service error to its HTTP response
+func (h *CustomFieldHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Custom field not found"})
+	case errors.Is(err, services.ErrUnknownForm), errors.Is(err, services.ErrInvalidCustomFieldKey), errors.Is(err, services.ErrCustomFieldDefinition):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrCustomFieldKeyTaken):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// respondCustomFieldError responds to a failure checking the custom field
+// values submitted with a form
+func respondCustomFieldError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrInvalidCustomFields) {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+}
+
+// parseCustomFieldID reads the custom field ID path parameter, responding 400
+// when invalid
+func parseCustomFieldID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid custom field ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	rtpprof "runtime/pprof"
+	"sync"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/database"
+	"product-management/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxVerboseLogging bounds how long verbose logging can be turned on for, so
+// a forgotten toggle doesn't flood the logs
+const maxVerboseLogging = time.Hour
+
+var publishRuntimeVars sync.Once
+
+// DiagnosticsHandler exposes profiles, goroutine dumps and runtime stats of
+// the instance serving the request, toggles its verbose logging and checks
+// its backends
+type DiagnosticsHandler struct {
+	selfTestService *services.SelfTestService
+}
+
+// NewDiagnosticsHandler creates a new diagnostics handler
+func NewDiagnosticsHandler(selfTestService *services.SelfTestService) *DiagnosticsHandler {
+	publishRuntimeVars.Do(func() {
+		expvar.Publish("goroutines", expvar.Func(func() interface{} { return runtime.NumGoroutine() }))
+		expvar.Publish("db_pool", expvar.Func(func() interface{} {
+			stats, err := database.PoolStats()
+			if err != nil {
+				return nil
+			}
+			return stats
+		}))
+	})
+	return &DiagnosticsHandler{selfTestService: selfTestService}
+}
+
+// SelfTest godoc
+// @Summary      Check backends
+// @Description  Check that the instance can use the database, cache, storage, mail server, product search and locks, writing and deleting a probe where needed. Failed checks come with a hint of what to look at. Responds 503 when a check fails. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[dto.SelfTestResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      503  {object}  types.DataResponse[dto.SelfTestResponse]
+// @Router       /admin/selftest [get]
+func (h *DiagnosticsHandler) SelfTest(c *gin.Context) {
+	report := h.selfTestService.Run(c.Request.Context())
+	status := http.StatusOK
+	if !report.OK {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, types.APIResponse{
+		Success: report.OK,
+		Data:    report,
+	})
+}
+
+// Profile godoc
+// @Summary      Runtime profiles
+// @Description  Serve the pprof profiles of the instance: an index without a name, or cmdline, profile (CPU, for ?seconds=30), symbol, trace, heap, allocs, goroutine, block, mutex or threadcreate. Use with go tool pprof. Admin only.
+// @Tags         admin
+// @Produce      octet-stream
+// @Security     Bearer
+// @Param        name  path      string  false  "Profile name, e.g. heap"
+// @Success      200   {file}    binary
+// @Failure      401   {object}  types.ErrorResponse
+// @Failure      403   {object}  types.ErrorResponse
+// @Failure      404   {object}  types.ErrorResponse
+// @Router       /admin/debug/pprof/{name} [get]
+func (h *DiagnosticsHandler) Profile(c *gin.Context) {
+	// The index links to the profiles relative to itself, so it needs the trailing slash
+	switch name := c.Param("name"); name {
+	case "/":
+		pprof.Index(c.Writer, c.Request)
+	case "/cmdline":
+		pprof.Cmdline(c.Writer, c.Request)
+	case "/profile":
+		pprof.Profile(c.Writer, c.Request)
+	case "/symbol":
+		pprof.Symbol(c.Writer, c.Request)
+	case "/trace":
+		pprof.Trace(c.Writer, c.Request)
+	default:
+		if rtpprof.Lookup(name[1:]) == nil {
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: fmt.Sprintf("unknown profile %q", name[1:])})
+			return
+		}
+		pprof.Handler(name[1:]).ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+// Goroutines godoc
+// @Summary      Goroutine dump
+// @Description  Get the stack trace of every goroutine of the instance as text, to find leaks and deadlocks. Admin only.
+// @Tags         admin
+// @Produce      plain
+// @Security     Bearer
+// @Success      200  {string}  string
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Router       /admin/debug/goroutines [get]
+func (h *DiagnosticsHandler) Goroutines(c *gin.Context) {
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Status(http.StatusOK)
+	rtpprof.Lookup("goroutine").WriteTo(c.Writer, 2)
+}
+
+// Vars godoc
+// @Summary      Runtime stats
+// @Description  Get the expvar variables of the instance: memory and GC stats (memstats), command line, goroutine count and database pool stats. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  map[string]interface{}
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Router       /admin/debug/vars [get]
+func (h *DiagnosticsHandler) Vars(c *gin.Context) {
+	expvar.Handler().ServeHTTP(c.Writer, c.Request)
+}
+
+// GetVerboseLogging godoc
+// @Summary      Get verbose logging
+// @Description  Get whether the instance logs verbosely and until when. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[dto.VerboseLoggingResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Router       /admin/debug/verbose-logging [get]
+func (h *DiagnosticsHandler) GetVerboseLogging(c *gin.Context) {
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    verboseLoggingResponse(),
+	})
+}
+
+// SetVerboseLogging godoc
+// @Summary      Toggle verbose logging
+// @Description  Log at debug level, with request headers and every SQL statement, for a duration of at most 1h, after which the instance goes back to its configured level. A duration of 0 turns it off. Only the instance serving the request is affected. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.VerboseLoggingRequest  true  "Duration"
+// @Success      200      {object}  types.DataResponse[dto.VerboseLoggingResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Router       /admin/debug/verbose-logging [put]
+func (h *DiagnosticsHandler) SetVerboseLogging(c *gin.Context) {
+	var req dto.VerboseLoggingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil || duration < 0 || duration > maxVerboseLogging {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: fmt.Sprintf("duration must be between 0 and %v", maxVerboseLogging)})
+		return
+	}
+
+	logger.SetVerbose(time.Now().Add(duration))
+	if duration > 0 {
+		logger.Info(fmt.Sprintf("Verbose logging on for %v", duration))
+	} else {
+		logger.Info("Verbose logging off")
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    verboseLoggingResponse(),
+	})
+}
+
+func verboseLoggingResponse() dto.VerboseLoggingResponse {
+	until := logger.VerboseUntil()
+	if until.IsZero() {
+		return dto.VerboseLoggingResponse{}
+	}
+	return dto.VerboseLoggingResponse{Enabled: true, Until: dto.NewTimePtr(&until)}
+}
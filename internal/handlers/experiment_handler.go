@@ -0,0 +1,418 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ExperimentHandler handles A/B experiment requests
+type ExperimentHandler struct {
+	experimentService *services.ExperimentService
+}
+
+// NewExperimentHandler creates a new experiment handler
+func NewExperimentHandler(experimentService *services.ExperimentService) *ExperimentHandler {
+	return &ExperimentHandler{experimentService: experimentService}
+}
+
+// GetAssignments godoc
+// @Summary      Get experiment assignments
+// @Description  Get the caller's variant in every running experiment they take part in, or in those with the given keys. Signed-in callers send their token and are assigned as the user; others send the anonymous ID their client keeps. The same caller always gets the same variant.
+// @Tags         experiments
+// @Produce      json
+// @Param        anonymous_id  query     string  false  "Anonymous visitor ID, required when not signed in"
+// @Param        keys          query     string  false  "Comma-separated experiment keys"
+// @Success      200  {object}  types.DataResponse[[]dto.ExperimentAssignment]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      429  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /experiments/assignments [get]
+func (h *ExperimentHandler) GetAssignments(c *gin.Context) {
+	var req dto.ExperimentAssignmentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid query parameters"})
+		return
+	}
+	subject, err := services.ExperimentSubject(c.GetUint("userID"), req.AnonymousID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var keys []string
+	for _, key := range strings.Split(req.Keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	assignments, err := h.experimentService.Assignments(subject, keys)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "Failed to get experiment assignments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    assignments,
+	})
+}
+
+// RecordEvent godoc
+// @Summary      Record an experiment event
+// @Description  Record that the caller was shown their variant of a running experiment (exposure), or reached a goal (conversion) with an optional value. The variant is assigned again on the server, so an event always counts toward the caller's own variant. Callers not taking part get 409.
+// @Tags         experiments
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ExperimentEventRequest  true  "Event"
+// @Success      202      {object}  types.DataResponse[dto.ExperimentEventResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      429      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /experiments/events [post]
+func (h *ExperimentHandler) RecordEvent(c *gin.Context) {
+	var req dto.ExperimentEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	subject, err := services.ExperimentSubject(c.GetUint("userID"), req.AnonymousID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	variant, err := h.experimentService.RecordEvent(req, subject)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, types.APIResponse{
+		Success: true,
+		Message: "Experiment event recorded",
+		Data:    dto.ExperimentEventResponse{Variant: variant},
+	})
+}
+
+// ListExperiments godoc
+// @Summary      List experiments
+// @Description  Get experiments, newest first, optionally only those with a status (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        status     query     string  false  "Experiment status" Enums(draft, running, stopped)
+// @Param        page       query     int     false  "Page number" default(1)
+// @Param        page_size  query     int     false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /admin/experiments [get]
+func (h *ExperimentHandler) ListExperiments(c *gin.Context) {
+	var req dto.ListExperimentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	pagination := utils.NormalizePagination("experiments", req.Page, req.PageSize)
+
+	experiments, total, err := h.experimentService.ListExperiments(models.ExperimentStatus(req.Status), pagination.Page, pagination.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.ExperimentResponse, len(experiments))
+	for i := range experiments {
+		items[i] = mappers.ToExperimentResponse(&experiments[i])
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(items, total, pagination))
+}
+
+// GetExperiment godoc
+// @Summary      Get an experiment
+// @Description  Get an experiment with its variants by ID (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Experiment ID"
+// @Success      200  {object}  types.DataResponse[dto.ExperimentResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/experiments/{id} [get]
+func (h *ExperimentHandler) GetExperiment(c *gin.Context) {
+	id, ok := parseExperimentID(c)
+	if !ok {
+		return
+	}
+
+	experiment, err := h.experimentService.GetExperiment(id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToExperimentResponse(experiment),
+	})
+}
+
+// CreateExperiment godoc
+// @Summary      Define an experiment
+// @Description  Define a draft experiment with its variants, whose weights add up to 100, and the percentage of subjects taking part. Keys are unique, deleted experiments included. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.ExperimentRequest  true  "Experiment"
+// @Success      201      {object}  types.DataResponse[dto.ExperimentResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/experiments [post]
+func (h *ExperimentHandler) CreateExperiment(c *gin.Context) {
+	var req dto.ExperimentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	experiment, err := h.experimentService.CreateExperiment(req, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Experiment created",
+		Data:    mappers.ToExperimentResponse(experiment),
+	})
+}
+
+// UpdateExperiment godoc
+// @Summary      Update an experiment
+// @Description  Replace the settings and variants of a draft experiment. Experiments that started can't change, since that would move subjects between variants. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                    true  "Experiment ID"
+// @Param        request  body      dto.ExperimentRequest  true  "Experiment"
+// @Success      200      {object}  types.DataResponse[dto.ExperimentResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/experiments/{id} [put]
+func (h *ExperimentHandler) UpdateExperiment(c *gin.Context) {
+	id, ok := parseExperimentID(c)
+	if !ok {
+		return
+	}
+	var req dto.ExperimentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	experiment, err := h.experimentService.UpdateExperiment(id, req, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Experiment updated",
+		Data:    mappers.ToExperimentResponse(experiment),
+	})
+}
+
+// StartExperiment godoc
+// @Summary      Start an experiment
+// @Description  Start assigning subjects to the variants of a draft experiment (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Experiment ID"
+// @Success      200  {object}  types.DataResponse[dto.ExperimentResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/experiments/{id}/start [post]
+func (h *ExperimentHandler) StartExperiment(c *gin.Context) {
+	id, ok := parseExperimentID(c)
+	if !ok {
+		return
+	}
+
+	experiment, err := h.experimentService.StartExperiment(id, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Experiment started",
+		Data:    mappers.ToExperimentResponse(experiment),
+	})
+}
+
+// StopExperiment godoc
+// @Summary      Stop an experiment
+// @Description  Stop a running experiment. Subjects get no variant anymore and events are no longer recorded; the results are kept. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Experiment ID"
+// @Success      200  {object}  types.DataResponse[dto.ExperimentResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/experiments/{id}/stop [post]
+func (h *ExperimentHandler) StopExperiment(c *gin.Context) {
+	id, ok := parseExperimentID(c)
+	if !ok {
+		return
+	}
+
+	experiment, err := h.experimentService.StopExperiment(id, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Experiment stopped",
+		Data:    mappers.ToExperimentResponse(experiment),
+	})
+}
+
+// DeleteExperiment godoc
+// @Summary      Delete an experiment
+// @Description  Delete an experiment, stopping it at once when it runs. Its key stays taken. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Experiment ID"
+// @Success      200  {object}  types.SuccessResponse
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/experiments/{id} [delete]
+func (h *ExperimentHandler) DeleteExperiment(c *gin.Context) {
+	id, ok := parseExperimentID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.experimentService.DeleteExperiment(id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Experiment deleted"})
+}
+
+// GetExperimentResults godoc
+// @Summary      Get experiment results
+// @Description  Compare the variants of an experiment: the subjects exposed to each, those who converted after their first exposure, the conversion rate and the total conversion value. Conversions without an exposure are not counted. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id    path      int     true   "Experiment ID"
+// @Param        goal  query     string  false  "Only count conversions reaching this goal"
+// @Success      200  {object}  types.DataResponse[dto.ExperimentResultsResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/experiments/{id}/results [get]
+func (h *ExperimentHandler) GetExperimentResults(c *gin.Context) {
+	id, ok := parseExperimentID(c)
+	if !ok {
+		return
+	}
+	var req dto.ExperimentResultsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid query parameters"})
+		return
+	}
+
+	results, err := h.experimentService.GetResults(id, req.Goal)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    results,
+	})
+}
+
+// respondError maps an experiment service error to its HTTP response
+func (h *ExperimentHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Experiment not found"})
+	case errors.Is(err, services.ErrExperimentNotRunning):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrExperimentWeights), errors.Is(err, services.ErrExperimentVariantKeys):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrExperimentKeyTaken), errors.Is(err, services.ErrExperimentNotDraft),
+		errors.Is(err, services.ErrExperimentStatus), errors.Is(err, services.ErrExperimentNotEnrolled):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// parseExperimentID reads the experiment ID path parameter, responding 400 when invalid
+func parseExperimentID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid experiment ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
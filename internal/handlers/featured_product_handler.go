@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// FeaturedProductHandler handles the curated featured product list
+type FeaturedProductHandler struct {
+	featuredService  *services.FeaturedProductService
+	priceListService *services.PriceListService
+}
+
+// NewFeaturedProductHandler creates a new featured product handler
+func NewFeaturedProductHandler(featuredService *services.FeaturedProductService, priceListService *services.PriceListService) *FeaturedProductHandler {
+	return &FeaturedProductHandler{featuredService: featuredService, priceListService: priceListService}
+}
+
+// GetFeaturedProducts godoc
+// @Summary      Get featured products
+// @Description  Get the products curated for the storefront homepage, in display order. Only entries whose schedule covers the current time and active products sold in the caller's country are returned. Customers with a price list also get their prices and quantity breaks.
+// @Tags         products
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[[]dto.ProductResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /products/featured [get]
+func (h *FeaturedProductHandler) GetFeaturedProducts(c *gin.Context) {
+	products, err := h.featuredService.LiveProducts(productRegion(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	pricing, ok := customerPricing(c, h.priceListService)
+	if !ok {
+		return
+	}
+	responses := mappers.ToProductResponses(products)
+	pricing.Apply(responses)
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// ListFeaturedEntries godoc
+// @Summary      List the featured list
+// @Description  List every entry of the curated featured list in display order, including scheduled and expired ones (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[[]dto.FeaturedProductResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/featured-products [get]
+func (h *FeaturedProductHandler) ListFeaturedEntries(c *gin.Context) {
+	entries, err := h.featuredService.ListFeatured()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.FeaturedProductResponse, len(entries))
+	for i := range entries {
+		items[i] = mappers.ToFeaturedProductResponse(&entries[i])
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    items,
+	})
+}
+
+// CreateFeaturedEntry godoc
+// @Summary      Feature a product
+// @Description  Add a product to the featured list at a position, optionally only between starts_at and ends_at (admin only). A product is featured at most once.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.FeaturedProductRequest  true  "Featured entry"
+// @Success      201      {object}  types.DataResponse[dto.FeaturedProductResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/featured-products [post]
+func (h *FeaturedProductHandler) CreateFeaturedEntry(c *gin.Context) {
+	var req dto.FeaturedProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	featured, err := h.featuredService.CreateFeatured(req, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Product featured",
+		Data:    mappers.ToFeaturedProductResponse(featured),
+	})
+}
+
+// UpdateFeaturedEntry godoc
+// @Summary      Update a featured entry
+// @Description  Replace the product, position and schedule of a featured list entry (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                         true  "Featured entry ID"
+// @Param        request  body      dto.FeaturedProductRequest  true  "Featured entry"
+// @Success      200      {object}  types.DataResponse[dto.FeaturedProductResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/featured-products/{id} [put]
+func (h *FeaturedProductHandler) UpdateFeaturedEntry(c *gin.Context) {
+	id, ok := parseFeaturedEntryID(c)
+	if !ok {
+		return
+	}
+	var req dto.FeaturedProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	featured, err := h.featuredService.UpdateFeatured(id, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Featured entry updated",
+		Data:    mappers.ToFeaturedProductResponse(featured),
+	})
+}
+
+// DeleteFeaturedEntry godoc
+// @Summary      Unfeature a product
+// @Description  Remove an entry from the featured list (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Featured entry ID"
+// @Success      200  {object}  types.SuccessResponse
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/featured-products/{id} [delete]
+func (h *FeaturedProductHandler) DeleteFeaturedEntry(c *gin.Context) {
+	id, ok := parseFeaturedEntryID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.featuredService.DeleteFeatured(id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Featured entry deleted"})
+}
+
+// respondError maps a featured product service error to its HTTP response
+func (h *FeaturedProductHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Featured entry not found"})
+	case errors.Is(err, services.ErrFeaturedProductMissing), errors.Is(err, services.ErrFeaturedSchedule):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrProductAlreadyFeatured):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// parseFeaturedEntryID reads the featured entry ID path parameter, responding
+// 400 when invalid
+func parseFeaturedEntryID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid featured entry ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
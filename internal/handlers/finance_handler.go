@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FinanceHandler handles the finance reports
+type FinanceHandler struct {
+	financeService *services.FinanceService
+	auditService   *services.AuditService
+}
+
+// NewFinanceHandler creates a new finance handler
+func NewFinanceHandler(financeService *services.FinanceService, auditService *services.AuditService) *FinanceHandler {
+	return &FinanceHandler{financeService: financeService, auditService: auditService}
+}
+
+// GetReconciliation godoc
+// @Summary      Payment reconciliation report
+// @Description  Reconcile the orders placed on every UTC day between two dates, inclusive, with the gift card and store credit ledgers that paid for them. Each day totals its orders, the gift card and store credit payments, the refunds of voided orders and what is left to settle outside the system. An order mismatches when a ledger's net for it differs from what its placement recorded, or from zero once its payment was voided. Payments of orders that were never placed are listed as unmatched. There is no payment provider yet, so what is due outside the system is reported, not checked. The CSV export has one row per order. Every run is recorded in the audit log. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Produce      text/csv
+// @Security     Bearer
+// @Param        from    query     string  false  "First UTC day (YYYY-MM-DD), defaults to to"
+// @Param        to      query     string  false  "Last UTC day (YYYY-MM-DD), defaults to yesterday; at most 31 days after from"
+// @Param        format  query     string  false  "Output format" Enums(json, csv) default(json)
+// @Success      200  {object}  types.DataResponse[dto.ReconciliationResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/finance/reconciliation [get]
+func (h *FinanceHandler) GetReconciliation(c *gin.Context) {
+	var req dto.ReconciliationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid query parameters"})
+		return
+	}
+
+	if err := h.auditService.Record(c.GetUint("userID"), requestLocation(c), models.AuditReportRun, map[string]interface{}{
+		"report": "finance_reconciliation",
+		"params": map[string]string{"from": req.From, "to": req.To},
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	report, err := h.financeService.Reconcile(req)
+	if err != nil {
+		if errors.Is(err, services.ErrReconciliationRange) {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "Failed to reconcile payments"})
+		return
+	}
+
+	if req.Format == "csv" {
+		writeReconciliationCSV(c, report)
+		return
+	}
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// writeReconciliationCSV writes the orders of a reconciliation report as a CSV
+// attachment, one row per order
+func writeReconciliationCSV(c *gin.Context, report *dto.ReconciliationResponse) {
+	filename := fmt.Sprintf("reconciliation-%s-%s.csv", report.From, report.To)
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	writer.Write([]string{"date", "reference", "order_number", "status", "total", "gift_card_applied", "gift_card_ledger",
+		"store_credit_applied", "store_credit_ledger", "external_due", "mismatch"})
+	for _, order := range report.Orders {
+		writer.Write([]string{
+			order.Date,
+			csvSafe(order.Reference),
+			csvSafe(order.OrderNumber),
+			order.Status,
+			fmt.Sprint(order.Total),
+			fmt.Sprint(order.GiftCardApplied),
+			fmt.Sprint(order.GiftCardLedger),
+			fmt.Sprint(order.StoreCreditApplied),
+			fmt.Sprint(order.StoreCreditLedger),
+			fmt.Sprint(order.ExternalDue),
+			csvSafe(order.Mismatch),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		c.Error(err)
+	}
+}
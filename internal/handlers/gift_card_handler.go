@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GiftCardHandler handles gift card and store credit requests
+type GiftCardHandler struct {
+	giftCardService *services.GiftCardService
+	auditService    *services.AuditService
+}
+
+// NewGiftCardHandler creates a new gift card handler
+func NewGiftCardHandler(giftCardService *services.GiftCardService, auditService *services.AuditService) *GiftCardHandler {
+	return &GiftCardHandler{giftCardService: giftCardService, auditService: auditService}
+}
+
+// IssueGiftCard godoc
+// @Summary      Issue a gift card
+// @Description  Create a gift card with a random code and the given balance (admin only). Every issue is recorded in the audit log.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.IssueGiftCardRequest  true  "Gift card"
+// @Success      201      {object}  types.DataResponse[dto.GiftCardResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/gift-cards [post]
+func (h *GiftCardHandler) IssueGiftCard(c *gin.Context) {
+	var req dto.IssueGiftCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil && !req.ExpiresAt.IsZero() {
+		if !req.ExpiresAt.After(time.Now()) {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "expires_at must be in the future"})
+			return
+		}
+		expiresAt = &req.ExpiresAt.Time
+	}
+
+	adminID := c.GetUint("userID")
+	giftCard, err := h.giftCardService.IssueGiftCard(req.Amount, expiresAt, adminID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := h.auditService.Record(adminID, requestLocation(c), models.AuditGiftCardIssue, map[string]interface{}{
+		"gift_card_id": giftCard.ID,
+		"amount":       giftCard.InitialBalance,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Gift card issued",
+		Data:    mappers.ToGiftCardResponse(giftCard),
+	})
+}
+
+// GetGiftCard godoc
+// @Summary      Check a gift card balance
+// @Description  Get the remaining balance and expiry of a gift card. Codes are matched case-insensitively, with or without dashes.
+// @Tags         gift-cards
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        code  path      string  true  "Gift card code"
+// @Success      200   {object}  types.DataResponse[dto.GiftCardResponse]
+// @Failure      401   {object}  types.ErrorResponse
+// @Failure      404   {object}  types.ErrorResponse
+// @Failure      500   {object}  types.ErrorResponse
+// @Router       /gift-cards/{code} [get]
+func (h *GiftCardHandler) GetGiftCard(c *gin.Context) {
+	giftCard, err := h.giftCardService.GetGiftCard(c.Param("code"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Gift card not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToGiftCardResponse(giftCard),
+	})
+}
+
+// RedeemGiftCard godoc
+// @Summary      Redeem a gift card
+// @Description  Apply a gift card to an amount due outside checkout, such as an in-store purchase (admin only). The card covers as much as its balance allows and the rest is reported as still due. Every redemption is recorded in the card's ledger and the audit log. Checkout applies cards itself when a quote is accepted.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        code     path      string                     true  "Gift card code"
+// @Param        request  body      dto.RedeemGiftCardRequest  true  "Amount due"
+// @Success      200      {object}  types.DataResponse[dto.GiftCardRedemptionResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/gift-cards/{code}/redeem [post]
+func (h *GiftCardHandler) RedeemGiftCard(c *gin.Context) {
+	var req dto.RedeemGiftCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	adminID := c.GetUint("userID")
+	code := services.NormalizeGiftCardCode(c.Param("code"))
+	entry, err := h.giftCardService.RedeemGiftCard(code, req.Amount, req.Reference, adminID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Gift card not found"})
+		return
+	case errors.Is(err, repositories.ErrGiftCardExpired), errors.Is(err, repositories.ErrGiftCardEmpty):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	applied := -entry.Delta
+	if err := h.auditService.Record(adminID, requestLocation(c), models.AuditGiftCardRedeem, map[string]interface{}{
+		"gift_card_id": entry.GiftCardID,
+		"amount":       applied,
+		"reference":    req.Reference,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Gift card redeemed",
+		Data: dto.GiftCardRedemptionResponse{
+			Code:         code,
+			Reference:    req.Reference,
+			Applied:      applied,
+			RemainingDue: math.Round((req.Amount-applied)*100) / 100,
+			Balance:      entry.ResultingBalance,
+		},
+	})
+}
+
+// GetStoreCreditBalance godoc
+// @Summary      Get store credit balance
+// @Description  Get the store credit balance of the current user, applied automatically at payment time
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[dto.StoreCreditBalanceResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /auth/store-credit [get]
+func (h *GiftCardHandler) GetStoreCreditBalance(c *gin.Context) {
+	balance, err := h.giftCardService.GetStoreCreditBalance(c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    dto.StoreCreditBalanceResponse{Balance: balance},
+	})
+}
+
+// ListStoreCredit godoc
+// @Summary      List store credit entries
+// @Description  Get the store credit ledger of the current user, newest first
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        page       query     int  false  "Page number" default(1)
+// @Param        page_size  query     int  false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /auth/store-credit/entries [get]
+func (h *GiftCardHandler) ListStoreCredit(c *gin.Context) {
+	var req dto.ListStoreCreditRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	pagination := utils.NormalizePagination("store_credit", req.Page, req.PageSize)
+
+	entries, total, err := h.giftCardService.ListStoreCredit(c.GetUint("userID"), pagination.Page, pagination.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.StoreCreditEntryResponse, len(entries))
+	for i := range entries {
+		items[i] = mappers.ToStoreCreditEntryResponse(&entries[i])
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(items, total, pagination))
+}
+
+// GrantStoreCredit godoc
+// @Summary      Grant store credit
+// @Description  Add store credit to a user's balance (admin only). Every grant is recorded in the audit log.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                          true  "User ID"
+// @Param        request  body      dto.GrantStoreCreditRequest  true  "Credit"
+// @Success      201      {object}  types.DataResponse[dto.StoreCreditEntryResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/users/{id}/store-credit [post]
+func (h *GiftCardHandler) GrantStoreCredit(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "invalid user ID"})
+		return
+	}
+	var req dto.GrantStoreCreditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	adminID := c.GetUint("userID")
+	entry, err := h.giftCardService.GrantStoreCredit(uint(userID), req.Amount, req.Note, adminID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "user not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := h.auditService.Record(adminID, requestLocation(c), models.AuditStoreCredit, map[string]interface{}{
+		"user_id": userID,
+		"amount":  entry.Delta,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Store credit granted",
+		Data:    mappers.ToStoreCreditEntryResponse(entry),
+	})
+}
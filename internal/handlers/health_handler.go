@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"product-management/internal/types"
+	"product-management/pkg/database"
+	"product-management/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler exposes liveness, readiness, database pool and request metrics
+type HealthHandler struct {
+	concurrency *ratelimit.Concurrency
+}
+
+// NewHealthHandler creates a new health handler
+func NewHealthHandler(concurrency *ratelimit.Concurrency) *HealthHandler {
+	return &HealthHandler{concurrency: concurrency}
+}
+
+// PoolStatsResponse represents the database connection pool statistics
+type PoolStatsResponse struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDurationMs     int64 `json:"wait_duration_ms"`
+	MaxIdleClosed      int64 `json:"max_idle_closed"`
+	MaxLifetimeClosed  int64 `json:"max_lifetime_closed"`
+}
+
+// ReadinessResponse represents the readiness status of the service
+type ReadinessResponse struct {
+	Status   string                `json:"status"`
+	Database database.HealthStatus `json:"database"`
+	Pool     *PoolStatsResponse    `json:"pool,omitempty"`
+}
+
+// Liveness reports that the process is up
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readiness reports whether the service can serve traffic, based on the database health monitor
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	health := database.Health()
+	response := ReadinessResponse{Status: "ready", Database: health}
+
+	if stats, err := database.PoolStats(); err == nil {
+		response.Pool = &PoolStatsResponse{
+			MaxOpenConnections: stats.MaxOpenConnections,
+			OpenConnections:    stats.OpenConnections,
+			InUse:              stats.InUse,
+			Idle:               stats.Idle,
+			WaitCount:          stats.WaitCount,
+			WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+			MaxIdleClosed:      stats.MaxIdleClosed,
+			MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+		}
+	}
+
+	if !health.Healthy || response.Pool == nil {
+		response.Status = "unavailable"
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Metrics exposes the database pool statistics in the Prometheus text format
+func (h *HealthHandler) Metrics(c *gin.Context) {
+	stats, err := database.PoolStats()
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	health := database.Health()
+
+	up := 0
+	if health.Healthy {
+		up = 1
+	}
+
+	var b strings.Builder
+	writeMetric(&b, "db_up", "gauge", "Whether the last database health check succeeded", up)
+	writeMetric(&b, "db_reconnects_total", "counter", "Number of times the database connection recovered", health.Reconnects)
+	writeMetric(&b, "db_pool_max_open_connections", "gauge", "Maximum number of open connections", stats.MaxOpenConnections)
+	writeMetric(&b, "db_pool_open_connections", "gauge", "Number of established connections", stats.OpenConnections)
+	writeMetric(&b, "db_pool_in_use_connections", "gauge", "Number of connections currently in use", stats.InUse)
+	writeMetric(&b, "db_pool_idle_connections", "gauge", "Number of idle connections", stats.Idle)
+	writeMetric(&b, "db_pool_wait_count_total", "counter", "Total number of connections waited for", stats.WaitCount)
+	writeMetric(&b, "db_pool_wait_duration_seconds_total", "counter", "Total time blocked waiting for a connection", stats.WaitDuration.Seconds())
+	writeMetric(&b, "db_pool_max_idle_closed_total", "counter", "Connections closed due to SetMaxIdleConns", stats.MaxIdleClosed)
+	writeMetric(&b, "db_pool_max_lifetime_closed_total", "counter", "Connections closed due to SetConnMaxLifetime", stats.MaxLifetimeClosed)
+
+	requests := h.concurrency.Stats()
+	writeMetric(&b, "http_requests_in_flight", "gauge", "Number of API requests being processed", requests.InFlight)
+	writeMetric(&b, "http_requests_queued", "gauge", "Number of API requests waiting for a slot", requests.Queued)
+	writeMetric(&b, "http_requests_shed_total", "counter", "Number of API requests rejected under load", requests.Shed)
+
+	c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(b.String()))
+}
+
+// writeMetric appends a single metric with its HELP and TYPE lines
+func writeMetric(b *strings.Builder, name, kind, help string, value interface{}) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, kind, name, value)
+}
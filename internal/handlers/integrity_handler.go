@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IntegrityHandler handles the data integrity checks
+type IntegrityHandler struct {
+	integrityService *services.IntegrityService
+	auditService     *services.AuditService
+}
+
+// NewIntegrityHandler creates a new integrity handler
+func NewIntegrityHandler(integrityService *services.IntegrityService, auditService *services.AuditService) *IntegrityHandler {
+	return &IntegrityHandler{integrityService: integrityService, auditService: auditService}
+}
+
+// CheckIntegrity godoc
+// @Summary      Check data integrity
+// @Description  Run every integrity check without changing anything: category links without a product or live category, reviews of deleted products, negative stock and wishlist entries of users that no longer exist. Each check comes with how its repair works, the number of broken records and the first 20 of them. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[dto.IntegrityReportResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/integrity [get]
+func (h *IntegrityHandler) CheckIntegrity(c *gin.Context) {
+	report, err := h.integrityService.Check()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// RepairIntegrity godoc
+// @Summary      Repair data integrity
+// @Description  Repair the records broken according to the given checks, or to every check when the body or checks is empty, by the rule each check reports. Recorded in the audit log. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.RepairIntegrityRequest  false  "Checks to repair"
+// @Success      200      {object}  types.DataResponse[dto.RepairIntegrityResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/integrity/repair [post]
+func (h *IntegrityHandler) RepairIntegrity(c *gin.Context) {
+	var req dto.RepairIntegrityRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	if err := h.auditService.Record(c.GetUint("userID"), requestLocation(c), models.AuditIntegrityRepair, map[string]interface{}{
+		"checks": req.Checks,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	result, err := h.integrityService.Repair(req.Checks, c.GetUint("userID"))
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownIntegrityCheck) {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Integrity issues repaired",
+		Data:    result,
+	})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"product-management/internal/dto"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LabelHandler handles printable spec sheet and shelf label requests
+type LabelHandler struct {
+	labelService *services.LabelService
+}
+
+// NewLabelHandler creates a new label handler
+func NewLabelHandler(labelService *services.LabelService) *LabelHandler {
+	return &LabelHandler{labelService: labelService}
+}
+
+// PrintProductLabels godoc
+// @Summary      Print product labels
+// @Description  Render products as a print-ready A4 PDF: a spec sheet page per product with its name, price, barcode, description and key attributes, or 70 x 37 mm shelf labels with name, price and barcode, 24 per sheet. Products without an SKU are printed without a barcode. Prices are converted to the request currency and formatted for its language, and the print date is in its time zone.
+// @Tags         products
+// @Accept       json
+// @Produce      application/pdf
+// @Security     Bearer
+// @Param        request  body      dto.ProductLabelsRequest  true  "Products and template"
+// @Success      200      {file}    binary
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /products/labels [post]
+func (h *LabelHandler) PrintProductLabels(c *gin.Context) {
+	var req dto.ProductLabelsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if req.Barcode == "" {
+		req.Barcode = services.BarcodeCode128
+	}
+
+	document, err := h.labelService.RenderLabels(requestContext(c), req.ProductIDs, req.Template, req.Barcode)
+	if err != nil {
+		if errors.Is(err, services.ErrLabelProduct) {
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", req.Template+".pdf"))
+	c.Data(http.StatusOK, "application/pdf", document)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/reqctx"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// MarketPriceHandler handles competitor price lookups
+type MarketPriceHandler struct {
+	marketPriceService *services.MarketPriceService
+}
+
+// NewMarketPriceHandler creates a new market price handler
+func NewMarketPriceHandler(marketPriceService *services.MarketPriceService) *MarketPriceHandler {
+	return &MarketPriceHandler{marketPriceService: marketPriceService}
+}
+
+// GetMarketPrices godoc
+// @Summary      Get competitor prices of a product
+// @Description  Look up the prices competitors sell a product at, by its SKU, with every configured price comparison provider, cheapest first. Prices are cached per provider for MARKET_PRICE_CACHE_TTL; refresh=true asks the providers again. Calls stay within each provider's rate limit, and a provider that fails, is rate limited or is suspended after repeated failures is listed in errors while the others are still returned. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int   true   "Product ID"
+// @Param        refresh  query     bool  false  "Bypass the cache"
+// @Success      200      {object}  types.DataResponse[dto.MarketPricesResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/products/{id}/market-prices [get]
+func (h *MarketPriceHandler) GetMarketPrices(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid product ID"})
+		return
+	}
+	var req dto.MarketPricesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	prices, err := h.marketPriceService.MarketPrices(uint(id), req.Refresh)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Product not found"})
+		return
+	case errors.Is(err, services.ErrProductWithoutSKU), errors.Is(err, services.ErrSKUNotTracked):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    marketPricesResponse(prices),
+	})
+}
+
+func marketPricesResponse(prices *services.MarketPrices) dto.MarketPricesResponse {
+	currency := reqctx.Default.Defaults().Currency
+	response := dto.MarketPricesResponse{
+		ProductID: prices.Product.ID,
+		SKU:       prices.Product.SKUValue(),
+		Price:     prices.Product.Price,
+		Currency:  currency,
+		Quotes:    make([]dto.MarketPriceQuote, len(prices.Quotes)),
+		Errors:    prices.Errors,
+	}
+	for i, quote := range prices.Quotes {
+		response.Quotes[i] = dto.MarketPriceQuote{
+			Provider:   quote.Provider,
+			Competitor: quote.Competitor,
+			Price:      quote.Price,
+			Currency:   quote.Currency,
+			URL:        quote.URL,
+			InStock:    quote.InStock,
+			ObservedAt: dto.NewTime(quote.ObservedAt),
+			FetchedAt:  dto.NewTime(quote.FetchedAt),
+		}
+		if quote.Currency == currency && prices.Product.Price > 0 {
+			difference := math.Round((quote.Price-prices.Product.Price)/prices.Product.Price*1000) / 10
+			response.Quotes[i].Difference = &difference
+			if response.Lowest == nil {
+				response.Lowest = &response.Quotes[i]
+			}
+		}
+	}
+	return response
+}
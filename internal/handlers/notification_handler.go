@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler lets users manage their notification preferences
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+// GetSettings godoc
+// @Summary      Get notification settings
+// @Description  Get the channels security alerts and order updates are delivered on for the current user
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[dto.NotificationSettingsResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /auth/notification-settings [get]
+func (h *NotificationHandler) GetSettings(c *gin.Context) {
+	settings, err := h.notificationService.GetSettings(c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToNotificationSettingsResponse(settings),
+	})
+}
+
+// UpdateSettings godoc
+// @Summary      Update notification settings
+// @Description  Choose the channels (email, SMS, push) of each kind of notification and set the phone number and push token they are sent to. Omitted fields are unchanged.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.UpdateNotificationSettingsRequest  true  "Notification settings"
+// @Success      200      {object}  types.DataResponse[dto.NotificationSettingsResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /auth/notification-settings [put]
+func (h *NotificationHandler) UpdateSettings(c *gin.Context) {
+	var req dto.UpdateNotificationSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	settings, err := h.notificationService.UpdateSettings(c.GetUint("userID"), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Notification settings updated",
+		Data:    mappers.ToNotificationSettingsResponse(settings),
+	})
+}
+
+// Unsubscribe godoc
+// @Summary      Unsubscribe from digest emails
+// @Description  Turn off the weekly wishlist digest or inventory report. The token comes from the unsubscribe link in the email, so no login is needed.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        token  query     string  true  "Unsubscribe token from the email link"
+// @Success      200    {object}  types.SuccessResponse
+// @Failure      400    {object}  types.ErrorResponse
+// @Failure      500    {object}  types.ErrorResponse
+// @Router       /auth/unsubscribe [get]
+func (h *NotificationHandler) Unsubscribe(c *gin.Context) {
+	if err := h.notificationService.UnsubscribeDigest(c.Query("token")); err != nil {
+		if errors.Is(err, services.ErrInvalidUnsubscribeToken) {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "You will no longer receive digest emails"})
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrderHandler handles the tracking, shipments and printable documents of
+// orders placed from quotes
+type OrderHandler struct {
+	orderService *services.OrderService
+}
+
+// NewOrderHandler creates a new order handler
+func NewOrderHandler(orderService *services.OrderService) *OrderHandler {
+	return &OrderHandler{orderService: orderService}
+}
+
+// TrackOrder godoc
+// @Summary      Track an order
+// @Description  Get the status, items and shipments of an order. The token comes from the tracking link in the order emails, so no login is needed and the link can be shared with a gift recipient. Prices and the customer's details are never shown. An invalid token reads as an unknown order.
+// @Tags         orders
+// @Produce      json
+// @Param        token  path      string  true  "Tracking token from the order email link"
+// @Success      200    {object}  types.DataResponse[dto.OrderTrackingResponse]
+// @Failure      404    {object}  types.ErrorResponse
+// @Failure      429    {object}  types.ErrorResponse
+// @Failure      500    {object}  types.ErrorResponse
+// @Router       /orders/track/{token} [get]
+func (h *OrderHandler) TrackOrder(c *gin.Context) {
+	order, err := h.orderService.Track(c.Param("token"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    order,
+	})
+}
+
+// AddShipment godoc
+// @Summary      Record a shipment
+// @Description  Record a parcel of the order placed from an accepted quote as handed to a carrier, and email the customer its tracking number with the order's tracking link. An order may ship in several parcels: list the products and quantities in this one, or omit the items to ship everything not shipped yet. Orders still being placed or cancelled can't ship. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                        true  "Quote ID"
+// @Param        request  body      dto.CreateShipmentRequest  true  "Carrier, tracking number and items"
+// @Success      201      {object}  types.DataResponse[dto.ShipmentResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/quotes/{id}/shipments [post]
+func (h *OrderHandler) AddShipment(c *gin.Context) {
+	id, ok := parseQuoteID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateShipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	shipment, err := h.orderService.AddShipment(id, c.GetUint("userID"), req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Shipment recorded",
+		Data:    mappers.ToShipmentResponse(shipment),
+	})
+}
+
+// GetMyInvoice godoc
+// @Summary      Download an invoice
+// @Description  Render the invoice of the order placed from one of the current user's quotes as a PDF: the items with their quoted prices and gift wrapping, and the totals. Items whose price the customer chose to hide are listed without it, and the totals are then left off too. Prices are converted to the request currency and formatted for its language, and dates are in its time zone.
+// @Tags         quotes
+// @Produce      application/pdf
+// @Security     Bearer
+// @Param        id   path      int  true  "Quote ID"
+// @Success      200  {file}    file  "PDF invoice"
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /quotes/{id}/invoice [get]
+func (h *OrderHandler) GetMyInvoice(c *gin.Context) {
+	h.renderInvoice(c, c.GetUint("userID"))
+}
+
+// GetInvoice godoc
+// @Summary      Download an invoice
+// @Description  Render the invoice of the order placed from any customer's quote as a PDF, as the customer gets it (admin only)
+// @Tags         admin
+// @Produce      application/pdf
+// @Security     Bearer
+// @Param        id   path      int  true  "Quote ID"
+// @Success      200  {file}    file  "PDF invoice"
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/quotes/{id}/invoice [get]
+func (h *OrderHandler) GetInvoice(c *gin.Context) {
+	h.renderInvoice(c, 0)
+}
+
+// GetPackingSlip godoc
+// @Summary      Download a packing slip
+// @Description  Render the packing slip of the order placed from a quote as a PDF: the products and quantities to pack with their SKU, which to gift wrap and the gift messages to enclose. It shows no prices. Admin only.
+// @Tags         admin
+// @Produce      application/pdf
+// @Security     Bearer
+// @Param        id   path      int  true  "Quote ID"
+// @Success      200  {file}    file  "PDF packing slip"
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/quotes/{id}/packing-slip [get]
+func (h *OrderHandler) GetPackingSlip(c *gin.Context) {
+	id, ok := parseQuoteID(c)
+	if !ok {
+		return
+	}
+
+	document, err := h.orderService.RenderPackingSlip(requestContext(c), id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="packing-slip-%d.pdf"`, id))
+	c.Data(http.StatusOK, "application/pdf", document)
+}
+
+// GetShipmentPackingSlip godoc
+// @Summary      Download the packing slip of a shipment
+// @Description  Render the packing slip of one parcel of an order as a PDF: only the products and quantities it holds, with the carrier and tracking number, which to gift wrap and the gift messages to enclose. It shows no prices. Admin only.
+// @Tags         admin
+// @Produce      application/pdf
+// @Security     Bearer
+// @Param        id   path      int  true  "Shipment ID"
+// @Success      200  {file}    file  "PDF packing slip"
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/shipments/{id}/packing-slip [get]
+func (h *OrderHandler) GetShipmentPackingSlip(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid shipment ID"})
+		return
+	}
+
+	document, err := h.orderService.RenderShipmentPackingSlip(requestContext(c), uint(id))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="packing-slip-shipment-%d.pdf"`, id))
+	c.Data(http.StatusOK, "application/pdf", document)
+}
+
+// CreatePickList godoc
+// @Summary      Generate a pick list
+// @Description  List the products to pick for a batch of open orders, grouped by the warehouse location of each product in walking order, with products without a location last. Each line has the units not shipped yet across the orders and how they split between them. Open orders are placed orders with units left to ship; without quote IDs the oldest 100 are taken. Responds with JSON, or a printable PDF when the format is pdf. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json,application/pdf
+// @Security     Bearer
+// @Param        request  body      dto.PickListRequest  true  "Orders to pick and format"
+// @Success      200      {object}  types.DataResponse[dto.PickListResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/pick-lists [post]
+func (h *OrderHandler) CreatePickList(c *gin.Context) {
+	var req dto.PickListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	pickList, err := h.orderService.PickList(req.QuoteIDs)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	if req.Format == "pdf" {
+		rc := requestContext(c)
+		document, err := h.orderService.RenderPickList(rc, pickList)
+		if err != nil {
+			h.respondError(c, err)
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="pick-list-%s.pdf"`, pickList.GeneratedAt.Time.In(rc.Location).Format("20060102-150405")))
+		c.Data(http.StatusOK, "application/pdf", document)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    pickList,
+	})
+}
+
+// renderInvoice responds with the invoice of an order. A non-zero userID
+// limits it to that user's orders.
+func (h *OrderHandler) renderInvoice(c *gin.Context, userID uint) {
+	id, ok := parseQuoteID(c)
+	if !ok {
+		return
+	}
+
+	document, err := h.orderService.RenderInvoice(requestContext(c), id, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="invoice-%d.pdf"`, id))
+	c.Data(http.StatusOK, "application/pdf", document)
+}
+
+// respondError maps an order service error to its HTTP response
+func (h *OrderHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidTrackingToken), errors.Is(err, services.ErrOrderNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Order not found"})
+	case errors.Is(err, services.ErrShipmentNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Shipment not found"})
+	case errors.Is(err, services.ErrShipmentItem):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrOrderCancelled), errors.Is(err, services.ErrOrderProcessing),
+		errors.Is(err, services.ErrOrderShipped), errors.Is(err, services.ErrOrderNotOpen):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
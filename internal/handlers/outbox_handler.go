@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OutboxHandler handles the events waiting in or published from the outbox
+type OutboxHandler struct {
+	outboxService *services.OutboxService
+	auditService  *services.AuditService
+}
+
+// NewOutboxHandler creates a new outbox handler
+func NewOutboxHandler(outboxService *services.OutboxService, auditService *services.AuditService) *OutboxHandler {
+	return &OutboxHandler{outboxService: outboxService, auditService: auditService}
+}
+
+// ListEvents godoc
+// @Summary      List outbox events
+// @Description  Get the events recorded in the outbox with the changes they report, newest first, with their delivery status, attempts and last error. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        status     query     string  false  "Delivery status" Enums(pending, published, failed)
+// @Param        topic      query     string  false  "Event topic, e.g. product.changed"
+// @Param        page       query     int     false  "Page number" default(1)
+// @Param        page_size  query     int     false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /admin/outbox [get]
+func (h *OutboxHandler) ListEvents(c *gin.Context) {
+	var req dto.ListOutboxEventsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	pagination := utils.NormalizePagination("outbox_events", req.Page, req.PageSize)
+
+	outboxEvents, total, err := h.outboxService.ListEvents(models.OutboxStatus(req.Status), req.Topic, pagination.Page, pagination.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.OutboxEventResponse, len(outboxEvents))
+	for i := range outboxEvents {
+		items[i] = mappers.ToOutboxEventResponse(&outboxEvents[i])
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(items, total, pagination))
+}
+
+// ReprocessEvents godoc
+// @Summary      Reprocess outbox events
+// @Description  Queue events for the relay again with a fresh set of attempts: the given ones whatever their status, or every failed one when ids is empty. Published events are delivered again, so consumers see them twice. Recorded in the audit log. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.ReprocessOutboxRequest  true  "Events to reprocess"
+// @Success      200      {object}  types.DataResponse[dto.ReprocessOutboxResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/outbox/reprocess [post]
+func (h *OutboxHandler) ReprocessEvents(c *gin.Context) {
+	var req dto.ReprocessOutboxRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.auditService.Record(c.GetUint("userID"), requestLocation(c), models.AuditOutboxReprocess, map[string]interface{}{
+		"ids": req.IDs,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	queued, err := h.outboxService.Reprocess(req.IDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Events queued for publishing",
+		Data:    dto.ReprocessOutboxResponse{Queued: queued},
+	})
+}
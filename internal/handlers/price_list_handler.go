@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PriceListHandler handles B2B price list requests
+type PriceListHandler struct {
+	priceListService *services.PriceListService
+}
+
+// NewPriceListHandler creates a new price list handler
+func NewPriceListHandler(priceListService *services.PriceListService) *PriceListHandler {
+	return &PriceListHandler{priceListService: priceListService}
+}
+
+// CreatePriceList godoc
+// @Summary      Create a price list
+// @Description  Create an empty B2B price list (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.PriceListRequest  true  "Price list"
+// @Success      201      {object}  types.DataResponse[dto.PriceListResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/price-lists [post]
+func (h *PriceListHandler) CreatePriceList(c *gin.Context) {
+	var req dto.PriceListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	priceList, err := h.priceListService.CreatePriceList(req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Price list created",
+		Data:    mappers.ToPriceListResponse(priceList),
+	})
+}
+
+// ListPriceLists godoc
+// @Summary      List price lists
+// @Description  List every B2B price list without its items (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[[]dto.PriceListResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/price-lists [get]
+func (h *PriceListHandler) ListPriceLists(c *gin.Context) {
+	priceLists, err := h.priceListService.ListPriceLists()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.PriceListResponse, len(priceLists))
+	for i := range priceLists {
+		items[i] = mappers.ToPriceListResponse(&priceLists[i])
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    items,
+	})
+}
+
+// GetPriceList godoc
+// @Summary      Get a price list
+// @Description  Get a B2B price list with the quantity breaks of every product in it (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Price list ID"
+// @Success      200  {object}  types.DataResponse[dto.PriceListResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/price-lists/{id} [get]
+func (h *PriceListHandler) GetPriceList(c *gin.Context) {
+	id, ok := parsePriceListID(c)
+	if !ok {
+		return
+	}
+
+	priceList, err := h.priceListService.GetPriceList(id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToPriceListResponse(priceList),
+	})
+}
+
+// UpdatePriceList godoc
+// @Summary      Update a price list
+// @Description  Rename or redescribe a B2B price list (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                   true  "Price list ID"
+// @Param        request  body      dto.PriceListRequest  true  "Price list"
+// @Success      200      {object}  types.DataResponse[dto.PriceListResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/price-lists/{id} [put]
+func (h *PriceListHandler) UpdatePriceList(c *gin.Context) {
+	id, ok := parsePriceListID(c)
+	if !ok {
+		return
+	}
+	var req dto.PriceListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	priceList, err := h.priceListService.UpdatePriceList(id, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Price list updated",
+		Data:    mappers.ToPriceListResponse(priceList),
+	})
+}
+
+// DeletePriceList godoc
+// @Summary      Delete a price list
+// @Description  Delete a B2B price list; the customers assigned to it pay list prices again (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Price list ID"
+// @Success      200  {object}  types.SuccessResponse
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/price-lists/{id} [delete]
+func (h *PriceListHandler) DeletePriceList(c *gin.Context) {
+	id, ok := parsePriceListID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.priceListService.DeletePriceList(id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Price list deleted"})
+}
+
+// SetProductPrices godoc
+// @Summary      Set product prices
+// @Description  Replace the quantity breaks of a product in a B2B price list. Each break is a unit price from a minimum quantity on; quantities below the lowest break pay the list price. An empty list removes the product (admin only).
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id         path      int                          true  "Price list ID"
+// @Param        productId  path      int                          true  "Product ID"
+// @Param        request    body      dto.SetProductPricesRequest  true  "Quantity breaks"
+// @Success      200        {object}  types.SuccessResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Failure      404        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /admin/price-lists/{id}/products/{productId} [put]
+func (h *PriceListHandler) SetProductPrices(c *gin.Context) {
+	id, ok := parsePriceListID(c)
+	if !ok {
+		return
+	}
+	productID, err := strconv.ParseUint(c.Param("productId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid product ID"})
+		return
+	}
+	var req dto.SetProductPricesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.priceListService.SetProductPrices(id, uint(productID), req.Breaks); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Product prices updated"})
+}
+
+// AssignPriceList godoc
+// @Summary      Assign a price list to a user
+// @Description  Make a customer pay the prices of a B2B price list, or list prices again with a null price_list_id (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                         true  "User ID"
+// @Param        request  body      dto.AssignPriceListRequest  true  "Price list"
+// @Success      200      {object}  types.SuccessResponse
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/users/{id}/price-list [put]
+func (h *PriceListHandler) AssignPriceList(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid user ID"})
+		return
+	}
+	var req dto.AssignPriceListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.priceListService.AssignPriceList(uint(userID), req.PriceListID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "User or price list not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Price list assigned"})
+}
+
+// respondError maps a price list service error to its HTTP response
+func (h *PriceListHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Price list or product not found"})
+	case errors.Is(err, services.ErrDuplicatePriceBreak):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrPriceListNameTaken):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// parsePriceListID reads the price list ID path parameter, responding 400 when invalid
+func parsePriceListID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid price list ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// customerPricing loads the prices the current user pays, responding 500 on
+// failure. Responses priced for a customer are marked private, so shared caches
+// never serve them to someone else.
+func customerPricing(c *gin.Context, priceListService *services.PriceListService) (*services.CustomerPricing, bool) {
+	pricing, err := priceListService.CustomerPricing(c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return nil, false
+	}
+	if pricing.Personal() {
+		c.Header("Cache-Control", "private")
+	}
+	return pricing, true
+}
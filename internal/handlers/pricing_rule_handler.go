@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PricingRuleHandler handles pricing rule requests
+type PricingRuleHandler struct {
+	pricingRuleService *services.PricingRuleService
+	auditService       *services.AuditService
+}
+
+// NewPricingRuleHandler creates a new pricing rule handler
+func NewPricingRuleHandler(pricingRuleService *services.PricingRuleService, auditService *services.AuditService) *PricingRuleHandler {
+	return &PricingRuleHandler{pricingRuleService: pricingRuleService, auditService: auditService}
+}
+
+// ListPricingRules godoc
+// @Summary      List pricing rules
+// @Description  List every pricing rule in the order they are tried: highest priority first, then oldest first. Disabled, scheduled and ended rules are included. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[[]dto.PricingRuleResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/pricing-rules [get]
+func (h *PricingRuleHandler) ListPricingRules(c *gin.Context) {
+	rules, err := h.pricingRuleService.ListRules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.PricingRuleResponse, len(rules))
+	for i := range rules {
+		items[i] = mappers.ToPricingRuleResponse(&rules[i])
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    items,
+	})
+}
+
+// GetPricingRule godoc
+// @Summary      Get a pricing rule
+// @Description  Get a pricing rule by ID (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Pricing rule ID"
+// @Success      200  {object}  types.DataResponse[dto.PricingRuleResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/pricing-rules/{id} [get]
+func (h *PricingRuleHandler) GetPricingRule(c *gin.Context) {
+	id, ok := parsePricingRuleID(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.pricingRuleService.GetRule(id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToPricingRuleResponse(rule),
+	})
+}
+
+// CreatePricingRule godoc
+// @Summary      Create a pricing rule
+// @Description  Add a rule lowering the price of the products matching its conditions, such as 10% off a category for a weekend, or a clearance price for products with more than min_stock units and no order in no_sales_days days. Rules apply to prices served to customers and to quotes when they are requested. When several rules match a product the one with the highest priority that lowers its price applies; a customer's price list wins when it is lower still. Recorded in the audit log. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.PricingRuleRequest  true  "Pricing rule"
+// @Success      201      {object}  types.DataResponse[dto.PricingRuleResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/pricing-rules [post]
+func (h *PricingRuleHandler) CreatePricingRule(c *gin.Context) {
+	var req dto.PricingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	rule, err := h.pricingRuleService.CreateRule(req, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	if !h.record(c, models.AuditPricingRuleSave, rule) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Pricing rule created",
+		Data:    mappers.ToPricingRuleResponse(rule),
+	})
+}
+
+// UpdatePricingRule godoc
+// @Summary      Update a pricing rule
+// @Description  Replace the conditions, adjustment, priority and schedule of a pricing rule. Recorded in the audit log. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                     true  "Pricing rule ID"
+// @Param        request  body      dto.PricingRuleRequest  true  "Pricing rule"
+// @Success      200      {object}  types.DataResponse[dto.PricingRuleResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/pricing-rules/{id} [put]
+func (h *PricingRuleHandler) UpdatePricingRule(c *gin.Context) {
+	id, ok := parsePricingRuleID(c)
+	if !ok {
+		return
+	}
+	var req dto.PricingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	rule, err := h.pricingRuleService.UpdateRule(id, req, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	if !h.record(c, models.AuditPricingRuleSave, rule) {
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Pricing rule updated",
+		Data:    mappers.ToPricingRuleResponse(rule),
+	})
+}
+
+// DeletePricingRule godoc
+// @Summary      Delete a pricing rule
+// @Description  Delete a pricing rule, so prices no longer follow it. Quote items it priced keep naming it. Recorded in the audit log. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Pricing rule ID"
+// @Success      200  {object}  types.SuccessResponse
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/pricing-rules/{id} [delete]
+func (h *PricingRuleHandler) DeletePricingRule(c *gin.Context) {
+	id, ok := parsePricingRuleID(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.pricingRuleService.GetRule(id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	if err := h.pricingRuleService.DeleteRule(id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+	if !h.record(c, models.AuditPricingRuleDrop, rule) {
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Pricing rule deleted"})
+}
+
+// SimulatePricing godoc
+// @Summary      Preview pricing rules
+// @Description  Price products at a time with the enabled rules, and again with a draft rule added, or replacing rule_id to preview an edit, without saving anything. rule_id without a rule previews deleting that rule. Without product_ids, up to 200 active products of the draft rule's category, or of the store, are priced. Price lists are left out. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.SimulatePricingRequest  true  "Draft rule and products to price"
+// @Success      200      {object}  types.DataResponse[dto.PricingSimulationResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/pricing-rules/simulate [post]
+func (h *PricingRuleHandler) SimulatePricing(c *gin.Context) {
+	var req dto.SimulatePricingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	at := time.Now()
+	if req.At != nil {
+		at = req.At.Time
+	}
+
+	items, err := h.pricingRuleService.Simulate(req, at)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	response := dto.PricingSimulationResponse{At: dto.NewTime(at), Products: items}
+	for _, item := range items {
+		if item.SimulatedPrice != item.CurrentPrice {
+			response.Affected++
+		}
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ListPricingRuleApplications godoc
+// @Summary      List the quote items a pricing rule priced
+// @Description  Get the quote items a rule set the price of when their quote was requested, newest first, to audit what a rule was applied to. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id         path      int  true   "Pricing rule ID"
+// @Param        page       query     int  false  "Page number" default(1)
+// @Param        page_size  query     int  false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Failure      404        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /admin/pricing-rules/{id}/applications [get]
+func (h *PricingRuleHandler) ListPricingRuleApplications(c *gin.Context) {
+	id, ok := parsePricingRuleID(c)
+	if !ok {
+		return
+	}
+	var req dto.ListPricingRuleApplicationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	pagination := utils.NormalizePagination("pricing_rule_applications", req.Page, req.PageSize)
+
+	applications, total, err := h.pricingRuleService.ListApplications(id, pagination.Page, pagination.Limit)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	items := make([]dto.PricingRuleApplicationResponse, len(applications))
+	for i := range applications {
+		items[i] = mappers.ToPricingRuleApplicationResponse(&applications[i])
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(items, total, pagination))
+}
+
+// record adds a change to a pricing rule to the audit log, responding 500 when
+// it cannot be recorded
+func (h *PricingRuleHandler) record(c *gin.Context, action models.AuditAction, rule *models.PricingRule) bool {
+	if err := h.auditService.Record(c.GetUint("userID"), requestLocation(c), action, map[string]interface{}{
+		"pricing_rule_id": rule.ID,
+		"name":            rule.Name,
+		"priority":        rule.Priority,
+		"enabled":         rule.Enabled,
+		"adjustment":      rule.Adjustment,
+		"value":           rule.Value,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return false
+	}
+	return true
+}
+
+// respondError maps a pricing rule service error to its HTTP response
+func (h *PricingRuleHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Pricing rule not found"})
+	case errors.Is(err, services.ErrPricingRuleSchedule), errors.Is(err, services.ErrPricingRulePercent),
+		errors.Is(err, services.ErrPricingRuleCategory):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// parsePricingRuleID reads the pricing rule ID path parameter, responding 400
+// when invalid
+func parsePricingRuleID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid pricing rule ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
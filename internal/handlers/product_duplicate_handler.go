@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ProductDuplicateHandler handles requests to find and merge duplicate products
+type ProductDuplicateHandler struct {
+	duplicateService *services.ProductDuplicateService
+	auditService     *services.AuditService
+}
+
+// NewProductDuplicateHandler creates a new product duplicate handler
+func NewProductDuplicateHandler(duplicateService *services.ProductDuplicateService, auditService *services.AuditService) *ProductDuplicateHandler {
+	return &ProductDuplicateHandler{duplicateService: duplicateService, auditService: auditService}
+}
+
+// ListDuplicates godoc
+// @Summary      List likely duplicate products
+// @Description  Scan the catalog for pairs of products that were likely entered twice, most likely first. Pairs are reported when their names share at least min_similarity of their trigrams, or when their SKUs match apart from case, spaces and punctuation. The score combines name similarity, a matching SKU and an identical price. The scan runs on every request. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        min_similarity  query     number  false  "Name similarity from 0.3 to 1 from which pairs are reported" default(0.6)
+// @Param        page            query     int     false  "Page number" default(1)
+// @Param        page_size       query     int     false  "Page size" default(10)
+// @Success      200             {object}  types.PaginatedResponse
+// @Failure      400             {object}  types.ErrorResponse
+// @Failure      401             {object}  types.ErrorResponse
+// @Failure      403             {object}  types.ErrorResponse
+// @Failure      500             {object}  types.ErrorResponse
+// @Router       /admin/products/duplicates [get]
+func (h *ProductDuplicateHandler) ListDuplicates(c *gin.Context) {
+	var req dto.ListDuplicatesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if req.MinSimilarity == 0 {
+		req.MinSimilarity = services.DefaultDuplicateSimilarity
+	}
+	pagination := utils.NormalizePagination("product_duplicates", req.Page, req.PageSize)
+
+	candidates, err := h.duplicateService.FindDuplicates(req.MinSimilarity)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	start := min(pagination.Offset(), len(candidates))
+	end := min(start+pagination.Limit, len(candidates))
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(candidates[start:end], int64(len(candidates)), pagination))
+}
+
+// MergeProducts godoc
+// @Summary      Merge a duplicate product
+// @Description  Consolidate a duplicate onto the product in the path, which survives, and delete the duplicate. Its reviews and wishlist entries move to the surviving product, except those of users who already reviewed or wished for it, which are deleted, and the surviving product joins its categories. The surviving product's rating is recomputed and a revision recorded. Recorded in the audit log. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                       true  "Surviving product ID"
+// @Param        request  body      dto.MergeProductsRequest  true  "Duplicate to merge"
+// @Success      200      {object}  types.DataResponse[dto.MergeProductsResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/products/{id}/merge [post]
+func (h *ProductDuplicateHandler) MergeProducts(c *gin.Context) {
+	id, ok := parseProductID(c)
+	if !ok {
+		return
+	}
+	var req dto.MergeProductsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.auditService.Record(c.GetUint("userID"), requestLocation(c), models.AuditProductMerge, map[string]interface{}{
+		"product_id":   id,
+		"duplicate_id": req.DuplicateID,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	result, err := h.duplicateService.MergeProducts(id, req.DuplicateID, c.GetUint("userID"))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Product not found"})
+		case errors.Is(err, services.ErrMergeSameProduct):
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Products merged",
+		Data:    result,
+	})
+}
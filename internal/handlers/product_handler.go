@@ -1,38 +1,50 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"product-management/internal/dto"
+	"product-management/internal/mappers"
 	"product-management/internal/models"
 	"product-management/internal/repositories"
 	"product-management/internal/services"
 	"product-management/internal/types"
+	"product-management/pkg/cdn"
+	"product-management/pkg/events"
 	"product-management/pkg/utils"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
+// maxBatchIDs is how many resources a batch lookup returns at most
+const maxBatchIDs = 100
+
 // ProductHandler handles product-related HTTP requests
 type ProductHandler struct {
-	productRepo    *repositories.ProductRepository
-	productService *services.ProductService
+	productRepo      *repositories.ProductRepository
+	productService   *services.ProductService
+	changeService    *services.ProductChangeService
+	priceListService *services.PriceListService
 }
 
 // NewProductHandler creates a new product handler
-func NewProductHandler(productRepo *repositories.ProductRepository) *ProductHandler {
+func NewProductHandler(productRepo *repositories.ProductRepository, changeService *services.ProductChangeService, priceListService *services.PriceListService) *ProductHandler {
 	return &ProductHandler{
-		productRepo:    productRepo,
-		productService: services.NewProductService(),
+		productRepo:      productRepo,
+		productService:   services.NewProductService(),
+		changeService:    changeService,
+		priceListService: priceListService,
 	}
 }
 
 // ListProducts godoc
 // @Summary      List products
-// @Description  Get a paginated list of products with optional filters
+// @Description  Get a paginated list of products with optional filters. Customers with a price list also get their price and quantity breaks. Products not available in the caller's country are left out, except for admins.
 // @Tags         products
 // @Accept       json
 // @Produce      json
@@ -41,8 +53,9 @@ func NewProductHandler(productRepo *repositories.ProductRepository) *ProductHand
 // @Param        page_size      query     int     false  "Items per page"
 // @Param        categoryId query     int     false  "Filter by category ID"
 // @Param        search     query     string  false  "Search term"
-// @Param        sort       query     string  false  "Sort field (name, price, created_at)"
+// @Param        sort       query     string  false  "Sort field (name, price, rating, created_at)"
 // @Param        statuses   query     []string false "Filter by statuses"
+// @Param        meta.{key} query     string  false  "Filter by a metadata value, e.g. meta.color=red; repeat for any of several values"
 // @Success      200        {object}  types.ProductListResponse
 // @Failure      400        {object}  types.ErrorResponse
 // @Failure      500        {object}  types.ErrorResponse
@@ -54,31 +67,53 @@ func (h *ProductHandler) ListProducts(c *gin.Context) {
 		return
 	}
 
+	metadata, ok := metadataFilters(c)
+	if !ok {
+		return
+	}
+
+	pagination := utils.NormalizePagination("products", req.Page, req.PageSize)
+
 	products, total, err := h.productService.ListProducts(
-		req.Page,
-		req.PageSize,
+		pagination.Page,
+		pagination.Limit,
 		req.CategoryID,
 		req.Search,
 		req.Sort,
 		req.Statuses,
+		productRegion(c),
+		metadata,
 	)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, types.NewProductListResponse(products, total, req.Page, req.PageSize))
+	// Searches feed the warehouse; paging through the results is one search
+	if req.Search != "" && pagination.Page == 1 {
+		services.RecordSearch(req.Search, req.CategoryID, total, c.GetUint("userID"))
+	}
+
+	pricing, ok := customerPricing(c, h.priceListService)
+	if !ok {
+		return
+	}
+	responses := mappers.ToProductResponses(products)
+	pricing.Apply(responses)
+
+	cdn.SetTags(c.Writer.Header(), cdn.ProductsTag)
+	c.JSON(http.StatusOK, types.NewProductListResponse(responses, total, pagination))
 }
 
 // GetProduct godoc
 // @Summary      Get a product
-// @Description  Get a product by its ID
+// @Description  Get a product by its ID. Customers with a price list also get their price and quantity breaks. Products not available in the caller's country are not found, except for admins.
 // @Tags         products
 // @Accept       json
 // @Produce      json
 // @Security     Bearer
 // @Param        id   path      int  true  "Product ID"
-// @Success      200  {object}  types.APIResponse
+// @Success      200  {object}  types.DataResponse[dto.ProductResponse]
 // @Failure      400  {object}  types.ErrorResponse
 // @Failure      404  {object}  types.ErrorResponse
 // @Failure      500  {object}  types.ErrorResponse
@@ -96,14 +131,132 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 		return
 	}
 
-	if product == nil {
+	// Products outside the caller's market are hidden as if they didn't exist
+	if product == nil || !availableToCaller(c, product) {
 		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Product not found"})
 		return
 	}
 
+	pricing, ok := customerPricing(c, h.priceListService)
+	if !ok {
+		return
+	}
+	responses := []dto.ProductResponse{mappers.ToProductResponse(product)}
+	pricing.Apply(responses)
+
+	cdn.SetTags(c.Writer.Header(), cdn.ProductTag(product.ID))
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    responses[0],
+	})
+}
+
+// GetProductsBatch godoc
+// @Summary      Get several products
+// @Description  Get up to 100 products by ID in one call, in the order requested. IDs that don't exist or whose products are not available in the caller's country are listed in missing. Customers with a price list also get their prices and quantity breaks.
+// @Tags         products
+// @Produce      json
+// @Security     Bearer
+// @Param        ids  query     string  true  "Comma-separated product IDs, e.g. 1,2,3"
+// @Success      200  {object}  types.DataResponse[dto.ProductBatchResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /products/batch [get]
+func (h *ProductHandler) GetProductsBatch(c *gin.Context) {
+	ids, ok := batchIDs(c)
+	if !ok {
+		return
+	}
+
+	products, err := h.productService.GetProducts(ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	pricing, ok := customerPricing(c, h.priceListService)
+	if !ok {
+		return
+	}
+	response := dto.ProductBatchResponse{Items: []dto.ProductResponse{}, Missing: []uint{}}
+	tags := make([]string, len(ids))
+	for i, id := range ids {
+		// Tagged whether found or not, so creating a missing product purges the response too
+		tags[i] = cdn.ProductTag(id)
+		product := products[id]
+		if product == nil || !availableToCaller(c, product) {
+			response.Missing = append(response.Missing, id)
+			continue
+		}
+		response.Items = append(response.Items, mappers.ToProductResponse(product))
+	}
+	pricing.Apply(response.Items)
+
+	cdn.SetTags(c.Writer.Header(), tags...)
 	c.JSON(http.StatusOK, types.APIResponse{
 		Success: true,
-		Data:    product,
+		Data:    response,
+	})
+}
+
+// GetProductPrice godoc
+// @Summary      Price a product
+// @Description  Get the unit price and total the current user pays for a quantity of a product, after pricing rules, their price list and quantity breaks, with the pricing rule that set the price
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id        path      int  true   "Product ID"
+// @Param        quantity  query     int  false  "Quantity (default: 1)"
+// @Success      200       {object}  types.DataResponse[dto.ProductPriceResponse]
+// @Failure      400       {object}  types.ErrorResponse
+// @Failure      401       {object}  types.ErrorResponse
+// @Failure      404       {object}  types.ErrorResponse
+// @Failure      500       {object}  types.ErrorResponse
+// @Router       /products/{id}/price [get]
+func (h *ProductHandler) GetProductPrice(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid product ID"})
+		return
+	}
+	var req dto.ProductPriceRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if req.Quantity == 0 {
+		req.Quantity = 1
+	}
+
+	product, err := h.productService.GetProduct(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if product == nil || !availableToCaller(c, product) {
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Product not found"})
+		return
+	}
+	pricing, ok := customerPricing(c, h.priceListService)
+	if !ok {
+		return
+	}
+
+	unitPrice, rule := pricing.PriceWithRule(product, req.Quantity)
+	response := dto.ProductPriceResponse{
+		ProductID: product.ID,
+		Quantity:  req.Quantity,
+		ListPrice: product.Price,
+		UnitPrice: unitPrice,
+		Total:     math.Round(unitPrice*float64(req.Quantity)*100) / 100,
+	}
+	if rule != nil {
+		response.PricingRule = &dto.AppliedPricingRule{ID: rule.ID, Name: rule.Name}
+	}
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
 	})
 }
 
@@ -115,8 +268,9 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 // @Produce      json
 // @Security     Bearer
 // @Param        product  body      dto.CreateProductRequest  true  "Product details"
-// @Success      201      {object}  types.APIResponse
+// @Success      201      {object}  types.DataResponse[dto.ProductResponse]
 // @Failure      400      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
 // @Failure      500      {object}  types.ErrorResponse
 // @Router       /products [post]
 func (h *ProductHandler) CreateProduct(c *gin.Context) {
@@ -127,22 +281,35 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	}
 
 	// Validate and get categories
-	categories, err := h.validateCategories(req.Categories)
+	categories, err := h.productService.ResolveCategories(req.Categories)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
 		return
 	}
+	if err := h.productService.ValidateMetadata(req.Metadata, categories); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if !h.checkSKU(c, req.SKU, 0) {
+		return
+	}
 
 	// Create product
 	product := &models.Product{
-		Name:          req.Name,
-		Description:   req.Description,
-		Price:         req.Price,
-		StockQuantity: req.Quantity,
-		Status:        models.StatusActive,
+		Name:             req.Name,
+		Description:      req.Description,
+		SKU:              models.OptionalSKU(req.SKU),
+		Location:         models.NormalizeLocation(req.Location),
+		Bookable:         req.Bookable,
+		Price:            req.Price,
+		StockQuantity:    req.Quantity,
+		Status:           models.StatusActive,
+		AllowedCountries: models.NormalizeCountries(req.AllowedCountries),
+		BlockedCountries: models.NormalizeCountries(req.BlockedCountries),
+		Metadata:         req.Metadata,
 	}
 
-	if err := h.productService.CreateProduct(product, categories); err != nil {
+	if err := h.productService.CreateProduct(product, categories, c.GetUint("userID")); err != nil {
 		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -150,21 +317,23 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	c.JSON(http.StatusCreated, types.APIResponse{
 		Success: true,
 		Message: "Product created successfully",
-		Data:    product,
+		Data:    mappers.ToProductResponse(product),
 	})
 }
 
 // UpdateProduct godoc
 // @Summary      Update a product
-// @Description  Update an existing product
+// @Description  Update an existing product. When change approval is enabled, edits by non-admins are stored as a pending change request instead.
 // @Tags         products
 // @Accept       json
 // @Produce      json
 // @Security     Bearer
 // @Param        id       path      int                     true  "Product ID"
 // @Param        product  body      dto.UpdateProductRequest true  "Product details to update"
-// @Success      200      {object}  types.APIResponse
+// @Success      200      {object}  types.DataResponse[dto.ProductResponse]
+// @Success      202      {object}  types.DataResponse[dto.ProductChangeRequestResponse]
 // @Failure      400      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
 // @Failure      500      {object}  types.ErrorResponse
 // @Router       /products/{id} [put]
 func (h *ProductHandler) UpdateProduct(c *gin.Context) {
@@ -180,31 +349,403 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 		return
 	}
 
+	categories, err := h.productService.ResolveCategories(req.Categories)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := h.productService.ValidateMetadata(req.Metadata, categories); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if !h.checkSKU(c, req.SKU, uint(id)) {
+		return
+	}
+
+	// Non-admin edits go through admin review when approval mode is enabled
+	if h.changeService.RequiresApproval(c.GetString("role")) {
+		changeRequest, err := h.changeService.RequestChange(uint(id), dto.ProductSnapshot{
+			Name:             req.Name,
+			Description:      req.Description,
+			SKU:              req.SKU,
+			Location:         models.NormalizeLocation(req.Location),
+			Bookable:         req.Bookable,
+			Price:            req.Price,
+			StockQuantity:    req.Quantity,
+			Status:           req.Status,
+			Categories:       req.Categories,
+			AllowedCountries: models.NormalizeCountries(req.AllowedCountries),
+			BlockedCountries: models.NormalizeCountries(req.BlockedCountries),
+			Metadata:         req.Metadata,
+		}, c.GetUint("userID"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, types.APIResponse{
+			Success: true,
+			Message: "Product change submitted for approval",
+			Data:    mappers.ToChangeRequestResponse(changeRequest),
+		})
+		return
+	}
+
 	// Update product
 	product := &models.Product{
-		BaseModel:     models.BaseModel{ID: uint(id)},
-		Name:          req.Name,
-		Description:   req.Description,
-		Price:         req.Price,
-		StockQuantity: req.Quantity,
-		Status:        models.ProductStatus(req.Status),
+		BaseModel:        models.BaseModel{ID: uint(id)},
+		Name:             req.Name,
+		Description:      req.Description,
+		SKU:              models.OptionalSKU(req.SKU),
+		Location:         models.NormalizeLocation(req.Location),
+		Bookable:         req.Bookable,
+		Price:            req.Price,
+		StockQuantity:    req.Quantity,
+		Status:           models.ProductStatus(req.Status),
+		AllowedCountries: models.NormalizeCountries(req.AllowedCountries),
+		BlockedCountries: models.NormalizeCountries(req.BlockedCountries),
+		Metadata:         req.Metadata,
 	}
 
-	if err := h.productService.UpdateProduct(product, req.Categories); err != nil {
+	if err := h.productService.UpdateProduct(product, req.Categories, c.GetUint("userID")); err != nil {
 		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
 		return
 	}
 
+	// Reload to return the product with its categories
+	updated, err := h.productService.GetProduct(uint(id))
+	if err != nil || updated == nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "Failed to load updated product"})
+		return
+	}
+
 	c.JSON(http.StatusOK, types.APIResponse{
 		Success: true,
 		Message: "Product updated successfully",
-		Data:    product,
+		Data:    mappers.ToProductResponse(updated),
+	})
+}
+
+// PatchProductMetadata godoc
+// @Summary      Patch product metadata
+// @Description  Change some metadata keys of a product as a JSON merge patch: keys set to null are removed, others are added or replaced, and keys left out are kept. The result is checked against the metadata schemas of the product's categories. When change approval is enabled, patches by non-admins are stored as a pending change request instead.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int     true  "Product ID"
+// @Param        request  body      object  true  "Metadata keys to change, e.g. {\"color\": \"red\", \"size\": null}"
+// @Success      200      {object}  types.DataResponse[dto.ProductResponse]
+// @Success      202      {object}  types.DataResponse[dto.ProductChangeRequestResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /products/{id}/metadata [patch]
+func (h *ProductHandler) PatchProductMetadata(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid product ID"})
+		return
+	}
+
+	var patch map[string]interface{}
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Body must be a JSON object of metadata keys"})
+		return
+	}
+
+	// Patch the stored metadata rather than a cached copy, so concurrent
+	// patches of other keys are kept
+	product, err := h.productRepo.GetByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if product == nil {
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Product not found"})
+		return
+	}
+
+	metadata := make(map[string]interface{}, len(product.Metadata)+len(patch))
+	for key, value := range product.Metadata {
+		metadata[key] = value
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(metadata, key)
+		} else {
+			metadata[key] = value
+		}
+	}
+	if err := h.productService.ValidateMetadata(metadata, product.Categories); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if h.changeService.RequiresApproval(c.GetString("role")) {
+		proposed := repositories.SnapshotProduct(product)
+		proposed.Metadata = metadata
+		changeRequest, err := h.changeService.RequestChange(uint(id), proposed, c.GetUint("userID"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, types.APIResponse{
+			Success: true,
+			Message: "Metadata change submitted for approval",
+			Data:    mappers.ToChangeRequestResponse(changeRequest),
+		})
+		return
+	}
+
+	if err := h.productService.UpdateMetadata(uint(id), metadata, c.GetUint("userID")); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	updated, err := h.productService.GetProduct(uint(id))
+	if err != nil || updated == nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "Failed to load updated product"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Product metadata updated",
+		Data:    mappers.ToProductResponse(updated),
+	})
+}
+
+// ListProductRevisions godoc
+// @Summary      List product revisions
+// @Description  Get the revision history of a product, newest first
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id     path      int  true   "Product ID"
+// @Param        page   query     int  false  "Page number"
+// @Param        limit  query     int  false  "Items per page"
+// @Success      200    {object}  types.PaginatedResponse
+// @Failure      400    {object}  types.ErrorResponse
+// @Failure      500    {object}  types.ErrorResponse
+// @Router       /products/{id}/revisions [get]
+func (h *ProductHandler) ListProductRevisions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid product ID"})
+		return
+	}
+
+	pagination := utils.ParsePaginationParams("product_revisions", c.Query("page"), c.Query("limit"))
+
+	revisions, total, err := h.productService.ListRevisions(uint(id), pagination.Page, pagination.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.ProductRevisionResponse, len(revisions))
+	for i := range revisions {
+		items[i] = mappers.ToProductRevisionResponse(&revisions[i])
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(items, total, pagination))
+}
+
+// RestoreProductRevision godoc
+// @Summary      Restore a product revision
+// @Description  Restore a product to the state recorded by a revision, except its stock quantity, which only moves through the stock ledger. The restore itself is recorded as a new revision, or submitted for approval when change approval is enabled.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Product ID"
+// @Param        rev  path      int  true  "Revision number"
+// @Success      200  {object}  types.DataResponse[dto.ProductResponse]
+// @Success      202  {object}  types.DataResponse[dto.ProductChangeRequestResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /products/{id}/revisions/{rev}/restore [post]
+func (h *ProductHandler) RestoreProductRevision(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid product ID"})
+		return
+	}
+	revision, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || revision < 1 {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid revision number"})
+		return
+	}
+
+	snapshot, err := h.productService.GetRevisionSnapshot(uint(id), revision)
+	if err != nil {
+		if err.Error() == "revision not found" {
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// Categories deleted since the revision was recorded can't be restored,
+	// and their metadata schemas may have changed
+	categories, err := h.productService.ResolveCategories(snapshot.Categories)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := h.productService.ValidateMetadata(snapshot.Metadata, categories); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	// The SKU may have been given to another product since
+	if !h.checkSKU(c, snapshot.SKU, uint(id)) {
+		return
+	}
+
+	if h.changeService.RequiresApproval(c.GetString("role")) {
+		changeRequest, err := h.changeService.RequestChange(uint(id), *snapshot, c.GetUint("userID"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, types.APIResponse{
+			Success: true,
+			Message: "Product restore submitted for approval",
+			Data:    mappers.ToChangeRequestResponse(changeRequest),
+		})
+		return
+	}
+
+	product := &models.Product{
+		BaseModel:        models.BaseModel{ID: uint(id)},
+		Name:             snapshot.Name,
+		Description:      snapshot.Description,
+		SKU:              models.OptionalSKU(snapshot.SKU),
+		Location:         snapshot.Location,
+		Bookable:         snapshot.Bookable,
+		Price:            snapshot.Price,
+		Status:           models.ProductStatus(snapshot.Status),
+		AllowedCountries: snapshot.AllowedCountries,
+		BlockedCountries: snapshot.BlockedCountries,
+		Metadata:         snapshot.Metadata,
+	}
+
+	if err := h.productService.RestoreProduct(product, snapshot.Categories, c.GetUint("userID")); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	restored, err := h.productService.GetProduct(uint(id))
+	if err != nil || restored == nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "Failed to load restored product"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("Product restored to revision %d", revision),
+		Data:    mappers.ToProductResponse(restored),
+	})
+}
+
+// GetStockHistory godoc
+// @Summary      Get product stock history
+// @Description  Get the ledger of stock movements for a product, newest first (admin only)
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id     path      int  true   "Product ID"
+// @Param        page   query     int  false  "Page number"
+// @Param        limit  query     int  false  "Items per page"
+// @Success      200    {object}  types.PaginatedResponse
+// @Failure      400    {object}  types.ErrorResponse
+// @Failure      403    {object}  types.ErrorResponse
+// @Failure      500    {object}  types.ErrorResponse
+// @Router       /products/{id}/stock-history [get]
+func (h *ProductHandler) GetStockHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid product ID"})
+		return
+	}
+
+	pagination := utils.ParsePaginationParams("stock_history", c.Query("page"), c.Query("limit"))
+
+	movements, total, err := h.productService.ListStockMovements(uint(id), pagination.Page, pagination.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.StockMovementResponse, len(movements))
+	for i := range movements {
+		items[i] = mappers.ToStockMovementResponse(&movements[i])
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(items, total, pagination))
+}
+
+// AdjustStock godoc
+// @Summary      Adjust product stock
+// @Description  Record a stock movement made by hand and move the product's stock by it: a sale outside the store's orders, a restock, a customer return or another adjustment. The movement is added to the stock ledger with its resulting quantity. Stock never goes below zero, so removing more than is left returns 409. Admin only.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                     true  "Product ID"
+// @Param        request  body      dto.AdjustStockRequest  true  "Units and reason"
+// @Success      201      {object}  types.DataResponse[dto.StockMovementResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /products/{id}/stock-adjustments [post]
+func (h *ProductHandler) AdjustStock(c *gin.Context) {
+	id, ok := parseProductID(c)
+	if !ok {
+		return
+	}
+	var req dto.AdjustStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	movement, err := h.productService.AdjustStock(id, c.GetUint("userID"), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrStockAdjustProduct):
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Product not found"})
+		case errors.Is(err, services.ErrStockAdjustment):
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, repositories.ErrInsufficientStock):
+			c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Stock adjusted",
+		Data:    mappers.ToStockMovementResponse(movement),
 	})
 }
 
 // DeleteProduct godoc
 // @Summary      Delete a product
-// @Description  Delete a product by its ID
+// @Description  Delete a product by its ID. While change approval is enabled, only admins can delete products, since deletions are not reviewed.
 // @Tags         products
 // @Accept       json
 // @Produce      json
@@ -212,6 +753,7 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 // @Param        id   path      int  true  "Product ID"
 // @Success      200  {object}  types.SuccessResponse
 // @Failure      400  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
 // @Failure      500  {object}  types.ErrorResponse
 // @Router       /products/{id} [delete]
 func (h *ProductHandler) DeleteProduct(c *gin.Context) {
@@ -221,10 +763,17 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 		return
 	}
 
+	// Deletions can't be reviewed, so approval mode keeps them to admins
+	if h.changeService.RequiresApproval(c.GetString("role")) {
+		c.JSON(http.StatusForbidden, types.ErrorResponse{Error: "Only admins can delete products while change approval is enabled"})
+		return
+	}
+
 	if err := h.productService.DeleteProduct(uint(id)); err != nil {
 		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
 		return
 	}
+	events.Publish(events.ProductDeleted{ActorID: c.GetUint("userID"), ProductID: uint(id), IP: c.ClientIP()})
 
 	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Product deleted successfully"})
 }
@@ -242,10 +791,7 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 // @Failure      500   {object}  types.ErrorResponse
 // @Router       /products/wishlist [get]
 func (h *ProductHandler) GetWishlist(c *gin.Context) {
-	pagination := utils.ParsePaginationParams(
-		c.DefaultQuery("page", "1"),
-		c.DefaultQuery("limit", "10"),
-	)
+	pagination := utils.ParsePaginationParams("wishlist", c.Query("page"), c.Query("limit"))
 
 	currentUserID := c.GetUint("userID")
 	wishlist, total, err := h.productService.GetWishlist(currentUserID, pagination.Page, pagination.Limit)
@@ -254,7 +800,21 @@ func (h *ProductHandler) GetWishlist(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, types.NewWishlistResponse(wishlist, total, pagination.Page, pagination.Limit))
+	pricing, ok := customerPricing(c, h.priceListService)
+	if !ok {
+		return
+	}
+	items := mappers.ToWishlistItemResponses(wishlist)
+	products := make([]dto.ProductResponse, len(items))
+	for i := range items {
+		products[i] = items[i].Product
+	}
+	pricing.Apply(products)
+	for i := range items {
+		items[i].Product = products[i]
+	}
+
+	c.JSON(http.StatusOK, types.NewWishlistResponse(items, total, pagination))
 }
 
 // AddToWishlist godoc
@@ -339,7 +899,7 @@ func (h *ProductHandler) RemoveFromWishlist(c *gin.Context) {
 // @Tags         products
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  types.APIResponse
+// @Success      200  {object}  types.DataResponse[dto.WishlistCountResponse]
 // @Failure      500  {object}  types.ErrorResponse
 // @Security     Bearer
 // @Router       /products/wishlist/count [get]
@@ -370,34 +930,89 @@ func (h *ProductHandler) GetTotalWishlistCount(c *gin.Context) {
 	c.JSON(http.StatusOK, types.APIResponse{
 		Success: true,
 		Message: "Total wishlist count retrieved successfully",
-		Data: gin.H{
-			"total_wishlist_count": count,
-			"my_wishlist_count":    myWishlistCount,
+		Data: dto.WishlistCountResponse{
+			TotalWishlistCount: count,
+			MyWishlistCount:    myWishlistCount,
 		},
 	})
 }
 
-// validateCategories checks for duplicate category IDs and validates their existence
-func (h *ProductHandler) validateCategories(categoryIDs []uint) ([]models.Category, error) {
-	categoryMap := make(map[uint]bool)
-	for _, id := range categoryIDs {
-		if categoryMap[id] {
-			return nil, fmt.Errorf("duplicate category ID found: %d", id)
+// productRegion returns the country the caller sees products of, or nil for
+// admins, who manage products of every market
+func productRegion(c *gin.Context) *string {
+	if c.GetString("role") == string(models.RoleAdmin) {
+		return nil
+	}
+	country := c.GetString("country")
+	return &country
+}
+
+// metadataFilters collects the meta.{key} query parameters of a product search
+// by key. It responds 400 when a key is not a valid metadata key.
+func metadataFilters(c *gin.Context) (map[string][]string, bool) {
+	var filters map[string][]string
+	for param, values := range c.Request.URL.Query() {
+		key, ok := strings.CutPrefix(param, "meta.")
+		if !ok {
+			continue
+		}
+		if !models.MetadataKeyPattern.MatchString(key) {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: fmt.Sprintf("Invalid metadata filter %q", param)})
+			return nil, false
+		}
+		if filters == nil {
+			filters = make(map[string][]string)
 		}
-		categoryMap[id] = true
+		filters[key] = values
+	}
+	return filters, true
+}
+
+// batchIDs parses the comma-separated ids of a batch lookup, without repeats
+// and in their first order of appearance. It responds 400 when they are
+// missing, invalid or more than maxBatchIDs.
+func batchIDs(c *gin.Context) ([]uint, bool) {
+	var req dto.BatchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return nil, false
 	}
 
-	var categories []models.Category
-	for _, categoryID := range categoryIDs {
-		var category models.Category
-		if err := h.productRepo.DB().First(&category, categoryID).Error; err != nil {
-			if err == gorm.ErrRecordNotFound {
-				return nil, fmt.Errorf("category not found with ID: %d", categoryID)
-			}
-			return nil, fmt.Errorf("failed to fetch category: %v", err)
+	seen := make(map[uint]bool)
+	var ids []uint
+	for _, value := range strings.Split(req.IDs, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+		if err != nil || id == 0 {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: fmt.Sprintf("Invalid ID %q", value)})
+			return nil, false
+		}
+		if !seen[uint(id)] {
+			seen[uint(id)] = true
+			ids = append(ids, uint(id))
 		}
-		categories = append(categories, category)
 	}
+	if len(ids) > maxBatchIDs {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: fmt.Sprintf("At most %d IDs can be requested at once", maxBatchIDs)})
+		return nil, false
+	}
+	return ids, true
+}
 
-	return categories, nil
+// availableToCaller reports whether the caller may see a product in their country
+func availableToCaller(c *gin.Context, product *models.Product) bool {
+	country := productRegion(c)
+	return country == nil || product.AvailableIn(*country)
+}
+
+// checkSKU responds 409 when another product already uses the SKU
+func (h *ProductHandler) checkSKU(c *gin.Context, sku string, productID uint) bool {
+	if err := h.productService.CheckSKU(sku, productID); err != nil {
+		if errors.Is(err, services.ErrSKUTaken) {
+			c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return false
+	}
+	return true
 }
@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/repositories"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxProductImageSize is the largest product image accepted
+const maxProductImageSize = 10 << 20
+
+// maxImageAltLength is how long the alternative text of an image may be
+const maxImageAltLength = 255
+
+// ProductImageHandler handles product image requests
+type ProductImageHandler struct {
+	imageService   *services.ProductImageService
+	productService *services.ProductService
+	changeService  *services.ProductChangeService
+}
+
+// NewProductImageHandler creates a new product image handler
+func NewProductImageHandler(imageService *services.ProductImageService, changeService *services.ProductChangeService) *ProductImageHandler {
+	return &ProductImageHandler{
+		imageService:   imageService,
+		productService: services.NewProductService(),
+		changeService:  changeService,
+	}
+}
+
+// ListProductImages godoc
+// @Summary      List product images
+// @Description  Get the images of a product in display order, the first being the main one. Products not available in the caller's country are not found, except for admins.
+// @Tags         products
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {object}  types.DataResponse[[]dto.ProductImageResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /products/{id}/images [get]
+func (h *ProductImageHandler) ListProductImages(c *gin.Context) {
+	id, ok := parseProductID(c)
+	if !ok {
+		return
+	}
+
+	product, err := h.productService.GetProduct(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if product == nil || !availableToCaller(c, product) {
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Product not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToProductImageResponses(product.Images),
+	})
+}
+
+// UploadProductImage godoc
+// @Summary      Upload a product image
+// @Description  Upload a JPEG, PNG, WebP or GIF image of up to 10 MB as the image field of a multipart form. It is added after the product's other images, up to 20. The type is detected from the content. While change approval is enabled, only admins can change images.
+// @Tags         products
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     Bearer
+// @Param        id     path      int     true   "Product ID"
+// @Param        image  formData  file    true   "Image file"
+// @Param        alt    formData  string  false  "Alternative text"
+// @Success      201  {object}  types.DataResponse[dto.ProductImageResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      413  {object}  types.ErrorResponse
+// @Failure      415  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /products/{id}/images [post]
+func (h *ProductImageHandler) UploadProductImage(c *gin.Context) {
+	id, ok := parseProductID(c)
+	if !ok || !h.canChangeImages(c) {
+		return
+	}
+
+	// Leave room for the rest of the form around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxProductImageSize+1<<20)
+	header, err := c.FormFile("image")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, types.ErrorResponse{Error: "Image is larger than 10 MB"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "An image file is required in the image field of a multipart form"})
+		return
+	}
+	if header.Size > maxProductImageSize {
+		c.JSON(http.StatusRequestEntityTooLarge, types.ErrorResponse{Error: "Image is larger than 10 MB"})
+		return
+	}
+	alt := c.PostForm("alt")
+	if utf8.RuneCountInString(alt) > maxImageAltLength {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "alt must be at most 255 characters"})
+		return
+	}
+
+	product, err := h.productService.GetProduct(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if product == nil {
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Product not found"})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Failed to read the image"})
+		return
+	}
+	defer file.Close()
+
+	image, err := h.imageService.UploadImage(c.Request.Context(), id, file, header.Size, alt, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Image uploaded",
+		Data:    mappers.ToProductImageResponse(image),
+	})
+}
+
+// ReorderProductImages godoc
+// @Summary      Reorder product images
+// @Description  Set the display order of a product's images. Every image of the product must be listed once; the first becomes the main image. While change approval is enabled, only admins can change images.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                           true  "Product ID"
+// @Param        request  body      dto.ProductImageOrderRequest  true  "Image IDs in display order"
+// @Success      200  {object}  types.DataResponse[[]dto.ProductImageResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /products/{id}/images/order [put]
+func (h *ProductImageHandler) ReorderProductImages(c *gin.Context) {
+	id, ok := parseProductID(c)
+	if !ok || !h.canChangeImages(c) {
+		return
+	}
+	var req dto.ProductImageOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	images, err := h.imageService.ReorderImages(id, req.ImageIDs)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Images reordered",
+		Data:    mappers.ToProductImageResponses(images),
+	})
+}
+
+// DeleteProductImage godoc
+// @Summary      Delete a product image
+// @Description  Delete an image of a product and its file. While change approval is enabled, only admins can change images.
+// @Tags         products
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int  true  "Product ID"
+// @Param        imageId  path      int  true  "Image ID"
+// @Success      200  {object}  types.SuccessResponse
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /products/{id}/images/{imageId} [delete]
+func (h *ProductImageHandler) DeleteProductImage(c *gin.Context) {
+	id, ok := parseProductID(c)
+	if !ok {
+		return
+	}
+	imageID, ok := parseImageID(c)
+	if !ok || !h.canChangeImages(c) {
+		return
+	}
+
+	if err := h.imageService.DeleteImage(c.Request.Context(), id, imageID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Image deleted"})
+}
+
+// GetProductImage godoc
+// @Summary      Get a product image
+// @Description  Redirect to a short-lived signed URL of a product image. This is the url of images in product responses when no CDN is configured, and works without login so it can be used in img tags. Products not available in the caller's country are not found.
+// @Tags         products
+// @Param        id       path  int  true  "Product ID"
+// @Param        imageId  path  int  true  "Image ID"
+// @Success      302
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      429  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /products/{id}/images/{imageId} [get]
+func (h *ProductImageHandler) GetProductImage(c *gin.Context) {
+	id, ok := parseProductID(c)
+	if !ok {
+		return
+	}
+	imageID, ok := parseImageID(c)
+	if !ok {
+		return
+	}
+
+	product, err := h.productService.GetProduct(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if product == nil || !availableToCaller(c, product) {
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Product not found"})
+		return
+	}
+
+	url, err := h.imageService.ImageURL(c.Request.Context(), product, imageID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	// Signed URLs expire, so the redirect itself must not be cached for long
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Redirect(http.StatusFound, url)
+}
+
+// canChangeImages responds 403 when the caller's product edits go through
+// review, since image changes can't be reviewed
+func (h *ProductImageHandler) canChangeImages(c *gin.Context) bool {
+	if h.changeService.RequiresApproval(c.GetString("role")) {
+		c.JSON(http.StatusForbidden, types.ErrorResponse{Error: "Only admins can change product images while change approval is enabled"})
+		return false
+	}
+	return true
+}
+
+// respondError maps a product image service error to its HTTP response
+func (h *ProductImageHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Image not found"})
+	case errors.Is(err, services.ErrImageType):
+		c.JSON(http.StatusUnsupportedMediaType, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrImageOrder):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, repositories.ErrImageLimit):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// parseProductID reads the product ID path parameter, responding 400 when invalid
+func parseProductID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid product ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// parseImageID reads the image ID path parameter, responding 400 when invalid
+func parseImageID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("imageId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid image ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
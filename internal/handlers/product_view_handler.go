@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Defaults of the product rankings built from views
+const (
+	defaultRankingDays  = 7
+	defaultRankingLimit = 10
+)
+
+// maxReferrerLength is how much of a referrer is kept
+const maxReferrerLength = 500
+
+// ProductViewHandler records product views and serves the rankings built from them
+type ProductViewHandler struct {
+	viewService      *services.ProductViewService
+	productService   *services.ProductService
+	priceListService *services.PriceListService
+}
+
+// NewProductViewHandler creates a new product view handler
+func NewProductViewHandler(viewService *services.ProductViewService, priceListService *services.PriceListService) *ProductViewHandler {
+	return &ProductViewHandler{
+		viewService:      viewService,
+		productService:   services.NewProductService(),
+		priceListService: priceListService,
+	}
+}
+
+// RecordProductView godoc
+// @Summary      Record a product view
+// @Description  Record that a product page was viewed, for trending products, related products and conversion analytics. Signed-in callers send their token and are recorded as the viewer; others may send an anonymous ID their client keeps. The body is optional, and the referrer defaults to the Referer header. Products not available in the caller's country are not found.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                     true   "Product ID"
+// @Param        request  body      dto.ProductViewRequest  false  "Anonymous ID and referrer"
+// @Success      202  {object}  types.SuccessResponse
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      429  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /products/{id}/view [post]
+func (h *ProductViewHandler) RecordProductView(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid product ID"})
+		return
+	}
+
+	var req dto.ProductViewRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+	if req.Referrer == "" {
+		req.Referrer = c.Request.Referer()
+		if len(req.Referrer) > maxReferrerLength {
+			req.Referrer = strings.ToValidUTF8(req.Referrer[:maxReferrerLength], "")
+		}
+	}
+
+	product, err := h.productService.GetProduct(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if product == nil || !availableToCaller(c, product) {
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Product not found"})
+		return
+	}
+
+	if err := h.viewService.RecordView(product.ID, c.GetUint("userID"), req.AnonymousID, req.Referrer); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "Failed to record product view"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, types.SuccessResponse{Message: "Product view recorded"})
+}
+
+// GetTrendingProducts godoc
+// @Summary      Get trending products
+// @Description  Get the products viewed the most over the last days, most viewed first. Only active products sold in the caller's country are returned. Rankings are cached for a few minutes. Customers with a price list also get their prices and quantity breaks.
+// @Tags         products
+// @Produce      json
+// @Security     Bearer
+// @Param        days   query     int  false  "Views of the last days that count (1-30)" default(7)
+// @Param        limit  query     int  false  "Number of products (1-50)" default(10)
+// @Success      200  {object}  types.DataResponse[[]dto.ProductResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /products/trending [get]
+func (h *ProductViewHandler) GetTrendingProducts(c *gin.Context) {
+	req, ok := rankingRequest(c)
+	if !ok {
+		return
+	}
+
+	products, err := h.viewService.Trending(req.Days, req.Limit, productRegion(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.respondProducts(c, products)
+}
+
+// GetAlsoViewedProducts godoc
+// @Summary      Get products also viewed
+// @Description  Get the other products viewed over the last days by those who viewed a product, the ones with the most such viewers first. Viewers are signed-in users and anonymous IDs. Only active products sold in the caller's country are returned. Rankings are cached for a few minutes. Customers with a price list also get their prices and quantity breaks.
+// @Tags         products
+// @Produce      json
+// @Security     Bearer
+// @Param        id     path      int  true   "Product ID"
+// @Param        days   query     int  false  "Views of the last days that count (1-30)" default(7)
+// @Param        limit  query     int  false  "Number of products (1-50)" default(10)
+// @Success      200  {object}  types.DataResponse[[]dto.ProductResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /products/{id}/also-viewed [get]
+func (h *ProductViewHandler) GetAlsoViewedProducts(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid product ID"})
+		return
+	}
+	req, ok := rankingRequest(c)
+	if !ok {
+		return
+	}
+
+	products, err := h.viewService.AlsoViewed(uint(id), req.Days, req.Limit, productRegion(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.respondProducts(c, products)
+}
+
+// respondProducts responds with ranked products priced for the caller
+func (h *ProductViewHandler) respondProducts(c *gin.Context, products []models.Product) {
+	pricing, ok := customerPricing(c, h.priceListService)
+	if !ok {
+		return
+	}
+	responses := mappers.ToProductResponses(products)
+	pricing.Apply(responses)
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// rankingRequest binds the query of a product ranking with its defaults,
+// responding 400 when it is invalid
+func rankingRequest(c *gin.Context) (dto.ProductRankingRequest, bool) {
+	var req dto.ProductRankingRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid query parameters"})
+		return req, false
+	}
+	if req.Days == 0 {
+		req.Days = defaultRankingDays
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultRankingLimit
+	}
+	return req, true
+}
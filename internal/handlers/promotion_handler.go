@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PromotionHandler handles promotion requests
+type PromotionHandler struct {
+	promotionService *services.PromotionService
+}
+
+// NewPromotionHandler creates a new promotion handler
+func NewPromotionHandler(promotionService *services.PromotionService) *PromotionHandler {
+	return &PromotionHandler{promotionService: promotionService}
+}
+
+// ListPromotions godoc
+// @Summary      List promotions
+// @Description  Get promotions, latest start first, optionally only those with a status (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        status     query     string  false  "Promotion status" Enums(scheduled, active, ended)
+// @Param        page       query     int     false  "Page number" default(1)
+// @Param        page_size  query     int     false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /admin/promotions [get]
+func (h *PromotionHandler) ListPromotions(c *gin.Context) {
+	var req dto.ListPromotionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	pagination := utils.NormalizePagination("promotions", req.Page, req.PageSize)
+
+	promotions, total, err := h.promotionService.ListPromotions(models.PromotionStatus(req.Status), pagination.Page, pagination.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.PromotionResponse, len(promotions))
+	for i := range promotions {
+		items[i] = mappers.ToPromotionResponse(&promotions[i])
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(items, total, pagination))
+}
+
+// GetPromotion godoc
+// @Summary      Get a promotion
+// @Description  Get a promotion by ID (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Promotion ID"
+// @Success      200  {object}  types.DataResponse[dto.PromotionResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/promotions/{id} [get]
+func (h *PromotionHandler) GetPromotion(c *gin.Context) {
+	id, ok := parsePromotionID(c)
+	if !ok {
+		return
+	}
+
+	promotion, err := h.promotionService.GetPromotion(id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToPromotionResponse(promotion),
+	})
+}
+
+// CreatePromotion godoc
+// @Summary      Schedule a promotion
+// @Description  Schedule a discount off the list price of a product, or of every product in a category, between starts_at and ends_at. The scheduler starts and ends it; while active, product responses show the original and promotional price. A promotion overlapping another one of the same products in time is rejected with 409. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.PromotionRequest  true  "Promotion"
+// @Success      201      {object}  types.DataResponse[dto.PromotionResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/promotions [post]
+func (h *PromotionHandler) CreatePromotion(c *gin.Context) {
+	var req dto.PromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	promotion, err := h.promotionService.CreatePromotion(req, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Promotion scheduled",
+		Data:    mappers.ToPromotionResponse(promotion),
+	})
+}
+
+// UpdatePromotion godoc
+// @Summary      Update a promotion
+// @Description  Replace the scope, discount and schedule of a promotion that has not ended. The scheduler moves it to the status of its new schedule. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                   true  "Promotion ID"
+// @Param        request  body      dto.PromotionRequest  true  "Promotion"
+// @Success      200      {object}  types.DataResponse[dto.PromotionResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/promotions/{id} [put]
+func (h *PromotionHandler) UpdatePromotion(c *gin.Context) {
+	id, ok := parsePromotionID(c)
+	if !ok {
+		return
+	}
+	var req dto.PromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	promotion, err := h.promotionService.UpdatePromotion(id, req, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Promotion updated",
+		Data:    mappers.ToPromotionResponse(promotion),
+	})
+}
+
+// DeletePromotion godoc
+// @Summary      Delete a promotion
+// @Description  Delete a promotion, ending it at once when it is active (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Promotion ID"
+// @Success      200  {object}  types.SuccessResponse
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/promotions/{id} [delete]
+func (h *PromotionHandler) DeletePromotion(c *gin.Context) {
+	id, ok := parsePromotionID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.promotionService.DeletePromotion(id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Promotion deleted"})
+}
+
+// respondError maps a promotion service error to its HTTP response
+func (h *PromotionHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Promotion not found"})
+	case errors.Is(err, services.ErrPromotionScope), errors.Is(err, services.ErrPromotionSchedule),
+		errors.Is(err, services.ErrPromotionDiscount), errors.Is(err, services.ErrPromotionTarget):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrPromotionConflict), errors.Is(err, services.ErrPromotionEnded):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// parsePromotionID reads the promotion ID path parameter, responding 400 when invalid
+func parsePromotionID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid promotion ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
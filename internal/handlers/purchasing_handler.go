@@ -0,0 +1,411 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PurchasingHandler handles supplier and purchase order requests
+type PurchasingHandler struct {
+	purchasingService *services.PurchasingService
+}
+
+// NewPurchasingHandler creates a new purchasing handler
+func NewPurchasingHandler(purchasingService *services.PurchasingService) *PurchasingHandler {
+	return &PurchasingHandler{purchasingService: purchasingService}
+}
+
+// CreateSupplier godoc
+// @Summary      Create a supplier
+// @Description  Create a supplier to order stock from (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.SupplierRequest  true  "Supplier"
+// @Success      201      {object}  types.DataResponse[dto.SupplierResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/suppliers [post]
+func (h *PurchasingHandler) CreateSupplier(c *gin.Context) {
+	var req dto.SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	supplier, err := h.purchasingService.CreateSupplier(req)
+	if err != nil {
+		h.respondError(c, err, "Supplier not found")
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Supplier created",
+		Data:    mappers.ToSupplierResponse(supplier),
+	})
+}
+
+// ListSuppliers godoc
+// @Summary      List suppliers
+// @Description  List every supplier (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[[]dto.SupplierResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/suppliers [get]
+func (h *PurchasingHandler) ListSuppliers(c *gin.Context) {
+	suppliers, err := h.purchasingService.ListSuppliers()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.SupplierResponse, len(suppliers))
+	for i := range suppliers {
+		items[i] = mappers.ToSupplierResponse(&suppliers[i])
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    items,
+	})
+}
+
+// GetSupplier godoc
+// @Summary      Get a supplier
+// @Description  Get a supplier by ID (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Supplier ID"
+// @Success      200  {object}  types.DataResponse[dto.SupplierResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/suppliers/{id} [get]
+func (h *PurchasingHandler) GetSupplier(c *gin.Context) {
+	id, ok := parseSupplierID(c)
+	if !ok {
+		return
+	}
+
+	supplier, err := h.purchasingService.GetSupplier(id)
+	if err != nil {
+		h.respondError(c, err, "Supplier not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToSupplierResponse(supplier),
+	})
+}
+
+// UpdateSupplier godoc
+// @Summary      Update a supplier
+// @Description  Replace a supplier's details (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                  true  "Supplier ID"
+// @Param        request  body      dto.SupplierRequest  true  "Supplier"
+// @Success      200      {object}  types.DataResponse[dto.SupplierResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/suppliers/{id} [put]
+func (h *PurchasingHandler) UpdateSupplier(c *gin.Context) {
+	id, ok := parseSupplierID(c)
+	if !ok {
+		return
+	}
+	var req dto.SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	supplier, err := h.purchasingService.UpdateSupplier(id, req)
+	if err != nil {
+		h.respondError(c, err, "Supplier not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Supplier updated",
+		Data:    mappers.ToSupplierResponse(supplier),
+	})
+}
+
+// DeleteSupplier godoc
+// @Summary      Delete a supplier
+// @Description  Delete a supplier that has no open or partially received purchase orders (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Supplier ID"
+// @Success      200  {object}  types.SuccessResponse
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/suppliers/{id} [delete]
+func (h *PurchasingHandler) DeleteSupplier(c *gin.Context) {
+	id, ok := parseSupplierID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.purchasingService.DeleteSupplier(id); err != nil {
+		h.respondError(c, err, "Supplier not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Supplier deleted"})
+}
+
+// CreatePurchaseOrder godoc
+// @Summary      Create a purchase order
+// @Description  Order products from a supplier to restock them (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.CreatePurchaseOrderRequest  true  "Purchase order"
+// @Success      201      {object}  types.DataResponse[dto.PurchaseOrderResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/purchase-orders [post]
+func (h *PurchasingHandler) CreatePurchaseOrder(c *gin.Context) {
+	var req dto.CreatePurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	purchaseOrder, err := h.purchasingService.CreatePurchaseOrder(req, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err, "Supplier not found")
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Purchase order created",
+		Data:    mappers.ToPurchaseOrderResponse(purchaseOrder),
+	})
+}
+
+// ListPurchaseOrders godoc
+// @Summary      List purchase orders
+// @Description  Get a paginated list of purchase orders, newest first, to track open, partially received and received orders (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        status       query     string  false  "Filter by status (open, partial, received, cancelled)"
+// @Param        supplier_id  query     int     false  "Filter by supplier"
+// @Param        page         query     int     false  "Page number" default(1)
+// @Param        page_size    query     int     false  "Page size" default(10)
+// @Success      200          {object}  types.PaginatedResponse
+// @Failure      400          {object}  types.ErrorResponse
+// @Failure      401          {object}  types.ErrorResponse
+// @Failure      403          {object}  types.ErrorResponse
+// @Failure      500          {object}  types.ErrorResponse
+// @Router       /admin/purchase-orders [get]
+func (h *PurchasingHandler) ListPurchaseOrders(c *gin.Context) {
+	var req dto.ListPurchaseOrdersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	pagination := utils.NormalizePagination("purchase_orders", req.Page, req.PageSize)
+
+	purchaseOrders, total, err := h.purchasingService.ListPurchaseOrders(models.PurchaseOrderStatus(req.Status), req.SupplierID, pagination.Page, pagination.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(mappers.ToPurchaseOrderResponses(purchaseOrders), total, pagination))
+}
+
+// GetPurchaseOrder godoc
+// @Summary      Get a purchase order
+// @Description  Get a purchase order with the ordered and received quantity of each product (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Purchase order ID"
+// @Success      200  {object}  types.DataResponse[dto.PurchaseOrderResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/purchase-orders/{id} [get]
+func (h *PurchasingHandler) GetPurchaseOrder(c *gin.Context) {
+	id, ok := parsePurchaseOrderID(c)
+	if !ok {
+		return
+	}
+
+	purchaseOrder, err := h.purchasingService.GetPurchaseOrder(id)
+	if err != nil {
+		h.respondError(c, err, "Purchase order not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToPurchaseOrderResponse(purchaseOrder),
+	})
+}
+
+// ReceivePurchaseOrder godoc
+// @Summary      Receive a delivery
+// @Description  Add delivered quantities of a purchase order to stock, recorded as purchase_order stock movements referencing it. The order becomes partial until every item is received in full (admin only).
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                              true  "Purchase order ID"
+// @Param        request  body      dto.ReceivePurchaseOrderRequest  true  "Delivered quantities"
+// @Success      200      {object}  types.DataResponse[dto.PurchaseOrderResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/purchase-orders/{id}/receive [post]
+func (h *PurchasingHandler) ReceivePurchaseOrder(c *gin.Context) {
+	id, ok := parsePurchaseOrderID(c)
+	if !ok {
+		return
+	}
+	var req dto.ReceivePurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	purchaseOrder, err := h.purchasingService.ReceivePurchaseOrder(id, req, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err, "Purchase order not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Delivery received",
+		Data:    mappers.ToPurchaseOrderResponse(purchaseOrder),
+	})
+}
+
+// CancelPurchaseOrder godoc
+// @Summary      Cancel a purchase order
+// @Description  Close an open or partially received purchase order whose remaining items will not be delivered (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Purchase order ID"
+// @Success      200  {object}  types.DataResponse[dto.PurchaseOrderResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/purchase-orders/{id}/cancel [post]
+func (h *PurchasingHandler) CancelPurchaseOrder(c *gin.Context) {
+	id, ok := parsePurchaseOrderID(c)
+	if !ok {
+		return
+	}
+
+	purchaseOrder, err := h.purchasingService.CancelPurchaseOrder(id)
+	if err != nil {
+		h.respondError(c, err, "Purchase order not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Purchase order cancelled",
+		Data:    mappers.ToPurchaseOrderResponse(purchaseOrder),
+	})
+}
+
+// respondError maps a purchasing service error to its HTTP response
+func (h *PurchasingHandler) respondError(c *gin.Context, err error, notFound string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: notFound})
+	case errors.Is(err, services.ErrPurchaseOrderProduct), errors.Is(err, services.ErrDuplicateOrderItem),
+		errors.Is(err, repositories.ErrNotOnPurchaseOrder), errors.Is(err, repositories.ErrOverReceipt):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrSupplierNameTaken), errors.Is(err, services.ErrSupplierHasOpenOrders),
+		errors.Is(err, repositories.ErrPurchaseOrderClosed):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// parseSupplierID reads the supplier ID path parameter, responding 400 when invalid
+func parseSupplierID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid supplier ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// parsePurchaseOrderID reads the purchase order ID path parameter, responding 400 when invalid
+func parsePurchaseOrderID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid purchase order ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
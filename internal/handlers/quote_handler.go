@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// QuoteHandler handles request-for-quote requests from customers and admins
+type QuoteHandler struct {
+	quoteService       *services.QuoteService
+	customFieldService *services.CustomFieldService
+}
+
+// NewQuoteHandler creates a new quote handler
+func NewQuoteHandler(quoteService *services.QuoteService, customFieldService *services.CustomFieldService) *QuoteHandler {
+	return &QuoteHandler{quoteService: quoteService, customFieldService: customFieldService}
+}
+
+// RequestQuote godoc
+// @Summary      Request a quote
+// @Description  Ask for a custom price on active products in bulk quantities. An admin answers with prices valid until a date. Products not available in the caller's country can't be quoted.
+// @Tags         quotes
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.CreateQuoteRequest  true  "Products and quantities"
+// @Success      201      {object}  types.DataResponse[dto.QuoteResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /quotes [post]
+func (h *QuoteHandler) RequestQuote(c *gin.Context) {
+	var req dto.CreateQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	quote, err := h.quoteService.RequestQuote(c.GetUint("userID"), c.GetString("country"), req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Quote requested",
+		Data:    mappers.ToQuoteResponse(quote),
+	})
+}
+
+// ListMyQuotes godoc
+// @Summary      List my quotes
+// @Description  Get a paginated list of the current user's quotes, newest first
+// @Tags         quotes
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        status     query     string  false  "Filter by status (requested, quoted, accepted, declined)"
+// @Param        page       query     int     false  "Page number" default(1)
+// @Param        page_size  query     int     false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /quotes [get]
+func (h *QuoteHandler) ListMyQuotes(c *gin.Context) {
+	h.listQuotes(c, c.GetUint("userID"))
+}
+
+// GetMyQuote godoc
+// @Summary      Get my quote
+// @Description  Get one of the current user's quotes with its status and quoted prices
+// @Tags         quotes
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Quote ID"
+// @Success      200  {object}  types.DataResponse[dto.QuoteResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /quotes/{id} [get]
+func (h *QuoteHandler) GetMyQuote(c *gin.Context) {
+	h.getQuote(c, c.GetUint("userID"))
+}
+
+// AcceptQuote godoc
+// @Summary      Accept a quote
+// @Description  Accept the prices of one of the current user's quotes before it expires, placing it as an order: its quantities are reserved in stock, the gift card given as gift_card_code and then store credit pay for what they can, and the customer is notified. Values of the checkout form's custom fields are sent as custom_fields and stored on the quote. Products can be gift wrapped, at the gift_wrap_fee store setting per unit added to the total, given a gift message printed on the packing slip, or have their price left off the invoice.
+// @Tags         quotes
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                     true   "Quote ID"
+// @Param        request  body      dto.AcceptQuoteRequest  false  "Checkout form values, gift options and gift card"
+// @Success      200  {object}  types.DataResponse[dto.QuoteResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /quotes/{id}/accept [post]
+func (h *QuoteHandler) AcceptQuote(c *gin.Context) {
+	var req dto.AcceptQuoteRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+	customFields, err := h.customFieldService.CheckValues(models.FormCheckout, req.CustomFields)
+	if err != nil {
+		respondCustomFieldError(c, err)
+		return
+	}
+
+	h.decide(c, func(id, userID uint) (*models.Quote, error) {
+		return h.quoteService.AcceptQuote(id, userID, customFields, req.Gifts, req.GiftCardCode)
+	}, "Quote accepted")
+}
+
+// DeclineQuote godoc
+// @Summary      Decline a quote
+// @Description  Decline one of the current user's quotes, or withdraw it before it is priced
+// @Tags         quotes
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Quote ID"
+// @Success      200  {object}  types.DataResponse[dto.QuoteResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      409  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /quotes/{id}/decline [post]
+func (h *QuoteHandler) DeclineQuote(c *gin.Context) {
+	h.decide(c, h.quoteService.DeclineQuote, "Quote declined")
+}
+
+// ListQuotes godoc
+// @Summary      List quotes
+// @Description  Get a paginated list of every customer's quotes, newest first (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        status     query     string  false  "Filter by status (requested, quoted, accepted, declined)"
+// @Param        page       query     int     false  "Page number" default(1)
+// @Param        page_size  query     int     false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /admin/quotes [get]
+func (h *QuoteHandler) ListQuotes(c *gin.Context) {
+	h.listQuotes(c, 0)
+}
+
+// GetQuote godoc
+// @Summary      Get a quote
+// @Description  Get any customer's quote (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Quote ID"
+// @Success      200  {object}  types.DataResponse[dto.QuoteResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/quotes/{id} [get]
+func (h *QuoteHandler) GetQuote(c *gin.Context) {
+	h.getQuote(c, 0)
+}
+
+// RespondToQuote godoc
+// @Summary      Respond to a quote
+// @Description  Offer a unit price for every product of a requested quote, valid until a date (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                        true  "Quote ID"
+// @Param        request  body      dto.RespondToQuoteRequest  true  "Quoted prices"
+// @Success      200      {object}  types.DataResponse[dto.QuoteResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/quotes/{id}/respond [post]
+func (h *QuoteHandler) RespondToQuote(c *gin.Context) {
+	id, ok := parseQuoteID(c)
+	if !ok {
+		return
+	}
+	var req dto.RespondToQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if !req.ValidUntil.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "valid_until must be in the future"})
+		return
+	}
+
+	quote, err := h.quoteService.RespondToQuote(id, c.GetUint("userID"), req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Quote sent",
+		Data:    mappers.ToQuoteResponse(quote),
+	})
+}
+
+// listQuotes responds with a page of quotes, limited to a user's unless userID is zero
+func (h *QuoteHandler) listQuotes(c *gin.Context, userID uint) {
+	var req dto.ListQuotesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	pagination := utils.NormalizePagination("quotes", req.Page, req.PageSize)
+
+	quotes, total, err := h.quoteService.ListQuotes(userID, models.QuoteStatus(req.Status), pagination.Page, pagination.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(mappers.ToQuoteResponses(quotes), total, pagination))
+}
+
+// getQuote responds with a quote, limited to a user's unless userID is zero
+func (h *QuoteHandler) getQuote(c *gin.Context, userID uint) {
+	id, ok := parseQuoteID(c)
+	if !ok {
+		return
+	}
+
+	quote, err := h.quoteService.GetQuote(id, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToQuoteResponse(quote),
+	})
+}
+
+// decide records the current user's decision on their quote
+func (h *QuoteHandler) decide(c *gin.Context, decide func(id, userID uint) (*models.Quote, error), message string) {
+	id, ok := parseQuoteID(c)
+	if !ok {
+		return
+	}
+
+	quote, err := decide(id, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    mappers.ToQuoteResponse(quote),
+	})
+}
+
+// respondError maps a quote service error to its HTTP response
+func (h *QuoteHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Quote not found"})
+	case errors.Is(err, services.ErrQuoteProduct), errors.Is(err, services.ErrQuotePricing), errors.Is(err, services.ErrDuplicateQuoteItem),
+		errors.Is(err, services.ErrQuoteGiftItem), errors.Is(err, services.ErrQuoteGiftCard):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrQuoteAnswered), errors.Is(err, services.ErrQuoteNotQuoted), errors.Is(err, services.ErrQuoteExpired),
+		errors.Is(err, repositories.ErrGiftCardExpired), errors.Is(err, repositories.ErrGiftCardEmpty):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// parseQuoteID reads the quote ID path parameter, responding 400 when invalid
+func parseQuoteID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid quote ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"product-management/internal/dto"
+	"product-management/internal/types"
+	"product-management/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitHandler lets admins inspect and reset rate limit quotas
+type RateLimitHandler struct {
+	limiter *ratelimit.Limiter
+}
+
+// NewRateLimitHandler creates a new rate limit handler
+func NewRateLimitHandler(limiter *ratelimit.Limiter) *RateLimitHandler {
+	return &RateLimitHandler{limiter: limiter}
+}
+
+// GetQuota godoc
+// @Summary      Inspect a principal's rate limit quota
+// @Description  Get the quota usage of a principal in every route group it used in the current window. Principals are "user:<id>", "ip:<address>" or "key:<api key name>". Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        principal  path      string  true  "Principal, e.g. user:42"
+// @Success      200        {object}  types.DataResponse[dto.RateLimitUsageResponse]
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Router       /admin/rate-limits/{principal} [get]
+func (h *RateLimitHandler) GetQuota(c *gin.Context) {
+	principal := c.Param("principal")
+
+	usages := h.limiter.Usage(principal)
+	response := dto.RateLimitUsageResponse{
+		Principal: principal,
+		Groups:    make([]dto.RateLimitGroupUsage, len(usages)),
+	}
+	for i, usage := range usages {
+		response.Groups[i] = dto.RateLimitGroupUsage{
+			Group:     usage.Group,
+			Tier:      usage.Tier,
+			Limit:     usage.Limit,
+			Used:      usage.Used,
+			Remaining: usage.Remaining(),
+			ResetAt:   dto.NewTime(usage.ResetAt),
+		}
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ResetQuota godoc
+// @Summary      Reset a principal's rate limit quota
+// @Description  Clear the quota usage of a principal in every route group, e.g. after a client bug caused a burst. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        principal  path      string  true  "Principal, e.g. user:42"
+// @Success      200        {object}  types.SuccessResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Router       /admin/rate-limits/{principal} [delete]
+func (h *RateLimitHandler) ResetQuota(c *gin.Context) {
+	reset := h.limiter.Reset(c.Param("principal"))
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: fmt.Sprintf("quota reset in %d route groups", reset)})
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RebuildHandler handles the background rebuilds of derived data
+type RebuildHandler struct {
+	rebuildService *services.RebuildService
+	auditService   *services.AuditService
+}
+
+// NewRebuildHandler creates a new rebuild handler
+func NewRebuildHandler(rebuildService *services.RebuildService, auditService *services.AuditService) *RebuildHandler {
+	return &RebuildHandler{rebuildService: rebuildService, auditService: auditService}
+}
+
+// StartRebuild godoc
+// @Summary      Start a rebuild
+// @Description  Rebuild derived data in the background and return its run. search recomputes the rating summary of every product into a staging table, then swaps the summaries that changed in one transaction; cache recomputes the cached categories with their product counts and the hot products, overwriting them in place, then starts a new generation of cached listings. Live data keeps being served until the swap. One rebuild of each target runs at a time. Recorded in the audit log. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.StartRebuildRequest  true  "Target to rebuild"
+// @Success      202      {object}  types.DataResponse[dto.RebuildRunResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/rebuilds [post]
+func (h *RebuildHandler) StartRebuild(c *gin.Context) {
+	var req dto.StartRebuildRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.auditService.Record(c.GetUint("userID"), requestLocation(c), models.AuditRebuildStart, map[string]interface{}{
+		"target": req.Target,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	run, err := h.rebuildService.Start(req.Target, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, types.APIResponse{
+		Success: true,
+		Message: "Rebuild started",
+		Data:    mappers.ToRebuildRunResponse(run),
+	})
+}
+
+// ListRebuilds godoc
+// @Summary      List rebuild runs
+// @Description  Get the log of rebuild runs, newest first, with their progress and the error of those that failed. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        page       query     int     false  "Page number" default(1)
+// @Param        page_size  query     int     false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /admin/rebuilds [get]
+func (h *RebuildHandler) ListRebuilds(c *gin.Context) {
+	var req dto.ListRebuildRunsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	pagination := utils.NormalizePagination("rebuilds", req.Page, req.PageSize)
+
+	runs, total, err := h.rebuildService.ListRuns(pagination.Page, pagination.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.RebuildRunResponse, len(runs))
+	for i := range runs {
+		items[i] = mappers.ToRebuildRunResponse(&runs[i])
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(items, total, pagination))
+}
+
+// GetRebuild godoc
+// @Summary      Get a rebuild run
+// @Description  Get a rebuild run with its progress, to follow a rebuild until it succeeds or fails. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Rebuild run ID"
+// @Success      200  {object}  types.DataResponse[dto.RebuildRunResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/rebuilds/{id} [get]
+func (h *RebuildHandler) GetRebuild(c *gin.Context) {
+	id, ok := parseRebuildRunID(c)
+	if !ok {
+		return
+	}
+
+	run, err := h.rebuildService.GetRun(id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToRebuildRunResponse(run),
+	})
+}
+
+// respondError maps a rebuild service error to its HTTP response
+func (h *RebuildHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrUnknownRebuildTarget):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrRebuildRunNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Rebuild run not found"})
+	case errors.Is(err, services.ErrRebuildRunning):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// parseRebuildRunID reads the rebuild run ID path parameter, responding 400 when invalid
+func parseRebuildRunID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid rebuild run ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReferralHandler handles referral program requests
+type ReferralHandler struct {
+	referralService *services.ReferralService
+}
+
+// NewReferralHandler creates a new referral handler
+func NewReferralHandler(referralService *services.ReferralService) *ReferralHandler {
+	return &ReferralHandler{referralService: referralService}
+}
+
+// GetReferrals godoc
+// @Summary      Get referrals
+// @Description  Get the current user's referral code, created on first use, and the users who signed up with it. The referrer earns store credit when a referred user completes their first order.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[dto.ReferralsResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /auth/referrals [get]
+func (h *ReferralHandler) GetReferrals(c *gin.Context) {
+	userID := c.GetUint("userID")
+	code, err := h.referralService.GetReferralCode(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	referrals, err := h.referralService.ListReferrals(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response := dto.ReferralsResponse{
+		ReferralCode: code,
+		RewardAmount: h.referralService.RewardAmount(),
+		Referrals:    make([]dto.ReferralResponse, len(referrals)),
+	}
+	for i := range referrals {
+		response.Referrals[i] = mappers.ToReferralResponse(&referrals[i])
+	}
+	response.TotalRewarded, err = h.referralService.TotalRewarded(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
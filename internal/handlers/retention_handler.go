@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RetentionHandler handles the data retention policies
+type RetentionHandler struct {
+	retentionService *services.RetentionService
+}
+
+// NewRetentionHandler creates a new retention handler
+func NewRetentionHandler(retentionService *services.RetentionService) *RetentionHandler {
+	return &RetentionHandler{retentionService: retentionService}
+}
+
+// GetPolicies godoc
+// @Summary      Get data retention policies
+// @Description  List the entities retention rules can apply to, the maximum age configured for each and the statistics of its last cleanup run. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[dto.RetentionResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/retention [get]
+func (h *RetentionHandler) GetPolicies(c *gin.Context) {
+	policies, err := h.retentionService.GetPolicies()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    policies,
+	})
+}
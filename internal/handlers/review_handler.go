@@ -3,13 +3,14 @@ package handlers
 import (
 	"net/http"
 	"strconv"
-	"time"
 
 	"product-management/internal/dto"
+	"product-management/internal/mappers"
 	"product-management/internal/models"
 	"product-management/internal/services"
 	"product-management/internal/types"
 	"product-management/pkg/logger"
+	"product-management/pkg/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
@@ -44,7 +45,7 @@ func (h *ReviewHandler) CreateReview(c *gin.Context) {
 	var req dto.CreateReviewRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, types.ErrorResponse{
-			Error: "Invalid request body",
+			Error: "Invalid request body: " + err.Error(),
 		})
 		return
 	}
@@ -88,15 +89,7 @@ func (h *ReviewHandler) CreateReview(c *gin.Context) {
 		return
 	}
 
-	response := dto.ReviewResponse{
-		ID:        review.ID,
-		UserID:    review.UserID,
-		ProductID: review.ProductID,
-		Rating:    review.Rating,
-		Comment:   review.Comment,
-		CreatedAt: review.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: review.UpdatedAt.Format(time.RFC3339),
-	}
+	response := mappers.ToReviewResponse(review)
 
 	logger.WithFields(logrus.Fields{
 		"review_id":  review.ID,
@@ -115,7 +108,7 @@ func (h *ReviewHandler) CreateReview(c *gin.Context) {
 // @Produce      json
 // @Security     Bearer
 // @Param        id   path      int  true  "Review ID"
-// @Success      200  {object}  models.Review
+// @Success      200  {object}  dto.ReviewResponse
 // @Failure      400  {object}  types.ErrorResponse
 // @Failure      404  {object}  types.ErrorResponse
 // @Router       /reviews/{id} [get]
@@ -144,7 +137,92 @@ func (h *ReviewHandler) GetReviewByID(c *gin.Context) {
 		"review_id": review.ID,
 	}).Info("Review retrieved successfully")
 
-	c.JSON(http.StatusOK, review)
+	c.JSON(http.StatusOK, mappers.ToReviewResponse(review))
+}
+
+// GetReviewsByUserID godoc
+// @Summary      List a user's reviews
+// @Description  Get a paginated list of reviews written by a user. Reviews of inactive products are only visible to the user themselves or an admin; other callers get a trimmed response.
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        userId     path      int  true   "User ID"
+// @Param        page       query     int  false  "Page number" default(1)
+// @Param        page_size  query     int  false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /reviews/user/{userId} [get]
+func (h *ReviewHandler) GetReviewsByUserID(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid user ID"})
+		return
+	}
+
+	var req dto.UserReviewListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid query parameters"})
+		return
+	}
+
+	// Only the owner or an admin may see reviews of hidden products
+	privileged := c.GetUint("userID") == uint(userID) || c.GetString("role") == string(models.RoleAdmin)
+
+	pagination := utils.NormalizePagination("user_reviews", req.Page, req.PageSize)
+
+	reviews, total, err := h.reviewService.ListReviewsByUserID(uint(userID), pagination.Page, pagination.Limit, privileged)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "Failed to list user reviews"})
+		return
+	}
+
+	var items interface{}
+	if privileged {
+		items = mappers.ToReviewResponses(reviews)
+	} else {
+		publicItems := make([]dto.PublicReviewResponse, len(reviews))
+		for i := range reviews {
+			publicItems[i] = mappers.ToPublicReviewResponse(&reviews[i])
+		}
+		items = publicItems
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(items, total, pagination))
+}
+
+// GetUserReviewStats godoc
+// @Summary      Get a user's review statistics
+// @Description  Get the review count, average rating given and rating distribution of a user. Reviews of inactive products only count for the user themselves or an admin.
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  types.DataResponse[dto.UserReviewStatsResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /users/{id}/review-stats [get]
+func (h *ReviewHandler) GetUserReviewStats(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid user ID"})
+		return
+	}
+
+	privileged := c.GetUint("userID") == uint(userID) || c.GetString("role") == string(models.RoleAdmin)
+
+	stats, err := h.reviewService.GetUserReviewStats(uint(userID), privileged)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "Failed to get user review statistics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    stats,
+	})
 }
 
 // DeleteReview godoc
@@ -155,20 +233,19 @@ func (h *ReviewHandler) GetReviewByID(c *gin.Context) {
 // @Produce      json
 // @Security     Bearer
 // @Param        id   path      int  true  "Review ID"
-// @Success      204  {object}  types.SuccessResponse
+// @Success      204  "No Content"
 // @Failure      400  {object}  types.ErrorResponse
-// @Failure      404  {object}  types.ErrorResponse
 // @Failure      500  {object}  types.ErrorResponse
 // @Router       /reviews/{id} [delete]
 func (h *ReviewHandler) DeleteReview(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid review ID"})
 		return
 	}
 
 	if err := h.reviewService.DeleteReview(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
 		return
 	}
 
@@ -200,9 +277,11 @@ func (h *ReviewHandler) SearchReviews(c *gin.Context) {
 		return
 	}
 
+	pagination := utils.NormalizePagination("reviews", req.Page, req.PageSize)
+
 	reviews, total, err := h.reviewService.SearchReviews(
-		req.Page,
-		req.PageSize,
+		pagination.Page,
+		pagination.Limit,
 		req.ProductName,
 		req.SortBy,
 		req.Order,
@@ -215,45 +294,22 @@ func (h *ReviewHandler) SearchReviews(c *gin.Context) {
 	}
 
 	// Convert reviews to response format
-	items := make([]dto.ReviewResponse, len(reviews))
-	for i, review := range reviews {
-		items[i] = dto.ReviewResponse{
-			ID:        review.ID,
-			UserID:    review.UserID,
-			ProductID: review.ProductID,
-			Rating:    review.Rating,
-			Comment:   review.Comment,
-			CreatedAt: review.CreatedAt.Format(time.RFC3339),
-			UpdatedAt: review.UpdatedAt.Format(time.RFC3339),
-			User: &dto.UserOutput{
-				ID:       review.User.ID,
-				Username: review.User.Username,
-				Email:    review.User.Email,
-				FullName: review.User.FullName,
-			},
-			Product: &dto.ProductResponse{
-				ID:          review.Product.ID,
-				Name:        review.Product.Name,
-				Description: review.Product.Description,
-				Price:       review.Product.Price,
-				Quantity:    review.Product.StockQuantity,
-				Status:      string(review.Product.Status),
-			},
-		}
-	}
+	items := mappers.ToReviewResponses(reviews)
 
 	// Calculate total pages
-	totalPages := int(total) / req.PageSize
-	if int(total)%req.PageSize > 0 {
+	totalPages := int(total) / pagination.Limit
+	if int(total)%pagination.Limit > 0 {
 		totalPages++
 	}
 
 	response := dto.ReviewListResponse{
-		Items:      items,
-		Total:      total,
-		Page:       req.Page,
-		PageSize:   req.PageSize,
-		TotalPages: totalPages,
+		Items:           items,
+		Total:           total,
+		Page:            pagination.Page,
+		PageSize:        pagination.Limit,
+		TotalPages:      totalPages,
+		DefaultPageSize: pagination.DefaultLimit,
+		MaxPageSize:     pagination.MaxLimit,
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -265,7 +321,7 @@ func (h *ReviewHandler) SearchReviews(c *gin.Context) {
 // @Tags         reviews
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  types.APIResponse
+// @Success      200  {object}  types.DataResponse[dto.ReviewCountResponse]
 // @Failure      500  {object}  types.ErrorResponse
 // @Security     Bearer
 // @Router       /reviews/count [get]
@@ -293,9 +349,9 @@ func (h *ReviewHandler) GetTotalReviews(c *gin.Context) {
 	c.JSON(http.StatusOK, types.APIResponse{
 		Success: true,
 		Message: "Total reviews retrieved successfully",
-		Data: gin.H{
-			"total_reviews":   count,
-			"my_review_count": myReviewCount,
+		Data: dto.ReviewCountResponse{
+			TotalReviews:  count,
+			MyReviewCount: myReviewCount,
 		},
 	})
 }
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// maxReviewImportSize is the largest review import body accepted
+const maxReviewImportSize = 10 << 20
+
+// ReviewImportHandler handles review imports from other store platforms
+type ReviewImportHandler struct {
+	importService *services.ReviewImportService
+	auditService  *services.AuditService
+}
+
+// NewReviewImportHandler creates a new review import handler
+func NewReviewImportHandler(importService *services.ReviewImportService, auditService *services.AuditService) *ReviewImportHandler {
+	return &ReviewImportHandler{importService: importService, auditService: auditService}
+}
+
+// ImportReviews godoc
+// @Summary      Import reviews
+// @Description  Import up to 5000 reviews exported from another store platform, as a JSON array of rows or a CSV file whose header names the same columns (admin only). Each external user gets a placeholder account that cannot sign in, reused across imports from the same source. Reviews are flagged as imported and keep their original dates. Invalid rows, including reviews the placeholder already wrote, are listed in errors while the other rows are still imported. With dry_run nothing is saved.
+// @Tags         admin
+// @Accept       json
+// @Accept       text/csv
+// @Produce      json
+// @Security     Bearer
+// @Param        source   query     string                 true   "Source platform, e.g. shopify"
+// @Param        dry_run  query     bool                   false  "Validate the rows without saving them"
+// @Param        request  body      []dto.ReviewImportRow  true   "Reviews"
+// @Success      200      {object}  types.DataResponse[dto.ReviewImportResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      413      {object}  types.ErrorResponse
+// @Failure      415      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/reviews/import [post]
+func (h *ReviewImportHandler) ImportReviews(c *gin.Context) {
+	var req dto.ReviewImportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	var isCSV bool
+	switch c.ContentType() {
+	case binding.MIMEJSON:
+	case "text/csv":
+		isCSV = true
+	default:
+		c.JSON(http.StatusUnsupportedMediaType, types.ErrorResponse{Error: "Content-Type must be application/json or text/csv"})
+		return
+	}
+
+	if !req.DryRun {
+		if err := h.auditService.Record(c.GetUint("userID"), requestLocation(c), models.AuditReviewImport, map[string]interface{}{
+			"source": req.Source,
+		}); err != nil {
+			c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+			return
+		}
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxReviewImportSize)
+	result, err := h.importService.Import(req.Source, body, isCSV, req.DryRun)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, types.ErrorResponse{Error: "Import file is larger than 10 MB"})
+		case errors.Is(err, services.ErrInvalidImportSource), errors.Is(err, services.ErrInvalidImportFile),
+			errors.Is(err, services.ErrEmptyImport), errors.Is(err, services.ErrTooManyImportRows):
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	message := "Reviews imported"
+	if result.DryRun {
+		message = "Dry run, nothing was imported"
+	}
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    result,
+	})
+}
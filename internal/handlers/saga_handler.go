@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SagaHandler handles the sagas running operations such as order placement
+type SagaHandler struct {
+	sagaService  *services.SagaService
+	auditService *services.AuditService
+}
+
+// NewSagaHandler creates a new saga handler
+func NewSagaHandler(sagaService *services.SagaService, auditService *services.AuditService) *SagaHandler {
+	return &SagaHandler{sagaService: sagaService, auditService: auditService}
+}
+
+// ListSagas godoc
+// @Summary      List sagas
+// @Description  Get the sagas placing orders, newest first, with the state of each step and why a saga is compensating or failed. Filter by reference, e.g. Q-12, to follow the order of a quote. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        status     query     string  false  "Saga status" Enums(running, completed, compensating, compensated, failed)
+// @Param        type       query     string  false  "Saga type" Enums(order_placement)
+// @Param        reference  query     string  false  "What the saga acts on, e.g. Q-12"
+// @Param        page       query     int     false  "Page number" default(1)
+// @Param        page_size  query     int     false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /admin/sagas [get]
+func (h *SagaHandler) ListSagas(c *gin.Context) {
+	var req dto.ListSagasRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	pagination := utils.NormalizePagination("sagas", req.Page, req.PageSize)
+
+	sagas, total, err := h.sagaService.ListSagas(models.SagaStatus(req.Status), models.SagaType(req.Type), req.Reference, pagination.Page, pagination.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.SagaResponse, len(sagas))
+	for i := range sagas {
+		items[i] = mappers.ToSagaResponse(&sagas[i])
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(items, total, pagination))
+}
+
+// GetSaga godoc
+// @Summary      Get a saga
+// @Description  Get a saga with its state and the state of each step. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Saga ID"
+// @Success      200  {object}  types.DataResponse[dto.SagaResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/sagas/{id} [get]
+func (h *SagaHandler) GetSaga(c *gin.Context) {
+	id, ok := parseSagaID(c)
+	if !ok {
+		return
+	}
+
+	saga, err := h.sagaService.GetSaga(id)
+	if err != nil {
+		h.respondError(c, err, "Saga not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToSagaResponse(saga),
+	})
+}
+
+// RetrySaga godoc
+// @Summary      Retry a failed saga
+// @Description  Resume undoing the completed steps of a saga whose compensation kept failing, with a fresh set of attempts, once its cause is fixed. Recorded in the audit log. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Saga ID"
+// @Success      200  {object}  types.DataResponse[dto.SagaResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/sagas/{id}/retry [post]
+func (h *SagaHandler) RetrySaga(c *gin.Context) {
+	id, ok := parseSagaID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.auditService.Record(c.GetUint("userID"), requestLocation(c), models.AuditSagaRetry, map[string]interface{}{
+		"saga_id": id,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	saga, err := h.sagaService.RetrySaga(id)
+	if err != nil {
+		h.respondError(c, err, "Failed saga not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Saga queued for compensation",
+		Data:    mappers.ToSagaResponse(saga),
+	})
+}
+
+// respondError maps a saga service error to its HTTP response
+func (h *SagaHandler) respondError(c *gin.Context, err error, notFound string) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: notFound})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+}
+
+// parseSagaID reads the saga ID path parameter, responding 400 when invalid
+func parseSagaID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid saga ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
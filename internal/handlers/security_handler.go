@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityHandler handles suspicious activity alerts and IP blocks
+type SecurityHandler struct {
+	securityService *services.SecurityService
+}
+
+// NewSecurityHandler creates a new security handler
+func NewSecurityHandler(securityService *services.SecurityService) *SecurityHandler {
+	return &SecurityHandler{securityService: securityService}
+}
+
+// ListAlerts godoc
+// @Summary      List security alerts
+// @Description  Get the suspicious activity detected so far, newest first: many accounts failing to sign in from one IP, bulk product deletions and promotions to admin. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        kind       query     string  false  "Alert kind" Enums(failed_logins, bulk_deletion, privilege_escalation)
+// @Param        page       query     int     false  "Page number" default(1)
+// @Param        page_size  query     int     false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /admin/security/alerts [get]
+func (h *SecurityHandler) ListAlerts(c *gin.Context) {
+	var req dto.ListSecurityAlertsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	pagination := utils.NormalizePagination("security_alerts", req.Page, req.PageSize)
+
+	alerts, total, err := h.securityService.ListAlerts(req.Kind, pagination.Page, pagination.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.SecurityAlertResponse, len(alerts))
+	for i := range alerts {
+		items[i] = mappers.ToSecurityAlertResponse(&alerts[i])
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(items, total, pagination))
+}
+
+// ListBlocks godoc
+// @Summary      List blocked IPs
+// @Description  Get the IP addresses blocked automatically after suspicious activity whose block has not expired. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[[]dto.IPBlockResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/security/blocks [get]
+func (h *SecurityHandler) ListBlocks(c *gin.Context) {
+	blocks, err := h.securityService.ListBlocks()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.IPBlockResponse, len(blocks))
+	for i := range blocks {
+		items[i] = mappers.ToIPBlockResponse(&blocks[i])
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    items,
+	})
+}
+
+// Unblock godoc
+// @Summary      Unblock an IP
+// @Description  Lift the block of an IP address. Other instances may keep rejecting it for up to 30 seconds. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        ip   path      string  true  "IP address"
+// @Success      200  {object}  types.SuccessResponse
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/security/blocks/{ip} [delete]
+func (h *SecurityHandler) Unblock(c *gin.Context) {
+	ip := net.ParseIP(c.Param("ip"))
+	if ip == nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "invalid IP address"})
+		return
+	}
+
+	if err := h.securityService.Unblock(ip.String()); err != nil {
+		if errors.Is(err, services.ErrIPNotBlocked) {
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "IP unblocked"})
+}
@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SegmentHandler handles customer segment requests
+type SegmentHandler struct {
+	segmentService *services.SegmentService
+	auditService   *services.AuditService
+}
+
+// NewSegmentHandler creates a new segment handler
+func NewSegmentHandler(segmentService *services.SegmentService, auditService *services.AuditService) *SegmentHandler {
+	return &SegmentHandler{segmentService: segmentService, auditService: auditService}
+}
+
+// CreateSegment godoc
+// @Summary      Create a segment
+// @Description  Define a customer segment as rules that members must all match (admin only). Rules filter on role, wishlist_size, review_count, days_since_signup and days_since_last_login.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      dto.SegmentRequest  true  "Segment"
+// @Success      201      {object}  types.DataResponse[dto.SegmentResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/segments [post]
+func (h *SegmentHandler) CreateSegment(c *gin.Context) {
+	var req dto.SegmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	segment, err := h.segmentService.CreateSegment(req, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Segment created",
+		Data:    mappers.ToSegmentResponse(segment),
+	})
+}
+
+// ListSegments godoc
+// @Summary      List segments
+// @Description  List every customer segment (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[[]dto.SegmentResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/segments [get]
+func (h *SegmentHandler) ListSegments(c *gin.Context) {
+	segments, err := h.segmentService.ListSegments()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.SegmentResponse, len(segments))
+	for i := range segments {
+		items[i] = mappers.ToSegmentResponse(&segments[i])
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    items,
+	})
+}
+
+// GetSegment godoc
+// @Summary      Get a segment
+// @Description  Get a customer segment by ID (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Segment ID"
+// @Success      200  {object}  types.DataResponse[dto.SegmentResponse]
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/segments/{id} [get]
+func (h *SegmentHandler) GetSegment(c *gin.Context) {
+	id, ok := parseSegmentID(c)
+	if !ok {
+		return
+	}
+
+	segment, err := h.segmentService.GetSegment(id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    mappers.ToSegmentResponse(segment),
+	})
+}
+
+// UpdateSegment godoc
+// @Summary      Update a segment
+// @Description  Replace the name, description and rules of a customer segment (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                 true  "Segment ID"
+// @Param        request  body      dto.SegmentRequest  true  "Segment"
+// @Success      200      {object}  types.DataResponse[dto.SegmentResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/segments/{id} [put]
+func (h *SegmentHandler) UpdateSegment(c *gin.Context) {
+	id, ok := parseSegmentID(c)
+	if !ok {
+		return
+	}
+	var req dto.SegmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	segment, err := h.segmentService.UpdateSegment(id, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Segment updated",
+		Data:    mappers.ToSegmentResponse(segment),
+	})
+}
+
+// DeleteSegment godoc
+// @Summary      Delete a segment
+// @Description  Delete a customer segment (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Segment ID"
+// @Success      200  {object}  types.SuccessResponse
+// @Failure      400  {object}  types.ErrorResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/segments/{id} [delete]
+func (h *SegmentHandler) DeleteSegment(c *gin.Context) {
+	id, ok := parseSegmentID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.segmentService.DeleteSegment(id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Segment deleted"})
+}
+
+// ListSegmentMembers godoc
+// @Summary      List segment members
+// @Description  Get a paginated list of the users currently matching a segment's rules (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id         path      int  true   "Segment ID"
+// @Param        page       query     int  false  "Page number" default(1)
+// @Param        page_size  query     int  false  "Page size" default(10)
+// @Success      200        {object}  types.DataResponse[types.UserListResponse]
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Failure      404        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /admin/segments/{id}/members [get]
+func (h *SegmentHandler) ListSegmentMembers(c *gin.Context) {
+	id, ok := parseSegmentID(c)
+	if !ok {
+		return
+	}
+	var req dto.ListSegmentMembersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	pagination := utils.NormalizePagination("segment_members", req.Page, req.PageSize)
+
+	members, total, err := h.segmentService.ListMembers(id, pagination.Page, pagination.Limit)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    types.NewUserListResponse(mappers.ToUserResponses(members), total, pagination),
+	})
+}
+
+// NotifySegment godoc
+// @Summary      Notify a segment
+// @Description  Send a promotion to every member of a segment by email and push, in the background (admin only). Members who turned off promotions in their notification settings are skipped. Every send is recorded in the audit log.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                       true  "Segment ID"
+// @Param        request  body      dto.NotifySegmentRequest  true  "Notification"
+// @Success      202      {object}  types.DataResponse[dto.NotifySegmentResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/segments/{id}/notify [post]
+func (h *SegmentHandler) NotifySegment(c *gin.Context) {
+	id, ok := parseSegmentID(c)
+	if !ok {
+		return
+	}
+	var req dto.NotifySegmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if _, err := h.segmentService.GetSegment(id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+	if err := h.auditService.Record(c.GetUint("userID"), requestLocation(c), models.AuditSegmentNotify, map[string]interface{}{
+		"segment_id": id,
+		"title":      req.Title,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: "failed to record audit log"})
+		return
+	}
+
+	recipients, err := h.segmentService.NotifySegment(id, req.Title, req.Message)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, types.APIResponse{
+		Success: true,
+		Message: "Notification queued",
+		Data:    dto.NotifySegmentResponse{Recipients: recipients},
+	})
+}
+
+// respondError maps a segment service error to its HTTP response
+func (h *SegmentHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Segment not found"})
+	case errors.Is(err, services.ErrInvalidSegmentRule):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrSegmentNameTaken):
+		c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// parseSegmentID reads the segment ID path parameter, responding 400 when invalid
+func parseSegmentID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid segment ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SettingHandler handles store setting requests
+type SettingHandler struct {
+	settingService *services.SettingService
+}
+
+// NewSettingHandler creates a new setting handler
+func NewSettingHandler(settingService *services.SettingService) *SettingHandler {
+	return &SettingHandler{settingService: settingService}
+}
+
+// ListSettings godoc
+// @Summary      List store settings
+// @Description  List every store setting with its type, default and value in effect (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[[]dto.SettingResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/settings [get]
+func (h *SettingHandler) ListSettings(c *gin.Context) {
+	settings, err := h.settingService.ListSettings()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.SettingResponse, len(settings))
+	for i := range settings {
+		items[i] = settingResponse(&settings[i])
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    items,
+	})
+}
+
+// GetSetting godoc
+// @Summary      Get a store setting
+// @Description  Get a store setting with its type, default and value in effect (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        key  path      string  true  "Setting key" Enums(store_name, logo_url, support_email, currency, order_number_prefix)
+// @Success      200  {object}  types.DataResponse[dto.SettingResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/settings/{key} [get]
+func (h *SettingHandler) GetSetting(c *gin.Context) {
+	setting, err := h.settingService.GetSetting(models.SettingKey(c.Param("key")))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    settingResponse(setting),
+	})
+}
+
+// UpdateSetting godoc
+// @Summary      Set a store setting
+// @Description  Set the value of a store setting, validated against its type: text up to 100 characters, an http or https URL, an email address, a supported currency code, or a prefix of up to 10 letters, digits and dashes. URLs and email addresses may be empty (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        key      path      string                    true  "Setting key" Enums(store_name, logo_url, support_email, currency, order_number_prefix)
+// @Param        request  body      dto.UpdateSettingRequest  true  "Value"
+// @Success      200      {object}  types.DataResponse[dto.SettingResponse]
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/settings/{key} [put]
+func (h *SettingHandler) UpdateSetting(c *gin.Context) {
+	var req dto.UpdateSettingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	setting, err := h.settingService.UpdateSetting(models.SettingKey(c.Param("key")), *req.Value, c.GetUint("userID"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Setting updated",
+		Data:    settingResponse(setting),
+	})
+}
+
+// ResetSetting godoc
+// @Summary      Reset a store setting
+// @Description  Remove the value set for a store setting, so its default applies again (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Param        key  path      string  true  "Setting key" Enums(store_name, logo_url, support_email, currency, order_number_prefix)
+// @Success      200  {object}  types.SuccessResponse
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      404  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/settings/{key} [delete]
+func (h *SettingHandler) ResetSetting(c *gin.Context) {
+	if err := h.settingService.ResetSetting(models.SettingKey(c.Param("key"))); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Setting reset to its default"})
+}
+
+// respondError maps a setting service error to its HTTP response
+func (h *SettingHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrUnknownSetting):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Setting not found"})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Setting has no value set"})
+	case errors.Is(err, services.ErrInvalidSetting):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+	}
+}
+
+func settingResponse(setting *services.StoreSetting) dto.SettingResponse {
+	response := dto.SettingResponse{
+		Key:     string(setting.Key),
+		Type:    setting.Type,
+		Value:   setting.Value,
+		Default: setting.Default,
+		Custom:  setting.Set != nil,
+	}
+	if setting.Set != nil {
+		updatedAt := dto.NewTime(setting.Set.UpdatedAt)
+		response.UpdatedBy = &setting.Set.UpdatedBy
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
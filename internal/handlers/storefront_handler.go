@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StorefrontHandler handles the storefront bootstrap and static bundle
+type StorefrontHandler struct {
+	storefrontService *services.StorefrontService
+	exportService     *services.StorefrontExportService
+}
+
+// NewStorefrontHandler creates a new storefront handler
+func NewStorefrontHandler(storefrontService *services.StorefrontService, exportService *services.StorefrontExportService) *StorefrontHandler {
+	return &StorefrontHandler{storefrontService: storefrontService, exportService: exportService}
+}
+
+// Bootstrap godoc
+// @Summary      Load the storefront's first page
+// @Description  Get the store settings, categories, featured products, current user, cart count and wishlist count in one call. Sections are loaded concurrently; one that fails is null and its error is listed in errors, while the others are still returned. Featured products are the live curated featured products sold in the caller's country, or the highest-rated active ones while none are live, priced for the user.
+// @Tags         storefront
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[dto.StorefrontBootstrapResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Router       /storefront/bootstrap [get]
+func (h *StorefrontHandler) Bootstrap(c *gin.Context) {
+	response := h.storefrontService.Bootstrap(c.GetUint("userID"), productRegion(c))
+
+	// Featured prices and the user summary are the caller's own
+	c.Header("Cache-Control", "private")
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ExportBundle godoc
+// @Summary      Regenerate the storefront bundle
+// @Description  Write every active product, its images and the categories to storage as static JSON files under storefront/, and delete the files of products that are no longer active. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  types.DataResponse[dto.StorefrontExportResponse]
+// @Failure      401  {object}  types.ErrorResponse
+// @Failure      403  {object}  types.ErrorResponse
+// @Failure      500  {object}  types.ErrorResponse
+// @Router       /admin/storefront/export [post]
+func (h *StorefrontHandler) ExportBundle(c *gin.Context) {
+	result, err := h.exportService.Export(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Message: "Storefront bundle regenerated",
+		Data:    result,
+	})
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/services"
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SyncHandler handles the delta sync of offline-capable clients
+type SyncHandler struct {
+	syncService      *services.SyncService
+	priceListService *services.PriceListService
+}
+
+// NewSyncHandler creates a new sync handler
+func NewSyncHandler(syncService *services.SyncService, priceListService *services.PriceListService) *SyncHandler {
+	return &SyncHandler{syncService: syncService, priceListService: priceListService}
+}
+
+// GetChanges godoc
+// @Summary      Sync changes
+// @Description  Get the changes to the catalog and to the current user's wishlist after a cursor, oldest first, for offline-capable clients to sync incrementally. Each record appears once, with its latest operation and its current state as the payload; products no longer available in the user's market come as deletions. Without a cursor, no changes are returned, only the cursor to sync from after a full refresh. Sync again with the returned cursor, right away while has_more is true. Changes from the last few seconds are held back until no earlier transaction can still commit. There are no carts to sync. Responds 410 when the changes after the cursor were removed by retention, in which case refresh fully and sync without a cursor.
+// @Tags         sync
+// @Produce      json
+// @Security     Bearer
+// @Param        cursor  query     string  false  "Cursor returned by the previous sync"
+// @Success      200     {object}  types.DataResponse[dto.SyncChangesResponse]
+// @Failure      400     {object}  types.ErrorResponse
+// @Failure      401     {object}  types.ErrorResponse
+// @Failure      410     {object}  types.ErrorResponse
+// @Failure      500     {object}  types.ErrorResponse
+// @Router       /sync/changes [get]
+func (h *SyncHandler) GetChanges(c *gin.Context) {
+	var req dto.SyncChangesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	var cursor uint64
+	if req.Cursor != "" {
+		var err error
+		if cursor, err = strconv.ParseUint(req.Cursor, 10, 32); err != nil {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid cursor"})
+			return
+		}
+	}
+
+	batch, err := h.syncService.Changes(c.GetUint("userID"), uint(cursor))
+	if err != nil {
+		if errors.Is(err, services.ErrSyncCursorExpired) {
+			c.JSON(http.StatusGone, types.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	pricing, ok := customerPricing(c, h.priceListService)
+	if !ok {
+		return
+	}
+
+	response := dto.SyncChangesResponse{
+		Changes: make([]dto.SyncChangeResponse, 0, len(batch.Changes)),
+		Cursor:  strconv.FormatUint(uint64(batch.Cursor), 10),
+		HasMore: batch.HasMore,
+	}
+	var products []*dto.ProductResponse
+	for _, change := range batch.Changes {
+		entry := dto.SyncChangeResponse{
+			EntityType: string(change.EntityType),
+			EntityID:   change.EntityID,
+			Operation:  string(models.SyncDelete),
+			Cursor:     strconv.FormatUint(uint64(change.ID), 10),
+		}
+		if change.Operation != models.SyncDelete {
+			switch change.EntityType {
+			case models.SyncEntityProduct:
+				if product, ok := batch.Products[change.EntityID]; ok && availableToCaller(c, product) {
+					output := mappers.ToProductResponse(product)
+					entry.Payload = &dto.SyncPayload{Product: &output}
+					products = append(products, &output)
+				}
+			case models.SyncEntityCategory:
+				if category, ok := batch.Categories[change.EntityID]; ok {
+					entry.Payload = &dto.SyncPayload{Category: &dto.SyncCategory{
+						ID:          category.ID,
+						Name:        category.Name,
+						Description: category.Description,
+					}}
+				}
+			case models.SyncEntityWishlist:
+				if item, ok := batch.Wishlist[change.EntityID]; ok {
+					output := mappers.ToWishlistItemResponse(item)
+					entry.Payload = &dto.SyncPayload{WishlistItem: &output}
+					products = append(products, &output.Product)
+				}
+			}
+			if entry.Payload != nil {
+				entry.Operation = string(models.SyncUpsert)
+			}
+		}
+		response.Changes = append(response.Changes, entry)
+	}
+
+	// Price the payloads in place, as CustomerPricing.Apply works on a slice
+	priced := make([]dto.ProductResponse, len(products))
+	for i, product := range products {
+		priced[i] = *product
+	}
+	pricing.Apply(priced)
+	for i, product := range products {
+		*product = priced[i]
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
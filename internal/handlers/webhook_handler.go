@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/services"
+	"product-management/internal/types"
+	"product-management/pkg/utils"
+	"product-management/pkg/webhook"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// WebhookHandler lets integrations manage their webhook subscriptions
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+// CreateWebhook godoc
+// @Summary      Subscribe to events
+// @Description  Subscribe an endpoint of the calling integration to events about the orders of the customer its key is linked to and the products that customer ordered, optionally limited to some of those products. The URL must be https and resolve to a public address. Deliveries are signed with the returned secret, which is not shown again.
+// @Tags         integrations
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header    string                    true  "Integration API key"
+// @Param        request    body      dto.CreateWebhookRequest  true  "Subscription"
+// @Success      201        {object}  types.DataResponse[dto.CreateWebhookResponse]
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      403        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /integrations/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req dto.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(c.GetString("apiKeyName"), req)
+	switch {
+	case errors.Is(err, webhook.ErrForbiddenURL):
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	case errors.Is(err, services.ErrAPIKeyNotLinked), errors.Is(err, services.ErrWebhookProductIDs):
+		c.JSON(http.StatusForbidden, types.ErrorResponse{Error: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.APIResponse{
+		Success: true,
+		Message: "Webhook subscription created",
+		Data: dto.CreateWebhookResponse{
+			WebhookSubscriptionResponse: mappers.ToWebhookSubscriptionResponse(subscription),
+			Secret:                      subscription.Secret,
+		},
+	})
+}
+
+// ListWebhooks godoc
+// @Summary      List webhook subscriptions
+// @Description  List the webhook subscriptions of the calling integration
+// @Tags         integrations
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header    string  true  "Integration API key"
+// @Success      200        {object}  types.DataResponse[[]dto.WebhookSubscriptionResponse]
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /integrations/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	subscriptions, err := h.webhookService.ListSubscriptions(c.GetString("apiKeyName"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.WebhookSubscriptionResponse, len(subscriptions))
+	for i := range subscriptions {
+		items[i] = mappers.ToWebhookSubscriptionResponse(&subscriptions[i])
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    items,
+	})
+}
+
+// DeleteWebhook godoc
+// @Summary      Delete a webhook subscription
+// @Description  Stop delivering events to a subscription of the calling integration
+// @Tags         integrations
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header    string  true  "Integration API key"
+// @Param        id         path      int     true  "Subscription ID"
+// @Success      200        {object}  types.SuccessResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      404        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /integrations/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid subscription ID"})
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(c.GetString("apiKeyName"), uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Subscription not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "Webhook subscription deleted"})
+}
+
+// ListWebhookDeliveries godoc
+// @Summary      List webhook deliveries
+// @Description  Get the paginated delivery log of a subscription of the calling integration, newest first. Each retry of an event is a separate entry sharing its event_id.
+// @Tags         integrations
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header    string  true   "Integration API key"
+// @Param        id         path      int     true   "Subscription ID"
+// @Param        page       query     int     false  "Page number" default(1)
+// @Param        page_size  query     int     false  "Page size" default(10)
+// @Success      200        {object}  types.PaginatedResponse
+// @Failure      400        {object}  types.ErrorResponse
+// @Failure      401        {object}  types.ErrorResponse
+// @Failure      404        {object}  types.ErrorResponse
+// @Failure      500        {object}  types.ErrorResponse
+// @Router       /integrations/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListWebhookDeliveries(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid subscription ID"})
+		return
+	}
+
+	var req dto.ListWebhookDeliveriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+	pagination := utils.NormalizePagination("webhook_deliveries", req.Page, req.PageSize)
+
+	deliveries, total, err := h.webhookService.ListDeliveries(c.GetString("apiKeyName"), uint(id), pagination.Page, pagination.Limit)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "Subscription not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		items[i] = mappers.ToWebhookDeliveryResponse(&deliveries[i])
+	}
+
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(items, total, pagination))
+}
+
+// LinkAPIKey godoc
+// @Summary      Link an API key to a customer
+// @Description  Make an integration API key act for a customer, so its webhooks cover the customer's orders and the products they ordered, or unlink the customer's key with a null api_key_name (admin only). A key acts for one customer at most.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                    true  "User ID"
+// @Param        request  body      dto.LinkAPIKeyRequest  true  "API key"
+// @Success      200      {object}  types.SuccessResponse
+// @Failure      400      {object}  types.ErrorResponse
+// @Failure      401      {object}  types.ErrorResponse
+// @Failure      403      {object}  types.ErrorResponse
+// @Failure      404      {object}  types.ErrorResponse
+// @Failure      409      {object}  types.ErrorResponse
+// @Failure      500      {object}  types.ErrorResponse
+// @Router       /admin/users/{id}/api-key [put]
+func (h *WebhookHandler) LinkAPIKey(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "Invalid user ID"})
+		return
+	}
+	var req dto.LinkAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.webhookService.LinkAPIKey(uint(userID), req.APIKeyName); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, types.ErrorResponse{Error: "User not found"})
+		case errors.Is(err, services.ErrAPIKeyLinked):
+			c.JSON(http.StatusConflict, types.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponse{Message: "API key linked"})
+}
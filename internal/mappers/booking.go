@@ -0,0 +1,55 @@
+package mappers
+
+import (
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToSlotResponse converts an availability slot to its response DTO, with the
+// units left to book at a time
+func ToSlotResponse(slot *models.AvailabilitySlot, at time.Time) dto.SlotResponse {
+	return dto.SlotResponse{
+		ID:        slot.ID,
+		StartsAt:  dto.NewTime(slot.StartsAt),
+		EndsAt:    dto.NewTime(slot.EndsAt),
+		Capacity:  slot.Capacity,
+		Available: slot.Available(at),
+	}
+}
+
+// ToSlotResponses converts availability slots to their response DTOs
+func ToSlotResponses(slots []models.AvailabilitySlot, at time.Time) []dto.SlotResponse {
+	responses := make([]dto.SlotResponse, len(slots))
+	for i := range slots {
+		responses[i] = ToSlotResponse(&slots[i], at)
+	}
+	return responses
+}
+
+// ToBookingResponse converts a booking to its response DTO
+func ToBookingResponse(booking *models.Booking) dto.BookingResponse {
+	return dto.BookingResponse{
+		ID:          booking.ID,
+		ProductID:   booking.ProductID,
+		ProductName: booking.Product.Name,
+		UserID:      booking.UserID,
+		SlotID:      booking.SlotID,
+		StartsAt:    dto.NewTime(booking.Slot.StartsAt),
+		EndsAt:      dto.NewTime(booking.Slot.EndsAt),
+		Quantity:    booking.Quantity,
+		Status:      string(booking.Status),
+		CancelledAt: dto.NewTimePtr(booking.CancelledAt),
+		CreatedAt:   dto.NewTime(booking.CreatedAt),
+	}
+}
+
+// ToBookingResponses converts bookings to their response DTOs
+func ToBookingResponses(bookings []models.Booking) []dto.BookingResponse {
+	responses := make([]dto.BookingResponse, len(bookings))
+	for i := range bookings {
+		responses[i] = ToBookingResponse(&bookings[i])
+	}
+	return responses
+}
@@ -0,0 +1,47 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToCategoryResponse converts a category model to its response DTO
+func ToCategoryResponse(category *models.Category) dto.CategoryResponse {
+	return dto.CategoryResponse{
+		ID:             category.ID,
+		Name:           category.Name,
+		Description:    category.Description,
+		MetadataSchema: ToMetadataFields(category.MetadataSchema),
+		ProductCount:   category.ProductsCount,
+	}
+}
+
+// ToMetadataFields converts a category's metadata schema to its response DTOs
+func ToMetadataFields(fields []models.MetadataField) []dto.MetadataField {
+	outputs := make([]dto.MetadataField, len(fields))
+	for i, field := range fields {
+		outputs[i] = dto.MetadataField{Key: field.Key, Type: string(field.Type), Required: field.Required}
+	}
+	return outputs
+}
+
+// FromMetadataFields converts a metadata schema from a request to its model form
+func FromMetadataFields(fields []dto.MetadataField) []models.MetadataField {
+	schema := make([]models.MetadataField, len(fields))
+	for i, field := range fields {
+		schema[i] = models.MetadataField{Key: field.Key, Type: models.MetadataType(field.Type), Required: field.Required}
+	}
+	return schema
+}
+
+// ToCategoryOutputs converts categories to the short form embedded in product responses
+func ToCategoryOutputs(categories []models.Category) []dto.CategoryOutput {
+	outputs := make([]dto.CategoryOutput, len(categories))
+	for i, category := range categories {
+		outputs[i] = dto.CategoryOutput{
+			ID:   category.ID,
+			Name: category.Name,
+		}
+	}
+	return outputs
+}
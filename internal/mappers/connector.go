@@ -0,0 +1,27 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToConnectorSyncRunResponse converts a connector sync run to its response DTO
+func ToConnectorSyncRunResponse(run *models.ConnectorSyncRun) dto.ConnectorSyncRunResponse {
+	return dto.ConnectorSyncRunResponse{
+		ID:              run.ID,
+		Connector:       run.Connector,
+		Status:          string(run.Status),
+		TriggeredBy:     run.TriggeredBy,
+		StartedAt:       dto.NewTime(run.StartedAt),
+		FinishedAt:      dto.NewTimePtr(run.FinishedAt),
+		ProductsPulled:  run.ProductsPulled,
+		ProductsCreated: run.ProductsCreated,
+		ProductsUpdated: run.ProductsUpdated,
+		StockPulled:     run.StockPulled,
+		StockUpdated:    run.StockUpdated,
+		Skipped:         run.Skipped,
+		OrdersPushed:    run.OrdersPushed,
+		OrdersThrough:   dto.NewTimePtr(run.OrdersThrough),
+		Error:           run.Error,
+	}
+}
@@ -0,0 +1,36 @@
+package mappers
+
+import (
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToContentBlockResponse converts a content block to its admin response DTO
+func ToContentBlockResponse(block *models.ContentBlock) dto.ContentBlockResponse {
+	return dto.ContentBlockResponse{
+		ID:        block.ID,
+		Key:       block.Key,
+		Title:     block.Title,
+		Body:      block.Body,
+		ImageURL:  block.ImageURL,
+		StartsAt:  dto.NewTimePtr(block.StartsAt),
+		EndsAt:    dto.NewTimePtr(block.EndsAt),
+		Live:      block.LiveAt(time.Now()),
+		UpdatedBy: block.UpdatedBy,
+		CreatedAt: dto.NewTime(block.CreatedAt),
+		UpdatedAt: dto.NewTime(block.UpdatedAt),
+	}
+}
+
+// ToPublicContentBlockResponse converts a content block to its public response DTO
+func ToPublicContentBlockResponse(block *models.ContentBlock) dto.PublicContentBlockResponse {
+	return dto.PublicContentBlockResponse{
+		Key:       block.Key,
+		Title:     block.Title,
+		Body:      block.Body,
+		ImageURL:  block.ImageURL,
+		UpdatedAt: dto.NewTime(block.UpdatedAt),
+	}
+}
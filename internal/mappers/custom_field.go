@@ -0,0 +1,36 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToCustomFieldResponse converts a custom field model to its response DTO
+func ToCustomFieldResponse(field *models.CustomField) dto.CustomFieldResponse {
+	return dto.CustomFieldResponse{
+		ID:        field.ID,
+		Form:      string(field.Form),
+		Key:       field.Key,
+		Label:     field.Label,
+		HelpText:  field.HelpText,
+		Type:      string(field.Type),
+		Required:  field.Required,
+		Options:   field.Options,
+		Pattern:   field.Pattern,
+		MinLength: field.MinLength,
+		MaxLength: field.MaxLength,
+		Min:       field.Min,
+		Max:       field.Max,
+		Position:  field.Position,
+		UpdatedAt: dto.NewTime(field.UpdatedAt),
+	}
+}
+
+// ToCustomFieldResponses converts a list of custom field models to response DTOs
+func ToCustomFieldResponses(fields []models.CustomField) []dto.CustomFieldResponse {
+	responses := make([]dto.CustomFieldResponse, len(fields))
+	for i := range fields {
+		responses[i] = ToCustomFieldResponse(&fields[i])
+	}
+	return responses
+}
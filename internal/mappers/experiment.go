@@ -0,0 +1,28 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToExperimentResponse converts an experiment to its response DTO
+func ToExperimentResponse(experiment *models.Experiment) dto.ExperimentResponse {
+	variants := make([]dto.ExperimentVariantResponse, len(experiment.Variants))
+	for i, variant := range experiment.Variants {
+		variants[i] = dto.ExperimentVariantResponse{Key: variant.Key, Weight: variant.Weight}
+	}
+	return dto.ExperimentResponse{
+		ID:          experiment.ID,
+		Key:         experiment.Key,
+		Name:        experiment.Name,
+		Description: experiment.Description,
+		Traffic:     experiment.Traffic,
+		Status:      string(experiment.Status),
+		Variants:    variants,
+		StartedAt:   dto.NewTimePtr(experiment.StartedAt),
+		StoppedAt:   dto.NewTimePtr(experiment.StoppedAt),
+		UpdatedBy:   experiment.UpdatedBy,
+		CreatedAt:   dto.NewTime(experiment.CreatedAt),
+		UpdatedAt:   dto.NewTime(experiment.UpdatedAt),
+	}
+}
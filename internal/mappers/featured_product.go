@@ -0,0 +1,23 @@
+package mappers
+
+import (
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToFeaturedProductResponse converts a featured list entry to its response DTO
+func ToFeaturedProductResponse(featured *models.FeaturedProduct) dto.FeaturedProductResponse {
+	return dto.FeaturedProductResponse{
+		ID:        featured.ID,
+		ProductID: featured.ProductID,
+		Position:  featured.Position,
+		StartsAt:  dto.NewTimePtr(featured.StartsAt),
+		EndsAt:    dto.NewTimePtr(featured.EndsAt),
+		Live:      featured.LiveAt(time.Now()),
+		CreatedBy: featured.CreatedBy,
+		CreatedAt: dto.NewTime(featured.CreatedAt),
+		UpdatedAt: dto.NewTime(featured.UpdatedAt),
+	}
+}
@@ -0,0 +1,34 @@
+package mappers
+
+import (
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToGiftCardResponse converts a gift card model to its response DTO
+func ToGiftCardResponse(giftCard *models.GiftCard) dto.GiftCardResponse {
+	return dto.GiftCardResponse{
+		Code:           giftCard.Code,
+		InitialBalance: giftCard.InitialBalance,
+		Balance:        giftCard.Balance,
+		ExpiresAt:      dto.NewTimePtr(giftCard.ExpiresAt),
+		Expired:        giftCard.Expired(time.Now()),
+		CreatedAt:      dto.NewTime(giftCard.CreatedAt),
+	}
+}
+
+// ToStoreCreditEntryResponse converts a store credit entry to its response DTO
+func ToStoreCreditEntryResponse(entry *models.StoreCreditEntry) dto.StoreCreditEntryResponse {
+	return dto.StoreCreditEntryResponse{
+		ID:               entry.ID,
+		Source:           string(entry.Source),
+		Reference:        entry.Reference,
+		Delta:            entry.Delta,
+		ResultingBalance: entry.ResultingBalance,
+		ActorID:          entry.ActorID,
+		Note:             entry.Note,
+		CreatedAt:        dto.NewTime(entry.CreatedAt),
+	}
+}
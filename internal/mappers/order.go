@@ -0,0 +1,22 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToShipmentResponse converts a shipment to its response DTO
+func ToShipmentResponse(shipment *models.Shipment) dto.ShipmentResponse {
+	response := dto.ShipmentResponse{
+		ID:             shipment.ID,
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		TrackingURL:    shipment.TrackingURL,
+		ShippedAt:      dto.NewTime(shipment.ShippedAt),
+		Items:          make([]dto.TrackedItemResponse, len(shipment.Items)),
+	}
+	for i, item := range shipment.Items {
+		response.Items[i] = dto.TrackedItemResponse{ProductName: item.QuoteItem.Product.Name, Quantity: item.Quantity}
+	}
+	return response
+}
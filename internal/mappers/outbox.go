@@ -0,0 +1,21 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToOutboxEventResponse converts an outbox event to its response DTO
+func ToOutboxEventResponse(event *models.OutboxEvent) dto.OutboxEventResponse {
+	return dto.OutboxEventResponse{
+		ID:            event.ID,
+		Topic:         event.Topic,
+		Payload:       event.Payload,
+		Status:        string(event.Status),
+		Attempts:      event.Attempts,
+		NextAttemptAt: dto.NewTime(event.NextAttemptAt),
+		LastError:     event.LastError,
+		PublishedAt:   dto.NewTimePtr(event.PublishedAt),
+		CreatedAt:     dto.NewTime(event.CreatedAt),
+	}
+}
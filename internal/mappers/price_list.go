@@ -0,0 +1,28 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToPriceListResponse converts a price list model to its response DTO
+func ToPriceListResponse(priceList *models.PriceList) dto.PriceListResponse {
+	response := dto.PriceListResponse{
+		ID:          priceList.ID,
+		Name:        priceList.Name,
+		Description: priceList.Description,
+		CreatedAt:   dto.NewTime(priceList.CreatedAt),
+		UpdatedAt:   dto.NewTime(priceList.UpdatedAt),
+	}
+	if len(priceList.Items) > 0 {
+		response.Items = make([]dto.PriceListItemResponse, len(priceList.Items))
+		for i, item := range priceList.Items {
+			response.Items[i] = dto.PriceListItemResponse{
+				ProductID:   item.ProductID,
+				MinQuantity: item.MinQuantity,
+				Price:       item.Price,
+			}
+		}
+	}
+	return response
+}
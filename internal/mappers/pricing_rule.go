@@ -0,0 +1,43 @@
+package mappers
+
+import (
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToPricingRuleResponse converts a pricing rule to its response DTO
+func ToPricingRuleResponse(rule *models.PricingRule) dto.PricingRuleResponse {
+	return dto.PricingRuleResponse{
+		ID:          rule.ID,
+		Name:        rule.Name,
+		Description: rule.Description,
+		Priority:    rule.Priority,
+		Enabled:     rule.Enabled,
+		Live:        rule.LiveAt(time.Now()),
+		StartsAt:    dto.NewTimePtr(rule.StartsAt),
+		EndsAt:      dto.NewTimePtr(rule.EndsAt),
+		CategoryID:  rule.CategoryID,
+		MinStock:    rule.MinStock,
+		NoSalesDays: rule.NoSalesDays,
+		Adjustment:  string(rule.Adjustment),
+		Value:       rule.Value,
+		UpdatedBy:   rule.UpdatedBy,
+		CreatedAt:   dto.NewTime(rule.CreatedAt),
+		UpdatedAt:   dto.NewTime(rule.UpdatedAt),
+	}
+}
+
+// ToPricingRuleApplicationResponse converts a quote item priced by a rule to
+// its response DTO
+func ToPricingRuleApplicationResponse(item *models.QuoteItem) dto.PricingRuleApplicationResponse {
+	return dto.PricingRuleApplicationResponse{
+		QuoteID:     item.QuoteID,
+		ProductID:   item.ProductID,
+		ProductName: item.Product.Name,
+		Quantity:    item.Quantity,
+		UnitPrice:   item.UnitPrice,
+		AppliedAt:   dto.NewTime(item.CreatedAt),
+	}
+}
@@ -0,0 +1,129 @@
+package mappers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/pkg/cdn"
+)
+
+// ToProductResponse converts a product model to its response DTO
+func ToProductResponse(product *models.Product) dto.ProductResponse {
+	return dto.ProductResponse{
+		ID:               product.ID,
+		Name:             product.Name,
+		Description:      product.Description,
+		SKU:              product.SKUValue(),
+		Location:         product.Location,
+		Bookable:         product.Bookable,
+		Price:            product.Price,
+		Quantity:         product.StockQuantity,
+		Status:           string(product.Status),
+		RatingAverage:    product.RatingAverage,
+		RatingCount:      product.RatingCount,
+		AllowedCountries: product.AllowedCountries,
+		BlockedCountries: product.BlockedCountries,
+		Categories:       ToCategoryOutputs(product.Categories),
+		Images:           ToProductImageResponses(product.Images),
+		Metadata:         product.Metadata,
+		CreatedAt:        dto.NewTime(product.CreatedAt),
+		UpdatedAt:        dto.NewTime(product.UpdatedAt),
+	}
+}
+
+// ToProductResponses converts a list of product models to response DTOs
+func ToProductResponses(products []models.Product) []dto.ProductResponse {
+	responses := make([]dto.ProductResponse, len(products))
+	for i := range products {
+		responses[i] = ToProductResponse(&products[i])
+	}
+	return responses
+}
+
+// ToProductImageResponse converts a product image model to its response DTO
+func ToProductImageResponse(image *models.ProductImage) dto.ProductImageResponse {
+	return dto.ProductImageResponse{
+		ID:          image.ID,
+		URL:         ProductImageURL(image),
+		Alt:         image.Alt,
+		Position:    image.Position,
+		ContentType: image.ContentType,
+		Size:        image.Size,
+		CreatedAt:   dto.NewTime(image.CreatedAt),
+	}
+}
+
+// ToProductImageResponses converts a list of product image models to response DTOs
+func ToProductImageResponses(images []models.ProductImage) []dto.ProductImageResponse {
+	responses := make([]dto.ProductImageResponse, len(images))
+	for i := range images {
+		responses[i] = ToProductImageResponse(&images[i])
+	}
+	return responses
+}
+
+// ProductImageURL returns the URL of a product image: through the CDN when
+// CDN_BASE_URL is set, otherwise the API route redirecting to a signed URL of
+// the file, which stays valid however long the response is cached
+func ProductImageURL(image *models.ProductImage) string {
+	if cdn.BaseURL != "" {
+		return cdn.AssetURL(image.Key)
+	}
+	return fmt.Sprintf("/api/v1/products/%d/images/%d", image.ProductID, image.ID)
+}
+
+// ToProductRevisionResponse converts a product revision model to its response DTO
+func ToProductRevisionResponse(revision *models.ProductRevision) dto.ProductRevisionResponse {
+	response := dto.ProductRevisionResponse{
+		ID:        revision.ID,
+		ProductID: revision.ProductID,
+		Revision:  revision.Revision,
+		EditedBy:  revision.EditedBy,
+		CreatedAt: dto.NewTime(revision.CreatedAt),
+	}
+	_ = json.Unmarshal([]byte(revision.Snapshot), &response.Snapshot)
+	return response
+}
+
+// ToStockMovementResponse converts a stock movement model to its response DTO
+func ToStockMovementResponse(movement *models.StockMovement) dto.StockMovementResponse {
+	return dto.StockMovementResponse{
+		ID:                movement.ID,
+		Source:            string(movement.Source),
+		Reference:         movement.Reference,
+		Delta:             movement.Delta,
+		ResultingQuantity: movement.ResultingQuantity,
+		ActorID:           movement.ActorID,
+		Note:              movement.Note,
+		CreatedAt:         dto.NewTime(movement.CreatedAt),
+	}
+}
+
+// ToChangeRequestResponse converts a product change request model to its response DTO
+func ToChangeRequestResponse(changeRequest *models.ProductChangeRequest) dto.ProductChangeRequestResponse {
+	response := dto.ProductChangeRequestResponse{
+		ID:          changeRequest.ID,
+		ProductID:   changeRequest.ProductID,
+		RequestedBy: changeRequest.RequestedBy,
+		Status:      string(changeRequest.Status),
+		ReviewedBy:  changeRequest.ReviewedBy,
+		ReviewNote:  changeRequest.ReviewNote,
+		ReviewedAt:  dto.NewTimePtr(changeRequest.ReviewedAt),
+		CreatedAt:   dto.NewTime(changeRequest.CreatedAt),
+	}
+	_ = json.Unmarshal([]byte(changeRequest.Diff), &response.Changes)
+	return response
+}
+
+// ToDuplicateProductResponse converts a product of a likely duplicate pair to its response DTO
+func ToDuplicateProductResponse(product *models.Product) dto.DuplicateProductResponse {
+	return dto.DuplicateProductResponse{
+		ID:     product.ID,
+		Name:   product.Name,
+		SKU:    product.SKUValue(),
+		Price:  product.Price,
+		Status: string(product.Status),
+	}
+}
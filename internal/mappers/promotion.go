@@ -0,0 +1,24 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToPromotionResponse converts a promotion to its response DTO
+func ToPromotionResponse(promotion *models.Promotion) dto.PromotionResponse {
+	return dto.PromotionResponse{
+		ID:         promotion.ID,
+		Name:       promotion.Name,
+		ProductID:  promotion.ProductID,
+		CategoryID: promotion.CategoryID,
+		Discount:   string(promotion.Discount),
+		Value:      promotion.Value,
+		StartsAt:   dto.NewTime(promotion.StartsAt),
+		EndsAt:     dto.NewTime(promotion.EndsAt),
+		Status:     string(promotion.Status),
+		UpdatedBy:  promotion.UpdatedBy,
+		CreatedAt:  dto.NewTime(promotion.CreatedAt),
+		UpdatedAt:  dto.NewTime(promotion.UpdatedAt),
+	}
+}
@@ -0,0 +1,60 @@
+package mappers
+
+import (
+	"math"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToSupplierResponse converts a supplier model to its response DTO
+func ToSupplierResponse(supplier *models.Supplier) dto.SupplierResponse {
+	return dto.SupplierResponse{
+		ID:          supplier.ID,
+		Name:        supplier.Name,
+		ContactName: supplier.ContactName,
+		Email:       supplier.Email,
+		Phone:       supplier.Phone,
+		Notes:       supplier.Notes,
+		CreatedAt:   dto.NewTime(supplier.CreatedAt),
+	}
+}
+
+// ToPurchaseOrderResponse converts a purchase order model with its items to its response DTO
+func ToPurchaseOrderResponse(purchaseOrder *models.PurchaseOrder) dto.PurchaseOrderResponse {
+	items := make([]dto.PurchaseOrderItemResponse, len(purchaseOrder.Items))
+	totalCost := 0.0
+	for i, item := range purchaseOrder.Items {
+		items[i] = dto.PurchaseOrderItemResponse{
+			ProductID:        item.ProductID,
+			ProductName:      item.Product.Name,
+			QuantityOrdered:  item.QuantityOrdered,
+			QuantityReceived: item.QuantityReceived,
+			UnitCost:         item.UnitCost,
+		}
+		totalCost += item.UnitCost * float64(item.QuantityOrdered)
+	}
+	return dto.PurchaseOrderResponse{
+		ID:           purchaseOrder.ID,
+		Reference:    purchaseOrder.Reference(),
+		SupplierID:   purchaseOrder.SupplierID,
+		SupplierName: purchaseOrder.Supplier.Name,
+		Status:       string(purchaseOrder.Status),
+		Items:        items,
+		TotalCost:    math.Round(totalCost*100) / 100,
+		ExpectedAt:   dto.NewTimePtr(purchaseOrder.ExpectedAt),
+		Notes:        purchaseOrder.Notes,
+		CreatedBy:    purchaseOrder.CreatedBy,
+		ClosedAt:     dto.NewTimePtr(purchaseOrder.ClosedAt),
+		CreatedAt:    dto.NewTime(purchaseOrder.CreatedAt),
+	}
+}
+
+// ToPurchaseOrderResponses converts a list of purchase order models to response DTOs
+func ToPurchaseOrderResponses(purchaseOrders []models.PurchaseOrder) []dto.PurchaseOrderResponse {
+	responses := make([]dto.PurchaseOrderResponse, len(purchaseOrders))
+	for i := range purchaseOrders {
+		responses[i] = ToPurchaseOrderResponse(&purchaseOrders[i])
+	}
+	return responses
+}
@@ -0,0 +1,54 @@
+package mappers
+
+import (
+	"math"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToQuoteResponse converts a quote model with its items to its response DTO
+func ToQuoteResponse(quote *models.Quote) dto.QuoteResponse {
+	items := make([]dto.QuoteItemResponse, len(quote.Items))
+	for i, item := range quote.Items {
+		items[i] = dto.QuoteItemResponse{
+			ProductID:     item.ProductID,
+			ProductName:   item.Product.Name,
+			Quantity:      item.Quantity,
+			UnitPrice:     item.UnitPrice,
+			PricingRuleID: item.PricingRuleID,
+			QuotedPrice:   item.QuotedPrice,
+			GiftWrap:      item.GiftWrap,
+			GiftMessage:   item.GiftMessage,
+			HidePrice:     item.HidePrice,
+			GiftWrapFee:   item.GiftWrapFee,
+		}
+	}
+	return dto.QuoteResponse{
+		ID:           quote.ID,
+		UserID:       quote.UserID,
+		Status:       string(quote.Status),
+		Expired:      quote.Expired(time.Now()),
+		Items:        items,
+		Subtotal:     math.Round(quote.Subtotal()*100) / 100,
+		GiftWrapFees: math.Round(quote.GiftWrapFees()*100) / 100,
+		Total:        math.Round(quote.Total()*100) / 100,
+		Note:         quote.Note,
+		ResponseNote: quote.ResponseNote,
+		ValidUntil:   dto.NewTimePtr(quote.ValidUntil),
+		RespondedAt:  dto.NewTimePtr(quote.RespondedAt),
+		DecidedAt:    dto.NewTimePtr(quote.DecidedAt),
+		CustomFields: quote.CustomFields,
+		CreatedAt:    dto.NewTime(quote.CreatedAt),
+	}
+}
+
+// ToQuoteResponses converts a list of quote models to response DTOs
+func ToQuoteResponses(quotes []models.Quote) []dto.QuoteResponse {
+	responses := make([]dto.QuoteResponse, len(quotes))
+	for i := range quotes {
+		responses[i] = ToQuoteResponse(&quotes[i])
+	}
+	return responses
+}
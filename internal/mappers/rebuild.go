@@ -0,0 +1,29 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToRebuildRunResponse converts a rebuild run to its response DTO
+func ToRebuildRunResponse(run *models.RebuildRun) dto.RebuildRunResponse {
+	progress := 0
+	if run.Total > 0 {
+		progress = run.Processed * 100 / run.Total
+	} else if run.Status == models.RebuildSucceeded {
+		progress = 100
+	}
+	return dto.RebuildRunResponse{
+		ID:          run.ID,
+		Target:      string(run.Target),
+		Status:      string(run.Status),
+		TriggeredBy: run.TriggeredBy,
+		StartedAt:   dto.NewTime(run.StartedAt),
+		FinishedAt:  dto.NewTimePtr(run.FinishedAt),
+		Total:       run.Total,
+		Processed:   run.Processed,
+		Progress:    progress,
+		Changed:     run.Changed,
+		Error:       run.Error,
+	}
+}
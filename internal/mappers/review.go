@@ -0,0 +1,57 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToReviewResponse converts a review model to its response DTO. The author and
+// product are included only when they were preloaded.
+func ToReviewResponse(review *models.Review) dto.ReviewResponse {
+	response := dto.ReviewResponse{
+		ID:        review.ID,
+		UserID:    review.UserID,
+		ProductID: review.ProductID,
+		Rating:    review.Rating,
+		Comment:   review.Comment,
+		Imported:  review.Imported,
+		Source:    review.Source,
+		CreatedAt: dto.NewTime(review.CreatedAt),
+		UpdatedAt: dto.NewTime(review.UpdatedAt),
+	}
+	if review.User.ID != 0 {
+		response.User = &dto.UserOutput{
+			ID:       review.User.ID,
+			Username: review.User.Username,
+			Email:    review.User.Email,
+			FullName: review.User.FullName,
+		}
+	}
+	if review.Product.ID != 0 {
+		product := ToProductResponse(&review.Product)
+		response.Product = &product
+	}
+	return response
+}
+
+// ToReviewResponses converts a list of review models to response DTOs
+func ToReviewResponses(reviews []models.Review) []dto.ReviewResponse {
+	responses := make([]dto.ReviewResponse, len(reviews))
+	for i := range reviews {
+		responses[i] = ToReviewResponse(&reviews[i])
+	}
+	return responses
+}
+
+// ToPublicReviewResponse converts a review model to the trimmed form shown to other users
+func ToPublicReviewResponse(review *models.Review) dto.PublicReviewResponse {
+	return dto.PublicReviewResponse{
+		ID:          review.ID,
+		ProductID:   review.ProductID,
+		ProductName: review.Product.Name,
+		Rating:      review.Rating,
+		Comment:     review.Comment,
+		Imported:    review.Imported,
+		CreatedAt:   dto.NewTime(review.CreatedAt),
+	}
+}
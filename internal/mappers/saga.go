@@ -0,0 +1,33 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToSagaResponse converts a saga with its steps to its response DTO
+func ToSagaResponse(saga *models.Saga) dto.SagaResponse {
+	steps := make([]dto.SagaStepResponse, len(saga.Steps))
+	for i, step := range saga.Steps {
+		steps[i] = dto.SagaStepResponse{
+			Name:          step.Name,
+			Status:        string(step.Status),
+			Error:         step.Error,
+			CompletedAt:   dto.NewTimePtr(step.CompletedAt),
+			CompensatedAt: dto.NewTimePtr(step.CompensatedAt),
+		}
+	}
+	return dto.SagaResponse{
+		ID:            saga.ID,
+		Type:          string(saga.Type),
+		Reference:     saga.Reference,
+		Status:        string(saga.Status),
+		Data:          saga.Data,
+		Attempts:      saga.Attempts,
+		NextAttemptAt: dto.NewTimePtr(saga.NextAttemptAt),
+		Error:         saga.Error,
+		Steps:         steps,
+		CreatedAt:     dto.NewTime(saga.CreatedAt),
+		FinishedAt:    dto.NewTimePtr(saga.FinishedAt),
+	}
+}
@@ -0,0 +1,29 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToSecurityAlertResponse converts a security alert to its response DTO
+func ToSecurityAlertResponse(alert *models.SecurityAlert) dto.SecurityAlertResponse {
+	return dto.SecurityAlertResponse{
+		ID:        alert.ID,
+		Kind:      string(alert.Kind),
+		IP:        alert.IP,
+		ActorID:   alert.ActorID,
+		Details:   alert.Details,
+		Blocked:   alert.Blocked,
+		CreatedAt: dto.NewTime(alert.CreatedAt),
+	}
+}
+
+// ToIPBlockResponse converts an IP block to its response DTO
+func ToIPBlockResponse(block *models.IPBlock) dto.IPBlockResponse {
+	return dto.IPBlockResponse{
+		IP:        block.IP,
+		Reason:    block.Reason,
+		CreatedAt: dto.NewTime(block.CreatedAt),
+		ExpiresAt: dto.NewTime(block.ExpiresAt),
+	}
+}
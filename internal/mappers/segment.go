@@ -0,0 +1,27 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToSegmentResponse converts a segment model to its response DTO
+func ToSegmentResponse(segment *models.Segment) dto.SegmentResponse {
+	rules := make([]dto.SegmentRule, len(segment.Rules))
+	for i, rule := range segment.Rules {
+		rules[i] = dto.SegmentRule{
+			Field:    string(rule.Field),
+			Operator: string(rule.Operator),
+			Value:    rule.Value,
+		}
+	}
+	return dto.SegmentResponse{
+		ID:          segment.ID,
+		Name:        segment.Name,
+		Description: segment.Description,
+		Rules:       rules,
+		CreatedBy:   segment.CreatedBy,
+		CreatedAt:   dto.NewTime(segment.CreatedAt),
+		UpdatedAt:   dto.NewTime(segment.UpdatedAt),
+	}
+}
@@ -0,0 +1,76 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToUserOutput converts a user model to the output DTO returned on login and registration
+func ToUserOutput(user *models.User) dto.UserOutput {
+	return dto.UserOutput{
+		ID:        user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		FullName:  user.FullName,
+		Role:      string(user.Role),
+		LastLogin: dto.NewTime(user.LastLogin),
+	}
+}
+
+// ToUserResponse converts a user model to its response DTO
+func ToUserResponse(user *models.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:           user.ID,
+		Username:     user.Username,
+		Email:        user.Email,
+		FullName:     user.FullName,
+		Role:         string(user.Role),
+		LastLogin:    dto.NewTime(user.LastLogin),
+		Locale:       user.Locale,
+		Currency:     user.Currency,
+		Timezone:     user.Timezone,
+		CustomFields: user.CustomFields,
+	}
+}
+
+// ToUserResponses converts a list of user models to response DTOs
+func ToUserResponses(users []models.User) []dto.UserResponse {
+	responses := make([]dto.UserResponse, len(users))
+	for i := range users {
+		responses[i] = ToUserResponse(&users[i])
+	}
+	return responses
+}
+
+// ToAnonymizedUserResponse converts an anonymized user model to its response DTO
+func ToAnonymizedUserResponse(user *models.User) dto.AnonymizedUserResponse {
+	response := dto.AnonymizedUserResponse{
+		ID:       user.ID,
+		Username: user.Username,
+	}
+	if user.AnonymizedAt != nil {
+		response.AnonymizedAt = dto.NewTime(*user.AnonymizedAt)
+	}
+	return response
+}
+
+// ToNotificationSettingsResponse converts notification settings to their response DTO
+func ToNotificationSettingsResponse(settings *models.NotificationSettings) dto.NotificationSettingsResponse {
+	return dto.NotificationSettingsResponse{
+		SecurityAlerts: dto.NotificationChannels(settings.SecurityAlerts),
+		OrderUpdates:   dto.NotificationChannels(settings.OrderUpdates),
+		PhoneNumber:    settings.PhoneNumber,
+		HasPushToken:   settings.PushToken != "",
+		WeeklyDigest:   !settings.DigestUnsubscribed,
+		Promotions:     !settings.PromotionsOptedOut,
+	}
+}
+
+// ToReferralResponse converts a referred user to its response DTO
+func ToReferralResponse(user *models.User) dto.ReferralResponse {
+	return dto.ReferralResponse{
+		Username: user.Username,
+		JoinedAt: dto.NewTime(user.CreatedAt),
+		Rewarded: user.ReferralRewardedAt != nil,
+	}
+}
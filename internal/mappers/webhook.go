@@ -0,0 +1,36 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToWebhookSubscriptionResponse converts a webhook subscription to its response DTO
+func ToWebhookSubscriptionResponse(subscription *models.WebhookSubscription) dto.WebhookSubscriptionResponse {
+	productIDs := subscription.ProductIDs
+	if productIDs == nil {
+		productIDs = []uint{}
+	}
+	return dto.WebhookSubscriptionResponse{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		Events:     subscription.Events,
+		ProductIDs: productIDs,
+		CreatedAt:  dto.NewTime(subscription.CreatedAt),
+	}
+}
+
+// ToWebhookDeliveryResponse converts a delivery attempt to its response DTO
+func ToWebhookDeliveryResponse(delivery *models.WebhookDelivery) dto.WebhookDeliveryResponse {
+	return dto.WebhookDeliveryResponse{
+		ID:         delivery.ID,
+		EventID:    delivery.EventID,
+		Event:      delivery.Event,
+		Attempt:    delivery.Attempt,
+		StatusCode: delivery.StatusCode,
+		Succeeded:  delivery.Succeeded,
+		Error:      delivery.Error,
+		DurationMs: delivery.DurationMs,
+		CreatedAt:  dto.NewTime(delivery.CreatedAt),
+	}
+}
@@ -0,0 +1,25 @@
+package mappers
+
+import (
+	"product-management/internal/dto"
+	"product-management/internal/models"
+)
+
+// ToWishlistItemResponse converts a wishlist model to its response DTO
+func ToWishlistItemResponse(item *models.Wishlist) dto.WishlistItemResponse {
+	return dto.WishlistItemResponse{
+		ID:        item.ID,
+		ProductID: item.ProductID,
+		Product:   ToProductResponse(&item.Product),
+		AddedAt:   dto.NewTime(item.AddedAt),
+	}
+}
+
+// ToWishlistItemResponses converts a list of wishlist models to response DTOs
+func ToWishlistItemResponses(items []models.Wishlist) []dto.WishlistItemResponse {
+	responses := make([]dto.WishlistItemResponse, len(items))
+	for i := range items {
+		responses[i] = ToWishlistItemResponse(&items[i])
+	}
+	return responses
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+
+	"product-management/internal/types"
+	"product-management/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyAuth requires a configured API key in the X-API-Key header and stores
+// its name in the context as "apiKeyName". Integrations authenticate this way
+// instead of with a user token. policy returns the keys in effect, which a
+// configuration reload may change.
+func APIKeyAuth(policy func() ratelimit.Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey, ok := policy().APIKeys[c.GetHeader(APIKeyHeader)]
+		if !ok {
+			c.JSON(http.StatusUnauthorized, types.ErrorResponse{Error: "A valid API key is required"})
+			c.Abort()
+			return
+		}
+		c.Set("apiKeyName", apiKey.Name)
+		c.Next()
+	}
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"product-management/config"
+	"product-management/internal/models"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -76,12 +77,12 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Extract specific claims: user_id, email, role
+		// Extract specific claims: user_id, role, token_version
 		userIDFloat, okID := claims["user_id"].(float64)
-		email, okEmail := claims["email"].(string)
 		role, okRole := claims["role"].(string)
+		tokenVersion, okVersion := claims["token_version"].(float64)
 
-		if !okID || !okEmail || !okRole {
+		if !okID || !okRole || !okVersion {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error":  "missing or invalid claim fields",
 				"status": http.StatusUnauthorized,
@@ -90,11 +91,72 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// Reject tokens issued before the user's last role or password change
+		currentVersion, err := currentTokenVersion(uint(userIDFloat))
+		if err != nil || uint(tokenVersion) != currentVersion {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":  "token has been revoked",
+				"status": http.StatusUnauthorized,
+			})
+			c.Abort()
+			return
+		}
+
+		// Reject tokens revoked at logout. Tokens issued before they carried a
+		// jti can only be revoked with the user's other sessions.
+		tokenID, _ := claims["jti"].(string)
+		if tokenID != "" {
+			if revoked, err := isTokenRevoked(tokenID); err != nil || revoked {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error":  "token has been revoked",
+					"status": http.StatusUnauthorized,
+				})
+				c.Abort()
+				return
+			}
+		}
+
+		// Scoped tokens may only reach the routes their scopes grant
+		if rawScopes, scoped := claims["scopes"].([]interface{}); scoped {
+			scopes := make([]models.Scope, 0, len(rawScopes))
+			for _, raw := range rawScopes {
+				if scope, ok := raw.(string); ok {
+					scopes = append(scopes, models.Scope(scope))
+				}
+			}
+			if !scopesAllow(scopes, c.Request.Method, c.FullPath()) {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error":  "token scope does not allow this endpoint",
+					"status": http.StatusForbidden,
+				})
+				c.Abort()
+				return
+			}
+			c.Set("scopes", scopes)
+		}
+
 		// Set into context
 		c.Set("userID", uint(userIDFloat))
-		c.Set("email", email)
 		c.Set("role", role)
+		c.Set("tokenID", tokenID)
+		if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+			c.Set("tokenExpiresAt", exp.Time)
+		}
 
 		c.Next()
 	}
 }
+
+// OptionalAuthMiddleware authenticates callers sending an Authorization header
+// like AuthMiddleware, and lets callers without one through anonymously. An
+// invalid token is rejected rather than ignored, so clients notice it expired.
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	auth := AuthMiddleware()
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		auth(c)
+	}
+}
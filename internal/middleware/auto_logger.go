@@ -2,8 +2,14 @@ package middleware
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"product-management/pkg/logger"
 
@@ -11,8 +17,44 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// AutoLogger middleware automatically logs request and response
-func AutoLogger() gin.HandlerFunc {
+// BodyLogging configures which request and response bodies AutoLogger logs
+// and how much of them
+type BodyLogging struct {
+	MaxBytes     int      // Bodies are cut to this many bytes; 0 logs none
+	RedactFields []string // JSON and form fields masked besides redactedFields
+	SkipRoutes   []string // Routes whose bodies are not logged, as "/api/v1/auth/login" or "POST /api/v1/auth/login"
+}
+
+// redactedFields carry credentials and are masked wherever they appear in a
+// JSON or form body
+var redactedFields = []string{
+	"password", "confirm_password", "current_password", "new_password", "confirm_new_password",
+	"token", "access_token", "refresh_token", "push_token", "form_token", "captcha_token",
+	"secret", "api_key", "code", "gift_card_code",
+}
+
+// bodyRedactor masks sensitive fields in bodies and cuts them to size
+type bodyRedactor struct {
+	maxBytes   int
+	fields     map[string]bool // Lower-cased field names
+	skipRoutes map[string]bool // "METHOD /path" or "/path"
+}
+
+// AutoLogger middleware automatically logs request and response, with
+// credentials masked in headers and bodies
+func AutoLogger(bodies BodyLogging) gin.HandlerFunc {
+	redactor := &bodyRedactor{
+		maxBytes:   bodies.MaxBytes,
+		fields:     make(map[string]bool),
+		skipRoutes: make(map[string]bool, len(bodies.SkipRoutes)),
+	}
+	for _, field := range append(redactedFields, bodies.RedactFields...) {
+		redactor.fields[strings.ToLower(field)] = true
+	}
+	for _, route := range bodies.SkipRoutes {
+		redactor.skipRoutes[route] = true
+	}
+
 	return func(c *gin.Context) {
 		// Start timer
 		start := time.Now()
@@ -20,25 +62,33 @@ func AutoLogger() gin.HandlerFunc {
 		// Log request
 		requestLogger := logger.WithFields(logrus.Fields{
 			"method":     c.Request.Method,
-			"path":       c.Request.URL.Path,
+			"path":       loggedPath(c),
 			"client_ip":  c.ClientIP(),
 			"user_agent": c.Request.UserAgent(),
 		})
+		requestLogger = withOrigin(requestLogger, c)
 
-		// Log request body if exists
-		if c.Request.Body != nil {
+		// Log request body if exists. Multipart forms carry uploaded files, which
+		// are left unread so they don't end up in the logs.
+		logBodies := redactor.logs(c)
+		if logBodies && c.Request.Body != nil && !strings.HasPrefix(c.ContentType(), "multipart/") {
 			body, _ := io.ReadAll(c.Request.Body)
 			c.Request.Body = io.NopCloser(bytes.NewBuffer(body))
 			if len(body) > 0 {
-				requestLogger = requestLogger.WithField("request_body", string(body))
+				requestLogger = requestLogger.WithField("request_body", redactor.redact(c.ContentType(), body))
 			}
 		}
 
 		requestLogger.Info("Incoming request")
+		if logger.Verbose() {
+			requestLogger.WithField("headers", redactHeaders(c.Request.Header)).Debug("Request headers")
+		}
 
 		// Create a custom response writer to capture response
 		blw := &bodyLogWriter{body: bytes.NewBufferString(""), ResponseWriter: c.Writer}
-		c.Writer = blw
+		if logBodies {
+			c.Writer = blw
+		}
 
 		// Process request
 		c.Next()
@@ -49,14 +99,15 @@ func AutoLogger() gin.HandlerFunc {
 		// Log response
 		responseLogger := logger.WithFields(logrus.Fields{
 			"method":   c.Request.Method,
-			"path":     c.Request.URL.Path,
+			"path":     loggedPath(c),
 			"status":   c.Writer.Status(),
 			"duration": duration,
 		})
+		responseLogger = withOrigin(responseLogger, c)
 
 		// Log response body if exists
 		if blw.body.Len() > 0 {
-			responseLogger = responseLogger.WithField("response_body", blw.body.String())
+			responseLogger = responseLogger.WithField("response_body", redactor.redact(mediaType(c.Writer.Header().Get("Content-Type")), blw.body.Bytes()))
 		}
 
 		// Log errors if any
@@ -71,6 +122,105 @@ func AutoLogger() gin.HandlerFunc {
 	}
 }
 
+// logs reports whether the bodies of a request and its response are logged
+func (r *bodyRedactor) logs(c *gin.Context) bool {
+	return r.maxBytes > 0 && !r.skipRoutes[c.FullPath()] && !r.skipRoutes[c.Request.Method+" "+c.FullPath()]
+}
+
+// redact returns a body as logged: JSON and form bodies with their sensitive
+// fields masked, other text as is, cut to the maximum size. Binary bodies such
+// as PDFs are only described.
+func (r *bodyRedactor) redact(contentType string, body []byte) string {
+	switch {
+	case contentType == "application/x-www-form-urlencoded":
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			for name := range values {
+				if r.fields[strings.ToLower(name)] {
+					values.Set(name, "[redacted]")
+				}
+			}
+			body = []byte(values.Encode())
+		}
+	case strings.HasSuffix(contentType, "json"):
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			// Unparsable bodies may still hold credentials
+			return fmt.Sprintf("[%d bytes of malformed JSON]", len(body))
+		}
+		if redacted, err := json.Marshal(r.redactValue(value)); err == nil {
+			body = redacted
+		}
+	case contentType != "" && !strings.HasPrefix(contentType, "text/") && !strings.HasSuffix(contentType, "xml"):
+		return fmt.Sprintf("[%d bytes of %s]", len(body), contentType)
+	}
+
+	if len(body) <= r.maxBytes {
+		return string(body)
+	}
+	cut := r.maxBytes
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s...[%d more bytes]", body[:cut], len(body)-cut)
+}
+
+// redactValue masks the sensitive fields of a decoded JSON value, at any depth
+func (r *bodyRedactor) redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if r.fields[strings.ToLower(key)] {
+				v[key] = "[redacted]"
+			} else {
+				v[key] = r.redactValue(field)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = r.redactValue(item)
+		}
+	}
+	return value
+}
+
+// mediaType returns the media type of a Content-Type header, without its parameters
+func mediaType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// redactedHeaders carry credentials and are not logged
+var redactedHeaders = []string{"Authorization", "Cookie", "X-API-Key"}
+
+// redactHeaders returns a copy of the headers with credentials masked
+func redactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "[redacted]")
+		}
+	}
+	return redacted
+}
+
+// withLocation adds the country and city Region resolved and the identity of
+// the client certificate, when known
+func withOrigin(entry *logrus.Entry, c *gin.Context) *logrus.Entry {
+	location := requestLocation(c)
+	if location.Country != "" {
+		entry = entry.WithField("country", location.Country)
+	}
+	if location.City != "" {
+		entry = entry.WithField("city", location.City)
+	}
+	if identity := c.GetString("clientIdentity"); identity != "" {
+		entry = entry.WithField("client_identity", identity)
+	}
+	return entry
+}
+
 // bodyLogWriter is a custom response writer to capture response body
 type bodyLogWriter struct {
 	gin.ResponseWriter
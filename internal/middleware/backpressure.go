@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"product-management/internal/types"
+	"product-management/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Backpressure admits requests through a concurrency limiter and sheds the ones
+// that can't get a slot with 503 and Retry-After. Sizing the limit to the
+// database pool keeps requests from piling up waiting for a connection.
+func Backpressure(limiter *ratelimit.Concurrency) gin.HandlerFunc {
+	retryAfter := strconv.Itoa(int(math.Max(1, math.Ceil(limiter.Timeout().Seconds()))))
+
+	return func(c *gin.Context) {
+		release, err := limiter.Acquire(c.Request.Context())
+		if err != nil {
+			c.Header("Retry-After", retryAfter)
+			c.JSON(http.StatusServiceUnavailable, types.ErrorResponse{Error: "Server is busy, please retry later"})
+			c.Abort()
+			return
+		}
+		defer release()
+
+		c.Next()
+	}
+}
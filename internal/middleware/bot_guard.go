@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"product-management/internal/types"
+	"product-management/pkg/botguard"
+
+	"github.com/gin-gonic/gin"
+)
+
+// botGuardMaxBody bounds the form bodies read for bot checks
+const botGuardMaxBody = 1 << 20
+
+// BotGuard runs the bot checks configured for a route on its JSON body. The
+// honeypot, form token and CAPTCHA fields are removed from the body so the
+// handler binds it as usual. Suspected bots get a generic 400 that does not
+// tell which check failed, and the signal is logged.
+func BotGuard(guard *botguard.Guard, route string) gin.HandlerFunc {
+	checks := guard.Checks(route)
+	return func(c *gin.Context) {
+		if len(checks) == 0 || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, botGuardMaxBody))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "failed to read request body"})
+			c.Abort()
+			return
+		}
+		// Leave malformed bodies to the handler's binding errors
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+			restoreBody(c, body)
+			c.Next()
+			return
+		}
+
+		honeypot := takeString(fields, guard.HoneypotField)
+		formToken := takeString(fields, botguard.FormTokenField)
+		captchaToken := takeString(fields, botguard.CaptchaField)
+		if body, err = json.Marshal(fields); err != nil {
+			c.JSON(http.StatusInternalServerError, types.ErrorResponse{Error: err.Error()})
+			c.Abort()
+			return
+		}
+		restoreBody(c, body)
+
+		for _, check := range checks {
+			var signal error
+			switch check {
+			case botguard.CheckHoneypot:
+				if honeypot != "" {
+					signal = errors.New("honeypot field filled in")
+				}
+			case botguard.CheckTiming:
+				if formToken == "" {
+					signal = errors.New("form token missing")
+				} else {
+					signal = guard.Tokens.Check(formToken)
+				}
+			case botguard.CheckCaptcha:
+				signal = verifyCaptcha(c, guard.Captcha, captchaToken)
+			}
+			if signal != nil {
+				log.Printf("Suspected bot on %s from %s (%s): %v", route, c.ClientIP(), c.Request.UserAgent(), signal)
+				c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: "request could not be processed"})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// verifyCaptcha returns the signal of a missing or rejected CAPTCHA response.
+// Requests are let through when the provider cannot be reached, so an outage
+// does not lock people out.
+func verifyCaptcha(c *gin.Context, captcha botguard.Captcha, response string) error {
+	if captcha == nil {
+		return nil
+	}
+	if response == "" {
+		return errors.New("captcha response missing")
+	}
+	err := captcha.Verify(c.Request.Context(), response, c.ClientIP())
+	if err != nil && !errors.Is(err, botguard.ErrCaptchaFailed) {
+		log.Printf("Warning: failed to verify captcha: %v", err)
+		return nil
+	}
+	return err
+}
+
+// takeString removes a field from a JSON object and returns it as a string.
+// Values that are not strings, except null, are returned as raw JSON so a bot
+// filling a honeypot with a number is still caught.
+func takeString(fields map[string]json.RawMessage, name string) string {
+	raw, ok := fields[name]
+	if !ok {
+		return ""
+	}
+	delete(fields, name)
+	var value string
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value
+	}
+	if string(raw) == "null" {
+		return ""
+	}
+	return string(raw)
+}
+
+// restoreBody replaces the request body once it has been read
+func restoreBody(c *gin.Context, body []byte) {
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	c.Request.ContentLength = int64(len(body))
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"product-management/internal/types"
+	"product-management/pkg/mtls"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientCertificate requires the verified client certificate of a mutual TLS
+// connection and stores the identity it carries in the context as
+// "clientIdentity", next to any JWT the route also requires. When allowed is
+// not empty, the certificate must carry one of those names as a URI SAN,
+// common name or DNS SAN.
+func ClientCertificate(allowed []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// The listener already rejects handshakes without a valid certificate,
+		// so this only guards against the route being served without one
+		if c.Request.TLS == nil || len(c.Request.TLS.VerifiedChains) == 0 {
+			c.JSON(http.StatusUnauthorized, types.ErrorResponse{Error: "client certificate required"})
+			c.Abort()
+			return
+		}
+		cert := c.Request.TLS.VerifiedChains[0][0]
+
+		if len(allowed) > 0 && !slices.ContainsFunc(mtls.Names(cert), func(name string) bool {
+			return slices.Contains(allowed, name)
+		}) {
+			c.JSON(http.StatusForbidden, types.ErrorResponse{Error: "client certificate is not allowed"})
+			c.Abort()
+			return
+		}
+
+		c.Set("clientIdentity", mtls.Identity(cert))
+		c.Next()
+	}
+}
@@ -1,27 +1,206 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"product-management/internal/types"
 
 	"github.com/gin-gonic/gin"
 )
 
-func ErrorHandlerMiddleware() gin.HandlerFunc {
+// ProblemJSON is the media type of RFC 7807 error responses
+const ProblemJSON = "application/problem+json"
+
+var (
+	// validationError matches a line of a validator.ValidationErrors message,
+	// e.g. Key: 'CreateProductRequest.Price' Error:Field validation for 'Price' failed on the 'gt' tag
+	validationError = regexp.MustCompile(`Key: '[^.']+\.([^']+)' Error:Field validation for '[^']*' failed on the '([^']*)' tag`)
+	// unknownFieldError matches the error of a strict JSON body with an unknown field
+	unknownFieldError = regexp.MustCompile(`^json: unknown field "([^"]*)"$`)
+	// typeError matches the error of a JSON value of the wrong type
+	typeError = regexp.MustCompile(`^json: cannot unmarshal \w+ into Go struct field [^.]+\.(\S+) of type (\S+)$`)
+)
+
+// ErrorHandlerMiddleware renders the errors handlers attach to the context.
+// Clients that accept application/problem+json, or every client when
+// alwaysProblem is set, get all error responses as RFC 7807 problem details.
+func ErrorHandlerMiddleware(alwaysProblem bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if !alwaysProblem && !acceptsProblem(c.GetHeader("Accept")) {
+			c.Next()
+
+			if len(c.Errors) > 0 {
+				status := c.Writer.Status()
+				if status < 400 {
+					status = http.StatusInternalServerError
+				}
+
+				c.JSON(status, gin.H{
+					"error":  c.Errors[0].Error(),
+					"status": status,
+				})
+
+				c.Abort()
+			}
+			return
+		}
+
+		writer := &problemWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		// Restored on panics too, so the recovery middleware can still respond
+		defer func() { c.Writer = writer.ResponseWriter }()
 		c.Next()
+		c.Writer = writer.ResponseWriter
 
-		if len(c.Errors) > 0 {
-			status := c.Writer.Status()
+		status := c.Writer.Status()
+		if !writer.held {
+			if len(c.Errors) == 0 || c.Writer.Written() {
+				// Headers of a streamed response are already sent
+				return
+			}
 			if status < 400 {
 				status = http.StatusInternalServerError
 			}
+		}
 
-			c.JSON(status, gin.H{
-				"error":  c.Errors[0].Error(),
-				"status": status,
-			})
+		problem := types.ProblemDetails{
+			Type:     "about:blank",
+			Title:    http.StatusText(status),
+			Status:   status,
+			Instance: c.Request.URL.Path,
+		}
+		if writer.body.Len() > 0 {
+			fillProblem(&problem, writer.body.Bytes())
+		} else if len(c.Errors) > 0 {
+			problem.Detail = c.Errors[0].Error()
+		}
+		problem.Errors = fieldErrors(problem.Detail)
+
+		c.Header("Content-Type", ProblemJSON)
+		c.Header("Content-Length", "")
+		c.Writer.WriteHeader(status)
+		body, _ := json.Marshal(problem)
+		c.Writer.Write(body)
+	}
+}
+
+// acceptsProblem reports whether an Accept header lists application/problem+json
+func acceptsProblem(accept string) bool {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(mediaRange, ";", 2)[0])
+		if strings.EqualFold(mediaType, ProblemJSON) {
+			return true
+		}
+	}
+	return false
+}
+
+// problemWriter holds back error responses, status and body, so that they can
+// be rewritten as problem details. Other responses pass through.
+type problemWriter struct {
+	gin.ResponseWriter
+	held bool
+	body bytes.Buffer
+}
 
-			c.Abort()
+func (w *problemWriter) WriteHeaderNow() {
+	if w.Status() >= 400 {
+		w.held = true
+		return
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *problemWriter) Write(data []byte) (int, error) {
+	if w.Status() >= 400 {
+		w.held = true
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *problemWriter) WriteString(s string) (int, error) {
+	if w.Status() >= 400 {
+		w.held = true
+		return w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// fillProblem takes the detail, code and data of a problem from the error
+// response a handler wrote. A body that is not a JSON object is the detail.
+func fillProblem(problem *types.ProblemDetails, body []byte) {
+	var response struct {
+		Error   string          `json:"error"`
+		Message string          `json:"message"`
+		Code    string          `json:"code"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		problem.Detail = strings.TrimSpace(string(body))
+		return
+	}
+	problem.Detail = response.Error
+	if problem.Detail == "" {
+		problem.Detail = response.Message
+	}
+	problem.Code = response.Code
+	if len(response.Data) > 0 && string(response.Data) != "null" {
+		problem.Data = response.Data
+	}
+}
+
+// fieldErrors extracts the invalid fields from a request binding error
+func fieldErrors(detail string) []types.FieldError {
+	if match := unknownFieldError.FindStringSubmatch(detail); match != nil {
+		return []types.FieldError{{Field: match[1], Message: "unknown field"}}
+	}
+	if match := typeError.FindStringSubmatch(detail); match != nil {
+		return []types.FieldError{{Field: match[1], Message: "must be of type " + match[2]}}
+	}
+
+	var errors []types.FieldError
+	for _, match := range validationError.FindAllStringSubmatch(detail, -1) {
+		errors = append(errors, types.FieldError{Field: fieldPath(match[1]), Message: "failed the " + match[2] + " rule"})
+	}
+	return errors
+}
+
+// fieldPath converts the Go field path of a validation error, such as
+// Categories[0] or Items[2].ProductID, to its snake_case JSON form
+func fieldPath(path string) string {
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		name, index := segment, ""
+		if bracket := strings.IndexByte(segment, '['); bracket >= 0 {
+			name, index = segment[:bracket], segment[bracket:]
+		}
+		segments[i] = snakeCase(name) + index
+	}
+	return strings.Join(segments, ".")
+}
+
+// snakeCase converts a Go field name to snake_case, keeping acronyms whole:
+// SKU is sku, ProductIDs is product_ids and IPAddress is ip_address
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var out strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			previous := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// A lone s after an acronym pluralizes it rather than starting a word
+			pluralAcronym := nextLower && runes[i+1] == 's' && (i+2 == len(runes) || unicode.IsUpper(runes[i+2]))
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextLower && !pluralAcronym) {
+				out.WriteByte('_')
+			}
 		}
+		out.WriteRune(unicode.ToLower(r))
 	}
+	return out.String()
 }
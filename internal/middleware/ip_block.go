@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"product-management/internal/repositories"
+	"product-management/internal/types"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ipBlockTTL bounds how long another instance keeps serving an IP after it is
+// blocked, or keeps rejecting it after it is unblocked, since changes only
+// invalidate the local cache
+const ipBlockTTL = 30 * time.Second
+
+// IPBlock rejects requests from IP addresses blocked after suspicious activity.
+// Requests are let through when the block list cannot be read.
+func IPBlock() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		blocked, err := isIPBlocked(c.ClientIP())
+		if err != nil {
+			log.Printf("Warning: failed to check IP block of %s: %v", c.ClientIP(), err)
+		}
+		if blocked {
+			c.JSON(http.StatusForbidden, types.ErrorResponse{Error: "access denied"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// isIPBlocked reports whether an IP is blocked, reading through the cache
+func isIPBlocked(ip string) (bool, error) {
+	var blocked bool
+	if cache.Store.Get(cache.IPBlockKey(ip), &blocked) {
+		return blocked, nil
+	}
+
+	blocked, err := repositories.NewSecurityRepository(database.DB).IsBlocked(ip)
+	if err != nil {
+		return false, err
+	}
+	cache.Store.Set(cache.IPBlockKey(ip), blocked, ipBlockTTL)
+	return blocked, nil
+}
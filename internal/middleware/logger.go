@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"slices"
 	"time"
 
 	"product-management/pkg/logger"
@@ -9,6 +10,21 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// credentialParams are route params that carry credentials: order tracking
+// tokens and gift card codes
+var credentialParams = []string{"token", "code"}
+
+// loggedPath returns the path of a request as logged: its route, such as
+// /api/v1/orders/track/:token, when one of its params is a credential
+func loggedPath(c *gin.Context) string {
+	for _, param := range c.Params {
+		if slices.Contains(credentialParams, param.Key) {
+			return c.FullPath()
+		}
+	}
+	return c.Request.URL.Path
+}
+
 // RequestLogger middleware logs all requests
 func RequestLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -24,7 +40,7 @@ func RequestLogger() gin.HandlerFunc {
 		// Log request details
 		logger.WithFields(logrus.Fields{
 			"method":     c.Request.Method,
-			"path":       c.Request.URL.Path,
+			"path":       loggedPath(c),
 			"status":     c.Writer.Status(),
 			"duration":   duration,
 			"client_ip":  c.ClientIP(),
@@ -43,7 +59,7 @@ func ErrorLogger() gin.HandlerFunc {
 			for _, err := range c.Errors {
 				logger.WithFields(logrus.Fields{
 					"method": c.Request.Method,
-					"path":   c.Request.URL.Path,
+					"path":   loggedPath(c),
 					"status": c.Writer.Status(),
 					"error":  err.Error(),
 				}).Error("Request error")
@@ -59,7 +75,7 @@ func Recovery() gin.HandlerFunc {
 			if err := recover(); err != nil {
 				logger.WithFields(logrus.Fields{
 					"method": c.Request.Method,
-					"path":   c.Request.URL.Path,
+					"path":   loggedPath(c),
 					"error":  err,
 				}).Error("Panic recovered")
 				c.AbortWithStatus(500)
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestLoggedPathMasksCredentials checks that tracking tokens and gift card
+// codes in a path are logged as their route
+func TestLoggedPathMasksCredentials(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	cases := []struct {
+		route string
+		path  string
+		want  string
+	}{
+		{"/orders/track/:token", "/orders/track/3f9c2a", "/orders/track/:token"},
+		{"/gift-cards/:code/redeem", "/gift-cards/K7QM-2XRP-9TWD-HC4N/redeem", "/gift-cards/:code/redeem"},
+		{"/products/:id", "/products/42", "/products/42"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.route, func(t *testing.T) {
+			router := gin.New()
+			var got string
+			router.GET(tc.route, func(c *gin.Context) {
+				got = loggedPath(c)
+			})
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if got != tc.want {
+				t.Errorf("loggedPath = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maintenanceExempt are the API path prefixes served during maintenance, so
+// admins can sign in, check the instance and turn maintenance mode off
+var maintenanceExempt = []string{"/api/v1/admin", "/api/v1/auth"}
+
+// Maintenance rejects API requests with 503 and the maintenance message while
+// maintenance mode is on. Health probes, documentation and admin and auth
+// routes stay available. enabled returns whether it is on and the message,
+// which a configuration reload may change.
+func Maintenance(enabled func() (bool, string)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		on, message := enabled()
+		path := c.Request.URL.Path
+		if !on || !strings.HasPrefix(path, "/api/") {
+			c.Next()
+			return
+		}
+		for _, prefix := range maintenanceExempt {
+			if strings.HasPrefix(path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusServiceUnavailable, types.ErrorResponse{Error: message})
+		c.Abort()
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/internal/types"
+	"product-management/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader carries an API key that was granted its own quota
+const APIKeyHeader = "X-API-Key"
+
+// RateLimit enforces the quota of the calling principal in a route group and
+// reports its usage in X-RateLimit-* headers. The principal is a configured API
+// key, otherwise the authenticated user, otherwise the client IP, and its tier
+// picks the quota. policy returns the quotas in effect, which a configuration
+// reload may change. Install it after AuthMiddleware so users are recognized.
+func RateLimit(limiter *ratelimit.Limiter, policy func() ratelimit.Policy, group string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, tier, limit := resolvePrincipal(c, policy(), group)
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		usage, allowed := limiter.Allow(group, principal, tier, limit)
+		c.Header("X-RateLimit-Limit", strconv.Itoa(usage.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(usage.Remaining()))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(usage.ResetAt.Unix(), 10))
+
+		if !allowed {
+			retryAfter := int(time.Until(usage.ResetAt).Seconds()) + 1
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.JSON(http.StatusTooManyRequests, types.ErrorResponse{Error: "Rate limit exceeded"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// resolvePrincipal identifies the caller and its quota in a route group
+func resolvePrincipal(c *gin.Context, policy ratelimit.Policy, group string) (string, string, int) {
+	if key := c.GetHeader(APIKeyHeader); key != "" {
+		if apiKey, ok := policy.APIKeys[key]; ok {
+			return "key:" + apiKey.Name, ratelimit.TierAPIKey, apiKey.Limit
+		}
+	}
+
+	if userID, ok := c.Get("userID"); ok {
+		tier := ratelimit.TierUser
+		switch c.GetString("role") {
+		case string(models.RoleAdmin):
+			tier = ratelimit.TierAdmin
+		case ratelimit.TierVendor:
+			tier = ratelimit.TierVendor
+		}
+		return fmt.Sprintf("user:%d", userID), tier, policy.Limit(group, tier)
+	}
+
+	// ClientIP only honours forwarding headers from TRUSTED_PROXIES, so callers
+	// cannot spread their requests over made-up addresses
+	return "ip:" + c.ClientIP(), ratelimit.TierAnonymous, policy.Limit(group, ratelimit.TierAnonymous)
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"product-management/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestResolvePrincipalIgnoresSpoofedForwarding checks that anonymous callers
+// can only pick their rate limit principal through a trusted proxy
+func TestResolvePrincipalIgnoresSpoofedForwarding(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	cases := []struct {
+		name       string
+		proxies    []string
+		remoteAddr string
+		want       string
+	}{
+		{"no trusted proxies", nil, "198.51.100.1:4000", "ip:198.51.100.1"},
+		{"untrusted peer", []string{"10.0.0.0/8"}, "198.51.100.1:4000", "ip:198.51.100.1"},
+		{"trusted proxy", []string{"10.0.0.0/8"}, "10.0.0.2:4000", "ip:203.0.113.7"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			if err := router.SetTrustedProxies(tc.proxies); err != nil {
+				t.Fatalf("failed to set trusted proxies: %v", err)
+			}
+			var principal string
+			router.GET("/", func(c *gin.Context) {
+				principal, _, _ = resolvePrincipal(c, ratelimit.Policy{}, "products")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			req.Header.Set("X-Forwarded-For", "203.0.113.7")
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if principal != tc.want {
+				t.Errorf("principal is %q, want %q", principal, tc.want)
+			}
+		})
+	}
+}
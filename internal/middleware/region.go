@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"strings"
+
+	"product-management/pkg/geoip"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Region resolves the caller's country and stores its ISO 3166-1 alpha-2 code
+// in the context as "country". The header, when configured, must be one a
+// trusted proxy or CDN sets and overwrites, since clients could otherwise pick
+// their own market. Without it, or when it holds no code, the client IP is
+// looked up in the GeoIP database. A country that can't be resolved is empty.
+// The city the database places the client IP in is stored as "city" when it
+// agrees with the country, for logs, audit entries and logins.
+func Region(header string, database *geoip.Reader) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		country := ""
+		if header != "" {
+			country = strings.ToUpper(strings.TrimSpace(c.GetHeader(header)))
+			// Responses may differ per country, so caches must key on it
+			c.Writer.Header().Add("Vary", header)
+		}
+		location := database.Locate(c.ClientIP())
+		// Cloudflare reports XX for unknown and T1 for Tor addresses
+		if !isCountryCode(country) || country == "XX" || country == "T1" {
+			country = location.Country
+		}
+		city := ""
+		if location.Country == country {
+			city = location.City
+		}
+		c.Set("country", country)
+		c.Set("city", city)
+		c.Next()
+	}
+}
+
+// requestLocation returns the country and city Region resolved for the request
+func requestLocation(c *gin.Context) geoip.Location {
+	return geoip.Location{Country: c.GetString("country"), City: c.GetString("city")}
+}
+
+// isCountryCode reports whether code looks like an ISO 3166-1 alpha-2 code
+func isCountryCode(code string) bool {
+	return len(code) == 2 && code[0] >= 'A' && code[0] <= 'Z' && code[1] >= 'A' && code[1] <= 'Z'
+}
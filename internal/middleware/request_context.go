@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"product-management/pkg/reqctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Headers a request sets its own currency and time zone with
+const (
+	CurrencyHeader = "X-Currency"
+	TimezoneHeader = "X-Timezone"
+)
+
+// preferencesTTL bounds how long another instance may serve a user with
+// preferences they since changed
+const preferencesTTL = 30 * time.Second
+
+// requestContextKey is the gin context key RequestContext stores its state under
+const requestContextKey = "requestContext"
+
+// requestContextState is what RequestContext stores for a request: the
+// preferences it set in headers, and the context once resolved
+type requestContextState struct {
+	resolver  *reqctx.Resolver
+	requested reqctx.Preferences
+	resolved  *reqctx.Context
+}
+
+// RequestContext resolves the locale, currency and time zone of a request.
+// Each comes from the request first: the best supported language of
+// Accept-Language, and the X-Currency and X-Timezone headers, which are
+// rejected with 400 when not supported. Then from the preferences saved in the
+// user's profile, then from the configured defaults. The profile is read when
+// a handler first asks for the context, after authentication identified the user.
+func RequestContext(resolver *reqctx.Resolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Responses may differ per locale, currency and time zone, so caches must key on them
+		c.Writer.Header().Add("Vary", "Accept-Language, "+CurrencyHeader+", "+TimezoneHeader)
+
+		requested, err := resolver.Validate(reqctx.Preferences{
+			Currency: c.GetHeader(CurrencyHeader),
+			Timezone: c.GetHeader(TimezoneHeader),
+		})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error":  err.Error(),
+				"status": http.StatusBadRequest,
+			})
+			return
+		}
+		requested.Locale = c.GetHeader("Accept-Language")
+
+		c.Set(requestContextKey, &requestContextState{resolver: resolver, requested: requested})
+		c.Next()
+	}
+}
+
+// CurrentRequestContext returns the locale, currency and time zone of a
+// request, resolving them on first use. Without the RequestContext middleware
+// it returns the defaults of reqctx.Default.
+func CurrentRequestContext(c *gin.Context) reqctx.Context {
+	value, ok := c.Get(requestContextKey)
+	if !ok {
+		return reqctx.Default.Defaults()
+	}
+	state := value.(*requestContextState)
+	if state.resolved == nil {
+		var saved reqctx.Preferences
+		if userID := c.GetUint("userID"); userID != 0 {
+			saved = userPreferences(userID)
+		}
+		context := state.resolver.Resolve(state.requested, saved)
+		state.resolved = &context
+		c.Header("Content-Language", context.Locale)
+	}
+	return *state.resolved
+}
+
+// userPreferences returns the preferences a user saved, reading through the
+// cache. They are left out when they can't be read, since the defaults serve
+// the request as well.
+func userPreferences(userID uint) reqctx.Preferences {
+	var preferences reqctx.Preferences
+	if cache.Store.Get(cache.UserPreferencesKey(userID), &preferences) {
+		return preferences
+	}
+	preferences, err := repositories.NewUserRepository(database.DB).GetPreferences(userID)
+	if err != nil {
+		return reqctx.Preferences{}
+	}
+	cache.Store.Set(cache.UserPreferencesKey(userID), preferences, preferencesTTL)
+	return preferences
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"time"
+
+	"product-management/pkg/reqstats"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestStats records the route, status code and latency of every request.
+// Paths matching no route are recorded as "unmatched" so scans of random URLs
+// do not create a row each.
+func RequestStats(recorder *reqstats.Recorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		recorder.Record(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SandboxHeader marks requests meant for the sandbox environment
+const SandboxHeader = "X-Sandbox"
+
+// Sandbox tags responses served by a sandbox deployment. On a production
+// deployment it rejects requests carrying the sandbox header so integration
+// partners never write to production data by mistake.
+func Sandbox(enabled bool, sandboxURL string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if enabled {
+			c.Set("sandbox", true)
+			c.Header(SandboxHeader, "true")
+			c.Next()
+			return
+		}
+
+		if requested, _ := strconv.ParseBool(c.GetHeader(SandboxHeader)); requested {
+			message := "Sandbox mode is not available on this server"
+			if sandboxURL != "" {
+				message = "Sandbox requests must be sent to " + sandboxURL
+			}
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{Error: message})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"product-management/internal/models"
+	"strings"
+)
+
+// scopeRule grants access to routes under a set of path prefixes, optionally
+// limited to read-only methods
+type scopeRule struct {
+	prefixes []string
+	readOnly bool
+}
+
+// scopeRules maps every scope a test token may carry to the routes it grants
+var scopeRules = map[models.Scope]scopeRule{
+	models.ScopeCatalogRead:  {prefixes: []string{"/api/v1/products", "/api/v1/categories", "/api/v1/sync"}, readOnly: true},
+	models.ScopeCatalogWrite: {prefixes: []string{"/api/v1/products", "/api/v1/categories", "/api/v1/sync"}},
+	models.ScopeReviewsRead:  {prefixes: []string{"/api/v1/reviews", "/api/v1/users"}, readOnly: true},
+	models.ScopeReviewsWrite: {prefixes: []string{"/api/v1/reviews"}},
+	models.ScopeAdminRead:    {prefixes: []string{"/api/v1/admin"}, readOnly: true},
+}
+
+// scopesAllow reports whether any of the scopes grants the method on the route
+func scopesAllow(scopes []models.Scope, method, route string) bool {
+	readOnly := method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+	for _, scope := range scopes {
+		rule, ok := scopeRules[scope]
+		if !ok || (rule.readOnly && !readOnly) {
+			continue
+		}
+		for _, prefix := range rule.prefixes {
+			if route == prefix || strings.HasPrefix(route, prefix+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
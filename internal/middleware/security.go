@@ -3,7 +3,6 @@ package middleware
 import (
 	"crypto/rand"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/csrf"
@@ -54,39 +53,3 @@ func CSRFMiddleware() gin.HandlerFunc {
 		})).ServeHTTP(c.Writer, c.Request)
 	}
 }
-
-// RateLimitMiddleware limits the number of requests from a single IP
-func RateLimitMiddleware(limit int, window time.Duration) gin.HandlerFunc {
-	// Create a map to store request counts
-	requests := make(map[string]int)
-	lastReset := make(map[string]time.Time)
-
-	return func(c *gin.Context) {
-		ip := c.ClientIP()
-		now := time.Now()
-
-		// Reset counter if window has passed
-		if lastResetTime, exists := lastReset[ip]; exists {
-			if now.Sub(lastResetTime) > window {
-				requests[ip] = 0
-				lastReset[ip] = now
-			}
-		} else {
-			lastReset[ip] = now
-		}
-
-		// Check if limit has been reached
-		if requests[ip] >= limit {
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error": "Rate limit exceeded",
-			})
-			c.Abort()
-			return
-		}
-
-		// Increment request count
-		requests[ip]++
-
-		c.Next()
-	}
-}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+
+	"product-management/internal/types"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// StrictJSON rejects request bodies that are not sent as application/json with
+// 415 Unsupported Media Type. Combined with binding.EnableDecoderDisallowUnknownFields,
+// which makes JSON binding fail with the name of any unknown field, client typos
+// such as "pricee" fail loudly instead of silently leaving a field at its default.
+// Routes in exempt, by full path, accept other media types such as CSV uploads.
+func StrictJSON(enabled bool, exempt ...string) gin.HandlerFunc {
+	exempted := make(map[string]bool, len(exempt))
+	for _, path := range exempt {
+		exempted[path] = true
+	}
+	return func(c *gin.Context) {
+		if !enabled || c.Request.ContentLength == 0 || exempted[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+			if c.ContentType() != binding.MIMEJSON {
+				c.JSON(http.StatusUnsupportedMediaType, types.ErrorResponse{
+					Error: "Content-Type must be application/json",
+				})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"time"
+)
+
+// tokenVersionTTL bounds how long another instance may accept a revoked token,
+// since bumps only invalidate the local cache
+const tokenVersionTTL = 30 * time.Second
+
+// currentTokenVersion returns the token version of a user, reading through the cache
+func currentTokenVersion(userID uint) (uint, error) {
+	var version uint
+	if cache.Store.Get(cache.TokenVersionKey(userID), &version) {
+		return version, nil
+	}
+
+	version, err := repositories.NewUserRepository(database.DB).GetTokenVersion(userID)
+	if err != nil {
+		return 0, err
+	}
+	cache.Store.Set(cache.TokenVersionKey(userID), version, tokenVersionTTL)
+	return version, nil
+}
+
+// isTokenRevoked reports whether an access token was revoked at logout,
+// reading through the cache. Another instance may accept it for up to
+// tokenVersionTTL after.
+func isTokenRevoked(tokenID string) (bool, error) {
+	var revoked bool
+	if cache.Store.Get(cache.RevokedTokenKey(tokenID), &revoked) {
+		return revoked, nil
+	}
+
+	revoked, err := repositories.NewRefreshTokenRepository(database.DB).IsRevoked(tokenID)
+	if err != nil {
+		return false, err
+	}
+	cache.Store.Set(cache.RevokedTokenKey(tokenID), revoked, tokenVersionTTL)
+	return revoked, nil
+}
@@ -0,0 +1,40 @@
+package models
+
+// AuditAction identifies an action recorded in the audit log
+type AuditAction string
+
+const (
+	AuditUserExport      AuditAction = "user.export"
+	AuditTestTokenMint   AuditAction = "test_token.mint"
+	AuditGiftCardIssue   AuditAction = "gift_card.issue"
+	AuditGiftCardRedeem  AuditAction = "gift_card.redeem"
+	AuditStoreCredit     AuditAction = "store_credit.grant"
+	AuditSegmentNotify   AuditAction = "segment.notify"
+	AuditReportRun       AuditAction = "report.run"
+	AuditUserAnonymize   AuditAction = "user.anonymize"
+	AuditReviewImport    AuditAction = "review.import"
+	AuditOutboxReprocess AuditAction = "outbox.reprocess"
+	AuditSagaRetry       AuditAction = "saga.retry"
+	AuditConfigReload    AuditAction = "config.reload"
+	AuditPricingRuleSave AuditAction = "pricing_rule.save"
+	AuditPricingRuleDrop AuditAction = "pricing_rule.delete"
+	AuditUserSessionsEnd AuditAction = "user.revoke_sessions"
+	AuditProductMerge    AuditAction = "product.merge"
+	AuditIntegrityRepair AuditAction = "integrity.repair"
+	AuditRebuildStart    AuditAction = "rebuild.start"
+)
+
+// AuditLog records a sensitive action performed by a user, for compliance
+type AuditLog struct {
+	BaseModel
+	ActorID uint        `gorm:"not null;index" json:"actor_id"`
+	Action  AuditAction `gorm:"type:varchar(50);not null;index" json:"action"`
+	Details string      `gorm:"type:text" json:"details"`       // JSON describing the action, e.g. the filters of an export
+	Country string      `gorm:"type:varchar(2)" json:"country"` // Where the request came from, when known
+	City    string      `gorm:"type:varchar(100)" json:"city"`
+}
+
+// TableName specifies the table name for the AuditLog model
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
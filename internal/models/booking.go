@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// AvailabilitySlot is a period a bookable product can be reserved for, such
+// as a rental day or a service appointment. Slots of a product never overlap.
+type AvailabilitySlot struct {
+	BaseModel
+	ProductID uint      `gorm:"not null;index:idx_availability_slots_product_starts,priority:1" json:"product_id"`
+	StartsAt  time.Time `gorm:"not null;index:idx_availability_slots_product_starts,priority:2" json:"starts_at"`
+	EndsAt    time.Time `gorm:"not null" json:"ends_at"`
+	Capacity  int       `gorm:"not null" json:"capacity"`         // Units that can be booked, e.g. 1 for a single rental item
+	Booked    int       `gorm:"not null;default:0" json:"booked"` // Units held by confirmed bookings
+}
+
+// TableName specifies the table name for the AvailabilitySlot model
+func (AvailabilitySlot) TableName() string {
+	return "availability_slots"
+}
+
+// Available returns how many units of the slot can still be booked at a time,
+// none once it started
+func (s *AvailabilitySlot) Available(at time.Time) int {
+	if !at.Before(s.StartsAt) || s.Booked >= s.Capacity {
+		return 0
+	}
+	return s.Capacity - s.Booked
+}
+
+// BookingStatus represents the possible statuses of a booking
+type BookingStatus string
+
+const (
+	BookingConfirmed BookingStatus = "confirmed"
+	BookingCancelled BookingStatus = "cancelled"
+)
+
+// Booking is a customer's reservation of units of an availability slot
+type Booking struct {
+	BaseModel
+	SlotID      uint             `gorm:"not null;index" json:"slot_id"`
+	Slot        AvailabilitySlot `gorm:"foreignKey:SlotID" json:"slot"`
+	ProductID   uint             `gorm:"not null;index" json:"product_id"`
+	Product     Product          `gorm:"foreignKey:ProductID" json:"-"`
+	UserID      uint             `gorm:"not null;index" json:"user_id"`
+	Quantity    int              `gorm:"not null" json:"quantity"`
+	Status      BookingStatus    `gorm:"type:varchar(20);not null;default:confirmed" json:"status"`
+	CancelledAt *time.Time       `json:"cancelled_at"`
+}
+
+// TableName specifies the table name for the Booking model
+func (Booking) TableName() string {
+	return "bookings"
+}
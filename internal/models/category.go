@@ -3,9 +3,11 @@ package models
 // Category represents a product category
 type Category struct {
 	BaseModel
-	Name        string    `gorm:"not null" json:"name"`
-	Description string    `json:"description"`
-	Products    []Product `gorm:"many2many:product_categories;" json:"products"`
+	Name           string          `gorm:"not null" json:"name"`
+	Description    string          `json:"description"`
+	MetadataSchema []MetadataField `gorm:"type:jsonb;serializer:json" json:"metadata_schema"` // Metadata keys of its products
+	ProductsCount  int             `gorm:"not null;default:0" json:"products_count"`          // Products linked to it, kept with the links and reconciled periodically
+	Products       []Product       `gorm:"many2many:product_categories;" json:"products"`
 }
 
 // TableName specifies the table name for the Category model
@@ -0,0 +1,14 @@
+package models
+
+// CategoryRevision represents a snapshot of a category taken after each create or update
+type CategoryRevision struct {
+	BaseModel
+	CategoryID uint   `gorm:"not null;uniqueIndex:idx_category_revision" json:"category_id"`
+	Revision   int    `gorm:"not null;uniqueIndex:idx_category_revision" json:"revision"`
+	Snapshot   string `gorm:"type:jsonb;not null" json:"-"`
+}
+
+// TableName specifies the table name for the CategoryRevision model
+func (CategoryRevision) TableName() string {
+	return "category_revisions"
+}
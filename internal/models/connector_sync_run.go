@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// ConnectorSyncStatus represents the state of a connector sync run
+type ConnectorSyncStatus string
+
+const (
+	ConnectorSyncRunning   ConnectorSyncStatus = "running"
+	ConnectorSyncSucceeded ConnectorSyncStatus = "succeeded"
+	ConnectorSyncFailed    ConnectorSyncStatus = "failed" // At least one step failed; the others still ran
+)
+
+// ConnectorSyncRun records one sync of a connector with its legacy system
+type ConnectorSyncRun struct {
+	BaseModel
+	Connector       string              `gorm:"type:varchar(50);not null;index" json:"connector"`
+	TriggeredBy     *uint               `json:"triggered_by"` // Admin who started it, nil for scheduled runs
+	Status          ConnectorSyncStatus `gorm:"type:varchar(20);not null;default:'running'" json:"status"`
+	StartedAt       time.Time           `gorm:"not null;index" json:"started_at"`
+	FinishedAt      *time.Time          `json:"finished_at"`
+	ProductsPulled  int                 `gorm:"not null;default:0" json:"products_pulled"`
+	ProductsCreated int                 `gorm:"not null;default:0" json:"products_created"` // Unknown SKUs, created as drafts
+	ProductsUpdated int                 `gorm:"not null;default:0" json:"products_updated"`
+	StockPulled     int                 `gorm:"not null;default:0" json:"stock_pulled"`
+	StockUpdated    int                 `gorm:"not null;default:0" json:"stock_updated"`
+	Skipped         int                 `gorm:"not null;default:0" json:"skipped"` // Pulled records without an SKU or for an unknown SKU
+	OrdersPushed    int                 `gorm:"not null;default:0" json:"orders_pushed"`
+	OrdersThrough   *time.Time          `json:"orders_through"` // Decision time of the last order pushed so far, carried over from run to run
+	Error           string              `gorm:"type:text" json:"error"`
+}
+
+// TableName specifies the table name for the ConnectorSyncRun model
+func (ConnectorSyncRun) TableName() string {
+	return "connector_sync_runs"
+}
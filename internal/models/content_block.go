@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// ContentBlock is a piece of marketing content, such as a homepage banner,
+// that the storefront looks up by key. A schedule limits when it is shown.
+type ContentBlock struct {
+	BaseModel
+	Key       string     `gorm:"size:100;not null;uniqueIndex" json:"key"`
+	Title     string     `gorm:"size:200" json:"title"`
+	Body      string     `gorm:"type:text" json:"body"` // HTML, served as written
+	ImageURL  string     `gorm:"size:500" json:"image_url"`
+	StartsAt  *time.Time `json:"starts_at"` // Shown from then on, at once when unset
+	EndsAt    *time.Time `json:"ends_at"`   // Hidden from then on, never when unset
+	UpdatedBy uint       `gorm:"not null" json:"updated_by"`
+}
+
+// LiveAt reports whether the block's schedule covers a time
+func (b *ContentBlock) LiveAt(t time.Time) bool {
+	return (b.StartsAt == nil || !t.Before(*b.StartsAt)) && (b.EndsAt == nil || t.Before(*b.EndsAt))
+}
+
+// TableName specifies the table name for the ContentBlock model
+func (ContentBlock) TableName() string {
+	return "content_blocks"
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"time"
+	"unicode/utf8"
+)
+
+// CustomForm is a form admins can add custom fields to
+type CustomForm string
+
+const (
+	FormRegistration CustomForm = "registration" // Values are stored on the registered user
+	FormCheckout     CustomForm = "checkout"     // Values are stored on the quote accepted as an order
+)
+
+// CustomFieldType is the kind of value a custom field takes
+type CustomFieldType string
+
+const (
+	CustomFieldText    CustomFieldType = "text"
+	CustomFieldNumber  CustomFieldType = "number"
+	CustomFieldBoolean CustomFieldType = "boolean"
+	CustomFieldSelect  CustomFieldType = "select" // One of Options
+	CustomFieldDate    CustomFieldType = "date"   // YYYY-MM-DD
+	CustomFieldEmail   CustomFieldType = "email"
+)
+
+// MaxCustomFieldTextLength is how long a text value may be when its field sets
+// no max length
+const MaxCustomFieldTextLength = 1000
+
+// CustomField is an admin-defined field of a form, such as a VAT number asked
+// at registration or a delivery note asked at checkout. It only describes the
+// value and its validation; clients decide how to render it.
+type CustomField struct {
+	BaseModel
+	Form      CustomForm      `gorm:"type:varchar(20);not null;uniqueIndex:idx_custom_fields_key" json:"form"`
+	Key       string          `gorm:"type:varchar(50);not null;uniqueIndex:idx_custom_fields_key" json:"key"` // Name of the value in submitted custom_fields
+	Label     string          `gorm:"type:varchar(100);not null" json:"label"`
+	HelpText  string          `gorm:"type:varchar(255)" json:"help_text"`
+	Type      CustomFieldType `gorm:"type:varchar(20);not null" json:"type"`
+	Required  bool            `gorm:"not null;default:false" json:"required"`
+	Options   []string        `gorm:"type:jsonb;serializer:json" json:"options"` // Choices of select fields
+	Pattern   string          `gorm:"type:varchar(255)" json:"pattern"`          // Regular expression text values must match
+	MinLength *int            `json:"min_length"`                                // Of text values
+	MaxLength *int            `json:"max_length"`                                // Of text values, MaxCustomFieldTextLength when unset
+	Min       *float64        `json:"min"`                                       // Of number values
+	Max       *float64        `json:"max"`                                       // Of number values
+	Position  int             `gorm:"not null;default:0" json:"position"`        // Fields are listed in ascending position
+	UpdatedBy uint            `gorm:"not null" json:"updated_by"`
+}
+
+// TableName specifies the table name for the CustomField model
+func (CustomField) TableName() string {
+	return "custom_fields"
+}
+
+// Check validates a submitted value of the field as decoded from JSON and
+// returns it as stored
+func (f *CustomField) Check(value interface{}) (interface{}, error) {
+	switch f.Type {
+	case CustomFieldNumber:
+		number, ok := value.(float64)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a number", f.Key)
+		}
+		if f.Min != nil && number < *f.Min {
+			return nil, fmt.Errorf("%s must be at least %g", f.Key, *f.Min)
+		}
+		if f.Max != nil && number > *f.Max {
+			return nil, fmt.Errorf("%s must be at most %g", f.Key, *f.Max)
+		}
+		return number, nil
+	case CustomFieldBoolean:
+		if _, ok := value.(bool); !ok {
+			return nil, fmt.Errorf("%s must be true or false", f.Key)
+		}
+		return value, nil
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a string", f.Key)
+	}
+	switch f.Type {
+	case CustomFieldSelect:
+		for _, option := range f.Options {
+			if text == option {
+				return text, nil
+			}
+		}
+		return nil, fmt.Errorf("%s must be one of the field's options", f.Key)
+	case CustomFieldDate:
+		if _, err := time.Parse(time.DateOnly, text); err != nil {
+			return nil, fmt.Errorf("%s must be a date formatted as YYYY-MM-DD", f.Key)
+		}
+		return text, nil
+	case CustomFieldEmail:
+		if address, err := mail.ParseAddress(text); err != nil || address.Address != text {
+			return nil, fmt.Errorf("%s must be an email address", f.Key)
+		}
+		return text, nil
+	}
+
+	length := utf8.RuneCountInString(text)
+	maxLength := MaxCustomFieldTextLength
+	if f.MaxLength != nil {
+		maxLength = *f.MaxLength
+	}
+	if length > maxLength {
+		return nil, fmt.Errorf("%s must be at most %d characters", f.Key, maxLength)
+	}
+	if f.MinLength != nil && length < *f.MinLength {
+		return nil, fmt.Errorf("%s must be at least %d characters", f.Key, *f.MinLength)
+	}
+	if f.Pattern != "" {
+		// Patterns are checked when the field is saved
+		if pattern, err := regexp.Compile(f.Pattern); err == nil && !pattern.MatchString(text) {
+			return nil, fmt.Errorf("%s is not in the expected format", f.Key)
+		}
+	}
+	return text, nil
+}
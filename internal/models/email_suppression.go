@@ -0,0 +1,14 @@
+package models
+
+// EmailSuppression is an address that must not receive email, for example
+// because it bounced permanently
+type EmailSuppression struct {
+	BaseModel
+	Email  string `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
+	Reason string `gorm:"type:text" json:"reason"`
+}
+
+// TableName specifies the table name for the EmailSuppression model
+func (EmailSuppression) TableName() string {
+	return "email_suppressions"
+}
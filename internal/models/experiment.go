@@ -0,0 +1,104 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"time"
+)
+
+// ExperimentStatus represents where an experiment is in its lifecycle
+type ExperimentStatus string
+
+const (
+	ExperimentDraft   ExperimentStatus = "draft"   // Being set up; variants and splits can change
+	ExperimentRunning ExperimentStatus = "running" // Assigning subjects and recording events
+	ExperimentStopped ExperimentStatus = "stopped" // Kept for its results
+)
+
+// ExperimentEventKind identifies what an experiment event records
+type ExperimentEventKind string
+
+const (
+	ExperimentExposure   ExperimentEventKind = "exposure"   // The subject was shown its variant
+	ExperimentConversion ExperimentEventKind = "conversion" // The subject reached a goal
+)
+
+// experimentBuckets is how finely traffic is split, in hundredths of a percent
+const experimentBuckets = 10000
+
+// Experiment is an A/B test between variants. A share of the subjects, users or
+// anonymous visitors, takes part, and each is assigned a variant by weight.
+// Assignments are derived from the experiment key and the subject, so the same
+// subject always gets the same variant without anything being stored.
+type Experiment struct {
+	BaseModel
+	Key         string              `gorm:"type:varchar(100);not null;uniqueIndex" json:"key"`
+	Name        string              `gorm:"type:varchar(100);not null" json:"name"`
+	Description string              `gorm:"type:text" json:"description"`
+	Traffic     int                 `gorm:"not null;default:100" json:"traffic"` // Percentage of subjects taking part
+	Status      ExperimentStatus    `gorm:"type:varchar(20);not null;default:'draft';index" json:"status"`
+	StartedAt   *time.Time          `json:"started_at"`
+	StoppedAt   *time.Time          `json:"stopped_at"`
+	UpdatedBy   uint                `gorm:"not null" json:"updated_by"`
+	Variants    []ExperimentVariant `gorm:"foreignKey:ExperimentID" json:"variants"`
+}
+
+// TableName specifies the table name for the Experiment model
+func (Experiment) TableName() string {
+	return "experiments"
+}
+
+// Assign returns the variant of a subject, or nil when the subject is not
+// taking part. Enrollment and variant are drawn independently, so raising the
+// traffic adds subjects without moving those already assigned.
+func (e *Experiment) Assign(subject string) *ExperimentVariant {
+	if experimentBucket(e.Key, "traffic", subject) >= e.Traffic*experimentBuckets/100 {
+		return nil
+	}
+	bucket := experimentBucket(e.Key, "variant", subject)
+	for i := range e.Variants {
+		bucket -= e.Variants[i].Weight * experimentBuckets / 100
+		if bucket < 0 {
+			return &e.Variants[i]
+		}
+	}
+	return nil
+}
+
+// experimentBucket hashes a subject into one of experimentBuckets buckets for
+// a draw of an experiment
+func experimentBucket(key, draw, subject string) int {
+	sum := sha256.Sum256([]byte(key + "\x00" + draw + "\x00" + subject))
+	return int(binary.BigEndian.Uint64(sum[:8]) % experimentBuckets)
+}
+
+// ExperimentVariant is an arm of an experiment with the percentage of its
+// subjects assigned to it. The weights of an experiment add up to 100.
+type ExperimentVariant struct {
+	ID           uint   `gorm:"primarykey" json:"id"`
+	ExperimentID uint   `gorm:"not null;uniqueIndex:idx_experiment_variant" json:"experiment_id"`
+	Key          string `gorm:"type:varchar(50);not null;uniqueIndex:idx_experiment_variant" json:"key"`
+	Weight       int    `gorm:"not null" json:"weight"`
+}
+
+// TableName specifies the table name for the ExperimentVariant model
+func (ExperimentVariant) TableName() string {
+	return "experiment_variants"
+}
+
+// ExperimentEvent records a subject being exposed to its variant or converting
+type ExperimentEvent struct {
+	ID           uint                `gorm:"primarykey" json:"id"`
+	ExperimentID uint                `gorm:"not null;index:idx_experiment_event" json:"experiment_id"`
+	Variant      string              `gorm:"type:varchar(50);not null" json:"variant"`
+	Subject      string              `gorm:"type:varchar(80);not null;index:idx_experiment_event" json:"subject"` // user:<id> or anon:<anonymous ID>
+	Kind         ExperimentEventKind `gorm:"type:varchar(20);not null" json:"kind"`
+	Goal         string              `gorm:"type:varchar(50)" json:"goal"` // What a conversion reached, such as order
+	Value        float64             `gorm:"not null;default:0" json:"value"`
+	CreatedAt    time.Time           `gorm:"index" json:"created_at"`
+}
+
+// TableName specifies the table name for the ExperimentEvent model
+func (ExperimentEvent) TableName() string {
+	return "experiment_events"
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// FeaturedProduct places a product in the curated spotlight of the storefront
+// homepage. Lower positions come first. A schedule limits when it is shown.
+type FeaturedProduct struct {
+	BaseModel
+	ProductID uint       `gorm:"not null;uniqueIndex" json:"product_id"`
+	Position  int        `gorm:"not null;default:0;index" json:"position"`
+	StartsAt  *time.Time `json:"starts_at"` // Shown from then on, at once when unset
+	EndsAt    *time.Time `json:"ends_at"`   // Hidden from then on, never when unset
+	CreatedBy uint       `gorm:"not null" json:"created_by"`
+}
+
+// LiveAt reports whether the entry's schedule covers a time
+func (f *FeaturedProduct) LiveAt(t time.Time) bool {
+	return (f.StartsAt == nil || !t.Before(*f.StartsAt)) && (f.EndsAt == nil || t.Before(*f.EndsAt))
+}
+
+// TableName specifies the table name for the FeaturedProduct model
+func (FeaturedProduct) TableName() string {
+	return "featured_products"
+}
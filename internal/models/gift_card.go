@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// GiftCard is a prepaid balance redeemable by code
+type GiftCard struct {
+	BaseModel
+	Code           string     `gorm:"type:varchar(19);uniqueIndex;not null" json:"code"` // XXXX-XXXX-XXXX-XXXX
+	InitialBalance float64    `gorm:"not null" json:"initial_balance"`
+	Balance        float64    `gorm:"not null" json:"balance"`
+	ExpiresAt      *time.Time `json:"expires_at"` // Never expires when nil
+	IssuedBy       uint       `gorm:"not null" json:"issued_by"`
+}
+
+// TableName specifies the table name for the GiftCard model
+func (GiftCard) TableName() string {
+	return "gift_cards"
+}
+
+// Expired reports whether the gift card can no longer be used at a time
+func (g *GiftCard) Expired(at time.Time) bool {
+	return g.ExpiresAt != nil && !at.Before(*g.ExpiresAt)
+}
+
+// GiftCardEntryKind represents what changed a gift card's balance
+type GiftCardEntryKind string
+
+const (
+	GiftCardEntryIssue   GiftCardEntryKind = "issue"
+	GiftCardEntryRedeem  GiftCardEntryKind = "redeem"  // Applied to an amount due
+	GiftCardEntryRestore GiftCardEntryKind = "restore" // Given back when the payment it covered was voided
+)
+
+// GiftCardEntry is a single change of a gift card's balance, so every
+// redemption can be traced to what it paid for
+type GiftCardEntry struct {
+	BaseModel
+	GiftCardID       uint              `gorm:"not null;index" json:"gift_card_id"`
+	Kind             GiftCardEntryKind `gorm:"type:varchar(20);not null" json:"kind"`
+	Reference        string            `gorm:"index" json:"reference"` // ID of the order or purchase paid for
+	Delta            float64           `gorm:"not null" json:"delta"`
+	ResultingBalance float64           `gorm:"not null" json:"resulting_balance"`
+	ActorID          uint              `json:"actor_id"`
+	Note             string            `json:"note"`
+}
+
+// TableName specifies the table name for the GiftCardEntry model
+func (GiftCardEntry) TableName() string {
+	return "gift_card_entries"
+}
+
+// StoreCreditSource represents what caused a store credit entry
+type StoreCreditSource string
+
+const (
+	StoreCreditSourceGrant    StoreCreditSource = "grant"   // Issued by an admin, e.g. as a goodwill gesture
+	StoreCreditSourcePayment  StoreCreditSource = "payment" // Spent at payment time
+	StoreCreditSourceRefund   StoreCreditSource = "refund"
+	StoreCreditSourceReferral StoreCreditSource = "referral" // Reward for a referred user's first order
+)
+
+// StoreCreditEntry is a single entry in a user's store credit ledger
+type StoreCreditEntry struct {
+	BaseModel
+	UserID           uint              `gorm:"not null;index" json:"user_id"`
+	Source           StoreCreditSource `gorm:"type:varchar(20);not null" json:"source"`
+	Reference        string            `json:"reference"` // Optional ID of the order, refund, etc.
+	Delta            float64           `gorm:"not null" json:"delta"`
+	ResultingBalance float64           `gorm:"not null" json:"resulting_balance"`
+	ActorID          uint              `json:"actor_id"`
+	Note             string            `json:"note"`
+}
+
+// TableName specifies the table name for the StoreCreditEntry model
+func (StoreCreditEntry) TableName() string {
+	return "store_credit_entries"
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+)
+
+// MetadataType is the type of a product metadata value
+type MetadataType string
+
+const (
+	MetadataString  MetadataType = "string"
+	MetadataNumber  MetadataType = "number"
+	MetadataBoolean MetadataType = "boolean"
+)
+
+// Limits of product metadata
+const (
+	MaxMetadataKeys        = 50
+	MaxMetadataValueLength = 500
+)
+
+// MetadataKeyPattern is what metadata keys look like, so they can be used as
+// meta.{key} query parameters
+var MetadataKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// MetadataField defines a metadata key of the products of a category
+type MetadataField struct {
+	Key      string       `json:"key"`
+	Type     MetadataType `json:"type"`
+	Required bool         `json:"required"`
+}
+
+// ValidateMetadata checks product metadata against the schemas of the
+// product's categories. Every value must be a string, number or boolean; keys
+// a category defines must have its type and required ones must be present.
+// Keys no category defines are allowed.
+func ValidateMetadata(metadata map[string]interface{}, categories []Category) error {
+	if len(metadata) > MaxMetadataKeys {
+		return fmt.Errorf("at most %d keys are allowed", MaxMetadataKeys)
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !MetadataKeyPattern.MatchString(key) {
+			return fmt.Errorf("key %q must be lowercase letters, digits and underscores, starting with a letter", key)
+		}
+		switch value := metadata[key].(type) {
+		case string:
+			if utf8.RuneCountInString(value) > MaxMetadataValueLength {
+				return fmt.Errorf("key %q must be at most %d characters", key, MaxMetadataValueLength)
+			}
+		case float64, bool:
+		default:
+			return fmt.Errorf("key %q must be a string, number or boolean", key)
+		}
+	}
+
+	for _, category := range categories {
+		for _, field := range category.MetadataSchema {
+			value, ok := metadata[field.Key]
+			if !ok {
+				if field.Required {
+					return fmt.Errorf("key %q is required by category %q", field.Key, category.Name)
+				}
+				continue
+			}
+			if metadataType(value) != field.Type {
+				return fmt.Errorf("key %q must be a %s for category %q", field.Key, field.Type, category.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// metadataType returns the type of a metadata value decoded from JSON
+func metadataType(value interface{}) MetadataType {
+	switch value.(type) {
+	case float64:
+		return MetadataNumber
+	case bool:
+		return MetadataBoolean
+	default:
+		return MetadataString
+	}
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+
+	_ "product-management/pkg/fieldcrypt" // Registers the encrypted serializer
+)
+
+// NotificationKind identifies a kind of notification users can opt in or out of
+type NotificationKind string
+
+const (
+	NotificationSecurityAlert NotificationKind = "security_alert"
+	NotificationOrderUpdate   NotificationKind = "order_update"
+	NotificationPromotion     NotificationKind = "promotion" // Sent to segments by admins, by email and push
+)
+
+// NotificationChannels selects the channels a kind of notification is delivered on
+type NotificationChannels struct {
+	Email bool `gorm:"not null" json:"email"`
+	SMS   bool `gorm:"not null" json:"sms"`
+	Push  bool `gorm:"not null" json:"push"`
+}
+
+// NotificationSettings holds a user's notification preferences and the
+// addresses of the SMS and push channels
+type NotificationSettings struct {
+	BaseModel
+	UserID             uint                 `gorm:"not null;uniqueIndex" json:"user_id"`
+	SecurityAlerts     NotificationChannels `gorm:"embedded;embeddedPrefix:security_alerts_" json:"security_alerts"`
+	OrderUpdates       NotificationChannels `gorm:"embedded;embeddedPrefix:order_updates_" json:"order_updates"`
+	PhoneNumber        string               `gorm:"type:text;serializer:encrypted" json:"phone_number"` // E.164, e.g. +14155550123; encrypted at rest
+	PushToken          string               `gorm:"type:text;serializer:encrypted" json:"-"`            // FCM registration token of the user's device; encrypted at rest
+	DigestUnsubscribed bool                 `gorm:"not null;default:false" json:"digest_unsubscribed"`  // Opt-out, so users without settings get digests
+	PromotionsOptedOut bool                 `gorm:"not null;default:false" json:"promotions_opted_out"`
+}
+
+// TableName specifies the table name for the NotificationSettings model
+func (NotificationSettings) TableName() string {
+	return "notification_settings"
+}
+
+// DefaultNotificationSettings returns the settings of a user who never changed them
+func DefaultNotificationSettings(userID uint) *NotificationSettings {
+	return &NotificationSettings{
+		UserID:         userID,
+		SecurityAlerts: NotificationChannels{Email: true},
+		OrderUpdates:   NotificationChannels{Email: true},
+	}
+}
+
+// Channels returns the channels selected for a kind of notification
+func (s *NotificationSettings) Channels(kind NotificationKind) NotificationChannels {
+	switch kind {
+	case NotificationSecurityAlert:
+		return s.SecurityAlerts
+	case NotificationOrderUpdate:
+		return s.OrderUpdates
+	case NotificationPromotion:
+		return NotificationChannels{Email: !s.PromotionsOptedOut, Push: !s.PromotionsOptedOut}
+	default:
+		return NotificationChannels{}
+	}
+}
+
+// KnownDevice is a device a user has logged in from, identified by a hash of
+// its user agent, so logins from new devices can raise a security alert
+type KnownDevice struct {
+	BaseModel
+	UserID      uint      `gorm:"not null;uniqueIndex:idx_known_devices_user_fingerprint" json:"user_id"`
+	Fingerprint string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_known_devices_user_fingerprint" json:"fingerprint"`
+	UserAgent   string    `gorm:"type:text" json:"user_agent"`
+	LastIP      string    `gorm:"type:varchar(45)" json:"last_ip"`
+	LastCountry string    `gorm:"type:varchar(2)" json:"last_country"` // Where LastIP is, when known
+	LastCity    string    `gorm:"type:varchar(100)" json:"last_city"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+// TableName specifies the table name for the KnownDevice model
+func (KnownDevice) TableName() string {
+	return "known_devices"
+}
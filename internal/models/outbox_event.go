@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// OutboxStatus represents the delivery state of an outbox event
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "pending" // Waiting for the relay, possibly after failed attempts
+	OutboxPublished OutboxStatus = "published"
+	OutboxFailed    OutboxStatus = "failed" // Gave up after the maximum attempts, until an admin reprocesses it
+)
+
+// OutboxEvent is an event recorded in the same transaction as the change it
+// reports, then published by the relay
+type OutboxEvent struct {
+	BaseModel
+	Topic         string       `gorm:"type:varchar(100);not null;index" json:"topic"`
+	Payload       string       `gorm:"type:text;not null" json:"payload"` // JSON of the event
+	Status        OutboxStatus `gorm:"type:varchar(20);not null;default:'pending';index:idx_outbox_events_due" json:"status"`
+	NextAttemptAt time.Time    `gorm:"not null;index:idx_outbox_events_due" json:"next_attempt_at"`
+	Attempts      int          `gorm:"not null;default:0" json:"attempts"`
+	LastError     string       `gorm:"type:text" json:"last_error"`
+	PublishedAt   *time.Time   `json:"published_at"`
+}
+
+// TableName specifies the table name for the OutboxEvent model
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -0,0 +1,30 @@
+package models
+
+// PriceList holds customer-specific prices for products, for wholesale
+// customers assigned to it. Products without an item keep their list price.
+type PriceList struct {
+	BaseModel
+	Name        string          `gorm:"type:varchar(100);not null;uniqueIndex" json:"name"`
+	Description string          `gorm:"type:text" json:"description"`
+	Items       []PriceListItem `gorm:"foreignKey:PriceListID" json:"items"`
+}
+
+// TableName specifies the table name for the PriceList model
+func (PriceList) TableName() string {
+	return "price_lists"
+}
+
+// PriceListItem is the unit price of a product in a price list from a minimum
+// quantity on. Several items for one product make quantity breaks.
+type PriceListItem struct {
+	BaseModel
+	PriceListID uint    `gorm:"not null;uniqueIndex:idx_price_list_item" json:"price_list_id"`
+	ProductID   uint    `gorm:"not null;uniqueIndex:idx_price_list_item;index" json:"product_id"`
+	MinQuantity int     `gorm:"not null;default:1;uniqueIndex:idx_price_list_item" json:"min_quantity"`
+	Price       float64 `gorm:"not null" json:"price"`
+}
+
+// TableName specifies the table name for the PriceListItem model
+func (PriceListItem) TableName() string {
+	return "price_list_items"
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// PricingAdjustment is how a pricing rule changes the price of the products it matches
+type PricingAdjustment string
+
+const (
+	PricingPercentOff PricingAdjustment = "percent_off" // Value is a percentage of the price
+	PricingAmountOff  PricingAdjustment = "amount_off"  // Value is subtracted from the price
+	PricingFixedPrice PricingAdjustment = "fixed_price" // Value replaces the price
+)
+
+// PricingRule lowers the price of the products matching its conditions while
+// its schedule covers the current time, such as 10% off a category for a
+// weekend or a clearance price for slow sellers. When several rules match a
+// product, the one with the highest priority applies.
+type PricingRule struct {
+	BaseModel
+	Name        string            `gorm:"type:varchar(100);not null" json:"name"`
+	Description string            `gorm:"type:text" json:"description"`
+	Priority    int               `gorm:"not null;default:0" json:"priority"` // Higher wins; ties go to the older rule
+	Enabled     bool              `gorm:"not null" json:"enabled"`
+	StartsAt    *time.Time        `json:"starts_at"`                // Applies from then on, at once when unset
+	EndsAt      *time.Time        `json:"ends_at"`                  // Stops applying then, never when unset
+	CategoryID  *uint             `gorm:"index" json:"category_id"` // Only products in the category
+	MinStock    *int              `json:"min_stock"`                // Only products with more units in stock
+	NoSalesDays *int              `json:"no_sales_days"`            // Only products with no order in that many days
+	Adjustment  PricingAdjustment `gorm:"type:varchar(20);not null" json:"adjustment"`
+	Value       float64           `gorm:"not null" json:"value"`
+	UpdatedBy   uint              `gorm:"not null" json:"updated_by"`
+}
+
+// TableName specifies the table name for the PricingRule model
+func (PricingRule) TableName() string {
+	return "pricing_rules"
+}
+
+// LiveAt reports whether the rule is enabled and its schedule covers a time
+func (r *PricingRule) LiveAt(t time.Time) bool {
+	return r.Enabled && (r.StartsAt == nil || !t.Before(*r.StartsAt)) && (r.EndsAt == nil || t.Before(*r.EndsAt))
+}
+
+// Adjust returns a price after the rule's adjustment, rounded to cents and
+// never below zero
+func (r *PricingRule) Adjust(price float64) float64 {
+	switch r.Adjustment {
+	case PricingPercentOff:
+		price = price * (100 - r.Value) / 100
+	case PricingAmountOff:
+		price -= r.Value
+	case PricingFixedPrice:
+		price = r.Value
+	}
+	return math.Max(math.Round(price*100)/100, 0)
+}
@@ -1,5 +1,7 @@
 package models
 
+import "strings"
+
 // ProductStatus represents the possible statuses of a product
 type ProductStatus string
 
@@ -12,14 +14,80 @@ const (
 // Product represents a product in the store
 type Product struct {
 	BaseModel
-	Name          string        `gorm:"not null" json:"name"`
-	Description   string        `json:"description"`
-	Price         float64       `gorm:"not null" json:"price"`
-	StockQuantity int           `gorm:"not null;default:0" json:"stock_quantity"`
-	Status        ProductStatus `gorm:"default:active" json:"status"`
-	Reviews       []Review      `json:"reviews"`
-	Categories    []Category    `gorm:"many2many:product_categories;" json:"categories"`
-	Wishlists     []Wishlist    `json:"wishlists"`
+	Name             string                 `gorm:"not null" json:"name"`
+	Description      string                 `json:"description"`
+	SKU              *string                `gorm:"type:varchar(64);uniqueIndex" json:"sku"` // Stock keeping unit printed on labels, optional
+	Location         string                 `gorm:"type:varchar(50);index" json:"location"`  // Where it is stored in the warehouse, e.g. aisle A, shelf 03, bin 2 as A-03-2
+	Price            float64                `gorm:"not null" json:"price"`
+	StockQuantity    int                    `gorm:"not null;default:0" json:"stock_quantity"`
+	Status           ProductStatus          `gorm:"default:active" json:"status"`
+	Bookable         bool                   `gorm:"not null;default:false" json:"bookable"` // Reserved for availability slots rather than sold from stock, e.g. rentals and services
+	RatingAverage    float64                `gorm:"not null;default:0;index" json:"rating_average"`
+	RatingCount      int                    `gorm:"not null;default:0" json:"rating_count"`
+	AllowedCountries []string               `gorm:"type:jsonb;serializer:json" json:"allowed_countries"`                             // ISO 3166-1 alpha-2 codes of the only markets it is sold in, empty for all
+	BlockedCountries []string               `gorm:"type:jsonb;serializer:json" json:"blocked_countries"`                             // ISO 3166-1 alpha-2 codes of markets it is withheld from
+	Metadata         map[string]interface{} `gorm:"type:jsonb;serializer:json;index:idx_products_metadata,type:gin" json:"metadata"` // Custom attributes, validated by the schemas of its categories
+	Reviews          []Review               `json:"reviews"`
+	Categories       []Category             `gorm:"many2many:product_categories;" json:"categories"`
+	Images           []ProductImage         `json:"images"`
+	Wishlists        []Wishlist             `json:"wishlists"`
+}
+
+// SKUValue returns the product's SKU, or an empty string when it has none
+func (p *Product) SKUValue() string {
+	if p.SKU == nil {
+		return ""
+	}
+	return *p.SKU
+}
+
+// OptionalSKU converts an SKU from a request to its stored form, where an
+// empty SKU is NULL so that products without one don't collide
+func OptionalSKU(sku string) *string {
+	sku = strings.TrimSpace(sku)
+	if sku == "" {
+		return nil
+	}
+	return &sku
+}
+
+// NormalizeLocation converts a warehouse location from a request to its stored
+// form, upper-cased so that a-03-2 and A-03-2 are grouped together
+func NormalizeLocation(location string) string {
+	return strings.ToUpper(strings.TrimSpace(location))
+}
+
+// AvailableIn reports whether the product may be shown and sold in a country.
+// Products limited to an allow list are unavailable when the country is unknown.
+func (p *Product) AvailableIn(country string) bool {
+	for _, blocked := range p.BlockedCountries {
+		if blocked == country {
+			return false
+		}
+	}
+	if len(p.AllowedCountries) == 0 {
+		return true
+	}
+	for _, allowed := range p.AllowedCountries {
+		if allowed == country {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeCountries upper-cases and deduplicates country codes from a request
+func NormalizeCountries(countries []string) []string {
+	normalized := make([]string, 0, len(countries))
+	seen := make(map[string]bool, len(countries))
+	for _, country := range countries {
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if !seen[country] {
+			seen[country] = true
+			normalized = append(normalized, country)
+		}
+	}
+	return normalized
 }
 
 // TableName specifies the table name for the Product model
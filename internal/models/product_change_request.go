@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// ChangeRequestStatus represents the review state of a product change request
+type ChangeRequestStatus string
+
+const (
+	ChangeRequestPending  ChangeRequestStatus = "pending"
+	ChangeRequestApproved ChangeRequestStatus = "approved"
+	ChangeRequestRejected ChangeRequestStatus = "rejected"
+)
+
+// ProductChangeRequest represents a proposed product edit awaiting admin review
+type ProductChangeRequest struct {
+	BaseModel
+	ProductID   uint                `gorm:"not null;index" json:"product_id"`
+	Product     Product             `gorm:"foreignKey:ProductID" json:"-"`
+	RequestedBy uint                `gorm:"not null;index" json:"requested_by"`
+	Status      ChangeRequestStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	Proposed    string              `gorm:"type:jsonb;not null" json:"-"` // Full proposed product state
+	Diff        string              `gorm:"type:jsonb;not null" json:"-"` // Changed fields with old and new values
+	ReviewedBy  *uint               `json:"reviewed_by"`
+	ReviewedAt  *time.Time          `json:"reviewed_at"`
+	ReviewNote  string              `json:"review_note"`
+}
+
+// TableName specifies the table name for the ProductChangeRequest model
+func (ProductChangeRequest) TableName() string {
+	return "product_change_requests"
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// ProductImage is an image of a product kept in object storage. Images are
+// shown in ascending position, the first one being the product's main image.
+type ProductImage struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	ProductID   uint      `gorm:"not null;index" json:"product_id"`
+	Key         string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"key"` // Storage key, products/{id}/images/{name}
+	ContentType string    `gorm:"type:varchar(50);not null" json:"content_type"`
+	Size        int64     `gorm:"not null" json:"size"`
+	Alt         string    `gorm:"type:varchar(255)" json:"alt"` // Alternative text for screen readers
+	Position    int       `gorm:"not null;default:0" json:"position"`
+	UploadedBy  uint      `gorm:"not null" json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for the ProductImage model
+func (ProductImage) TableName() string {
+	return "product_images"
+}
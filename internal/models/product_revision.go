@@ -0,0 +1,16 @@
+package models
+
+// ProductRevision represents a snapshot of a product taken after each create or update
+type ProductRevision struct {
+	BaseModel
+	ProductID uint    `gorm:"not null;uniqueIndex:idx_product_revision" json:"product_id"`
+	Product   Product `gorm:"foreignKey:ProductID" json:"-"`
+	Revision  int     `gorm:"not null;uniqueIndex:idx_product_revision" json:"revision"`
+	Snapshot  string  `gorm:"type:jsonb;not null" json:"-"`
+	EditedBy  uint    `gorm:"not null" json:"edited_by"`
+}
+
+// TableName specifies the table name for the ProductRevision model
+func (ProductRevision) TableName() string {
+	return "product_revisions"
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// ProductView records a product page viewed by a signed-in user or by an
+// anonymous visitor identified by the client, or by neither
+type ProductView struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	ProductID   uint      `gorm:"not null;index" json:"product_id"`
+	UserID      *uint     `gorm:"index" json:"user_id"`
+	AnonymousID string    `gorm:"type:varchar(64)" json:"anonymous_id"`
+	Referrer    string    `gorm:"type:varchar(500)" json:"referrer"`
+	ViewedAt    time.Time `gorm:"not null;index" json:"viewed_at"`
+}
+
+// TableName specifies the table name for the ProductView model
+func (ProductView) TableName() string {
+	return "product_views"
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// PromotionStatus represents where a promotion is in its schedule
+type PromotionStatus string
+
+const (
+	PromotionScheduled PromotionStatus = "scheduled" // Waiting for the scheduler to start it
+	PromotionActive    PromotionStatus = "active"    // Started by the scheduler; products show the promotional price
+	PromotionEnded     PromotionStatus = "ended"
+)
+
+// Promotion is a sale of a product, or of every product in a category, at a
+// discount from its list price between two times. The scheduler moves it from
+// scheduled to active to ended; only active promotions change prices.
+type Promotion struct {
+	BaseModel
+	Name       string            `gorm:"type:varchar(100);not null" json:"name"`
+	ProductID  *uint             `gorm:"index" json:"product_id"`                   // Set for a single product
+	CategoryID *uint             `gorm:"index" json:"category_id"`                  // Set for every product in a category
+	Discount   PricingAdjustment `gorm:"type:varchar(20);not null" json:"discount"` // percent_off or amount_off
+	Value      float64           `gorm:"not null" json:"value"`
+	StartsAt   time.Time         `gorm:"not null;index" json:"starts_at"`
+	EndsAt     time.Time         `gorm:"not null;index" json:"ends_at"`
+	Status     PromotionStatus   `gorm:"type:varchar(20);not null;default:'scheduled';index" json:"status"`
+	UpdatedBy  uint              `gorm:"not null" json:"updated_by"`
+}
+
+// TableName specifies the table name for the Promotion model
+func (Promotion) TableName() string {
+	return "promotions"
+}
+
+// StatusAt returns the status the schedule gives the promotion at a time
+func (p *Promotion) StatusAt(t time.Time) PromotionStatus {
+	switch {
+	case t.Before(p.StartsAt):
+		return PromotionScheduled
+	case t.Before(p.EndsAt):
+		return PromotionActive
+	default:
+		return PromotionEnded
+	}
+}
+
+// Price returns a list price after the promotion's discount
+func (p *Promotion) Price(listPrice float64) float64 {
+	rule := PricingRule{Adjustment: p.Discount, Value: p.Value}
+	return rule.Adjust(listPrice)
+}
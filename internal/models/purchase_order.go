@@ -0,0 +1,71 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Supplier is a vendor products are restocked from
+type Supplier struct {
+	BaseModel
+	Name        string `gorm:"type:varchar(100);not null;uniqueIndex" json:"name"`
+	ContactName string `gorm:"type:varchar(100)" json:"contact_name"`
+	Email       string `gorm:"type:varchar(255)" json:"email"`
+	Phone       string `gorm:"type:varchar(30)" json:"phone"`
+	Notes       string `gorm:"type:text" json:"notes"`
+}
+
+// TableName specifies the table name for the Supplier model
+func (Supplier) TableName() string {
+	return "suppliers"
+}
+
+// PurchaseOrderStatus represents how much of a purchase order was delivered
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderOpen      PurchaseOrderStatus = "open"    // Nothing received yet
+	PurchaseOrderPartial   PurchaseOrderStatus = "partial" // Some items received
+	PurchaseOrderReceived  PurchaseOrderStatus = "received"
+	PurchaseOrderCancelled PurchaseOrderStatus = "cancelled" // Closed with items still outstanding
+)
+
+// PurchaseOrder restocks products from a supplier. Receiving its items adds
+// them to stock through the stock ledger.
+type PurchaseOrder struct {
+	BaseModel
+	SupplierID uint                `gorm:"not null;index" json:"supplier_id"`
+	Supplier   Supplier            `gorm:"foreignKey:SupplierID" json:"-"`
+	Status     PurchaseOrderStatus `gorm:"type:varchar(20);not null;default:'open';index" json:"status"`
+	ExpectedAt *time.Time          `json:"expected_at"`
+	Notes      string              `gorm:"type:text" json:"notes"`
+	CreatedBy  uint                `gorm:"not null" json:"created_by"`
+	ClosedAt   *time.Time          `json:"closed_at"` // When fully received or cancelled
+	Items      []PurchaseOrderItem `gorm:"foreignKey:PurchaseOrderID" json:"items"`
+}
+
+// TableName specifies the table name for the PurchaseOrder model
+func (PurchaseOrder) TableName() string {
+	return "purchase_orders"
+}
+
+// Reference returns the reference of the purchase order in the stock ledger
+func (po *PurchaseOrder) Reference() string {
+	return fmt.Sprintf("PO-%d", po.ID)
+}
+
+// PurchaseOrderItem is a product and quantity ordered from a supplier
+type PurchaseOrderItem struct {
+	BaseModel
+	PurchaseOrderID  uint    `gorm:"not null;index" json:"purchase_order_id"`
+	ProductID        uint    `gorm:"not null;index" json:"product_id"`
+	Product          Product `gorm:"foreignKey:ProductID" json:"-"`
+	QuantityOrdered  int     `gorm:"not null" json:"quantity_ordered"`
+	QuantityReceived int     `gorm:"not null;default:0" json:"quantity_received"`
+	UnitCost         float64 `gorm:"not null" json:"unit_cost"`
+}
+
+// TableName specifies the table name for the PurchaseOrderItem model
+func (PurchaseOrderItem) TableName() string {
+	return "purchase_order_items"
+}
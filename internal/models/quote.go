@@ -0,0 +1,106 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// QuoteStatus represents the state of a request for quote
+type QuoteStatus string
+
+const (
+	QuoteRequested QuoteStatus = "requested" // Waiting for an admin to price it
+	QuoteQuoted    QuoteStatus = "quoted"    // Priced, waiting for the customer until ValidUntil
+	QuoteAccepted  QuoteStatus = "accepted"
+	QuoteDeclined  QuoteStatus = "declined"
+)
+
+// Quote is a customer's request for a custom price on a set of products and
+// quantities, and the admin's answer to it
+type Quote struct {
+	BaseModel
+	UserID       uint                   `gorm:"not null;index" json:"user_id"`
+	Status       QuoteStatus            `gorm:"type:varchar(20);not null;default:'requested';index" json:"status"`
+	Note         string                 `gorm:"type:text" json:"note"`                // From the customer
+	Country      string                 `gorm:"type:varchar(2);index" json:"country"` // Where the customer requested it from, when known
+	ResponseNote string                 `gorm:"type:text" json:"response_note"`       // From the admin
+	ValidUntil   *time.Time             `json:"valid_until"`                          // Set when quoted; the quote can no longer be accepted after it
+	RespondedBy  *uint                  `json:"responded_by"`
+	RespondedAt  *time.Time             `json:"responded_at"`
+	DecidedAt    *time.Time             `json:"decided_at"`                                      // When the customer accepted or declined
+	CustomFields map[string]interface{} `gorm:"type:jsonb;serializer:json" json:"custom_fields"` // Values of the checkout form's custom fields, set when accepted
+	Items        []QuoteItem            `gorm:"foreignKey:QuoteID" json:"items"`
+}
+
+// TableName specifies the table name for the Quote model
+func (Quote) TableName() string {
+	return "quotes"
+}
+
+// Reference returns the reference of the quote in systems orders are synced to
+func (q *Quote) Reference() string {
+	return fmt.Sprintf("Q-%d", q.ID)
+}
+
+// Expired reports whether a quoted price is no longer valid at the given time
+func (q *Quote) Expired(at time.Time) bool {
+	return q.Status == QuoteQuoted && q.ValidUntil != nil && !at.Before(*q.ValidUntil)
+}
+
+// Subtotal returns the quoted price of the items, or zero before the quote is priced
+func (q *Quote) Subtotal() float64 {
+	subtotal := 0.0
+	for _, item := range q.Items {
+		if item.QuotedPrice != nil {
+			subtotal += *item.QuotedPrice * float64(item.Quantity)
+		}
+	}
+	return subtotal
+}
+
+// GiftWrapFees returns the fees charged for gift wrapping items, set when the
+// quote is accepted
+func (q *Quote) GiftWrapFees() float64 {
+	fees := 0.0
+	for _, item := range q.Items {
+		fees += item.GiftWrapFee
+	}
+	return fees
+}
+
+// Total returns the quoted total with gift wrapping, or zero before the quote is priced
+func (q *Quote) Total() float64 {
+	return q.Subtotal() + q.GiftWrapFees()
+}
+
+// HidesPrices reports whether an item's price is left off the invoice, in
+// which case so are the totals
+func (q *Quote) HidesPrices() bool {
+	for _, item := range q.Items {
+		if item.HidePrice {
+			return true
+		}
+	}
+	return false
+}
+
+// QuoteItem is a product and quantity in a quote
+type QuoteItem struct {
+	BaseModel
+	QuoteID       uint     `gorm:"not null;index" json:"quote_id"`
+	ProductID     uint     `gorm:"not null" json:"product_id"`
+	Product       Product  `gorm:"foreignKey:ProductID" json:"-"`
+	Quantity      int      `gorm:"not null" json:"quantity"`
+	UnitPrice     float64  `gorm:"not null" json:"unit_price"`   // What the customer would pay without the quote
+	PricingRuleID *uint    `gorm:"index" json:"pricing_rule_id"` // Pricing rule that lowered UnitPrice, if any
+	QuotedPrice   *float64 `json:"quoted_price"`                 // Unit price offered by the admin
+	GiftWrap      bool     `gorm:"not null;default:false" json:"gift_wrap"`
+	GiftMessage   string   `gorm:"type:varchar(500)" json:"gift_message"`    // Printed on the packing slip
+	HidePrice     bool     `gorm:"not null;default:false" json:"hide_price"` // Left off the invoice, such as for a gift
+	GiftWrapFee   float64  `gorm:"not null;default:0" json:"gift_wrap_fee"`  // Charged for wrapping every unit, set when accepted
+}
+
+// TableName specifies the table name for the QuoteItem model
+func (QuoteItem) TableName() string {
+	return "quote_items"
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// RebuildTarget is what a rebuild run recomputes
+type RebuildTarget string
+
+const (
+	RebuildSearch RebuildTarget = "search" // The product rating summaries search sorts and filters by
+	RebuildCache  RebuildTarget = "cache"  // Cached categories with their product counts, hot products and listings
+)
+
+// RebuildStatus represents the state of a rebuild run
+type RebuildStatus string
+
+const (
+	RebuildRunning   RebuildStatus = "running"
+	RebuildSucceeded RebuildStatus = "succeeded"
+	RebuildFailed    RebuildStatus = "failed"
+)
+
+// RebuildRun records one background rebuild of derived data. The rebuilt data
+// replaces the live data in one step when the run completes, so until then
+// readers keep being served the previous data.
+type RebuildRun struct {
+	BaseModel
+	Target      RebuildTarget `gorm:"type:varchar(20);not null;index" json:"target"`
+	TriggeredBy *uint         `json:"triggered_by"` // Admin who started it, nil for the admin command
+	Status      RebuildStatus `gorm:"type:varchar(20);not null;default:'running'" json:"status"`
+	StartedAt   time.Time     `gorm:"not null;index" json:"started_at"`
+	FinishedAt  *time.Time    `json:"finished_at"`
+	Total       int           `gorm:"not null;default:0" json:"total"`     // Items to rebuild, known once the run has counted them
+	Processed   int           `gorm:"not null;default:0" json:"processed"` // Items rebuilt so far
+	Changed     int           `gorm:"not null;default:0" json:"changed"`   // Items whose live value differed when swapped in
+	Error       string        `gorm:"type:text" json:"error"`
+}
+
+// TableName specifies the table name for the RebuildRun model
+func (RebuildRun) TableName() string {
+	return "rebuild_runs"
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// RefreshToken records a refresh token issued to a user, identified by the
+// jti claim it carries. A refresh token can be exchanged once; exchanging it
+// again means it leaked, so every refresh token of the user is revoked.
+type RefreshToken struct {
+	ID        uint       `gorm:"primarykey" json:"id"`
+	TokenID   string     `gorm:"type:varchar(32);not null;uniqueIndex" json:"token_id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	ExpiresAt time.Time  `gorm:"not null;index" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"` // When it was exchanged for a new pair of tokens
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for the RefreshToken model
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// RequestStat aggregates the API requests of one route and status code over a
+// time bucket. Each instance writes its own rows, so queries sum them.
+type RequestStat struct {
+	BaseModel
+	BucketStart    time.Time `gorm:"not null;index" json:"bucket_start"`
+	Method         string    `gorm:"type:varchar(10);not null" json:"method"`
+	Route          string    `gorm:"type:varchar(255);not null;index" json:"route"` // Route pattern, "unmatched" for unknown paths
+	Status         int       `gorm:"not null" json:"status"`
+	Count          int64     `gorm:"not null" json:"count"`
+	TotalMs        float64   `gorm:"not null" json:"total_ms"` // Sum of the latencies, for averages
+	MaxMs          float64   `gorm:"not null" json:"max_ms"`
+	LatencyBuckets []int64   `gorm:"type:jsonb;serializer:json;not null" json:"latency_buckets"` // Counts per reqstats.LatencyBounds bucket
+}
+
+// TableName specifies the table name for the RequestStat model
+func (RequestStat) TableName() string {
+	return "request_stats"
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// RetentionRun records the last time the retention rule of an entity was enforced
+type RetentionRun struct {
+	BaseModel
+	Entity       string    `gorm:"type:varchar(50);not null;uniqueIndex" json:"entity"`
+	RanAt        time.Time `gorm:"not null" json:"ran_at"`
+	Deleted      int64     `gorm:"not null" json:"deleted"`       // Records deleted by the last run
+	TotalDeleted int64     `gorm:"not null" json:"total_deleted"` // Records deleted by every run so far
+	DurationMs   int64     `json:"duration_ms"`
+	Error        string    `gorm:"type:text" json:"error"` // Empty when the last run succeeded
+}
+
+// TableName specifies the table name for the RetentionRun model
+func (RetentionRun) TableName() string {
+	return "retention_runs"
+}
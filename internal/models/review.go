@@ -7,6 +7,8 @@ type Review struct {
 	UserID    uint    `gorm:"not null" json:"user_id"`
 	Rating    int     `gorm:"not null;check:rating >= 1 AND rating <= 5" json:"rating"`
 	Comment   string  `json:"comment"`
+	Imported  bool    `gorm:"not null;default:false" json:"imported"` // Migrated from another store platform
+	Source    string  `gorm:"size:32" json:"source,omitempty"`        // Platform an imported review came from
 	Product   Product `json:"product" gorm:"foreignKey:ProductID"`
 	User      User    `json:"user" gorm:"foreignKey:UserID"`
 }
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// RevokedToken is an access token rejected before it expires, identified by
+// the jti claim it carries. It is kept until the token would have expired.
+type RevokedToken struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	TokenID   string    `gorm:"type:varchar(32);not null;uniqueIndex" json:"token_id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for the RevokedToken model
+func (RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
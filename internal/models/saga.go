@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+// SagaType identifies the steps a saga runs
+type SagaType string
+
+const (
+	SagaOrderPlacement SagaType = "order_placement" // Places an accepted quote as an order
+)
+
+// SagaStatus represents the state of a saga
+type SagaStatus string
+
+const (
+	SagaRunning      SagaStatus = "running" // Running its steps in order, possibly after failed attempts
+	SagaCompleted    SagaStatus = "completed"
+	SagaCompensating SagaStatus = "compensating" // A step failed; undoing the completed ones in reverse order
+	SagaCompensated  SagaStatus = "compensated"  // Every completed step was undone
+	SagaFailed       SagaStatus = "failed"       // A compensation kept failing, until an admin retries it
+)
+
+// Saga is an operation spanning several parts of the system, run one step at a
+// time with its progress stored so any instance can resume it, and undone by
+// compensating its completed steps when a step fails
+type Saga struct {
+	BaseModel
+	Type          SagaType   `gorm:"type:varchar(50);not null;index" json:"type"`
+	Reference     string     `gorm:"index" json:"reference"` // What the saga acts on, e.g. the quote placed as an order
+	Status        SagaStatus `gorm:"type:varchar(20);not null;default:'running';index:idx_sagas_due" json:"status"`
+	Data          string     `gorm:"type:text;not null" json:"data"`             // JSON state the steps read and write
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`         // Failed attempts at the current step or compensation
+	NextAttemptAt *time.Time `gorm:"index:idx_sagas_due" json:"next_attempt_at"` // When the runner next advances it; nil once it stopped
+	Error         string     `gorm:"type:text" json:"error"`                     // Why the saga is compensating or failed
+	FinishedAt    *time.Time `json:"finished_at"`
+	Steps         []SagaStep `gorm:"foreignKey:SagaID" json:"steps"`
+}
+
+// TableName specifies the table name for the Saga model
+func (Saga) TableName() string {
+	return "sagas"
+}
+
+// SagaStepStatus represents the state of a step of a saga
+type SagaStepStatus string
+
+const (
+	SagaStepPending     SagaStepStatus = "pending"
+	SagaStepCompleted   SagaStepStatus = "completed"
+	SagaStepFailed      SagaStepStatus = "failed" // The step the saga compensates for
+	SagaStepCompensated SagaStepStatus = "compensated"
+)
+
+// SagaStep is the stored state of a step of a saga
+type SagaStep struct {
+	BaseModel
+	SagaID        uint           `gorm:"not null;uniqueIndex:idx_saga_steps_position" json:"saga_id"`
+	Position      int            `gorm:"not null;uniqueIndex:idx_saga_steps_position" json:"position"`
+	Name          string         `gorm:"type:varchar(50);not null" json:"name"`
+	Status        SagaStepStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	Error         string         `gorm:"type:text" json:"error"` // Last failure of the step or its compensation
+	CompletedAt   *time.Time     `json:"completed_at"`
+	CompensatedAt *time.Time     `json:"compensated_at"`
+}
+
+// TableName specifies the table name for the SagaStep model
+func (SagaStep) TableName() string {
+	return "saga_steps"
+}
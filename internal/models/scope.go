@@ -0,0 +1,12 @@
+package models
+
+// Scope restricts what a scoped access token may do
+type Scope string
+
+const (
+	ScopeCatalogRead  Scope = "catalog:read"  // Read products, categories and wishlists
+	ScopeCatalogWrite Scope = "catalog:write" // Create, update and delete products and categories
+	ScopeReviewsRead  Scope = "reviews:read"  // Read reviews and review statistics
+	ScopeReviewsWrite Scope = "reviews:write" // Create and delete reviews
+	ScopeAdminRead    Scope = "admin:read"    // Read admin endpoints such as analytics
+)
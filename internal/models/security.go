@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// SecurityAlertKind identifies the pattern a security alert was raised for
+type SecurityAlertKind string
+
+const (
+	SecurityAlertFailedLogins        SecurityAlertKind = "failed_logins"        // Many accounts failing to sign in from one IP
+	SecurityAlertBulkDeletion        SecurityAlertKind = "bulk_deletion"        // Many products deleted by one user
+	SecurityAlertPrivilegeEscalation SecurityAlertKind = "privilege_escalation" // A user promoted to admin
+)
+
+// SecurityAlert records suspicious activity raised to the admins
+type SecurityAlert struct {
+	BaseModel
+	Kind    SecurityAlertKind `gorm:"type:varchar(30);not null;index" json:"kind"`
+	IP      string            `gorm:"type:varchar(45);index" json:"ip"`
+	ActorID *uint             `gorm:"index" json:"actor_id"` // User behind the activity, when known
+	Details string            `gorm:"type:text" json:"details"`
+	Blocked bool              `gorm:"not null;default:false" json:"blocked"` // Whether the IP was blocked automatically
+}
+
+// TableName specifies the table name for the SecurityAlert model
+func (SecurityAlert) TableName() string {
+	return "security_alerts"
+}
+
+// IPBlock rejects every request from an IP address until it expires
+type IPBlock struct {
+	BaseModel
+	IP        string    `gorm:"type:varchar(45);not null;uniqueIndex" json:"ip"`
+	Reason    string    `gorm:"type:text" json:"reason"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+}
+
+// TableName specifies the table name for the IPBlock model
+func (IPBlock) TableName() string {
+	return "ip_blocks"
+}
@@ -0,0 +1,51 @@
+package models
+
+// SegmentField is a user attribute a segment rule can filter on
+type SegmentField string
+
+const (
+	SegmentFieldRole               SegmentField = "role"
+	SegmentFieldWishlistSize       SegmentField = "wishlist_size"
+	SegmentFieldReviewCount        SegmentField = "review_count"
+	SegmentFieldDaysSinceSignup    SegmentField = "days_since_signup"
+	SegmentFieldDaysSinceLastLogin SegmentField = "days_since_last_login" // Counts from sign-up for users who never logged in
+)
+
+// Numeric reports whether the field holds a number rather than text
+func (f SegmentField) Numeric() bool {
+	return f != SegmentFieldRole
+}
+
+// SegmentOperator compares a user attribute with a rule's value
+type SegmentOperator string
+
+const (
+	SegmentOperatorEq  SegmentOperator = "eq"
+	SegmentOperatorNe  SegmentOperator = "ne"
+	SegmentOperatorGt  SegmentOperator = "gt"
+	SegmentOperatorGte SegmentOperator = "gte"
+	SegmentOperatorLt  SegmentOperator = "lt"
+	SegmentOperatorLte SegmentOperator = "lte"
+)
+
+// SegmentRule is one condition of a segment, such as wishlist_size gte 5
+type SegmentRule struct {
+	Field    SegmentField    `json:"field"`
+	Operator SegmentOperator `json:"operator"`
+	Value    string          `json:"value"`
+}
+
+// Segment is a named group of users matching every one of its rules. Members
+// are evaluated when the segment is queried, so they follow user activity.
+type Segment struct {
+	BaseModel
+	Name        string        `gorm:"type:varchar(100);not null;uniqueIndex" json:"name"`
+	Description string        `gorm:"type:text" json:"description"`
+	Rules       []SegmentRule `gorm:"type:jsonb;serializer:json;not null" json:"rules"`
+	CreatedBy   uint          `gorm:"not null" json:"created_by"`
+}
+
+// TableName specifies the table name for the Segment model
+func (Segment) TableName() string {
+	return "segments"
+}
@@ -0,0 +1,27 @@
+package models
+
+// SettingKey identifies a store setting. Each key has a type its values are
+// validated against and a default used until an admin sets it.
+type SettingKey string
+
+const (
+	SettingStoreName         SettingKey = "store_name"
+	SettingLogoURL           SettingKey = "logo_url"
+	SettingSupportEmail      SettingKey = "support_email"
+	SettingCurrency          SettingKey = "currency"            // Currency the storefront shows prices in
+	SettingOrderNumberPrefix SettingKey = "order_number_prefix" // Put before the quote ID in order numbers, e.g. ORD-12
+	SettingGiftWrapFee       SettingKey = "gift_wrap_fee"       // Charged per unit gift wrapped, in the base currency
+)
+
+// Setting is the value an admin set for a store setting
+type Setting struct {
+	BaseModel
+	Key       SettingKey `gorm:"type:varchar(100);not null;uniqueIndex" json:"key"`
+	Value     string     `gorm:"type:text;not null" json:"value"`
+	UpdatedBy uint       `gorm:"not null" json:"updated_by"`
+}
+
+// TableName specifies the table name for the Setting model
+func (Setting) TableName() string {
+	return "settings"
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Shipment is a parcel of an order, placed from an accepted quote, handed to a
+// carrier. An order may ship in several parcels.
+type Shipment struct {
+	BaseModel
+	QuoteID        uint           `gorm:"not null;index" json:"quote_id"`
+	Carrier        string         `gorm:"type:varchar(50);not null" json:"carrier"`
+	TrackingNumber string         `gorm:"type:varchar(100);not null" json:"tracking_number"`
+	TrackingURL    string         `gorm:"type:varchar(500)" json:"tracking_url"` // Carrier's tracking page, when known
+	ShippedAt      time.Time      `gorm:"not null" json:"shipped_at"`
+	RecordedBy     uint           `gorm:"not null" json:"recorded_by"` // Admin who recorded it
+	Items          []ShipmentItem `gorm:"foreignKey:ShipmentID" json:"items"`
+}
+
+// TableName specifies the table name for the Shipment model
+func (Shipment) TableName() string {
+	return "shipments"
+}
+
+// ShipmentItem is a quantity of an item of the order packed in a shipment
+type ShipmentItem struct {
+	BaseModel
+	ShipmentID  uint      `gorm:"not null;index" json:"shipment_id"`
+	QuoteItemID uint      `gorm:"not null;index" json:"quote_item_id"`
+	QuoteItem   QuoteItem `gorm:"foreignKey:QuoteItemID" json:"-"`
+	Quantity    int       `gorm:"not null" json:"quantity"`
+}
+
+// TableName specifies the table name for the ShipmentItem model
+func (ShipmentItem) TableName() string {
+	return "shipment_items"
+}
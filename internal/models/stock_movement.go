@@ -0,0 +1,33 @@
+package models
+
+// StockMovementSource represents what caused a stock movement
+type StockMovementSource string
+
+const (
+	StockSourceOrder         StockMovementSource = "order" // Reserved for or released from an order placed from a quote
+	StockSourceSale          StockMovementSource = "sale"  // Sold outside the store's orders, e.g. in a shop
+	StockSourceRestock       StockMovementSource = "restock"
+	StockSourceReturn        StockMovementSource = "return" // Returned by a customer and back on sale
+	StockSourceAdjustment    StockMovementSource = "adjustment"
+	StockSourceImport        StockMovementSource = "import"
+	StockSourcePurchaseOrder StockMovementSource = "purchase_order" // Delivery received against a purchase order
+	StockSourceSync          StockMovementSource = "sync"           // Level pulled from a legacy system by a connector
+)
+
+// StockMovement represents a single entry in a product's stock ledger
+type StockMovement struct {
+	BaseModel
+	ProductID         uint                `gorm:"not null;index" json:"product_id"`
+	Product           Product             `gorm:"foreignKey:ProductID" json:"-"`
+	Source            StockMovementSource `gorm:"type:varchar(20);not null" json:"source"`
+	Reference         string              `json:"reference"` // Optional ID of the order, import batch, etc.
+	Delta             int                 `gorm:"not null" json:"delta"`
+	ResultingQuantity int                 `gorm:"not null" json:"resulting_quantity"`
+	ActorID           uint                `json:"actor_id"`
+	Note              string              `json:"note"`
+}
+
+// TableName specifies the table name for the StockMovement model
+func (StockMovement) TableName() string {
+	return "stock_movements"
+}
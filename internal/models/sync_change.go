@@ -0,0 +1,34 @@
+package models
+
+// SyncEntity identifies the kind of record a sync change is about
+type SyncEntity string
+
+const (
+	SyncEntityProduct  SyncEntity = "product"
+	SyncEntityCategory SyncEntity = "category"
+	SyncEntityWishlist SyncEntity = "wishlist" // A wishlist item, identified by its product ID
+)
+
+// SyncOperation is what happened to a record since a client last synced
+type SyncOperation string
+
+const (
+	SyncUpsert SyncOperation = "upsert" // Created or updated; clients replace their copy
+	SyncDelete SyncOperation = "delete"
+)
+
+// SyncChange is an entry of the change log mobile clients sync from. Its ID is
+// the cursor clients resume from, so it is recorded in the same transaction as
+// the change it reports.
+type SyncChange struct {
+	BaseModel
+	EntityType SyncEntity    `gorm:"type:varchar(20);not null" json:"entity_type"`
+	EntityID   uint          `gorm:"not null" json:"entity_id"`
+	UserID     *uint         `gorm:"index" json:"user_id"` // Owner of a private record such as a wishlist item, nil for the catalog
+	Operation  SyncOperation `gorm:"type:varchar(20);not null" json:"operation"`
+}
+
+// TableName specifies the table name for the SyncChange model
+func (SyncChange) TableName() string {
+	return "sync_changes"
+}
@@ -18,14 +18,30 @@ const (
 // User represents a user in the system
 type User struct {
 	BaseModel
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Username  string    `json:"username" gorm:"unique;not null"`
-	Email     string    `json:"email" gorm:"unique;not null"`
-	FullName  string    `json:"full_name"`
-	Password  string    `json:"-" gorm:"not null"` // "-" means this field won't be included in JSON
-	Role      Role      `json:"role" gorm:"type:varchar(10);default:'user'"`
-	LastLogin time.Time `json:"last_login"`
-	Reviews   []Review  `json:"reviews"` // One-to-many relationship with Review
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	Username     string    `json:"username" gorm:"unique;not null"`
+	Email        string    `json:"email" gorm:"unique;not null"`
+	FullName     string    `json:"full_name"`
+	Password     string    `json:"-" gorm:"not null"` // "-" means this field won't be included in JSON
+	Role         Role      `json:"role" gorm:"type:varchar(10);default:'user'"`
+	LastLogin    time.Time `json:"last_login"`
+	TokenVersion uint      `json:"-" gorm:"not null;default:0"` // Bumped to revoke every access token issued before
+	Reviews      []Review  `json:"reviews"`                     // One-to-many relationship with Review
+
+	ReferralCode       *string    `json:"-" gorm:"type:varchar(12);uniqueIndex"` // Code others sign up with, created on first use
+	ReferredByID       *uint      `json:"-" gorm:"index"`                        // User whose code this user signed up with
+	ReferralRewardedAt *time.Time `json:"-"`                                     // When the referrer was rewarded for this user's first order
+	PriceListID        *uint      `json:"-" gorm:"index"`                        // Customer-specific prices, nil for list prices
+	AnonymizedAt       *time.Time `json:"-"`                                     // When the user's personal data was erased
+
+	APIKeyName *string `json:"-" gorm:"type:varchar(100);uniqueIndex"` // Integration API key acting for this customer, whose orders and products its webhooks cover
+
+	CustomFields map[string]interface{} `json:"custom_fields" gorm:"type:jsonb;serializer:json"` // Values of the registration form's custom fields
+
+	// Preferences of requests that do not set their own, empty for the defaults
+	Locale   string `json:"locale" gorm:"type:varchar(16)"`
+	Currency string `json:"currency" gorm:"type:varchar(3)"`
+	Timezone string `json:"timezone" gorm:"type:varchar(64)"`
 }
 
 // BeforeSave is a GORM hook that hashes the password before saving
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// WarehouseEventKind identifies what a warehouse event records, and the
+// warehouse table it is exported to
+type WarehouseEventKind string
+
+const (
+	WarehouseOrder       WarehouseEventKind = "order"        // An accepted quote placed as an order
+	WarehouseProductView WarehouseEventKind = "product_view" // A product page viewed
+	WarehouseSearch      WarehouseEventKind = "search"       // A product search
+)
+
+// WarehouseEvent is an analytics event queued for export to the warehouse.
+// Orders are queued in the transaction placing them; the exporter loads the
+// queue in batches and marks what it loaded.
+type WarehouseEvent struct {
+	ID         uint               `gorm:"primarykey" json:"id"`
+	Kind       WarehouseEventKind `gorm:"type:varchar(30);not null" json:"kind"`
+	Payload    string             `gorm:"type:text;not null" json:"payload"` // JSON row of the warehouse table
+	OccurredAt time.Time          `gorm:"not null" json:"occurred_at"`
+	ExportedAt *time.Time         `gorm:"index" json:"exported_at"`
+}
+
+// TableName specifies the table name for the WarehouseEvent model
+func (WarehouseEvent) TableName() string {
+	return "warehouse_events"
+}
@@ -0,0 +1,58 @@
+package models
+
+// WebhookSubscription delivers events to an integration's endpoint. It belongs
+// to the API key that created it and is only visible to that key.
+type WebhookSubscription struct {
+	BaseModel
+	APIKeyName string   `gorm:"type:varchar(100);not null;index" json:"api_key_name"`
+	URL        string   `gorm:"type:text;not null" json:"url"`
+	Secret     string   `gorm:"type:varchar(100);not null" json:"-"` // Signs every delivery
+	Events     []string `gorm:"type:jsonb;serializer:json;not null" json:"events"`
+	ProductIDs []uint   `gorm:"type:jsonb;serializer:json" json:"product_ids"` // Empty means every product the key's customer ordered
+}
+
+// TableName specifies the table name for the WebhookSubscription model
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Matches reports whether the subscription wants an event about a product
+func (s *WebhookSubscription) Matches(event string, productID uint) bool {
+	subscribed := false
+	for _, e := range s.Events {
+		if e == event {
+			subscribed = true
+			break
+		}
+	}
+	if !subscribed {
+		return false
+	}
+	if len(s.ProductIDs) == 0 {
+		return true
+	}
+	for _, id := range s.ProductIDs {
+		if id == productID {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery records one attempt to deliver an event to a subscription
+type WebhookDelivery struct {
+	BaseModel
+	SubscriptionID uint   `gorm:"not null;index" json:"subscription_id"`
+	EventID        string `gorm:"type:varchar(36);not null;index" json:"event_id"` // Shared by the retries of an event
+	Event          string `gorm:"type:varchar(50);not null" json:"event"`
+	Attempt        int    `gorm:"not null" json:"attempt"`
+	StatusCode     int    `json:"status_code"` // 0 when no response was received
+	Succeeded      bool   `gorm:"not null" json:"succeeded"`
+	Error          string `gorm:"type:text" json:"error"`
+	DurationMs     int64  `json:"duration_ms"`
+}
+
+// TableName specifies the table name for the WebhookDelivery model
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository handles database operations for audit log entries
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new audit log repository
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// Create records a new audit log entry
+func (r *AuditLogRepository) Create(entry *models.AuditLog) error {
+	return r.db.Create(entry).Error
+}
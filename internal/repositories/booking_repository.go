@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Errors returned when slots can't be added or booked
+var (
+	ErrSlotOverlap = errors.New("slot overlaps another slot of the product")
+	ErrSlotStarted = errors.New("slot has already started")
+	ErrSlotFull    = errors.New("slot doesn't have enough units left")
+	ErrSlotBooked  = errors.New("slot has bookings")
+	ErrBookingOver = errors.New("booking can no longer be cancelled")
+)
+
+// BookingRepository handles database operations for the availability slots
+// of bookable products and their bookings
+type BookingRepository struct {
+	db *gorm.DB
+}
+
+// NewBookingRepository creates a new booking repository
+func NewBookingRepository(db *gorm.DB) *BookingRepository {
+	return &BookingRepository{db: db}
+}
+
+// CreateSlots adds slots to a product. The product is locked while they are
+// compared with its existing slots, so concurrent additions can't overlap.
+func (r *BookingRepository) CreateSlots(productID uint, slots []models.AvailabilitySlot) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&product, productID).Error; err != nil {
+			return err
+		}
+		for _, slot := range slots {
+			var overlapping int64
+			if err := tx.Model(&models.AvailabilitySlot{}).
+				Where("product_id = ? AND starts_at < ? AND ends_at > ?", productID, slot.EndsAt, slot.StartsAt).
+				Count(&overlapping).Error; err != nil {
+				return err
+			}
+			if overlapping > 0 {
+				return ErrSlotOverlap
+			}
+		}
+		return tx.Create(&slots).Error
+	})
+}
+
+// ListSlots retrieves the slots of a product starting within [from, to), in
+// the order they start
+func (r *BookingRepository) ListSlots(productID uint, from, to time.Time) ([]models.AvailabilitySlot, error) {
+	var slots []models.AvailabilitySlot
+	err := r.db.Where("product_id = ? AND starts_at >= ? AND starts_at < ?", productID, from, to).
+		Order("starts_at, id").Find(&slots).Error
+	return slots, err
+}
+
+// DeleteSlot deletes a slot nobody holds a booking of
+func (r *BookingRepository) DeleteSlot(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var slot models.AvailabilitySlot
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&slot, id).Error; err != nil {
+			return err
+		}
+		if slot.Booked > 0 {
+			return ErrSlotBooked
+		}
+		return tx.Delete(&slot).Error
+	})
+}
+
+// CreateBooking books units of a slot of a product. The units are taken by a
+// single conditional update, so concurrent bookings can't exceed the slot's
+// capacity.
+func (r *BookingRepository) CreateBooking(booking *models.Booking) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.AvailabilitySlot{}).
+			Where("id = ? AND product_id = ? AND starts_at > ? AND booked + ? <= capacity", booking.SlotID, booking.ProductID, now, booking.Quantity).
+			Update("booked", gorm.Expr("booked + ?", booking.Quantity))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var slot models.AvailabilitySlot
+			if err := tx.Where("id = ? AND product_id = ?", booking.SlotID, booking.ProductID).First(&slot).Error; err != nil {
+				return err
+			}
+			if !now.Before(slot.StartsAt) {
+				return ErrSlotStarted
+			}
+			return ErrSlotFull
+		}
+		return tx.Create(booking).Error
+	})
+}
+
+// GetBooking retrieves a booking with its slot and product. A non-zero userID
+// limits it to that user's bookings.
+func (r *BookingRepository) GetBooking(id, userID uint) (*models.Booking, error) {
+	query := r.preload(r.db)
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	var booking models.Booking
+	if err := query.First(&booking, id).Error; err != nil {
+		return nil, err
+	}
+	return &booking, nil
+}
+
+// ListBookings retrieves a paginated list of bookings, newest first. Non-zero
+// userID and productID limit it to that user's or product's bookings.
+func (r *BookingRepository) ListBookings(userID, productID uint, status models.BookingStatus, page, limit int) ([]models.Booking, int64, error) {
+	var bookings []models.Booking
+	var total int64
+
+	query := r.db.Model(&models.Booking{})
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if productID != 0 {
+		query = query.Where("product_id = ?", productID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := r.preload(query).Order("created_at DESC").Offset(offset).Limit(limit).Find(&bookings).Error
+	return bookings, total, err
+}
+
+// CancelBooking cancels a confirmed booking before its slot starts and gives
+// its units back to the slot. A non-zero userID limits it to that user's
+// bookings.
+func (r *BookingRepository) CancelBooking(id, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"})
+		if userID != 0 {
+			query = query.Where("user_id = ?", userID)
+		}
+		var booking models.Booking
+		if err := query.First(&booking, id).Error; err != nil {
+			return err
+		}
+		var slot models.AvailabilitySlot
+		if err := tx.Unscoped().First(&slot, booking.SlotID).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		if booking.Status != models.BookingConfirmed || !now.Before(slot.StartsAt) {
+			return ErrBookingOver
+		}
+
+		if err := tx.Model(&booking).Updates(map[string]interface{}{
+			"status":       models.BookingCancelled,
+			"cancelled_at": now,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&slot).Update("booked", gorm.Expr("booked - ?", booking.Quantity)).Error
+	})
+}
+
+// preload loads the slots and products of bookings, including deleted ones
+func (r *BookingRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.Preload("Slot", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Preload("Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	})
+}
@@ -1,8 +1,10 @@
 package repositories
 
 import (
+	"encoding/json"
 	"product-management/internal/dto"
 	"product-management/internal/models"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -17,9 +19,14 @@ func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
 	return &CategoryRepository{db: db}
 }
 
-// Create creates a new category
+// Create creates a new category and records its first revision
 func (r *CategoryRepository) Create(category *models.Category) error {
-	return r.db.Create(category).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(category).Error; err != nil {
+			return err
+		}
+		return recordCategoryRevision(tx, category.ID)
+	})
 }
 
 // GetByID retrieves a category by its ID
@@ -30,6 +37,30 @@ func (r *CategoryRepository) GetByID(id uint) (*models.Category, error) {
 	return &category, err
 }
 
+// GetByIDs retrieves the categories with the given IDs in a single query and
+// reports which of the IDs do not exist
+func (r *CategoryRepository) GetByIDs(ids []uint) ([]models.Category, []uint, error) {
+	var categories []models.Category
+	if len(ids) == 0 {
+		return categories, nil, nil
+	}
+	if err := r.db.Where("id IN ?", ids).Find(&categories).Error; err != nil {
+		return nil, nil, err
+	}
+
+	found := make(map[uint]bool, len(categories))
+	for _, category := range categories {
+		found[category.ID] = true
+	}
+	var missing []uint
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	return categories, missing, nil
+}
+
 // GetAll retrieves all categories
 func (r *CategoryRepository) GetAll() ([]models.Category, error) {
 	var categories []models.Category
@@ -38,14 +69,89 @@ func (r *CategoryRepository) GetAll() ([]models.Category, error) {
 	return categories, err
 }
 
-// Update updates a category
+// Update updates the name, description and metadata schema of a category and
+// records a revision. It returns gorm.ErrRecordNotFound when the category does
+// not exist.
 func (r *CategoryRepository) Update(category *models.Category) error {
-	return r.db.Save(category).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(category).Select("name", "description", "metadata_schema").Updates(category)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return recordCategoryRevision(tx, category.ID)
+	})
 }
 
-// Delete deletes a category
+// recordCategoryRevision stores a snapshot of the category's current state as
+// its next revision and appends the change to the sync log
+func recordCategoryRevision(tx *gorm.DB, categoryID uint) error {
+	var category models.Category
+	if err := tx.First(&category, categoryID).Error; err != nil {
+		return err
+	}
+	snapshotJSON, err := json.Marshal(dto.CategorySnapshot{Name: category.Name, Description: category.Description})
+	if err != nil {
+		return err
+	}
+
+	var lastRevision int
+	if err := tx.Model(&models.CategoryRevision{}).
+		Where("category_id = ?", categoryID).
+		Select("COALESCE(MAX(revision), 0)").
+		Row().
+		Scan(&lastRevision); err != nil {
+		return err
+	}
+
+	if err := tx.Create(&models.CategoryRevision{
+		CategoryID: categoryID,
+		Revision:   lastRevision + 1,
+		Snapshot:   string(snapshotJSON),
+	}).Error; err != nil {
+		return err
+	}
+	return recordSyncChange(tx, models.SyncEntityCategory, categoryID, nil, models.SyncUpsert)
+}
+
+// Delete deletes a category and appends the deletion to the sync log
 func (r *CategoryRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Category{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.Category{}, id).Error; err != nil {
+			return err
+		}
+		return recordSyncChange(tx, models.SyncEntityCategory, id, nil, models.SyncDelete)
+	})
+}
+
+// ListChangedSince retrieves the categories created, updated, deleted or
+// revised after a time, including deleted ones
+func (r *CategoryRepository) ListChangedSince(since time.Time) ([]models.Category, error) {
+	var categories []models.Category
+	revised := r.db.Model(&models.CategoryRevision{}).Select("category_id").Where("created_at > ?", since)
+	err := r.db.Unscoped().
+		Where("created_at > ? OR updated_at > ? OR deleted_at > ? OR id IN (?)", since, since, since, revised).
+		Order("id").Find(&categories).Error
+	return categories, err
+}
+
+// RevisionsAsOf retrieves the latest revision of each category recorded at or
+// before a time, keyed by category ID
+func (r *CategoryRepository) RevisionsAsOf(categoryIDs []uint, at time.Time) (map[uint]models.CategoryRevision, error) {
+	revisions := make(map[uint]models.CategoryRevision, len(categoryIDs))
+	if len(categoryIDs) == 0 {
+		return revisions, nil
+	}
+	var rows []models.CategoryRevision
+	err := r.db.Raw(`SELECT DISTINCT ON (category_id) * FROM category_revisions
+		WHERE category_id IN ? AND created_at <= ? AND deleted_at IS NULL
+		ORDER BY category_id, revision DESC`, categoryIDs, at).Scan(&rows).Error
+	for _, revision := range rows {
+		revisions[revision.CategoryID] = revision
+	}
+	return revisions, err
 }
 
 // GetProductsByCategoryID retrieves all products in a category
@@ -58,7 +164,8 @@ func (r *CategoryRepository) GetProductsByCategoryID(categoryID uint) ([]models.
 	return category.Products, nil
 }
 
-// AddProductToCategory adds a product to a category
+// AddProductToCategory adds a product to a category and appends the product
+// change to the sync log
 func (r *CategoryRepository) AddProductToCategory(categoryID, productID uint) error {
 	var category models.Category
 	var product models.Product
@@ -70,10 +177,19 @@ func (r *CategoryRepository) AddProductToCategory(categoryID, productID uint) er
 		return err
 	}
 
-	return r.db.Model(&category).Association("Products").Append(&product)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&category).Association("Products").Append(&product); err != nil {
+			return err
+		}
+		if _, err := refreshProductCounts(tx, []uint{categoryID}); err != nil {
+			return err
+		}
+		return recordSyncChange(tx, models.SyncEntityProduct, productID, nil, models.SyncUpsert)
+	})
 }
 
-// RemoveProductFromCategory removes a product from a category
+// RemoveProductFromCategory removes a product from a category and appends the
+// product change to the sync log
 func (r *CategoryRepository) RemoveProductFromCategory(categoryID, productID uint) error {
 	var category models.Category
 	var product models.Product
@@ -85,36 +201,108 @@ func (r *CategoryRepository) RemoveProductFromCategory(categoryID, productID uin
 		return err
 	}
 
-	return r.db.Model(&category).Association("Products").Delete(&product)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&category).Association("Products").Delete(&product); err != nil {
+			return err
+		}
+		if _, err := refreshProductCounts(tx, []uint{categoryID}); err != nil {
+			return err
+		}
+		return recordSyncChange(tx, models.SyncEntityProduct, productID, nil, models.SyncUpsert)
+	})
+}
+
+// refreshProductCounts recounts the products linked to categories after tx
+// changed their links, and returns the IDs of the categories whose count
+// changed. The category rows are locked first, so the count, read by a later
+// statement, includes the links of every transaction that locked them before.
+func refreshProductCounts(tx *gorm.DB, categoryIDs []uint) ([]uint, error) {
+	if len(categoryIDs) == 0 {
+		return nil, nil
+	}
+	// FOR NO KEY UPDATE doesn't wait for the key share locks link inserts take
+	var locked []uint
+	if err := tx.Raw("SELECT id FROM categories WHERE id IN ? ORDER BY id FOR NO KEY UPDATE", categoryIDs).
+		Scan(&locked).Error; err != nil {
+		return nil, err
+	}
+
+	var changed []uint
+	err := tx.Raw(`UPDATE categories c
+		SET products_count = (SELECT COUNT(*) FROM product_categories pc WHERE pc.category_id = c.id)
+		WHERE c.id IN ?
+			AND c.products_count <> (SELECT COUNT(*) FROM product_categories pc WHERE pc.category_id = c.id)
+		RETURNING c.id`, categoryIDs).
+		Scan(&changed).Error
+	return changed, err
+}
+
+// linkedCategoryIDs returns the IDs of the categories a product is linked to
+func linkedCategoryIDs(tx *gorm.DB, productID uint) ([]uint, error) {
+	var categoryIDs []uint
+	err := tx.Model(&models.ProductCategory{}).Where("product_id = ?", productID).Pluck("category_id", &categoryIDs).Error
+	return categoryIDs, err
+}
+
+// ReconcileProductCounts corrects the product counts of categories that drifted
+// from their links, such as after links were changed by hand, and returns the
+// IDs of the categories corrected
+func (r *CategoryRepository) ReconcileProductCounts() ([]uint, error) {
+	var drifted []uint
+	if err := r.db.Raw(`SELECT c.id FROM categories c
+		LEFT JOIN product_categories pc ON pc.category_id = c.id
+		GROUP BY c.id
+		HAVING c.products_count <> COUNT(pc.product_id)`).
+		Scan(&drifted).Error; err != nil {
+		return nil, err
+	}
+	if len(drifted) == 0 {
+		return nil, nil
+	}
+
+	// Counts are compared again under the lock, since links may have changed
+	// in the meantime
+	var corrected []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		corrected, err = refreshProductCounts(tx, drifted)
+		return err
+	})
+	return corrected, err
 }
 
-// DB returns the database instance
-func (r *CategoryRepository) DB() *gorm.DB {
-	return r.db
+// CountProductsInCategory returns the number of products in a category
+func (r *CategoryRepository) CountProductsInCategory(categoryID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.ProductCategory{}).Where("category_id = ?", categoryID).Count(&count).Error
+	return count, err
 }
 
 // GetCategoryDistribution gets the distribution of products across categories
 func (r *CategoryRepository) GetCategoryDistribution() ([]dto.CategoryDistributionResponse, error) {
 	var distributions []dto.CategoryDistributionResponse
 
-	err := r.db.Table("categories").
-		Select("categories.name, COUNT(DISTINCT product_categories.product_id) as product_count").
-		Joins("LEFT JOIN product_categories ON categories.id = product_categories.category_id").
-		Group("categories.id, categories.name").
+	err := r.db.Model(&models.Category{}).
+		Select("name, products_count AS product_count").
 		Find(&distributions).Error
 
 	return distributions, err
 }
 
-// GetAllWithProductCount retrieves all categories with their product counts
-func (r *CategoryRepository) GetAllWithProductCount() ([]dto.CategoryResponse, error) {
-	var responses []dto.CategoryResponse
+// GetCategoryAnalytics returns the product count of every category with the
+// number of wishlist adds of its products in [from, to). Wishlist entries that
+// were removed since still count as adds.
+func (r *CategoryRepository) GetCategoryAnalytics(from, to time.Time) ([]dto.CategoryAnalyticsItem, error) {
+	var items []dto.CategoryAnalyticsItem
 
 	err := r.db.Table("categories").
-		Select("categories.id, categories.name, categories.description, COUNT(DISTINCT product_categories.product_id) as product_count").
+		Select("categories.id AS category_id, categories.name, COUNT(DISTINCT product_categories.product_id) AS product_count, COUNT(wishlists.id) AS wishlist_adds").
 		Joins("LEFT JOIN product_categories ON categories.id = product_categories.category_id").
-		Group("categories.id, categories.name, categories.description").
-		Find(&responses).Error
+		Joins("LEFT JOIN wishlists ON wishlists.product_id = product_categories.product_id AND wishlists.added_at >= ? AND wishlists.added_at < ?", from, to).
+		Where("categories.deleted_at IS NULL").
+		Group("categories.id, categories.name").
+		Order("wishlist_adds DESC, categories.name").
+		Find(&items).Error
 
-	return responses, err
+	return items, err
 }
@@ -0,0 +1,207 @@
+package repositories
+
+import (
+	"time"
+
+	"product-management/internal/models"
+	"product-management/pkg/events"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ConnectorRepository applies what connectors pull from legacy systems and
+// keeps the log of their sync runs
+type ConnectorRepository struct {
+	db *gorm.DB
+}
+
+// NewConnectorRepository creates a new connector repository
+func NewConnectorRepository(db *gorm.DB) *ConnectorRepository {
+	return &ConnectorRepository{db: db}
+}
+
+// SyncProduct creates the product with an SKU as a draft when there is none,
+// or updates its name, description and price when they differ, recording a
+// revision and adding a ProductChanged event to the outbox either way. It
+// returns the product with whether it was created or updated, and nil when the
+// SKU belongs to a deleted product.
+func (r *ConnectorRepository) SyncProduct(sku, name, description string, price float64) (*models.Product, bool, bool, error) {
+	var product models.Product
+	var created, updated bool
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("sku = ?", sku).First(&product).Error
+		if err == gorm.ErrRecordNotFound {
+			product = models.Product{Name: name, Description: description, SKU: &sku, Price: price, Status: models.StatusDraft}
+			if err := tx.Create(&product).Error; err != nil {
+				return err
+			}
+			created = true
+			if err := recordEvent(tx, events.ProductChanged{ProductID: product.ID}); err != nil {
+				return err
+			}
+			return recordRevision(tx, product.ID, 0)
+		}
+		if err != nil || product.DeletedAt.Valid {
+			return err
+		}
+		if product.Name == name && product.Description == description && product.Price == price {
+			return nil
+		}
+		product.Name, product.Description, product.Price = name, description, price
+		if err := tx.Model(&product).Select("name", "description", "price").Updates(&product).Error; err != nil {
+			return err
+		}
+		updated = true
+		if err := recordEvent(tx, events.ProductChanged{ProductID: product.ID}); err != nil {
+			return err
+		}
+		return recordRevision(tx, product.ID, 0)
+	})
+	if err != nil || product.DeletedAt.Valid {
+		return nil, false, false, err
+	}
+	return &product, created, updated, nil
+}
+
+// SyncStock sets the stock quantity of the product with an SKU, recording the
+// difference as a sync stock movement referencing the connector along with
+// ProductChanged and StockChanged events in the outbox. It returns
+// the product with its previous quantity, and nil when no product has the SKU.
+func (r *ConnectorRepository) SyncStock(sku string, quantity int, connector string) (*models.Product, int, error) {
+	var product models.Product
+	var previous int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("sku = ?", sku).First(&product).Error; err != nil {
+			return err
+		}
+		previous = product.StockQuantity
+		if previous == quantity {
+			return nil
+		}
+		if err := tx.Model(&product).Update("stock_quantity", quantity).Error; err != nil {
+			return err
+		}
+		product.StockQuantity = quantity
+		if err := recordStockMovement(tx, product.ID, models.StockSourceSync, connector, quantity-previous, quantity, 0, "connector sync"); err != nil {
+			return err
+		}
+		if err := recordEvent(tx, events.ProductChanged{ProductID: product.ID}); err != nil {
+			return err
+		}
+		return recordEvent(tx, events.StockChanged{ProductID: product.ID, ProductName: product.Name, Previous: previous, Quantity: quantity})
+	})
+	if err == gorm.ErrRecordNotFound {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return &product, previous, nil
+}
+
+// ListAcceptedQuotes retrieves up to limit quotes accepted after a time, or
+// ever when it is nil, oldest decision first, with their items and products
+// and the email of each customer keyed by user ID
+func (r *ConnectorRepository) ListAcceptedQuotes(after *time.Time, limit int) ([]models.Quote, map[uint]string, error) {
+	query := r.db.Where("status = ? AND decided_at IS NOT NULL", models.QuoteAccepted)
+	if after != nil {
+		query = query.Where("decided_at > ?", *after)
+	}
+	var quotes []models.Quote
+	err := query.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Preload("Items.Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Order("decided_at, id").Limit(limit).Find(&quotes).Error
+	if err != nil || len(quotes) == 0 {
+		return quotes, nil, err
+	}
+
+	userIDs := make([]uint, len(quotes))
+	for i, quote := range quotes {
+		userIDs[i] = quote.UserID
+	}
+	var users []models.User
+	if err := r.db.Unscoped().Select("id", "email").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, nil, err
+	}
+	emails := make(map[uint]string, len(users))
+	for _, user := range users {
+		emails[user.ID] = user.Email
+	}
+	return quotes, emails, nil
+}
+
+// CreateRun stores a sync run as it starts
+func (r *ConnectorRepository) CreateRun(run *models.ConnectorSyncRun) error {
+	return r.db.Create(run).Error
+}
+
+// FinishRun saves the outcome of a sync run
+func (r *ConnectorRepository) FinishRun(run *models.ConnectorSyncRun) error {
+	return r.db.Save(run).Error
+}
+
+// FailRunning marks the runs of a connector still running when they started
+// before a time as failed, since the instance running them stopped midway
+func (r *ConnectorRepository) FailRunning(connector string, before time.Time) error {
+	return r.db.Model(&models.ConnectorSyncRun{}).
+		Where("connector = ? AND status = ? AND started_at < ?", connector, models.ConnectorSyncRunning, before).
+		Updates(map[string]interface{}{
+			"status":     models.ConnectorSyncFailed,
+			"error":      "interrupted",
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// ListRuns retrieves a paginated list of the runs of a connector, newest first
+func (r *ConnectorRepository) ListRuns(connector string, page, limit int) ([]models.ConnectorSyncRun, int64, error) {
+	var runs []models.ConnectorSyncRun
+	var total int64
+
+	query := r.db.Model(&models.ConnectorSyncRun{}).Where("connector = ?", connector)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("started_at DESC, id DESC").Offset(offset).Limit(limit).Find(&runs).Error
+	return runs, total, err
+}
+
+// LastRuns retrieves the latest run of every connector that ran
+func (r *ConnectorRepository) LastRuns() ([]models.ConnectorSyncRun, error) {
+	var runs []models.ConnectorSyncRun
+	err := r.db.Raw(`SELECT DISTINCT ON (connector) * FROM connector_sync_runs
+		WHERE deleted_at IS NULL ORDER BY connector, started_at DESC, id DESC`).Scan(&runs).Error
+	return runs, err
+}
+
+// OrdersThrough returns the decision time of the last order pushed by a
+// connector, or nil before it pushed any
+func (r *ConnectorRepository) OrdersThrough(connector string) (*time.Time, error) {
+	var run models.ConnectorSyncRun
+	err := r.db.Where("connector = ? AND orders_through IS NOT NULL", connector).
+		Order("orders_through DESC").First(&run).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return run.OrdersThrough, nil
+}
+
+// LastRun retrieves the latest run of a connector, or nil before it first ran
+func (r *ConnectorRepository) LastRun(connector string) (*models.ConnectorSyncRun, error) {
+	var run models.ConnectorSyncRun
+	err := r.db.Where("connector = ?", connector).Order("started_at DESC, id DESC").First(&run).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
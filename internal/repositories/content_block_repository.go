@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ContentBlockRepository handles database operations for content blocks
+type ContentBlockRepository struct {
+	db *gorm.DB
+}
+
+// NewContentBlockRepository creates a new ContentBlockRepository instance
+func NewContentBlockRepository(db *gorm.DB) *ContentBlockRepository {
+	return &ContentBlockRepository{db: db}
+}
+
+// Create adds a content block
+func (r *ContentBlockRepository) Create(block *models.ContentBlock) error {
+	return r.db.Create(block).Error
+}
+
+// GetByID retrieves a content block by ID
+func (r *ContentBlockRepository) GetByID(id uint) (*models.ContentBlock, error) {
+	var block models.ContentBlock
+	if err := r.db.First(&block, id).Error; err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// GetByKey retrieves a content block by its key
+func (r *ContentBlockRepository) GetByKey(key string) (*models.ContentBlock, error) {
+	var block models.ContentBlock
+	if err := r.db.Where("key = ?", key).First(&block).Error; err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// List retrieves every content block ordered by key
+func (r *ContentBlockRepository) List() ([]models.ContentBlock, error) {
+	var blocks []models.ContentBlock
+	err := r.db.Order("key").Find(&blocks).Error
+	return blocks, err
+}
+
+// KeyTaken reports whether another block than excludeID already uses a key
+func (r *ContentBlockRepository) KeyTaken(key string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.ContentBlock{}).Where("key = ? AND id <> ?", key, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// Update saves a block's content and schedule
+func (r *ContentBlockRepository) Update(block *models.ContentBlock) error {
+	return r.db.Model(block).Select("key", "title", "body", "image_url", "starts_at", "ends_at", "updated_by").Updates(block).Error
+}
+
+// Delete removes a content block for good, so its key can be reused
+func (r *ContentBlockRepository) Delete(id uint) error {
+	result := r.db.Unscoped().Delete(&models.ContentBlock{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
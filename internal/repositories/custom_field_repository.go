@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CustomFieldRepository handles database operations for custom form fields
+type CustomFieldRepository struct {
+	db *gorm.DB
+}
+
+// NewCustomFieldRepository creates a new CustomFieldRepository instance
+func NewCustomFieldRepository(db *gorm.DB) *CustomFieldRepository {
+	return &CustomFieldRepository{db: db}
+}
+
+// Create adds a custom field
+func (r *CustomFieldRepository) Create(field *models.CustomField) error {
+	return r.db.Create(field).Error
+}
+
+// GetByID retrieves a custom field by ID
+func (r *CustomFieldRepository) GetByID(id uint) (*models.CustomField, error) {
+	var field models.CustomField
+	if err := r.db.First(&field, id).Error; err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+// List retrieves the fields of a form in display order, or of every form
+// when form is empty
+func (r *CustomFieldRepository) List(form models.CustomForm) ([]models.CustomField, error) {
+	query := r.db.Order("form, position, id")
+	if form != "" {
+		query = query.Where("form = ?", form)
+	}
+	var fields []models.CustomField
+	err := query.Find(&fields).Error
+	return fields, err
+}
+
+// KeyTaken reports whether another field of a form than excludeID already
+// uses a key
+func (r *CustomFieldRepository) KeyTaken(form models.CustomForm, key string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.CustomField{}).Where("form = ? AND key = ? AND id <> ?", form, key, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// Update saves a field's definition
+func (r *CustomFieldRepository) Update(field *models.CustomField) error {
+	return r.db.Model(field).
+		Select("form", "key", "label", "help_text", "type", "required", "options", "pattern", "min_length", "max_length", "min", "max", "position", "updated_by").
+		Updates(field).Error
+}
+
+// Delete removes a custom field for good, so its key can be reused. Values
+// already submitted for it are kept.
+func (r *CustomFieldRepository) Delete(id uint) error {
+	result := r.db.Unscoped().Delete(&models.CustomField{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"product-management/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WishlistChange is a wishlisted product whose price changed or that came back
+// in stock, with the user to tell about it
+type WishlistChange struct {
+	UserID      uint
+	Email       string
+	FullName    string
+	ProductID   uint
+	ProductName string
+	OldPrice    *float64 // Price before the period, nil when it did not change
+	NewPrice    float64
+	BackInStock bool
+}
+
+// DigestRecipient is a user subscribed to digest emails
+type DigestRecipient struct {
+	ID       uint
+	Email    string
+	FullName string
+}
+
+// LowStockProduct is an active product at or below the low stock threshold
+type LowStockProduct struct {
+	ID            uint
+	Name          string
+	StockQuantity int
+}
+
+// InventoryReport summarizes the catalog and its activity over a period
+type InventoryReport struct {
+	ActiveProducts int64
+	OutOfStock     int64
+	LowStock       []LowStockProduct
+	UnitsAdded     int64
+	UnitsRemoved   int64
+	NewUsers       int64
+	NewReviews     int64
+}
+
+// DigestRepository gathers the data of the weekly digest and report emails
+type DigestRepository struct {
+	db *gorm.DB
+}
+
+// NewDigestRepository creates a new digest repository
+func NewDigestRepository(db *gorm.DB) *DigestRepository {
+	return &DigestRepository{db: db}
+}
+
+// GetWishlistChanges returns the wishlisted products whose price differs from
+// their last revision before since, or that went from no stock to some stock
+// after since, for users subscribed to digests, ordered by user
+func (r *DigestRepository) GetWishlistChanges(since time.Time) ([]WishlistChange, error) {
+	var changes []WishlistChange
+	err := r.db.Raw(`
+		WITH previous AS (
+			SELECT DISTINCT ON (product_id) product_id, (snapshot->>'price')::float8 AS price
+			FROM product_revisions
+			WHERE created_at < @since AND deleted_at IS NULL
+			ORDER BY product_id, revision DESC
+		), restocked AS (
+			SELECT DISTINCT product_id
+			FROM stock_movements
+			WHERE created_at >= @since AND deleted_at IS NULL
+				AND resulting_quantity > 0 AND resulting_quantity - delta <= 0
+		)
+		SELECT u.id AS user_id, u.email, u.full_name, p.id AS product_id, p.name AS product_name,
+			CASE WHEN prev.price <> p.price THEN prev.price END AS old_price,
+			p.price AS new_price,
+			(r.product_id IS NOT NULL AND p.stock_quantity > 0) AS back_in_stock
+		FROM wishlists w
+		JOIN users u ON u.id = w.user_id AND u.deleted_at IS NULL
+		JOIN products p ON p.id = w.product_id AND p.deleted_at IS NULL AND p.status = @active
+		LEFT JOIN notification_settings ns ON ns.user_id = u.id AND ns.deleted_at IS NULL
+		LEFT JOIN previous prev ON prev.product_id = p.id
+		LEFT JOIN restocked r ON r.product_id = p.id
+		WHERE w.deleted_at IS NULL
+			AND NOT COALESCE(ns.digest_unsubscribed, false)
+			AND (prev.price <> p.price OR (r.product_id IS NOT NULL AND p.stock_quantity > 0))
+		ORDER BY u.id, p.name`,
+		map[string]interface{}{"since": since, "active": models.StatusActive},
+	).Scan(&changes).Error
+	return changes, err
+}
+
+// GetReportRecipients returns the users of a role subscribed to digests
+func (r *DigestRepository) GetReportRecipients(role models.Role) ([]DigestRecipient, error) {
+	var recipients []DigestRecipient
+	err := r.db.Table("users u").
+		Select("u.id, u.email, u.full_name").
+		Joins("LEFT JOIN notification_settings ns ON ns.user_id = u.id AND ns.deleted_at IS NULL").
+		Where("u.role = ? AND u.deleted_at IS NULL AND NOT COALESCE(ns.digest_unsubscribed, false)", role).
+		Scan(&recipients).Error
+	return recipients, err
+}
+
+// GetInventoryReport summarizes stock levels now and activity since a time.
+// Products with a stock quantity from 1 to lowStockThreshold are listed as low.
+func (r *DigestRepository) GetInventoryReport(since time.Time, lowStockThreshold int) (*InventoryReport, error) {
+	report := &InventoryReport{}
+	active := r.db.Model(&models.Product{}).Where("status = ?", models.StatusActive)
+
+	if err := active.Session(&gorm.Session{}).Count(&report.ActiveProducts).Error; err != nil {
+		return nil, err
+	}
+	if err := active.Session(&gorm.Session{}).Where("stock_quantity <= 0").Count(&report.OutOfStock).Error; err != nil {
+		return nil, err
+	}
+	if err := active.Session(&gorm.Session{}).
+		Select("id, name, stock_quantity").
+		Where("stock_quantity > 0 AND stock_quantity <= ?", lowStockThreshold).
+		Order("stock_quantity, name").
+		Scan(&report.LowStock).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Model(&models.StockMovement{}).
+		Select("COALESCE(SUM(CASE WHEN delta > 0 THEN delta END), 0) AS units_added, COALESCE(-SUM(CASE WHEN delta < 0 THEN delta END), 0) AS units_removed").
+		Where("created_at >= ?", since).
+		Row().Scan(&report.UnitsAdded, &report.UnitsRemoved); err != nil {
+		return nil, err
+	}
+	if err := r.db.Model(&models.User{}).Where("created_at >= ?", since).Count(&report.NewUsers).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Model(&models.Review{}).Where("created_at >= ?", since).Count(&report.NewReviews).Error; err != nil {
+		return nil, err
+	}
+	return report, nil
+}
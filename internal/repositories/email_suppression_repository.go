@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"product-management/internal/models"
+	"product-management/pkg/mailer"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EmailSuppressionRepository handles database operations for suppressed email
+// addresses. It implements mailer.SuppressionList.
+type EmailSuppressionRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailSuppressionRepository creates a new email suppression repository
+func NewEmailSuppressionRepository(db *gorm.DB) *EmailSuppressionRepository {
+	return &EmailSuppressionRepository{db: db}
+}
+
+// IsSuppressed reports whether an address is on the suppression list
+func (r *EmailSuppressionRepository) IsSuppressed(email string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.EmailSuppression{}).Where("email = ?", mailer.NormalizeAddress(email)).Count(&count).Error
+	return count > 0, err
+}
+
+// Suppress adds an address to the suppression list, keeping the first reason
+// if it is already there
+func (r *EmailSuppressionRepository) Suppress(email, reason string) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.EmailSuppression{
+		Email:  mailer.NormalizeAddress(email),
+		Reason: reason,
+	}).Error
+}
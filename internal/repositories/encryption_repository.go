@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// EncryptedRow holds the ID of a row and the values of its encrypted columns
+// as stored, without decrypting them
+type EncryptedRow struct {
+	ID     uint
+	Values map[string]string
+}
+
+// EncryptionRepository reads and rewrites encrypted columns directly, for
+// re-encrypting them under a new key
+type EncryptionRepository struct {
+	db *gorm.DB
+}
+
+// NewEncryptionRepository creates a new encryption repository
+func NewEncryptionRepository(db *gorm.DB) *EncryptionRepository {
+	return &EncryptionRepository{db: db}
+}
+
+// Batch returns up to limit rows of a table with an ID above afterID, in ID
+// order, including soft-deleted rows. The table and columns must come from
+// code, never from user input.
+func (r *EncryptionRepository) Batch(table string, columns []string, afterID uint, limit int) ([]EncryptedRow, error) {
+	var results []map[string]interface{}
+	err := r.db.Table(table).Select(append([]string{"id"}, columns...)).
+		Where("id > ?", afterID).Order("id").Limit(limit).
+		Find(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]EncryptedRow, len(results))
+	for i, result := range results {
+		rows[i] = EncryptedRow{ID: toUint(result["id"]), Values: make(map[string]string, len(columns))}
+		for _, column := range columns {
+			switch value := result[column].(type) {
+			case string:
+				rows[i].Values[column] = value
+			case []byte:
+				rows[i].Values[column] = string(value)
+			}
+		}
+	}
+	return rows, nil
+}
+
+// Replace sets the columns of a row to new values if they still hold the old
+// ones, so writes made since the row was read are not overwritten. It reports
+// whether the row was updated.
+func (r *EncryptionRepository) Replace(table string, id uint, old, updated map[string]string) (bool, error) {
+	query := r.db.Table(table).Where("id = ?", id)
+	values := make(map[string]interface{}, len(updated))
+	for column, value := range updated {
+		query = query.Where(fmt.Sprintf("%s = ?", column), old[column])
+		values[column] = value
+	}
+	result := query.Updates(values)
+	return result.RowsAffected > 0, result.Error
+}
+
+// toUint converts an ID scanned into an interface to a uint
+func toUint(value interface{}) uint {
+	switch v := value.(type) {
+	case int64:
+		return uint(v)
+	case int32:
+		return uint(v)
+	case uint:
+		return v
+	case uint64:
+		return uint(v)
+	}
+	return 0
+}
@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ExperimentVariantResult is the exposures and conversions of a variant
+type ExperimentVariantResult struct {
+	Variant     string
+	Exposed     int64   // Subjects exposed to the variant
+	Converted   int64   // Exposed subjects with a conversion after their first exposure
+	Conversions int64   // Conversions of the exposed subjects after their first exposure
+	Value       float64 // Total value of those conversions
+}
+
+// ExperimentRepository handles database operations for experiments, their
+// variants and events
+type ExperimentRepository struct {
+	db *gorm.DB
+}
+
+// NewExperimentRepository creates a new ExperimentRepository instance
+func NewExperimentRepository(db *gorm.DB) *ExperimentRepository {
+	return &ExperimentRepository{db: db}
+}
+
+// Create adds an experiment with its variants
+func (r *ExperimentRepository) Create(experiment *models.Experiment) error {
+	return r.db.Create(experiment).Error
+}
+
+// GetByID retrieves an experiment with its variants
+func (r *ExperimentRepository) GetByID(id uint) (*models.Experiment, error) {
+	var experiment models.Experiment
+	if err := r.withVariants().First(&experiment, id).Error; err != nil {
+		return nil, err
+	}
+	return &experiment, nil
+}
+
+// List retrieves a paginated list of experiments with their variants, newest
+// first. An empty status lists them all.
+func (r *ExperimentRepository) List(status models.ExperimentStatus, page, limit int) ([]models.Experiment, int64, error) {
+	var experiments []models.Experiment
+	var total int64
+
+	query := r.db.Model(&models.Experiment{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	find := r.withVariants()
+	if status != "" {
+		find = find.Where("status = ?", status)
+	}
+	offset := (page - 1) * limit
+	err := find.Order("id DESC").Offset(offset).Limit(limit).Find(&experiments).Error
+	return experiments, total, err
+}
+
+// ListRunning retrieves the running experiments with their variants
+func (r *ExperimentRepository) ListRunning() ([]models.Experiment, error) {
+	var experiments []models.Experiment
+	err := r.withVariants().Where("status = ?", models.ExperimentRunning).Order("id").Find(&experiments).Error
+	return experiments, err
+}
+
+// KeyExists reports whether another experiment than excludeID has a key.
+// Deleted experiments keep their key, so their events don't mix with a new one's.
+func (r *ExperimentRepository) KeyExists(key string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&models.Experiment{}).Where("key = ? AND id <> ?", key, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// Update saves an experiment's settings and replaces its variants
+func (r *ExperimentRepository) Update(experiment *models.Experiment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(experiment).Select("key", "name", "description", "traffic", "updated_by").
+			Updates(experiment).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("experiment_id = ?", experiment.ID).Delete(&models.ExperimentVariant{}).Error; err != nil {
+			return err
+		}
+		for i := range experiment.Variants {
+			experiment.Variants[i].ID = 0
+			experiment.Variants[i].ExperimentID = experiment.ID
+		}
+		if len(experiment.Variants) == 0 {
+			return nil
+		}
+		return tx.Create(&experiment.Variants).Error
+	})
+}
+
+// UpdateStatus saves an experiment's status and when it started and stopped
+func (r *ExperimentRepository) UpdateStatus(experiment *models.Experiment) error {
+	return r.db.Model(experiment).Select("status", "started_at", "stopped_at", "updated_by").Updates(experiment).Error
+}
+
+// Delete soft deletes an experiment, keeping its variants and events
+func (r *ExperimentRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Experiment{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RecordEvent adds an exposure or conversion
+func (r *ExperimentRepository) RecordEvent(event *models.ExperimentEvent) error {
+	return r.db.Create(event).Error
+}
+
+// Results returns the exposures and conversions of every variant of an
+// experiment with exposures. An empty goal counts conversions of every goal.
+func (r *ExperimentRepository) Results(experimentID uint, goal string) ([]ExperimentVariantResult, error) {
+	var results []ExperimentVariantResult
+	err := r.db.Raw(`
+		WITH exposed AS (
+			SELECT variant, subject, MIN(created_at) AS first_exposed
+			FROM experiment_events
+			WHERE experiment_id = @experiment AND kind = @exposure
+			GROUP BY variant, subject
+		)
+		SELECT x.variant, COUNT(DISTINCT x.subject) AS exposed, COUNT(DISTINCT c.subject) AS converted,
+			COUNT(c.id) AS conversions, COALESCE(SUM(c.value), 0) AS value
+		FROM exposed x
+		LEFT JOIN experiment_events c ON c.experiment_id = @experiment AND c.kind = @conversion
+			AND c.subject = x.subject AND c.created_at >= x.first_exposed AND (@goal = '' OR c.goal = @goal)
+		GROUP BY x.variant
+		ORDER BY x.variant`,
+		map[string]interface{}{
+			"experiment": experimentID,
+			"exposure":   models.ExperimentExposure,
+			"conversion": models.ExperimentConversion,
+			"goal":       goal,
+		}).
+		Scan(&results).Error
+	return results, err
+}
+
+// withVariants loads experiments with their variants in the order they were defined
+func (r *ExperimentRepository) withVariants() *gorm.DB {
+	return r.db.Preload("Variants", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	})
+}
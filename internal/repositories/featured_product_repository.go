@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// FeaturedProductRepository handles database operations for the curated featured products
+type FeaturedProductRepository struct {
+	db *gorm.DB
+}
+
+// NewFeaturedProductRepository creates a new FeaturedProductRepository instance
+func NewFeaturedProductRepository(db *gorm.DB) *FeaturedProductRepository {
+	return &FeaturedProductRepository{db: db}
+}
+
+// Create adds a product to the featured list
+func (r *FeaturedProductRepository) Create(featured *models.FeaturedProduct) error {
+	return r.db.Create(featured).Error
+}
+
+// GetByID retrieves a featured list entry
+func (r *FeaturedProductRepository) GetByID(id uint) (*models.FeaturedProduct, error) {
+	var featured models.FeaturedProduct
+	if err := r.db.First(&featured, id).Error; err != nil {
+		return nil, err
+	}
+	return &featured, nil
+}
+
+// List retrieves every entry, scheduled and expired ones included, in display order
+func (r *FeaturedProductRepository) List() ([]models.FeaturedProduct, error) {
+	var featured []models.FeaturedProduct
+	err := r.db.Order("position, id").Find(&featured).Error
+	return featured, err
+}
+
+// ProductFeatured reports whether another entry than excludeID already features a product
+func (r *FeaturedProductRepository) ProductFeatured(productID, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.FeaturedProduct{}).Where("product_id = ? AND id <> ?", productID, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// Update saves an entry's product, position and schedule
+func (r *FeaturedProductRepository) Update(featured *models.FeaturedProduct) error {
+	return r.db.Model(featured).Select("product_id", "position", "starts_at", "ends_at").Updates(featured).Error
+}
+
+// Delete removes an entry for good, so the product can be featured again
+func (r *FeaturedProductRepository) Delete(id uint) error {
+	result := r.db.Unscoped().Delete(&models.FeaturedProduct{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
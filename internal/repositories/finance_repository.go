@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"time"
+
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// LedgerTotals are the amounts a payment ledger recorded against one reference
+type LedgerTotals struct {
+	Reference string
+	Charged   float64 // Taken from the balance: store credit payments, gift card redemptions
+	Returned  float64 // Given back: store credit refunds, gift card restores
+}
+
+// UnmatchedPayment is money a ledger moved for an order that does not exist
+type UnmatchedPayment struct {
+	Ledger    string // store_credit or gift_card
+	Reference string
+	Net       float64 // Charged minus returned
+	FirstAt   time.Time
+}
+
+// FinanceRepository reads orders and the ledgers that paid for them, for reconciliation
+type FinanceRepository struct {
+	db *gorm.DB
+}
+
+// NewFinanceRepository creates a new finance repository
+func NewFinanceRepository(db *gorm.DB) *FinanceRepository {
+	return &FinanceRepository{db: db}
+}
+
+// OrderPlacements returns the order placement sagas started in the UTC days
+// [from, to] with their steps, oldest first
+func (r *FinanceRepository) OrderPlacements(from, to time.Time) ([]models.Saga, error) {
+	var sagas []models.Saga
+	err := r.db.Preload("Steps").
+		Where("type = ? AND created_at >= ? AND created_at < ?", models.SagaOrderPlacement, from, to.AddDate(0, 0, 1)).
+		Order("created_at, id").
+		Find(&sagas).Error
+	return sagas, err
+}
+
+// StoreCreditTotals sums the store credit paid and refunded per reference
+func (r *FinanceRepository) StoreCreditTotals(references []string) ([]LedgerTotals, error) {
+	var totals []LedgerTotals
+	if len(references) == 0 {
+		return totals, nil
+	}
+	err := r.db.Raw(`
+		SELECT reference,
+			CAST(COALESCE(SUM(-delta) FILTER (WHERE source = @payment), 0) AS double precision) AS charged,
+			CAST(COALESCE(SUM(delta) FILTER (WHERE source = @refund), 0) AS double precision) AS returned
+		FROM store_credit_entries
+		WHERE reference IN @references AND deleted_at IS NULL
+		GROUP BY reference`,
+		map[string]interface{}{
+			"payment":    models.StoreCreditSourcePayment,
+			"refund":     models.StoreCreditSourceRefund,
+			"references": references,
+		}).
+		Scan(&totals).Error
+	return totals, err
+}
+
+// GiftCardTotals sums the gift card balance redeemed and restored per reference
+func (r *FinanceRepository) GiftCardTotals(references []string) ([]LedgerTotals, error) {
+	var totals []LedgerTotals
+	if len(references) == 0 {
+		return totals, nil
+	}
+	err := r.db.Raw(`
+		SELECT reference,
+			CAST(COALESCE(SUM(-delta) FILTER (WHERE kind = @redeem), 0) AS double precision) AS charged,
+			CAST(COALESCE(SUM(delta) FILTER (WHERE kind = @restore), 0) AS double precision) AS returned
+		FROM gift_card_entries
+		WHERE reference IN @references AND deleted_at IS NULL
+		GROUP BY reference`,
+		map[string]interface{}{
+			"redeem":     models.GiftCardEntryRedeem,
+			"restore":    models.GiftCardEntryRestore,
+			"references": references,
+		}).
+		Scan(&totals).Error
+	return totals, err
+}
+
+// UnmatchedPayments returns the order payments and refunds first recorded in
+// the UTC days [from, to] whose order has no placement saga, by ledger and
+// reference
+func (r *FinanceRepository) UnmatchedPayments(from, to time.Time) ([]UnmatchedPayment, error) {
+	var payments []UnmatchedPayment
+	err := r.db.Raw(`
+		SELECT ledger, reference, CAST(-SUM(delta) AS double precision) AS net, MIN(created_at) AS first_at
+		FROM (
+			SELECT 'store_credit' AS ledger, reference, delta, created_at
+			FROM store_credit_entries
+			WHERE source IN (@payment, @refund) AND deleted_at IS NULL
+			UNION ALL
+			SELECT 'gift_card' AS ledger, reference, delta, created_at
+			FROM gift_card_entries
+			WHERE kind IN (@redeem, @restore) AND deleted_at IS NULL
+		) AS entries
+		WHERE reference LIKE 'Q-%'
+			AND NOT EXISTS (SELECT 1 FROM sagas WHERE sagas.type = @saga AND sagas.reference = entries.reference)
+		GROUP BY ledger, reference
+		HAVING MIN(created_at) >= @from AND MIN(created_at) < @to
+		ORDER BY first_at, reference`,
+		map[string]interface{}{
+			"payment": models.StoreCreditSourcePayment,
+			"refund":  models.StoreCreditSourceRefund,
+			"redeem":  models.GiftCardEntryRedeem,
+			"restore": models.GiftCardEntryRestore,
+			"saga":    models.SagaOrderPlacement,
+			"from":    from,
+			"to":      to.AddDate(0, 0, 1),
+		}).
+		Scan(&payments).Error
+	return payments, err
+}
@@ -0,0 +1,211 @@
+package repositories
+
+import (
+	"errors"
+	"math"
+	"product-management/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Errors returned when a balance cannot be used
+var (
+	ErrGiftCardExpired    = errors.New("gift card has expired")
+	ErrGiftCardEmpty      = errors.New("gift card has no balance left")
+	ErrInsufficientCredit = errors.New("store credit balance cannot go below zero")
+)
+
+// GiftCardRepository handles database operations for gift cards and store credit ledgers
+type GiftCardRepository struct {
+	db *gorm.DB
+}
+
+// NewGiftCardRepository creates a new gift card repository
+func NewGiftCardRepository(db *gorm.DB) *GiftCardRepository {
+	return &GiftCardRepository{db: db}
+}
+
+// WithTx returns a repository that runs its queries in the given transaction,
+// so checkout can apply balances atomically with the order
+func (r *GiftCardRepository) WithTx(tx *gorm.DB) *GiftCardRepository {
+	return &GiftCardRepository{db: tx}
+}
+
+// Create issues a new gift card and opens its ledger
+func (r *GiftCardRepository) Create(giftCard *models.GiftCard) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(giftCard).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.GiftCardEntry{
+			GiftCardID:       giftCard.ID,
+			Kind:             models.GiftCardEntryIssue,
+			Delta:            giftCard.InitialBalance,
+			ResultingBalance: giftCard.Balance,
+			ActorID:          giftCard.IssuedBy,
+		}).Error
+	})
+}
+
+// GetByCode retrieves a gift card by its code
+func (r *GiftCardRepository) GetByCode(code string) (*models.GiftCard, error) {
+	var giftCard models.GiftCard
+	if err := r.db.Where("code = ?", code).First(&giftCard).Error; err != nil {
+		return nil, err
+	}
+	return &giftCard, nil
+}
+
+// Redeem takes up to amount from a gift card's balance for what reference
+// paid for, and returns the ledger entry. The amount taken is less than
+// requested when the balance runs out.
+func (r *GiftCardRepository) Redeem(code string, amount float64, reference string, actorID uint) (*models.GiftCardEntry, error) {
+	return r.redeem("code", code, amount, reference, actorID)
+}
+
+// RedeemByID takes up to amount from a gift card's balance like Redeem, for
+// callers that kept the card's ID rather than its code
+func (r *GiftCardRepository) RedeemByID(id uint, amount float64, reference string, actorID uint) (*models.GiftCardEntry, error) {
+	return r.redeem("id", id, amount, reference, actorID)
+}
+
+// redeem takes up to amount from the balance of the gift card whose column
+// holds value
+func (r *GiftCardRepository) redeem(column string, value interface{}, amount float64, reference string, actorID uint) (*models.GiftCardEntry, error) {
+	var entry *models.GiftCardEntry
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		giftCard, err := lockGiftCard(tx, column, value)
+		if err != nil {
+			return err
+		}
+		if giftCard.Expired(time.Now()) {
+			return ErrGiftCardExpired
+		}
+		if giftCard.Balance <= 0 {
+			return ErrGiftCardEmpty
+		}
+
+		applied := roundCents(math.Min(amount, giftCard.Balance))
+		entry, err = r.WithTx(tx).addEntry(giftCard, models.GiftCardEntryRedeem, -applied, reference, actorID, "")
+		return err
+	})
+	return entry, err
+}
+
+// Restore gives amount back to a gift card after the payment reference it
+// covered was voided. Expired cards get it back too, so the ledger still adds up.
+func (r *GiftCardRepository) Restore(id uint, amount float64, reference, note string) (*models.GiftCardEntry, error) {
+	var entry *models.GiftCardEntry
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		giftCard, err := lockGiftCard(tx, "id", id)
+		if err != nil {
+			return err
+		}
+		entry, err = r.WithTx(tx).addEntry(giftCard, models.GiftCardEntryRestore, roundCents(amount), reference, 0, note)
+		return err
+	})
+	return entry, err
+}
+
+// ListEntries retrieves the ledger of a gift card, oldest first
+func (r *GiftCardRepository) ListEntries(giftCardID uint) ([]models.GiftCardEntry, error) {
+	var entries []models.GiftCardEntry
+	err := r.db.Where("gift_card_id = ?", giftCardID).Order("id").Find(&entries).Error
+	return entries, err
+}
+
+// lockGiftCard reads the gift card whose column (code or id) holds value and
+// locks it until the transaction ends, so concurrent balance changes apply in turn
+func lockGiftCard(tx *gorm.DB, column string, value interface{}) (*models.GiftCard, error) {
+	var giftCard models.GiftCard
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(column+" = ?", value).First(&giftCard).Error; err != nil {
+		return nil, err
+	}
+	return &giftCard, nil
+}
+
+// addEntry changes the balance of a locked gift card by delta and records it
+func (r *GiftCardRepository) addEntry(giftCard *models.GiftCard, kind models.GiftCardEntryKind, delta float64, reference string, actorID uint, note string) (*models.GiftCardEntry, error) {
+	giftCard.Balance = roundCents(giftCard.Balance + delta)
+	if err := r.db.Model(giftCard).Update("balance", giftCard.Balance).Error; err != nil {
+		return nil, err
+	}
+	entry := &models.GiftCardEntry{
+		GiftCardID:       giftCard.ID,
+		Kind:             kind,
+		Reference:        reference,
+		Delta:            delta,
+		ResultingBalance: giftCard.Balance,
+		ActorID:          actorID,
+		Note:             note,
+	}
+	return entry, r.db.Create(entry).Error
+}
+
+// GetStoreCreditBalance returns a user's current store credit balance
+func (r *GiftCardRepository) GetStoreCreditBalance(userID uint) (float64, error) {
+	var entry models.StoreCreditEntry
+	err := r.db.Where("user_id = ?", userID).Order("id DESC").First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	return entry.ResultingBalance, err
+}
+
+// SumStoreCredit returns the total credit a user received from a source
+func (r *GiftCardRepository) SumStoreCredit(userID uint, source models.StoreCreditSource) (float64, error) {
+	var total float64
+	err := r.db.Model(&models.StoreCreditEntry{}).
+		Where("user_id = ? AND source = ?", userID, source).
+		Select("COALESCE(SUM(delta), 0)").
+		Row().
+		Scan(&total)
+	return roundCents(total), err
+}
+
+// AddStoreCredit appends an entry to a user's store credit ledger. A negative
+// delta spends credit and fails rather than overdrawing the balance. It returns
+// gorm.ErrRecordNotFound when the user does not exist.
+func (r *GiftCardRepository) AddStoreCredit(entry *models.StoreCreditEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the user so concurrent entries compute their balance in turn
+		var userID uint
+		if err := tx.Raw("SELECT id FROM users WHERE id = ? AND deleted_at IS NULL FOR UPDATE", entry.UserID).Scan(&userID).Error; err != nil {
+			return err
+		}
+		if userID == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		balance, err := r.WithTx(tx).GetStoreCreditBalance(entry.UserID)
+		if err != nil {
+			return err
+		}
+		entry.ResultingBalance = roundCents(balance + entry.Delta)
+		if entry.ResultingBalance < 0 {
+			return ErrInsufficientCredit
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+// ListStoreCredit retrieves a paginated store credit ledger for a user, newest first
+func (r *GiftCardRepository) ListStoreCredit(userID uint, page, limit int) ([]models.StoreCreditEntry, int64, error) {
+	var entries []models.StoreCreditEntry
+	var total int64
+
+	query := r.db.Model(&models.StoreCreditEntry{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&entries).Error
+	return entries, total, err
+}
+
+// roundCents rounds an amount of money to two decimals
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"fmt"
+
+	"product-management/internal/models"
+	"product-management/pkg/events"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// integritySampleSize is how many broken records a scan lists per check
+const integritySampleSize = 20
+
+// IntegrityFindings are the records a check found broken: how many, and the
+// first few as references such as "review 42 (product 7)"
+type IntegrityFindings struct {
+	Count   int64
+	Samples []string
+}
+
+// IntegrityRepository finds records that break the relationships the
+// application relies on, and repairs them
+type IntegrityRepository struct {
+	db *gorm.DB
+}
+
+// NewIntegrityRepository creates a new IntegrityRepository instance
+func NewIntegrityRepository(db *gorm.DB) *IntegrityRepository {
+	return &IntegrityRepository{db: db}
+}
+
+// orphanedProductCategories selects the category links whose product row is
+// gone, or whose category row is gone or deleted
+const orphanedProductCategories = `FROM product_categories pc
+	LEFT JOIN products p ON p.id = pc.product_id
+	LEFT JOIN categories c ON c.id = pc.category_id
+	WHERE p.id IS NULL OR c.id IS NULL OR c.deleted_at IS NOT NULL`
+
+// FindOrphanedProductCategories finds category links left without a product
+// or a live category
+func (r *IntegrityRepository) FindOrphanedProductCategories() (*IntegrityFindings, error) {
+	var rows []struct{ ProductID, CategoryID uint }
+	err := r.db.Raw("SELECT pc.product_id, pc.category_id "+orphanedProductCategories+" ORDER BY pc.product_id, pc.category_id LIMIT ?", integritySampleSize).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	findings := &IntegrityFindings{Samples: make([]string, len(rows))}
+	for i, row := range rows {
+		findings.Samples[i] = fmt.Sprintf("product %d, category %d", row.ProductID, row.CategoryID)
+	}
+	if err := r.db.Raw("SELECT COUNT(*) " + orphanedProductCategories).Scan(&findings.Count).Error; err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+// DeleteOrphanedProductCategories deletes the category links left without a
+// product or a live category, recounting the products of their categories,
+// and returns how many were deleted
+func (r *IntegrityRepository) DeleteOrphanedProductCategories() (int64, error) {
+	var categoryIDs []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw(`DELETE FROM product_categories pc
+			WHERE NOT EXISTS (SELECT 1 FROM products p WHERE p.id = pc.product_id)
+			OR NOT EXISTS (SELECT 1 FROM categories c WHERE c.id = pc.category_id AND c.deleted_at IS NULL)
+			RETURNING pc.category_id`).
+			Scan(&categoryIDs).Error; err != nil {
+			return err
+		}
+		_, err := refreshProductCounts(tx, categoryIDs)
+		return err
+	})
+	return int64(len(categoryIDs)), err
+}
+
+// reviewsOfDeletedProducts scopes the live reviews whose product row is gone
+// or deleted
+func reviewsOfDeletedProducts(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.Review{}).
+		Where("NOT EXISTS (SELECT 1 FROM products p WHERE p.id = reviews.product_id AND p.deleted_at IS NULL)")
+}
+
+// FindReviewsOfDeletedProducts finds the live reviews of products that were
+// deleted or are missing
+func (r *IntegrityRepository) FindReviewsOfDeletedProducts() (*IntegrityFindings, error) {
+	var reviews []models.Review
+	if err := reviewsOfDeletedProducts(r.db).Select("id", "product_id").Order("id").Limit(integritySampleSize).Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	findings := &IntegrityFindings{Samples: make([]string, len(reviews))}
+	for i, review := range reviews {
+		findings.Samples[i] = fmt.Sprintf("review %d (product %d)", review.ID, review.ProductID)
+	}
+	if err := reviewsOfDeletedProducts(r.db).Count(&findings.Count).Error; err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+// DeleteReviewsOfDeletedProducts soft-deletes the live reviews of products
+// that were deleted or are missing and returns how many were deleted
+func (r *IntegrityRepository) DeleteReviewsOfDeletedProducts() (int64, error) {
+	result := r.db.Where("NOT EXISTS (SELECT 1 FROM products p WHERE p.id = reviews.product_id AND p.deleted_at IS NULL)").
+		Delete(&models.Review{})
+	return result.RowsAffected, result.Error
+}
+
+// FindNegativeStock finds the products, deleted ones included, whose stock
+// quantity is below zero
+func (r *IntegrityRepository) FindNegativeStock() (*IntegrityFindings, error) {
+	var products []models.Product
+	err := r.db.Unscoped().Select("id", "stock_quantity").Where("stock_quantity < 0").
+		Order("id").Limit(integritySampleSize).Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	findings := &IntegrityFindings{Samples: make([]string, len(products))}
+	for i, product := range products {
+		findings.Samples[i] = fmt.Sprintf("product %d (stock %d)", product.ID, product.StockQuantity)
+	}
+	if err := r.db.Unscoped().Model(&models.Product{}).Where("stock_quantity < 0").Count(&findings.Count).Error; err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+// ResetNegativeStock sets every negative stock quantity to zero, recording an
+// adjustment in the stock ledger and the usual events for each product, and
+// returns the IDs of the products repaired
+func (r *IntegrityRepository) ResetNegativeStock(actorID uint) ([]uint, error) {
+	var repaired []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var products []models.Product
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "name", "stock_quantity").Where("stock_quantity < 0").
+			Order("id").Find(&products).Error; err != nil {
+			return err
+		}
+		for _, product := range products {
+			if err := tx.Unscoped().Model(&product).Update("stock_quantity", 0).Error; err != nil {
+				return err
+			}
+			if err := recordStockMovement(tx, product.ID, models.StockSourceAdjustment, "", -product.StockQuantity, 0, actorID, "integrity repair"); err != nil {
+				return err
+			}
+			if err := recordEvent(tx, events.ProductChanged{ProductID: product.ID}); err != nil {
+				return err
+			}
+			if err := recordEvent(tx, events.StockChanged{ProductID: product.ID, ProductName: product.Name, Previous: product.StockQuantity, Quantity: 0}); err != nil {
+				return err
+			}
+			repaired = append(repaired, product.ID)
+		}
+		return nil
+	})
+	return repaired, err
+}
+
+// wishlistsOfMissingUsers scopes the wishlist entries, deleted ones included,
+// whose user row is gone. Deleted and anonymized users keep their row.
+func wishlistsOfMissingUsers(db *gorm.DB) *gorm.DB {
+	return db.Unscoped().Model(&models.Wishlist{}).
+		Where("NOT EXISTS (SELECT 1 FROM users u WHERE u.id = wishlists.user_id)")
+}
+
+// FindWishlistsOfMissingUsers finds the wishlist entries of users that no
+// longer exist
+func (r *IntegrityRepository) FindWishlistsOfMissingUsers() (*IntegrityFindings, error) {
+	var wishlists []models.Wishlist
+	if err := wishlistsOfMissingUsers(r.db).Select("id", "user_id").Order("id").Limit(integritySampleSize).Find(&wishlists).Error; err != nil {
+		return nil, err
+	}
+	findings := &IntegrityFindings{Samples: make([]string, len(wishlists))}
+	for i, wishlist := range wishlists {
+		findings.Samples[i] = fmt.Sprintf("wishlist %d (user %d)", wishlist.ID, wishlist.UserID)
+	}
+	if err := wishlistsOfMissingUsers(r.db).Count(&findings.Count).Error; err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+// DeleteWishlistsOfMissingUsers permanently deletes the wishlist entries of
+// users that no longer exist and returns how many were deleted
+func (r *IntegrityRepository) DeleteWishlistsOfMissingUsers() (int64, error) {
+	result := r.db.Unscoped().Where("NOT EXISTS (SELECT 1 FROM users u WHERE u.id = wishlists.user_id)").
+		Delete(&models.Wishlist{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"errors"
+	"product-management/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationSettingsRepository handles database operations for notification
+// settings and the known devices behind new-device alerts
+type NotificationSettingsRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationSettingsRepository creates a new notification settings repository
+func NewNotificationSettingsRepository(db *gorm.DB) *NotificationSettingsRepository {
+	return &NotificationSettingsRepository{db: db}
+}
+
+// GetByUserID retrieves a user's settings, or the defaults if they never saved any
+func (r *NotificationSettingsRepository) GetByUserID(userID uint) (*models.NotificationSettings, error) {
+	var settings models.NotificationSettings
+	err := r.db.Where("user_id = ?", userID).First(&settings).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DefaultNotificationSettings(userID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// Save creates or updates a user's settings
+func (r *NotificationSettingsRepository) Save(settings *models.NotificationSettings) error {
+	return r.db.Save(settings).Error
+}
+
+// TouchDevice records a login from a device and reports whether the device is
+// new and whether the user had logged in from any other device before
+func (r *NotificationSettingsRepository) TouchDevice(userID uint, fingerprint, userAgent, ip, country, city string) (isNew, hadDevices bool, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var device models.KnownDevice
+		err := tx.Where("user_id = ? AND fingerprint = ?", userID, fingerprint).First(&device).Error
+		if err == nil {
+			return tx.Model(&device).Updates(map[string]interface{}{
+				"last_ip":      ip,
+				"last_country": country,
+				"last_city":    city,
+				"last_seen_at": time.Now(),
+			}).Error
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.KnownDevice{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+			return err
+		}
+		isNew, hadDevices = true, count > 0
+
+		// A concurrent login from the same device may have recorded it first
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.KnownDevice{
+			UserID:      userID,
+			Fingerprint: fingerprint,
+			UserAgent:   userAgent,
+			LastIP:      ip,
+			LastCountry: country,
+			LastCity:    city,
+			LastSeenAt:  time.Now(),
+		}).Error
+	})
+	return isNew, hadDevices, err
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"time"
+
+	"product-management/internal/models"
+	"product-management/pkg/events"
+
+	"gorm.io/gorm"
+)
+
+// OutboxRepository handles the events waiting in the outbox for the relay
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// recordEvent adds an event to the outbox within tx, so it is published if and
+// only if the change it reports is committed. Product changes are appended to
+// the sync log as well.
+func recordEvent(tx *gorm.DB, event events.Event) error {
+	payload, err := events.Encode(event)
+	if err != nil {
+		return err
+	}
+	if changed, ok := event.(events.ProductChanged); ok {
+		operation := models.SyncUpsert
+		if changed.Deleted {
+			operation = models.SyncDelete
+		}
+		if err := recordSyncChange(tx, models.SyncEntityProduct, changed.ProductID, nil, operation); err != nil {
+			return err
+		}
+	}
+	return tx.Create(&models.OutboxEvent{
+		Topic:         event.Topic(),
+		Payload:       string(payload),
+		Status:        models.OutboxPending,
+		NextAttemptAt: time.Now(),
+	}).Error
+}
+
+// Record adds an event to the outbox, within the transaction of the
+// repository when it was created with one
+func (r *OutboxRepository) Record(event events.Event) error {
+	return recordEvent(r.db, event)
+}
+
+// ListDue retrieves up to limit pending events whose next attempt is due,
+// oldest first
+func (r *OutboxRepository) ListDue(limit int) ([]models.OutboxEvent, error) {
+	var outboxEvents []models.OutboxEvent
+	err := r.db.Where("status = ? AND next_attempt_at <= ?", models.OutboxPending, time.Now()).
+		Order("id").Limit(limit).Find(&outboxEvents).Error
+	return outboxEvents, err
+}
+
+// MarkPublished records that an event was published
+func (r *OutboxRepository) MarkPublished(event *models.OutboxEvent) error {
+	now := time.Now()
+	return r.db.Model(event).Updates(map[string]interface{}{
+		"status":       models.OutboxPublished,
+		"attempts":     event.Attempts + 1,
+		"last_error":   "",
+		"published_at": now,
+	}).Error
+}
+
+// MarkAttemptFailed records a failed attempt to publish an event, leaving it
+// pending until nextAttempt, or failed for good when nextAttempt is nil
+func (r *OutboxRepository) MarkAttemptFailed(event *models.OutboxEvent, attemptErr error, nextAttempt *time.Time) error {
+	updates := map[string]interface{}{
+		"attempts":   event.Attempts + 1,
+		"last_error": attemptErr.Error(),
+	}
+	if nextAttempt != nil {
+		updates["next_attempt_at"] = *nextAttempt
+	} else {
+		updates["status"] = models.OutboxFailed
+	}
+	return r.db.Model(event).Updates(updates).Error
+}
+
+// List retrieves a paginated list of events, newest first, optionally
+// filtered by status and topic
+func (r *OutboxRepository) List(status models.OutboxStatus, topic string, page, limit int) ([]models.OutboxEvent, int64, error) {
+	var outboxEvents []models.OutboxEvent
+	var total int64
+
+	query := r.db.Model(&models.OutboxEvent{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if topic != "" {
+		query = query.Where("topic = ?", topic)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&outboxEvents).Error
+	return outboxEvents, total, err
+}
+
+// Reprocess queues events again for the relay with a fresh set of attempts:
+// the failed ones, or the given ones whatever their status, and returns how
+// many were queued
+func (r *OutboxRepository) Reprocess(ids []uint) (int64, error) {
+	query := r.db.Model(&models.OutboxEvent{})
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	} else {
+		query = query.Where("status = ?", models.OutboxFailed)
+	}
+	result := query.Updates(map[string]interface{}{
+		"status":          models.OutboxPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+		"published_at":    nil,
+	})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// PriceListRepository handles database operations for price lists and their assignment to users
+type PriceListRepository struct {
+	db *gorm.DB
+}
+
+// NewPriceListRepository creates a new PriceListRepository instance
+func NewPriceListRepository(db *gorm.DB) *PriceListRepository {
+	return &PriceListRepository{db: db}
+}
+
+// Create creates a new price list
+func (r *PriceListRepository) Create(priceList *models.PriceList) error {
+	return r.db.Create(priceList).Error
+}
+
+// GetByID retrieves a price list with its items ordered by product and quantity
+func (r *PriceListRepository) GetByID(id uint) (*models.PriceList, error) {
+	var priceList models.PriceList
+	err := r.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("product_id, min_quantity")
+	}).First(&priceList, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &priceList, nil
+}
+
+// List retrieves every price list ordered by name, without items
+func (r *PriceListRepository) List() ([]models.PriceList, error) {
+	var priceLists []models.PriceList
+	err := r.db.Order("name").Find(&priceLists).Error
+	return priceLists, err
+}
+
+// NameExists reports whether another price list than excludeID already has a name
+func (r *PriceListRepository) NameExists(name string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.PriceList{}).Where("LOWER(name) = LOWER(?) AND id <> ?", name, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// Update saves a price list's name and description
+func (r *PriceListRepository) Update(priceList *models.PriceList) error {
+	return r.db.Model(priceList).Select("name", "description").Updates(priceList).Error
+}
+
+// Delete deletes a price list with its items and moves its customers back to list prices
+func (r *PriceListRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.PriceList{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Unscoped().Where("price_list_id = ?", id).Delete(&models.PriceListItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).Where("price_list_id = ?", id).Update("price_list_id", nil).Error
+	})
+}
+
+// ListItems retrieves the items of a price list
+func (r *PriceListRepository) ListItems(priceListID uint) ([]models.PriceListItem, error) {
+	var items []models.PriceListItem
+	err := r.db.Where("price_list_id = ?", priceListID).Order("product_id, min_quantity").Find(&items).Error
+	return items, err
+}
+
+// SetProductPrices replaces the prices of a product in a price list. No items
+// removes the product, so it is sold at its list price again.
+func (r *PriceListRepository) SetProductPrices(priceListID, productID uint, items []models.PriceListItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Hard delete, as the unique index also covers soft-deleted rows
+		if err := tx.Unscoped().Where("price_list_id = ? AND product_id = ?", priceListID, productID).Delete(&models.PriceListItem{}).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return tx.Create(&items).Error
+	})
+}
+
+// GetUserPriceListID returns the price list assigned to a user, nil for none
+func (r *PriceListRepository) GetUserPriceListID(userID uint) (*uint, error) {
+	var user models.User
+	if err := r.db.Select("id", "price_list_id").First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+	return user.PriceListID, nil
+}
+
+// AssignUser assigns a price list to a user, or list prices when priceListID is nil
+func (r *PriceListRepository) AssignUser(userID uint, priceListID *uint) error {
+	result := r.db.Model(&models.User{}).Where("id = ?", userID).Update("price_list_id", priceListID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
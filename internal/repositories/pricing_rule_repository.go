@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"time"
+
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// PricingRuleRepository handles database operations for pricing rules and the
+// quote items they priced
+type PricingRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewPricingRuleRepository creates a new PricingRuleRepository instance
+func NewPricingRuleRepository(db *gorm.DB) *PricingRuleRepository {
+	return &PricingRuleRepository{db: db}
+}
+
+// Create adds a pricing rule
+func (r *PricingRuleRepository) Create(rule *models.PricingRule) error {
+	return r.db.Create(rule).Error
+}
+
+// GetByID retrieves a pricing rule by ID
+func (r *PricingRuleRepository) GetByID(id uint) (*models.PricingRule, error) {
+	var rule models.PricingRule
+	if err := r.db.First(&rule, id).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// List retrieves every pricing rule in the order they are tried: highest
+// priority first, then oldest first
+func (r *PricingRuleRepository) List() ([]models.PricingRule, error) {
+	var rules []models.PricingRule
+	err := r.db.Order("priority DESC, id").Find(&rules).Error
+	return rules, err
+}
+
+// ListEnabled retrieves the enabled rules that have not ended by a time, in the
+// order they are tried. Rules scheduled to start later are included.
+func (r *PricingRuleRepository) ListEnabled(at time.Time) ([]models.PricingRule, error) {
+	var rules []models.PricingRule
+	err := r.db.Where("enabled AND (ends_at IS NULL OR ends_at > ?)", at).Order("priority DESC, id").Find(&rules).Error
+	return rules, err
+}
+
+// Update saves a rule's conditions, adjustment and schedule
+func (r *PricingRuleRepository) Update(rule *models.PricingRule) error {
+	return r.db.Model(rule).Select("name", "description", "priority", "enabled", "starts_at", "ends_at",
+		"category_id", "min_stock", "no_sales_days", "adjustment", "value", "updated_by").Updates(rule).Error
+}
+
+// Delete soft deletes a pricing rule, so the quote items it priced still name it
+func (r *PricingRuleRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.PricingRule{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UnsoldProductIDs returns the products matching a rule's category and stock
+// conditions that were listed before since and had no order from then on
+func (r *PricingRuleRepository) UnsoldProductIDs(rule *models.PricingRule, since time.Time) ([]uint, error) {
+	query := r.db.Model(&models.Product{}).
+		Where("products.created_at < ?", since).
+		Where("NOT EXISTS (SELECT 1 FROM stock_movements WHERE stock_movements.product_id = products.id AND stock_movements.source = ? AND stock_movements.delta < 0 AND stock_movements.created_at >= ?)",
+			models.StockSourceOrder, since)
+	if rule.MinStock != nil {
+		query = query.Where("products.stock_quantity > ?", *rule.MinStock)
+	}
+	if rule.CategoryID != nil {
+		query = query.Joins("JOIN product_categories ON products.id = product_categories.product_id").
+			Where("product_categories.category_id = ?", *rule.CategoryID)
+	}
+
+	var ids []uint
+	err := query.Pluck("products.id", &ids).Error
+	return ids, err
+}
+
+// ListApplications retrieves a paginated list of the quote items a rule priced,
+// newest first
+func (r *PricingRuleRepository) ListApplications(ruleID uint, page, limit int) ([]models.QuoteItem, int64, error) {
+	var items []models.QuoteItem
+	var total int64
+
+	query := r.db.Model(&models.QuoteItem{}).Where("pricing_rule_id = ?", ruleID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&items).Error
+	return items, total, err
+}
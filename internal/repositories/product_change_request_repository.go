@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"errors"
+	"product-management/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrChangeRequestResolved is returned when reviewing a change request that is no longer pending
+var ErrChangeRequestResolved = errors.New("change request has already been resolved")
+
+// ProductChangeRequestRepository handles database operations for product change requests
+type ProductChangeRequestRepository struct {
+	db *gorm.DB
+}
+
+// NewProductChangeRequestRepository creates a new product change request repository
+func NewProductChangeRequestRepository(db *gorm.DB) *ProductChangeRequestRepository {
+	return &ProductChangeRequestRepository{db: db}
+}
+
+// Create creates a new change request
+func (r *ProductChangeRequestRepository) Create(changeRequest *models.ProductChangeRequest) error {
+	return r.db.Create(changeRequest).Error
+}
+
+// GetByID retrieves a change request by its ID
+func (r *ProductChangeRequestRepository) GetByID(id uint) (*models.ProductChangeRequest, error) {
+	var changeRequest models.ProductChangeRequest
+	if err := r.db.First(&changeRequest, id).Error; err != nil {
+		return nil, err
+	}
+	return &changeRequest, nil
+}
+
+// List retrieves a paginated list of change requests, optionally filtered by status
+func (r *ProductChangeRequestRepository) List(status models.ChangeRequestStatus, page, pageSize int) ([]models.ProductChangeRequest, int64, error) {
+	var changeRequests []models.ProductChangeRequest
+	var total int64
+
+	query := r.db.Model(&models.ProductChangeRequest{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Order("created_at DESC").
+		Offset(offset).Limit(pageSize).
+		Find(&changeRequests).Error
+
+	return changeRequests, total, err
+}
+
+// Resolve marks a pending change request as approved or rejected.
+// For approvals, apply runs in the same transaction so the product update and the
+// status change are committed together.
+func (r *ProductChangeRequestRepository) Resolve(id uint, status models.ChangeRequestStatus, reviewerID uint, note string, apply func(tx *gorm.DB, changeRequest *models.ProductChangeRequest) error) (*models.ProductChangeRequest, error) {
+	var changeRequest models.ProductChangeRequest
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&changeRequest, id).Error; err != nil {
+			return err
+		}
+		if changeRequest.Status != models.ChangeRequestPending {
+			return ErrChangeRequestResolved
+		}
+
+		if apply != nil {
+			if err := apply(tx, &changeRequest); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		changeRequest.Status = status
+		changeRequest.ReviewedBy = &reviewerID
+		changeRequest.ReviewedAt = &now
+		changeRequest.ReviewNote = note
+
+		return tx.Model(&changeRequest).Updates(map[string]interface{}{
+			"status":      changeRequest.Status,
+			"reviewed_by": reviewerID,
+			"reviewed_at": now,
+			"review_note": note,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &changeRequest, nil
+}
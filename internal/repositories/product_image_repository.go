@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"errors"
+
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrImageLimit is returned when a product already has as many images as allowed
+var ErrImageLimit = errors.New("product has reached its image limit")
+
+// ProductImageRepository handles database operations for product images
+type ProductImageRepository struct {
+	db *gorm.DB
+}
+
+// NewProductImageRepository creates a new ProductImageRepository instance
+func NewProductImageRepository(db *gorm.DB) *ProductImageRepository {
+	return &ProductImageRepository{db: db}
+}
+
+// ListByProduct retrieves the images of a product in display order
+func (r *ProductImageRepository) ListByProduct(productID uint) ([]models.ProductImage, error) {
+	var images []models.ProductImage
+	err := orderImages(r.db).Where("product_id = ?", productID).Find(&images).Error
+	return images, err
+}
+
+// GetByID retrieves an image of a product
+func (r *ProductImageRepository) GetByID(productID, id uint) (*models.ProductImage, error) {
+	var image models.ProductImage
+	if err := r.db.Where("product_id = ?", productID).First(&image, id).Error; err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+// Create adds an image after the last one of its product, failing with
+// ErrImageLimit when the product already has limit images. The product row is
+// locked so concurrent uploads can't exceed the limit or share a position.
+func (r *ProductImageRepository) Create(image *models.ProductImage, limit int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&product, image.ProductID).Error; err != nil {
+			return err
+		}
+
+		var stats struct {
+			Count        int
+			NextPosition int
+		}
+		if err := tx.Model(&models.ProductImage{}).
+			Select("COUNT(*) AS count, COALESCE(MAX(position) + 1, 0) AS next_position").
+			Where("product_id = ?", image.ProductID).
+			Scan(&stats).Error; err != nil {
+			return err
+		}
+		if stats.Count >= limit {
+			return ErrImageLimit
+		}
+
+		image.Position = stats.NextPosition
+		return tx.Create(image).Error
+	})
+}
+
+// Reorder sets the positions of a product's images to their order in ids
+func (r *ProductImageRepository) Reorder(productID uint, ids []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for position, id := range ids {
+			if err := tx.Model(&models.ProductImage{}).
+				Where("id = ? AND product_id = ?", id, productID).
+				Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete removes an image of a product and returns it, so its file can be deleted
+func (r *ProductImageRepository) Delete(productID, id uint) (*models.ProductImage, error) {
+	var image models.ProductImage
+	result := r.db.Clauses(clause.Returning{}).Where("product_id = ?", productID).Delete(&image, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &image, nil
+}
+
+// orderImages sorts product images in display order, also when preloaded with
+// their products
+func orderImages(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
@@ -1,12 +1,25 @@
 package repositories
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"product-management/internal/dto"
 	"product-management/internal/models"
+	"product-management/pkg/events"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrInsufficientStock is returned when an order asks for more of a product than is in stock
+var ErrInsufficientStock = errors.New("not enough stock")
+
 // ProductRepository handles database operations for products
 type ProductRepository struct {
 	db *gorm.DB
@@ -17,23 +30,70 @@ func NewProductRepository(db *gorm.DB) *ProductRepository {
 	return &ProductRepository{db: db}
 }
 
-// Create creates a new product with categories
-func (r *ProductRepository) Create(product *models.Product, categories []models.Category) error {
+// WithTx returns a repository bound to the given transaction
+func (r *ProductRepository) WithTx(tx *gorm.DB) *ProductRepository {
+	return &ProductRepository{db: tx}
+}
+
+// Create creates a new product with categories, records its first revision
+// and adds a ProductChanged event to the outbox
+func (r *ProductRepository) Create(product *models.Product, categories []models.Category, editorID uint) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(product).Error; err != nil {
 			return err
 		}
 		if len(categories) > 0 {
-			return tx.Model(product).Association("Categories").Append(categories)
+			if err := tx.Model(product).Association("Categories").Append(categories); err != nil {
+				return err
+			}
+			categoryIDs := make([]uint, len(categories))
+			for i, category := range categories {
+				categoryIDs[i] = category.ID
+			}
+			if _, err := refreshProductCounts(tx, categoryIDs); err != nil {
+				return err
+			}
 		}
-		return nil
+		if product.StockQuantity != 0 {
+			if err := recordStockMovement(tx, product.ID, models.StockSourceAdjustment, "", product.StockQuantity, product.StockQuantity, editorID, "initial stock"); err != nil {
+				return err
+			}
+		}
+		if err := recordEvent(tx, events.ProductChanged{ProductID: product.ID}); err != nil {
+			return err
+		}
+		return recordRevision(tx, product.ID, editorID)
 	})
 }
 
 // GetByID retrieves a product by ID
 func (r *ProductRepository) GetByID(id uint) (*models.Product, error) {
 	var product models.Product
-	err := r.db.Preload("Categories").Preload("Reviews").First(&product, id).Error
+	err := r.db.Preload("Categories").Preload("Images", orderImages).Preload("Reviews").First(&product, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &product, nil
+}
+
+// GetByIDs retrieves the products with the given IDs in a single query, in no
+// particular order. IDs that do not exist are left out.
+func (r *ProductRepository) GetByIDs(ids []uint) ([]models.Product, error) {
+	var products []models.Product
+	if len(ids) == 0 {
+		return products, nil
+	}
+	err := r.db.Preload("Categories").Preload("Images", orderImages).Preload("Reviews").Where("id IN ?", ids).Find(&products).Error
+	return products, err
+}
+
+// GetBySKU retrieves a product by its SKU, returning nil when none has it
+func (r *ProductRepository) GetBySKU(sku string) (*models.Product, error) {
+	var product models.Product
+	err := r.db.Where("sku = ?", sku).First(&product).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -43,20 +103,92 @@ func (r *ProductRepository) GetByID(id uint) (*models.Product, error) {
 	return &product, nil
 }
 
+// SKUExists reports whether a product other than excludeID, deleted ones
+// included, already has an SKU
+func (r *ProductRepository) SKUExists(sku string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&models.Product{}).Where("sku = ? AND id <> ?", sku, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// EachProduct calls fn for every product with its categories, ordered by ID and
+// loaded in batches so large exports stay in bounded memory
+func (r *ProductRepository) EachProduct(batchSize int, fn func(product models.Product) error) error {
+	var products []models.Product
+	return r.db.Preload("Categories").Order("id").FindInBatches(&products, batchSize, func(tx *gorm.DB, batch int) error {
+		for _, product := range products {
+			if err := fn(product); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
 // GetAll retrieves all products
 func (r *ProductRepository) GetAll() ([]models.Product, error) {
 	var products []models.Product
-	err := r.db.Preload("Categories").Preload("Reviews").Find(&products).Error
+	err := r.db.Preload("Categories").Preload("Images", orderImages).Preload("Reviews").Find(&products).Error
 	return products, err
 }
 
-// Update updates a product and its categories
-func (r *ProductRepository) Update(product *models.Product, categoryIDs []uint) error {
+// productDetailColumns are the columns of a product besides its stock quantity
+var productDetailColumns = []string{"name", "description", "sku", "location", "bookable", "price", "status", "allowed_countries", "blocked_countries", "metadata"}
+
+// Update updates a product and its categories, records a new revision and adds
+// a ProductChanged event to the outbox, with a StockChanged event when the
+// stock quantity changed
+func (r *ProductRepository) Update(product *models.Product, categoryIDs []uint, editorID uint) error {
+	return r.update(product, categoryIDs, editorID, true)
+}
+
+// UpdateDetails updates a product and its categories like Update but leaves
+// its stock quantity as it is, for edits replaying an earlier state of the
+// product: the stock movement ledger owns the quantity
+func (r *ProductRepository) UpdateDetails(product *models.Product, categoryIDs []uint, editorID uint) error {
+	return r.update(product, categoryIDs, editorID, false)
+}
+
+// update writes a product's details, and its stock quantity when withStock is set
+func (r *ProductRepository) update(product *models.Product, categoryIDs []uint, editorID uint, withStock bool) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Model(product).Select("name", "description", "price", "stock_quantity", "status").Updates(product).Error; err != nil {
+		// Lock the row so the stock delta is computed against a stable quantity
+		var previousQuantity int
+		if err := tx.Model(&models.Product{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", product.ID).
+			Select("stock_quantity").
+			Row().
+			Scan(&previousQuantity); err != nil {
 			return err
 		}
 
+		columns := productDetailColumns
+		if withStock {
+			columns = append(columns[:len(columns):len(columns)], "stock_quantity")
+		} else {
+			product.StockQuantity = previousQuantity
+		}
+		if err := tx.Model(product).Select(columns).Updates(product).Error; err != nil {
+			return err
+		}
+
+		if delta := product.StockQuantity - previousQuantity; delta != 0 {
+			if err := recordStockMovement(tx, product.ID, models.StockSourceAdjustment, "", delta, product.StockQuantity, editorID, "product update"); err != nil {
+				return err
+			}
+			if err := recordEvent(tx, events.StockChanged{ProductID: product.ID, ProductName: product.Name, Previous: previousQuantity, Quantity: product.StockQuantity}); err != nil {
+				return err
+			}
+		}
+		if err := recordEvent(tx, events.ProductChanged{ProductID: product.ID}); err != nil {
+			return err
+		}
+
+		previousCategoryIDs, err := linkedCategoryIDs(tx, product.ID)
+		if err != nil {
+			return err
+		}
 		if err := tx.Model(product).Association("Categories").Clear(); err != nil {
 			return err
 		}
@@ -66,19 +198,360 @@ func (r *ProductRepository) Update(product *models.Product, categoryIDs []uint)
 			if err := tx.Find(&categories, categoryIDs).Error; err != nil {
 				return err
 			}
-			return tx.Model(product).Association("Categories").Append(categories)
+			if err := tx.Model(product).Association("Categories").Append(categories); err != nil {
+				return err
+			}
+		}
+		if _, err := refreshProductCounts(tx, append(previousCategoryIDs, categoryIDs...)); err != nil {
+			return err
+		}
+		return recordRevision(tx, product.ID, editorID)
+	})
+}
+
+// recordStockMovement appends an entry to a product's stock ledger
+func recordStockMovement(tx *gorm.DB, productID uint, source models.StockMovementSource, reference string, delta, resultingQuantity int, actorID uint, note string) error {
+	return tx.Create(&models.StockMovement{
+		ProductID:         productID,
+		Source:            source,
+		Reference:         reference,
+		Delta:             delta,
+		ResultingQuantity: resultingQuantity,
+		ActorID:           actorID,
+		Note:              note,
+	}).Error
+}
+
+// ReserveStock takes quantities of products out of stock for an order, all or
+// none, recording order stock movements referencing it with ProductChanged and
+// StockChanged events in the outbox. It fails with ErrInsufficientStock when a
+// product has less in stock than asked for, and gorm.ErrRecordNotFound when a
+// product no longer exists.
+func (r *ProductRepository) ReserveStock(quantities map[uint]int, reference string, actorID uint) ([]StockChange, error) {
+	return r.moveStock(quantities, -1, reference, actorID, "reserved for order")
+}
+
+// ReleaseStock puts quantities reserved for an order back in stock, including
+// for products deleted since, recording the movements and events like
+// ReserveStock
+func (r *ProductRepository) ReleaseStock(quantities map[uint]int, reference string, actorID uint) ([]StockChange, error) {
+	return r.moveStock(quantities, 1, reference, actorID, "reservation released")
+}
+
+// moveStock adds or removes quantities of products from stock in a transaction
+func (r *ProductRepository) moveStock(quantities map[uint]int, sign int, reference string, actorID uint, note string) ([]StockChange, error) {
+	// Lock products in ID order so concurrent orders cannot deadlock
+	productIDs := make([]uint, 0, len(quantities))
+	for productID := range quantities {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Slice(productIDs, func(i, j int) bool { return productIDs[i] < productIDs[j] })
+
+	var changes []StockChange
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, productID := range productIDs {
+			query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "name", "stock_quantity")
+			if sign > 0 {
+				query = query.Unscoped()
+			}
+			var product models.Product
+			if err := query.First(&product, productID).Error; err != nil {
+				return err
+			}
+			delta := sign * quantities[productID]
+			resulting := product.StockQuantity + delta
+			if resulting < 0 {
+				return fmt.Errorf("%w: %s has %d left", ErrInsufficientStock, product.Name, product.StockQuantity)
+			}
+			if err := tx.Model(&product).Update("stock_quantity", resulting).Error; err != nil {
+				return err
+			}
+			if err := recordStockMovement(tx, product.ID, models.StockSourceOrder, reference, delta, resulting, actorID, note); err != nil {
+				return err
+			}
+			if err := recordEvent(tx, events.ProductChanged{ProductID: product.ID}); err != nil {
+				return err
+			}
+			if err := recordEvent(tx, events.StockChanged{ProductID: product.ID, ProductName: product.Name, Previous: product.StockQuantity, Quantity: resulting}); err != nil {
+				return err
+			}
+			changes = append(changes, StockChange{ProductID: product.ID, ProductName: product.Name, Previous: product.StockQuantity, Quantity: resulting})
 		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// AdjustStock moves a product's stock by the movement's delta and records the
+// movement with its resulting quantity, and ProductChanged and StockChanged
+// events in the outbox. The product is locked while its stock changes, and
+// stock never goes below zero: a removal of more than is left fails with
+// ErrInsufficientStock.
+func (r *ProductRepository) AdjustStock(movement *models.StockMovement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "name", "stock_quantity").First(&product, movement.ProductID).Error; err != nil {
+			return err
+		}
+		movement.ResultingQuantity = product.StockQuantity + movement.Delta
+		if movement.ResultingQuantity < 0 {
+			return fmt.Errorf("%w: %s has %d left", ErrInsufficientStock, product.Name, product.StockQuantity)
+		}
+		if err := tx.Model(&product).Update("stock_quantity", movement.ResultingQuantity).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(movement).Error; err != nil {
+			return err
+		}
+		if err := recordEvent(tx, events.ProductChanged{ProductID: product.ID}); err != nil {
+			return err
+		}
+		return recordEvent(tx, events.StockChanged{ProductID: product.ID, ProductName: product.Name, Previous: product.StockQuantity, Quantity: movement.ResultingQuantity})
+	})
+}
+
+// ListStockMovements retrieves a paginated stock ledger for a product, newest first
+func (r *ProductRepository) ListStockMovements(productID uint, page, limit int) ([]models.StockMovement, int64, error) {
+	var movements []models.StockMovement
+	var total int64
+
+	query := r.db.Model(&models.StockMovement{}).Where("product_id = ?", productID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&movements).Error
+	return movements, total, err
+}
+
+// recordRevision stores a snapshot of the product's current state as its next revision
+func recordRevision(tx *gorm.DB, productID, editorID uint) error {
+	var product models.Product
+	if err := tx.Preload("Categories").First(&product, productID).Error; err != nil {
+		return err
+	}
+
+	snapshotJSON, err := json.Marshal(SnapshotProduct(&product))
+	if err != nil {
+		return err
+	}
+
+	var lastRevision int
+	if err := tx.Model(&models.ProductRevision{}).
+		Where("product_id = ?", productID).
+		Select("COALESCE(MAX(revision), 0)").
+		Row().
+		Scan(&lastRevision); err != nil {
+		return err
+	}
+
+	return tx.Create(&models.ProductRevision{
+		ProductID: productID,
+		Revision:  lastRevision + 1,
+		Snapshot:  string(snapshotJSON),
+		EditedBy:  editorID,
+	}).Error
+}
+
+// SnapshotProduct returns the editable state of a product loaded with its categories
+func SnapshotProduct(product *models.Product) dto.ProductSnapshot {
+	snapshot := dto.ProductSnapshot{
+		Name:             product.Name,
+		Description:      product.Description,
+		SKU:              product.SKUValue(),
+		Location:         product.Location,
+		Bookable:         product.Bookable,
+		Price:            product.Price,
+		StockQuantity:    product.StockQuantity,
+		Status:           string(product.Status),
+		Categories:       make([]uint, len(product.Categories)),
+		AllowedCountries: product.AllowedCountries,
+		BlockedCountries: product.BlockedCountries,
+		Metadata:         product.Metadata,
+	}
+	for i, category := range product.Categories {
+		snapshot.Categories[i] = category.ID
+	}
+	return snapshot
+}
+
+// ListRevisions retrieves a paginated list of a product's revisions, newest first
+func (r *ProductRepository) ListRevisions(productID uint, page, limit int) ([]models.ProductRevision, int64, error) {
+	var revisions []models.ProductRevision
+	var total int64
+
+	query := r.db.Model(&models.ProductRevision{}).Where("product_id = ?", productID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("revision DESC").Offset(offset).Limit(limit).Find(&revisions).Error
+	return revisions, total, err
+}
+
+// ListChangedSince retrieves the products created, updated, deleted or revised
+// after a time, including deleted ones, with their categories
+func (r *ProductRepository) ListChangedSince(since time.Time) ([]models.Product, error) {
+	var products []models.Product
+	revised := r.db.Model(&models.ProductRevision{}).Select("product_id").Where("created_at > ?", since)
+	err := r.db.Unscoped().Preload("Categories").
+		Where("created_at > ? OR updated_at > ? OR deleted_at > ? OR id IN (?)", since, since, since, revised).
+		Order("id").Find(&products).Error
+	return products, err
 }
 
-// Delete deletes a product
+// RevisionsAsOf retrieves the latest revision of each product recorded at or
+// before a time, keyed by product ID
+func (r *ProductRepository) RevisionsAsOf(productIDs []uint, at time.Time) (map[uint]models.ProductRevision, error) {
+	revisions := make(map[uint]models.ProductRevision, len(productIDs))
+	if len(productIDs) == 0 {
+		return revisions, nil
+	}
+	var rows []models.ProductRevision
+	err := r.db.Raw(`SELECT DISTINCT ON (product_id) * FROM product_revisions
+		WHERE product_id IN ? AND created_at <= ? AND deleted_at IS NULL
+		ORDER BY product_id, revision DESC`, productIDs, at).Scan(&rows).Error
+	for _, revision := range rows {
+		revisions[revision.ProductID] = revision
+	}
+	return revisions, err
+}
+
+// GetRevision retrieves a single revision of a product
+func (r *ProductRepository) GetRevision(productID uint, revision int) (*models.ProductRevision, error) {
+	var productRevision models.ProductRevision
+	err := r.db.Where("product_id = ? AND revision = ?", productID, revision).First(&productRevision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &productRevision, nil
+}
+
+// Delete deletes a product and adds a ProductChanged event to the outbox
 func (r *ProductRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Product{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.Product{}, id).Error; err != nil {
+			return err
+		}
+		return recordEvent(tx, events.ProductChanged{ProductID: id, Deleted: true})
+	})
 }
 
-// List retrieves a paginated list of products with filters
-func (r *ProductRepository) List(page, limit int, categoryID uint, search string, sort string, statuses []string) ([]models.Product, int64, error) {
+// ListForDuplicateScan retrieves the ID, name, SKU, price and status of every
+// product, for comparing them with each other
+func (r *ProductRepository) ListForDuplicateScan() ([]models.Product, error) {
+	var products []models.Product
+	err := r.db.Select("id", "name", "sku", "price", "status").Order("id").Find(&products).Error
+	return products, err
+}
+
+// MergeResult counts what a merge moved onto the surviving product
+type MergeResult struct {
+	ReviewsMoved     int64 // Reviews of the duplicate moved to the surviving product
+	ReviewsDropped   int64 // Reviews of the duplicate by users who had reviewed the surviving product too
+	WishlistsMoved   int64
+	WishlistsDropped int64 // Wishlist entries of users who had both products in their wishlist
+	CategoriesAdded  int64
+}
+
+// Merge consolidates a duplicate product onto the surviving one and deletes
+// the duplicate. Its reviews and wishlist entries move to the surviving
+// product, except those of users who already reviewed or wished for it, which
+// are deleted, and the surviving product joins its categories. Both products
+// are locked, so concurrent merges of either wait for each other.
+func (r *ProductRepository) Merge(productID, duplicateID, editorID uint) (*MergeResult, error) {
+	result := &MergeResult{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var locked []models.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+			Where("id IN ?", []uint{productID, duplicateID}).Order("id").Find(&locked).Error; err != nil {
+			return err
+		}
+		if len(locked) != 2 {
+			return gorm.ErrRecordNotFound
+		}
+
+		reviewed := tx.Model(&models.Review{}).Select("user_id").Where("product_id = ?", productID)
+		dropped := tx.Where("product_id = ? AND user_id IN (?)", duplicateID, reviewed).Delete(&models.Review{})
+		if dropped.Error != nil {
+			return dropped.Error
+		}
+		moved := tx.Model(&models.Review{}).Where("product_id = ?", duplicateID).Update("product_id", productID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.ReviewsDropped, result.ReviewsMoved = dropped.RowsAffected, moved.RowsAffected
+
+		var wishers []uint
+		if err := tx.Model(&models.Wishlist{}).Where("product_id = ?", duplicateID).Distinct().Pluck("user_id", &wishers).Error; err != nil {
+			return err
+		}
+		wished := tx.Model(&models.Wishlist{}).Select("user_id").Where("product_id = ?", productID)
+		dropped = tx.Where("product_id = ? AND user_id IN (?)", duplicateID, wished).Delete(&models.Wishlist{})
+		if dropped.Error != nil {
+			return dropped.Error
+		}
+		moved = tx.Model(&models.Wishlist{}).Where("product_id = ?", duplicateID).Update("product_id", productID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.WishlistsDropped, result.WishlistsMoved = dropped.RowsAffected, moved.RowsAffected
+		// Synced clients drop the duplicate and pick up the surviving product
+		for _, userID := range wishers {
+			if err := recordSyncChange(tx, models.SyncEntityWishlist, duplicateID, &userID, models.SyncDelete); err != nil {
+				return err
+			}
+			if err := recordSyncChange(tx, models.SyncEntityWishlist, productID, &userID, models.SyncUpsert); err != nil {
+				return err
+			}
+		}
+
+		added := tx.Exec(`INSERT INTO product_categories (product_id, category_id, created_at, updated_at)
+			SELECT ?, category_id, NOW(), NOW() FROM product_categories WHERE product_id = ?
+			ON CONFLICT DO NOTHING`, productID, duplicateID)
+		if added.Error != nil {
+			return added.Error
+		}
+		result.CategoriesAdded = added.RowsAffected
+		if added.RowsAffected > 0 {
+			categoryIDs, err := linkedCategoryIDs(tx, productID)
+			if err != nil {
+				return err
+			}
+			if _, err := refreshProductCounts(tx, categoryIDs); err != nil {
+				return err
+			}
+		}
+
+		if err := refreshProductRating(tx, productID); err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Product{}, duplicateID).Error; err != nil {
+			return err
+		}
+		if err := recordEvent(tx, events.ProductChanged{ProductID: duplicateID, Deleted: true}); err != nil {
+			return err
+		}
+		if err := recordEvent(tx, events.ProductChanged{ProductID: productID}); err != nil {
+			return err
+		}
+		return recordRevision(tx, productID, editorID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// List retrieves a paginated list of products with filters. A non-nil country
+// limits it to the products available there, as decided by Product.AvailableIn.
+// metadata keeps the products with one of the listed values for every key.
+func (r *ProductRepository) List(page, limit int, categoryID uint, search string, sort string, statuses []string, country *string, metadata map[string][]string) ([]models.Product, int64, error) {
 	var products []models.Product
 	var total int64
 
@@ -89,12 +562,25 @@ func (r *ProductRepository) List(page, limit int, categoryID uint, search string
 		query = query.Where("status IN ?", statuses)
 	}
 
+	// Hide products blocked in the country or limited to other markets. The
+	// lists may be JSON null, which contains nothing.
+	if country != nil {
+		query = query.Where("NOT COALESCE(products.blocked_countries, 'null') @> jsonb_build_array(CAST(? AS text))", *country).
+			Where("(COALESCE(products.allowed_countries, 'null') IN ('null', '[]') OR products.allowed_countries @> jsonb_build_array(CAST(? AS text)))", *country)
+	}
+
 	// Apply category filter if provided
 	if categoryID > 0 {
 		query = query.Joins("JOIN product_categories ON products.id = product_categories.product_id").
 			Where("product_categories.category_id = ?", categoryID)
 	}
 
+	// Apply metadata filters, which the GIN index on metadata serves
+	for key, values := range metadata {
+		conditions, args := metadataContainment(key, values)
+		query = query.Where(conditions, args...)
+	}
+
 	// Apply search filter if provided
 	if search != "" {
 		search = "%" + strings.ToLower(search) + "%"
@@ -107,6 +593,8 @@ func (r *ProductRepository) List(page, limit int, categoryID uint, search string
 		query = query.Order("name")
 	case "price":
 		query = query.Order("price")
+	case "rating":
+		query = query.Order("rating_average desc").Order("rating_count desc")
 	case "created_at":
 		query = query.Order("created_at desc")
 	default:
@@ -120,25 +608,79 @@ func (r *ProductRepository) List(page, limit int, categoryID uint, search string
 
 	// Apply pagination
 	offset := (page - 1) * limit
-	err := query.Preload("Categories").Preload("Reviews").
+	err := query.Preload("Categories").Preload("Images", orderImages).
 		Offset(offset).Limit(limit).Find(&products).Error
 
 	return products, total, err
 }
 
-// AddToWishlist adds a product to a user's wishlist
+// metadataContainment returns the condition matching products whose metadata
+// has one of values under key. Query parameters are strings, so a value that
+// reads as a number or boolean also matches it stored as one.
+func metadataContainment(key string, values []string) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(value interface{}) {
+		document, _ := json.Marshal(map[string]interface{}{key: value})
+		conditions = append(conditions, "products.metadata @> CAST(? AS jsonb)")
+		args = append(args, string(document))
+	}
+	for _, value := range values {
+		add(value)
+		if number, err := strconv.ParseFloat(value, 64); err == nil && !math.IsInf(number, 0) && !math.IsNaN(number) {
+			add(number)
+		}
+		if value == "true" || value == "false" {
+			add(value == "true")
+		}
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// UpdateMetadata replaces a product's metadata, records a new revision and
+// adds a ProductChanged event to the outbox
+func (r *ProductRepository) UpdateMetadata(productID uint, metadata map[string]interface{}, editorID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Product{BaseModel: models.BaseModel{ID: productID}}).
+			Select("metadata").Updates(&models.Product{Metadata: metadata})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := recordEvent(tx, events.ProductChanged{ProductID: productID}); err != nil {
+			return err
+		}
+		return recordRevision(tx, productID, editorID)
+	})
+}
+
+// AddToWishlist adds a product to a user's wishlist and appends the change to
+// the user's sync log
 func (r *ProductRepository) AddToWishlist(userID, productID uint) error {
 	wishlist := &models.Wishlist{
 		UserID:    userID,
 		ProductID: productID,
 	}
-	return r.db.Create(wishlist).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(wishlist).Error; err != nil {
+			return err
+		}
+		return recordSyncChange(tx, models.SyncEntityWishlist, productID, &userID, models.SyncUpsert)
+	})
 }
 
-// RemoveFromWishlist removes a product from a user's wishlist
+// RemoveFromWishlist removes a product from a user's wishlist and appends the
+// change to the user's sync log
 func (r *ProductRepository) RemoveFromWishlist(userID, productID uint) error {
-	return r.db.Where("user_id = ? AND product_id = ?", userID, productID).
-		Delete(&models.Wishlist{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND product_id = ?", userID, productID).
+			Delete(&models.Wishlist{}).Error; err != nil {
+			return err
+		}
+		return recordSyncChange(tx, models.SyncEntityWishlist, productID, &userID, models.SyncDelete)
+	})
 }
 
 // GetWishlist retrieves a user's wishlist
@@ -151,9 +693,9 @@ func (r *ProductRepository) GetWishlist(userID uint, page, limit int) ([]models.
 		return nil, 0, err
 	}
 
-	// Apply pagination and preload product with its categories
+	// Apply pagination and preload product with its categories and images
 	offset := (page - 1) * limit
-	err := r.db.Preload("Product.Categories").
+	err := r.db.Preload("Product.Categories").Preload("Product.Images", orderImages).
 		Where("user_id = ?", userID).
 		Offset(offset).Limit(limit).
 		Find(&wishlist).Error
@@ -161,6 +703,31 @@ func (r *ProductRepository) GetWishlist(userID uint, page, limit int) ([]models.
 	return wishlist, total, err
 }
 
+// GetWishlistItems retrieves the items of a user's wishlist holding the given
+// products, with each product, its categories and images
+func (r *ProductRepository) GetWishlistItems(userID uint, productIDs []uint) ([]models.Wishlist, error) {
+	var wishlist []models.Wishlist
+	if len(productIDs) == 0 {
+		return wishlist, nil
+	}
+	err := r.db.Preload("Product.Categories").Preload("Product.Images", orderImages).
+		Where("user_id = ? AND product_id IN ?", userID, productIDs).
+		Find(&wishlist).Error
+	return wishlist, err
+}
+
+// ExistsInWishlist checks whether a product is in a user's wishlist
+func (r *ProductRepository) ExistsInWishlist(userID, productID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Wishlist{}).
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 // CountTotalWishlistItems counts the total number of wishlist items
 func (r *ProductRepository) CountTotalWishlistItems() (int64, error) {
 	var count int64
@@ -178,8 +745,3 @@ func (r *ProductRepository) CountUserWishlistItems(userID uint) (int64, error) {
 	}
 	return count, nil
 }
-
-// DB returns the database instance
-func (r *ProductRepository) DB() *gorm.DB {
-	return r.db
-}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"testing"
+
+	"product-management/internal/testutil"
+
+	"gorm.io/gorm"
+)
+
+// BenchmarkProductRepositoryList measures product listings over the seeded
+// datasets: the default page, a deep page, a category filter, a search and
+// the rating sort
+func BenchmarkProductRepositoryList(b *testing.B) {
+	cases := []struct {
+		name       string
+		page       int
+		categoryID uint
+		search     string
+		sort       string
+		statuses   []string
+	}{
+		{name: "default", page: 1},
+		{name: "deep_page", page: 250},
+		{name: "category", page: 1, categoryID: 3, sort: "price", statuses: []string{"active"}},
+		{name: "search", page: 1, search: "number 42", sort: "name"},
+		{name: "rating", page: 1, sort: "rating", statuses: []string{"active"}},
+	}
+
+	testutil.RunOnDatasets(b, func(b *testing.B, db *gorm.DB) {
+		products := NewProductRepository(db)
+		for _, bc := range cases {
+			b.Run(bc.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, _, err := products.List(bc.page, 20, bc.categoryID, bc.search, bc.sort, bc.statuses, nil, nil); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	})
+}
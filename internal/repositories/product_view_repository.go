@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// viewerKey identifies who viewed a product: the user, otherwise the anonymous
+// ID, otherwise no one, whose views count toward no viewer
+const viewerKey = "COALESCE('u' || %[1]s.user_id, 'a' || NULLIF(%[1]s.anonymous_id, ''))"
+
+// ProductViewCount is the number of views and distinct viewers of a product
+type ProductViewCount struct {
+	ProductID uint
+	Views     int64
+	Viewers   int64
+}
+
+// ProductConversion is the views of a product over a period with the wishlist
+// adds and accepted quotes that followed in the same period
+type ProductConversion struct {
+	ProductID    uint
+	ProductName  string
+	Views        int64
+	Viewers      int64
+	WishlistAdds int64
+	Orders       int64
+	Units        int64
+}
+
+// ProductViewRepository handles database operations for product views
+type ProductViewRepository struct {
+	db *gorm.DB
+}
+
+// NewProductViewRepository creates a new ProductViewRepository instance
+func NewProductViewRepository(db *gorm.DB) *ProductViewRepository {
+	return &ProductViewRepository{db: db}
+}
+
+// Create records a product view
+func (r *ProductViewRepository) Create(view *models.ProductView) error {
+	return r.db.Create(view).Error
+}
+
+// MostViewed returns up to limit products with the most views since the given
+// time, most viewed first
+func (r *ProductViewRepository) MostViewed(since time.Time, limit int) ([]ProductViewCount, error) {
+	var counts []ProductViewCount
+	err := r.db.Raw(fmt.Sprintf(`
+		SELECT v.product_id, COUNT(*) AS views, COUNT(DISTINCT %s) AS viewers
+		FROM product_views v
+		WHERE v.viewed_at >= ?
+		GROUP BY v.product_id
+		ORDER BY views DESC, v.product_id
+		LIMIT ?`, fmt.Sprintf(viewerKey, "v")), since, limit).
+		Scan(&counts).Error
+	return counts, err
+}
+
+// AlsoViewed returns up to limit other products viewed since the given time by
+// the viewers of a product since then, those with the most such viewers first
+func (r *ProductViewRepository) AlsoViewed(productID uint, since time.Time, limit int) ([]ProductViewCount, error) {
+	var counts []ProductViewCount
+	err := r.db.Raw(fmt.Sprintf(`
+		SELECT o.product_id, COUNT(*) AS views, COUNT(DISTINCT %[2]s) AS viewers
+		FROM product_views o
+		WHERE o.viewed_at >= @since AND o.product_id <> @product AND %[2]s IN (
+			SELECT %[1]s FROM product_views v
+			WHERE v.product_id = @product AND v.viewed_at >= @since AND %[1]s IS NOT NULL
+		)
+		GROUP BY o.product_id
+		ORDER BY viewers DESC, views DESC, o.product_id
+		LIMIT @limit`, fmt.Sprintf(viewerKey, "v"), fmt.Sprintf(viewerKey, "o")),
+		map[string]interface{}{
+			"product": productID,
+			"since":   since,
+			"limit":   limit,
+		}).
+		Scan(&counts).Error
+	return counts, err
+}
+
+// Conversion returns up to limit products viewed in [from, to), most viewed
+// first, with their wishlist adds and the quotes accepted for them in the same
+// range. Wishlist entries that were removed since still count as adds.
+func (r *ProductViewRepository) Conversion(from, to time.Time, limit int) ([]ProductConversion, error) {
+	var conversions []ProductConversion
+	err := r.db.Raw(fmt.Sprintf(`
+		WITH views AS (
+			SELECT v.product_id, COUNT(*) AS views, COUNT(DISTINCT %s) AS viewers
+			FROM product_views v
+			WHERE v.viewed_at >= @from AND v.viewed_at < @to
+			GROUP BY v.product_id
+		), wishlist_adds AS (
+			SELECT product_id, COUNT(*) AS wishlist_adds
+			FROM wishlists
+			WHERE added_at >= @from AND added_at < @to
+			GROUP BY product_id
+		), orders AS (
+			SELECT i.product_id, COUNT(DISTINCT q.id) AS orders, SUM(i.quantity) AS units
+			FROM quotes q
+			JOIN quote_items i ON i.quote_id = q.id AND i.deleted_at IS NULL
+			WHERE q.deleted_at IS NULL AND q.status = @accepted AND q.decided_at >= @from AND q.decided_at < @to
+			GROUP BY i.product_id
+		)
+		SELECT views.product_id, p.name AS product_name, views.views, views.viewers,
+			COALESCE(wishlist_adds.wishlist_adds, 0) AS wishlist_adds,
+			COALESCE(orders.orders, 0) AS orders, COALESCE(orders.units, 0) AS units
+		FROM views
+		JOIN products p ON p.id = views.product_id
+		LEFT JOIN wishlist_adds ON wishlist_adds.product_id = views.product_id
+		LEFT JOIN orders ON orders.product_id = views.product_id
+		ORDER BY views.views DESC, views.product_id
+		LIMIT @limit`, fmt.Sprintf(viewerKey, "v")),
+		map[string]interface{}{
+			"from":     from,
+			"to":       to,
+			"accepted": models.QuoteAccepted,
+			"limit":    limit,
+		}).
+		Scan(&conversions).Error
+	return conversions, err
+}
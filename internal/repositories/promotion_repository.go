@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"time"
+
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PromotionRepository handles database operations for promotions
+type PromotionRepository struct {
+	db *gorm.DB
+}
+
+// NewPromotionRepository creates a new PromotionRepository instance
+func NewPromotionRepository(db *gorm.DB) *PromotionRepository {
+	return &PromotionRepository{db: db}
+}
+
+// promotionScheduleLock names the transaction-level advisory lock that
+// serializes promotion writes. Conflicts can involve any promotion, so there
+// is no row to lock instead.
+const promotionScheduleLock = "promotion_schedule"
+
+// Create adds a promotion. check is given the conflicting promotions found
+// while holding the schedule lock, so two overlapping promotions saved at the
+// same time can't both pass the check.
+func (r *PromotionRepository) Create(promotion *models.Promotion, check func(conflicts []uint) error) error {
+	return r.withScheduleLock(promotion, check, func(tx *gorm.DB) error {
+		return tx.Create(promotion).Error
+	})
+}
+
+// GetByID retrieves a promotion by ID
+func (r *PromotionRepository) GetByID(id uint) (*models.Promotion, error) {
+	var promotion models.Promotion
+	if err := r.db.First(&promotion, id).Error; err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// List retrieves a paginated list of promotions, latest start first. An empty
+// status lists them all.
+func (r *PromotionRepository) List(status models.PromotionStatus, page, limit int) ([]models.Promotion, int64, error) {
+	var promotions []models.Promotion
+	var total int64
+
+	query := r.db.Model(&models.Promotion{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("starts_at DESC, id DESC").Offset(offset).Limit(limit).Find(&promotions).Error
+	return promotions, total, err
+}
+
+// ListActive retrieves the promotions the scheduler started and has not ended
+func (r *PromotionRepository) ListActive() ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	err := r.db.Where("status = ?", models.PromotionActive).Order("id").Find(&promotions).Error
+	return promotions, err
+}
+
+// Update saves a promotion's scope, discount, schedule and status, checking
+// its conflicts under the schedule lock like Create
+func (r *PromotionRepository) Update(promotion *models.Promotion, check func(conflicts []uint) error) error {
+	return r.withScheduleLock(promotion, check, func(tx *gorm.DB) error {
+		return tx.Model(promotion).Select("name", "product_id", "category_id", "discount", "value",
+			"starts_at", "ends_at", "status", "updated_by").Updates(promotion).Error
+	})
+}
+
+// withScheduleLock runs check on the promotion's conflicts and then save in
+// one transaction holding the schedule lock until it commits
+func (r *PromotionRepository) withScheduleLock(promotion *models.Promotion, check func(conflicts []uint) error, save func(tx *gorm.DB) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", promotionScheduleLock).Error; err != nil {
+			return err
+		}
+		conflicts, err := (&PromotionRepository{db: tx}).Conflicts(promotion)
+		if err != nil {
+			return err
+		}
+		if err := check(conflicts); err != nil {
+			return err
+		}
+		return save(tx)
+	})
+}
+
+// Delete soft deletes a promotion
+func (r *PromotionRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Promotion{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Conflicts returns the IDs of the promotions other than the given one whose
+// schedule overlaps it and that cover one of its products: the same product,
+// the same category, or a product in the other's category
+func (r *PromotionRepository) Conflicts(promotion *models.Promotion) ([]uint, error) {
+	query := r.db.Model(&models.Promotion{}).
+		Where("id <> ? AND starts_at < ? AND ends_at > ?", promotion.ID, promotion.EndsAt, promotion.StartsAt)
+	if promotion.ProductID != nil {
+		query = query.Where("product_id = ? OR category_id IN (SELECT category_id FROM product_categories WHERE product_id = ?)",
+			*promotion.ProductID, *promotion.ProductID)
+	} else {
+		query = query.Where("category_id = ? OR product_id IN (SELECT product_id FROM product_categories WHERE category_id = ?)",
+			*promotion.CategoryID, *promotion.CategoryID)
+	}
+
+	var ids []uint
+	err := query.Order("id").Pluck("id", &ids).Error
+	return ids, err
+}
+
+// Transition moves every promotion whose status differs from what its
+// schedule gives at a time to that status, and returns the moved promotions
+func (r *PromotionRepository) Transition(at time.Time) ([]models.Promotion, error) {
+	var moved []models.Promotion
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("(status <> ? AND starts_at <= ? AND ends_at > ?) OR (status <> ? AND ends_at <= ?) OR (status <> ? AND starts_at > ?)",
+				models.PromotionActive, at, at, models.PromotionEnded, at, models.PromotionScheduled, at).
+			Order("id").Find(&moved).Error
+		if err != nil {
+			return err
+		}
+		for i := range moved {
+			moved[i].Status = moved[i].StatusAt(at)
+			if err := tx.Model(&moved[i]).Update("status", moved[i].Status).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return moved, nil
+}
@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"product-management/internal/models"
+	"product-management/pkg/events"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrPurchaseOrderClosed = errors.New("purchase order is already closed")
+	ErrNotOnPurchaseOrder  = errors.New("product is not on the purchase order")
+	ErrOverReceipt         = errors.New("cannot receive more than was ordered")
+)
+
+// StockChange describes how a product's stock moved in a committed
+// transaction, so callers can invalidate what they cached of it
+type StockChange struct {
+	ProductID   uint
+	ProductName string
+	Previous    int
+	Quantity    int
+}
+
+// PurchaseOrderRepository handles database operations for purchase orders
+type PurchaseOrderRepository struct {
+	db *gorm.DB
+}
+
+// NewPurchaseOrderRepository creates a new PurchaseOrderRepository instance
+func NewPurchaseOrderRepository(db *gorm.DB) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{db: db}
+}
+
+// Create creates a new purchase order with its items
+func (r *PurchaseOrderRepository) Create(purchaseOrder *models.PurchaseOrder) error {
+	return r.db.Create(purchaseOrder).Error
+}
+
+// GetByID retrieves a purchase order with its supplier, items and their products
+func (r *PurchaseOrderRepository) GetByID(id uint) (*models.PurchaseOrder, error) {
+	var purchaseOrder models.PurchaseOrder
+	if err := r.preload(r.db).First(&purchaseOrder, id).Error; err != nil {
+		return nil, err
+	}
+	return &purchaseOrder, nil
+}
+
+// List retrieves a paginated list of purchase orders, newest first, optionally
+// filtered by status and supplier
+func (r *PurchaseOrderRepository) List(status models.PurchaseOrderStatus, supplierID uint, page, limit int) ([]models.PurchaseOrder, int64, error) {
+	var purchaseOrders []models.PurchaseOrder
+	var total int64
+
+	query := r.db.Model(&models.PurchaseOrder{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if supplierID != 0 {
+		query = query.Where("supplier_id = ?", supplierID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := r.preload(query).Order("created_at DESC").Offset(offset).Limit(limit).Find(&purchaseOrders).Error
+	return purchaseOrders, total, err
+}
+
+// Receive records a delivery against an open purchase order. Each received
+// quantity is added to the product's stock with a stock movement referencing
+// the purchase order and ProductChanged and StockChanged events in the
+// outbox, and the order becomes partial or received.
+func (r *PurchaseOrderRepository) Receive(id uint, quantities map[uint]int, actorID uint, note string) ([]StockChange, error) {
+	var changes []StockChange
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var purchaseOrder models.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&purchaseOrder, id).Error; err != nil {
+			return err
+		}
+		if purchaseOrder.Status != models.PurchaseOrderOpen && purchaseOrder.Status != models.PurchaseOrderPartial {
+			return ErrPurchaseOrderClosed
+		}
+		if err := tx.Where("purchase_order_id = ?", id).Order("id").Find(&purchaseOrder.Items).Error; err != nil {
+			return err
+		}
+
+		ordered := make(map[uint]bool, len(purchaseOrder.Items))
+		for _, item := range purchaseOrder.Items {
+			ordered[item.ProductID] = true
+		}
+		for productID := range quantities {
+			if !ordered[productID] {
+				return fmt.Errorf("%w: %d", ErrNotOnPurchaseOrder, productID)
+			}
+		}
+
+		complete := true
+		for _, item := range purchaseOrder.Items {
+			quantity := quantities[item.ProductID]
+			if item.QuantityReceived+quantity > item.QuantityOrdered {
+				return fmt.Errorf("%w: product %d has %d outstanding", ErrOverReceipt, item.ProductID, item.QuantityOrdered-item.QuantityReceived)
+			}
+			if item.QuantityReceived+quantity < item.QuantityOrdered {
+				complete = false
+			}
+			if quantity == 0 {
+				continue
+			}
+
+			// Lock the product so the movement is computed against a stable quantity
+			var product models.Product
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("id", "name", "stock_quantity").
+				First(&product, item.ProductID).Error; err != nil {
+				return err
+			}
+			resulting := product.StockQuantity + quantity
+			if err := tx.Model(&product).Update("stock_quantity", resulting).Error; err != nil {
+				return err
+			}
+			if err := recordStockMovement(tx, product.ID, models.StockSourcePurchaseOrder, purchaseOrder.Reference(), quantity, resulting, actorID, note); err != nil {
+				return err
+			}
+			if err := tx.Model(&item).Update("quantity_received", item.QuantityReceived+quantity).Error; err != nil {
+				return err
+			}
+			if err := recordEvent(tx, events.ProductChanged{ProductID: product.ID}); err != nil {
+				return err
+			}
+			if err := recordEvent(tx, events.StockChanged{ProductID: product.ID, ProductName: product.Name, Previous: product.StockQuantity, Quantity: resulting}); err != nil {
+				return err
+			}
+			changes = append(changes, StockChange{ProductID: product.ID, ProductName: product.Name, Previous: product.StockQuantity, Quantity: resulting})
+		}
+
+		updates := map[string]interface{}{"status": models.PurchaseOrderPartial}
+		if complete {
+			updates = map[string]interface{}{"status": models.PurchaseOrderReceived, "closed_at": time.Now()}
+		}
+		return tx.Model(&purchaseOrder).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// Cancel closes an open or partially received purchase order without waiting
+// for the rest of the delivery
+func (r *PurchaseOrderRepository) Cancel(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var purchaseOrder models.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&purchaseOrder, id).Error; err != nil {
+			return err
+		}
+		if purchaseOrder.Status != models.PurchaseOrderOpen && purchaseOrder.Status != models.PurchaseOrderPartial {
+			return ErrPurchaseOrderClosed
+		}
+		return tx.Model(&purchaseOrder).Updates(map[string]interface{}{
+			"status":    models.PurchaseOrderCancelled,
+			"closed_at": time.Now(),
+		}).Error
+	})
+}
+
+// preload loads the supplier and items of purchase orders, including deleted
+// suppliers and products
+func (r *PurchaseOrderRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.Preload("Supplier", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Preload("Items.Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	})
+}
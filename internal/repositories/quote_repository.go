@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"product-management/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// QuoteRepository handles database operations for quotes
+type QuoteRepository struct {
+	db *gorm.DB
+}
+
+// NewQuoteRepository creates a new QuoteRepository instance
+func NewQuoteRepository(db *gorm.DB) *QuoteRepository {
+	return &QuoteRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *QuoteRepository) WithTx(tx *gorm.DB) *QuoteRepository {
+	return &QuoteRepository{db: tx}
+}
+
+// Create creates a new quote with its items
+func (r *QuoteRepository) Create(quote *models.Quote) error {
+	return r.db.Create(quote).Error
+}
+
+// GetByID retrieves a quote with its items and their products
+func (r *QuoteRepository) GetByID(id uint) (*models.Quote, error) {
+	var quote models.Quote
+	if err := r.preloadItems(r.db).First(&quote, id).Error; err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+// List retrieves a paginated list of quotes, newest first, optionally limited
+// to a user's quotes and filtered by status
+func (r *QuoteRepository) List(userID uint, status models.QuoteStatus, page, limit int) ([]models.Quote, int64, error) {
+	var quotes []models.Quote
+	var total int64
+
+	query := r.db.Model(&models.Quote{})
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := r.preloadItems(query).Order("created_at DESC").Offset(offset).Limit(limit).Find(&quotes).Error
+	return quotes, total, err
+}
+
+// ListOpenOrders retrieves up to limit quotes placed as orders that still have
+// units to ship, oldest first, optionally only those with the given IDs. An
+// order is open once its placement saga completed, or when it has none for
+// being accepted before orders were placed by a saga.
+func (r *QuoteRepository) ListOpenOrders(ids []uint, limit int) ([]models.Quote, error) {
+	var quotes []models.Quote
+	query := r.db.Model(&models.Quote{}).
+		Where("quotes.status = ?", models.QuoteAccepted).
+		// Sagas reference the quote they place as Quote.Reference does
+		Where(`NOT EXISTS (SELECT 1 FROM sagas s
+			WHERE s.type = ? AND s.reference = 'Q-' || quotes.id AND s.status <> ? AND s.deleted_at IS NULL)`,
+			models.SagaOrderPlacement, models.SagaCompleted).
+		Where(`EXISTS (SELECT 1 FROM quote_items qi
+			WHERE qi.quote_id = quotes.id AND qi.deleted_at IS NULL AND qi.quantity > COALESCE((
+				SELECT SUM(si.quantity) FROM shipment_items si
+				JOIN shipments sh ON sh.id = si.shipment_id AND sh.deleted_at IS NULL
+				WHERE si.quote_item_id = qi.id AND si.deleted_at IS NULL
+			), 0))`)
+	if len(ids) > 0 {
+		query = query.Where("quotes.id IN ?", ids)
+	}
+	err := r.preloadItems(query).Order("decided_at, id").Limit(limit).Find(&quotes).Error
+	return quotes, err
+}
+
+// Transition locks a quote and lets apply check and change its state. The
+// status, response and decision fields set by apply are saved in the same
+// transaction, along with anything apply writes through tx.
+func (r *QuoteRepository) Transition(id uint, apply func(tx *gorm.DB, quote *models.Quote) error) (*models.Quote, error) {
+	var quote models.Quote
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&quote, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("quote_id = ?", quote.ID).Order("id").Find(&quote.Items).Error; err != nil {
+			return err
+		}
+		if err := apply(tx, &quote); err != nil {
+			return err
+		}
+		return tx.Model(&quote).Updates(map[string]interface{}{
+			"status":        quote.Status,
+			"response_note": quote.ResponseNote,
+			"valid_until":   quote.ValidUntil,
+			"responded_by":  quote.RespondedBy,
+			"responded_at":  quote.RespondedAt,
+			"decided_at":    quote.DecidedAt,
+			"updated_at":    time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(id)
+}
+
+// SetQuotedPrice records the unit price offered for an item of a quote
+func (r *QuoteRepository) SetQuotedPrice(itemID uint, price float64) error {
+	return r.db.Model(&models.QuoteItem{}).Where("id = ?", itemID).Update("quoted_price", price).Error
+}
+
+// preloadItems loads the items of quotes with their products, including deleted ones
+func (r *QuoteRepository) preloadItems(db *gorm.DB) *gorm.DB {
+	return db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Preload("Items.Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	})
+}
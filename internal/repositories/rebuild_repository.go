@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/pkg/events"
+
+	"gorm.io/gorm"
+)
+
+// RebuildRepository handles the runs of background rebuilds and the staging
+// tables they build into
+type RebuildRepository struct {
+	db *gorm.DB
+}
+
+// NewRebuildRepository creates a new RebuildRepository instance
+func NewRebuildRepository(db *gorm.DB) *RebuildRepository {
+	return &RebuildRepository{db: db}
+}
+
+// CreateRun stores a rebuild run as it starts
+func (r *RebuildRepository) CreateRun(run *models.RebuildRun) error {
+	return r.db.Create(run).Error
+}
+
+// UpdateProgress saves the counters of a running rebuild
+func (r *RebuildRepository) UpdateProgress(run *models.RebuildRun) error {
+	return r.db.Model(run).Updates(map[string]interface{}{
+		"total":     run.Total,
+		"processed": run.Processed,
+		"changed":   run.Changed,
+	}).Error
+}
+
+// FinishRun saves the outcome of a rebuild run
+func (r *RebuildRepository) FinishRun(run *models.RebuildRun) error {
+	return r.db.Save(run).Error
+}
+
+// FailRunning marks the runs of a target still running when they started
+// before a time as failed, since the instance running them stopped midway,
+// and drops the staging tables they left behind
+func (r *RebuildRepository) FailRunning(target models.RebuildTarget, before time.Time) error {
+	var runIDs []uint
+	if err := r.db.Model(&models.RebuildRun{}).
+		Where("target = ? AND status = ? AND started_at < ?", target, models.RebuildRunning, before).
+		Pluck("id", &runIDs).Error; err != nil {
+		return err
+	}
+	if len(runIDs) == 0 {
+		return nil
+	}
+	for _, id := range runIDs {
+		if err := r.DropRatingStaging(id); err != nil {
+			return err
+		}
+	}
+	return r.db.Model(&models.RebuildRun{}).
+		Where("id IN ?", runIDs).
+		Updates(map[string]interface{}{
+			"status":     models.RebuildFailed,
+			"error":      "interrupted",
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// GetRun retrieves a rebuild run by ID
+func (r *RebuildRepository) GetRun(id uint) (*models.RebuildRun, error) {
+	var run models.RebuildRun
+	if err := r.db.First(&run, id).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// ListRuns retrieves a paginated list of rebuild runs, newest first
+func (r *RebuildRepository) ListRuns(page, limit int) ([]models.RebuildRun, int64, error) {
+	var runs []models.RebuildRun
+	var total int64
+
+	query := r.db.Model(&models.RebuildRun{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("started_at DESC, id DESC").Offset(offset).Limit(limit).Find(&runs).Error
+	return runs, total, err
+}
+
+// ratingStagingTable names the table a run stages rating summaries in
+func ratingStagingTable(runID uint) string {
+	return fmt.Sprintf("rebuild_ratings_%d", runID)
+}
+
+// CountLiveProducts returns the number of products that aren't deleted
+func (r *RebuildRepository) CountLiveProducts() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Product{}).Count(&count).Error
+	return count, err
+}
+
+// CreateRatingStaging creates the empty table a run stages rating summaries
+// in. It is unlogged, since a crashed run is started over rather than resumed.
+func (r *RebuildRepository) CreateRatingStaging(runID uint) error {
+	return r.db.Exec(`CREATE UNLOGGED TABLE IF NOT EXISTS ` + ratingStagingTable(runID) + ` (
+		product_id bigint PRIMARY KEY,
+		rating_average decimal NOT NULL,
+		rating_count bigint NOT NULL
+	)`).Error
+}
+
+// StageRatings computes the rating summaries of up to limit live products
+// with an ID above afterID into the staging table of a run, without touching
+// the products. It returns the last product ID staged, or 0 when none were left.
+func (r *RebuildRepository) StageRatings(runID, afterID uint, limit int) (uint, int, error) {
+	var productIDs []uint
+	if err := r.db.Model(&models.Product{}).
+		Where("id > ?", afterID).
+		Order("id").
+		Limit(limit).
+		Pluck("id", &productIDs).Error; err != nil {
+		return 0, 0, err
+	}
+	if len(productIDs) == 0 {
+		return 0, 0, nil
+	}
+	if err := stageRatings(r.db, ratingStagingTable(runID), productIDs); err != nil {
+		return 0, 0, err
+	}
+	return productIDs[len(productIDs)-1], len(productIDs), nil
+}
+
+// stageRatings writes the rating summaries of products into a staging table,
+// replacing those already staged. Summaries are computed like
+// refreshProductRating does.
+func stageRatings(tx *gorm.DB, table string, productIDs []uint) error {
+	return tx.Exec(`INSERT INTO `+table+` (product_id, rating_average, rating_count)
+		SELECT p.id, COALESCE(AVG(r.rating), 0), COUNT(r.id)
+		FROM products p
+		LEFT JOIN reviews r ON r.product_id = p.id AND r.deleted_at IS NULL
+		WHERE p.id IN ?
+		GROUP BY p.id
+		ON CONFLICT (product_id) DO UPDATE
+		SET rating_average = EXCLUDED.rating_average, rating_count = EXCLUDED.rating_count`, productIDs).Error
+}
+
+// SwapRatings replaces the rating summaries of products with those staged by
+// a run and drops the staging table, in one transaction. Review writes wait
+// for it, and products whose reviews changed since the run started are staged
+// again first, so no write made during the run is overwritten. Only products
+// whose summary differs are updated, and their IDs are returned.
+func (r *RebuildRepository) SwapRatings(runID uint, since time.Time) ([]uint, error) {
+	table := ratingStagingTable(runID)
+	var changed []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE reviews IN SHARE MODE").Error; err != nil {
+			return err
+		}
+
+		var touched []uint
+		if err := tx.Raw(`SELECT DISTINCT r.product_id FROM reviews r
+			JOIN `+table+` s ON s.product_id = r.product_id
+			WHERE r.updated_at >= ? OR r.deleted_at >= ?`, since, since).
+			Scan(&touched).Error; err != nil {
+			return err
+		}
+		if len(touched) > 0 {
+			if err := stageRatings(tx, table, touched); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Raw(`UPDATE products p
+			SET rating_average = s.rating_average, rating_count = s.rating_count, updated_at = ?
+			FROM `+table+` s
+			WHERE p.id = s.product_id AND p.deleted_at IS NULL
+				AND (p.rating_average IS DISTINCT FROM s.rating_average OR p.rating_count IS DISTINCT FROM s.rating_count)
+			RETURNING p.id`, time.Now()).
+			Scan(&changed).Error; err != nil {
+			return err
+		}
+		for _, productID := range changed {
+			if err := recordEvent(tx, events.ProductChanged{ProductID: productID}); err != nil {
+				return err
+			}
+		}
+		return tx.Exec("DROP TABLE " + table).Error
+	})
+	return changed, err
+}
+
+// DropRatingStaging drops the staging table of a run that failed
+func (r *RebuildRepository) DropRatingStaging(runID uint) error {
+	return r.db.Exec("DROP TABLE IF EXISTS " + ratingStagingTable(runID)).Error
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"time"
+
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RefreshTokenRepository handles database operations for refresh tokens and
+// revoked access tokens
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository creates a new RefreshTokenRepository instance
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+// Create records an issued refresh token
+func (r *RefreshTokenRepository) Create(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetByTokenID retrieves a refresh token by its jti claim
+func (r *RefreshTokenRepository) GetByTokenID(tokenID string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := r.db.Where("token_id = ?", tokenID).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed marks a refresh token as exchanged and reports whether this call
+// did, so two concurrent exchanges of the same token cannot both succeed
+func (r *RefreshTokenRepository) MarkUsed(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.RefreshToken{}).Where("id = ? AND used_at IS NULL", id).Update("used_at", at)
+	return result.RowsAffected == 1, result.Error
+}
+
+// DeleteByUser revokes every refresh token of a user
+func (r *RefreshTokenRepository) DeleteByUser(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.RefreshToken{}).Error
+}
+
+// DeleteByTokenID revokes a refresh token of a user by its jti claim
+func (r *RefreshTokenRepository) DeleteByTokenID(userID uint, tokenID string) error {
+	return r.db.Where("user_id = ? AND token_id = ?", userID, tokenID).Delete(&models.RefreshToken{}).Error
+}
+
+// Revoke blacklists an access token until it expires. Revoking it again is a
+// no-op.
+func (r *RefreshTokenRepository) Revoke(token *models.RevokedToken) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+// IsRevoked reports whether an access token was blacklisted
+func (r *RefreshTokenRepository) IsRevoked(tokenID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.RevokedToken{}).Where("token_id = ?", tokenID).Count(&count).Error
+	return count > 0, err
+}
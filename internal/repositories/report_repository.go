@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReportRepository runs the SQL of reporting queries
+type ReportRepository struct {
+	db *gorm.DB
+}
+
+// NewReportRepository creates a new ReportRepository instance
+func NewReportRepository(db *gorm.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// Query runs a query with named arguments in a read-only transaction that is
+// cancelled after timeout. It returns the column names and at most limit rows,
+// and whether more rows were left out.
+func (r *ReportRepository) Query(query string, args map[string]interface{}, limit int, timeout time.Duration) ([]string, [][]interface{}, bool, error) {
+	var columns []string
+	var rows [][]interface{}
+	truncated := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Even if a query were to write, Postgres refuses it in a read-only transaction
+		if err := tx.Exec("SET TRANSACTION READ ONLY").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(fmt.Sprintf("SET LOCAL statement_timeout = %d", timeout.Milliseconds())).Error; err != nil {
+			return err
+		}
+
+		// An empty map would still be sent to Postgres as an argument
+		statement := tx.Raw(query)
+		if len(args) > 0 {
+			statement = tx.Raw(query, args)
+		}
+		result, err := statement.Rows()
+		if err != nil {
+			return err
+		}
+		defer result.Close()
+
+		if columns, err = result.Columns(); err != nil {
+			return err
+		}
+		for result.Next() {
+			if len(rows) == limit {
+				truncated = true
+				break
+			}
+			values := make([]interface{}, len(columns))
+			pointers := make([]interface{}, len(columns))
+			for i := range values {
+				pointers[i] = &values[i]
+			}
+			if err := result.Scan(pointers...); err != nil {
+				return err
+			}
+			for i, value := range values {
+				// Numeric and text values may come back as raw bytes
+				if raw, ok := value.([]byte); ok {
+					values[i] = string(raw)
+				}
+			}
+			rows = append(rows, values)
+		}
+		return result.Err()
+	})
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return columns, rows, truncated, nil
+}
@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"product-management/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// SalesDay is the number of accepted quotes, units and revenue of a UTC day
+type SalesDay struct {
+	Day            time.Time
+	AcceptedQuotes int64
+	Units          int64
+	Revenue        float64
+}
+
+// CategorySales is the units and revenue of the products of a category
+type CategorySales struct {
+	CategoryID uint
+	Name       string
+	Units      int64
+	Revenue    float64
+}
+
+// ReportingRepository reads the aggregates of the reporting schema. In live
+// mode it computes them from the tables instead, with the queries the
+// materialized views are built from, so both modes give the same answers.
+type ReportingRepository struct {
+	db           *gorm.DB
+	materialized bool
+}
+
+// NewReportingRepository creates a new ReportingRepository instance reading
+// the materialized views, or the live tables
+func NewReportingRepository(db *gorm.DB, materialized bool) *ReportingRepository {
+	return &ReportingRepository{db: db, materialized: materialized}
+}
+
+// source returns what the rows of a reporting view are selected from
+func (r *ReportingRepository) source(name string) string {
+	for _, view := range database.ReportingViews {
+		if view.Name != name {
+			continue
+		}
+		if r.materialized {
+			return database.ReportingSchema + "." + name
+		}
+		return "(" + view.Query + ") AS " + name
+	}
+	panic("unknown reporting view " + name)
+}
+
+// SalesByDay returns the sales of every UTC day in [from, to] with any, oldest first
+func (r *ReportingRepository) SalesByDay(from, to time.Time) ([]SalesDay, error) {
+	var days []SalesDay
+	err := r.db.Raw(fmt.Sprintf(`
+		SELECT CAST(day AS timestamp) AT TIME ZONE 'UTC' AS day, accepted_quotes, units, revenue
+		FROM %s
+		WHERE day >= CAST(@from AS date) AND day <= CAST(@to AS date)
+		ORDER BY day`, r.source("sales_by_day")),
+		map[string]interface{}{
+			"from": from.Format(time.DateOnly),
+			"to":   to.Format(time.DateOnly),
+		}).
+		Scan(&days).Error
+	return days, err
+}
+
+// CategoryRevenue returns the units and revenue of every category with sales
+// in the UTC days [from, to], highest revenue first. A product in several
+// categories counts toward each.
+func (r *ReportingRepository) CategoryRevenue(from, to time.Time) ([]CategorySales, error) {
+	var categories []CategorySales
+	err := r.db.Raw(fmt.Sprintf(`
+		SELECT category_revenue.category_id, categories.name, SUM(category_revenue.units) AS units,
+			CAST(ROUND(CAST(SUM(category_revenue.revenue) AS numeric), 2) AS double precision) AS revenue
+		FROM %s
+		JOIN categories ON categories.id = category_revenue.category_id AND categories.deleted_at IS NULL
+		WHERE category_revenue.day >= CAST(@from AS date) AND category_revenue.day <= CAST(@to AS date)
+		GROUP BY category_revenue.category_id, categories.name
+		ORDER BY revenue DESC, categories.name`, r.source("category_revenue")),
+		map[string]interface{}{
+			"from": from.Format(time.DateOnly),
+			"to":   to.Format(time.DateOnly),
+		}).
+		Scan(&categories).Error
+	return categories, err
+}
+
+// ReviewVolume groups the reviews of the UTC days since the given time into
+// periods ("day", "week" or "month") starting at UTC midnight, with their
+// count and average rating
+func (r *ReportingRepository) ReviewVolume(since time.Time, interval string) ([]ReviewVolume, error) {
+	var volume []ReviewVolume
+	err := r.db.Raw(fmt.Sprintf(`
+		SELECT date_trunc(@interval, CAST(day AS timestamp)) AT TIME ZONE 'UTC' AS period,
+			SUM(review_count) AS review_count, CAST(SUM(rating_sum) AS double precision) / SUM(review_count) AS average_rating
+		FROM %s
+		WHERE day >= CAST(@since AS date)
+		GROUP BY 1
+		ORDER BY 1`, r.source("rating_trends")),
+		map[string]interface{}{
+			"interval": interval,
+			"since":    since.UTC().Format(time.DateOnly),
+		}).
+		Scan(&volume).Error
+	return volume, err
+}
+
+// RatingDrops returns the products whose average rating fell the most between
+// the UTC days from previousStart to before currentStart and those since.
+// Products need at least minReviews reviews in each window to qualify.
+func (r *ReportingRepository) RatingDrops(previousStart, currentStart time.Time, minReviews, limit int) ([]RatingDrop, error) {
+	var drops []RatingDrop
+	err := r.db.Raw(fmt.Sprintf(`
+		SELECT product_id, product_name, previous_average, current_average, previous_count, current_count
+		FROM (
+			SELECT rating_trends.product_id, products.name AS product_name,
+				CAST(SUM(rating_trends.rating_sum) FILTER (WHERE rating_trends.day < CAST(@current AS date)) AS double precision) /
+					NULLIF(SUM(rating_trends.review_count) FILTER (WHERE rating_trends.day < CAST(@current AS date)), 0) AS previous_average,
+				CAST(SUM(rating_trends.rating_sum) FILTER (WHERE rating_trends.day >= CAST(@current AS date)) AS double precision) /
+					NULLIF(SUM(rating_trends.review_count) FILTER (WHERE rating_trends.day >= CAST(@current AS date)), 0) AS current_average,
+				COALESCE(SUM(rating_trends.review_count) FILTER (WHERE rating_trends.day < CAST(@current AS date)), 0) AS previous_count,
+				COALESCE(SUM(rating_trends.review_count) FILTER (WHERE rating_trends.day >= CAST(@current AS date)), 0) AS current_count
+			FROM %s
+			JOIN products ON products.id = rating_trends.product_id AND products.deleted_at IS NULL
+			WHERE rating_trends.day >= CAST(@previous AS date)
+			GROUP BY rating_trends.product_id, products.name
+		) AS windows
+		WHERE previous_count >= @min_reviews AND current_count >= @min_reviews
+			AND current_average < previous_average
+		ORDER BY previous_average - current_average DESC
+		LIMIT @limit`, r.source("rating_trends")),
+		map[string]interface{}{
+			"previous":    previousStart.UTC().Format(time.DateOnly),
+			"current":     currentStart.UTC().Format(time.DateOnly),
+			"min_reviews": minReviews,
+			"limit":       limit,
+		}).
+		Scan(&drops).Error
+	return drops, err
+}
+
+// Refresh recomputes a materialized view of the reporting schema. Readers keep
+// seeing the previous rows until it commits.
+func (r *ReportingRepository) Refresh(name string) error {
+	return r.db.Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY " + database.ReportingSchema + "." + name).Error
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"time"
+
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// RequestStatRepository handles database operations for aggregated request statistics
+type RequestStatRepository struct {
+	db *gorm.DB
+}
+
+// NewRequestStatRepository creates a new request statistics repository
+func NewRequestStatRepository(db *gorm.DB) *RequestStatRepository {
+	return &RequestStatRepository{db: db}
+}
+
+// CreateBatch stores aggregated request statistics
+func (r *RequestStatRepository) CreateBatch(stats []models.RequestStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+	return r.db.CreateInBatches(stats, 500).Error
+}
+
+// Each calls fn for every row of the buckets starting in [from, to), optionally
+// limited to a method and route, loading batchSize rows at a time
+func (r *RequestStatRepository) Each(from, to time.Time, method, route string, batchSize int, fn func(stat models.RequestStat)) error {
+	query := r.db.Model(&models.RequestStat{}).Where("bucket_start >= ? AND bucket_start < ?", from, to)
+	if method != "" {
+		query = query.Where("method = ?", method)
+	}
+	if route != "" {
+		query = query.Where("route = ?", route)
+	}
+
+	var stats []models.RequestStat
+	return query.Order("id").FindInBatches(&stats, batchSize, func(tx *gorm.DB, batch int) error {
+		for _, stat := range stats {
+			fn(stat)
+		}
+		return nil
+	}).Error
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RetentionRepository deletes records past their retention period and keeps
+// the statistics of each run
+type RetentionRepository struct {
+	db *gorm.DB
+}
+
+// NewRetentionRepository creates a new retention repository
+func NewRetentionRepository(db *gorm.DB) *RetentionRepository {
+	return &RetentionRepository{db: db}
+}
+
+// DeleteOlderThan permanently deletes the rows of a table whose timestamp
+// column is before cutoff, batchSize rows per statement so locks stay short,
+// and returns how many were deleted. The table and column must come from code,
+// never from user input, since they are interpolated.
+func (r *RetentionRepository) DeleteOlderThan(table, column string, cutoff time.Time, batchSize int) (int64, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id IN (SELECT id FROM %s WHERE %s < ? LIMIT ?)", table, table, column)
+	var deleted int64
+	for {
+		result := r.db.Exec(query, cutoff, batchSize)
+		if result.Error != nil {
+			return deleted, result.Error
+		}
+		deleted += result.RowsAffected
+		if result.RowsAffected < int64(batchSize) {
+			return deleted, nil
+		}
+	}
+}
+
+// RecordRun stores the outcome of the last run for an entity, adding its
+// deletions to the running total
+func (r *RetentionRepository) RecordRun(run *models.RetentionRun) error {
+	run.TotalDeleted = run.Deleted
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "entity"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"ran_at":        run.RanAt,
+			"deleted":       run.Deleted,
+			"total_deleted": gorm.Expr("retention_runs.total_deleted + ?", run.Deleted),
+			"duration_ms":   run.DurationMs,
+			"error":         run.Error,
+			"updated_at":    time.Now(),
+		}),
+	}).Create(run).Error
+}
+
+// ListRuns returns the last run of every entity
+func (r *RetentionRepository) ListRuns() ([]models.RetentionRun, error) {
+	var runs []models.RetentionRun
+	err := r.db.Order("entity").Find(&runs).Error
+	return runs, err
+}
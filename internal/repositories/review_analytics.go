@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"time"
+)
+
+// ReviewVolume is the number and average rating of reviews created in a period
+type ReviewVolume struct {
+	Period        time.Time
+	ReviewCount   int64
+	AverageRating float64
+}
+
+// RatingDrop compares a product's average rating between two consecutive windows
+type RatingDrop struct {
+	ProductID       uint
+	ProductName     string
+	PreviousAverage float64
+	CurrentAverage  float64
+	PreviousCount   int64
+	CurrentCount    int64
+}
+
+// GetReviewVolume groups reviews created since the given time into periods
+// ("day", "week" or "month") with their count and average rating. Periods start
+// at midnight in the given location.
+func (r *ReviewRepository) GetReviewVolume(since time.Time, interval string, loc *time.Location) ([]ReviewVolume, error) {
+	var volume []ReviewVolume
+	err := r.db.Raw(`
+		SELECT date_trunc(@interval, created_at AT TIME ZONE @tz) AT TIME ZONE @tz AS period,
+			COUNT(*) AS review_count, AVG(rating) AS average_rating
+		FROM reviews
+		WHERE deleted_at IS NULL AND created_at >= @since
+		GROUP BY 1
+		ORDER BY 1`,
+		map[string]interface{}{
+			"interval": interval,
+			"tz":       loc.String(),
+			"since":    since,
+		}).
+		Scan(&volume).Error
+	return volume, err
+}
+
+// GetRatingDrops returns the products whose average rating fell the most between
+// [previousStart, currentStart) and [currentStart, now). Products need at least
+// minReviews reviews in each window to qualify.
+func (r *ReviewRepository) GetRatingDrops(previousStart, currentStart time.Time, minReviews, limit int) ([]RatingDrop, error) {
+	var drops []RatingDrop
+	err := r.db.Raw(`
+		SELECT product_id, product_name, previous_average, current_average, previous_count, current_count
+		FROM (
+			SELECT reviews.product_id, products.name AS product_name,
+				AVG(reviews.rating) FILTER (WHERE reviews.created_at < @current) AS previous_average,
+				AVG(reviews.rating) FILTER (WHERE reviews.created_at >= @current) AS current_average,
+				COUNT(*) FILTER (WHERE reviews.created_at < @current) AS previous_count,
+				COUNT(*) FILTER (WHERE reviews.created_at >= @current) AS current_count
+			FROM reviews
+			JOIN products ON products.id = reviews.product_id AND products.deleted_at IS NULL
+			WHERE reviews.deleted_at IS NULL AND reviews.created_at >= @previous
+			GROUP BY reviews.product_id, products.name
+		) AS windows
+		WHERE previous_count >= @min_reviews AND current_count >= @min_reviews
+			AND current_average < previous_average
+		ORDER BY previous_average - current_average DESC
+		LIMIT @limit`,
+		map[string]interface{}{
+			"previous":    previousStart,
+			"current":     currentStart,
+			"min_reviews": minReviews,
+			"limit":       limit,
+		}).
+		Scan(&drops).Error
+	return drops, err
+}
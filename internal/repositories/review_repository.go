@@ -16,9 +16,14 @@ func NewReviewRepository(db *gorm.DB) *ReviewRepository {
 	return &ReviewRepository{db: db}
 }
 
-// Create creates a new review
+// Create creates a new review and refreshes the product rating summary
 func (r *ReviewRepository) Create(review *models.Review) error {
-	return r.db.Create(review).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(review).Error; err != nil {
+			return err
+		}
+		return refreshProductRating(tx, review.ProductID)
+	})
 }
 
 // GetByID retrieves a review by its ID
@@ -48,6 +53,56 @@ func (r *ReviewRepository) GetByUserID(userID uint) ([]models.Review, error) {
 	return reviews, err
 }
 
+// ListByUserID retrieves a paginated list of a user's reviews.
+// Reviews for non-active products are only included when includeHidden is true.
+func (r *ReviewRepository) ListByUserID(userID uint, page, pageSize int, includeHidden bool) ([]models.Review, int64, error) {
+	var reviews []models.Review
+	var total int64
+
+	query := r.db.Model(&models.Review{}).
+		Joins("JOIN products ON products.id = reviews.product_id AND products.deleted_at IS NULL").
+		Where("reviews.user_id = ?", userID)
+
+	if !includeHidden {
+		query = query.Where("products.status = ?", models.StatusActive)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Preload("Product").
+		Order("reviews.created_at DESC").
+		Offset(offset).Limit(pageSize).
+		Find(&reviews).Error
+
+	return reviews, total, err
+}
+
+// RatingCount is the number of reviews with a given rating
+type RatingCount struct {
+	Rating int
+	Count  int64
+}
+
+// GetUserRatingCounts returns how many reviews a user gave per rating in one grouped query
+func (r *ReviewRepository) GetUserRatingCounts(userID uint, includeHidden bool) ([]RatingCount, error) {
+	var counts []RatingCount
+
+	query := r.db.Model(&models.Review{}).
+		Select("reviews.rating AS rating, COUNT(*) AS count").
+		Joins("JOIN products ON products.id = reviews.product_id AND products.deleted_at IS NULL").
+		Where("reviews.user_id = ?", userID)
+
+	if !includeHidden {
+		query = query.Where("products.status = ?", models.StatusActive)
+	}
+
+	err := query.Group("reviews.rating").Order("reviews.rating").Scan(&counts).Error
+	return counts, err
+}
+
 // GetByUserAndProduct retrieves a review by user ID and product ID
 func (r *ReviewRepository) GetByUserAndProduct(userID, productID uint) (*models.Review, error) {
 	var review models.Review
@@ -58,36 +113,103 @@ func (r *ReviewRepository) GetByUserAndProduct(userID, productID uint) (*models.
 	return &review, nil
 }
 
-// Update updates a review
+// Update updates a review and refreshes the product rating summary
 func (r *ReviewRepository) Update(review *models.Review) error {
-	return r.db.Save(review).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(review).Error; err != nil {
+			return err
+		}
+		return refreshProductRating(tx, review.ProductID)
+	})
 }
 
-// Delete deletes a review
+// Delete deletes a review and refreshes the product rating summary
 func (r *ReviewRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Review{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var review models.Review
+		if err := tx.First(&review, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&review).Error; err != nil {
+			return err
+		}
+		return refreshProductRating(tx, review.ProductID)
+	})
+}
+
+// refreshProductRating recomputes the cached rating columns on a product and
+// appends the change to the sync log. The product is locked first, so a
+// concurrent review write waits for this transaction and then aggregates with
+// its review included, rather than missing it and caching a stale summary.
+func refreshProductRating(tx *gorm.DB, productID uint) error {
+	// FOR NO KEY UPDATE doesn't wait for the key share locks review inserts take
+	var locked []uint
+	if err := tx.Raw("SELECT id FROM products WHERE id = ? FOR NO KEY UPDATE", productID).Scan(&locked).Error; err != nil {
+		return err
+	}
+
+	var summary struct {
+		Average float64
+		Count   int
+	}
+	err := tx.Model(&models.Review{}).
+		Select("COALESCE(AVG(rating), 0) AS average, COUNT(*) AS count").
+		Where("product_id = ?", productID).
+		Scan(&summary).Error
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Model(&models.Product{}).
+		Where("id = ?", productID).
+		Updates(map[string]interface{}{
+			"rating_average": summary.Average,
+			"rating_count":   summary.Count,
+		}).Error; err != nil {
+		return err
+	}
+	return recordSyncChange(tx, models.SyncEntityProduct, productID, nil, models.SyncUpsert)
 }
 
-// GetAverageRating calculates the average rating for a product
+// GetAverageRating returns the cached average rating for a product
 func (r *ReviewRepository) GetAverageRating(productID uint) (float64, error) {
 	var avg float64
-	err := r.db.Model(&models.Review{}).
-		Where("product_id = ?", productID).
-		Select("AVG(rating)").
+	err := r.db.Model(&models.Product{}).
+		Where("id = ?", productID).
+		Select("rating_average").
 		Row().
 		Scan(&avg)
 	return avg, err
 }
 
-// GetReviewCount returns the number of reviews for a product
+// GetReviewCount returns the cached number of reviews for a product
 func (r *ReviewRepository) GetReviewCount(productID uint) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.Review{}).
-		Where("product_id = ?", productID).
-		Count(&count).Error
+	err := r.db.Model(&models.Product{}).
+		Where("id = ?", productID).
+		Select("rating_count").
+		Row().
+		Scan(&count)
 	return count, err
 }
 
+// RecalculateAllRatings rebuilds the cached rating summary for every product
+func (r *ReviewRepository) RecalculateAllRatings() error {
+	var productIDs []uint
+	if err := r.db.Model(&models.Product{}).Pluck("id", &productIDs).Error; err != nil {
+		return err
+	}
+
+	for _, productID := range productIDs {
+		if err := r.db.Transaction(func(tx *gorm.DB) error {
+			return refreshProductRating(tx, productID)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Search retrieves reviews with pagination, filtering, and sorting
 func (r *ReviewRepository) Search(page, pageSize int, productName, sortBy, order string) ([]models.Review, int64, error) {
 	var reviews []models.Review
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"testing"
+
+	"product-management/internal/testutil"
+
+	"gorm.io/gorm"
+)
+
+// BenchmarkReviewRepositorySearch measures review searches over the seeded
+// datasets, newest first and by product name
+func BenchmarkReviewRepositorySearch(b *testing.B) {
+	cases := []struct {
+		name        string
+		productName string
+		sortBy      string
+	}{
+		{name: "default", sortBy: "created_at"},
+		{name: "product_name", productName: "Product 42", sortBy: "rating"},
+	}
+
+	testutil.RunOnDatasets(b, func(b *testing.B, db *gorm.DB) {
+		reviews := NewReviewRepository(db)
+		for _, bc := range cases {
+			b.Run(bc.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, _, err := reviews.Search(1, 20, bc.productName, bc.sortBy, "desc"); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package repositories
+
+import (
+	"time"
+
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// SagaRepository handles the stored state of sagas and their steps
+type SagaRepository struct {
+	db *gorm.DB
+}
+
+// NewSagaRepository creates a new saga repository
+func NewSagaRepository(db *gorm.DB) *SagaRepository {
+	return &SagaRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction, so a saga can be
+// started atomically with the change that calls for it
+func (r *SagaRepository) WithTx(tx *gorm.DB) *SagaRepository {
+	return &SagaRepository{db: tx}
+}
+
+// Create stores a new saga with its steps
+func (r *SagaRepository) Create(saga *models.Saga) error {
+	return r.db.Create(saga).Error
+}
+
+// GetByID retrieves a saga with its steps
+func (r *SagaRepository) GetByID(id uint) (*models.Saga, error) {
+	var saga models.Saga
+	if err := r.preloadSteps(r.db).First(&saga, id).Error; err != nil {
+		return nil, err
+	}
+	return &saga, nil
+}
+
+// GetByReference retrieves the latest saga of a type acting on a reference
+func (r *SagaRepository) GetByReference(sagaType models.SagaType, reference string) (*models.Saga, error) {
+	var saga models.Saga
+	if err := r.db.Where("type = ? AND reference = ?", sagaType, reference).Order("id DESC").First(&saga).Error; err != nil {
+		return nil, err
+	}
+	return &saga, nil
+}
+
+// ListDue retrieves up to limit running or compensating sagas whose next
+// attempt is due, oldest first, with their steps
+func (r *SagaRepository) ListDue(limit int) ([]models.Saga, error) {
+	var sagas []models.Saga
+	err := r.preloadSteps(r.db).
+		Where("status IN ? AND next_attempt_at <= ?", []models.SagaStatus{models.SagaRunning, models.SagaCompensating}, time.Now()).
+		Order("id").Limit(limit).Find(&sagas).Error
+	return sagas, err
+}
+
+// List retrieves a paginated list of sagas with their steps, newest first,
+// optionally filtered by status, type and reference
+func (r *SagaRepository) List(status models.SagaStatus, sagaType models.SagaType, reference string, page, limit int) ([]models.Saga, int64, error) {
+	var sagas []models.Saga
+	var total int64
+
+	query := r.db.Model(&models.Saga{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if sagaType != "" {
+		query = query.Where("type = ?", sagaType)
+	}
+	if reference != "" {
+		query = query.Where("reference = ?", reference)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := r.preloadSteps(query).Order("id DESC").Offset(offset).Limit(limit).Find(&sagas).Error
+	return sagas, total, err
+}
+
+// CompleteStep runs apply and records the step as completed in the same
+// transaction, with the saga's data as apply left it, so the step takes effect
+// exactly once
+func (r *SagaRepository) CompleteStep(saga *models.Saga, step *models.SagaStep, apply func(tx *gorm.DB) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := apply(tx); err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := tx.Model(step).Updates(map[string]interface{}{
+			"status":       models.SagaStepCompleted,
+			"error":        "",
+			"completed_at": now,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(saga).Updates(map[string]interface{}{
+			"data":            saga.Data,
+			"attempts":        0,
+			"next_attempt_at": now,
+		}).Error
+	})
+}
+
+// CompensateStep runs apply and records the step as compensated in the same
+// transaction, so the step is undone exactly once
+func (r *SagaRepository) CompensateStep(saga *models.Saga, step *models.SagaStep, apply func(tx *gorm.DB) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := apply(tx); err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := tx.Model(step).Updates(map[string]interface{}{
+			"status":         models.SagaStepCompensated,
+			"compensated_at": now,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(saga).Updates(map[string]interface{}{
+			"data":            saga.Data,
+			"attempts":        0,
+			"next_attempt_at": now,
+		}).Error
+	})
+}
+
+// RecordAttemptFailed records a failed attempt at a step or its compensation,
+// to be attempted again at nextAttempt
+func (r *SagaRepository) RecordAttemptFailed(saga *models.Saga, step *models.SagaStep, attemptErr error, nextAttempt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(step).Update("error", attemptErr.Error()).Error; err != nil {
+			return err
+		}
+		return tx.Model(saga).Updates(map[string]interface{}{
+			"attempts":        saga.Attempts + 1,
+			"next_attempt_at": nextAttempt,
+		}).Error
+	})
+}
+
+// StartCompensating records that a step failed for good and that the saga now
+// undoes its completed steps, running apply in the same transaction
+func (r *SagaRepository) StartCompensating(saga *models.Saga, step *models.SagaStep, stepErr error, apply func(tx *gorm.DB) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(step).Updates(map[string]interface{}{
+			"status": models.SagaStepFailed,
+			"error":  stepErr.Error(),
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(saga).Updates(map[string]interface{}{
+			"status":          models.SagaCompensating,
+			"attempts":        0,
+			"error":           step.Name + ": " + stepErr.Error(),
+			"next_attempt_at": time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+		return apply(tx)
+	})
+}
+
+// Finish records that a saga stopped with a status, and why when it failed,
+// running apply in the same transaction
+func (r *SagaRepository) Finish(saga *models.Saga, status models.SagaStatus, reason string, apply func(tx *gorm.DB) error) error {
+	updates := map[string]interface{}{
+		"status":          status,
+		"next_attempt_at": nil,
+		"finished_at":     time.Now(),
+	}
+	if reason != "" {
+		updates["error"] = reason
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(saga).Updates(updates).Error; err != nil {
+			return err
+		}
+		return apply(tx)
+	})
+}
+
+// Retry resumes compensating a failed saga with a fresh set of attempts. It
+// returns gorm.ErrRecordNotFound when no failed saga has the ID.
+func (r *SagaRepository) Retry(id uint) error {
+	result := r.db.Model(&models.Saga{}).
+		Where("id = ? AND status = ?", id, models.SagaFailed).
+		Updates(map[string]interface{}{
+			"status":          models.SagaCompensating,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+			"finished_at":     nil,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// preloadSteps loads the steps of sagas in order
+func (r *SagaRepository) preloadSteps(db *gorm.DB) *gorm.DB {
+	return db.Preload("Steps", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	})
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"time"
+
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SecurityRepository handles database operations for security alerts and IP blocks
+type SecurityRepository struct {
+	db *gorm.DB
+}
+
+// NewSecurityRepository creates a new security repository
+func NewSecurityRepository(db *gorm.DB) *SecurityRepository {
+	return &SecurityRepository{db: db}
+}
+
+// CreateAlert stores a security alert
+func (r *SecurityRepository) CreateAlert(alert *models.SecurityAlert) error {
+	return r.db.Create(alert).Error
+}
+
+// ListAlerts retrieves a paginated list of security alerts, newest first,
+// optionally of one kind
+func (r *SecurityRepository) ListAlerts(kind string, page, limit int) ([]models.SecurityAlert, int64, error) {
+	var alerts []models.SecurityAlert
+	var total int64
+
+	query := r.db.Model(&models.SecurityAlert{})
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&alerts).Error
+	return alerts, total, err
+}
+
+// Block blocks an IP until expiresAt, replacing the expiry and reason of an
+// existing block
+func (r *SecurityRepository) Block(ip, reason string, expiresAt time.Time) error {
+	block := &models.IPBlock{IP: ip, Reason: reason, ExpiresAt: expiresAt}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "ip"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"reason":     reason,
+			"expires_at": expiresAt,
+			"updated_at": time.Now(),
+		}),
+	}).Create(block).Error
+}
+
+// IsBlocked reports whether an IP has a block that has not expired
+func (r *SecurityRepository) IsBlocked(ip string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.IPBlock{}).Where("ip = ? AND expires_at > ?", ip, time.Now()).Count(&count).Error
+	return count > 0, err
+}
+
+// ListBlocks returns the blocks that have not expired, soonest to expire first
+func (r *SecurityRepository) ListBlocks() ([]models.IPBlock, error) {
+	var blocks []models.IPBlock
+	err := r.db.Where("expires_at > ?", time.Now()).Order("expires_at").Find(&blocks).Error
+	return blocks, err
+}
+
+// Unblock removes the block of an IP and reports whether there was one
+func (r *SecurityRepository) Unblock(ip string) (bool, error) {
+	result := r.db.Unscoped().Where("ip = ?", ip).Delete(&models.IPBlock{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// segmentFieldSQL maps every segment field to the SQL expression computing it
+// for a row of the users table
+var segmentFieldSQL = map[models.SegmentField]string{
+	models.SegmentFieldRole:               "users.role",
+	models.SegmentFieldWishlistSize:       "(SELECT COUNT(*) FROM wishlists WHERE wishlists.user_id = users.id AND wishlists.deleted_at IS NULL)",
+	models.SegmentFieldReviewCount:        "(SELECT COUNT(*) FROM reviews WHERE reviews.user_id = users.id AND reviews.deleted_at IS NULL)",
+	models.SegmentFieldDaysSinceSignup:    "EXTRACT(EPOCH FROM NOW() - users.created_at) / 86400",
+	models.SegmentFieldDaysSinceLastLogin: "EXTRACT(EPOCH FROM NOW() - GREATEST(users.last_login, users.created_at)) / 86400",
+}
+
+// segmentOperatorSQL maps every segment operator to its SQL comparison
+var segmentOperatorSQL = map[models.SegmentOperator]string{
+	models.SegmentOperatorEq:  "=",
+	models.SegmentOperatorNe:  "<>",
+	models.SegmentOperatorGt:  ">",
+	models.SegmentOperatorGte: ">=",
+	models.SegmentOperatorLt:  "<",
+	models.SegmentOperatorLte: "<=",
+}
+
+// SegmentRepository handles database operations for customer segments
+type SegmentRepository struct {
+	db *gorm.DB
+}
+
+// NewSegmentRepository creates a new SegmentRepository instance
+func NewSegmentRepository(db *gorm.DB) *SegmentRepository {
+	return &SegmentRepository{db: db}
+}
+
+// Create creates a new segment
+func (r *SegmentRepository) Create(segment *models.Segment) error {
+	return r.db.Create(segment).Error
+}
+
+// GetByID retrieves a segment by ID
+func (r *SegmentRepository) GetByID(id uint) (*models.Segment, error) {
+	var segment models.Segment
+	if err := r.db.First(&segment, id).Error; err != nil {
+		return nil, err
+	}
+	return &segment, nil
+}
+
+// List retrieves every segment ordered by name
+func (r *SegmentRepository) List() ([]models.Segment, error) {
+	var segments []models.Segment
+	err := r.db.Order("name").Find(&segments).Error
+	return segments, err
+}
+
+// NameExists reports whether another segment than excludeID already has a name
+func (r *SegmentRepository) NameExists(name string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Segment{}).Where("LOWER(name) = LOWER(?) AND id <> ?", name, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// Update saves a segment's name, description and rules
+func (r *SegmentRepository) Update(segment *models.Segment) error {
+	return r.db.Model(segment).Select("name", "description", "rules").Updates(segment).Error
+}
+
+// Delete deletes a segment
+func (r *SegmentRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Segment{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListMembers retrieves a paginated list of the users matching every rule,
+// ordered by ID. Rules must have been validated, as unknown fields and
+// operators are skipped.
+func (r *SegmentRepository) ListMembers(rules []models.SegmentRule, page, limit int) ([]models.User, int64, error) {
+	var users []models.User
+	var total int64
+
+	query := r.db.Model(&models.User{})
+	for _, rule := range rules {
+		field, ok := segmentFieldSQL[rule.Field]
+		operator, known := segmentOperatorSQL[rule.Operator]
+		if !ok || !known {
+			continue
+		}
+		// Only the value is user input; it is bound as a parameter
+		placeholder := "?"
+		if rule.Field.Numeric() {
+			placeholder = "?::numeric"
+		}
+		query = query.Where(field+" "+operator+" "+placeholder, rule.Value)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("users.id").Offset(offset).Limit(limit).Find(&users).Error
+	return users, total, err
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SettingRepository handles database operations for store settings
+type SettingRepository struct {
+	db *gorm.DB
+}
+
+// NewSettingRepository creates a new SettingRepository instance
+func NewSettingRepository(db *gorm.DB) *SettingRepository {
+	return &SettingRepository{db: db}
+}
+
+// List retrieves every setting an admin set, ordered by key
+func (r *SettingRepository) List() ([]models.Setting, error) {
+	var settings []models.Setting
+	err := r.db.Order("key").Find(&settings).Error
+	return settings, err
+}
+
+// Upsert stores the value of a setting, replacing the one set before
+func (r *SettingRepository) Upsert(setting *models.Setting) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_by", "updated_at"}),
+	}).Create(setting).Error
+}
+
+// Delete removes the value set for a setting, so its default applies again.
+// It returns gorm.ErrRecordNotFound when none was set.
+func (r *SettingRepository) Delete(key models.SettingKey) error {
+	result := r.db.Unscoped().Where("key = ?", key).Delete(&models.Setting{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ShipmentRepository handles database operations for the shipments of orders
+type ShipmentRepository struct {
+	db *gorm.DB
+}
+
+// NewShipmentRepository creates a new shipment repository
+func NewShipmentRepository(db *gorm.DB) *ShipmentRepository {
+	return &ShipmentRepository{db: db}
+}
+
+// Create stores a shipment with its items. The quote of the order is locked
+// while check compares the items with the quantities already shipped, so
+// concurrent shipments of an order can't ship a unit twice. check runs in the
+// transaction storing the shipment.
+func (r *ShipmentRepository) Create(shipment *models.Shipment, check func(tx *gorm.DB, shipped map[uint]int) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var quote models.Quote
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&quote, shipment.QuoteID).Error; err != nil {
+			return err
+		}
+		shipped, err := shippedQuantities(tx, []uint{shipment.QuoteID})
+		if err != nil {
+			return err
+		}
+		if err := check(tx, shipped); err != nil {
+			return err
+		}
+		return tx.Create(shipment).Error
+	})
+}
+
+// GetByID retrieves a shipment with its items and their products
+func (r *ShipmentRepository) GetByID(id uint) (*models.Shipment, error) {
+	var shipment models.Shipment
+	if err := r.preloadItems(r.db).First(&shipment, id).Error; err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+// ListByQuote retrieves the shipments of the order placed from a quote with
+// their items, in the order they shipped
+func (r *ShipmentRepository) ListByQuote(quoteID uint) ([]models.Shipment, error) {
+	var shipments []models.Shipment
+	err := r.preloadItems(r.db).Where("quote_id = ?", quoteID).Order("shipped_at, id").Find(&shipments).Error
+	return shipments, err
+}
+
+// ShippedQuantities returns the units shipped of the items of quotes, by quote item ID
+func (r *ShipmentRepository) ShippedQuantities(quoteIDs []uint) (map[uint]int, error) {
+	return shippedQuantities(r.db, quoteIDs)
+}
+
+// shippedQuantities sums the units shipped of the items of quotes, by quote item ID
+func shippedQuantities(tx *gorm.DB, quoteIDs []uint) (map[uint]int, error) {
+	var rows []struct {
+		QuoteItemID uint
+		Quantity    int
+	}
+	if err := tx.Table("shipment_items si").
+		Select("si.quote_item_id, SUM(si.quantity) AS quantity").
+		Joins("JOIN shipments s ON s.id = si.shipment_id AND s.deleted_at IS NULL").
+		Joins("JOIN quote_items qi ON qi.id = si.quote_item_id").
+		Where("qi.quote_id IN ? AND si.deleted_at IS NULL", quoteIDs).
+		Group("si.quote_item_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	shipped := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		shipped[row.QuoteItemID] = row.Quantity
+	}
+	return shipped, nil
+}
+
+// preloadItems loads the items of shipments with their products, including deleted ones
+func (r *ShipmentRepository) preloadItems(db *gorm.DB) *gorm.DB {
+	return db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Preload("Items.QuoteItem").Preload("Items.QuoteItem.Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	})
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// SupplierRepository handles database operations for suppliers
+type SupplierRepository struct {
+	db *gorm.DB
+}
+
+// NewSupplierRepository creates a new SupplierRepository instance
+func NewSupplierRepository(db *gorm.DB) *SupplierRepository {
+	return &SupplierRepository{db: db}
+}
+
+// Create creates a new supplier
+func (r *SupplierRepository) Create(supplier *models.Supplier) error {
+	return r.db.Create(supplier).Error
+}
+
+// GetByID retrieves a supplier by ID
+func (r *SupplierRepository) GetByID(id uint) (*models.Supplier, error) {
+	var supplier models.Supplier
+	if err := r.db.First(&supplier, id).Error; err != nil {
+		return nil, err
+	}
+	return &supplier, nil
+}
+
+// List retrieves every supplier ordered by name
+func (r *SupplierRepository) List() ([]models.Supplier, error) {
+	var suppliers []models.Supplier
+	err := r.db.Order("name").Find(&suppliers).Error
+	return suppliers, err
+}
+
+// NameExists reports whether another supplier than excludeID already has a name
+func (r *SupplierRepository) NameExists(name string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Supplier{}).Where("LOWER(name) = LOWER(?) AND id <> ?", name, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// Update saves a supplier's details
+func (r *SupplierRepository) Update(supplier *models.Supplier) error {
+	return r.db.Model(supplier).Select("name", "contact_name", "email", "phone", "notes").Updates(supplier).Error
+}
+
+// HasOpenPurchaseOrders reports whether a supplier still has deliveries outstanding
+func (r *SupplierRepository) HasOpenPurchaseOrders(id uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.PurchaseOrder{}).
+		Where("supplier_id = ? AND status IN ?", id, []models.PurchaseOrderStatus{models.PurchaseOrderOpen, models.PurchaseOrderPartial}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Delete deletes a supplier
+func (r *SupplierRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Supplier{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"time"
+
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// SyncRepository reads the change log mobile clients sync from
+type SyncRepository struct {
+	db *gorm.DB
+}
+
+// NewSyncRepository creates a new sync repository
+func NewSyncRepository(db *gorm.DB) *SyncRepository {
+	return &SyncRepository{db: db}
+}
+
+// recordSyncChange appends a change to the sync log within tx. userID is the
+// owner of a private record, nil for catalog records.
+func recordSyncChange(tx *gorm.DB, entityType models.SyncEntity, entityID uint, userID *uint, operation models.SyncOperation) error {
+	return tx.Create(&models.SyncChange{
+		EntityType: entityType,
+		EntityID:   entityID,
+		UserID:     userID,
+		Operation:  operation,
+	}).Error
+}
+
+// ListAfter retrieves up to limit changes after a cursor that a user may see,
+// oldest first: catalog changes and changes to the user's own records. Changes
+// recorded after before are left out.
+func (r *SyncRepository) ListAfter(cursor, userID uint, before time.Time, limit int) ([]models.SyncChange, error) {
+	var changes []models.SyncChange
+	err := r.db.Where("id > ? AND created_at <= ? AND (user_id IS NULL OR user_id = ?)", cursor, before, userID).
+		Order("id").Limit(limit).Find(&changes).Error
+	return changes, err
+}
+
+// LatestID returns the ID of the latest change recorded at or before a time,
+// 0 when there is none
+func (r *SyncRepository) LatestID(before time.Time) (uint, error) {
+	var id uint
+	err := r.db.Model(&models.SyncChange{}).Where("created_at <= ?", before).
+		Select("COALESCE(MAX(id), 0)").Row().Scan(&id)
+	return id, err
+}
+
+// OldestID returns the ID of the oldest change still kept, 0 when there is none
+func (r *SyncRepository) OldestID() (uint, error) {
+	var id uint
+	err := r.db.Model(&models.SyncChange{}).Select("COALESCE(MIN(id), 0)").Row().Scan(&id)
+	return id, err
+}
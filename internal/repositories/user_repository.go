@@ -3,6 +3,8 @@ package repositories
 import (
 	"errors"
 	"product-management/internal/models"
+	"product-management/pkg/mailer"
+	"product-management/pkg/reqctx"
 	"time"
 
 	"gorm.io/gorm"
@@ -40,6 +42,11 @@ func (r *UserRepository) Create(user *models.User) error {
 	return r.db.Create(user).Error
 }
 
+// WithTx returns a repository that runs its queries in the given transaction
+func (r *UserRepository) WithTx(tx *gorm.DB) *UserRepository {
+	return &UserRepository{db: tx}
+}
+
 // GetByID retrieves a user by ID
 func (r *UserRepository) GetByID(id uint) (*models.User, error) {
 	var user models.User
@@ -49,6 +56,15 @@ func (r *UserRepository) GetByID(id uint) (*models.User, error) {
 	return &user, nil
 }
 
+// GetByIDWithDeleted retrieves a user by ID, including soft-deleted users
+func (r *UserRepository) GetByIDWithDeleted(id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.Unscoped().First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 // GetByUsername retrieves a user by username
 func (r *UserRepository) GetByUsername(username string) (*models.User, error) {
 	var user models.User
@@ -114,11 +130,72 @@ func (r *UserRepository) UpdateFields(userID uint, fields map[string]interface{}
 		Updates(fields).Error
 }
 
+// GetTokenVersion returns the current token version of a user
+func (r *UserRepository) GetTokenVersion(id uint) (uint, error) {
+	var user models.User
+	err := r.db.Select("token_version").First(&user, id).Error
+	return user.TokenVersion, err
+}
+
+// GetPreferences returns the locale, currency and time zone a user saved
+func (r *UserRepository) GetPreferences(id uint) (reqctx.Preferences, error) {
+	var user models.User
+	err := r.db.Select("locale", "currency", "timezone").First(&user, id).Error
+	return reqctx.Preferences{Locale: user.Locale, Currency: user.Currency, Timezone: user.Timezone}, err
+}
+
 // Delete deletes a user
 func (r *UserRepository) Delete(id uint) error {
 	return r.db.Delete(&models.User{}, id).Error
 }
 
+// Anonymize replaces the personal data of a user with the given pseudonymous
+// username and email, and erases what other tables hold about them, in one
+// transaction. The user row keeps its ID, so reviews, wishlists, quotes and
+// audit entries still reference it, and is soft-deleted if it was not already.
+func (r *UserRepository) Anonymize(user *models.User, username, email string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Unscoped().Model(&models.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+			"username":      username,
+			"email":         email,
+			"full_name":     "",
+			"timezone":      "",  // Hints at where the user lives
+			"password":      "!", // Not a bcrypt hash, so no password matches it
+			"referral_code": nil,
+			"custom_fields": nil,
+			"token_version": gorm.Expr("token_version + 1"),
+			"anonymized_at": now,
+			"deleted_at":    gorm.Expr("COALESCE(deleted_at, ?)", now),
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.NotificationSettings{}).Where("user_id = ?", user.ID).
+			Updates(map[string]interface{}{"phone_number": "", "push_token": ""}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&models.KnownDevice{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", user.ID).Delete(&models.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		// Customers' notes may mention names or delivery addresses, gift
+		// messages name their recipients, and checkout form values hold
+		// whatever the store asked for
+		if err := tx.Unscoped().Model(&models.Quote{}).Where("user_id = ?", user.ID).
+			Updates(map[string]interface{}{"note": "", "custom_fields": nil}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&models.QuoteItem{}).
+			Where("quote_id IN (?)", tx.Unscoped().Model(&models.Quote{}).Select("id").Where("user_id = ?", user.ID)).
+			Update("gift_message", "").Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("email = ?", mailer.NormalizeAddress(user.Email)).Delete(&models.EmailSuppression{}).Error
+	})
+}
+
 // UpdateLastLogin updates the last login time for a user
 func (r *UserRepository) UpdateLastLogin(user *models.User) error {
 	// Set the current time
@@ -137,7 +214,38 @@ func (r *UserRepository) ListUsers(page, pageSize int, search string, role model
 	var users []models.User
 	var total int64
 
-	// Build query
+	query := r.filterUsers(search, role)
+
+	// Count total records
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	offset := (page - 1) * pageSize
+	if err := query.Offset(offset).Limit(pageSize).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// EachUser calls fn for every user matching the same search and role filters as
+// ListUsers, ordered by ID and loaded in batches so large exports stay in bounded memory
+func (r *UserRepository) EachUser(search string, role models.Role, batchSize int, fn func(user models.User) error) error {
+	var users []models.User
+	return r.filterUsers(search, role).Order("id").FindInBatches(&users, batchSize, func(tx *gorm.DB, batch int) error {
+		for _, user := range users {
+			if err := fn(user); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+// filterUsers builds a user query with the search and role filters applied
+func (r *UserRepository) filterUsers(search string, role models.Role) *gorm.DB {
 	query := r.db.Model(&models.User{})
 
 	// Apply search filter
@@ -150,16 +258,37 @@ func (r *UserRepository) ListUsers(page, pageSize int, search string, role model
 		query = query.Where("role = ?", role)
 	}
 
-	// Count total records
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
+	return query
+}
 
-	// Apply pagination
-	offset := (page - 1) * pageSize
-	if err := query.Offset(offset).Limit(pageSize).Find(&users).Error; err != nil {
-		return nil, 0, err
+// GetByReferralCode retrieves the user owning a referral code
+func (r *UserRepository) GetByReferralCode(code string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("referral_code = ?", code).First(&user).Error; err != nil {
+		return nil, err
 	}
+	return &user, nil
+}
 
-	return users, total, nil
+// SetReferralCode assigns a referral code to a user who has none yet
+func (r *UserRepository) SetReferralCode(userID uint, code string) error {
+	return r.db.Model(&models.User{}).
+		Where("id = ? AND referral_code IS NULL", userID).
+		Update("referral_code", code).Error
+}
+
+// ListReferrals retrieves the users who signed up with a user's referral code, newest first
+func (r *UserRepository) ListReferrals(referrerID uint) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Where("referred_by_id = ?", referrerID).Order("created_at DESC").Find(&users).Error
+	return users, err
+}
+
+// MarkReferralRewarded records that a referred user's referrer was rewarded and
+// reports false when there is no referrer or the reward was already given
+func (r *UserRepository) MarkReferralRewarded(userID uint) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND referred_by_id IS NOT NULL AND referral_rewarded_at IS NULL", userID).
+		Update("referral_rewarded_at", time.Now())
+	return result.RowsAffected > 0, result.Error
 }
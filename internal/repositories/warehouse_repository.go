@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"time"
+
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// WarehouseRepository handles database operations for the events queued for
+// export to the warehouse
+type WarehouseRepository struct {
+	db *gorm.DB
+}
+
+// NewWarehouseRepository creates a new WarehouseRepository instance
+func NewWarehouseRepository(db *gorm.DB) *WarehouseRepository {
+	return &WarehouseRepository{db: db}
+}
+
+// Queue adds an event to the export queue
+func (r *WarehouseRepository) Queue(event *models.WarehouseEvent) error {
+	return r.db.Create(event).Error
+}
+
+// ListPending retrieves up to limit events not exported yet, oldest first
+func (r *WarehouseRepository) ListPending(limit int) ([]models.WarehouseEvent, error) {
+	var warehouseEvents []models.WarehouseEvent
+	err := r.db.Where("exported_at IS NULL").Order("id").Limit(limit).Find(&warehouseEvents).Error
+	return warehouseEvents, err
+}
+
+// MarkExported records that events were loaded into the warehouse
+func (r *WarehouseRepository) MarkExported(ids []uint, at time.Time) error {
+	return r.db.Model(&models.WarehouseEvent{}).Where("id IN ?", ids).Update("exported_at", at).Error
+}
+
+// ListAcceptedQuotes retrieves up to limit quotes with their items accepted in
+// [from, to) with an ID above afterID, by ID
+func (r *WarehouseRepository) ListAcceptedQuotes(from, to time.Time, afterID uint, limit int) ([]models.Quote, error) {
+	var quotes []models.Quote
+	err := r.db.Preload("Items").
+		Where("status = ? AND decided_at >= ? AND decided_at < ? AND id > ?", models.QuoteAccepted, from, to, afterID).
+		Order("id").Limit(limit).Find(&quotes).Error
+	return quotes, err
+}
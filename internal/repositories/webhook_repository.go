@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// WebhookRepository handles database operations for webhook subscriptions and
+// their delivery logs
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// Create creates a new subscription
+func (r *WebhookRepository) Create(subscription *models.WebhookSubscription) error {
+	return r.db.Create(subscription).Error
+}
+
+// ListByAPIKey retrieves the subscriptions of an API key
+func (r *WebhookRepository) ListByAPIKey(apiKeyName string) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := r.db.Where("api_key_name = ?", apiKeyName).Order("id").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// GetByIDForAPIKey retrieves a subscription of an API key. Another key's
+// subscription is reported as not found.
+func (r *WebhookRepository) GetByIDForAPIKey(id uint, apiKeyName string) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	err := r.db.Where("id = ? AND api_key_name = ?", id, apiKeyName).First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// Delete deletes a subscription
+func (r *WebhookRepository) Delete(id uint) error {
+	return r.db.Delete(&models.WebhookSubscription{}, id).Error
+}
+
+// ListForCustomerEvent retrieves the subscriptions to an event of the API key
+// linked to a customer
+func (r *WebhookRepository) ListForCustomerEvent(event string, userID uint) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := r.subscribedTo(event).
+		Where("users.id = ?", userID).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// ListForProductEvent retrieves the subscriptions to an event of the API keys
+// linked to customers who ordered a product
+func (r *WebhookRepository) ListForProductEvent(event string, productID uint) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := r.subscribedTo(event).
+		Where("EXISTS (?)", r.orderedProducts().Select("1").Where("quotes.user_id = users.id AND quote_items.product_id = ?", productID)).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// subscribedTo selects the subscriptions to an event joined to the customer
+// their API key is linked to. Subscriptions of unlinked keys are left out.
+func (r *WebhookRepository) subscribedTo(event string) *gorm.DB {
+	return r.db.Model(&models.WebhookSubscription{}).
+		Joins("JOIN users ON users.api_key_name = webhook_subscriptions.api_key_name AND users.deleted_at IS NULL").
+		Where("webhook_subscriptions.events @> ?::jsonb", `["`+event+`"]`)
+}
+
+// orderedProducts selects the items of accepted quotes, which are placed as orders
+func (r *WebhookRepository) orderedProducts() *gorm.DB {
+	return r.db.Table("quote_items").
+		Joins("JOIN quotes ON quotes.id = quote_items.quote_id AND quotes.deleted_at IS NULL").
+		Where("quotes.status = ? AND quote_items.deleted_at IS NULL", models.QuoteAccepted)
+}
+
+// GetLinkedUserID retrieves the ID of the customer an API key is linked to
+func (r *WebhookRepository) GetLinkedUserID(apiKeyName string) (uint, error) {
+	var user models.User
+	if err := r.db.Select("id").Where("api_key_name = ?", apiKeyName).First(&user).Error; err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+// OrderedProductIDs returns which of productIDs a customer ordered
+func (r *WebhookRepository) OrderedProductIDs(userID uint, productIDs []uint) ([]uint, error) {
+	var ids []uint
+	err := r.orderedProducts().
+		Where("quotes.user_id = ? AND quote_items.product_id IN ?", userID, productIDs).
+		Distinct("quote_items.product_id").
+		Pluck("quote_items.product_id", &ids).Error
+	return ids, err
+}
+
+// LinkAPIKey links an API key to a customer, or unlinks the customer's key
+// when apiKeyName is nil
+func (r *WebhookRepository) LinkAPIKey(userID uint, apiKeyName *string) error {
+	result := r.db.Model(&models.User{}).Where("id = ?", userID).Update("api_key_name", apiKeyName)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreateDelivery records a delivery attempt
+func (r *WebhookRepository) CreateDelivery(delivery *models.WebhookDelivery) error {
+	return r.db.Create(delivery).Error
+}
+
+// ListDeliveries retrieves a paginated delivery log of a subscription, newest first
+func (r *WebhookRepository) ListDeliveries(subscriptionID uint, page, pageSize int) ([]models.WebhookDelivery, int64, error) {
+	var deliveries []models.WebhookDelivery
+	var total int64
+
+	query := r.db.Model(&models.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Order("created_at DESC, id DESC").
+		Offset(offset).Limit(pageSize).
+		Find(&deliveries).Error
+
+	return deliveries, total, err
+}
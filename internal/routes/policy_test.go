@@ -0,0 +1,261 @@
+package routes
+
+// access is the authorization a route is declared to require
+type access string
+
+const (
+	public        access = "public"        // Reachable without a token
+	authenticated access = "authenticated" // Any valid token
+	admin         access = "admin"         // A valid token with the admin role
+	apiKey        access = "api key"       // A configured X-API-Key; user tokens are not accepted
+)
+
+// policy declares the required access of every route registered by
+// SetupRoutes. A route missing from this table fails TestAuthorizationPolicy,
+// so every new route needs an explicit decision here.
+var policy = map[string]access{
+	// Health and metrics
+	"GET /healthz": public,
+	"GET /readyz":  public,
+	"GET /metrics": public,
+
+	// Local storage files, authorized by the URL signature instead of a token
+	"GET /files/*key": public,
+
+	// Admin console assets; the console signs in against the API
+	"GET /admin-ui/*path": public,
+
+	// Marketing content, rendered by storefronts before login
+	"GET /api/v1/content/:key": public,
+
+	// Custom field schemas, rendered by clients on the registration form
+	"GET /api/v1/forms/:name/schema": public,
+
+	// Order tracking, authorized by the signed token in the link
+	"GET /api/v1/orders/track/:token": public,
+
+	// Product views, recorded for anonymous visitors too
+	"POST /api/v1/products/:id/view": public,
+
+	// Product image files, linked from img tags
+	"GET /api/v1/products/:id/images/:imageId": public,
+
+	// Experiments, which anonymous visitors take part in too
+	"GET /api/v1/experiments/assignments": public,
+	"POST /api/v1/experiments/events":     public,
+
+	// Auth
+	"GET /api/v1/auth/form-token":            public,
+	"POST /api/v1/auth/register":             public,
+	"POST /api/v1/auth/login":                public,
+	"POST /api/v1/auth/refresh":              public,
+	"POST /api/v1/auth/logout":               authenticated,
+	"GET /api/v1/auth/me":                    authenticated,
+	"PUT /api/v1/auth/me":                    authenticated,
+	"PUT /api/v1/auth/password":              authenticated,
+	"GET /api/v1/auth/notification-settings": authenticated,
+	"PUT /api/v1/auth/notification-settings": authenticated,
+	"GET /api/v1/auth/unsubscribe":           public,
+	"GET /api/v1/auth/store-credit":          authenticated,
+	"GET /api/v1/auth/store-credit/entries":  authenticated,
+	"GET /api/v1/auth/referrals":             authenticated,
+	"POST /api/v1/quotes":                    authenticated,
+	"GET /api/v1/quotes":                     authenticated,
+	"GET /api/v1/quotes/:id":                 authenticated,
+	"POST /api/v1/quotes/:id/accept":         authenticated,
+	"POST /api/v1/quotes/:id/decline":        authenticated,
+	"GET /api/v1/quotes/:id/invoice":         authenticated,
+	"GET /api/v1/bookings":                   authenticated,
+	"POST /api/v1/bookings/:id/cancel":       authenticated,
+	"GET /api/v1/gift-cards/:code":           authenticated,
+	"GET /api/v1/auth/users":                 authenticated,
+	"GET /api/v1/auth/users/:id":             authenticated,
+	"PUT /api/v1/auth/users/:id/role":        admin,
+	"DELETE /api/v1/auth/users/:id":          admin,
+	"GET /api/v1/users/:id/review-stats":     authenticated,
+
+	// Products and wishlist
+	"GET /api/v1/products":                             authenticated,
+	"POST /api/v1/products":                            authenticated,
+	"GET /api/v1/products/:id":                         authenticated,
+	"GET /api/v1/products/batch":                       authenticated,
+	"GET /api/v1/products/featured":                    authenticated,
+	"GET /api/v1/products/trending":                    authenticated,
+	"GET /api/v1/products/:id/price":                   authenticated,
+	"GET /api/v1/products/:id/barcode":                 authenticated,
+	"GET /api/v1/products/:id/also-viewed":             authenticated,
+	"GET /api/v1/products/:id/images":                  authenticated,
+	"POST /api/v1/products/:id/images":                 authenticated,
+	"PUT /api/v1/products/:id/images/order":            authenticated,
+	"DELETE /api/v1/products/:id/images/:imageId":      authenticated,
+	"POST /api/v1/products/labels":                     authenticated,
+	"PUT /api/v1/products/:id":                         authenticated,
+	"PATCH /api/v1/products/:id/metadata":              authenticated,
+	"DELETE /api/v1/products/:id":                      authenticated,
+	"GET /api/v1/products/:id/revisions":               authenticated,
+	"POST /api/v1/products/:id/revisions/:rev/restore": authenticated,
+	"GET /api/v1/products/:id/stock-history":           admin,
+	"POST /api/v1/products/:id/stock-adjustments":      admin,
+	"GET /api/v1/products/:id/availability":            authenticated,
+	"POST /api/v1/products/:id/bookings":               authenticated,
+	"GET /api/v1/products/wishlist":                    authenticated,
+	"GET /api/v1/products/wishlist/count":              authenticated,
+	"POST /api/v1/products/wishlist/:product_id":       authenticated,
+	"DELETE /api/v1/products/wishlist/:product_id":     authenticated,
+
+	// Categories
+	"GET /api/v1/categories":                            authenticated,
+	"POST /api/v1/categories":                           authenticated,
+	"GET /api/v1/categories/distribution":               authenticated,
+	"GET /api/v1/categories/:id":                        authenticated,
+	"GET /api/v1/categories/batch":                      authenticated,
+	"PUT /api/v1/categories/:id":                        authenticated,
+	"DELETE /api/v1/categories/:id":                     authenticated,
+	"GET /api/v1/categories/:id/products":               authenticated,
+	"GET /api/v1/storefront/bootstrap":                  authenticated,
+	"GET /api/v1/sync/changes":                          authenticated,
+	"POST /api/v1/categories/:id/products/:productId":   authenticated,
+	"DELETE /api/v1/categories/:id/products/:productId": authenticated,
+
+	// Reviews
+	"GET /api/v1/reviews/":             authenticated,
+	"POST /api/v1/reviews/":            authenticated,
+	"GET /api/v1/reviews/count":        authenticated,
+	"GET /api/v1/reviews/:id":          authenticated,
+	"DELETE /api/v1/reviews/:id":       authenticated,
+	"GET /api/v1/reviews/user/:userId": authenticated,
+
+	// Integrations
+	"POST /api/v1/integrations/webhooks":               apiKey,
+	"GET /api/v1/integrations/webhooks":                apiKey,
+	"DELETE /api/v1/integrations/webhooks/:id":         apiKey,
+	"GET /api/v1/integrations/webhooks/:id/deliveries": apiKey,
+
+	// Admin
+	"GET /api/v1/admin/change-requests":                     admin,
+	"GET /api/v1/admin/change-requests/:id":                 admin,
+	"POST /api/v1/admin/change-requests/:id/approve":        admin,
+	"POST /api/v1/admin/change-requests/:id/reject":         admin,
+	"GET /api/v1/admin/analytics/reviews":                   admin,
+	"GET /api/v1/admin/analytics/categories":                admin,
+	"GET /api/v1/admin/analytics/sales":                     admin,
+	"GET /api/v1/admin/analytics/conversion":                admin,
+	"GET /api/v1/admin/analytics/activity":                  admin,
+	"GET /api/v1/admin/reports":                             admin,
+	"GET /api/v1/admin/reports/:name":                       admin,
+	"GET /api/v1/admin/finance/reconciliation":              admin,
+	"GET /api/v1/admin/retention":                           admin,
+	"GET /api/v1/admin/integrity":                           admin,
+	"POST /api/v1/admin/integrity/repair":                   admin,
+	"POST /api/v1/admin/rebuilds":                           admin,
+	"GET /api/v1/admin/rebuilds":                            admin,
+	"GET /api/v1/admin/rebuilds/:id":                        admin,
+	"POST /api/v1/admin/storefront/export":                  admin,
+	"GET /api/v1/admin/featured-products":                   admin,
+	"POST /api/v1/admin/featured-products":                  admin,
+	"PUT /api/v1/admin/featured-products/:id":               admin,
+	"DELETE /api/v1/admin/featured-products/:id":            admin,
+	"POST /api/v1/admin/reviews/import":                     admin,
+	"GET /api/v1/admin/connectors":                          admin,
+	"GET /api/v1/admin/connectors/:name/runs":               admin,
+	"POST /api/v1/admin/connectors/:name/sync":              admin,
+	"GET /api/v1/admin/outbox":                              admin,
+	"POST /api/v1/admin/outbox/reprocess":                   admin,
+	"GET /api/v1/admin/catalog/diff":                        admin,
+	"GET /api/v1/admin/products/:id/market-prices":          admin,
+	"GET /api/v1/admin/products/duplicates":                 admin,
+	"POST /api/v1/admin/products/:id/merge":                 admin,
+	"POST /api/v1/admin/products/:id/slots":                 admin,
+	"DELETE /api/v1/admin/slots/:id":                        admin,
+	"GET /api/v1/admin/bookings":                            admin,
+	"POST /api/v1/admin/bookings/:id/cancel":                admin,
+	"GET /api/v1/admin/sagas":                               admin,
+	"GET /api/v1/admin/sagas/:id":                           admin,
+	"POST /api/v1/admin/sagas/:id/retry":                    admin,
+	"GET /api/v1/admin/content-blocks":                      admin,
+	"POST /api/v1/admin/content-blocks":                     admin,
+	"GET /api/v1/admin/content-blocks/:id":                  admin,
+	"PUT /api/v1/admin/content-blocks/:id":                  admin,
+	"DELETE /api/v1/admin/content-blocks/:id":               admin,
+	"GET /api/v1/admin/custom-fields":                       admin,
+	"POST /api/v1/admin/custom-fields":                      admin,
+	"PUT /api/v1/admin/custom-fields/:id":                   admin,
+	"DELETE /api/v1/admin/custom-fields/:id":                admin,
+	"GET /api/v1/admin/settings":                            admin,
+	"GET /api/v1/admin/settings/:key":                       admin,
+	"PUT /api/v1/admin/settings/:key":                       admin,
+	"DELETE /api/v1/admin/settings/:key":                    admin,
+	"GET /api/v1/admin/security/alerts":                     admin,
+	"GET /api/v1/admin/security/blocks":                     admin,
+	"DELETE /api/v1/admin/security/blocks/:ip":              admin,
+	"GET /api/v1/admin/selftest":                            admin,
+	"GET /api/v1/admin/config":                              admin,
+	"POST /api/v1/admin/config/reload":                      admin,
+	"GET /api/v1/admin/debug/pprof/*name":                   admin,
+	"GET /api/v1/admin/debug/goroutines":                    admin,
+	"GET /api/v1/admin/debug/vars":                          admin,
+	"GET /api/v1/admin/debug/verbose-logging":               admin,
+	"PUT /api/v1/admin/debug/verbose-logging":               admin,
+	"GET /api/v1/admin/users/export":                        admin,
+	"DELETE /api/v1/admin/users/:id/anonymize":              admin,
+	"DELETE /api/v1/admin/users/:id/sessions":               admin,
+	"POST /api/v1/admin/test-tokens":                        admin,
+	"POST /api/v1/admin/gift-cards":                         admin,
+	"POST /api/v1/admin/gift-cards/:code/redeem":            admin,
+	"POST /api/v1/admin/users/:id/store-credit":             admin,
+	"POST /api/v1/admin/price-lists":                        admin,
+	"GET /api/v1/admin/price-lists":                         admin,
+	"GET /api/v1/admin/price-lists/:id":                     admin,
+	"PUT /api/v1/admin/price-lists/:id":                     admin,
+	"DELETE /api/v1/admin/price-lists/:id":                  admin,
+	"PUT /api/v1/admin/price-lists/:id/products/:productId": admin,
+	"PUT /api/v1/admin/users/:id/price-list":                admin,
+	"PUT /api/v1/admin/users/:id/api-key":                   admin,
+	"GET /api/v1/admin/pricing-rules":                       admin,
+	"POST /api/v1/admin/pricing-rules":                      admin,
+	"POST /api/v1/admin/pricing-rules/simulate":             admin,
+	"GET /api/v1/admin/pricing-rules/:id":                   admin,
+	"PUT /api/v1/admin/pricing-rules/:id":                   admin,
+	"DELETE /api/v1/admin/pricing-rules/:id":                admin,
+	"GET /api/v1/admin/pricing-rules/:id/applications":      admin,
+	"GET /api/v1/admin/promotions":                          admin,
+	"POST /api/v1/admin/promotions":                         admin,
+	"GET /api/v1/admin/promotions/:id":                      admin,
+	"PUT /api/v1/admin/promotions/:id":                      admin,
+	"DELETE /api/v1/admin/promotions/:id":                   admin,
+	"GET /api/v1/admin/experiments":                         admin,
+	"POST /api/v1/admin/experiments":                        admin,
+	"GET /api/v1/admin/experiments/:id":                     admin,
+	"PUT /api/v1/admin/experiments/:id":                     admin,
+	"DELETE /api/v1/admin/experiments/:id":                  admin,
+	"POST /api/v1/admin/experiments/:id/start":              admin,
+	"POST /api/v1/admin/experiments/:id/stop":               admin,
+	"GET /api/v1/admin/experiments/:id/results":             admin,
+	"GET /api/v1/admin/quotes":                              admin,
+	"GET /api/v1/admin/quotes/:id":                          admin,
+	"POST /api/v1/admin/quotes/:id/respond":                 admin,
+	"POST /api/v1/admin/quotes/:id/shipments":               admin,
+	"GET /api/v1/admin/quotes/:id/invoice":                  admin,
+	"GET /api/v1/admin/quotes/:id/packing-slip":             admin,
+	"GET /api/v1/admin/shipments/:id/packing-slip":          admin,
+	"POST /api/v1/admin/pick-lists":                         admin,
+	"POST /api/v1/admin/suppliers":                          admin,
+	"GET /api/v1/admin/suppliers":                           admin,
+	"GET /api/v1/admin/suppliers/:id":                       admin,
+	"PUT /api/v1/admin/suppliers/:id":                       admin,
+	"DELETE /api/v1/admin/suppliers/:id":                    admin,
+	"POST /api/v1/admin/purchase-orders":                    admin,
+	"GET /api/v1/admin/purchase-orders":                     admin,
+	"GET /api/v1/admin/purchase-orders/:id":                 admin,
+	"POST /api/v1/admin/purchase-orders/:id/receive":        admin,
+	"POST /api/v1/admin/purchase-orders/:id/cancel":         admin,
+	"POST /api/v1/admin/segments":                           admin,
+	"GET /api/v1/admin/segments":                            admin,
+	"GET /api/v1/admin/segments/:id":                        admin,
+	"PUT /api/v1/admin/segments/:id":                        admin,
+	"DELETE /api/v1/admin/segments/:id":                     admin,
+	"GET /api/v1/admin/segments/:id/members":                admin,
+	"POST /api/v1/admin/segments/:id/notify":                admin,
+	"GET /api/v1/admin/rate-limits/:principal":              admin,
+	"DELETE /api/v1/admin/rate-limits/:principal":           admin,
+}
@@ -1,11 +1,17 @@
 package routes
 
 import (
+	"product-management/config"
+	"product-management/internal/adminui"
 	"product-management/internal/handlers"
 	"product-management/internal/middleware"
 	"product-management/internal/models"
 	"product-management/internal/repositories"
 	"product-management/internal/services"
+	"product-management/pkg/botguard"
+	"product-management/pkg/events"
+	"product-management/pkg/ratelimit"
+	"product-management/pkg/storage"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -31,8 +37,18 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 
-// SetupRoutes configures all the routes for the application
-func SetupRoutes(db *gorm.DB, r *gin.Engine) {
+// ReviewImportPath is the full path of the review import route, which takes CSV
+// as well as JSON bodies
+const ReviewImportPath = "/api/v1/admin/reviews/import"
+
+// ProductImageUploadPath is the full path of the product image upload route,
+// which takes multipart forms
+const ProductImageUploadPath = "/api/v1/products/:id/images"
+
+// SetupRoutes configures all the routes for the application. When internal is
+// not nil, the admin routes are served by it, behind a mutual TLS listener,
+// instead of by r.
+func SetupRoutes(cfg *config.Config, db *gorm.DB, r *gin.Engine, internal *gin.Engine) {
 	// Initialize repositories
 	productRepo := repositories.NewProductRepository(db)
 	reviewRepo := repositories.NewReviewRepository(db)
@@ -41,26 +57,147 @@ func SetupRoutes(db *gorm.DB, r *gin.Engine) {
 	// Initialize services
 	categoryService := services.NewCategoryService()
 	reviewService := services.NewReviewService(reviewRepo)
+	productChangeService := services.NewProductChangeService(func() bool {
+		return config.CurrentRuntime().Feature(config.FeatureProductChangeApproval)
+	})
+	analyticsService := services.NewAnalyticsService(cfg.ReportingMode == "materialized")
+	activityService := services.NewActivityService(cfg.ActivityBucket)
+	auditService := services.NewAuditService()
+	notificationService := services.NewNotificationService()
+	webhookService := services.NewWebhookService()
+	giftCardService := services.NewGiftCardService()
+	referralService := services.NewReferralService(cfg.ReferralRewardAmount)
+	segmentService := services.NewSegmentService(notificationService)
+	pricingRuleService := services.NewPricingRuleService()
+	promotionService := services.NewPromotionService(cfg.PromotionPollInterval)
+	priceListService := services.NewPriceListService(pricingRuleService, promotionService)
+	featuredService := services.NewFeaturedProductService()
+	quoteService := services.NewQuoteService(priceListService)
+	purchasingService := services.NewPurchasingService()
+	barcodeService := services.NewBarcodeService(services.NewProductService())
+	labelService := services.NewLabelService(services.NewProductService())
+	reportService := services.NewReportService()
+	retentionService := services.NewRetentionService(cfg.RetentionRules, cfg.RetentionInterval)
+	connectorService := services.NewConnectorService(cfg.Connectors)
+	securityService := services.NewSecurityService(services.SecurityPolicy{
+		FailedLoginAccounts: cfg.SecurityFailedLoginAccounts,
+		FailedLoginWindow:   cfg.SecurityFailedLoginWindow,
+		BulkDeleteThreshold: cfg.SecurityBulkDeleteThreshold,
+		BulkDeleteWindow:    cfg.SecurityBulkDeleteWindow,
+		AutoBlock:           cfg.SecurityAutoBlock,
+		BlockDuration:       cfg.SecurityBlockDuration,
+	}, notificationService)
 
 	// Initialize handlers
-	productHandler := handlers.NewProductHandler(productRepo)
+	productHandler := handlers.NewProductHandler(productRepo, productChangeService, priceListService)
 	reviewHandler := handlers.NewReviewHandler(reviewService)
-	categoryHandler := handlers.NewCategoryHandler(categoryService)
+	categoryHandler := handlers.NewCategoryHandler(categoryService, priceListService)
 	authService := services.NewAuthService()
-	authHandler := handlers.NewAuthHandler(userRepo, authService)
+	customFieldService := services.NewCustomFieldService()
+	authHandler := handlers.NewAuthHandler(userRepo, authService, auditService, notificationService, referralService, customFieldService)
+	changeRequestHandler := handlers.NewChangeRequestHandler(productChangeService)
+	concurrency := ratelimit.NewConcurrency(cfg.MaxInFlightRequests, cfg.RequestQueueDepth, cfg.RequestQueueTimeout)
+	healthHandler := handlers.NewHealthHandler(concurrency)
+	limiter := ratelimit.New(cfg.RateLimitWindow)
+	rateLimitHandler := handlers.NewRateLimitHandler(limiter)
+	fileHandler := handlers.NewFileHandler()
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService, activityService)
+	reportHandler := handlers.NewReportHandler(reportService, auditService)
+	financeHandler := handlers.NewFinanceHandler(services.NewFinanceService(), auditService)
+	retentionHandler := handlers.NewRetentionHandler(retentionService)
+	connectorHandler := handlers.NewConnectorHandler(connectorService)
+	outboxHandler := handlers.NewOutboxHandler(services.NewOutboxService(events.Default, cfg.OutboxPollInterval, cfg.OutboxMaxAttempts), auditService)
+	catalogHandler := handlers.NewCatalogHandler(services.NewCatalogService())
+	syncHandler := handlers.NewSyncHandler(services.NewSyncService(), priceListService)
+	sagaHandler := handlers.NewSagaHandler(services.NewSagaService(cfg.SagaPollInterval, cfg.SagaMaxAttempts), auditService)
+	storefrontHandler := handlers.NewStorefrontHandler(services.NewStorefrontService(priceListService, featuredService, cfg.StorefrontFeaturedProducts),
+		services.NewStorefrontExportService())
+	securityHandler := handlers.NewSecurityHandler(securityService)
+	configHandler := handlers.NewConfigHandler(services.NewConfigService(events.Default), auditService)
+	diagnosticsHandler := handlers.NewDiagnosticsHandler(services.NewSelfTestService(cfg.StorageBackend, cfg.MailDriver))
+	botGuardHandler := handlers.NewBotGuardHandler(botguard.Default)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	giftCardHandler := handlers.NewGiftCardHandler(giftCardService, auditService)
+	referralHandler := handlers.NewReferralHandler(referralService)
+	segmentHandler := handlers.NewSegmentHandler(segmentService, auditService)
+	priceListHandler := handlers.NewPriceListHandler(priceListService)
+	pricingRuleHandler := handlers.NewPricingRuleHandler(pricingRuleService, auditService)
+	promotionHandler := handlers.NewPromotionHandler(promotionService)
+	featuredHandler := handlers.NewFeaturedProductHandler(featuredService, priceListService)
+	contentHandler := handlers.NewContentBlockHandler(services.NewContentBlockService())
+	customFieldHandler := handlers.NewCustomFieldHandler(customFieldService)
+	duplicateHandler := handlers.NewProductDuplicateHandler(services.NewProductDuplicateService(), auditService)
+	integrityHandler := handlers.NewIntegrityHandler(services.NewIntegrityService(), auditService)
+	rebuildHandler := handlers.NewRebuildHandler(services.NewRebuildService(cfg.CacheWarmupTopProducts), auditService)
+	settingHandler := handlers.NewSettingHandler(services.NewSettingService())
+	marketPriceHandler := handlers.NewMarketPriceHandler(services.NewMarketPriceService(cfg.MarketPriceProviders, cfg.MarketPriceSKUs,
+		cfg.MarketPriceCacheTTL, cfg.MarketPriceTimeout))
+	reviewImportHandler := handlers.NewReviewImportHandler(services.NewReviewImportService(), auditService)
+	quoteHandler := handlers.NewQuoteHandler(quoteService, customFieldService)
+	orderHandler := handlers.NewOrderHandler(services.NewOrderService())
+	bookingHandler := handlers.NewBookingHandler(services.NewBookingService())
+	purchasingHandler := handlers.NewPurchasingHandler(purchasingService)
+	barcodeHandler := handlers.NewBarcodeHandler(barcodeService)
+	labelHandler := handlers.NewLabelHandler(labelService)
+	productViewHandler := handlers.NewProductViewHandler(services.NewProductViewService(), priceListService)
+	experimentHandler := handlers.NewExperimentHandler(services.NewExperimentService())
+	productImageHandler := handlers.NewProductImageHandler(services.NewProductImageService(), productChangeService)
+
+	// Health and metrics routes
+	r.GET("/healthz", healthHandler.Liveness)
+	r.GET("/readyz", healthHandler.Readiness)
+	r.GET("/metrics", healthHandler.Metrics)
+
+	// Locally stored files, authorized by signed URLs
+	r.GET(storage.LocalPathPrefix+"*key", fileHandler.ServeFile)
 
-	// API version group
+	// Embedded admin console, which signs in and calls the API like any client
+	if cfg.AdminUI {
+		r.GET(adminui.PathPrefix+"*path", adminui.Serve)
+	}
+
+	// Rate limits are counted per route group. They run after authentication so
+	// the caller's tier is known.
+	rateLimitPolicy := func() ratelimit.Policy {
+		return config.CurrentRuntime().RateLimitPolicy
+	}
+	rateLimit := func(group string) gin.HandlerFunc {
+		return middleware.RateLimit(limiter, rateLimitPolicy, group)
+	}
+
+	// API version group, shedding load once too many requests are in flight.
+	// Health routes stay outside so probes answer during a spike.
 	api := r.Group("/api/v1")
+	api.Use(middleware.Backpressure(concurrency))
 
 	// Product routes
 	products := api.Group("/products")
-	products.Use(middleware.AuthMiddleware())
+	products.Use(middleware.AuthMiddleware(), rateLimit("products"))
 	{
 		products.POST("", productHandler.CreateProduct)
+		products.POST("/labels", labelHandler.PrintProductLabels)
+		products.GET("/batch", productHandler.GetProductsBatch)
+		products.GET("/featured", featuredHandler.GetFeaturedProducts)
+		products.GET("/trending", productViewHandler.GetTrendingProducts)
 		products.GET("/:id", productHandler.GetProduct)
+		products.GET("/:id/price", productHandler.GetProductPrice)
+		products.GET("/:id/barcode", barcodeHandler.GetProductBarcode)
+		products.GET("/:id/also-viewed", productViewHandler.GetAlsoViewedProducts)
+		products.GET("/:id/images", productImageHandler.ListProductImages)
+		products.POST("/:id/images", productImageHandler.UploadProductImage)
+		products.PUT("/:id/images/order", productImageHandler.ReorderProductImages)
+		products.DELETE("/:id/images/:imageId", productImageHandler.DeleteProductImage)
 		products.PUT("/:id", productHandler.UpdateProduct)
+		products.PATCH("/:id/metadata", productHandler.PatchProductMetadata)
 		products.DELETE("/:id", productHandler.DeleteProduct)
 		products.GET("", productHandler.ListProducts)
+		products.GET("/:id/revisions", productHandler.ListProductRevisions)
+		products.POST("/:id/revisions/:rev/restore", productHandler.RestoreProductRevision)
+		products.GET("/:id/stock-history", middleware.RequireRole(string(models.RoleAdmin)), productHandler.GetStockHistory)
+		products.POST("/:id/stock-adjustments", middleware.RequireRole(string(models.RoleAdmin)), productHandler.AdjustStock)
+		products.GET("/:id/availability", bookingHandler.GetAvailability)
+		products.POST("/:id/bookings", rateLimit("bookings"), bookingHandler.CreateBooking)
 
 		// Wishlist routes
 		wishlist := products.Group("/wishlist")
@@ -72,41 +209,75 @@ func SetupRoutes(db *gorm.DB, r *gin.Engine) {
 		}
 	}
 
+	// Product views are recorded for anonymous visitors too, and attributed to
+	// the user when a token comes along
+	api.POST("/products/:id/view", middleware.OptionalAuthMiddleware(), rateLimit("product-views"), productViewHandler.RecordProductView)
+
+	// Product image URLs work without login so they can be used in img tags
+	api.GET("/products/:id/images/:imageId", rateLimit("product-images"), productImageHandler.GetProductImage)
+
+	// Experiment routes, for anonymous visitors too, who identify themselves
+	// with an anonymous ID; users are recognized by their token
+	experiments := api.Group("/experiments")
+	experiments.Use(middleware.OptionalAuthMiddleware(), rateLimit("experiments"))
+	{
+		experiments.GET("/assignments", experimentHandler.GetAssignments)
+		experiments.POST("/events", experimentHandler.RecordEvent)
+	}
+
 	// Auth routes
 	auth := api.Group("/auth")
+	authLimit := rateLimit("auth")
 	{
-		auth.POST("/register", authHandler.Register)
-		auth.POST("/login", authHandler.Login)
-		auth.GET("/me", middleware.AuthMiddleware(), authHandler.GetCurrentUser)
-		auth.PUT("/me", middleware.AuthMiddleware(), authHandler.UpdateUser)
-		auth.PUT("/password", middleware.AuthMiddleware(), authHandler.UpdatePassword)
-		auth.GET("/users/:id", middleware.AuthMiddleware(), authHandler.GetUserByID)
-		auth.GET("/users", middleware.AuthMiddleware(), authHandler.ListUsers)
-		auth.PUT("/users/:id/role", middleware.AuthMiddleware(), middleware.RequireRole(string(models.RoleAdmin)), authHandler.UpdateUserRole)
-		auth.DELETE("/users/:id", middleware.AuthMiddleware(), middleware.RequireRole(string(models.RoleAdmin)), authHandler.DeleteUser)
+		auth.GET("/form-token", authLimit, botGuardHandler.GetFormToken)
+		auth.POST("/register", authLimit, middleware.BotGuard(botguard.Default, "register"), authHandler.Register)
+		auth.POST("/login", authLimit, middleware.BotGuard(botguard.Default, "login"), authHandler.Login)
+		auth.POST("/refresh", authLimit, authHandler.RefreshToken)
+		auth.POST("/logout", middleware.AuthMiddleware(), authLimit, authHandler.Logout)
+		auth.GET("/me", middleware.AuthMiddleware(), authLimit, authHandler.GetCurrentUser)
+		auth.PUT("/me", middleware.AuthMiddleware(), authLimit, authHandler.UpdateUser)
+		auth.PUT("/password", middleware.AuthMiddleware(), authLimit, authHandler.UpdatePassword)
+		auth.GET("/notification-settings", middleware.AuthMiddleware(), authLimit, notificationHandler.GetSettings)
+		auth.PUT("/notification-settings", middleware.AuthMiddleware(), authLimit, notificationHandler.UpdateSettings)
+		auth.GET("/unsubscribe", authLimit, notificationHandler.Unsubscribe)
+		auth.GET("/store-credit", middleware.AuthMiddleware(), authLimit, giftCardHandler.GetStoreCreditBalance)
+		auth.GET("/store-credit/entries", middleware.AuthMiddleware(), authLimit, giftCardHandler.ListStoreCredit)
+		auth.GET("/referrals", middleware.AuthMiddleware(), authLimit, referralHandler.GetReferrals)
+		auth.GET("/users/:id", middleware.AuthMiddleware(), authLimit, authHandler.GetUserByID)
+		auth.GET("/users", middleware.AuthMiddleware(), authLimit, authHandler.ListUsers)
+		auth.PUT("/users/:id/role", middleware.AuthMiddleware(), middleware.RequireRole(string(models.RoleAdmin)), authLimit, authHandler.UpdateUserRole)
+		auth.DELETE("/users/:id", middleware.AuthMiddleware(), middleware.RequireRole(string(models.RoleAdmin)), authLimit, authHandler.DeleteUser)
 	}
 
 	// Review routes
 	reviews := api.Group("/reviews")
-	reviews.Use(middleware.AuthMiddleware())
+	reviews.Use(middleware.AuthMiddleware(), rateLimit("reviews"))
 	{
-		reviews.POST("/", reviewHandler.CreateReview)
+		reviews.POST("/", middleware.BotGuard(botguard.Default, "review"), reviewHandler.CreateReview)
 		reviews.GET("/", reviewHandler.SearchReviews)
 		reviews.GET("/count", reviewHandler.GetTotalReviews)
 		reviews.GET("/:id", reviewHandler.GetReviewByID)
 		// reviews.GET("/product/:productId", reviewHandler.GetReviewsByProductID)
-		// reviews.GET("/user/:userId", reviewHandler.GetReviewsByUserID)
+		reviews.GET("/user/:userId", reviewHandler.GetReviewsByUserID)
 		// reviews.PUT("/:id", reviewHandler.UpdateReview)
 		reviews.DELETE("/:id", reviewHandler.DeleteReview)
 		// reviews.GET("/product/:productId/rating", reviewHandler.GetProductRating)
 		// reviews.GET("/product/:productId/count", reviewHandler.GetProductReviewCount)
 	}
 
+	// User routes
+	users := api.Group("/users")
+	users.Use(middleware.AuthMiddleware(), rateLimit("users"))
+	{
+		users.GET("/:id/review-stats", reviewHandler.GetUserReviewStats)
+	}
+
 	// Category routes
 	categories := api.Group("/categories")
-	categories.Use(middleware.AuthMiddleware())
+	categories.Use(middleware.AuthMiddleware(), rateLimit("categories"))
 	{
 		categories.POST("", categoryHandler.CreateCategory)
+		categories.GET("/batch", categoryHandler.GetCategoriesBatch)
 		categories.GET("/:id", categoryHandler.GetCategoryByID)
 		categories.PUT("/:id", categoryHandler.UpdateCategory)
 		categories.DELETE("/:id", categoryHandler.DeleteCategory)
@@ -121,4 +292,329 @@ func SetupRoutes(db *gorm.DB, r *gin.Engine) {
 			categoryProducts.DELETE("/:productId", categoryHandler.RemoveProductFromCategory)
 		}
 	}
+
+	// Delta sync routes for offline-capable clients
+	sync := api.Group("/sync")
+	sync.Use(middleware.AuthMiddleware(), rateLimit("sync"))
+	{
+		sync.GET("/changes", syncHandler.GetChanges)
+	}
+
+	// Storefront routes
+	storefront := api.Group("/storefront")
+	storefront.Use(middleware.AuthMiddleware(), rateLimit("storefront"))
+	{
+		storefront.GET("/bootstrap", storefrontHandler.Bootstrap)
+	}
+
+	// Content routes, public so storefronts can render banners before login
+	content := api.Group("/content")
+	content.Use(rateLimit("content"))
+	{
+		content.GET("/:key", contentHandler.GetContent)
+	}
+
+	// Form schemas, public so clients can render the registration form
+	api.GET("/forms/:name/schema", rateLimit("forms"), customFieldHandler.GetFormSchema)
+
+	// Gift card routes
+	giftCards := api.Group("/gift-cards")
+	giftCards.Use(middleware.AuthMiddleware(), rateLimit("gift-cards"))
+	{
+		giftCards.GET("/:code", giftCardHandler.GetGiftCard)
+	}
+
+	// Request-for-quote routes
+	quotes := api.Group("/quotes")
+	quotes.Use(middleware.AuthMiddleware(), rateLimit("quotes"))
+	{
+		quotes.POST("", quoteHandler.RequestQuote)
+		quotes.GET("", quoteHandler.ListMyQuotes)
+		quotes.GET("/:id", quoteHandler.GetMyQuote)
+		quotes.POST("/:id/accept", quoteHandler.AcceptQuote)
+		quotes.POST("/:id/decline", quoteHandler.DeclineQuote)
+		quotes.GET("/:id/invoice", orderHandler.GetMyInvoice)
+	}
+
+	// Bookings of rental and service products
+	bookings := api.Group("/bookings")
+	bookings.Use(middleware.AuthMiddleware(), rateLimit("bookings"))
+	{
+		bookings.GET("", bookingHandler.ListMyBookings)
+		bookings.POST("/:id/cancel", bookingHandler.CancelMyBooking)
+	}
+
+	// Order tracking, public so guests and gift recipients can follow an order
+	// from its signed link
+	api.GET("/orders/track/:token", rateLimit("orders"), orderHandler.TrackOrder)
+
+	// Integration routes, authenticated by API key instead of a user token
+	integrations := api.Group("/integrations")
+	integrations.Use(middleware.APIKeyAuth(rateLimitPolicy), rateLimit("integrations"))
+	{
+		webhooks := integrations.Group("/webhooks")
+		{
+			webhooks.POST("", webhookHandler.CreateWebhook)
+			webhooks.GET("", webhookHandler.ListWebhooks)
+			webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)
+			webhooks.GET("/:id/deliveries", webhookHandler.ListWebhookDeliveries)
+		}
+	}
+
+	// Admin routes, which also require a client certificate on the internal listener
+	adminAPI := api
+	if internal != nil {
+		adminAPI = internal.Group("/api/v1")
+		adminAPI.Use(middleware.ClientCertificate(cfg.InternalClientNames), middleware.Backpressure(concurrency))
+	}
+	admin := adminAPI.Group("/admin")
+	admin.Use(middleware.AuthMiddleware(), middleware.RequireRole(string(models.RoleAdmin)), rateLimit("admin"))
+	{
+		// Product change request review
+		changeRequests := admin.Group("/change-requests")
+		{
+			changeRequests.GET("", changeRequestHandler.ListChangeRequests)
+			changeRequests.GET("/:id", changeRequestHandler.GetChangeRequest)
+			changeRequests.POST("/:id/approve", changeRequestHandler.ApproveChangeRequest)
+			changeRequests.POST("/:id/reject", changeRequestHandler.RejectChangeRequest)
+		}
+
+		// User export
+		admin.GET("/users/export", authHandler.ExportUsers)
+
+		// Right-to-be-forgotten requests
+		admin.DELETE("/users/:id/anonymize", authHandler.AnonymizeUser)
+
+		// Session revocation
+		admin.DELETE("/users/:id/sessions", authHandler.RevokeUserSessions)
+
+		// Gift cards and store credit
+		admin.POST("/gift-cards", giftCardHandler.IssueGiftCard)
+		admin.POST("/gift-cards/:code/redeem", giftCardHandler.RedeemGiftCard)
+		admin.POST("/users/:id/store-credit", giftCardHandler.GrantStoreCredit)
+
+		// B2B price lists
+		priceLists := admin.Group("/price-lists")
+		{
+			priceLists.POST("", priceListHandler.CreatePriceList)
+			priceLists.GET("", priceListHandler.ListPriceLists)
+			priceLists.GET("/:id", priceListHandler.GetPriceList)
+			priceLists.PUT("/:id", priceListHandler.UpdatePriceList)
+			priceLists.DELETE("/:id", priceListHandler.DeletePriceList)
+			priceLists.PUT("/:id/products/:productId", priceListHandler.SetProductPrices)
+		}
+		admin.PUT("/users/:id/price-list", priceListHandler.AssignPriceList)
+		admin.PUT("/users/:id/api-key", webhookHandler.LinkAPIKey)
+
+		// Pricing rules
+		pricingRules := admin.Group("/pricing-rules")
+		{
+			pricingRules.GET("", pricingRuleHandler.ListPricingRules)
+			pricingRules.POST("", pricingRuleHandler.CreatePricingRule)
+			pricingRules.POST("/simulate", pricingRuleHandler.SimulatePricing)
+			pricingRules.GET("/:id", pricingRuleHandler.GetPricingRule)
+			pricingRules.PUT("/:id", pricingRuleHandler.UpdatePricingRule)
+			pricingRules.DELETE("/:id", pricingRuleHandler.DeletePricingRule)
+			pricingRules.GET("/:id/applications", pricingRuleHandler.ListPricingRuleApplications)
+		}
+
+		// Promotions
+		promotions := admin.Group("/promotions")
+		{
+			promotions.GET("", promotionHandler.ListPromotions)
+			promotions.POST("", promotionHandler.CreatePromotion)
+			promotions.GET("/:id", promotionHandler.GetPromotion)
+			promotions.PUT("/:id", promotionHandler.UpdatePromotion)
+			promotions.DELETE("/:id", promotionHandler.DeletePromotion)
+		}
+
+		// A/B experiments
+		adminExperiments := admin.Group("/experiments")
+		{
+			adminExperiments.GET("", experimentHandler.ListExperiments)
+			adminExperiments.POST("", experimentHandler.CreateExperiment)
+			adminExperiments.GET("/:id", experimentHandler.GetExperiment)
+			adminExperiments.PUT("/:id", experimentHandler.UpdateExperiment)
+			adminExperiments.DELETE("/:id", experimentHandler.DeleteExperiment)
+			adminExperiments.POST("/:id/start", experimentHandler.StartExperiment)
+			adminExperiments.POST("/:id/stop", experimentHandler.StopExperiment)
+			adminExperiments.GET("/:id/results", experimentHandler.GetExperimentResults)
+		}
+
+		// Quotes
+		admin.GET("/quotes", quoteHandler.ListQuotes)
+		admin.GET("/quotes/:id", quoteHandler.GetQuote)
+		admin.POST("/quotes/:id/respond", quoteHandler.RespondToQuote)
+		admin.POST("/quotes/:id/shipments", orderHandler.AddShipment)
+		admin.GET("/quotes/:id/invoice", orderHandler.GetInvoice)
+		admin.GET("/quotes/:id/packing-slip", orderHandler.GetPackingSlip)
+		admin.GET("/shipments/:id/packing-slip", orderHandler.GetShipmentPackingSlip)
+		admin.POST("/pick-lists", orderHandler.CreatePickList)
+
+		// Suppliers and purchase orders
+		suppliers := admin.Group("/suppliers")
+		{
+			suppliers.POST("", purchasingHandler.CreateSupplier)
+			suppliers.GET("", purchasingHandler.ListSuppliers)
+			suppliers.GET("/:id", purchasingHandler.GetSupplier)
+			suppliers.PUT("/:id", purchasingHandler.UpdateSupplier)
+			suppliers.DELETE("/:id", purchasingHandler.DeleteSupplier)
+		}
+		purchaseOrders := admin.Group("/purchase-orders")
+		{
+			purchaseOrders.POST("", purchasingHandler.CreatePurchaseOrder)
+			purchaseOrders.GET("", purchasingHandler.ListPurchaseOrders)
+			purchaseOrders.GET("/:id", purchasingHandler.GetPurchaseOrder)
+			purchaseOrders.POST("/:id/receive", purchasingHandler.ReceivePurchaseOrder)
+			purchaseOrders.POST("/:id/cancel", purchasingHandler.CancelPurchaseOrder)
+		}
+
+		// Customer segments
+		segments := admin.Group("/segments")
+		{
+			segments.POST("", segmentHandler.CreateSegment)
+			segments.GET("", segmentHandler.ListSegments)
+			segments.GET("/:id", segmentHandler.GetSegment)
+			segments.PUT("/:id", segmentHandler.UpdateSegment)
+			segments.DELETE("/:id", segmentHandler.DeleteSegment)
+			segments.GET("/:id/members", segmentHandler.ListSegmentMembers)
+			segments.POST("/:id/notify", segmentHandler.NotifySegment)
+		}
+
+		// Scoped test tokens
+		admin.POST("/test-tokens", authHandler.CreateTestToken)
+
+		// Rate limit quotas
+		admin.GET("/rate-limits/:principal", rateLimitHandler.GetQuota)
+		admin.DELETE("/rate-limits/:principal", rateLimitHandler.ResetQuota)
+
+		// Analytics dashboards
+		analytics := admin.Group("/analytics")
+		{
+			analytics.GET("/reviews", analyticsHandler.GetReviewAnalytics)
+			analytics.GET("/categories", analyticsHandler.GetCategoryAnalytics)
+			analytics.GET("/sales", analyticsHandler.GetSalesAnalytics)
+			analytics.GET("/conversion", analyticsHandler.GetConversionAnalytics)
+			analytics.GET("/activity", analyticsHandler.GetActivity)
+		}
+
+		// Predefined reports
+		admin.GET("/reports", reportHandler.ListReports)
+		admin.GET("/reports/:name", reportHandler.RunReport)
+
+		// Payment reconciliation
+		admin.GET("/finance/reconciliation", financeHandler.GetReconciliation)
+
+		// Data retention
+		admin.GET("/retention", retentionHandler.GetPolicies)
+
+		// Data integrity
+		admin.GET("/integrity", integrityHandler.CheckIntegrity)
+		admin.POST("/integrity/repair", integrityHandler.RepairIntegrity)
+
+		// Background rebuilds of derived data
+		admin.POST("/rebuilds", rebuildHandler.StartRebuild)
+		admin.GET("/rebuilds", rebuildHandler.ListRebuilds)
+		admin.GET("/rebuilds/:id", rebuildHandler.GetRebuild)
+
+		// Legacy system connectors
+		connectors := admin.Group("/connectors")
+		{
+			connectors.GET("", connectorHandler.ListConnectors)
+			connectors.GET("/:name/runs", connectorHandler.ListRuns)
+			connectors.POST("/:name/sync", connectorHandler.SyncConnector)
+		}
+
+		// Transactional outbox
+		admin.GET("/outbox", outboxHandler.ListEvents)
+		admin.POST("/outbox/reprocess", outboxHandler.ReprocessEvents)
+
+		// Competitor prices for pricing teams
+		admin.GET("/products/:id/market-prices", marketPriceHandler.GetMarketPrices)
+
+		// Duplicate products
+		admin.GET("/products/duplicates", duplicateHandler.ListDuplicates)
+		admin.POST("/products/:id/merge", duplicateHandler.MergeProducts)
+
+		// Availability slots and bookings of bookable products
+		admin.POST("/products/:id/slots", bookingHandler.CreateSlots)
+		admin.DELETE("/slots/:id", bookingHandler.DeleteSlot)
+		admin.GET("/bookings", bookingHandler.ListBookings)
+		admin.POST("/bookings/:id/cancel", bookingHandler.CancelBooking)
+
+		// Catalog changes for incremental syncs
+		admin.GET("/catalog/diff", catalogHandler.GetDiff)
+
+		// Sagas
+		sagas := admin.Group("/sagas")
+		{
+			sagas.GET("", sagaHandler.ListSagas)
+			sagas.GET("/:id", sagaHandler.GetSaga)
+			sagas.POST("/:id/retry", sagaHandler.RetrySaga)
+		}
+
+		// Static storefront bundle
+		admin.POST("/storefront/export", storefrontHandler.ExportBundle)
+
+		// Featured product curation
+		featured := admin.Group("/featured-products")
+		{
+			featured.GET("", featuredHandler.ListFeaturedEntries)
+			featured.POST("", featuredHandler.CreateFeaturedEntry)
+			featured.PUT("/:id", featuredHandler.UpdateFeaturedEntry)
+			featured.DELETE("/:id", featuredHandler.DeleteFeaturedEntry)
+		}
+
+		// Review imports from other store platforms
+		admin.POST("/reviews/import", reviewImportHandler.ImportReviews)
+
+		// Content blocks
+		contentBlocks := admin.Group("/content-blocks")
+		{
+			contentBlocks.GET("", contentHandler.ListContentBlocks)
+			contentBlocks.POST("", contentHandler.CreateContentBlock)
+			contentBlocks.GET("/:id", contentHandler.GetContentBlock)
+			contentBlocks.PUT("/:id", contentHandler.UpdateContentBlock)
+			contentBlocks.DELETE("/:id", contentHandler.DeleteContentBlock)
+		}
+
+		// Custom fields of the registration and checkout forms
+		customFields := admin.Group("/custom-fields")
+		{
+			customFields.GET("", customFieldHandler.ListCustomFields)
+			customFields.POST("", customFieldHandler.CreateCustomField)
+			customFields.PUT("/:id", customFieldHandler.UpdateCustomField)
+			customFields.DELETE("/:id", customFieldHandler.DeleteCustomField)
+		}
+
+		// Store settings
+		settings := admin.Group("/settings")
+		{
+			settings.GET("", settingHandler.ListSettings)
+			settings.GET("/:key", settingHandler.GetSetting)
+			settings.PUT("/:key", settingHandler.UpdateSetting)
+			settings.DELETE("/:key", settingHandler.ResetSetting)
+		}
+
+		// Suspicious activity
+		security := admin.Group("/security")
+		{
+			security.GET("/alerts", securityHandler.ListAlerts)
+			security.GET("/blocks", securityHandler.ListBlocks)
+			security.DELETE("/blocks/:ip", securityHandler.Unblock)
+		}
+
+		// Runtime diagnostics of the instance serving the request
+		admin.GET("/selftest", diagnosticsHandler.SelfTest)
+		admin.GET("/config", configHandler.GetConfig)
+		admin.POST("/config/reload", configHandler.ReloadConfig)
+		debug := admin.Group("/debug")
+		{
+			debug.GET("/pprof/*name", diagnosticsHandler.Profile)
+			debug.GET("/goroutines", diagnosticsHandler.Goroutines)
+			debug.GET("/vars", diagnosticsHandler.Vars)
+			debug.GET("/verbose-logging", diagnosticsHandler.GetVerboseLogging)
+			debug.PUT("/verbose-logging", diagnosticsHandler.SetVerboseLogging)
+		}
+	}
 }
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/reqstats"
+)
+
+// Defaults of the API activity dashboard
+const (
+	defaultActivityHours    = 24
+	defaultActivityInterval = time.Hour
+	defaultActivityLimit    = 20
+	maxActivityPeriods      = 1000
+)
+
+// ErrInvalidActivityInterval is returned for a series interval that is not a
+// multiple of the bucket requests are recorded in, or yields too many periods
+var ErrInvalidActivityInterval = errors.New("invalid interval")
+
+// ActivityService stores the request statistics the server records and
+// aggregates them into the API activity dashboard
+type ActivityService struct {
+	statRepo *repositories.RequestStatRepository
+	bucket   time.Duration
+}
+
+// NewActivityService creates a new ActivityService instance for statistics
+// recorded in buckets of the given size
+func NewActivityService(bucket time.Duration) *ActivityService {
+	return &ActivityService{
+		statRepo: repositories.NewRequestStatRepository(database.DB),
+		bucket:   bucket,
+	}
+}
+
+// Start stores the buckets of the recorder as they close. The returned
+// function stops it after storing what was recorded so far.
+func (s *ActivityService) Start(recorder *reqstats.Recorder) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(recorder.Bucket())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				s.flush(recorder.Drain(time.Time{}))
+				return
+			case <-ticker.C:
+				s.flush(recorder.Drain(time.Now().UTC().Truncate(recorder.Bucket())))
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// flush stores drained statistics. They are dropped when the database is
+// unavailable, since holding them would grow memory during an outage.
+func (s *ActivityService) flush(stats []reqstats.Stat) {
+	rows := make([]models.RequestStat, len(stats))
+	for i, stat := range stats {
+		rows[i] = models.RequestStat{
+			BucketStart:    stat.Bucket,
+			Method:         stat.Method,
+			Route:          stat.Route,
+			Status:         stat.Status,
+			Count:          stat.Count,
+			TotalMs:        stat.TotalMs,
+			MaxMs:          stat.MaxMs,
+			LatencyBuckets: stat.Histogram,
+		}
+	}
+	if err := s.statRepo.CreateBatch(rows); err != nil {
+		log.Printf("Warning: failed to store %d request statistics: %v", len(rows), err)
+	}
+}
+
+// activityTotals accumulates the statistics of a period or endpoint
+type activityTotals struct {
+	requests     int64
+	clientErrors int64
+	serverErrors int64
+	totalMs      float64
+	maxMs        float64
+	histogram    []int64
+	statusCodes  map[string]int64
+}
+
+func newActivityTotals() *activityTotals {
+	return &activityTotals{
+		histogram:   make([]int64, len(reqstats.LatencyBounds)+1),
+		statusCodes: make(map[string]int64),
+	}
+}
+
+func (t *activityTotals) add(stat *models.RequestStat) {
+	t.requests += stat.Count
+	switch {
+	case stat.Status >= 500:
+		t.serverErrors += stat.Count
+	case stat.Status >= 400:
+		t.clientErrors += stat.Count
+	}
+	t.totalMs += stat.TotalMs
+	t.maxMs = math.Max(t.maxMs, stat.MaxMs)
+	reqstats.Merge(t.histogram, stat.LatencyBuckets)
+	t.statusCodes[strconv.Itoa(stat.Status)] += stat.Count
+}
+
+func (t *activityTotals) percentiles() dto.LatencyPercentiles {
+	return dto.LatencyPercentiles{
+		P50: roundLatency(reqstats.Quantile(t.histogram, t.maxMs, 0.50)),
+		P95: roundLatency(reqstats.Quantile(t.histogram, t.maxMs, 0.95)),
+		P99: roundLatency(reqstats.Quantile(t.histogram, t.maxMs, 0.99)),
+	}
+}
+
+// GetActivity returns request volumes, status codes and latency percentiles
+// over the window, as a series of periods aligned to the interval in UTC and
+// per endpoint. Requests still held in memory by the servers are not included.
+func (s *ActivityService) GetActivity(req dto.ActivityRequest) (*dto.ActivityResponse, error) {
+	if req.Hours == 0 {
+		req.Hours = defaultActivityHours
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultActivityLimit
+	}
+	interval := defaultActivityInterval
+	if req.Interval != "" {
+		parsed, err := time.ParseDuration(req.Interval)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("%w: %q is not a duration", ErrInvalidActivityInterval, req.Interval)
+		}
+		interval = parsed
+	}
+	if s.bucket > 0 && interval%s.bucket != 0 {
+		return nil, fmt.Errorf("%w: must be a multiple of %v", ErrInvalidActivityInterval, s.bucket)
+	}
+
+	to := time.Now().UTC()
+	from := to.Add(-time.Duration(req.Hours) * time.Hour).Truncate(interval)
+	periods := int(to.Sub(from)/interval) + 1
+	if periods > maxActivityPeriods {
+		return nil, fmt.Errorf("%w: the window would have more than %d periods", ErrInvalidActivityInterval, maxActivityPeriods)
+	}
+
+	overall := newActivityTotals()
+	series := make([]*activityTotals, periods)
+	for i := range series {
+		series[i] = newActivityTotals()
+	}
+	type endpointKey struct{ method, route string }
+	endpoints := make(map[endpointKey]*activityTotals)
+	volumes := make(map[endpointKey][]int64)
+
+	err := s.statRepo.Each(from, to, req.Method, req.Route, 1000, func(stat models.RequestStat) {
+		period := int(stat.BucketStart.Sub(from) / interval)
+		if period < 0 || period >= periods {
+			return
+		}
+		key := endpointKey{stat.Method, stat.Route}
+		if endpoints[key] == nil {
+			endpoints[key] = newActivityTotals()
+			volumes[key] = make([]int64, periods)
+		}
+		overall.add(&stat)
+		series[period].add(&stat)
+		endpoints[key].add(&stat)
+		volumes[key][period] += stat.Count
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ActivityResponse{
+		From:               dto.NewTime(from),
+		To:                 dto.NewTime(to),
+		Interval:           interval.String(),
+		Requests:           overall.requests,
+		StatusCodes:        overall.statusCodes,
+		LatencyPercentiles: overall.percentiles(),
+		Series:             make([]dto.ActivityPoint, periods),
+		Endpoints:          make([]dto.EndpointActivity, 0, len(endpoints)),
+	}
+	for i, totals := range series {
+		response.Series[i] = dto.ActivityPoint{
+			Period:             dto.NewTime(from.Add(time.Duration(i) * interval)),
+			Requests:           totals.requests,
+			ClientErrors:       totals.clientErrors,
+			ServerErrors:       totals.serverErrors,
+			LatencyPercentiles: totals.percentiles(),
+		}
+	}
+	for key, totals := range endpoints {
+		response.Endpoints = append(response.Endpoints, dto.EndpointActivity{
+			Method:             key.method,
+			Route:              key.route,
+			Requests:           totals.requests,
+			StatusCodes:        totals.statusCodes,
+			ClientErrors:       totals.clientErrors,
+			ServerErrors:       totals.serverErrors,
+			AverageMs:          roundLatency(totals.totalMs / float64(totals.requests)),
+			MaxMs:              roundLatency(totals.maxMs),
+			LatencyPercentiles: totals.percentiles(),
+			Volume:             volumes[key],
+		})
+	}
+	sort.Slice(response.Endpoints, func(i, j int) bool {
+		a, b := response.Endpoints[i], response.Endpoints[j]
+		if a.Requests != b.Requests {
+			return a.Requests > b.Requests
+		}
+		return a.Method+" "+a.Route < b.Method+" "+b.Route
+	})
+	if len(response.Endpoints) > req.Limit {
+		response.Endpoints = response.Endpoints[:req.Limit]
+	}
+
+	return response, nil
+}
+
+// roundLatency rounds a latency in milliseconds to two decimals
+func roundLatency(ms float64) float64 {
+	return math.Round(ms*100) / 100
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"product-management/internal/dto"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/reqctx"
+	"time"
+)
+
+// Defaults of the review analytics dashboard
+const (
+	defaultAnalyticsDays       = 30
+	defaultAnalyticsInterval   = "day"
+	defaultAnalyticsLimit      = 10
+	defaultAnalyticsMinReviews = 3
+)
+
+// AnalyticsService computes the admin analytics dashboards. In materialized
+// mode, review and sales figures come from the reporting views rather than the
+// live tables, as of their last refresh and by UTC day.
+type AnalyticsService struct {
+	materialized    bool
+	reviewRepo      *repositories.ReviewRepository
+	categoryRepo    *repositories.CategoryRepository
+	reportingRepo   *repositories.ReportingRepository
+	productViewRepo *repositories.ProductViewRepository
+}
+
+// NewAnalyticsService creates a new AnalyticsService instance
+func NewAnalyticsService(materialized bool) *AnalyticsService {
+	return &AnalyticsService{
+		materialized:    materialized,
+		reviewRepo:      repositories.NewReviewRepository(database.DB),
+		categoryRepo:    repositories.NewCategoryRepository(database.DB),
+		reportingRepo:   repositories.NewReportingRepository(database.DB, materialized),
+		productViewRepo: repositories.NewProductViewRepository(database.DB),
+	}
+}
+
+// GetReviewAnalytics returns review volume and average rating over the window,
+// and the products whose rating dropped the most compared with the window before it.
+// Volume periods are aligned to midnight in the request's time zone, or to UTC
+// midnight in materialized mode, whose windows also start at UTC midnight.
+func (s *AnalyticsService) GetReviewAnalytics(rc reqctx.Context, req dto.ReviewAnalyticsRequest) (*dto.ReviewAnalyticsResponse, error) {
+	loc := rc.Location
+	if s.materialized {
+		loc = time.UTC
+	}
+	if req.Days == 0 {
+		req.Days = defaultAnalyticsDays
+	}
+	if req.Interval == "" {
+		req.Interval = defaultAnalyticsInterval
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultAnalyticsLimit
+	}
+	if req.MinReviews == 0 {
+		req.MinReviews = defaultAnalyticsMinReviews
+	}
+
+	now := time.Now().UTC()
+	window := time.Duration(req.Days) * 24 * time.Hour
+	currentStart := now.Add(-window)
+	previousStart := currentStart.Add(-window)
+
+	var volume []repositories.ReviewVolume
+	var drops []repositories.RatingDrop
+	var err error
+	if s.materialized {
+		volume, err = s.reportingRepo.ReviewVolume(currentStart, req.Interval)
+	} else {
+		volume, err = s.reviewRepo.GetReviewVolume(currentStart, req.Interval, loc)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s.materialized {
+		drops, err = s.reportingRepo.RatingDrops(previousStart, currentStart, req.MinReviews, req.Limit)
+	} else {
+		drops, err = s.reviewRepo.GetRatingDrops(previousStart, currentStart, req.MinReviews, req.Limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ReviewAnalyticsResponse{
+		From:             dto.NewTime(currentStart),
+		To:               dto.NewTime(now),
+		Days:             req.Days,
+		Interval:         req.Interval,
+		Timezone:         loc.String(),
+		Volume:           make([]dto.ReviewVolumePoint, len(volume)),
+		DroppingProducts: make([]dto.RatingDropResponse, len(drops)),
+	}
+	for i, point := range volume {
+		response.Volume[i] = dto.ReviewVolumePoint{
+			Period:        dto.NewTime(point.Period),
+			ReviewCount:   point.ReviewCount,
+			AverageRating: roundRating(point.AverageRating),
+		}
+	}
+	for i, drop := range drops {
+		response.DroppingProducts[i] = dto.RatingDropResponse{
+			ProductID:       drop.ProductID,
+			ProductName:     drop.ProductName,
+			PreviousAverage: roundRating(drop.PreviousAverage),
+			CurrentAverage:  roundRating(drop.CurrentAverage),
+			Drop:            roundRating(drop.PreviousAverage - drop.CurrentAverage),
+			PreviousCount:   drop.PreviousCount,
+			CurrentCount:    drop.CurrentCount,
+		}
+	}
+
+	return response, nil
+}
+
+// GetCategoryAnalytics returns the size and wishlist activity of every category
+// between two dates, inclusive, where days start at midnight in the request's
+// time zone. The range defaults to the last 30 days.
+func (s *AnalyticsService) GetCategoryAnalytics(rc reqctx.Context, req dto.CategoryAnalyticsRequest) (*dto.CategoryAnalyticsResponse, error) {
+	loc := rc.Location
+	now := time.Now().In(loc)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if req.To != "" {
+		parsed, err := time.ParseInLocation(time.DateOnly, req.To, loc)
+		if err != nil {
+			return nil, err
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, 1-defaultAnalyticsDays)
+	if req.From != "" {
+		parsed, err := time.ParseInLocation(time.DateOnly, req.From, loc)
+		if err != nil {
+			return nil, err
+		}
+		from = parsed
+	}
+	if from.After(to) {
+		return nil, errors.New("from must not be after to")
+	}
+
+	items, err := s.categoryRepo.GetCategoryAnalytics(from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		if items[i].ProductCount > 0 {
+			items[i].WishlistAddsPerProduct = roundRating(float64(items[i].WishlistAdds) / float64(items[i].ProductCount))
+		}
+	}
+
+	return &dto.CategoryAnalyticsResponse{
+		From:       from.Format(time.DateOnly),
+		To:         to.Format(time.DateOnly),
+		Timezone:   loc.String(),
+		Categories: items,
+	}, nil
+}
+
+// GetSalesAnalytics returns the accepted quotes, units and revenue of every UTC
+// day between two dates, inclusive, and per category. The range defaults to
+// the last 30 days.
+func (s *AnalyticsService) GetSalesAnalytics(req dto.SalesAnalyticsRequest) (*dto.SalesAnalyticsResponse, error) {
+	from, to, err := utcDayRange(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := s.reportingRepo.SalesByDay(from, to)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.reportingRepo.CategoryRevenue(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.SalesAnalyticsResponse{
+		From:       from.Format(time.DateOnly),
+		To:         to.Format(time.DateOnly),
+		Source:     "live",
+		Days:       make([]dto.SalesDayPoint, len(days)),
+		Categories: make([]dto.CategoryRevenueItem, len(categories)),
+	}
+	if s.materialized {
+		response.Source = "materialized"
+	}
+	for i, day := range days {
+		response.Days[i] = dto.SalesDayPoint{
+			Day:            dto.NewTime(day.Day),
+			AcceptedQuotes: day.AcceptedQuotes,
+			Units:          day.Units,
+			Revenue:        day.Revenue,
+		}
+		response.AcceptedQuotes += day.AcceptedQuotes
+		response.Units += day.Units
+		response.Revenue += day.Revenue
+	}
+	response.Revenue = math.Round(response.Revenue*100) / 100
+	for i, category := range categories {
+		response.Categories[i] = dto.CategoryRevenueItem{
+			CategoryID: category.CategoryID,
+			Name:       category.Name,
+			Units:      category.Units,
+			Revenue:    category.Revenue,
+		}
+	}
+
+	return response, nil
+}
+
+// GetConversionAnalytics returns the products viewed the most between two UTC
+// days, inclusive, with their wishlist adds and orders over the same days. The
+// range defaults to the last 30 days.
+func (s *AnalyticsService) GetConversionAnalytics(req dto.ConversionAnalyticsRequest) (*dto.ConversionAnalyticsResponse, error) {
+	from, to, err := utcDayRange(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultAnalyticsLimit
+	}
+
+	conversions, err := s.productViewRepo.Conversion(from, to.AddDate(0, 0, 1), req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ConversionAnalyticsResponse{
+		From:     from.Format(time.DateOnly),
+		To:       to.Format(time.DateOnly),
+		Products: make([]dto.ProductConversionItem, len(conversions)),
+	}
+	for i, conversion := range conversions {
+		response.Products[i] = dto.ProductConversionItem{
+			ProductID:      conversion.ProductID,
+			ProductName:    conversion.ProductName,
+			Views:          conversion.Views,
+			UniqueViewers:  conversion.Viewers,
+			WishlistAdds:   conversion.WishlistAdds,
+			Orders:         conversion.Orders,
+			Units:          conversion.Units,
+			WishlistRate:   math.Round(float64(conversion.WishlistAdds)/float64(conversion.Views)*10000) / 10000,
+			ConversionRate: math.Round(float64(conversion.Orders)/float64(conversion.Views)*10000) / 10000,
+		}
+	}
+
+	return response, nil
+}
+
+// utcDayRange parses the first and last UTC days of a range, which defaults to
+// the last 30 days
+func utcDayRange(fromDay, toDay string) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if toDay != "" {
+		parsed, err := time.Parse(time.DateOnly, toDay)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, 1-defaultAnalyticsDays)
+	if fromDay != "" {
+		parsed, err := time.Parse(time.DateOnly, fromDay)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = parsed
+	}
+	if from.After(to) {
+		return time.Time{}, time.Time{}, errors.New("from must not be after to")
+	}
+	return from, to, nil
+}
+
+// roundRating rounds an average rating to two decimals
+func roundRating(rating float64) float64 {
+	return math.Round(rating*100) / 100
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"encoding/json"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/geoip"
+)
+
+// AuditService records sensitive actions in the audit log
+type AuditService struct {
+	auditRepo *repositories.AuditLogRepository
+}
+
+// NewAuditService creates a new AuditService instance
+func NewAuditService() *AuditService {
+	return &AuditService{
+		auditRepo: repositories.NewAuditLogRepository(database.DB),
+	}
+}
+
+// Record stores an audit log entry for an action, with its details encoded as
+// JSON and the location the request came from
+func (s *AuditService) Record(actorID uint, origin geoip.Location, action models.AuditAction, details interface{}) error {
+	encoded, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.Create(&models.AuditLog{
+		ActorID: actorID,
+		Action:  action,
+		Details: string(encoded),
+		Country: origin.Country,
+		City:    origin.City,
+	})
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"log"
 	"time"
@@ -8,20 +10,41 @@ import (
 	"product-management/internal/dto"
 	"product-management/internal/models"
 	"product-management/internal/repositories"
+	"product-management/pkg/cache"
 	"product-management/pkg/database"
+	"product-management/pkg/reqctx"
 	"product-management/pkg/utils"
 
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
+var (
+	// ErrCannotAnonymizeAdmin is returned when anonymizing an admin, who must be demoted first
+	ErrCannotAnonymizeAdmin = errors.New("cannot anonymize admin user")
+	// ErrUserAlreadyAnonymized is returned when a user's personal data was already erased
+	ErrUserAlreadyAnonymized = errors.New("user is already anonymized")
+	// ErrInvalidRefreshToken is returned when a refresh token is malformed,
+	// expired, already exchanged or issued before the user's tokens were revoked
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+)
+
+// refreshTokenTTL is how long a refresh token can be exchanged
+const refreshTokenTTL = 7 * 24 * time.Hour
+
+// anonymizedEmailDomain is reserved by RFC 2606, so mail to anonymized users is never delivered
+const anonymizedEmailDomain = "anonymized.invalid"
+
 type AuthService struct {
-	userRepo *repositories.UserRepository
+	userRepo         *repositories.UserRepository
+	refreshTokenRepo *repositories.RefreshTokenRepository
 }
 
 func NewAuthService() *AuthService {
 	return &AuthService{
-		userRepo: repositories.NewUserRepository(database.DB),
+		userRepo:         repositories.NewUserRepository(database.DB),
+		refreshTokenRepo: repositories.NewRefreshTokenRepository(database.DB),
 	}
 }
 
@@ -57,30 +80,210 @@ func (s *AuthService) Login(req dto.LoginRequest) (*models.User, string, string,
 	return user, accessToken, refreshToken, nil
 }
 
+// Refresh exchanges a refresh token for a new access token and a new refresh
+// token. Each refresh token can be exchanged once: exchanging it again means
+// it leaked, so the user's tokens are all revoked and they must log in again.
+func (s *AuthService) Refresh(refreshToken string) (*models.User, string, string, error) {
+	token, err := s.ValidateRefreshToken(refreshToken)
+	if err != nil || !token.Valid {
+		return nil, "", "", ErrInvalidRefreshToken
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, "", "", ErrInvalidRefreshToken
+	}
+	// Refresh tokens issued before they were recorded carry no jti
+	tokenID, okID := claims["jti"].(string)
+	userIDFloat, okUser := claims["user_id"].(float64)
+	tokenVersion, okVersion := claims["token_version"].(float64)
+	if !okID || !okUser || !okVersion {
+		return nil, "", "", ErrInvalidRefreshToken
+	}
+
+	stored, err := s.refreshTokenRepo.GetByTokenID(tokenID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, "", "", ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, "", "", err
+	}
+	if stored.UserID != uint(userIDFloat) {
+		return nil, "", "", ErrInvalidRefreshToken
+	}
+	exchanged, err := s.refreshTokenRepo.MarkUsed(stored.ID, time.Now())
+	if err != nil {
+		return nil, "", "", err
+	}
+	if !exchanged {
+		log.Printf("Warning: refresh token %s of user %d was exchanged twice; revoking every token of the user", tokenID, stored.UserID)
+		if err := s.revokeTokens(stored.UserID); err != nil {
+			return nil, "", "", err
+		}
+		return nil, "", "", ErrInvalidRefreshToken
+	}
+
+	// Deleted users and users whose tokens were revoked since must log in again
+	user, err := s.userRepo.GetByID(stored.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, "", "", ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, "", "", err
+	}
+	if user.TokenVersion != uint(tokenVersion) {
+		return nil, "", "", ErrInvalidRefreshToken
+	}
+
+	accessToken, err := s.generateAccessToken(user)
+	if err != nil {
+		return nil, "", "", err
+	}
+	newRefreshToken, err := s.generateRefreshToken(user)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return user, accessToken, newRefreshToken, nil
+}
+
+// Logout revokes the access token a request was made with, identified by its
+// jti claim, and the refresh token it was issued with, when given. With
+// allSessions, every access and refresh token of the user is revoked instead.
+func (s *AuthService) Logout(userID uint, tokenID string, expiresAt time.Time, refreshToken string, allSessions bool) error {
+	if allSessions {
+		return s.revokeTokens(userID)
+	}
+
+	// Access tokens issued before they carried a jti can only be revoked with
+	// every other session
+	if tokenID != "" {
+		if err := s.refreshTokenRepo.Revoke(&models.RevokedToken{TokenID: tokenID, UserID: userID, ExpiresAt: expiresAt}); err != nil {
+			return err
+		}
+		cache.Store.Delete(cache.RevokedTokenKey(tokenID))
+	}
+
+	// A refresh token that no longer validates can't be exchanged anyway
+	if refreshToken == "" {
+		return nil
+	}
+	token, err := s.ValidateRefreshToken(refreshToken)
+	if err != nil || !token.Valid {
+		return nil
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil
+	}
+	if refreshID, ok := claims["jti"].(string); ok {
+		return s.refreshTokenRepo.DeleteByTokenID(userID, refreshID)
+	}
+	return nil
+}
+
+// RevokeSessions revokes every access token and refresh token of a user, so
+// they must log in again everywhere
+func (s *AuthService) RevokeSessions(userID uint) error {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return err
+	}
+	return s.revokeTokens(userID)
+}
+
+// revokeTokens revokes every access token and refresh token of a user
+func (s *AuthService) revokeTokens(userID uint) error {
+	if err := s.userRepo.UpdateFields(userID, map[string]interface{}{
+		"token_version": gorm.Expr("token_version + 1"),
+	}); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.TokenVersionKey(userID))
+	return s.refreshTokenRepo.DeleteByUser(userID)
+}
+
 // generateAccessToken creates a new JWT access token
 func (s *AuthService) generateAccessToken(user *models.User) (string, error) {
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
 	claims := jwt.MapClaims{
-		"user_id": user.ID,
-		"email":   user.Email,
-		"role":    user.Role,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(), // 24 hours
+		"user_id":       user.ID,
+		"role":          user.Role,
+		"token_version": user.TokenVersion,
+		"jti":           tokenID,
+		"exp":           time.Now().Add(time.Hour * 24).Unix(), // 24 hours
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(utils.GetEnv("JWT_SECRET", "your-secret-key")))
 }
 
-// generateRefreshToken creates a new JWT refresh token
+// GenerateTestToken mints a short-lived access token restricted to the given
+// scopes. It carries the minting user's ID with the requested role, so it can be
+// used for testing without handing out real credentials.
+func (s *AuthService) GenerateTestToken(userID uint, role models.Role, scopes []string, ttl time.Duration) (string, time.Time, error) {
+	tokenVersion, err := s.userRepo.GetTokenVersion(userID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	claims := jwt.MapClaims{
+		"user_id":       userID,
+		"role":          role,
+		"token_version": tokenVersion,
+		"jti":           tokenID,
+		"scopes":        scopes,
+		"test":          true,
+		"exp":           expiresAt.Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(utils.GetEnv("JWT_SECRET", "your-secret-key")))
+	return signed, expiresAt, err
+}
+
+// generateRefreshToken creates a new JWT refresh token and records it, so it
+// can be exchanged once
 func (s *AuthService) generateRefreshToken(user *models.User) (string, error) {
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+	stored := &models.RefreshToken{
+		TokenID:   tokenID,
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	}
+	if err := s.refreshTokenRepo.Create(stored); err != nil {
+		return "", err
+	}
+
 	claims := jwt.MapClaims{
-		"user_id": user.ID,
-		"exp":     time.Now().Add(time.Hour * 24 * 7).Unix(), // 7 days
+		"user_id":       user.ID,
+		"token_version": user.TokenVersion,
+		"jti":           stored.TokenID,
+		"exp":           stored.ExpiresAt.Unix(), // 7 days
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(utils.GetEnv("JWT_REFRESH_SECRET", "your-refresh-secret-key")))
 }
 
+// newTokenID returns a random jti claim identifying a token
+func newTokenID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
 // ValidateToken validates a JWT token
 func (s *AuthService) ValidateToken(tokenString string) (*jwt.Token, error) {
 	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -124,8 +327,14 @@ func (s *AuthService) UpdatePassword(userID uint, req dto.UpdatePasswordRequest)
 	// 	return err
 	// }
 
+	// Revoke the tokens issued with the old password
 	user.Password = string(req.NewPassword)
-	return s.userRepo.Update(user)
+	user.TokenVersion++
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.TokenVersionKey(user.ID))
+	return s.refreshTokenRepo.DeleteByUser(user.ID)
 }
 
 // UpdateUser updates a user's information
@@ -145,11 +354,28 @@ func (s *AuthService) UpdateUser(userID uint, req dto.UpdateUserRequest) error {
 	if req.FullName != "" {
 		updateFields["full_name"] = req.FullName
 	}
+	preferences, err := reqctx.Default.Validate(reqctx.Preferences{Locale: req.Locale, Currency: req.Currency, Timezone: req.Timezone})
+	if err != nil {
+		return err
+	}
+	if preferences.Locale != "" {
+		updateFields["locale"] = preferences.Locale
+	}
+	if preferences.Currency != "" {
+		updateFields["currency"] = preferences.Currency
+	}
+	if preferences.Timezone != "" {
+		updateFields["timezone"] = preferences.Timezone
+	}
 
 	if len(updateFields) == 0 {
 		return nil
 	}
-	return s.userRepo.UpdateFields(user.ID, updateFields)
+	if err := s.userRepo.UpdateFields(user.ID, updateFields); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.UserPreferencesKey(user.ID))
+	return nil
 }
 
 // CheckUserNameExists checks if a username exists
@@ -170,18 +396,26 @@ func (s *AuthService) CheckEmailExists(email string) (bool, error) {
 	return user != nil, nil
 }
 
-// UpdateUserRole updates a user's role
-func (s *AuthService) UpdateUserRole(userID uint, role models.Role) error {
+// UpdateUserRole updates a user's role and returns the role they had before
+func (s *AuthService) UpdateUserRole(userID uint, role models.Role) (models.Role, error) {
 	// Check if user exists
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	// Update only the role field
-	return s.userRepo.UpdateFields(user.ID, map[string]interface{}{
-		"role": role,
-	})
+	// Update the role and revoke the tokens carrying the old one
+	if err := s.userRepo.UpdateFields(user.ID, map[string]interface{}{
+		"role":          role,
+		"token_version": gorm.Expr("token_version + 1"),
+	}); err != nil {
+		return "", err
+	}
+	cache.Store.Delete(cache.TokenVersionKey(user.ID))
+	if err := s.refreshTokenRepo.DeleteByUser(user.ID); err != nil {
+		return "", err
+	}
+	return user.Role, nil
 }
 
 // DeleteUser performs a soft delete on a user
@@ -199,3 +433,32 @@ func (s *AuthService) DeleteUser(userID uint) error {
 
 	return s.userRepo.Delete(userID)
 }
+
+// AnonymizeUser irreversibly erases a user's personal data and soft-deletes
+// the account. The username and email are replaced with a random pseudonym
+// unrelated to the old values, so the user's reviews and other records keep
+// pointing at the same ID without identifying them. Soft-deleted users can be
+// anonymized too.
+func (s *AuthService) AnonymizeUser(userID uint) (*models.User, error) {
+	user, err := s.userRepo.GetByIDWithDeleted(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.AnonymizedAt != nil {
+		return nil, ErrUserAlreadyAnonymized
+	}
+	if user.Role == models.RoleAdmin {
+		return nil, ErrCannotAnonymizeAdmin
+	}
+
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+	pseudonym := "deleted-" + hex.EncodeToString(suffix)
+	if err := s.userRepo.Anonymize(user, pseudonym, pseudonym+"@"+anonymizedEmailDomain); err != nil {
+		return nil, err
+	}
+	cache.Store.Delete(cache.TokenVersionKey(user.ID))
+	return s.userRepo.GetByIDWithDeleted(userID)
+}
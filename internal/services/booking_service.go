@@ -0,0 +1,200 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/reqctx"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBookingProduct     = errors.New("product not found")
+	ErrProductNotBookable = errors.New("product is not bookable")
+	ErrInvalidSlot        = errors.New("slots must start in the future, end after they start and not overlap each other")
+	ErrSlotNotFound       = errors.New("slot not found")
+	ErrBookingNotFound    = errors.New("booking not found")
+)
+
+// BookingService handles the availability calendars of bookable products,
+// such as rentals and services, and the bookings customers make of them
+type BookingService struct {
+	bookingRepo *repositories.BookingRepository
+	productRepo *repositories.ProductRepository
+}
+
+// NewBookingService creates a new BookingService instance
+func NewBookingService() *BookingService {
+	return &BookingService{
+		bookingRepo: repositories.NewBookingRepository(database.DB),
+		productRepo: repositories.NewProductRepository(database.DB),
+	}
+}
+
+// CreateSlots adds availability slots to a bookable product
+func (s *BookingService) CreateSlots(productID uint, req dto.CreateSlotsRequest) ([]models.AvailabilitySlot, error) {
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, ErrBookingProduct
+	}
+	if !product.Bookable {
+		return nil, ErrProductNotBookable
+	}
+
+	now := time.Now()
+	slots := make([]models.AvailabilitySlot, len(req.Slots))
+	for i, slot := range req.Slots {
+		if !slot.StartsAt.After(now) || !slot.EndsAt.After(slot.StartsAt.Time) {
+			return nil, ErrInvalidSlot
+		}
+		slots[i] = models.AvailabilitySlot{
+			ProductID: productID,
+			StartsAt:  slot.StartsAt.Time,
+			EndsAt:    slot.EndsAt.Time,
+			Capacity:  slot.Capacity,
+		}
+	}
+	slices.SortFunc(slots, func(a, b models.AvailabilitySlot) int {
+		return a.StartsAt.Compare(b.StartsAt)
+	})
+	for i := 1; i < len(slots); i++ {
+		if slots[i].StartsAt.Before(slots[i-1].EndsAt) {
+			return nil, ErrInvalidSlot
+		}
+	}
+
+	if err := s.bookingRepo.CreateSlots(productID, slots); err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
+// DeleteSlot deletes an availability slot nobody has booked
+func (s *BookingService) DeleteSlot(id uint) error {
+	err := s.bookingRepo.DeleteSlot(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrSlotNotFound
+	}
+	return err
+}
+
+// Availability returns the availability calendar of a bookable product for a
+// month, such as 2026-11, or the current one when empty. Months and days are
+// in the request's time zone.
+func (s *BookingService) Availability(rc reqctx.Context, productID uint, country, month string) (*dto.AvailabilityResponse, error) {
+	if err := s.checkBookable(productID, country); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	from := now.In(rc.Location)
+	from = time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, rc.Location)
+	if month != "" {
+		parsed, err := time.ParseInLocation("2006-01", month, rc.Location)
+		if err != nil {
+			return nil, err
+		}
+		from = parsed
+	}
+	to := from.AddDate(0, 1, 0)
+
+	slots, err := s.bookingRepo.ListSlots(productID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &dto.AvailabilityResponse{
+		ProductID: productID,
+		Month:     from.Format("2006-01"),
+		Timezone:  rc.Timezone(),
+	}
+	days := make(map[string]int)
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+		days[date] = len(calendar.Days)
+		calendar.Days = append(calendar.Days, dto.AvailabilityDayResponse{Date: date, Slots: []dto.SlotResponse{}})
+	}
+	for i := range slots {
+		day := &calendar.Days[days[slots[i].StartsAt.In(rc.Location).Format(time.DateOnly)]]
+		slot := mappers.ToSlotResponse(&slots[i], now)
+		day.Slots = append(day.Slots, slot)
+		day.Available += slot.Available
+	}
+	return calendar, nil
+}
+
+// Book reserves units of a slot of a bookable product for a customer
+func (s *BookingService) Book(productID, userID uint, country string, req dto.CreateBookingRequest) (*models.Booking, error) {
+	if err := s.checkBookable(productID, country); err != nil {
+		return nil, err
+	}
+
+	booking := &models.Booking{
+		SlotID:    req.SlotID,
+		ProductID: productID,
+		UserID:    userID,
+		Quantity:  max(req.Quantity, 1),
+		Status:    models.BookingConfirmed,
+	}
+	err := s.bookingRepo.CreateBooking(booking)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSlotNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.bookingRepo.GetBooking(booking.ID, 0)
+}
+
+// GetBooking retrieves a booking. A non-zero userID limits it to that user's bookings.
+func (s *BookingService) GetBooking(id, userID uint) (*models.Booking, error) {
+	booking, err := s.bookingRepo.GetBooking(id, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrBookingNotFound
+	}
+	return booking, err
+}
+
+// ListBookings retrieves a paginated list of bookings, newest first. Non-zero
+// userID and productID limit it to that user's or product's bookings.
+func (s *BookingService) ListBookings(userID, productID uint, status models.BookingStatus, page, limit int) ([]models.Booking, int64, error) {
+	return s.bookingRepo.ListBookings(userID, productID, status, page, limit)
+}
+
+// CancelBooking cancels a booking before its slot starts, freeing its units.
+// A non-zero userID limits it to that user's bookings.
+func (s *BookingService) CancelBooking(id, userID uint) (*models.Booking, error) {
+	err := s.bookingRepo.CancelBooking(id, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrBookingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.bookingRepo.GetBooking(id, userID)
+}
+
+// checkBookable checks that customers in a country can book a product
+func (s *BookingService) checkBookable(productID uint, country string) error {
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return err
+	}
+	if product == nil || product.Status != models.StatusActive || !product.AvailableIn(country) {
+		return ErrBookingProduct
+	}
+	if !product.Bookable {
+		return ErrProductNotBookable
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"product-management/internal/models"
+	"product-management/pkg/cache"
+)
+
+// WarmCache preloads hot data into the cache: all categories and the
+// topProducts highest-rated active products
+func WarmCache(topProducts int) error {
+	if _, err := NewCategoryService().GetAllCategories(); err != nil {
+		return err
+	}
+
+	if topProducts <= 0 {
+		return nil
+	}
+
+	productService := NewProductService()
+	products, _, err := productService.ListProducts(1, topProducts, 0, "", "rating", []string{string(models.StatusActive)}, nil, nil)
+	if err != nil {
+		return err
+	}
+	for i := range products {
+		cache.Store.Set(cache.ProductKey(products[i].ID), &products[i], cache.TTL)
+	}
+
+	return nil
+}
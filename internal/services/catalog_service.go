@@ -0,0 +1,146 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+)
+
+// CatalogService describes how the catalog changed over time, so downstream
+// systems can sync incrementally instead of pulling the whole catalog
+type CatalogService struct {
+	productRepo  *repositories.ProductRepository
+	categoryRepo *repositories.CategoryRepository
+}
+
+// NewCatalogService creates a new CatalogService instance
+func NewCatalogService() *CatalogService {
+	return &CatalogService{
+		productRepo:  repositories.NewProductRepository(database.DB),
+		categoryRepo: repositories.NewCategoryRepository(database.DB),
+	}
+}
+
+// Diff returns the products and categories created, updated and deleted since
+// a time. Updates list the fields that changed, comparing the current state
+// with the latest revision recorded by then. Entities created and deleted within
+// the period are left out, and so are updates that changed nothing in the end.
+func (s *CatalogService) Diff(from time.Time) (*dto.CatalogDiffResponse, error) {
+	// Taken before reading, so changes made meanwhile show up again in the next diff rather than never
+	until := time.Now()
+
+	products, err := s.productDiff(from)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryDiff(from)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.CatalogDiffResponse{
+		From:       dto.NewTime(from),
+		Until:      dto.NewTime(until),
+		Products:   products,
+		Categories: categories,
+	}, nil
+}
+
+// productDiff returns the product changes since a time
+func (s *CatalogService) productDiff(from time.Time) (dto.ProductDiff, error) {
+	diff := dto.ProductDiff{Created: []dto.ProductDiffEntry{}, Updated: []dto.ProductDiffEntry{}, Deleted: []uint{}}
+
+	products, err := s.productRepo.ListChangedSince(from)
+	if err != nil {
+		return diff, err
+	}
+	ids := make([]uint, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+	baselines, err := s.productRepo.RevisionsAsOf(ids, from)
+	if err != nil {
+		return diff, err
+	}
+
+	for i := range products {
+		product := &products[i]
+		baseline, hasBaseline := baselines[product.ID]
+		existed := hasBaseline || !product.CreatedAt.After(from)
+		switch {
+		case product.DeletedAt.Valid:
+			if existed && product.DeletedAt.Time.After(from) {
+				diff.Deleted = append(diff.Deleted, product.ID)
+			}
+		case !existed:
+			diff.Created = append(diff.Created, dto.ProductDiffEntry{ID: product.ID, Snapshot: repositories.SnapshotProduct(product)})
+		default:
+			entry := dto.ProductDiffEntry{ID: product.ID, Snapshot: repositories.SnapshotProduct(product)}
+			if hasBaseline {
+				var old dto.ProductSnapshot
+				if err := json.Unmarshal([]byte(baseline.Snapshot), &old); err != nil {
+					return diff, err
+				}
+				if entry.Changes = diffProductSnapshots(old, entry.Snapshot); len(entry.Changes) == 0 {
+					continue
+				}
+			}
+			diff.Updated = append(diff.Updated, entry)
+		}
+	}
+	return diff, nil
+}
+
+// categoryDiff returns the category changes since a time
+func (s *CatalogService) categoryDiff(from time.Time) (dto.CategoryDiff, error) {
+	diff := dto.CategoryDiff{Created: []dto.CategoryDiffEntry{}, Updated: []dto.CategoryDiffEntry{}, Deleted: []uint{}}
+
+	categories, err := s.categoryRepo.ListChangedSince(from)
+	if err != nil {
+		return diff, err
+	}
+	ids := make([]uint, len(categories))
+	for i, category := range categories {
+		ids[i] = category.ID
+	}
+	baselines, err := s.categoryRepo.RevisionsAsOf(ids, from)
+	if err != nil {
+		return diff, err
+	}
+
+	for _, category := range categories {
+		baseline, hasBaseline := baselines[category.ID]
+		existed := hasBaseline || !category.CreatedAt.After(from)
+		current := dto.CategorySnapshot{Name: category.Name, Description: category.Description}
+		switch {
+		case category.DeletedAt.Valid:
+			if existed && category.DeletedAt.Time.After(from) {
+				diff.Deleted = append(diff.Deleted, category.ID)
+			}
+		case !existed:
+			diff.Created = append(diff.Created, dto.CategoryDiffEntry{ID: category.ID, Snapshot: current})
+		default:
+			entry := dto.CategoryDiffEntry{ID: category.ID, Snapshot: current}
+			if hasBaseline {
+				var old dto.CategorySnapshot
+				if err := json.Unmarshal([]byte(baseline.Snapshot), &old); err != nil {
+					return diff, err
+				}
+				entry.Changes = make(map[string]dto.FieldChange)
+				if old.Name != current.Name {
+					entry.Changes["name"] = dto.FieldChange{Old: old.Name, New: current.Name}
+				}
+				if old.Description != current.Description {
+					entry.Changes["description"] = dto.FieldChange{Old: old.Description, New: current.Description}
+				}
+				if len(entry.Changes) == 0 {
+					continue
+				}
+			}
+			diff.Updated = append(diff.Updated, entry)
+		}
+	}
+	return diff, nil
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"product-management/pkg/lock"
+)
+
+// CategoryCountService reconciles the product counts stored on categories
+// with their links. Counts are kept up to date in the transactions that change
+// links, so this only corrects links changed another way, such as by hand.
+type CategoryCountService struct {
+	interval     time.Duration
+	categoryRepo *repositories.CategoryRepository
+}
+
+// NewCategoryCountService creates a new CategoryCountService instance
+func NewCategoryCountService(interval time.Duration) *CategoryCountService {
+	return &CategoryCountService{
+		interval:     interval,
+		categoryRepo: repositories.NewCategoryRepository(database.DB),
+	}
+}
+
+// Start reconciles the counts now and then every interval, and returns a
+// function that stops it
+func (s *CategoryCountService) Start() func() {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			if _, err := s.Run(); err != nil {
+				log.Printf("Warning: category count reconciliation failed: %v", err)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
+
+// Run corrects the counts that drifted from the links and returns how many
+// were corrected. When several instances run, the one holding the lock
+// reconciles and the others skip their turn.
+func (s *CategoryCountService) Run() (int, error) {
+	held, err := lock.Default.TryLock(context.Background(), "category count reconciliation")
+	if errors.Is(err, lock.ErrLocked) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer held.Release()
+
+	corrected, err := s.categoryRepo.ReconcileProductCounts()
+	if err != nil {
+		return 0, err
+	}
+	if len(corrected) > 0 {
+		log.Printf("Corrected the product counts of categories %v", corrected)
+		cache.Store.Delete(cache.CategoriesKey)
+	}
+	return len(corrected), nil
+}
@@ -2,14 +2,22 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"product-management/internal/dto"
+	"product-management/internal/mappers"
 	"product-management/internal/models"
 	"product-management/internal/repositories"
+	"product-management/pkg/cache"
 	"product-management/pkg/database"
+	"product-management/pkg/events"
 
 	"gorm.io/gorm"
 )
 
+// ErrMetadataSchema is returned for a category metadata schema with an invalid
+// or repeated key
+var ErrMetadataSchema = errors.New("invalid metadata schema")
+
 // CategoryService handles business logic for categories
 type CategoryService struct {
 	categoryRepo *repositories.CategoryRepository
@@ -24,14 +32,20 @@ func NewCategoryService() *CategoryService {
 
 // CreateCategory creates a new category
 func (s *CategoryService) CreateCategory(req dto.CreateCategoryRequest) (*models.Category, error) {
+	if err := validateMetadataSchema(req.MetadataSchema); err != nil {
+		return nil, err
+	}
 	category := &models.Category{
-		Name:        req.Name,
-		Description: req.Description,
+		Name:           req.Name,
+		Description:    req.Description,
+		MetadataSchema: mappers.FromMetadataFields(req.MetadataSchema),
 	}
 
 	if err := s.categoryRepo.Create(category); err != nil {
 		return nil, err
 	}
+	cache.Store.Delete(cache.CategoriesKey)
+	events.Publish(events.CategoryChanged{CategoryID: category.ID})
 
 	return category, nil
 }
@@ -48,17 +62,52 @@ func (s *CategoryService) GetCategoryByID(id uint) (*models.Category, error) {
 	return category, nil
 }
 
-// GetAllCategories retrieves all categories
+// GetAllCategories retrieves all categories, reading through the cache
 func (s *CategoryService) GetAllCategories() ([]dto.CategoryResponse, error) {
-	return s.categoryRepo.GetAllWithProductCount()
+	var cached []dto.CategoryResponse
+	if cache.Store.Get(cache.CategoriesKey, &cached) {
+		return cached, nil
+	}
+
+	value, err, _ := readGroup.Do(cache.CategoriesKey, func() (interface{}, error) {
+		categories, err := s.loadCategories()
+		if err != nil {
+			return nil, err
+		}
+		cache.Store.Set(cache.CategoriesKey, categories, cache.TTL)
+		return categories, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]dto.CategoryResponse), nil
+}
+
+// loadCategories reads all categories with their product counts from the
+// database, as they are cached
+func (s *CategoryService) loadCategories() ([]dto.CategoryResponse, error) {
+	rows, err := s.categoryRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	categories := make([]dto.CategoryResponse, len(rows))
+	for i := range rows {
+		categories[i] = mappers.ToCategoryResponse(&rows[i])
+	}
+	return categories, nil
 }
 
-// UpdateCategory updates an existing category
+// UpdateCategory updates an existing category. Products already in the
+// category are checked against a changed metadata schema on their next edit.
 func (s *CategoryService) UpdateCategory(id uint, req dto.UpdateCategoryRequest) (*models.Category, error) {
+	if err := validateMetadataSchema(req.MetadataSchema); err != nil {
+		return nil, err
+	}
 	category := &models.Category{
-		BaseModel:   models.BaseModel{ID: id},
-		Name:        req.Name,
-		Description: req.Description,
+		BaseModel:      models.BaseModel{ID: id},
+		Name:           req.Name,
+		Description:    req.Description,
+		MetadataSchema: mappers.FromMetadataFields(req.MetadataSchema),
 	}
 
 	if err := s.categoryRepo.Update(category); err != nil {
@@ -67,6 +116,9 @@ func (s *CategoryService) UpdateCategory(id uint, req dto.UpdateCategoryRequest)
 		}
 		return nil, err
 	}
+	cache.Store.Delete(cache.CategoriesKey)
+	invalidateProductLists()
+	events.Publish(events.CategoryChanged{CategoryID: category.ID})
 
 	return category, nil
 }
@@ -74,8 +126,8 @@ func (s *CategoryService) UpdateCategory(id uint, req dto.UpdateCategoryRequest)
 // DeleteCategory deletes a category
 func (s *CategoryService) DeleteCategory(id uint) error {
 	// Check if category has any products
-	var count int64
-	if err := s.categoryRepo.DB().Model(&models.ProductCategory{}).Where("category_id = ?", id).Count(&count).Error; err != nil {
+	count, err := s.categoryRepo.CountProductsInCategory(id)
+	if err != nil {
 		return err
 	}
 
@@ -83,7 +135,12 @@ func (s *CategoryService) DeleteCategory(id uint) error {
 		return errors.New("cannot delete category with associated products")
 	}
 
-	return s.categoryRepo.Delete(id)
+	if err := s.categoryRepo.Delete(id); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.CategoriesKey)
+	events.Publish(events.CategoryChanged{CategoryID: id, Deleted: true})
+	return nil
 }
 
 // GetProductsByCategoryID retrieves all products in a category
@@ -93,15 +150,43 @@ func (s *CategoryService) GetProductsByCategoryID(categoryID uint) ([]models.Pro
 
 // AddProductToCategory adds a product to a category
 func (s *CategoryService) AddProductToCategory(categoryID, productID uint) error {
-	return s.categoryRepo.AddProductToCategory(categoryID, productID)
+	if err := s.categoryRepo.AddProductToCategory(categoryID, productID); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.CategoriesKey, cache.ProductKey(productID))
+	invalidateProductLists()
+	events.Publish(events.ProductChanged{ProductID: productID})
+	return nil
 }
 
 // RemoveProductFromCategory removes a product from a category
 func (s *CategoryService) RemoveProductFromCategory(categoryID, productID uint) error {
-	return s.categoryRepo.RemoveProductFromCategory(categoryID, productID)
+	if err := s.categoryRepo.RemoveProductFromCategory(categoryID, productID); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.CategoriesKey, cache.ProductKey(productID))
+	invalidateProductLists()
+	events.Publish(events.ProductChanged{ProductID: productID})
+	return nil
 }
 
 // GetCategoryDistribution gets the distribution of products across categories
 func (s *CategoryService) GetCategoryDistribution() ([]dto.CategoryDistributionResponse, error) {
 	return s.categoryRepo.GetCategoryDistribution()
 }
+
+// validateMetadataSchema checks that the keys of a metadata schema are valid
+// metadata keys and defined once
+func validateMetadataSchema(fields []dto.MetadataField) error {
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if !models.MetadataKeyPattern.MatchString(field.Key) {
+			return fmt.Errorf("%w: key %q must be lowercase letters, digits and underscores, starting with a letter", ErrMetadataSchema, field.Key)
+		}
+		if seen[field.Key] {
+			return fmt.Errorf("%w: key %q is defined twice", ErrMetadataSchema, field.Key)
+		}
+		seen[field.Key] = true
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"log"
+	"strings"
+
+	"product-management/config"
+	"product-management/pkg/events"
+	"product-management/pkg/logger"
+)
+
+// ConfigService reloads the runtime configuration of this instance and tells
+// the subsystems what changed
+type ConfigService struct {
+	bus *events.Bus
+}
+
+// NewConfigService creates a new ConfigService instance publishing changes on bus
+func NewConfigService(bus *events.Bus) *ConfigService {
+	return &ConfigService{bus: bus}
+}
+
+// Current returns the runtime configuration in effect
+func (s *ConfigService) Current() *config.Runtime {
+	return config.CurrentRuntime()
+}
+
+// Reload loads the runtime configuration again and returns the variables that
+// changed, publishing them when any did. source tells what triggered it, e.g.
+// SIGHUP. An invalid configuration is rejected and the one in effect is kept.
+func (s *ConfigService) Reload(source string) ([]string, error) {
+	changed, err := config.ReloadRuntime()
+	if err != nil {
+		log.Printf("Warning: configuration reload (%s) rejected: %v", source, err)
+		return nil, err
+	}
+	if len(changed) == 0 {
+		log.Printf("Configuration reloaded (%s), nothing changed", source)
+		return changed, nil
+	}
+	log.Printf("Configuration reloaded (%s), changed %s", source, strings.Join(changed, ", "))
+	s.bus.Publish(events.ConfigChanged{Keys: changed, Source: source})
+	return changed, nil
+}
+
+// Subscribe applies reloaded configuration to the subsystems that keep their
+// own copy of it. Rate limits, feature flags and maintenance mode are read
+// from the configuration in effect on every request instead.
+func (s *ConfigService) Subscribe(bus *events.Bus) {
+	bus.Subscribe(events.TopicConfigChanged, func(event events.Event) {
+		for _, key := range event.(events.ConfigChanged).Keys {
+			if key == "LOG_LEVEL" {
+				logger.SetLevel(config.CurrentRuntime().Level())
+			}
+		}
+	})
+}
@@ -0,0 +1,369 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/connectors"
+	"product-management/pkg/database"
+	"product-management/pkg/lock"
+)
+
+const (
+	// connectorSyncTimeout bounds a whole sync run
+	connectorSyncTimeout = 30 * time.Minute
+	// connectorOrderBatch is the most orders pushed per run; the rest follow in later runs
+	connectorOrderBatch = 500
+)
+
+var (
+	ErrConnectorNotFound   = errors.New("connector not found")
+	ErrConnectorSyncActive = errors.New("a sync of this connector is already running")
+)
+
+// CheckConnectors returns an error when a connector configuration is invalid
+// or two connectors share a name
+func CheckConnectors(configs []connectors.Config) error {
+	seen := make(map[string]bool, len(configs))
+	for _, cfg := range configs {
+		if seen[cfg.Name] {
+			return fmt.Errorf("connector %s is configured twice", cfg.Name)
+		}
+		seen[cfg.Name] = true
+		if _, err := connectors.New(cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// connectorEntry is a configured connector
+type connectorEntry struct {
+	config    connectors.Config
+	connector connectors.Connector
+}
+
+// ConnectorService syncs the catalog with legacy systems through connectors:
+// it pulls products and stock levels from them and pushes accepted quotes to
+// them as orders, logging every run
+type ConnectorService struct {
+	entries       map[string]connectorEntry
+	connectorRepo *repositories.ConnectorRepository
+}
+
+// NewConnectorService creates a new ConnectorService instance for connector
+// configurations that passed CheckConnectors
+func NewConnectorService(configs []connectors.Config) *ConnectorService {
+	entries := make(map[string]connectorEntry, len(configs))
+	for _, cfg := range configs {
+		connector, err := connectors.New(cfg)
+		if err != nil {
+			log.Printf("Warning: skipping %v", err)
+			continue
+		}
+		entries[cfg.Name] = connectorEntry{config: cfg, connector: connector}
+	}
+	return &ConnectorService{
+		entries:       entries,
+		connectorRepo: repositories.NewConnectorRepository(database.DB),
+	}
+}
+
+// Start syncs every connector now and then at its interval, and returns a
+// function that stops them. When several instances run, the first to take a
+// connector's lock syncs it and the others skip runs started less than half
+// an interval ago.
+func (s *ConnectorService) Start() func() {
+	stop := make(chan struct{})
+	for _, name := range s.names() {
+		entry := s.entries[name]
+		go func() {
+			ticker := time.NewTicker(entry.config.Interval)
+			defer ticker.Stop()
+			for {
+				if err := s.runScheduled(entry); err != nil {
+					log.Printf("Warning: connector %s sync failed: %v", entry.config.Name, err)
+				}
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	}
+	return func() { close(stop) }
+}
+
+// runScheduled syncs a connector unless it is syncing elsewhere or synced recently
+func (s *ConnectorService) runScheduled(entry connectorEntry) error {
+	held, err := lock.Default.TryLock(context.Background(), connectorLockName(entry.config.Name))
+	if errors.Is(err, lock.ErrLocked) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer held.Release()
+
+	last, err := s.connectorRepo.LastRun(entry.config.Name)
+	if err != nil {
+		return err
+	}
+	if last != nil && time.Since(last.StartedAt) < entry.config.Interval/2 {
+		return nil
+	}
+	run, err := s.startRun(entry, nil)
+	if err != nil {
+		return err
+	}
+	s.sync(entry, run)
+	if run.Error != "" {
+		return errors.New(run.Error)
+	}
+	return nil
+}
+
+// Trigger starts a sync of a connector in the background on behalf of an
+// admin and returns its run, still running
+func (s *ConnectorService) Trigger(name string, userID uint) (*models.ConnectorSyncRun, error) {
+	entry, ok := s.entries[name]
+	if !ok {
+		return nil, ErrConnectorNotFound
+	}
+	held, err := lock.Default.TryLock(context.Background(), connectorLockName(name))
+	if errors.Is(err, lock.ErrLocked) {
+		return nil, ErrConnectorSyncActive
+	}
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.startRun(entry, &userID)
+	if err != nil {
+		held.Release()
+		return nil, err
+	}
+	started := *run
+	go func() {
+		defer held.Release()
+		s.sync(entry, run)
+		if run.Error != "" {
+			log.Printf("Warning: connector %s sync failed: %v", name, run.Error)
+		}
+	}()
+	return &started, nil
+}
+
+// startRun records the start of a run, first failing the runs left running by
+// an instance that stopped, since the caller holds the connector's lock
+func (s *ConnectorService) startRun(entry connectorEntry, userID *uint) (*models.ConnectorSyncRun, error) {
+	now := time.Now()
+	if err := s.connectorRepo.FailRunning(entry.config.Name, now); err != nil {
+		return nil, err
+	}
+	run := &models.ConnectorSyncRun{
+		Connector:   entry.config.Name,
+		TriggeredBy: userID,
+		Status:      models.ConnectorSyncRunning,
+		StartedAt:   now,
+	}
+	if err := s.connectorRepo.CreateRun(run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// sync pulls products and stock and pushes orders, then records the outcome
+// in the run. A failing step does not stop the others.
+func (s *ConnectorService) sync(entry connectorEntry, run *models.ConnectorSyncRun) {
+	ctx, cancel := context.WithTimeout(context.Background(), connectorSyncTimeout)
+	defer cancel()
+
+	var errs []error
+	for _, step := range []struct {
+		name string
+		run  func(context.Context, connectorEntry, *models.ConnectorSyncRun) error
+	}{
+		{"products", s.pullProducts},
+		{"stock", s.pullStock},
+		{"orders", s.pushOrders},
+	} {
+		if err := step.run(ctx, entry, run); err != nil && !errors.Is(err, connectors.ErrUnsupported) {
+			errs = append(errs, fmt.Errorf("%s: %w", step.name, err))
+		}
+	}
+
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.Status = models.ConnectorSyncSucceeded
+	if err := errors.Join(errs...); err != nil {
+		run.Status = models.ConnectorSyncFailed
+		run.Error = err.Error()
+	}
+	if err := s.connectorRepo.FinishRun(run); err != nil {
+		log.Printf("Warning: failed to record connector %s sync run: %v", entry.config.Name, err)
+	}
+}
+
+// pullProducts creates or updates the products the system knows, by SKU
+func (s *ConnectorService) pullProducts(ctx context.Context, entry connectorEntry, run *models.ConnectorSyncRun) error {
+	products, err := entry.connector.PullProducts(ctx)
+	if err != nil {
+		return err
+	}
+	run.ProductsPulled = len(products)
+	for _, pulled := range products {
+		sku := strings.TrimSpace(pulled.SKU)
+		name := strings.TrimSpace(pulled.Name)
+		if sku == "" || name == "" || pulled.Price < 0 {
+			run.Skipped++
+			continue
+		}
+		product, created, updated, err := s.connectorRepo.SyncProduct(sku, name, pulled.Description, pulled.Price)
+		if err != nil {
+			return fmt.Errorf("%s: %w", sku, err)
+		}
+		switch {
+		case product == nil:
+			run.Skipped++
+		case created:
+			run.ProductsCreated++
+		case updated:
+			run.ProductsUpdated++
+		}
+		if created || updated {
+			cache.Store.Delete(cache.ProductKey(product.ID), cache.CategoriesKey)
+			notifyOutbox()
+		}
+	}
+	return nil
+}
+
+// pullStock sets the stock levels of the products the system holds, by SKU
+func (s *ConnectorService) pullStock(ctx context.Context, entry connectorEntry, run *models.ConnectorSyncRun) error {
+	levels, err := entry.connector.PullStock(ctx)
+	if err != nil {
+		return err
+	}
+	run.StockPulled = len(levels)
+	for _, level := range levels {
+		sku := strings.TrimSpace(level.SKU)
+		if sku == "" || level.Quantity < 0 {
+			run.Skipped++
+			continue
+		}
+		product, previous, err := s.connectorRepo.SyncStock(sku, level.Quantity, entry.config.Name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", sku, err)
+		}
+		if product == nil {
+			run.Skipped++
+			continue
+		}
+		if previous != product.StockQuantity {
+			run.StockUpdated++
+			cache.Store.Delete(cache.ProductKey(product.ID), cache.CategoriesKey)
+			notifyOutbox()
+		}
+	}
+	return nil
+}
+
+// pushOrders sends the quotes accepted since the last order pushed, as orders
+// priced at their quoted prices
+func (s *ConnectorService) pushOrders(ctx context.Context, entry connectorEntry, run *models.ConnectorSyncRun) error {
+	through, err := s.connectorRepo.OrdersThrough(entry.config.Name)
+	if err != nil {
+		return err
+	}
+	run.OrdersThrough = through
+	quotes, emails, err := s.connectorRepo.ListAcceptedQuotes(through, connectorOrderBatch)
+	if err != nil || len(quotes) == 0 {
+		return err
+	}
+
+	orders := make([]connectors.Order, len(quotes))
+	for i, quote := range quotes {
+		order := connectors.Order{
+			Reference:     quote.Reference(),
+			CustomerEmail: emails[quote.UserID],
+			PlacedAt:      *quote.DecidedAt,
+			Lines:         make([]connectors.OrderLine, len(quote.Items)),
+		}
+		for j, item := range quote.Items {
+			price := item.UnitPrice
+			if item.QuotedPrice != nil {
+				price = *item.QuotedPrice
+			}
+			order.Lines[j] = connectors.OrderLine{SKU: item.Product.SKUValue(), Quantity: item.Quantity, UnitPrice: price}
+		}
+		orders[i] = order
+	}
+	if err := entry.connector.PushOrders(ctx, orders); err != nil {
+		return err
+	}
+	run.OrdersPushed = len(orders)
+	run.OrdersThrough = quotes[len(quotes)-1].DecidedAt
+	return nil
+}
+
+// ListConnectors describes every configured connector with its latest run
+func (s *ConnectorService) ListConnectors() ([]dto.ConnectorResponse, error) {
+	runs, err := s.connectorRepo.LastRuns()
+	if err != nil {
+		return nil, err
+	}
+	lastRuns := make(map[string]*models.ConnectorSyncRun, len(runs))
+	for i := range runs {
+		lastRuns[runs[i].Connector] = &runs[i]
+	}
+
+	names := s.names()
+	response := make([]dto.ConnectorResponse, len(names))
+	for i, name := range names {
+		cfg := s.entries[name].config
+		response[i] = dto.ConnectorResponse{
+			Name:     cfg.Name,
+			Type:     cfg.Type,
+			Interval: cfg.Interval.String(),
+		}
+		if run := lastRuns[name]; run != nil {
+			lastRun := mappers.ToConnectorSyncRunResponse(run)
+			response[i].LastRun = &lastRun
+		}
+	}
+	return response, nil
+}
+
+// ListRuns retrieves the sync runs of a connector, newest first
+func (s *ConnectorService) ListRuns(name string, page, limit int) ([]models.ConnectorSyncRun, int64, error) {
+	if _, ok := s.entries[name]; !ok {
+		return nil, 0, ErrConnectorNotFound
+	}
+	return s.connectorRepo.ListRuns(name, page, limit)
+}
+
+// names returns the names of the configured connectors, sorted
+func (s *ConnectorService) names() []string {
+	names := make([]string, 0, len(s.entries))
+	for name := range s.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// connectorLockName returns the name of the lock held while a connector syncs
+func connectorLockName(name string) string {
+	return "connector:" + name
+}
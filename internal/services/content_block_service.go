@@ -0,0 +1,159 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidContentKey    = errors.New("key may only contain lowercase letters, digits, dots, dashes and underscores")
+	ErrContentKeyTaken      = errors.New("content block key is already in use")
+	ErrContentBlockSchedule = errors.New("ends_at must be after starts_at")
+)
+
+// contentKeyPattern matches a valid content block key, such as homepage-hero
+var contentKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// ContentBlockService manages the marketing content blocks the storefront
+// renders by key
+type ContentBlockService struct {
+	contentRepo *repositories.ContentBlockRepository
+}
+
+// NewContentBlockService creates a new ContentBlockService instance
+func NewContentBlockService() *ContentBlockService {
+	return &ContentBlockService{
+		contentRepo: repositories.NewContentBlockRepository(database.DB),
+	}
+}
+
+// ListBlocks retrieves every content block, scheduled and expired ones included
+func (s *ContentBlockService) ListBlocks() ([]models.ContentBlock, error) {
+	return s.contentRepo.List()
+}
+
+// GetBlock retrieves a content block by ID
+func (s *ContentBlockService) GetBlock(id uint) (*models.ContentBlock, error) {
+	return s.contentRepo.GetByID(id)
+}
+
+// CreateBlock adds a content block
+func (s *ContentBlockService) CreateBlock(req dto.ContentBlockRequest, editorID uint) (*models.ContentBlock, error) {
+	block := &models.ContentBlock{}
+	if err := s.apply(block, req, editorID); err != nil {
+		return nil, err
+	}
+	if err := s.contentRepo.Create(block); err != nil {
+		return nil, err
+	}
+	// A miss may be cached for the key
+	cache.Store.Delete(cache.ContentBlockKey(block.Key))
+	return block, nil
+}
+
+// UpdateBlock replaces the key, content and schedule of a block
+func (s *ContentBlockService) UpdateBlock(id uint, req dto.ContentBlockRequest, editorID uint) (*models.ContentBlock, error) {
+	block, err := s.contentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	previousKey := block.Key
+	if err := s.apply(block, req, editorID); err != nil {
+		return nil, err
+	}
+	if err := s.contentRepo.Update(block); err != nil {
+		return nil, err
+	}
+	cache.Store.Delete(cache.ContentBlockKey(previousKey))
+	cache.Store.Delete(cache.ContentBlockKey(block.Key))
+	return block, nil
+}
+
+// DeleteBlock removes a content block
+func (s *ContentBlockService) DeleteBlock(id uint) error {
+	block, err := s.contentRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.contentRepo.Delete(id); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.ContentBlockKey(block.Key))
+	return nil
+}
+
+// LiveBlock retrieves the block with a key if its schedule covers the current
+// time. It returns gorm.ErrRecordNotFound for missing, scheduled and expired
+// blocks alike.
+func (s *ContentBlockService) LiveBlock(key string) (*models.ContentBlock, error) {
+	block, err := s.cachedBlock(key)
+	if err != nil {
+		return nil, err
+	}
+	if block.ID == 0 || !block.LiveAt(time.Now()) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return block, nil
+}
+
+// cachedBlock reads a block through the cache. Keys without a block are cached
+// as an empty block, so unknown keys don't reach the database on every request.
+// Schedules are checked on every read, so cached blocks go live and expire on
+// time.
+func (s *ContentBlockService) cachedBlock(key string) (*models.ContentBlock, error) {
+	var cached models.ContentBlock
+	if cache.Store.Get(cache.ContentBlockKey(key), &cached) {
+		return &cached, nil
+	}
+
+	value, err, _ := readGroup.Do(cache.ContentBlockKey(key), func() (interface{}, error) {
+		block, err := s.contentRepo.GetByKey(key)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			block, err = &models.ContentBlock{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		cache.Store.Set(cache.ContentBlockKey(key), block, cache.TTL)
+		return block, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*models.ContentBlock), nil
+}
+
+// apply validates a request and copies it onto a block
+func (s *ContentBlockService) apply(block *models.ContentBlock, req dto.ContentBlockRequest, editorID uint) error {
+	if !contentKeyPattern.MatchString(req.Key) {
+		return ErrInvalidContentKey
+	}
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(req.StartsAt.Time) {
+		return ErrContentBlockSchedule
+	}
+	taken, err := s.contentRepo.KeyTaken(req.Key, block.ID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrContentKeyTaken
+	}
+
+	block.Key = req.Key
+	block.Title = req.Title
+	block.Body = req.Body
+	block.ImageURL = req.ImageURL
+	block.StartsAt = optionalTime(req.StartsAt)
+	block.EndsAt = optionalTime(req.EndsAt)
+	block.UpdatedBy = editorID
+	return nil
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+)
+
+var (
+	ErrUnknownForm           = errors.New("form must be registration or checkout")
+	ErrInvalidCustomFieldKey = errors.New("key must be lowercase letters, digits and underscores, starting with a letter")
+	ErrCustomFieldKeyTaken   = errors.New("the form already has a field with this key")
+	ErrCustomFieldDefinition = errors.New("invalid custom field")
+	ErrInvalidCustomFields   = errors.New("invalid custom fields")
+)
+
+// customFieldKeyPattern matches a valid custom field key, such as vat_number
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// CustomFieldService manages the admin-defined fields of the registration and
+// checkout forms and validates the values submitted for them
+type CustomFieldService struct {
+	fieldRepo *repositories.CustomFieldRepository
+}
+
+// NewCustomFieldService creates a new CustomFieldService instance
+func NewCustomFieldService() *CustomFieldService {
+	return &CustomFieldService{fieldRepo: repositories.NewCustomFieldRepository(database.DB)}
+}
+
+// ParseForm returns the form with a name, or ErrUnknownForm
+func ParseForm(name string) (models.CustomForm, error) {
+	switch form := models.CustomForm(name); form {
+	case models.FormRegistration, models.FormCheckout:
+		return form, nil
+	default:
+		return "", ErrUnknownForm
+	}
+}
+
+// ListFields retrieves the fields of a form, or of every form when form is empty
+func (s *CustomFieldService) ListFields(form models.CustomForm) ([]models.CustomField, error) {
+	return s.fieldRepo.List(form)
+}
+
+// CreateField adds a field to a form
+func (s *CustomFieldService) CreateField(req dto.CustomFieldRequest, editorID uint) (*models.CustomField, error) {
+	field := &models.CustomField{}
+	if err := s.apply(field, req, editorID); err != nil {
+		return nil, err
+	}
+	if err := s.fieldRepo.Create(field); err != nil {
+		return nil, err
+	}
+	cache.Store.Delete(cache.FormSchemaKey(string(field.Form)))
+	return field, nil
+}
+
+// UpdateField replaces the definition of a field. Values already submitted
+// are not checked again.
+func (s *CustomFieldService) UpdateField(id uint, req dto.CustomFieldRequest, editorID uint) (*models.CustomField, error) {
+	field, err := s.fieldRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	previousForm := field.Form
+	if err := s.apply(field, req, editorID); err != nil {
+		return nil, err
+	}
+	if err := s.fieldRepo.Update(field); err != nil {
+		return nil, err
+	}
+	cache.Store.Delete(cache.FormSchemaKey(string(previousForm)))
+	cache.Store.Delete(cache.FormSchemaKey(string(field.Form)))
+	return field, nil
+}
+
+// DeleteField removes a field from its form. Values already submitted for it
+// are kept.
+func (s *CustomFieldService) DeleteField(id uint) error {
+	field, err := s.fieldRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.fieldRepo.Delete(id); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.FormSchemaKey(string(field.Form)))
+	return nil
+}
+
+// FormFields retrieves the fields of a form in display order through the cache
+func (s *CustomFieldService) FormFields(form models.CustomForm) ([]models.CustomField, error) {
+	var cached []models.CustomField
+	if cache.Store.Get(cache.FormSchemaKey(string(form)), &cached) {
+		return cached, nil
+	}
+
+	value, err, _ := readGroup.Do(cache.FormSchemaKey(string(form)), func() (interface{}, error) {
+		fields, err := s.fieldRepo.List(form)
+		if err != nil {
+			return nil, err
+		}
+		cache.Store.Set(cache.FormSchemaKey(string(form)), fields, cache.TTL)
+		return fields, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]models.CustomField), nil
+}
+
+// CheckValues validates the custom field values submitted with a form and
+// returns them as they are stored. Required fields must have a value, null
+// counts as none, and keys the form has no field for are rejected. Validation
+// failures wrap ErrInvalidCustomFields.
+func (s *CustomFieldService) CheckValues(form models.CustomForm, values map[string]interface{}) (map[string]interface{}, error) {
+	fields, err := s.FormFields(form)
+	if err != nil {
+		return nil, err
+	}
+
+	defined := make(map[string]bool, len(fields))
+	checked := make(map[string]interface{}, len(values))
+	for i := range fields {
+		field := &fields[i]
+		defined[field.Key] = true
+		value, ok := values[field.Key]
+		if !ok || value == nil {
+			if field.Required {
+				return nil, fmt.Errorf("%w: %s is required", ErrInvalidCustomFields, field.Key)
+			}
+			continue
+		}
+		if checked[field.Key], err = field.Check(value); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCustomFields, err)
+		}
+	}
+
+	var unknown []string
+	for key := range values {
+		if !defined[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%w: the %s form has no %s field", ErrInvalidCustomFields, form, unknown[0])
+	}
+
+	if len(checked) == 0 {
+		return nil, nil
+	}
+	return checked, nil
+}
+
+// apply validates a request and copies it onto a field
+func (s *CustomFieldService) apply(field *models.CustomField, req dto.CustomFieldRequest, editorID uint) error {
+	form, err := ParseForm(req.Form)
+	if err != nil {
+		return err
+	}
+	if !customFieldKeyPattern.MatchString(req.Key) {
+		return ErrInvalidCustomFieldKey
+	}
+
+	fieldType := models.CustomFieldType(req.Type)
+	switch {
+	case fieldType == models.CustomFieldSelect && len(req.Options) == 0:
+		return fmt.Errorf("%w: select fields need options", ErrCustomFieldDefinition)
+	case fieldType != models.CustomFieldSelect && len(req.Options) > 0:
+		return fmt.Errorf("%w: only select fields have options", ErrCustomFieldDefinition)
+	case fieldType != models.CustomFieldText && (req.Pattern != "" || req.MinLength != nil || req.MaxLength != nil):
+		return fmt.Errorf("%w: only text fields have a pattern, min_length or max_length", ErrCustomFieldDefinition)
+	case fieldType != models.CustomFieldNumber && (req.Min != nil || req.Max != nil):
+		return fmt.Errorf("%w: only number fields have a min or max", ErrCustomFieldDefinition)
+	case req.MinLength != nil && req.MaxLength != nil && *req.MinLength > *req.MaxLength:
+		return fmt.Errorf("%w: min_length must not exceed max_length", ErrCustomFieldDefinition)
+	case req.Min != nil && req.Max != nil && *req.Min > *req.Max:
+		return fmt.Errorf("%w: min must not exceed max", ErrCustomFieldDefinition)
+	}
+	if req.Pattern != "" {
+		if _, err := regexp.Compile(req.Pattern); err != nil {
+			return fmt.Errorf("%w: pattern is not a valid regular expression", ErrCustomFieldDefinition)
+		}
+	}
+
+	taken, err := s.fieldRepo.KeyTaken(form, req.Key, field.ID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrCustomFieldKeyTaken
+	}
+
+	field.Form = form
+	field.Key = req.Key
+	field.Label = req.Label
+	field.HelpText = req.HelpText
+	field.Type = fieldType
+	field.Required = req.Required
+	field.Options = req.Options
+	field.Pattern = req.Pattern
+	field.MinLength = req.MinLength
+	field.MaxLength = req.MaxLength
+	field.Min = req.Min
+	field.Max = req.Max
+	field.Position = req.Position
+	field.UpdatedBy = editorID
+	return nil
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/mailer"
+)
+
+// DigestPeriod is the period covered by each digest
+const DigestPeriod = 7 * 24 * time.Hour
+
+// DigestService sends the weekly wishlist digest to users and the weekly
+// inventory report to admins
+type DigestService struct {
+	digestRepo        *repositories.DigestRepository
+	lowStockThreshold int
+	appURL            string
+}
+
+// NewDigestService creates a new DigestService instance. appURL is the public
+// URL of the API, used in unsubscribe links.
+func NewDigestService(lowStockThreshold int, appURL string) *DigestService {
+	return &DigestService{
+		digestRepo:        repositories.NewDigestRepository(database.DB),
+		lowStockThreshold: lowStockThreshold,
+		appURL:            strings.TrimSuffix(appURL, "/"),
+	}
+}
+
+// digestItem is a wishlisted product in the digest template
+type digestItem struct {
+	Name     string
+	OldPrice float64
+	NewPrice float64
+}
+
+// SendWeeklyDigests queues the digests of the week ending at until. Users only
+// receive a digest when something on their wishlist changed.
+func (s *DigestService) SendWeeklyDigests(until time.Time) error {
+	since := until.Add(-DigestPeriod)
+
+	changes, err := s.digestRepo.GetWishlistChanges(since)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(changes); {
+		end := start
+		for end < len(changes) && changes[end].UserID == changes[start].UserID {
+			end++
+		}
+		s.sendWishlistDigest(changes[start:end])
+		start = end
+	}
+
+	report, err := s.digestRepo.GetInventoryReport(since, s.lowStockThreshold)
+	if err != nil {
+		return err
+	}
+	admins, err := s.digestRepo.GetReportRecipients(models.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	for _, admin := range admins {
+		err := mailer.Default.Enqueue(mailer.DefaultTenant, mailer.TemplateWeeklyReport, admin.Email, map[string]interface{}{
+			"Name":              admin.FullName,
+			"Since":             since.Format("2006-01-02"),
+			"Until":             until.Format("2006-01-02"),
+			"Report":            report,
+			"LowStockThreshold": s.lowStockThreshold,
+			"UnsubscribeURL":    s.unsubscribeURL(admin.ID),
+		})
+		if err != nil {
+			log.Printf("Warning: failed to queue weekly report to user %d: %v", admin.ID, err)
+		}
+	}
+
+	log.Printf("Queued weekly digests for %d changed wishlist items and reports for %d admins", len(changes), len(admins))
+	return nil
+}
+
+// sendWishlistDigest queues the digest of one user's changed wishlist items
+func (s *DigestService) sendWishlistDigest(changes []repositories.WishlistChange) {
+	var priceChanges, backInStock []digestItem
+	for _, change := range changes {
+		item := digestItem{Name: change.ProductName, NewPrice: change.NewPrice}
+		if change.OldPrice != nil {
+			item.OldPrice = *change.OldPrice
+			priceChanges = append(priceChanges, item)
+		}
+		if change.BackInStock {
+			backInStock = append(backInStock, item)
+		}
+	}
+
+	user := changes[0]
+	err := mailer.Default.Enqueue(mailer.DefaultTenant, mailer.TemplateWeeklyDigest, user.Email, map[string]interface{}{
+		"Name":           user.FullName,
+		"PriceChanges":   priceChanges,
+		"BackInStock":    backInStock,
+		"UnsubscribeURL": s.unsubscribeURL(user.UserID),
+	})
+	if err != nil {
+		log.Printf("Warning: failed to queue weekly digest to user %d: %v", user.UserID, err)
+	}
+}
+
+// unsubscribeURL returns the link that turns off a user's digests
+func (s *DigestService) unsubscribeURL(userID uint) string {
+	return fmt.Sprintf("%s/api/v1/auth/unsubscribe?token=%s", s.appURL, UnsubscribeToken(userID))
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"errors"
+	"sort"
+
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/fieldcrypt"
+)
+
+// encryptedColumns are the columns of the model fields tagged
+// `serializer:encrypted`, by table. Keep it in sync with the models.
+var encryptedColumns = map[string][]string{
+	"notification_settings": {"phone_number", "push_token"},
+}
+
+// ErrNoEncryptionKeys is returned when re-encrypting without ENCRYPTION_KEYS
+var ErrNoEncryptionKeys = errors.New("no ENCRYPTION_KEYS are configured")
+
+// EncryptionResult counts the encrypted values of a table checked and rewritten
+type EncryptionResult struct {
+	Table       string
+	Rows        int // Rows read
+	Reencrypted int // Rows with a plaintext value or one under an old key, rewritten under the active key
+	Skipped     int // Rows changed by someone else while being rewritten; they already use the active key
+}
+
+// EncryptionService maintains the fields encrypted at rest
+type EncryptionService struct {
+	encryptionRepo *repositories.EncryptionRepository
+}
+
+// NewEncryptionService creates a new EncryptionService instance
+func NewEncryptionService() *EncryptionService {
+	return &EncryptionService{
+		encryptionRepo: repositories.NewEncryptionRepository(database.DB),
+	}
+}
+
+// Reencrypt rewrites every encrypted value that is plaintext or under a key
+// other than the active one, batchSize rows at a time. Once it has run, keys
+// that are no longer active can be removed from ENCRYPTION_KEYS.
+func (s *EncryptionService) Reencrypt(batchSize int) ([]EncryptionResult, error) {
+	keyring := fieldcrypt.Default
+	if keyring == nil {
+		return nil, ErrNoEncryptionKeys
+	}
+
+	tables := make([]string, 0, len(encryptedColumns))
+	for table := range encryptedColumns {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	results := make([]EncryptionResult, 0, len(tables))
+	for _, table := range tables {
+		result := EncryptionResult{Table: table}
+		afterID := uint(0)
+		for {
+			rows, err := s.encryptionRepo.Batch(table, encryptedColumns[table], afterID, batchSize)
+			if err != nil {
+				return results, err
+			}
+			for _, row := range rows {
+				result.Rows++
+				afterID = row.ID
+
+				updated := make(map[string]string)
+				for column, value := range row.Values {
+					if !keyring.NeedsRotation(value) {
+						continue
+					}
+					plaintext, err := keyring.Decrypt(value)
+					if err != nil {
+						return results, err
+					}
+					if updated[column], err = keyring.Encrypt(plaintext); err != nil {
+						return results, err
+					}
+				}
+				if len(updated) == 0 {
+					continue
+				}
+				ok, err := s.encryptionRepo.Replace(table, row.ID, row.Values, updated)
+				if err != nil {
+					return results, err
+				}
+				if ok {
+					result.Reencrypted++
+				} else {
+					result.Skipped++
+				}
+			}
+			if len(rows) < batchSize {
+				break
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+)
+
+var (
+	ErrExperimentKeyTaken    = errors.New("experiment key is already taken")
+	ErrExperimentWeights     = errors.New("variant weights must add up to 100")
+	ErrExperimentVariantKeys = errors.New("variant keys must be unique")
+	ErrExperimentNotDraft    = errors.New("only draft experiments can be changed")
+	ErrExperimentStatus      = errors.New("experiment cannot move to this status")
+	ErrExperimentNotRunning  = errors.New("experiment not found or not running")
+	ErrExperimentNotEnrolled = errors.New("subject is not taking part in the experiment")
+	ErrExperimentSubject     = errors.New("an anonymous_id is required when not signed in")
+)
+
+// ExperimentService runs A/B experiments server-side: it assigns users and
+// anonymous visitors to variants, records exposures and conversions, and
+// compares the variants
+type ExperimentService struct {
+	experimentRepo *repositories.ExperimentRepository
+}
+
+// NewExperimentService creates a new ExperimentService instance
+func NewExperimentService() *ExperimentService {
+	return &ExperimentService{experimentRepo: repositories.NewExperimentRepository(database.DB)}
+}
+
+// ExperimentSubject identifies who takes part in experiments: the user when
+// signed in, otherwise the anonymous ID the client keeps. A visitor signing in
+// becomes a new subject, and may get other variants.
+func ExperimentSubject(userID uint, anonymousID string) (string, error) {
+	if userID != 0 {
+		return fmt.Sprintf("user:%d", userID), nil
+	}
+	if anonymousID != "" {
+		return "anon:" + anonymousID, nil
+	}
+	return "", ErrExperimentSubject
+}
+
+// ListExperiments retrieves a paginated list of experiments, newest first
+func (s *ExperimentService) ListExperiments(status models.ExperimentStatus, page, limit int) ([]models.Experiment, int64, error) {
+	return s.experimentRepo.List(status, page, limit)
+}
+
+// GetExperiment retrieves an experiment by ID
+func (s *ExperimentService) GetExperiment(id uint) (*models.Experiment, error) {
+	return s.experimentRepo.GetByID(id)
+}
+
+// CreateExperiment defines a draft experiment
+func (s *ExperimentService) CreateExperiment(req dto.ExperimentRequest, editorID uint) (*models.Experiment, error) {
+	experiment := &models.Experiment{Status: models.ExperimentDraft}
+	if err := s.apply(experiment, req, editorID); err != nil {
+		return nil, err
+	}
+	if err := s.experimentRepo.Create(experiment); err != nil {
+		return nil, err
+	}
+	return experiment, nil
+}
+
+// UpdateExperiment replaces the settings and variants of a draft experiment.
+// Running experiments can't change, since that would move subjects between
+// variants.
+func (s *ExperimentService) UpdateExperiment(id uint, req dto.ExperimentRequest, editorID uint) (*models.Experiment, error) {
+	experiment, err := s.experimentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if experiment.Status != models.ExperimentDraft {
+		return nil, ErrExperimentNotDraft
+	}
+	if err := s.apply(experiment, req, editorID); err != nil {
+		return nil, err
+	}
+	if err := s.experimentRepo.Update(experiment); err != nil {
+		return nil, err
+	}
+	return experiment, nil
+}
+
+// StartExperiment starts assigning subjects to the variants of a draft experiment
+func (s *ExperimentService) StartExperiment(id, editorID uint) (*models.Experiment, error) {
+	return s.transition(id, editorID, models.ExperimentDraft, models.ExperimentRunning)
+}
+
+// StopExperiment stops a running experiment, keeping its results
+func (s *ExperimentService) StopExperiment(id, editorID uint) (*models.Experiment, error) {
+	return s.transition(id, editorID, models.ExperimentRunning, models.ExperimentStopped)
+}
+
+// DeleteExperiment removes an experiment, stopping it at once when it runs.
+// Its key stays taken.
+func (s *ExperimentService) DeleteExperiment(id uint) error {
+	if err := s.experimentRepo.Delete(id); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.ExperimentsKey)
+	return nil
+}
+
+// Assignments returns the variant of a subject in every running experiment it
+// takes part in, or only in those with the given keys when there are any
+func (s *ExperimentService) Assignments(subject string, keys []string) ([]dto.ExperimentAssignment, error) {
+	running, err := s.runningExperiments()
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		wanted[key] = true
+	}
+	assignments := make([]dto.ExperimentAssignment, 0, len(running))
+	for i := range running {
+		if len(keys) > 0 && !wanted[running[i].Key] {
+			continue
+		}
+		if variant := running[i].Assign(subject); variant != nil {
+			assignments = append(assignments, dto.ExperimentAssignment{Experiment: running[i].Key, Variant: variant.Key})
+		}
+	}
+	return assignments, nil
+}
+
+// Variant returns the variant of a subject in a running experiment, or an
+// empty string when the experiment doesn't run or the subject is not taking
+// part, in which case callers keep their default behaviour
+func (s *ExperimentService) Variant(key, subject string) (string, error) {
+	experiment, err := s.runningExperiment(key)
+	if err != nil || experiment == nil {
+		return "", err
+	}
+	if variant := experiment.Assign(subject); variant != nil {
+		return variant.Key, nil
+	}
+	return "", nil
+}
+
+// RecordEvent records that a subject was exposed to its variant of a running
+// experiment, or converted. The variant is assigned again rather than taken
+// from the client, so events can't be attributed to another variant.
+func (s *ExperimentService) RecordEvent(req dto.ExperimentEventRequest, subject string) (string, error) {
+	experiment, err := s.runningExperiment(req.Experiment)
+	if err != nil {
+		return "", err
+	}
+	if experiment == nil {
+		return "", ErrExperimentNotRunning
+	}
+	variant := experiment.Assign(subject)
+	if variant == nil {
+		return "", ErrExperimentNotEnrolled
+	}
+
+	event := &models.ExperimentEvent{
+		ExperimentID: experiment.ID,
+		Variant:      variant.Key,
+		Subject:      subject,
+		Kind:         models.ExperimentEventKind(req.Kind),
+		Goal:         req.Goal,
+		Value:        req.Value,
+	}
+	if err := s.experimentRepo.RecordEvent(event); err != nil {
+		return "", err
+	}
+	return variant.Key, nil
+}
+
+// GetResults compares the variants of an experiment: the subjects exposed to
+// each, those of them who converted afterwards, and the conversion rate and
+// value. An empty goal counts conversions of every goal.
+func (s *ExperimentService) GetResults(id uint, goal string) (*dto.ExperimentResultsResponse, error) {
+	experiment, err := s.experimentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.experimentRepo.Results(id, goal)
+	if err != nil {
+		return nil, err
+	}
+
+	byVariant := make(map[string]repositories.ExperimentVariantResult, len(results))
+	for _, result := range results {
+		byVariant[result.Variant] = result
+	}
+	response := &dto.ExperimentResultsResponse{
+		ExperimentID: experiment.ID,
+		Key:          experiment.Key,
+		Status:       string(experiment.Status),
+		Goal:         goal,
+		Variants:     make([]dto.ExperimentVariantResult, len(experiment.Variants)),
+	}
+	for i, variant := range experiment.Variants {
+		result := byVariant[variant.Key]
+		response.Variants[i] = dto.ExperimentVariantResult{
+			Variant:     variant.Key,
+			Weight:      variant.Weight,
+			Exposed:     result.Exposed,
+			Converted:   result.Converted,
+			Conversions: result.Conversions,
+			Value:       math.Round(result.Value*100) / 100,
+		}
+		if result.Exposed > 0 {
+			response.Variants[i].ConversionRate = math.Round(float64(result.Converted)/float64(result.Exposed)*10000) / 10000
+		}
+	}
+	return response, nil
+}
+
+// transition moves an experiment from one status to the next
+func (s *ExperimentService) transition(id, editorID uint, from, to models.ExperimentStatus) (*models.Experiment, error) {
+	experiment, err := s.experimentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if experiment.Status != from {
+		return nil, ErrExperimentStatus
+	}
+
+	now := time.Now()
+	experiment.Status = to
+	experiment.UpdatedBy = editorID
+	if to == models.ExperimentRunning {
+		experiment.StartedAt = &now
+	} else {
+		experiment.StoppedAt = &now
+	}
+	if err := s.experimentRepo.UpdateStatus(experiment); err != nil {
+		return nil, err
+	}
+	cache.Store.Delete(cache.ExperimentsKey)
+	return experiment, nil
+}
+
+// runningExperiment returns the running experiment with a key, or nil
+func (s *ExperimentService) runningExperiment(key string) (*models.Experiment, error) {
+	running, err := s.runningExperiments()
+	if err != nil {
+		return nil, err
+	}
+	for i := range running {
+		if running[i].Key == key {
+			return &running[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// runningExperiments loads the running experiments through the cache, which
+// is cleared when one starts, stops or is deleted
+func (s *ExperimentService) runningExperiments() ([]models.Experiment, error) {
+	var running []models.Experiment
+	if cache.Store.Get(cache.ExperimentsKey, &running) {
+		return running, nil
+	}
+	value, err, _ := readGroup.Do(cache.ExperimentsKey, func() (interface{}, error) {
+		experiments, err := s.experimentRepo.ListRunning()
+		if err != nil {
+			return nil, err
+		}
+		cache.Store.Set(cache.ExperimentsKey, experiments, cache.TTL)
+		return experiments, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]models.Experiment), nil
+}
+
+// apply validates an experiment request and copies it onto the experiment
+func (s *ExperimentService) apply(experiment *models.Experiment, req dto.ExperimentRequest, editorID uint) error {
+	total := 0
+	seen := make(map[string]bool, len(req.Variants))
+	variants := make([]models.ExperimentVariant, len(req.Variants))
+	for i, variant := range req.Variants {
+		if seen[variant.Key] {
+			return ErrExperimentVariantKeys
+		}
+		seen[variant.Key] = true
+		total += variant.Weight
+		variants[i] = models.ExperimentVariant{Key: variant.Key, Weight: variant.Weight}
+	}
+	if total != 100 {
+		return ErrExperimentWeights
+	}
+	taken, err := s.experimentRepo.KeyExists(req.Key, experiment.ID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrExperimentKeyTaken
+	}
+
+	experiment.Key = req.Key
+	experiment.Name = req.Name
+	experiment.Description = req.Description
+	experiment.Traffic = 100
+	if req.Traffic != nil {
+		experiment.Traffic = *req.Traffic
+	}
+	experiment.Variants = variants
+	experiment.UpdatedBy = editorID
+	return nil
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+)
+
+var (
+	ErrProductAlreadyFeatured = errors.New("product is already featured")
+	ErrFeaturedProductMissing = errors.New("product not found")
+	ErrFeaturedSchedule       = errors.New("ends_at must be after starts_at")
+)
+
+// FeaturedProductService curates the products spotlighted on the storefront
+// homepage
+type FeaturedProductService struct {
+	featuredRepo   *repositories.FeaturedProductRepository
+	productService *ProductService
+}
+
+// NewFeaturedProductService creates a new FeaturedProductService instance
+func NewFeaturedProductService() *FeaturedProductService {
+	return &FeaturedProductService{
+		featuredRepo:   repositories.NewFeaturedProductRepository(database.DB),
+		productService: NewProductService(),
+	}
+}
+
+// ListFeatured retrieves every entry of the featured list, scheduled and
+// expired ones included, in display order
+func (s *FeaturedProductService) ListFeatured() ([]models.FeaturedProduct, error) {
+	return s.featuredRepo.List()
+}
+
+// CreateFeatured adds a product to the featured list
+func (s *FeaturedProductService) CreateFeatured(req dto.FeaturedProductRequest, creatorID uint) (*models.FeaturedProduct, error) {
+	featured := &models.FeaturedProduct{CreatedBy: creatorID}
+	if err := s.apply(featured, req); err != nil {
+		return nil, err
+	}
+	if err := s.featuredRepo.Create(featured); err != nil {
+		return nil, err
+	}
+	cache.Store.Delete(cache.FeaturedProductsKey)
+	return featured, nil
+}
+
+// UpdateFeatured changes the product, position or schedule of an entry
+func (s *FeaturedProductService) UpdateFeatured(id uint, req dto.FeaturedProductRequest) (*models.FeaturedProduct, error) {
+	featured, err := s.featuredRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(featured, req); err != nil {
+		return nil, err
+	}
+	if err := s.featuredRepo.Update(featured); err != nil {
+		return nil, err
+	}
+	cache.Store.Delete(cache.FeaturedProductsKey)
+	return featured, nil
+}
+
+// DeleteFeatured removes an entry from the featured list
+func (s *FeaturedProductService) DeleteFeatured(id uint) error {
+	if err := s.featuredRepo.Delete(id); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.FeaturedProductsKey)
+	return nil
+}
+
+// LiveProducts returns the featured products whose schedule covers the
+// current time, in display order. Products that are not active or not sold in
+// the country are left out; a nil country allows every market.
+func (s *FeaturedProductService) LiveProducts(country *string) ([]models.Product, error) {
+	entries, err := s.cachedEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var ids []uint
+	for i := range entries {
+		if entries[i].LiveAt(now) {
+			ids = append(ids, entries[i].ProductID)
+		}
+	}
+	products, err := s.productService.GetProducts(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	live := make([]models.Product, 0, len(ids))
+	for _, id := range ids {
+		product := products[id]
+		if product == nil || product.Status != models.StatusActive {
+			continue
+		}
+		if country != nil && !product.AvailableIn(*country) {
+			continue
+		}
+		live = append(live, *product)
+	}
+	return live, nil
+}
+
+// cachedEntries reads the whole featured list through the cache. Schedules are
+// checked on every read, so cached entries go live and expire on time.
+func (s *FeaturedProductService) cachedEntries() ([]models.FeaturedProduct, error) {
+	var cached []models.FeaturedProduct
+	if cache.Store.Get(cache.FeaturedProductsKey, &cached) {
+		return cached, nil
+	}
+
+	value, err, _ := readGroup.Do(cache.FeaturedProductsKey, func() (interface{}, error) {
+		entries, err := s.featuredRepo.List()
+		if err != nil {
+			return nil, err
+		}
+		cache.Store.Set(cache.FeaturedProductsKey, entries, cache.TTL)
+		return entries, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]models.FeaturedProduct), nil
+}
+
+// apply validates a request and copies it onto an entry
+func (s *FeaturedProductService) apply(featured *models.FeaturedProduct, req dto.FeaturedProductRequest) error {
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(req.StartsAt.Time) {
+		return ErrFeaturedSchedule
+	}
+	product, err := s.productService.GetProduct(req.ProductID)
+	if err != nil {
+		return err
+	}
+	if product == nil {
+		return ErrFeaturedProductMissing
+	}
+	taken, err := s.featuredRepo.ProductFeatured(req.ProductID, featured.ID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrProductAlreadyFeatured
+	}
+
+	featured.ProductID = req.ProductID
+	featured.Position = req.Position
+	featured.StartsAt = optionalTime(req.StartsAt)
+	featured.EndsAt = optionalTime(req.EndsAt)
+	return nil
+}
+
+// optionalTime unwraps an optional request timestamp, treating null as unset
+func optionalTime(t *dto.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	value := t.Time
+	return &value
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+)
+
+// maxReconciliationDays bounds the range of a reconciliation report
+const maxReconciliationDays = 31
+
+// ErrReconciliationRange is returned when the days of a reconciliation report
+// are out of order or span too long
+var ErrReconciliationRange = fmt.Errorf("the range must run forward and span at most %d days", maxReconciliationDays)
+
+// FinanceService reconciles orders with the ledgers that paid for them. There
+// is no payment provider yet: what gift cards and store credit leave of a
+// total is reported as due outside the system, not checked.
+type FinanceService struct {
+	financeRepo *repositories.FinanceRepository
+}
+
+// NewFinanceService creates a new FinanceService instance
+func NewFinanceService() *FinanceService {
+	return &FinanceService{
+		financeRepo: repositories.NewFinanceRepository(database.DB),
+	}
+}
+
+// Reconcile reports, for every UTC day between two dates, the orders placed,
+// what gift cards and store credit paid and gave back, and what is left to
+// settle outside the system. An order mismatches when a ledger's net for it
+// differs from what its placement saga recorded, and payments of orders with
+// no placement are reported as unmatched. The range defaults to yesterday.
+func (s *FinanceService) Reconcile(req dto.ReconciliationRequest) (*dto.ReconciliationResponse, error) {
+	from, to, err := reconciliationRange(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	sagas, err := s.financeRepo.OrderPlacements(from, to)
+	if err != nil {
+		return nil, err
+	}
+	references := make([]string, len(sagas))
+	for i := range sagas {
+		references[i] = sagas[i].Reference
+	}
+	storeCredit, err := s.financeRepo.StoreCreditTotals(references)
+	if err != nil {
+		return nil, err
+	}
+	giftCards, err := s.financeRepo.GiftCardTotals(references)
+	if err != nil {
+		return nil, err
+	}
+	unmatched, err := s.financeRepo.UnmatchedPayments(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ReconciliationResponse{
+		From:      from.Format(time.DateOnly),
+		To:        to.Format(time.DateOnly),
+		Orders:    make([]dto.ReconciliationOrder, 0, len(sagas)),
+		Unmatched: make([]dto.UnmatchedPaymentResponse, len(unmatched)),
+	}
+	days := make(map[string]*dto.ReconciliationDay)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		response.Days = append(response.Days, dto.ReconciliationDay{Date: day.Format(time.DateOnly)})
+	}
+	for i := range response.Days {
+		days[response.Days[i].Date] = &response.Days[i]
+	}
+
+	storeCreditByRef := ledgerTotalsByReference(storeCredit)
+	giftCardByRef := ledgerTotalsByReference(giftCards)
+	for i := range sagas {
+		saga := &sagas[i]
+		var placement orderPlacement
+		if err := decodeSagaData(saga, &placement); err != nil {
+			return nil, err
+		}
+		order := reconcileOrder(saga, placement, giftCardByRef[saga.Reference], storeCreditByRef[saga.Reference])
+		response.Orders = append(response.Orders, order)
+
+		day := days[order.Date]
+		day.Orders++
+		day.OrderTotal += order.Total
+		day.GiftCardPayments += giftCardByRef[saga.Reference].Charged
+		day.StoreCreditPayments += storeCreditByRef[saga.Reference].Charged
+		day.Refunds += giftCardByRef[saga.Reference].Returned + storeCreditByRef[saga.Reference].Returned
+		day.ExternalDue += order.ExternalDue
+		if order.Mismatch != "" {
+			day.Mismatches++
+		}
+	}
+	for i := range response.Days {
+		day := &response.Days[i]
+		day.OrderTotal = roundMoney(day.OrderTotal)
+		day.GiftCardPayments = roundMoney(day.GiftCardPayments)
+		day.StoreCreditPayments = roundMoney(day.StoreCreditPayments)
+		day.Refunds = roundMoney(day.Refunds)
+		day.ExternalDue = roundMoney(day.ExternalDue)
+	}
+	for i, payment := range unmatched {
+		response.Unmatched[i] = dto.UnmatchedPaymentResponse{
+			Ledger:    payment.Ledger,
+			Reference: payment.Reference,
+			Net:       roundMoney(payment.Net),
+			FirstAt:   dto.NewTime(payment.FirstAt),
+		}
+	}
+	return response, nil
+}
+
+// reconcileOrder compares what an order's placement recorded as paid with the
+// net of each ledger for it. Once the payment step is voided both ledgers must
+// net to zero; before that they must match the recorded amounts.
+func reconcileOrder(saga *models.Saga, placement orderPlacement, giftCard, storeCredit repositories.LedgerTotals) dto.ReconciliationOrder {
+	order := dto.ReconciliationOrder{
+		Date:               saga.CreatedAt.UTC().Format(time.DateOnly),
+		Reference:          saga.Reference,
+		OrderNumber:        placement.number(saga),
+		Status:             string(saga.Status),
+		Total:              placement.Total,
+		GiftCardApplied:    placement.GiftCardApplied,
+		GiftCardLedger:     roundMoney(giftCard.Charged - giftCard.Returned),
+		StoreCreditApplied: placement.CreditApplied,
+		StoreCreditLedger:  roundMoney(storeCredit.Charged - storeCredit.Returned),
+	}
+
+	expectedGiftCard, expectedCredit := placement.GiftCardApplied, placement.CreditApplied
+	switch paymentStepStatus(saga) {
+	case models.SagaStepCompensated:
+		expectedGiftCard, expectedCredit = 0, 0
+	case models.SagaStepCompleted:
+		order.ExternalDue = roundMoney(placement.Total - placement.GiftCardApplied - placement.CreditApplied)
+	}
+
+	var mismatches []string
+	if order.GiftCardLedger != roundMoney(expectedGiftCard) {
+		mismatches = append(mismatches, fmt.Sprintf("gift card ledger %.2f, order recorded %.2f", order.GiftCardLedger, expectedGiftCard))
+	}
+	if order.StoreCreditLedger != roundMoney(expectedCredit) {
+		mismatches = append(mismatches, fmt.Sprintf("store credit ledger %.2f, order recorded %.2f", order.StoreCreditLedger, expectedCredit))
+	}
+	order.Mismatch = strings.Join(mismatches, "; ")
+	return order
+}
+
+// paymentStepStatus returns the status of the charge_payment step of an order
+// placement saga
+func paymentStepStatus(saga *models.Saga) models.SagaStepStatus {
+	for _, step := range saga.Steps {
+		if step.Name == "charge_payment" {
+			return step.Status
+		}
+	}
+	return models.SagaStepPending
+}
+
+// ledgerTotalsByReference indexes ledger totals by the reference they are for
+func ledgerTotalsByReference(totals []repositories.LedgerTotals) map[string]repositories.LedgerTotals {
+	byReference := make(map[string]repositories.LedgerTotals, len(totals))
+	for _, total := range totals {
+		byReference[total.Reference] = total
+	}
+	return byReference
+}
+
+// reconciliationRange parses the first and last UTC days of a reconciliation
+// report. to defaults to yesterday, the last complete day, and from to to.
+func reconciliationRange(fromDay, toDay string) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.UTC)
+	if toDay != "" {
+		parsed, err := time.Parse(time.DateOnly, toDay)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = parsed
+	}
+	from := to
+	if fromDay != "" {
+		parsed, err := time.Parse(time.DateOnly, fromDay)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = parsed
+	}
+	if from.After(to) || to.Sub(from) >= maxReconciliationDays*24*time.Hour {
+		return time.Time{}, time.Time{}, ErrReconciliationRange
+	}
+	return from, to, nil
+}
+
+// roundMoney rounds an amount of money to two decimals
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
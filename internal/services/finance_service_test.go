@@ -0,0 +1,91 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+)
+
+func TestReconcileOrder(t *testing.T) {
+	placed := time.Date(2024, 1, 16, 9, 30, 0, 0, time.UTC)
+	placement := orderPlacement{QuoteID: 42, OrderNumber: "ORD-42", Total: 120, GiftCardApplied: 50, CreditApplied: 20}
+
+	cases := []struct {
+		name        string
+		step        models.SagaStepStatus
+		giftCard    repositories.LedgerTotals
+		storeCredit repositories.LedgerTotals
+		externalDue float64
+		mismatch    string
+	}{
+		{
+			name:        "charged",
+			step:        models.SagaStepCompleted,
+			giftCard:    repositories.LedgerTotals{Charged: 50},
+			storeCredit: repositories.LedgerTotals{Charged: 20},
+			externalDue: 50,
+		},
+		{
+			name:        "voided",
+			step:        models.SagaStepCompensated,
+			giftCard:    repositories.LedgerTotals{Charged: 50, Returned: 50},
+			storeCredit: repositories.LedgerTotals{Charged: 20, Returned: 20},
+		},
+		{
+			name:        "store credit never refunded",
+			step:        models.SagaStepCompensated,
+			giftCard:    repositories.LedgerTotals{Charged: 50, Returned: 50},
+			storeCredit: repositories.LedgerTotals{Charged: 20},
+			mismatch:    "store credit ledger 20.00, order recorded 0.00",
+		},
+		{
+			name:        "gift card charged twice",
+			step:        models.SagaStepCompleted,
+			giftCard:    repositories.LedgerTotals{Charged: 100},
+			storeCredit: repositories.LedgerTotals{Charged: 20},
+			externalDue: 50,
+			mismatch:    "gift card ledger 100.00, order recorded 50.00",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			saga := &models.Saga{
+				Reference: "Q-42",
+				Status:    models.SagaCompleted,
+				Steps:     []models.SagaStep{{Name: "charge_payment", Status: tc.step}},
+			}
+			saga.CreatedAt = placed
+
+			order := reconcileOrder(saga, placement, tc.giftCard, tc.storeCredit)
+			if order.Date != "2024-01-16" || order.OrderNumber != "ORD-42" {
+				t.Errorf("order is %s on %s, want ORD-42 on 2024-01-16", order.OrderNumber, order.Date)
+			}
+			if order.ExternalDue != tc.externalDue {
+				t.Errorf("external due is %v, want %v", order.ExternalDue, tc.externalDue)
+			}
+			if order.Mismatch != tc.mismatch {
+				t.Errorf("mismatch is %q, want %q", order.Mismatch, tc.mismatch)
+			}
+		})
+	}
+}
+
+func TestReconciliationRange(t *testing.T) {
+	from, to, err := reconciliationRange("2024-01-01", "2024-01-31")
+	if err != nil || from.Format(time.DateOnly) != "2024-01-01" || to.Format(time.DateOnly) != "2024-01-31" {
+		t.Errorf("got %v to %v, %v", from, to, err)
+	}
+	if _, _, err := reconciliationRange("2024-01-01", "2024-02-01"); !errors.Is(err, ErrReconciliationRange) {
+		t.Errorf("32 days accepted")
+	}
+	if _, _, err := reconciliationRange("2024-01-02", "2024-01-01"); !errors.Is(err, ErrReconciliationRange) {
+		t.Errorf("backward range accepted")
+	}
+	from, to, err = reconciliationRange("", "")
+	if err != nil || !from.Equal(to) || !to.Before(time.Now()) {
+		t.Errorf("default range is %v to %v, %v", from, to, err)
+	}
+}
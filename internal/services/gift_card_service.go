@@ -0,0 +1,166 @@
+package services
+
+import (
+	"crypto/rand"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+)
+
+// codeAlphabet leaves out characters that are easily confused, such as 0/O and 1/I
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GiftCardService issues gift cards and manages store credit. Purchases made
+// outside checkout apply gift cards with RedeemGiftCard and store credit with
+// SpendStoreCredit.
+type GiftCardService struct {
+	giftCardRepo *repositories.GiftCardRepository
+}
+
+// NewGiftCardService creates a new GiftCardService instance
+func NewGiftCardService() *GiftCardService {
+	return &GiftCardService{
+		giftCardRepo: repositories.NewGiftCardRepository(database.DB),
+	}
+}
+
+// IssueGiftCard creates a gift card with a random code and the given balance
+func (s *GiftCardService) IssueGiftCard(amount float64, expiresAt *time.Time, issuedBy uint) (*models.GiftCard, error) {
+	code, err := newGiftCardCode()
+	if err != nil {
+		return nil, err
+	}
+	amount = math.Round(amount*100) / 100
+	giftCard := &models.GiftCard{
+		Code:           code,
+		InitialBalance: amount,
+		Balance:        amount,
+		ExpiresAt:      expiresAt,
+		IssuedBy:       issuedBy,
+	}
+	if err := s.giftCardRepo.Create(giftCard); err != nil {
+		return nil, err
+	}
+	return giftCard, nil
+}
+
+// GetGiftCard retrieves a gift card by code, as typed by a customer
+func (s *GiftCardService) GetGiftCard(code string) (*models.GiftCard, error) {
+	return s.giftCardRepo.GetByCode(NormalizeGiftCardCode(code))
+}
+
+// RedeemGiftCard applies a gift card to an amount due for what reference pays
+// for, and returns the ledger entry, whose negated delta is the part the card
+// covered. The rest of the card's balance stays available for later.
+func (s *GiftCardService) RedeemGiftCard(code string, amount float64, reference string, actorID uint) (*models.GiftCardEntry, error) {
+	return s.giftCardRepo.Redeem(NormalizeGiftCardCode(code), math.Round(amount*100)/100, reference, actorID)
+}
+
+// GetStoreCreditBalance returns a user's store credit balance
+func (s *GiftCardService) GetStoreCreditBalance(userID uint) (float64, error) {
+	return s.giftCardRepo.GetStoreCreditBalance(userID)
+}
+
+// ListStoreCredit retrieves a paginated store credit ledger for a user
+func (s *GiftCardService) ListStoreCredit(userID uint, page, limit int) ([]models.StoreCreditEntry, int64, error) {
+	return s.giftCardRepo.ListStoreCredit(userID, page, limit)
+}
+
+// GrantStoreCredit adds credit to a user's balance
+func (s *GiftCardService) GrantStoreCredit(userID uint, amount float64, note string, actorID uint) (*models.StoreCreditEntry, error) {
+	entry := &models.StoreCreditEntry{
+		UserID:  userID,
+		Source:  models.StoreCreditSourceGrant,
+		Delta:   math.Round(amount*100) / 100,
+		ActorID: actorID,
+		Note:    note,
+	}
+	if err := s.giftCardRepo.AddStoreCredit(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// SpendStoreCredit applies a user's store credit to an amount due at payment
+// time and returns the part it covered, which is less when the balance is lower
+func (s *GiftCardService) SpendStoreCredit(userID uint, amount float64, reference string) (float64, error) {
+	return spendStoreCredit(s.giftCardRepo, userID, amount, reference)
+}
+
+// spendStoreCredit applies a user's store credit to an amount due through
+// giftCardRepo, which may be bound to a transaction
+func spendStoreCredit(giftCardRepo *repositories.GiftCardRepository, userID uint, amount float64, reference string) (float64, error) {
+	balance, err := giftCardRepo.GetStoreCreditBalance(userID)
+	if err != nil {
+		return 0, err
+	}
+	applied := math.Min(amount, balance)
+	if applied <= 0 {
+		return 0, nil
+	}
+	err = giftCardRepo.AddStoreCredit(&models.StoreCreditEntry{
+		UserID:    userID,
+		Source:    models.StoreCreditSourcePayment,
+		Reference: reference,
+		Delta:     -applied,
+		ActorID:   userID,
+	})
+	return applied, err
+}
+
+// refundStoreCredit gives back store credit spent on a payment that was voided
+func refundStoreCredit(giftCardRepo *repositories.GiftCardRepository, userID uint, amount float64, reference, note string) error {
+	if amount <= 0 {
+		return nil
+	}
+	return giftCardRepo.AddStoreCredit(&models.StoreCreditEntry{
+		UserID:    userID,
+		Source:    models.StoreCreditSourceRefund,
+		Reference: reference,
+		Delta:     amount,
+		Note:      note,
+	})
+}
+
+// NormalizeGiftCardCode uppercases a code and restores its dashes, so codes
+// typed in lowercase or without dashes still match
+func NormalizeGiftCardCode(code string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(code) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	compact := b.String()
+	if len(compact) != 16 {
+		return compact
+	}
+	return compact[0:4] + "-" + compact[4:8] + "-" + compact[8:12] + "-" + compact[12:16]
+}
+
+// newGiftCardCode generates a random code of four groups of four characters
+func newGiftCardCode() (string, error) {
+	code, err := randomCode(16)
+	if err != nil {
+		return "", err
+	}
+	return NormalizeGiftCardCode(code), nil
+}
+
+// randomCode generates a random code of unambiguous uppercase characters
+func randomCode(length int) (string, error) {
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(codeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = codeAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
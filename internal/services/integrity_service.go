@@ -0,0 +1,162 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+)
+
+// ErrUnknownIntegrityCheck is returned when a repair names a check that does
+// not exist
+var ErrUnknownIntegrityCheck = errors.New("unknown integrity check")
+
+// integrityCheck finds one kind of broken record and repairs it by a fixed rule
+type integrityCheck struct {
+	name        string
+	description string
+	repair      string
+	find        func(r *repositories.IntegrityRepository) (*repositories.IntegrityFindings, error)
+	fix         func(r *repositories.IntegrityRepository, actorID uint) (int64, error)
+}
+
+// integrityChecks are run, reported and repaired in this order
+var integrityChecks = []integrityCheck{
+	{
+		name:        "orphaned_product_categories",
+		description: "Category links whose product no longer exists, or whose category no longer exists or was deleted",
+		repair:      "Delete the links. Products keep their other categories.",
+		find:        (*repositories.IntegrityRepository).FindOrphanedProductCategories,
+		fix: func(r *repositories.IntegrityRepository, _ uint) (int64, error) {
+			repaired, err := r.DeleteOrphanedProductCategories()
+			if err == nil && repaired > 0 {
+				cache.Store.Delete(cache.CategoriesKey)
+			}
+			return repaired, err
+		},
+	},
+	{
+		name:        "reviews_of_deleted_products",
+		description: "Reviews of products that were deleted or no longer exist",
+		repair:      "Soft-delete the reviews, so they stay in the database but are no longer listed",
+		find:        (*repositories.IntegrityRepository).FindReviewsOfDeletedProducts,
+		fix: func(r *repositories.IntegrityRepository, _ uint) (int64, error) {
+			return r.DeleteReviewsOfDeletedProducts()
+		},
+	},
+	{
+		name:        "negative_stock",
+		description: "Products whose stock quantity is below zero",
+		repair:      "Set the stock to zero, recording an adjustment noted \"integrity repair\" in the stock ledger and the usual stock events",
+		find:        (*repositories.IntegrityRepository).FindNegativeStock,
+		fix: func(r *repositories.IntegrityRepository, actorID uint) (int64, error) {
+			productIDs, err := r.ResetNegativeStock(actorID)
+			if err != nil {
+				return 0, err
+			}
+			keys := make([]string, len(productIDs))
+			for i, id := range productIDs {
+				keys[i] = cache.ProductKey(id)
+			}
+			cache.Store.Delete(keys...)
+			notifyOutbox()
+			return int64(len(productIDs)), nil
+		},
+	},
+	{
+		name:        "wishlists_of_missing_users",
+		description: "Wishlist entries of users that no longer exist. Deleted and anonymized users still exist.",
+		repair:      "Permanently delete the entries",
+		find:        (*repositories.IntegrityRepository).FindWishlistsOfMissingUsers,
+		fix: func(r *repositories.IntegrityRepository, _ uint) (int64, error) {
+			return r.DeleteWishlistsOfMissingUsers()
+		},
+	},
+}
+
+// IntegrityService finds records that break the relationships the application
+// relies on, such as reviews of deleted products, and repairs them by
+// documented rules
+type IntegrityService struct {
+	integrityRepo *repositories.IntegrityRepository
+}
+
+// NewIntegrityService creates a new IntegrityService instance
+func NewIntegrityService() *IntegrityService {
+	return &IntegrityService{integrityRepo: repositories.NewIntegrityRepository(database.DB)}
+}
+
+// IntegrityCheckNames returns the names of the integrity checks in the order
+// they run
+func IntegrityCheckNames() []string {
+	names := make([]string, len(integrityChecks))
+	for i, check := range integrityChecks {
+		names[i] = check.name
+	}
+	return names
+}
+
+// Check runs every integrity check without changing anything
+func (s *IntegrityService) Check() (*dto.IntegrityReportResponse, error) {
+	report := &dto.IntegrityReportResponse{
+		CheckedAt: dto.NewTime(time.Now()),
+		Checks:    make([]dto.IntegrityCheckResponse, 0, len(integrityChecks)),
+	}
+	for _, check := range integrityChecks {
+		findings, err := check.find(s.integrityRepo)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", check.name, err)
+		}
+		report.Issues += findings.Count
+		report.Checks = append(report.Checks, dto.IntegrityCheckResponse{
+			Check:       check.name,
+			Description: check.description,
+			Repair:      check.repair,
+			Count:       findings.Count,
+			Samples:     findings.Samples,
+		})
+	}
+	return report, nil
+}
+
+// Repair repairs the records broken according to the named checks, or to
+// every check when names is empty. Each check is repaired in its own
+// statement or transaction, so a failure leaves the checks before it repaired.
+func (s *IntegrityService) Repair(names []string, actorID uint) (*dto.RepairIntegrityResponse, error) {
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !isIntegrityCheck(name) {
+			return nil, fmt.Errorf("%w %q, expected one of %s", ErrUnknownIntegrityCheck, name, strings.Join(IntegrityCheckNames(), ", "))
+		}
+		selected[name] = true
+	}
+
+	result := &dto.RepairIntegrityResponse{Checks: []dto.IntegrityRepairResponse{}}
+	for _, check := range integrityChecks {
+		if len(selected) > 0 && !selected[check.name] {
+			continue
+		}
+		repaired, err := check.fix(s.integrityRepo, actorID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", check.name, err)
+		}
+		result.Repaired += repaired
+		result.Checks = append(result.Checks, dto.IntegrityRepairResponse{Check: check.name, Repaired: repaired})
+	}
+	return result, nil
+}
+
+// isIntegrityCheck reports whether there is an integrity check with a name
+func isIntegrityCheck(name string) bool {
+	for _, check := range integrityChecks {
+		if check.name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/pkg/barcode"
+	"product-management/pkg/pdf"
+	"product-management/pkg/reqctx"
+)
+
+// ErrLabelProduct is returned when a product to print does not exist
+var ErrLabelProduct = errors.New("product not found")
+
+// Label templates
+const (
+	LabelSpecSheet  = "spec_sheet"
+	LabelShelfLabel = "shelf_label"
+)
+
+// labelTemplate lays products out on A4 pages as a grid of equally sized
+// cells, one product per cell
+type labelTemplate struct {
+	columns, rows int
+	margin        float64 // Page margin in points
+	draw          func(page *pdf.Page, cell box, product *models.Product, symbol *barcode.Symbol, rc reqctx.Context)
+}
+
+var labelTemplates = map[string]labelTemplate{
+	// One product per page
+	LabelSpecSheet: {columns: 1, rows: 1, margin: 20 * pdf.MM, draw: drawSpecSheet},
+	// 70 x 37 mm labels, 3 by 8 on a sheet
+	LabelShelfLabel: {columns: 3, rows: 8, margin: 0, draw: drawShelfLabel},
+}
+
+// box is a rectangle on a page, from its bottom left corner
+type box struct {
+	x, y, width, height float64
+}
+
+// LabelService renders printable spec sheets and shelf labels of products
+type LabelService struct {
+	productService *ProductService
+}
+
+// NewLabelService creates a new LabelService instance
+func NewLabelService(productService *ProductService) *LabelService {
+	return &LabelService{productService: productService}
+}
+
+// RenderLabels renders products in order as a PDF using a template, with
+// prices and dates in the request's currency, language and time zone. Products
+// with an SKU get a barcode in the given format.
+func (s *LabelService) RenderLabels(rc reqctx.Context, productIDs []uint, template, barcodeFormat string) ([]byte, error) {
+	layout, ok := labelTemplates[template]
+	if !ok {
+		return nil, fmt.Errorf("unknown label template %q", template)
+	}
+
+	products := make([]*models.Product, len(productIDs))
+	for i, id := range productIDs {
+		product, err := s.productService.GetProduct(id)
+		if err != nil {
+			return nil, err
+		}
+		if product == nil {
+			return nil, fmt.Errorf("%w: %d", ErrLabelProduct, id)
+		}
+		products[i] = product
+	}
+
+	document := pdf.New(pdf.A4Width, pdf.A4Height)
+	perPage := layout.columns * layout.rows
+	cellWidth := (pdf.A4Width - 2*layout.margin) / float64(layout.columns)
+	cellHeight := (pdf.A4Height - 2*layout.margin) / float64(layout.rows)
+
+	var page *pdf.Page
+	for i, product := range products {
+		if i%perPage == 0 {
+			page = document.AddPage()
+		}
+		slot := i % perPage
+		cell := box{
+			x:      layout.margin + float64(slot%layout.columns)*cellWidth,
+			y:      pdf.A4Height - layout.margin - float64(slot/layout.columns+1)*cellHeight,
+			width:  cellWidth,
+			height: cellHeight,
+		}
+
+		var symbol *barcode.Symbol
+		if sku := product.SKUValue(); sku != "" {
+			var err error
+			if barcodeFormat == BarcodeQR {
+				symbol, err = barcode.QR(sku)
+			} else {
+				symbol, err = barcode.Code128(sku)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		layout.draw(page, cell, product, symbol, rc)
+	}
+	return document.Bytes()
+}
+
+// drawSpecSheet fills a page with a product's name, price, barcode,
+// description and key attributes
+func drawSpecSheet(page *pdf.Page, cell box, product *models.Product, symbol *barcode.Symbol, rc reqctx.Context) {
+	top := cell.y + cell.height
+	textWidth := cell.width
+	if symbol != nil {
+		// The barcode sits in the top right corner, beside the heading
+		drawSymbol(page, symbol, box{x: cell.x + cell.width - 60*pdf.MM, y: top - 30*pdf.MM, width: 60 * pdf.MM, height: 30 * pdf.MM})
+		textWidth -= 65 * pdf.MM
+	}
+
+	y := top - 22
+	for _, line := range pdf.Wrap(pdf.HelveticaBold, 22, product.Name, textWidth) {
+		page.Text(cell.x, y, pdf.HelveticaBold, 22, line)
+		y -= 26
+	}
+	page.Text(cell.x, y-4, pdf.HelveticaBold, 18, rc.FormatPrice(product.Price))
+	y -= 30
+	if sku := product.SKUValue(); sku != "" {
+		page.Text(cell.x, y, pdf.Helvetica, 10, "SKU "+sku)
+		y -= 14
+	}
+
+	y = min(y, top-34*pdf.MM) - 8
+	page.Line(cell.x, y, cell.x+cell.width, y, 0.5)
+	y -= 24
+
+	if product.Description != "" {
+		for _, line := range pdf.Wrap(pdf.Helvetica, 11, product.Description, cell.width) {
+			if y < cell.y+60 {
+				break
+			}
+			page.Text(cell.x, y, pdf.Helvetica, 11, line)
+			y -= 15
+		}
+		y -= 15
+	}
+
+	categories := make([]string, len(product.Categories))
+	for i, category := range product.Categories {
+		categories[i] = category.Name
+	}
+	rating := "No reviews"
+	if product.RatingCount > 0 {
+		rating = fmt.Sprintf("%.1f / 5 from %d reviews", product.RatingAverage, product.RatingCount)
+	}
+	attributes := [][2]string{
+		{"Categories", strings.Join(categories, ", ")},
+		{"Status", string(product.Status)},
+		{"In stock", fmt.Sprintf("%d", product.StockQuantity)},
+		{"Rating", rating},
+	}
+
+	page.Text(cell.x, y, pdf.HelveticaBold, 13, "Specifications")
+	y -= 20
+	for i, attribute := range attributes {
+		if i%2 == 0 {
+			page.Rect(cell.x, y-5, cell.width, 18, 0.93)
+		}
+		page.Text(cell.x+6, y, pdf.HelveticaBold, 10, attribute[0])
+		page.Text(cell.x+40*pdf.MM, y, pdf.Helvetica, 10, pdf.Truncate(pdf.Helvetica, 10, attribute[1], cell.width-42*pdf.MM))
+		y -= 18
+	}
+
+	page.Text(cell.x, cell.y, pdf.Helvetica, 8, "Printed "+rc.FormatDate(time.Now()))
+}
+
+// drawShelfLabel fills a label with a product's name, price and barcode
+func drawShelfLabel(page *pdf.Page, cell box, product *models.Product, symbol *barcode.Symbol, rc reqctx.Context) {
+	padding := 4 * pdf.MM
+	inner := box{x: cell.x + padding, y: cell.y + padding, width: cell.width - 2*padding, height: cell.height - 2*padding}
+	top := inner.y + inner.height
+
+	lines := pdf.Wrap(pdf.HelveticaBold, 10, product.Name, inner.width)
+	if len(lines) > 2 {
+		lines = append(lines[:1], pdf.Truncate(pdf.HelveticaBold, 10, strings.Join(lines[1:], " "), inner.width))
+	}
+	y := top - 10
+	for _, line := range lines {
+		page.Text(inner.x, y, pdf.HelveticaBold, 10, line)
+		y -= 12
+	}
+	page.Text(inner.x, y-12, pdf.HelveticaBold, 20, rc.FormatPrice(product.Price))
+
+	if symbol == nil {
+		return
+	}
+	sku := product.SKUValue()
+	if len(symbol.Modules) > 1 {
+		// QR codes sit in the bottom right corner, the SKU beside them
+		side := 14 * pdf.MM
+		drawSymbol(page, symbol, box{x: inner.x + inner.width - side, y: inner.y, width: side, height: side})
+		page.Text(inner.x, inner.y, pdf.Helvetica, 7, pdf.Truncate(pdf.Helvetica, 7, sku, inner.width-side-2))
+		return
+	}
+	drawSymbol(page, symbol, box{x: inner.x, y: inner.y + 8, width: inner.width, height: 8 * pdf.MM})
+	page.Text(inner.x+(inner.width-pdf.TextWidth(pdf.Helvetica, 7, sku))/2, inner.y, pdf.Helvetica, 7, sku)
+}
+
+// drawSymbol draws a barcode and its quiet zone as large as fits in a box. QR
+// codes stay square; linear barcodes fill the box height.
+func drawSymbol(page *pdf.Page, symbol *barcode.Symbol, area box) {
+	columns := len(symbol.Modules[0])
+	module := area.width / float64(columns+2*symbol.QuietZone)
+	height := area.height
+	if len(symbol.Modules) > 1 {
+		module = min(module, area.height/float64(len(symbol.Modules)+2*symbol.QuietZone))
+		height = module
+	}
+	left := area.x + float64(symbol.QuietZone)*module
+	top := area.y + area.height
+	if len(symbol.Modules) > 1 {
+		top -= float64(symbol.QuietZone) * module
+	}
+
+	for row, modules := range symbol.Modules {
+		y := top - float64(row+1)*height
+		for x := 0; x < columns; {
+			if !modules[x] {
+				x++
+				continue
+			}
+			start := x
+			for x < columns && modules[x] {
+				x++
+			}
+			page.Rect(left+float64(start)*module, y, float64(x-start)*module, height, 0)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/lock"
+	"product-management/pkg/mailer"
+	"product-management/pkg/reqstats"
+	"product-management/pkg/webhook"
+)
+
+// Latency budget checks
+const (
+	// latencyBudgetMinRequests keeps a few slow requests to a quiet group from alerting
+	latencyBudgetMinRequests = 20
+	// latencyBudgetRoutes is the number of slowest routes named in an alert
+	latencyBudgetRoutes = 5
+	// latencyBudgetSlowQueries is the number of slow queries sampled in an alert
+	latencyBudgetSlowQueries = 5
+	// LatencyBudgetEvent is the event of budget alert webhooks
+	LatencyBudgetEvent = "latency_budget.exceeded"
+)
+
+// LatencyBudgetAlert describes a route group whose p95 latency exceeded its
+// budget. It is the body of the alert webhook and the data of the alert email.
+type LatencyBudgetAlert struct {
+	Group       string            `json:"group"`
+	BudgetMs    float64           `json:"budget_ms"`
+	P95Ms       float64           `json:"p95_ms"`
+	Requests    int64             `json:"requests"`
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	Routes      []SlowRoute       `json:"routes"`       // Slowest routes of the group, by p95
+	SlowQueries []SlowQuerySample `json:"slow_queries"` // Slowest queries this instance ran in the window
+}
+
+// SlowRoute is a route of a group over its latency budget
+type SlowRoute struct {
+	Method   string  `json:"method"`
+	Route    string  `json:"route"`
+	P95Ms    float64 `json:"p95_ms"`
+	Requests int64   `json:"requests"`
+}
+
+// SlowQuerySample is a slow database query sampled in a budget alert
+type SlowQuerySample struct {
+	SQL        string    `json:"sql"`
+	DurationMs float64   `json:"duration_ms"`
+	At         time.Time `json:"at"`
+}
+
+// LatencyBudgetService checks the p95 latency of route groups against their
+// budgets and alerts by email and webhook when one is exceeded
+type LatencyBudgetService struct {
+	statRepo      *repositories.RequestStatRepository
+	budgets       map[string]time.Duration
+	window        time.Duration
+	alertTo       string
+	webhookURL    string
+	webhookSecret string
+}
+
+// NewLatencyBudgetService creates a new LatencyBudgetService instance. Alerts
+// are emailed to alertTo and posted to webhookURL, each when not empty.
+func NewLatencyBudgetService(budgets map[string]time.Duration, window time.Duration, alertTo, webhookURL, webhookSecret string) *LatencyBudgetService {
+	return &LatencyBudgetService{
+		statRepo:      repositories.NewRequestStatRepository(database.DB),
+		budgets:       budgets,
+		window:        window,
+		alertTo:       alertTo,
+		webhookURL:    webhookURL,
+		webhookSecret: webhookSecret,
+	}
+}
+
+// Start checks the budgets every window. With several instances, the first to
+// check keeps the lock for most of the window, so the others skip their turn
+// and each alert is sent once. The returned function stops it.
+func (s *LatencyBudgetService) Start() func() {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(s.window)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			_, err := lock.Exclusive(context.Background(), lock.Default, "latency_budgets", s.window-s.window/10, func() {
+				alerts, err := s.Check()
+				if err != nil {
+					log.Printf("Warning: latency budget check failed: %v", err)
+				}
+				for i := range alerts {
+					s.send(&alerts[i])
+				}
+			})
+			if err != nil {
+				log.Printf("Warning: failed to lock latency budget check: %v", err)
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
+
+// routeGroup returns the group of a route pattern, its first segment after
+// /api/v1, or "" outside the API
+func routeGroup(route string) string {
+	rest, ok := strings.CutPrefix(route, "/api/v1/")
+	if !ok {
+		return ""
+	}
+	group, _, _ := strings.Cut(rest, "/")
+	return group
+}
+
+// latencyHistogram accumulates the latencies of a group or route
+type latencyHistogram struct {
+	requests  int64
+	maxMs     float64
+	histogram []int64
+}
+
+func (h *latencyHistogram) add(stat *models.RequestStat) {
+	if h.histogram == nil {
+		h.histogram = make([]int64, len(reqstats.LatencyBounds)+1)
+	}
+	h.requests += stat.Count
+	h.maxMs = math.Max(h.maxMs, stat.MaxMs)
+	reqstats.Merge(h.histogram, stat.LatencyBuckets)
+}
+
+func (h *latencyHistogram) p95() float64 {
+	return roundLatency(reqstats.Quantile(h.histogram, h.maxMs, 0.95))
+}
+
+// Check returns an alert for every group whose p95 latency over the last
+// window exceeded its budget
+func (s *LatencyBudgetService) Check() ([]LatencyBudgetAlert, error) {
+	to := time.Now().UTC()
+	from := to.Add(-s.window)
+
+	type routeKey struct{ method, route string }
+	groups := make(map[string]*latencyHistogram)
+	routes := make(map[string]map[routeKey]*latencyHistogram)
+	err := s.statRepo.Each(from, to, "", "", 1000, func(stat models.RequestStat) {
+		group := routeGroup(stat.Route)
+		if _, ok := s.budgets[group]; !ok {
+			return
+		}
+		if groups[group] == nil {
+			groups[group] = &latencyHistogram{}
+			routes[group] = make(map[routeKey]*latencyHistogram)
+		}
+		key := routeKey{stat.Method, stat.Route}
+		if routes[group][key] == nil {
+			routes[group][key] = &latencyHistogram{}
+		}
+		groups[group].add(&stat)
+		routes[group][key].add(&stat)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var slowQueries []SlowQuerySample
+	for _, query := range database.SlowQueries(from, latencyBudgetSlowQueries) {
+		slowQueries = append(slowQueries, SlowQuerySample{
+			SQL:        query.SQL,
+			DurationMs: roundLatency(float64(query.Duration) / float64(time.Millisecond)),
+			At:         query.At.UTC(),
+		})
+	}
+
+	var alerts []LatencyBudgetAlert
+	for group, latency := range groups {
+		budget := float64(s.budgets[group]) / float64(time.Millisecond)
+		p95 := latency.p95()
+		if latency.requests < latencyBudgetMinRequests || p95 <= budget {
+			continue
+		}
+
+		alert := LatencyBudgetAlert{
+			Group:       group,
+			BudgetMs:    budget,
+			P95Ms:       p95,
+			Requests:    latency.requests,
+			From:        from,
+			To:          to,
+			SlowQueries: slowQueries,
+		}
+		for key, route := range routes[group] {
+			alert.Routes = append(alert.Routes, SlowRoute{Method: key.method, Route: key.route, P95Ms: route.p95(), Requests: route.requests})
+		}
+		sort.Slice(alert.Routes, func(i, j int) bool { return alert.Routes[i].P95Ms > alert.Routes[j].P95Ms })
+		if len(alert.Routes) > latencyBudgetRoutes {
+			alert.Routes = alert.Routes[:latencyBudgetRoutes]
+		}
+		alerts = append(alerts, alert)
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Group < alerts[j].Group })
+	return alerts, nil
+}
+
+// send logs an alert, emails it and posts it to the webhook
+func (s *LatencyBudgetService) send(alert *LatencyBudgetAlert) {
+	log.Printf("Latency budget exceeded for %s: p95 %.0fms over %d requests, budget %.0fms",
+		alert.Group, alert.P95Ms, alert.Requests, alert.BudgetMs)
+
+	if s.alertTo != "" {
+		if err := mailer.Default.Enqueue(mailer.DefaultTenant, mailer.TemplateLatencyBudget, s.alertTo, alert); err != nil {
+			log.Printf("Warning: failed to queue latency budget alert for %s: %v", alert.Group, err)
+		}
+	}
+
+	if s.webhookURL != "" {
+		body, err := json.Marshal(alert)
+		if err != nil {
+			log.Printf("Warning: failed to encode latency budget alert: %v", err)
+			return
+		}
+		eventID, err := webhook.NewEventID()
+		if err != nil {
+			log.Printf("Warning: failed to create latency budget event ID: %v", err)
+			return
+		}
+		if _, err := webhook.Send(context.Background(), s.webhookURL, s.webhookSecret, LatencyBudgetEvent, eventID, body); err != nil {
+			log.Printf("Warning: failed to post latency budget alert for %s: %v", alert.Group, err)
+		}
+	}
+}
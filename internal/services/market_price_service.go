@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"product-management/pkg/marketprices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrProductWithoutSKU = errors.New("product has no SKU to look up market prices by")
+	ErrSKUNotTracked     = errors.New("market prices are not tracked for this SKU")
+)
+
+// CheckMarketPriceProviders reports the first invalid market price provider
+// configuration, so a typo fails at startup instead of at the first lookup
+func CheckMarketPriceProviders(configs []marketprices.Config) error {
+	seen := make(map[string]bool, len(configs))
+	for _, cfg := range configs {
+		if seen[cfg.Name] {
+			return fmt.Errorf("market price provider %s is configured twice", cfg.Name)
+		}
+		seen[cfg.Name] = true
+		if _, err := marketprices.New(cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarketQuote is a competitor price and the provider it came from
+type MarketQuote struct {
+	marketprices.Quote
+	Provider  string
+	FetchedAt time.Time
+}
+
+// MarketPrices are the competitor prices of a product across providers
+type MarketPrices struct {
+	Product *models.Product
+	Quotes  []MarketQuote     // Cheapest first
+	Errors  map[string]string // Providers that failed, with their errors
+}
+
+// marketPriceEntry is what a provider returned for a SKU, as cached
+type marketPriceEntry struct {
+	Quotes    []marketprices.Quote `json:"quotes"`
+	FetchedAt time.Time            `json:"fetched_at"`
+}
+
+// MarketPriceService looks up the prices competitors sell products at through
+// external price comparison APIs, for pricing teams. Results are cached per
+// provider and SKU, and calls to each API are kept within its rate limit.
+type MarketPriceService struct {
+	providers   []*marketprices.Guarded
+	skus        map[string]bool // Empty allows every SKU
+	cacheTTL    time.Duration
+	timeout     time.Duration
+	productRepo *repositories.ProductRepository
+}
+
+// NewMarketPriceService creates a new MarketPriceService instance. Lookups
+// are limited to skus unless it is empty, reuse prices for cacheTTL and wait
+// at most timeout for the providers.
+func NewMarketPriceService(configs []marketprices.Config, skus []string, cacheTTL, timeout time.Duration) *MarketPriceService {
+	providers := make([]*marketprices.Guarded, 0, len(configs))
+	for _, cfg := range configs {
+		provider, err := marketprices.New(cfg)
+		if err != nil {
+			log.Printf("Warning: skipping %v", err)
+			continue
+		}
+		providers = append(providers, provider)
+	}
+	tracked := make(map[string]bool, len(skus))
+	for _, sku := range skus {
+		tracked[sku] = true
+	}
+	return &MarketPriceService{
+		providers:   providers,
+		skus:        tracked,
+		cacheTTL:    cacheTTL,
+		timeout:     timeout,
+		productRepo: repositories.NewProductRepository(database.DB),
+	}
+}
+
+// MarketPrices looks up the competitor prices of a product with every
+// provider concurrently, from the cache unless refresh is set. A provider
+// that fails is reported in Errors without failing the others. It returns
+// gorm.ErrRecordNotFound when no product has the ID.
+func (s *MarketPriceService) MarketPrices(productID uint, refresh bool) (*MarketPrices, error) {
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	sku := product.SKUValue()
+	if sku == "" {
+		return nil, ErrProductWithoutSKU
+	}
+	if len(s.skus) > 0 && !s.skus[sku] {
+		return nil, ErrSKUNotTracked
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	prices := &MarketPrices{Product: product, Quotes: []MarketQuote{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, provider := range s.providers {
+		wg.Add(1)
+		go func(provider *marketprices.Guarded) {
+			defer wg.Done()
+			entry, err := s.fetch(ctx, provider, sku, refresh)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Printf("Warning: market price provider %s failed for SKU %s: %v", provider.Name, sku, err)
+				if prices.Errors == nil {
+					prices.Errors = make(map[string]string)
+				}
+				prices.Errors[provider.Name] = err.Error()
+				return
+			}
+			for _, quote := range entry.Quotes {
+				prices.Quotes = append(prices.Quotes, MarketQuote{Quote: quote, Provider: provider.Name, FetchedAt: entry.FetchedAt})
+			}
+		}(provider)
+	}
+	wg.Wait()
+
+	sort.SliceStable(prices.Quotes, func(i, j int) bool { return prices.Quotes[i].Price < prices.Quotes[j].Price })
+	return prices, nil
+}
+
+// fetch returns what a provider knows of a SKU, through the cache unless
+// refresh is set. Concurrent lookups of the same SKU share one call.
+func (s *MarketPriceService) fetch(ctx context.Context, provider *marketprices.Guarded, sku string, refresh bool) (*marketPriceEntry, error) {
+	key := cache.MarketPricesKey(provider.Name, sku)
+	if !refresh {
+		var cached marketPriceEntry
+		if cache.Store.Get(key, &cached) {
+			return &cached, nil
+		}
+	}
+
+	value, err, _ := readGroup.Do(key, func() (interface{}, error) {
+		quotes, err := provider.Fetch(ctx, sku)
+		if err != nil {
+			return nil, err
+		}
+		entry := &marketPriceEntry{Quotes: quotes, FetchedAt: time.Now()}
+		cache.Store.Set(key, entry, s.cacheTTL)
+		return entry, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*marketPriceEntry), nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/geoip"
+	"product-management/pkg/mailer"
+	"product-management/pkg/notifier"
+	"product-management/pkg/utils"
+)
+
+// NotificationService delivers notifications to users on the channels they chose
+type NotificationService struct {
+	settingsRepo *repositories.NotificationSettingsRepository
+}
+
+// NewNotificationService creates a new NotificationService instance
+func NewNotificationService() *NotificationService {
+	return &NotificationService{
+		settingsRepo: repositories.NewNotificationSettingsRepository(database.DB),
+	}
+}
+
+// GetSettings retrieves a user's notification settings
+func (s *NotificationService) GetSettings(userID uint) (*models.NotificationSettings, error) {
+	return s.settingsRepo.GetByUserID(userID)
+}
+
+// UpdateSettings applies the provided fields to a user's notification settings
+func (s *NotificationService) UpdateSettings(userID uint, req dto.UpdateNotificationSettingsRequest) (*models.NotificationSettings, error) {
+	settings, err := s.settingsRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.SecurityAlerts != nil {
+		settings.SecurityAlerts = models.NotificationChannels(*req.SecurityAlerts)
+	}
+	if req.OrderUpdates != nil {
+		settings.OrderUpdates = models.NotificationChannels(*req.OrderUpdates)
+	}
+	if req.PhoneNumber != nil {
+		settings.PhoneNumber = *req.PhoneNumber
+	}
+	if req.PushToken != nil {
+		settings.PushToken = *req.PushToken
+	}
+	if req.WeeklyDigest != nil {
+		settings.DigestUnsubscribed = !*req.WeeklyDigest
+	}
+	if req.Promotions != nil {
+		settings.PromotionsOptedOut = !*req.Promotions
+	}
+	if err := s.settingsRepo.Save(settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Notify delivers a notification of a kind to a user on every channel selected
+// in their settings. Channels without an address or provider are skipped and
+// delivery failures are logged.
+func (s *NotificationService) Notify(user *models.User, kind models.NotificationKind, notification notifier.Notification) {
+	settings, err := s.settingsRepo.GetByUserID(user.ID)
+	if err != nil {
+		log.Printf("Warning: failed to load notification settings of user %d: %v", user.ID, err)
+		return
+	}
+	channels := settings.Channels(kind)
+
+	if channels.Email {
+		if err := mailer.Default.Enqueue(mailer.DefaultTenant, mailer.TemplateNotification, user.Email, notification); err != nil {
+			log.Printf("Warning: failed to queue %s email to user %d: %v", kind, user.ID, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if channels.SMS && settings.PhoneNumber != "" && notifier.SMS != nil {
+		if err := notifier.SMS.Notify(ctx, settings.PhoneNumber, notification); err != nil {
+			log.Printf("Warning: failed to send %s SMS to user %d: %v", kind, user.ID, err)
+		}
+	}
+	if channels.Push && settings.PushToken != "" && notifier.Push != nil {
+		if err := notifier.Push.Notify(ctx, settings.PushToken, notification); err != nil {
+			log.Printf("Warning: failed to send %s push to user %d: %v", kind, user.ID, err)
+		}
+	}
+}
+
+// CheckLoginDevice records the device of a login and sends a security alert
+// when a user who logged in before signs in from a device never seen for them
+func (s *NotificationService) CheckLoginDevice(user *models.User, userAgent, ip string, location geoip.Location) {
+	fingerprint := sha256.Sum256([]byte(userAgent))
+	isNew, hadDevices, err := s.settingsRepo.TouchDevice(user.ID, hex.EncodeToString(fingerprint[:]), userAgent, ip, location.Country, location.City)
+	if err != nil {
+		log.Printf("Warning: failed to record login device of user %d: %v", user.ID, err)
+		return
+	}
+	if !isNew || !hadDevices {
+		return
+	}
+
+	origin := "IP " + ip
+	if place := location.String(); place != "" {
+		origin += " in " + place
+	}
+	s.Notify(user, models.NotificationSecurityAlert, notifier.Notification{
+		Title: "New sign-in to your account",
+		Body: fmt.Sprintf("Your account %s was signed in to from a new device (%s, %s) at %s UTC. If this wasn't you, change your password.",
+			user.Username, userAgent, origin, time.Now().UTC().Format("2006-01-02 15:04")),
+		Data: map[string]string{"kind": string(models.NotificationSecurityAlert)},
+	})
+}
+
+// ErrInvalidUnsubscribeToken is returned for a malformed or forged unsubscribe token
+var ErrInvalidUnsubscribeToken = errors.New("invalid unsubscribe token")
+
+// UnsubscribeToken returns the token of a user's digest unsubscribe link. It
+// does not expire, so links in old emails keep working.
+func UnsubscribeToken(userID uint) string {
+	id := strconv.FormatUint(uint64(userID), 10)
+	return id + "." + unsubscribeSignature(id)
+}
+
+// UnsubscribeDigest turns off digest emails for the user of an unsubscribe token
+func (s *NotificationService) UnsubscribeDigest(token string) error {
+	id, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(unsubscribeSignature(id))) {
+		return ErrInvalidUnsubscribeToken
+	}
+	userID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return ErrInvalidUnsubscribeToken
+	}
+
+	settings, err := s.settingsRepo.GetByUserID(uint(userID))
+	if err != nil {
+		return err
+	}
+	settings.DigestUnsubscribed = true
+	return s.settingsRepo.Save(settings)
+}
+
+// unsubscribeSignature signs a user ID for unsubscribe links
+func unsubscribeSignature(id string) string {
+	mac := hmac.New(sha256.New, []byte(utils.GetEnv("JWT_SECRET", "your-secret-key")))
+	mac.Write([]byte("digest-unsubscribe:" + id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
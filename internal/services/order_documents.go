@@ -0,0 +1,259 @@
+package services
+
+import (
+	"strconv"
+	"strings"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/pkg/pdf"
+	"product-management/pkg/reqctx"
+)
+
+// orderDocumentMargin is the page margin of invoices and packing slips
+const orderDocumentMargin = 20 * pdf.MM
+
+// orderDocument writes an order's printable document on A4 pages from top to
+// bottom, starting a new page when the next line doesn't fit
+type orderDocument struct {
+	document *pdf.Document
+	page     *pdf.Page
+	y        float64 // Baseline of the last line written
+	left     float64
+	right    float64
+}
+
+// newOrderDocument starts a document with a heading naming the store and the
+// kind of document, followed by its detail lines
+func newOrderDocument(storeName, title string, details ...string) *orderDocument {
+	d := &orderDocument{
+		document: pdf.New(pdf.A4Width, pdf.A4Height),
+		left:     orderDocumentMargin,
+		right:    pdf.A4Width - orderDocumentMargin,
+	}
+	d.addPage()
+
+	d.text(0, 20, pdf.HelveticaBold, 20, storeName)
+	d.page.Text(d.right-pdf.TextWidth(pdf.HelveticaBold, 16, title), d.y, pdf.HelveticaBold, 16, title)
+	d.y -= 13
+	for _, detail := range details {
+		for _, line := range pdf.Wrap(pdf.Helvetica, 11, detail, d.right-d.left) {
+			d.text(0, 15, pdf.Helvetica, 11, line)
+		}
+	}
+	d.y -= 12
+	return d
+}
+
+// orderDetails returns the detail lines of an order's documents: its number
+// and the day it was placed
+func orderDetails(rc reqctx.Context, orderNumber string, quote *models.Quote) []string {
+	details := []string{"Order " + orderNumber}
+	if quote.DecidedAt != nil {
+		details = append(details, "Placed "+rc.FormatDate(*quote.DecidedAt))
+	}
+	return details
+}
+
+// addPage starts a new page and moves to its top
+func (d *orderDocument) addPage() {
+	d.page = d.document.AddPage()
+	d.y = pdf.A4Height - orderDocumentMargin
+}
+
+// next moves down by height to the baseline of the next line, starting a new
+// page when it would fall in the bottom margin
+func (d *orderDocument) next(height float64) {
+	d.y -= height
+	if d.y < orderDocumentMargin {
+		d.addPage()
+		d.y -= height
+	}
+}
+
+// text writes a line at x from the left margin, height below the last one
+func (d *orderDocument) text(x, height float64, font pdf.Font, size float64, text string) {
+	d.next(height)
+	d.page.Text(d.left+x, d.y, font, size, text)
+}
+
+// rightText writes text on the current line, ending at x from the left margin
+func (d *orderDocument) rightText(x float64, font pdf.Font, size float64, text string) {
+	d.page.Text(d.left+x-pdf.TextWidth(font, size, text), d.y, font, size, text)
+}
+
+// documentColumn is a column of an order document table, at x from the left margin
+type documentColumn struct {
+	title string
+	x     float64
+	right bool // Aligned on its right edge at x
+}
+
+// header writes the title row of a table on a gray band
+func (d *orderDocument) header(columns []documentColumn) {
+	d.next(24)
+	d.page.Rect(d.left, d.y-5, d.right-d.left, 18, 0.9)
+	d.row(columns, pdf.HelveticaBold, columnTitles(columns)...)
+}
+
+// row writes the cells of a table row on the current line. Cells of
+// left-aligned columns are truncated to leave room for the right-aligned
+// column after them.
+func (d *orderDocument) row(columns []documentColumn, font pdf.Font, cells ...string) {
+	for i, cell := range cells {
+		c := columns[i]
+		if cell == "" {
+			continue
+		}
+		if c.right {
+			d.rightText(c.x, font, 10, cell)
+			continue
+		}
+		width := d.right - d.left - c.x
+		if i+1 < len(columns) {
+			width = columns[i+1].x - c.x - 40
+		}
+		d.page.Text(d.left+c.x+6, d.y, font, 10, pdf.Truncate(font, 10, cell, width))
+	}
+}
+
+// rule draws a line across the page below the current line
+func (d *orderDocument) rule() {
+	d.next(10)
+	d.page.Line(d.left, d.y, d.right, d.y, 0.5)
+}
+
+// columnTitles returns the titles of table columns
+func columnTitles(columns []documentColumn) []string {
+	cells := make([]string, len(columns))
+	for i, c := range columns {
+		cells[i] = c.title
+	}
+	return cells
+}
+
+// invoiceColumns are the columns of an invoice's items
+var invoiceColumns = []documentColumn{
+	{title: "Product", x: 0},
+	{title: "Qty", x: 115 * pdf.MM, right: true},
+	{title: "Unit price", x: 145 * pdf.MM, right: true},
+	{title: "Amount", x: pdf.A4Width - 2*orderDocumentMargin - 6, right: true},
+}
+
+// drawInvoice writes the items of an order with their prices and gift
+// wrapping, and its totals. Items the customer chose to hide the price of are
+// listed without it, and so are the totals, which would give it away.
+func drawInvoice(d *orderDocument, rc reqctx.Context, quote *models.Quote, billedTo string) {
+	if billedTo != "" {
+		d.text(0, 15, pdf.Helvetica, 11, "Billed to "+billedTo)
+	}
+	d.header(invoiceColumns)
+	for _, item := range quote.Items {
+		unitPrice, amount := "", ""
+		if !item.HidePrice && item.QuotedPrice != nil {
+			unitPrice = rc.FormatPrice(*item.QuotedPrice)
+			amount = rc.FormatPrice(*item.QuotedPrice * float64(item.Quantity))
+		}
+		d.next(18)
+		d.row(invoiceColumns, pdf.Helvetica, item.Product.Name, strconv.Itoa(item.Quantity), unitPrice, amount)
+		if item.GiftWrap {
+			fee := ""
+			if !item.HidePrice {
+				fee = rc.FormatPrice(item.GiftWrapFee)
+			}
+			d.next(14)
+			d.row(invoiceColumns, pdf.Helvetica, "  Gift wrap", strconv.Itoa(item.Quantity), "", fee)
+		}
+	}
+	d.rule()
+
+	if quote.HidesPrices() {
+		d.text(0, 18, pdf.Helvetica, 10, "Prices are not shown on this invoice.")
+		return
+	}
+	totals := [][2]string{{"Subtotal", rc.FormatPrice(quote.Subtotal())}}
+	if fees := quote.GiftWrapFees(); fees > 0 {
+		totals = append(totals, [2]string{"Gift wrapping", rc.FormatPrice(fees)})
+	}
+	totals = append(totals, [2]string{"Total", rc.FormatPrice(quote.Total())})
+	for i, total := range totals {
+		font := pdf.Helvetica
+		if i == len(totals)-1 {
+			font = pdf.HelveticaBold
+		}
+		d.next(18)
+		d.rightText(invoiceColumns[2].x, font, 10, total[0])
+		d.rightText(invoiceColumns[3].x, font, 10, total[1])
+	}
+}
+
+// packingSlipColumns are the columns of a packing slip's items
+var packingSlipColumns = []documentColumn{
+	{title: "Product", x: 0},
+	{title: "SKU", x: 100 * pdf.MM},
+	{title: "Qty", x: 145 * pdf.MM, right: true},
+	{title: "Gift wrap", x: pdf.A4Width - 2*orderDocumentMargin - 6, right: true},
+}
+
+// drawPackingSlip writes the items to pack, which to gift wrap and the gift
+// messages to enclose, without any price
+func drawPackingSlip(d *orderDocument, items []models.QuoteItem) {
+	d.header(packingSlipColumns)
+	for _, item := range items {
+		wrap := ""
+		if item.GiftWrap {
+			wrap = "Yes"
+		}
+		d.next(18)
+		d.row(packingSlipColumns, pdf.Helvetica, item.Product.Name, item.Product.SKUValue(), strconv.Itoa(item.Quantity), wrap)
+	}
+	d.rule()
+
+	first := true
+	for _, item := range items {
+		if item.GiftMessage == "" {
+			continue
+		}
+		if first {
+			d.text(0, 24, pdf.HelveticaBold, 13, "Gift messages")
+			first = false
+		}
+		d.text(0, 20, pdf.HelveticaBold, 10, pdf.Truncate(pdf.HelveticaBold, 10, "With "+item.Product.Name, d.right-d.left))
+		for _, line := range pdf.Wrap(pdf.Helvetica, 10, item.GiftMessage, d.right-d.left) {
+			d.text(0, 14, pdf.Helvetica, 10, line)
+		}
+	}
+}
+
+// pickListColumns are the columns of a pick list's lines
+var pickListColumns = []documentColumn{
+	{title: "Product", x: 0},
+	{title: "SKU", x: 85 * pdf.MM},
+	{title: "Order", x: 125 * pdf.MM},
+	{title: "Qty", x: pdf.A4Width - 2*orderDocumentMargin - 6, right: true},
+}
+
+// drawPickList writes the orders a pick list covers, then the products to pick
+// at each location with their total quantity and how it splits across orders
+func drawPickList(d *orderDocument, orderNumbers []string, locations []dto.PickLocationResponse) {
+	for _, line := range pdf.Wrap(pdf.Helvetica, 10, "Orders: "+strings.Join(orderNumbers, ", "), d.right-d.left) {
+		d.text(0, 14, pdf.Helvetica, 10, line)
+	}
+	for _, location := range locations {
+		name := location.Location
+		if name == "" {
+			name = "No location"
+		}
+		d.text(0, 28, pdf.HelveticaBold, 13, pdf.Truncate(pdf.HelveticaBold, 13, name, d.right-d.left))
+		d.header(pickListColumns)
+		for _, line := range location.Lines {
+			d.next(18)
+			d.row(pickListColumns, pdf.HelveticaBold, line.ProductName, line.SKU, "", strconv.Itoa(line.Quantity))
+			for _, order := range line.Orders {
+				d.next(14)
+				d.row(pickListColumns, pdf.Helvetica, "", "", order.OrderNumber, strconv.Itoa(order.Quantity))
+			}
+		}
+		d.rule()
+	}
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/events"
+	"product-management/pkg/notifier"
+
+	"gorm.io/gorm"
+)
+
+// orderPlacement is the state of an order placement saga
+type orderPlacement struct {
+	QuoteID         uint         `json:"quote_id"`
+	OrderNumber     string       `json:"order_number,omitempty"` // Store order number prefix followed by the quote ID
+	UserID          uint         `json:"user_id"`
+	Total           float64      `json:"total"`
+	Quantities      map[uint]int `json:"quantities"`                  // Ordered, by product ID
+	CreditApplied   float64      `json:"credit_applied,omitempty"`    // Store credit spent on the payment
+	GiftCardID      uint         `json:"gift_card_id,omitempty"`      // Gift card paying before store credit
+	GiftCardApplied float64      `json:"gift_card_applied,omitempty"` // Taken from the gift card
+}
+
+// number returns the order number, or the saga reference for orders placed
+// before order numbers were assigned
+func (o orderPlacement) number(saga *models.Saga) string {
+	if o.OrderNumber != "" {
+		return o.OrderNumber
+	}
+	return saga.Reference
+}
+
+// orderPlacementSteps place an accepted quote as an order: its quantities are
+// reserved in stock, the gift card given at checkout and then the customer's
+// store credit pay for what they can and the customer is notified. The
+// reservation is released and the payment voided when a later step fails for good.
+var orderPlacementSteps = []sagaStep{
+	{name: "reserve_stock", action: reserveOrderStock, compensate: releaseOrderStock, committed: orderStockMoved},
+	{name: "charge_payment", action: chargeOrderPayment, compensate: voidOrderPayment},
+	{name: "notify_customer", action: notifyOrderPlaced},
+}
+
+// startOrderPlacement starts placing an accepted quote as an order within the
+// transaction accepting it, paid with the gift card giftCardID when not 0,
+// queues the order for the warehouse and records it as processing
+func startOrderPlacement(tx *gorm.DB, quote *models.Quote, giftCardID uint) error {
+	quantities := make(map[uint]int, len(quote.Items))
+	for _, item := range quote.Items {
+		quantities[item.ProductID] += item.Quantity
+	}
+	orderNumber := NewSettingService().Value(models.SettingOrderNumberPrefix) + strconv.FormatUint(uint64(quote.ID), 10)
+	if err := queueWarehouseOrder(tx, quote, orderNumber); err != nil {
+		return err
+	}
+	if err := recordOrderStatus(tx, quote.ID, quote.UserID, orderNumber, OrderProcessing); err != nil {
+		return err
+	}
+	return startSaga(tx, models.SagaOrderPlacement, quote.Reference(), orderPlacement{
+		QuoteID:     quote.ID,
+		OrderNumber: orderNumber,
+		UserID:      quote.UserID,
+		Total:       math.Round(quote.Total()*100) / 100,
+		Quantities:  quantities,
+		GiftCardID:  giftCardID,
+	})
+}
+
+// orderPlacementStatusChanged records the order of a placement saga as
+// confirmed when the saga completes, and as cancelled when it starts undoing
+// its steps
+func orderPlacementStatusChanged(tx *gorm.DB, saga *models.Saga, status models.SagaStatus) error {
+	var orderStatus string
+	switch status {
+	case models.SagaCompleted:
+		orderStatus = OrderConfirmed
+	case models.SagaCompensating:
+		orderStatus = OrderCancelled
+	default:
+		return nil
+	}
+	var order orderPlacement
+	if err := decodeSagaData(saga, &order); err != nil {
+		// The saga must still stop, so only the event is lost
+		log.Printf("Warning: failed to read order placement %s: %v", saga.Reference, err)
+		return nil
+	}
+	return recordOrderStatus(tx, order.QuoteID, order.UserID, order.number(saga), orderStatus)
+}
+
+// recordOrderStatus adds an OrderStatusChanged event to the outbox within tx
+func recordOrderStatus(tx *gorm.DB, quoteID, userID uint, orderNumber, status string) error {
+	return repositories.NewOutboxRepository(tx).Record(events.OrderStatusChanged{
+		QuoteID:     quoteID,
+		UserID:      userID,
+		OrderNumber: orderNumber,
+		Status:      status,
+	})
+}
+
+// reserveOrderStock takes the ordered quantities out of stock, failing for good
+// when a product is out of stock or no longer exists
+func reserveOrderStock(tx *gorm.DB, saga *models.Saga) error {
+	var order orderPlacement
+	if err := decodeSagaData(saga, &order); err != nil {
+		return abortSaga(err)
+	}
+	_, err := repositories.NewProductRepository(tx).ReserveStock(order.Quantities, saga.Reference, order.UserID)
+	switch {
+	case errors.Is(err, repositories.ErrInsufficientStock):
+		return abortSaga(err)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return abortSaga(ErrQuoteProduct)
+	}
+	return err
+}
+
+// releaseOrderStock puts the reserved quantities back in stock
+func releaseOrderStock(tx *gorm.DB, saga *models.Saga) error {
+	var order orderPlacement
+	if err := decodeSagaData(saga, &order); err != nil {
+		return err
+	}
+	_, err := repositories.NewProductRepository(tx).ReleaseStock(order.Quantities, saga.Reference, order.UserID)
+	return err
+}
+
+// orderStockMoved drops the cached products whose stock was reserved or
+// released, and publishes the events recorded with the movements
+func orderStockMoved(saga *models.Saga) {
+	var order orderPlacement
+	if err := decodeSagaData(saga, &order); err != nil {
+		return
+	}
+	for productID := range order.Quantities {
+		cache.Store.Delete(cache.ProductKey(productID))
+	}
+	notifyOutbox()
+}
+
+// chargeOrderPayment redeems the gift card given at checkout and spends the
+// customer's store credit on what it leaves of the order total. Whatever they
+// do not cover is settled outside the system. A card that expired or ran out
+// since checkout is skipped.
+func chargeOrderPayment(tx *gorm.DB, saga *models.Saga) error {
+	var order orderPlacement
+	if err := decodeSagaData(saga, &order); err != nil {
+		return abortSaga(err)
+	}
+	giftCardRepo := repositories.NewGiftCardRepository(tx)
+
+	due := order.Total
+	if order.GiftCardID != 0 && due > 0 {
+		entry, err := giftCardRepo.RedeemByID(order.GiftCardID, due, saga.Reference, order.UserID)
+		switch {
+		case errors.Is(err, repositories.ErrGiftCardExpired), errors.Is(err, repositories.ErrGiftCardEmpty):
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return abortSaga(ErrQuoteGiftCard)
+		case err != nil:
+			return err
+		default:
+			order.GiftCardApplied = -entry.Delta
+			due = math.Round((due-order.GiftCardApplied)*100) / 100
+		}
+	}
+
+	applied, err := spendStoreCredit(giftCardRepo, order.UserID, due, saga.Reference)
+	if err != nil {
+		return err
+	}
+	order.CreditApplied = applied
+	return encodeSagaData(saga, order)
+}
+
+// voidOrderPayment gives back the gift card balance and refunds the store
+// credit spent on the order
+func voidOrderPayment(tx *gorm.DB, saga *models.Saga) error {
+	var order orderPlacement
+	if err := decodeSagaData(saga, &order); err != nil {
+		return err
+	}
+	giftCardRepo := repositories.NewGiftCardRepository(tx)
+	if order.GiftCardApplied > 0 {
+		if _, err := giftCardRepo.Restore(order.GiftCardID, order.GiftCardApplied, saga.Reference, "order placement failed"); err != nil {
+			return err
+		}
+	}
+	return refundStoreCredit(giftCardRepo, order.UserID, order.CreditApplied, saga.Reference, "order placement failed")
+}
+
+// notifyOrderPlaced tells the customer their order was placed, with its
+// tracking link, unless their account was deleted since
+func notifyOrderPlaced(tx *gorm.DB, saga *models.Saga) error {
+	var order orderPlacement
+	if err := decodeSagaData(saga, &order); err != nil {
+		return abortSaga(err)
+	}
+	var user models.User
+	err := tx.First(&user, order.UserID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	trackingURL := OrderTrackingURL(order.QuoteID)
+	NewNotificationService().Notify(&user, models.NotificationOrderUpdate, notifier.Notification{
+		Title: "Order placed",
+		Body:  fmt.Sprintf("Your order %s for quote #%d has been placed. Track it, or share the link with its recipient: %s", order.number(saga), order.QuoteID, trackingURL),
+		Data: map[string]string{
+			"quote_id":     strconv.FormatUint(uint64(order.QuoteID), 10),
+			"tracking_url": trackingURL,
+		},
+	})
+	return nil
+}
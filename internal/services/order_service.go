@@ -0,0 +1,459 @@
+package services
+
+import (
+	"cmp"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/notifier"
+	"product-management/pkg/reqctx"
+	"product-management/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+// pickListMaxOrders is how many open orders a pick list takes at most
+const pickListMaxOrders = 100
+
+var (
+	ErrInvalidTrackingToken = errors.New("invalid tracking token")
+	ErrOrderNotFound        = errors.New("order not found")
+	ErrOrderCancelled       = errors.New("order was cancelled")
+	ErrOrderProcessing      = errors.New("order is still being placed")
+	ErrOrderShipped         = errors.New("order has already shipped in full")
+	ErrOrderNotOpen         = errors.New("order has nothing left to pick")
+	ErrShipmentItem         = errors.New("shipment items must be products of the order, each listed once")
+	ErrShipmentNotFound     = errors.New("shipment not found")
+)
+
+// Statuses of an order as shown on its tracking page
+const (
+	OrderProcessing = "processing" // Being placed: stock reserved and payment taken
+	OrderConfirmed  = "confirmed"  // Placed, waiting to ship
+	OrderShipped    = "shipped"    // At least one shipment was handed to a carrier
+	OrderCancelled  = "cancelled"  // Placing it failed and was undone
+)
+
+// OrderService tracks the orders placed from accepted quotes and their
+// shipments. Anyone holding an order's signed tracking link can follow it
+// without an account, such as the recipient of a gift.
+type OrderService struct {
+	quoteRepo      *repositories.QuoteRepository
+	sagaRepo       *repositories.SagaRepository
+	shipmentRepo   *repositories.ShipmentRepository
+	userRepo       *repositories.UserRepository
+	settingService *SettingService
+}
+
+// NewOrderService creates a new OrderService instance
+func NewOrderService() *OrderService {
+	return &OrderService{
+		quoteRepo:      repositories.NewQuoteRepository(database.DB),
+		sagaRepo:       repositories.NewSagaRepository(database.DB),
+		shipmentRepo:   repositories.NewShipmentRepository(database.DB),
+		userRepo:       repositories.NewUserRepository(database.DB),
+		settingService: NewSettingService(),
+	}
+}
+
+// OrderTrackingToken returns the token of the tracking link of the order
+// placed from a quote. It is signed, so it can't be guessed from the quote ID.
+func OrderTrackingToken(quoteID uint) string {
+	id := strconv.FormatUint(uint64(quoteID), 10)
+	return id + "." + trackingSignature(id)
+}
+
+// OrderTrackingURL returns the public tracking link of the order placed from a quote
+func OrderTrackingURL(quoteID uint) string {
+	appURL := strings.TrimSuffix(utils.GetEnv("APP_URL", "http://localhost:8080"), "/")
+	return appURL + "/api/v1/orders/track/" + OrderTrackingToken(quoteID)
+}
+
+// Track returns the status, items and shipments of the order of a tracking
+// token. Prices and the customer are left out, since the link may be shared.
+func (s *OrderService) Track(token string) (*dto.OrderTrackingResponse, error) {
+	id, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(trackingSignature(id))) {
+		return nil, ErrInvalidTrackingToken
+	}
+	quoteID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidTrackingToken
+	}
+
+	quote, err := s.acceptedQuote(uint(quoteID))
+	if err != nil {
+		return nil, err
+	}
+	orderNumber, status, err := s.orderState(quote)
+	if err != nil {
+		return nil, err
+	}
+	shipments, err := s.shipmentRepo.ListByQuote(quote.ID)
+	if err != nil {
+		return nil, err
+	}
+	if status == OrderConfirmed && len(shipments) > 0 {
+		status = OrderShipped
+	}
+
+	response := &dto.OrderTrackingResponse{
+		OrderNumber: orderNumber,
+		Status:      status,
+		PlacedAt:    dto.NewTimePtr(quote.DecidedAt),
+		Items:       make([]dto.TrackedItemResponse, len(quote.Items)),
+		Shipments:   make([]dto.ShipmentResponse, len(shipments)),
+	}
+	for i, item := range quote.Items {
+		response.Items[i] = dto.TrackedItemResponse{ProductName: item.Product.Name, Quantity: item.Quantity}
+		response.ItemCount += item.Quantity
+	}
+	for i := range shipments {
+		response.Shipments[i] = mappers.ToShipmentResponse(&shipments[i])
+	}
+	return response, nil
+}
+
+// AddShipment records a parcel of the order placed from a quote as handed to
+// a carrier, and tells the customer how to track it. The parcel holds the
+// requested quantities, or every unit not shipped yet.
+func (s *OrderService) AddShipment(quoteID, adminID uint, req dto.CreateShipmentRequest) (*models.Shipment, error) {
+	quote, err := s.acceptedQuote(quoteID)
+	if err != nil {
+		return nil, err
+	}
+	orderNumber, status, err := s.orderState(quote)
+	if err != nil {
+		return nil, err
+	}
+	switch status {
+	case OrderCancelled:
+		return nil, ErrOrderCancelled
+	case OrderProcessing:
+		return nil, ErrOrderProcessing
+	}
+
+	shipment := &models.Shipment{
+		QuoteID:        quote.ID,
+		Carrier:        req.Carrier,
+		TrackingNumber: req.TrackingNumber,
+		TrackingURL:    req.TrackingURL,
+		ShippedAt:      time.Now(),
+		RecordedBy:     adminID,
+	}
+	if shippedAt := optionalTime(req.ShippedAt); shippedAt != nil {
+		shipment.ShippedAt = *shippedAt
+	}
+	err = s.shipmentRepo.Create(shipment, func(tx *gorm.DB, shipped map[uint]int) error {
+		items, err := shipmentItems(quote, shipped, req.Items)
+		shipment.Items = items
+		if err != nil || len(shipped) > 0 {
+			return err
+		}
+		// The first parcel moves the order to shipped
+		return recordOrderStatus(tx, quote.ID, quote.UserID, orderNumber, OrderShipped)
+	})
+	if err != nil {
+		return nil, err
+	}
+	notifyOutbox()
+
+	s.notifyShipped(quote, orderNumber, shipment)
+	return s.shipmentRepo.GetByID(shipment.ID)
+}
+
+// shipmentItems resolves the requested items of a shipment against the units
+// of an order not shipped yet, or takes all of them when none are requested
+func shipmentItems(quote *models.Quote, shipped map[uint]int, requested []dto.ShipmentItemRequest) ([]models.ShipmentItem, error) {
+	var items []models.ShipmentItem
+	if len(requested) == 0 {
+		for _, item := range quote.Items {
+			if left := item.Quantity - shipped[item.ID]; left > 0 {
+				items = append(items, models.ShipmentItem{QuoteItemID: item.ID, Quantity: left})
+			}
+		}
+		if len(items) == 0 {
+			return nil, ErrOrderShipped
+		}
+		return items, nil
+	}
+
+	seen := make(map[uint]bool, len(requested))
+	for _, r := range requested {
+		i := slices.IndexFunc(quote.Items, func(item models.QuoteItem) bool { return item.ProductID == r.ProductID })
+		if i < 0 || seen[r.ProductID] {
+			return nil, ErrShipmentItem
+		}
+		seen[r.ProductID] = true
+		item := quote.Items[i]
+		if left := item.Quantity - shipped[item.ID]; r.Quantity > left {
+			return nil, fmt.Errorf("%w: %d of product %d left to ship", ErrShipmentItem, left, r.ProductID)
+		}
+		items = append(items, models.ShipmentItem{QuoteItemID: item.ID, Quantity: r.Quantity})
+	}
+	return items, nil
+}
+
+// RenderInvoice renders the invoice of the order placed from a quote as a PDF,
+// with prices and dates in the request's currency, language and time zone. A
+// non-zero userID limits it to that user's orders.
+func (s *OrderService) RenderInvoice(rc reqctx.Context, quoteID, userID uint) ([]byte, error) {
+	quote, orderNumber, err := s.placedOrder(quoteID, userID)
+	if err != nil {
+		return nil, err
+	}
+	billedTo := ""
+	if user, err := s.userRepo.GetByIDWithDeleted(quote.UserID); err == nil {
+		billedTo = user.FullName
+		if billedTo == "" {
+			billedTo = user.Username
+		}
+	}
+
+	document := newOrderDocument(s.settingService.Value(models.SettingStoreName), "Invoice", orderDetails(rc, orderNumber, quote)...)
+	drawInvoice(document, rc, quote, billedTo)
+	return document.document.Bytes()
+}
+
+// RenderPackingSlip renders the packing slip of the order placed from a quote
+// as a PDF, listing what to pack, what to gift wrap and the gift messages
+func (s *OrderService) RenderPackingSlip(rc reqctx.Context, quoteID uint) ([]byte, error) {
+	quote, orderNumber, err := s.placedOrder(quoteID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	document := newOrderDocument(s.settingService.Value(models.SettingStoreName), "Packing slip", orderDetails(rc, orderNumber, quote)...)
+	drawPackingSlip(document, quote.Items)
+	return document.document.Bytes()
+}
+
+// RenderShipmentPackingSlip renders the packing slip of a shipment as a PDF,
+// listing only what goes in its parcel and the gift messages of those items
+func (s *OrderService) RenderShipmentPackingSlip(rc reqctx.Context, shipmentID uint) ([]byte, error) {
+	shipment, err := s.shipmentRepo.GetByID(shipmentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrShipmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	quote, orderNumber, err := s.placedOrder(shipment.QuoteID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.QuoteItem, len(shipment.Items))
+	for i, item := range shipment.Items {
+		items[i] = item.QuoteItem
+		items[i].Quantity = item.Quantity
+	}
+	details := append(orderDetails(rc, orderNumber, quote),
+		fmt.Sprintf("Shipped %s with %s, tracking number %s", rc.FormatDate(shipment.ShippedAt), shipment.Carrier, shipment.TrackingNumber))
+	document := newOrderDocument(s.settingService.Value(models.SettingStoreName), "Packing slip", details...)
+	drawPackingSlip(document, items)
+	return document.document.Bytes()
+}
+
+// PickList returns the units left to ship of a batch of open orders, grouped
+// by the warehouse location of their products in walking order. Without quote
+// IDs, it takes the oldest open orders.
+func (s *OrderService) PickList(quoteIDs []uint) (*dto.PickListResponse, error) {
+	quoteIDs = slices.Compact(slices.Sorted(slices.Values(quoteIDs)))
+	quotes, err := s.quoteRepo.ListOpenOrders(quoteIDs, pickListMaxOrders)
+	if err != nil {
+		return nil, err
+	}
+	if len(quotes) < len(quoteIDs) {
+		for _, id := range quoteIDs {
+			if !slices.ContainsFunc(quotes, func(quote models.Quote) bool { return quote.ID == id }) {
+				return nil, fmt.Errorf("%w: quote %d", ErrOrderNotOpen, id)
+			}
+		}
+	}
+	ids := make([]uint, len(quotes))
+	for i := range quotes {
+		ids[i] = quotes[i].ID
+	}
+	shipped, err := s.shipmentRepo.ShippedQuantities(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	pickList := &dto.PickListResponse{
+		GeneratedAt: dto.NewTime(time.Now()),
+		Orders:      make([]dto.PickListOrderResponse, len(quotes)),
+	}
+	locations := make(map[string]*dto.PickLocationResponse)
+	lines := make(map[uint]*dto.PickLineResponse)
+	for i := range quotes {
+		quote := &quotes[i]
+		orderNumber, _, err := s.orderState(quote)
+		if err != nil {
+			return nil, err
+		}
+		pickList.Orders[i] = dto.PickListOrderResponse{QuoteID: quote.ID, OrderNumber: orderNumber}
+
+		for _, item := range quote.Items {
+			left := item.Quantity - shipped[item.ID]
+			if left <= 0 {
+				continue
+			}
+			line, ok := lines[item.ProductID]
+			if !ok {
+				line = &dto.PickLineResponse{ProductID: item.ProductID, ProductName: item.Product.Name, SKU: item.Product.SKUValue()}
+				lines[item.ProductID] = line
+				location, ok := locations[item.Product.Location]
+				if !ok {
+					location = &dto.PickLocationResponse{Location: item.Product.Location}
+					locations[item.Product.Location] = location
+				}
+				location.Lines = append(location.Lines, dto.PickLineResponse{ProductID: item.ProductID})
+			}
+			line.Quantity += left
+			line.Orders = append(line.Orders, dto.PickOrderQuantity{OrderNumber: orderNumber, Quantity: left})
+			pickList.Units += left
+		}
+	}
+
+	for _, location := range locations {
+		for i := range location.Lines {
+			location.Lines[i] = *lines[location.Lines[i].ProductID]
+		}
+		slices.SortFunc(location.Lines, func(a, b dto.PickLineResponse) int {
+			return cmp.Or(strings.Compare(a.ProductName, b.ProductName), cmp.Compare(a.ProductID, b.ProductID))
+		})
+		pickList.Locations = append(pickList.Locations, *location)
+	}
+	// Products without a location come last, to be looked for once the rest is picked
+	slices.SortFunc(pickList.Locations, func(a, b dto.PickLocationResponse) int {
+		if (a.Location == "") != (b.Location == "") {
+			if a.Location == "" {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.Location, b.Location)
+	})
+	return pickList, nil
+}
+
+// RenderPickList renders a pick list as a PDF, with its date in the request's
+// time zone
+func (s *OrderService) RenderPickList(rc reqctx.Context, pickList *dto.PickListResponse) ([]byte, error) {
+	orderNumbers := make([]string, len(pickList.Orders))
+	for i, order := range pickList.Orders {
+		orderNumbers[i] = order.OrderNumber
+	}
+	document := newOrderDocument(s.settingService.Value(models.SettingStoreName), "Pick list",
+		fmt.Sprintf("Generated %s for %d orders, %d units", rc.FormatDate(pickList.GeneratedAt.Time), len(pickList.Orders), pickList.Units))
+	drawPickList(document, orderNumbers, pickList.Locations)
+	return document.document.Bytes()
+}
+
+// placedOrder retrieves a quote placed as an order that wasn't cancelled, with
+// its order number. A non-zero userID limits it to that user's orders.
+func (s *OrderService) placedOrder(quoteID, userID uint) (*models.Quote, string, error) {
+	quote, err := s.acceptedQuote(quoteID)
+	if err != nil {
+		return nil, "", err
+	}
+	if userID != 0 && quote.UserID != userID {
+		return nil, "", ErrOrderNotFound
+	}
+	orderNumber, status, err := s.orderState(quote)
+	if err != nil {
+		return nil, "", err
+	}
+	if status == OrderCancelled {
+		return nil, "", ErrOrderCancelled
+	}
+	return quote, orderNumber, nil
+}
+
+// acceptedQuote retrieves a quote placed as an order, with its items
+func (s *OrderService) acceptedQuote(quoteID uint) (*models.Quote, error) {
+	quote, err := s.quoteRepo.GetByID(quoteID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if quote.Status != models.QuoteAccepted {
+		return nil, ErrOrderNotFound
+	}
+	return quote, nil
+}
+
+// orderState returns the number and status of the order placed from a quote,
+// read from its placement saga. Quotes accepted before orders were placed by
+// a saga have none, and are shown as confirmed under their reference.
+func (s *OrderService) orderState(quote *models.Quote) (string, string, error) {
+	saga, err := s.sagaRepo.GetByReference(models.SagaOrderPlacement, quote.Reference())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return quote.Reference(), OrderConfirmed, nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+	var order orderPlacement
+	if err := decodeSagaData(saga, &order); err != nil {
+		return "", "", err
+	}
+
+	switch saga.Status {
+	case models.SagaRunning:
+		return order.number(saga), OrderProcessing, nil
+	case models.SagaCompleted:
+		return order.number(saga), OrderConfirmed, nil
+	default:
+		return order.number(saga), OrderCancelled, nil
+	}
+}
+
+// notifyShipped tells the customer a parcel of their order shipped, unless
+// their account was deleted since. Failing to is only logged, since the
+// shipment is recorded.
+func (s *OrderService) notifyShipped(quote *models.Quote, orderNumber string, shipment *models.Shipment) {
+	user, err := s.userRepo.GetByID(quote.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return
+	}
+	if err != nil {
+		log.Printf("Warning: failed to load the customer of order %s: %v", orderNumber, err)
+		return
+	}
+	trackingURL := OrderTrackingURL(quote.ID)
+	NewNotificationService().Notify(user, models.NotificationOrderUpdate, notifier.Notification{
+		Title: "Order shipped",
+		Body: fmt.Sprintf("Your order %s has shipped with %s, tracking number %s. Follow it at %s",
+			orderNumber, shipment.Carrier, shipment.TrackingNumber, trackingURL),
+		Data: map[string]string{
+			"quote_id":        strconv.FormatUint(uint64(quote.ID), 10),
+			"carrier":         shipment.Carrier,
+			"tracking_number": shipment.TrackingNumber,
+			"tracking_url":    trackingURL,
+		},
+	})
+}
+
+// trackingSignature signs a quote ID for order tracking links
+func trackingSignature(id string) string {
+	mac := hmac.New(sha256.New, []byte(utils.GetEnv("JWT_SECRET", "your-secret-key")))
+	mac.Write([]byte("order-tracking:" + id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
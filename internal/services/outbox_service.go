@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/events"
+	"product-management/pkg/lock"
+)
+
+const (
+	// outboxBatchSize is the number of events the relay loads at a time
+	outboxBatchSize = 100
+	// outboxMaxBackoff caps the wait between attempts to publish an event
+	outboxMaxBackoff = time.Hour
+	// outboxSendTimeout bounds an attempt to publish an event
+	outboxSendTimeout = 30 * time.Second
+)
+
+// outboxPending wakes the relay of this instance when a change recorded events
+var outboxPending = make(chan struct{}, 1)
+
+// notifyOutbox tells the relay that events were committed to the outbox, so
+// they are published now rather than at its next poll
+func notifyOutbox() {
+	select {
+	case outboxPending <- struct{}{}:
+	default:
+	}
+}
+
+// OutboxService relays the events recorded in the outbox to a publisher, at
+// least once each, and lets admins reprocess the ones it gave up on
+type OutboxService struct {
+	publisher    events.Publisher
+	pollInterval time.Duration
+	maxAttempts  int
+	outboxRepo   *repositories.OutboxRepository
+}
+
+// NewOutboxService creates a new OutboxService instance publishing to publisher
+func NewOutboxService(publisher events.Publisher, pollInterval time.Duration, maxAttempts int) *OutboxService {
+	return &OutboxService{
+		publisher:    publisher,
+		pollInterval: pollInterval,
+		maxAttempts:  maxAttempts,
+		outboxRepo:   repositories.NewOutboxRepository(database.DB),
+	}
+}
+
+// Start relays due events now, then every poll interval and whenever this
+// instance commits new ones, and returns a function that stops it
+func (s *OutboxService) Start() func() {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+		for {
+			if err := s.Relay(); err != nil {
+				log.Printf("Warning: outbox relay failed: %v", err)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			case <-outboxPending:
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
+
+// Relay publishes every due event, oldest first. When several instances run,
+// the one holding the relay lock publishes and the others skip their turn. An
+// event is marked published only once the publisher accepted it, so a crash in
+// between publishes it again. A failed attempt is retried with exponential
+// backoff until the maximum attempts, after which the event is marked failed.
+func (s *OutboxService) Relay() error {
+	held, err := lock.Default.TryLock(context.Background(), "outbox relay")
+	if errors.Is(err, lock.ErrLocked) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer held.Release()
+
+	for {
+		due, err := s.outboxRepo.ListDue(outboxBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range due {
+			if err := s.send(&due[i]); err != nil {
+				return err
+			}
+		}
+		if len(due) < outboxBatchSize {
+			return nil
+		}
+	}
+}
+
+// send publishes an event and records the outcome of the attempt
+func (s *OutboxService) send(event *models.OutboxEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), outboxSendTimeout)
+	sendErr := s.publisher.Send(ctx, event.Topic, []byte(event.Payload))
+	cancel()
+	if sendErr == nil {
+		return s.outboxRepo.MarkPublished(event)
+	}
+
+	var nextAttempt *time.Time
+	if event.Attempts+1 < s.maxAttempts {
+		backoff := outboxMaxBackoff
+		if event.Attempts < 12 { // Later attempts would wait longer than the cap anyway
+			backoff = min(time.Second<<event.Attempts, outboxMaxBackoff)
+		}
+		at := time.Now().Add(backoff)
+		nextAttempt = &at
+	} else {
+		log.Printf("Warning: giving up on outbox event %d (%s) after %d attempts: %v", event.ID, event.Topic, event.Attempts+1, sendErr)
+	}
+	return s.outboxRepo.MarkAttemptFailed(event, sendErr, nextAttempt)
+}
+
+// ListEvents retrieves a paginated list of outbox events, newest first
+func (s *OutboxService) ListEvents(status models.OutboxStatus, topic string, page, limit int) ([]models.OutboxEvent, int64, error) {
+	return s.outboxRepo.List(status, topic, page, limit)
+}
+
+// Reprocess queues events for the relay again: the given ones, or every failed
+// one when ids is empty. It returns how many were queued, leaving out IDs that
+// do not exist.
+func (s *OutboxService) Reprocess(ids []uint) (int64, error) {
+	queued, err := s.outboxRepo.Reprocess(ids)
+	if err != nil {
+		return 0, err
+	}
+	notifyOutbox()
+	return queued, nil
+}
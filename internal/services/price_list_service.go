@@ -0,0 +1,295 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPriceListNameTaken  = errors.New("price list name already exists")
+	ErrDuplicatePriceBreak = errors.New("price breaks must have distinct minimum quantities")
+)
+
+// PriceListService manages B2B price lists and computes the prices customers
+// pay, after promotions, pricing rules and the price list assigned to them.
+// Order calculations price each line with UnitPrice.
+type PriceListService struct {
+	priceListRepo      *repositories.PriceListRepository
+	productRepo        *repositories.ProductRepository
+	pricingRuleService *PricingRuleService
+	promotionService   *PromotionService
+}
+
+// NewPriceListService creates a new PriceListService instance
+func NewPriceListService(pricingRuleService *PricingRuleService, promotionService *PromotionService) *PriceListService {
+	return &PriceListService{
+		priceListRepo:      repositories.NewPriceListRepository(database.DB),
+		productRepo:        repositories.NewProductRepository(database.DB),
+		pricingRuleService: pricingRuleService,
+		promotionService:   promotionService,
+	}
+}
+
+// CreatePriceList creates an empty price list
+func (s *PriceListService) CreatePriceList(req dto.PriceListRequest) (*models.PriceList, error) {
+	if err := s.checkName(req.Name, 0); err != nil {
+		return nil, err
+	}
+	priceList := &models.PriceList{Name: strings.TrimSpace(req.Name), Description: req.Description}
+	if err := s.priceListRepo.Create(priceList); err != nil {
+		return nil, err
+	}
+	return priceList, nil
+}
+
+// UpdatePriceList renames or redescribes a price list
+func (s *PriceListService) UpdatePriceList(id uint, req dto.PriceListRequest) (*models.PriceList, error) {
+	priceList, err := s.priceListRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkName(req.Name, id); err != nil {
+		return nil, err
+	}
+	priceList.Name = strings.TrimSpace(req.Name)
+	priceList.Description = req.Description
+	if err := s.priceListRepo.Update(priceList); err != nil {
+		return nil, err
+	}
+	return priceList, nil
+}
+
+// GetPriceList retrieves a price list with its items
+func (s *PriceListService) GetPriceList(id uint) (*models.PriceList, error) {
+	return s.priceListRepo.GetByID(id)
+}
+
+// ListPriceLists retrieves every price list
+func (s *PriceListService) ListPriceLists() ([]models.PriceList, error) {
+	return s.priceListRepo.List()
+}
+
+// DeletePriceList deletes a price list; its customers pay list prices again
+func (s *PriceListService) DeletePriceList(id uint) error {
+	if err := s.priceListRepo.Delete(id); err != nil {
+		return err
+	}
+	// Users still cached with this list find no items, which means list prices
+	cache.Store.Delete(cache.PriceListKey(id))
+	return nil
+}
+
+// SetProductPrices replaces the quantity breaks of a product in a price list
+func (s *PriceListService) SetProductPrices(priceListID, productID uint, breaks []dto.PriceBreak) error {
+	if _, err := s.priceListRepo.GetByID(priceListID); err != nil {
+		return err
+	}
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return err
+	}
+	if product == nil {
+		return fmt.Errorf("product %d: %w", productID, gorm.ErrRecordNotFound)
+	}
+
+	items := make([]models.PriceListItem, len(breaks))
+	seen := make(map[int]bool, len(breaks))
+	for i, b := range breaks {
+		if seen[b.MinQuantity] {
+			return ErrDuplicatePriceBreak
+		}
+		seen[b.MinQuantity] = true
+		items[i] = models.PriceListItem{
+			PriceListID: priceListID,
+			ProductID:   productID,
+			MinQuantity: b.MinQuantity,
+			Price:       math.Round(b.Price*100) / 100,
+		}
+	}
+	if err := s.priceListRepo.SetProductPrices(priceListID, productID, items); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.PriceListKey(priceListID))
+	return nil
+}
+
+// AssignPriceList assigns a price list to a user, or list prices when priceListID is nil
+func (s *PriceListService) AssignPriceList(userID uint, priceListID *uint) error {
+	if priceListID != nil {
+		if _, err := s.priceListRepo.GetByID(*priceListID); err != nil {
+			return err
+		}
+	}
+	if err := s.priceListRepo.AssignUser(userID, priceListID); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.UserPriceListKey(userID))
+	return nil
+}
+
+// CustomerPricing loads the prices a user pays, reading through the cache. It
+// returns nil when no promotion is active, no pricing rule is enabled and the
+// user has no price list, so list prices apply.
+func (s *PriceListService) CustomerPricing(userID uint) (*CustomerPricing, error) {
+	promotions, err := s.promotionService.ActivePromotions()
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.pricingRuleService.LiveRules()
+	if err != nil {
+		return nil, err
+	}
+
+	var priceListID uint
+	if !cache.Store.Get(cache.UserPriceListKey(userID), &priceListID) {
+		id, err := s.priceListRepo.GetUserPriceListID(userID)
+		if err != nil {
+			return nil, err
+		}
+		if id != nil {
+			priceListID = *id
+		}
+		cache.Store.Set(cache.UserPriceListKey(userID), priceListID, cache.TTL)
+	}
+	if priceListID == 0 {
+		if promotions == nil && rules == nil {
+			return nil, nil
+		}
+		return &CustomerPricing{promotions: promotions, rules: rules}, nil
+	}
+
+	var items []models.PriceListItem
+	if !cache.Store.Get(cache.PriceListKey(priceListID), &items) {
+		items, err = s.priceListRepo.ListItems(priceListID)
+		if err != nil {
+			return nil, err
+		}
+		cache.Store.Set(cache.PriceListKey(priceListID), items, cache.TTL)
+	}
+
+	pricing := &CustomerPricing{promotions: promotions, rules: rules, breaks: make(map[uint][]models.PriceListItem)}
+	for _, item := range items {
+		pricing.breaks[item.ProductID] = append(pricing.breaks[item.ProductID], item)
+	}
+	for _, productBreaks := range pricing.breaks {
+		sort.Slice(productBreaks, func(i, j int) bool { return productBreaks[i].MinQuantity < productBreaks[j].MinQuantity })
+	}
+	return pricing, nil
+}
+
+// checkName rejects a name already used by another price list
+func (s *PriceListService) checkName(name string, excludeID uint) error {
+	exists, err := s.priceListRepo.NameExists(strings.TrimSpace(name), excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrPriceListNameTaken
+	}
+	return nil
+}
+
+// CustomerPricing holds the active promotions, the pricing rules in effect and
+// the quantity breaks of a customer's price list by product. A nil
+// CustomerPricing prices everything at list price.
+type CustomerPricing struct {
+	promotions *Promotions
+	rules      *PricingRules
+	breaks     map[uint][]models.PriceListItem // Sorted by minimum quantity, nil without a price list
+}
+
+// unitPrice is the price of a product and what set it
+type unitPrice struct {
+	price     float64
+	rule      *models.PricingRule // Set when a pricing rule gave the price
+	promotion *models.Promotion   // The product's active promotion, whether or not it gave the price
+}
+
+// Personal reports whether the prices depend on the customer, rather than only
+// on the promotions and pricing rules everyone gets
+func (p *CustomerPricing) Personal() bool {
+	return p != nil && p.breaks != nil
+}
+
+// UnitPrice returns the unit price of a product when buying a quantity
+func (p *CustomerPricing) UnitPrice(product *models.Product, quantity int) float64 {
+	return p.price(newPricedProduct(product), quantity).price
+}
+
+// PriceWithRule returns the unit price of a product when buying a quantity,
+// along with the pricing rule that set it, nil when no rule did
+func (p *CustomerPricing) PriceWithRule(product *models.Product, quantity int) (float64, *models.PricingRule) {
+	price := p.price(newPricedProduct(product), quantity)
+	return price.price, price.rule
+}
+
+// price returns the lowest of the promotional price, the price after pricing
+// rules and the break with the highest minimum quantity reached. Without any
+// of them it is the list price.
+func (p *CustomerPricing) price(product pricedProduct, quantity int) unitPrice {
+	if p == nil {
+		return unitPrice{price: product.price}
+	}
+	result := unitPrice{price: product.price, promotion: p.promotions.For(product)}
+	if result.promotion != nil {
+		result.price = result.promotion.Price(product.price)
+	}
+	if price, rule := p.rules.Price(product); rule != nil && price < result.price {
+		result.price, result.rule = price, rule
+	}
+	breakPrice, reached := 0.0, false
+	for _, item := range p.breaks[product.id] {
+		if item.MinQuantity > quantity {
+			break
+		}
+		breakPrice, reached = item.Price, true
+	}
+	if reached && ((result.promotion == nil && result.rule == nil) || breakPrice < result.price) {
+		result.price, result.rule = breakPrice, nil
+	}
+	return result
+}
+
+// Apply adds the customer's price, the rule that set it, the active promotion
+// and the quantity breaks to product responses
+func (p *CustomerPricing) Apply(products []dto.ProductResponse) {
+	if p == nil {
+		return
+	}
+	for i := range products {
+		productBreaks, ok := p.breaks[products[i].ID]
+		price := p.price(pricedProductResponse(&products[i]), 1)
+		if !ok && price.rule == nil && price.promotion == nil {
+			continue
+		}
+		products[i].CustomerPrice = &price.price
+		products[i].PricingRule = appliedPricingRule(price.rule)
+		if price.promotion != nil {
+			products[i].Promotion = &dto.ProductPromotion{
+				ID:               price.promotion.ID,
+				Name:             price.promotion.Name,
+				OriginalPrice:    products[i].Price,
+				PromotionalPrice: price.promotion.Price(products[i].Price),
+				EndsAt:           dto.NewTime(price.promotion.EndsAt),
+			}
+		}
+		if !ok {
+			continue
+		}
+		products[i].PriceBreaks = make([]dto.PriceBreak, len(productBreaks))
+		for j, item := range productBreaks {
+			products[i].PriceBreaks[j] = dto.PriceBreak{MinQuantity: item.MinQuantity, Price: item.Price}
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package services
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPricingRuleSchedule = errors.New("ends_at must be after starts_at")
+	ErrPricingRulePercent  = errors.New("a percent_off value must be at most 100")
+	ErrPricingRuleCategory = errors.New("category not found")
+)
+
+// simulationLimit caps the products priced by a simulation that names none
+const simulationLimit = 200
+
+// pricingRuleSet is the enabled pricing rules as cached, with the products
+// meeting the no-sales condition of the rules that have one
+type pricingRuleSet struct {
+	Rules  []models.PricingRule `json:"rules"`  // In the order they are tried
+	Unsold map[uint][]uint      `json:"unsold"` // Product IDs by rule ID
+}
+
+// PricingRuleService manages the pricing rules that lower product prices
+// while they are served and when quotes are requested, and previews their
+// effect before they go live
+type PricingRuleService struct {
+	ruleRepo     *repositories.PricingRuleRepository
+	productRepo  *repositories.ProductRepository
+	categoryRepo *repositories.CategoryRepository
+}
+
+// NewPricingRuleService creates a new PricingRuleService instance
+func NewPricingRuleService() *PricingRuleService {
+	return &PricingRuleService{
+		ruleRepo:     repositories.NewPricingRuleRepository(database.DB),
+		productRepo:  repositories.NewProductRepository(database.DB),
+		categoryRepo: repositories.NewCategoryRepository(database.DB),
+	}
+}
+
+// ListRules retrieves every pricing rule in the order they are tried
+func (s *PricingRuleService) ListRules() ([]models.PricingRule, error) {
+	return s.ruleRepo.List()
+}
+
+// GetRule retrieves a pricing rule by ID
+func (s *PricingRuleService) GetRule(id uint) (*models.PricingRule, error) {
+	return s.ruleRepo.GetByID(id)
+}
+
+// CreateRule adds a pricing rule
+func (s *PricingRuleService) CreateRule(req dto.PricingRuleRequest, editorID uint) (*models.PricingRule, error) {
+	rule := &models.PricingRule{}
+	if err := s.apply(rule, req, editorID); err != nil {
+		return nil, err
+	}
+	if err := s.ruleRepo.Create(rule); err != nil {
+		return nil, err
+	}
+	cache.Store.Delete(cache.PricingRulesKey)
+	return rule, nil
+}
+
+// UpdateRule replaces the conditions, adjustment and schedule of a rule
+func (s *PricingRuleService) UpdateRule(id uint, req dto.PricingRuleRequest, editorID uint) (*models.PricingRule, error) {
+	rule, err := s.ruleRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(rule, req, editorID); err != nil {
+		return nil, err
+	}
+	if err := s.ruleRepo.Update(rule); err != nil {
+		return nil, err
+	}
+	cache.Store.Delete(cache.PricingRulesKey)
+	return rule, nil
+}
+
+// DeleteRule removes a pricing rule
+func (s *PricingRuleService) DeleteRule(id uint) error {
+	if err := s.ruleRepo.Delete(id); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.PricingRulesKey)
+	return nil
+}
+
+// ListApplications retrieves a paginated list of the quote items a rule priced
+func (s *PricingRuleService) ListApplications(id uint, page, limit int) ([]models.QuoteItem, int64, error) {
+	if _, err := s.ruleRepo.GetByID(id); err != nil {
+		return nil, 0, err
+	}
+	return s.ruleRepo.ListApplications(id, page, limit)
+}
+
+// LiveRules loads the rules that apply now, reading through the cache. It
+// returns nil when no rule is enabled. Schedules are checked whenever a price
+// is computed, so cached rules start and end on time; stock and sales
+// conditions are as fresh as the cache.
+func (s *PricingRuleService) LiveRules() (*PricingRules, error) {
+	var set pricingRuleSet
+	if !cache.Store.Get(cache.PricingRulesKey, &set) {
+		value, err, _ := readGroup.Do(cache.PricingRulesKey, func() (interface{}, error) {
+			rules, err := s.ruleRepo.ListEnabled(time.Now())
+			if err != nil {
+				return nil, err
+			}
+			set, err := s.ruleSet(rules)
+			if err != nil {
+				return nil, err
+			}
+			cache.Store.Set(cache.PricingRulesKey, set, cache.TTL)
+			return set, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		set = value.(pricingRuleSet)
+	}
+	if len(set.Rules) == 0 {
+		return nil, nil
+	}
+	return newPricingRules(set, time.Now()), nil
+}
+
+// Simulate prices products at a time with the enabled rules, and again with a
+// draft rule added or replacing an existing one, without saving anything. A
+// rule ID without a draft previews removing that rule. Sales conditions are
+// evaluated against the sales up to now.
+func (s *PricingRuleService) Simulate(req dto.SimulatePricingRequest, at time.Time) ([]dto.PricingSimulationItem, error) {
+	current, err := s.ruleRepo.ListEnabled(at)
+	if err != nil {
+		return nil, err
+	}
+
+	simulated := current
+	var categoryID uint
+	if req.RuleID != nil {
+		if _, err := s.ruleRepo.GetByID(*req.RuleID); err != nil {
+			return nil, err
+		}
+		simulated = slices.DeleteFunc(slices.Clone(current), func(rule models.PricingRule) bool {
+			return rule.ID == *req.RuleID
+		})
+	}
+	if req.Rule != nil {
+		draft := models.PricingRule{}
+		if err := s.apply(&draft, *req.Rule, 0); err != nil {
+			return nil, err
+		}
+		if req.RuleID != nil {
+			draft.ID = *req.RuleID
+		}
+		if draft.CategoryID != nil {
+			categoryID = *draft.CategoryID
+		}
+		simulated = append(slices.Clone(simulated), draft)
+		slices.SortStableFunc(simulated, ruleOrder)
+	}
+
+	currentSet, err := s.ruleSet(current)
+	if err != nil {
+		return nil, err
+	}
+	simulatedSet, err := s.ruleSet(simulated)
+	if err != nil {
+		return nil, err
+	}
+	currentRules := newPricingRules(currentSet, at)
+	simulatedRules := newPricingRules(simulatedSet, at)
+
+	var products []models.Product
+	if len(req.ProductIDs) > 0 {
+		products, err = s.productRepo.GetByIDs(req.ProductIDs)
+		slices.SortFunc(products, func(a, b models.Product) int { return strings.Compare(a.Name, b.Name) })
+	} else {
+		products, _, err = s.productRepo.List(1, simulationLimit, categoryID, "", "name", []string{string(models.StatusActive)}, nil, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.PricingSimulationItem, len(products))
+	for i := range products {
+		product := newPricedProduct(&products[i])
+		currentPrice, currentRule := currentRules.Price(product)
+		simulatedPrice, simulatedRule := simulatedRules.Price(product)
+		items[i] = dto.PricingSimulationItem{
+			ProductID:      products[i].ID,
+			ProductName:    products[i].Name,
+			ListPrice:      products[i].Price,
+			CurrentPrice:   currentPrice,
+			CurrentRule:    appliedPricingRule(currentRule),
+			SimulatedPrice: simulatedPrice,
+			SimulatedRule:  appliedPricingRule(simulatedRule),
+		}
+	}
+	return items, nil
+}
+
+// ruleSet finds the products meeting the no-sales condition of each rule that
+// has one
+func (s *PricingRuleService) ruleSet(rules []models.PricingRule) (pricingRuleSet, error) {
+	set := pricingRuleSet{Rules: rules, Unsold: make(map[uint][]uint)}
+	for i := range rules {
+		if rules[i].NoSalesDays == nil {
+			continue
+		}
+		ids, err := s.ruleRepo.UnsoldProductIDs(&rules[i], time.Now().AddDate(0, 0, -*rules[i].NoSalesDays))
+		if err != nil {
+			return pricingRuleSet{}, err
+		}
+		set.Unsold[rules[i].ID] = ids
+	}
+	return set, nil
+}
+
+// apply validates a request and copies it onto a rule
+func (s *PricingRuleService) apply(rule *models.PricingRule, req dto.PricingRuleRequest, editorID uint) error {
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(req.StartsAt.Time) {
+		return ErrPricingRuleSchedule
+	}
+	if models.PricingAdjustment(req.Adjustment) == models.PricingPercentOff && req.Value > 100 {
+		return ErrPricingRulePercent
+	}
+	if req.CategoryID != nil {
+		if _, err := s.categoryRepo.GetByID(*req.CategoryID); errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPricingRuleCategory
+		} else if err != nil {
+			return err
+		}
+	}
+
+	rule.Name = strings.TrimSpace(req.Name)
+	rule.Description = req.Description
+	rule.Priority = req.Priority
+	rule.Enabled = req.Enabled == nil || *req.Enabled
+	rule.StartsAt = optionalTime(req.StartsAt)
+	rule.EndsAt = optionalTime(req.EndsAt)
+	rule.CategoryID = req.CategoryID
+	rule.MinStock = req.MinStock
+	rule.NoSalesDays = req.NoSalesDays
+	rule.Adjustment = models.PricingAdjustment(req.Adjustment)
+	rule.Value = math.Round(req.Value*100) / 100
+	rule.UpdatedBy = editorID
+	return nil
+}
+
+// ruleOrder orders rules as they are tried: highest priority first, then
+// oldest first, with a draft that has no ID yet counting as the newest
+func ruleOrder(a, b models.PricingRule) int {
+	if a.Priority != b.Priority {
+		return cmp.Compare(b.Priority, a.Priority)
+	}
+	aID, bID := a.ID, b.ID
+	if aID == 0 {
+		aID = math.MaxUint
+	}
+	if bID == 0 {
+		bID = math.MaxUint
+	}
+	return cmp.Compare(aID, bID)
+}
+
+// appliedPricingRule names the rule that set a price, nil for none
+func appliedPricingRule(rule *models.PricingRule) *dto.AppliedPricingRule {
+	if rule == nil {
+		return nil
+	}
+	return &dto.AppliedPricingRule{ID: rule.ID, Name: rule.Name}
+}
+
+// pricedProduct is what pricing rules look at in a product
+type pricedProduct struct {
+	id          uint
+	price       float64
+	stock       int
+	categoryIDs []uint
+}
+
+// newPricedProduct describes a product for pricing rules
+func newPricedProduct(product *models.Product) pricedProduct {
+	priced := pricedProduct{id: product.ID, price: product.Price, stock: product.StockQuantity}
+	for _, category := range product.Categories {
+		priced.categoryIDs = append(priced.categoryIDs, category.ID)
+	}
+	return priced
+}
+
+// pricedProductResponse describes a product response for pricing rules
+func pricedProductResponse(product *dto.ProductResponse) pricedProduct {
+	priced := pricedProduct{id: product.ID, price: product.Price, stock: product.Quantity}
+	for _, category := range product.Categories {
+		priced.categoryIDs = append(priced.categoryIDs, category.ID)
+	}
+	return priced
+}
+
+// PricingRules evaluates pricing rules at a time. A nil PricingRules leaves
+// every price as it is.
+type PricingRules struct {
+	at     time.Time
+	rules  []models.PricingRule   // In the order they are tried
+	unsold map[uint]map[uint]bool // Products meeting the no-sales condition, by rule ID
+}
+
+// newPricingRules evaluates a rule set at a time
+func newPricingRules(set pricingRuleSet, at time.Time) *PricingRules {
+	rules := &PricingRules{at: at, rules: set.Rules, unsold: make(map[uint]map[uint]bool, len(set.Unsold))}
+	for ruleID, productIDs := range set.Unsold {
+		rules.unsold[ruleID] = make(map[uint]bool, len(productIDs))
+		for _, productID := range productIDs {
+			rules.unsold[ruleID][productID] = true
+		}
+	}
+	return rules
+}
+
+// Price returns the price of a product after the first matching rule that
+// lowers it, along with that rule, or its list price and nil
+func (r *PricingRules) Price(product pricedProduct) (float64, *models.PricingRule) {
+	if r == nil {
+		return product.price, nil
+	}
+	for i := range r.rules {
+		rule := &r.rules[i]
+		if !r.matches(rule, product) {
+			continue
+		}
+		if price := rule.Adjust(product.price); price < product.price {
+			return price, rule
+		}
+	}
+	return product.price, nil
+}
+
+// matches reports whether a rule applies to a product
+func (r *PricingRules) matches(rule *models.PricingRule, product pricedProduct) bool {
+	if !rule.LiveAt(r.at) {
+		return false
+	}
+	if rule.CategoryID != nil && !slices.Contains(product.categoryIDs, *rule.CategoryID) {
+		return false
+	}
+	if rule.MinStock != nil && product.stock <= *rule.MinStock {
+		return false
+	}
+	if rule.NoSalesDays != nil && !r.unsold[rule.ID][product.id] {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"reflect"
+	"slices"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// ErrChangeRequestOutdated is returned when approving a change request for a
+// product edited since it was made
+var ErrChangeRequestOutdated = errors.New("product changed after the change request was made")
+
+// ProductChangeService handles the review workflow for product edits
+type ProductChangeService struct {
+	changeRepo      *repositories.ProductChangeRequestRepository
+	productRepo     *repositories.ProductRepository
+	requireApproval func() bool
+}
+
+// NewProductChangeService creates a new ProductChangeService instance.
+// requireApproval reports whether approval mode is on, which a configuration
+// reload may change.
+func NewProductChangeService(requireApproval func() bool) *ProductChangeService {
+	return &ProductChangeService{
+		changeRepo:      repositories.NewProductChangeRequestRepository(database.DB),
+		productRepo:     repositories.NewProductRepository(database.DB),
+		requireApproval: requireApproval,
+	}
+}
+
+// RequiresApproval reports whether edits by the given role must go through review
+func (s *ProductChangeService) RequiresApproval(role string) bool {
+	return s.requireApproval() && role != string(models.RoleAdmin)
+}
+
+// RequestChange records a proposed product edit for admin review. The stock
+// quantity can't be changed this way, since approving would undo the sales
+// and adjustments made while the request waited.
+func (s *ProductChangeService) RequestChange(productID uint, proposed dto.ProductSnapshot, requestedBy uint) (*models.ProductChangeRequest, error) {
+	current, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, errors.New("product not found")
+	}
+	proposed.StockQuantity = current.StockQuantity
+
+	diff := diffProduct(current, proposed)
+	if len(diff) == 0 {
+		return nil, errors.New("no changes proposed")
+	}
+
+	proposedJSON, err := json.Marshal(proposed)
+	if err != nil {
+		return nil, err
+	}
+	diffJSON, err := json.Marshal(diff)
+	if err != nil {
+		return nil, err
+	}
+
+	changeRequest := &models.ProductChangeRequest{
+		ProductID:   productID,
+		RequestedBy: requestedBy,
+		Status:      models.ChangeRequestPending,
+		Proposed:    string(proposedJSON),
+		Diff:        string(diffJSON),
+	}
+	if err := s.changeRepo.Create(changeRequest); err != nil {
+		return nil, err
+	}
+
+	return changeRequest, nil
+}
+
+// GetChangeRequest retrieves a change request by ID
+func (s *ProductChangeService) GetChangeRequest(id uint) (*models.ProductChangeRequest, error) {
+	return s.changeRepo.GetByID(id)
+}
+
+// ListChangeRequests retrieves a paginated list of change requests
+func (s *ProductChangeService) ListChangeRequests(status models.ChangeRequestStatus, page, pageSize int) ([]models.ProductChangeRequest, int64, error) {
+	return s.changeRepo.List(status, page, pageSize)
+}
+
+// ApproveChangeRequest applies the fields the request changes and marks it as
+// approved. It fails with ErrChangeRequestOutdated when the product was edited
+// since the request was made, and never touches the stock quantity.
+func (s *ProductChangeService) ApproveChangeRequest(id, reviewerID uint, note string) (*models.ProductChangeRequest, error) {
+	changeRequest, err := s.changeRepo.Resolve(id, models.ChangeRequestApproved, reviewerID, note, func(tx *gorm.DB, changeRequest *models.ProductChangeRequest) error {
+		var proposed dto.ProductSnapshot
+		if err := json.Unmarshal([]byte(changeRequest.Proposed), &proposed); err != nil {
+			return err
+		}
+		var diff map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(changeRequest.Diff), &diff); err != nil {
+			return err
+		}
+		// Requests made before stock was left out of them may still carry it
+		delete(diff, "stock_quantity")
+
+		productRepo := s.productRepo.WithTx(tx)
+		current, err := productRepo.GetByID(changeRequest.ProductID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return errors.New("product not found")
+		}
+
+		unchanged, err := unchangedSince(current, proposed, diff)
+		if err != nil {
+			return err
+		}
+		if !unchanged {
+			return ErrChangeRequestOutdated
+		}
+
+		snapshot := repositories.SnapshotProduct(current)
+		for field := range diff {
+			applyChangedField(&snapshot, proposed, field)
+		}
+		product := &models.Product{
+			BaseModel:        models.BaseModel{ID: changeRequest.ProductID},
+			Name:             snapshot.Name,
+			Description:      snapshot.Description,
+			SKU:              models.OptionalSKU(snapshot.SKU),
+			Location:         snapshot.Location,
+			Bookable:         snapshot.Bookable,
+			Price:            snapshot.Price,
+			Status:           models.ProductStatus(snapshot.Status),
+			AllowedCountries: snapshot.AllowedCountries,
+			BlockedCountries: snapshot.BlockedCountries,
+			Metadata:         snapshot.Metadata,
+		}
+		return productRepo.UpdateDetails(product, snapshot.Categories, changeRequest.RequestedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cache.Store.Delete(cache.ProductKey(changeRequest.ProductID), cache.CategoriesKey)
+	invalidateProductLists()
+	notifyOutbox()
+	return changeRequest, nil
+}
+
+// unchangedSince reports whether a product is as it was when a change request
+// recorded diff, besides its stock quantity: exactly when diffing it with the
+// proposal again gives the recorded diff
+func unchangedSince(current *models.Product, proposed dto.ProductSnapshot, diff map[string]json.RawMessage) (bool, error) {
+	currentDiff := diffProduct(current, proposed)
+	delete(currentDiff, "stock_quantity")
+	recorded, err := json.Marshal(diff)
+	if err != nil {
+		return false, err
+	}
+	again, err := json.Marshal(currentDiff)
+	if err != nil {
+		return false, err
+	}
+	return string(recorded) == string(again), nil
+}
+
+// applyChangedField copies a field diffProductSnapshots names from the
+// proposal to a snapshot. The stock quantity is not one of them.
+func applyChangedField(snapshot *dto.ProductSnapshot, proposed dto.ProductSnapshot, field string) {
+	switch field {
+	case "name":
+		snapshot.Name = proposed.Name
+	case "description":
+		snapshot.Description = proposed.Description
+	case "sku":
+		snapshot.SKU = proposed.SKU
+	case "location":
+		snapshot.Location = proposed.Location
+	case "bookable":
+		snapshot.Bookable = proposed.Bookable
+	case "price":
+		snapshot.Price = proposed.Price
+	case "status":
+		snapshot.Status = proposed.Status
+	case "allowed_countries":
+		snapshot.AllowedCountries = proposed.AllowedCountries
+	case "blocked_countries":
+		snapshot.BlockedCountries = proposed.BlockedCountries
+	case "metadata":
+		snapshot.Metadata = proposed.Metadata
+	case "categories":
+		snapshot.Categories = proposed.Categories
+	}
+}
+
+// RejectChangeRequest marks the request as rejected without touching the product
+func (s *ProductChangeService) RejectChangeRequest(id, reviewerID uint, note string) (*models.ProductChangeRequest, error) {
+	return s.changeRepo.Resolve(id, models.ChangeRequestRejected, reviewerID, note, nil)
+}
+
+// diffProduct returns the fields that differ between the current product and the proposal
+func diffProduct(current *models.Product, proposed dto.ProductSnapshot) map[string]dto.FieldChange {
+	return diffProductSnapshots(repositories.SnapshotProduct(current), proposed)
+}
+
+// diffProductSnapshots returns the fields that differ between two states of a product
+func diffProductSnapshots(old, new dto.ProductSnapshot) map[string]dto.FieldChange {
+	diff := make(map[string]dto.FieldChange)
+
+	if old.Name != new.Name {
+		diff["name"] = dto.FieldChange{Old: old.Name, New: new.Name}
+	}
+	if old.Description != new.Description {
+		diff["description"] = dto.FieldChange{Old: old.Description, New: new.Description}
+	}
+	if old.SKU != new.SKU {
+		diff["sku"] = dto.FieldChange{Old: old.SKU, New: new.SKU}
+	}
+	if old.Location != new.Location {
+		diff["location"] = dto.FieldChange{Old: old.Location, New: new.Location}
+	}
+	if old.Bookable != new.Bookable {
+		diff["bookable"] = dto.FieldChange{Old: old.Bookable, New: new.Bookable}
+	}
+	if old.Price != new.Price {
+		diff["price"] = dto.FieldChange{Old: old.Price, New: new.Price}
+	}
+	if old.StockQuantity != new.StockQuantity {
+		diff["stock_quantity"] = dto.FieldChange{Old: old.StockQuantity, New: new.StockQuantity}
+	}
+	if old.Status != new.Status {
+		diff["status"] = dto.FieldChange{Old: old.Status, New: new.Status}
+	}
+	if !slices.Equal(old.AllowedCountries, new.AllowedCountries) {
+		diff["allowed_countries"] = dto.FieldChange{Old: old.AllowedCountries, New: new.AllowedCountries}
+	}
+	if !slices.Equal(old.BlockedCountries, new.BlockedCountries) {
+		diff["blocked_countries"] = dto.FieldChange{Old: old.BlockedCountries, New: new.BlockedCountries}
+	}
+	if (len(old.Metadata) > 0 || len(new.Metadata) > 0) && !reflect.DeepEqual(old.Metadata, new.Metadata) {
+		diff["metadata"] = dto.FieldChange{Old: old.Metadata, New: new.Metadata}
+	}
+
+	oldCategories := append([]uint(nil), old.Categories...)
+	newCategories := append([]uint(nil), new.Categories...)
+	sort.Slice(oldCategories, func(i, j int) bool { return oldCategories[i] < oldCategories[j] })
+	sort.Slice(newCategories, func(i, j int) bool { return newCategories[i] < newCategories[j] })
+	if !equalIDs(oldCategories, newCategories) {
+		diff["categories"] = dto.FieldChange{Old: oldCategories, New: newCategories}
+	}
+
+	return diff
+}
+
+// equalIDs reports whether two sorted ID slices are equal
+func equalIDs(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+)
+
+func TestUnchangedSince(t *testing.T) {
+	requested := &models.Product{Name: "Lamp", Price: 40, StockQuantity: 12, Status: models.ProductStatus("active")}
+	proposed := repositories.SnapshotProduct(requested)
+	proposed.Price = 35
+	proposed.StockQuantity = requested.StockQuantity
+
+	recorded, err := json.Marshal(diffProduct(requested, proposed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var diff map[string]json.RawMessage
+	if err := json.Unmarshal(recorded, &diff); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		edit   func(p *models.Product)
+		expect bool
+	}{
+		{"untouched", func(p *models.Product) {}, true},
+		{"stock sold", func(p *models.Product) { p.StockQuantity = 3 }, true},
+		{"changed field repriced", func(p *models.Product) { p.Price = 45 }, false},
+		{"other field renamed", func(p *models.Product) { p.Name = "Desk lamp" }, false},
+		{"already applied", func(p *models.Product) { p.Price = 35 }, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			current := *requested
+			tc.edit(&current)
+			got, err := unchangedSince(&current, proposed, diff)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.expect {
+				t.Errorf("unchangedSince = %v, want %v", got, tc.expect)
+			}
+		})
+	}
+}
+
+func TestApplyChangedFieldLeavesOthers(t *testing.T) {
+	snapshot := dto.ProductSnapshot{Name: "Lamp", Price: 40, StockQuantity: 3}
+	proposed := dto.ProductSnapshot{Name: "Old lamp", Price: 35, StockQuantity: 12}
+
+	applyChangedField(&snapshot, proposed, "price")
+	applyChangedField(&snapshot, proposed, "stock_quantity")
+	if snapshot.Price != 35 || snapshot.Name != "Lamp" || snapshot.StockQuantity != 3 {
+		t.Errorf("snapshot = %+v, want only the price changed", snapshot)
+	}
+}
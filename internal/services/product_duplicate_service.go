@@ -0,0 +1,163 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"product-management/pkg/trigram"
+)
+
+// DefaultDuplicateSimilarity is the name similarity from which two products
+// are reported as likely duplicates when the caller sets none
+const DefaultDuplicateSimilarity = 0.6
+
+// Weights of the signals in a duplicate score
+const (
+	sameSKUScore   = 0.9 // Identical SKUs make a pair at least this likely
+	samePriceBonus = 0.1 // Identical prices make a pair this much more likely
+)
+
+// ErrMergeSameProduct is returned when a product is merged into itself
+var ErrMergeSameProduct = errors.New("a product cannot be merged into itself")
+
+// ProductDuplicateService finds products that were likely entered twice and
+// merges them
+type ProductDuplicateService struct {
+	productRepo *repositories.ProductRepository
+}
+
+// NewProductDuplicateService creates a new ProductDuplicateService instance
+func NewProductDuplicateService() *ProductDuplicateService {
+	return &ProductDuplicateService{productRepo: repositories.NewProductRepository(database.DB)}
+}
+
+// FindDuplicates compares every product with every other one and returns the
+// pairs whose names are at least minSimilarity alike or whose SKUs match,
+// most likely first. Names are compared through an index of their trigrams,
+// so only products sharing one are compared.
+func (s *ProductDuplicateService) FindDuplicates(minSimilarity float64) ([]dto.DuplicateCandidateResponse, error) {
+	products, err := s.productRepo.ListForDuplicateScan()
+	if err != nil {
+		return nil, err
+	}
+
+	type pair struct{ a, b int }
+	found := make(map[pair]*dto.DuplicateCandidateResponse)
+	candidate := func(a, b int) *dto.DuplicateCandidateResponse {
+		if c, ok := found[pair{a, b}]; ok {
+			return c
+		}
+		c := &dto.DuplicateCandidateResponse{
+			Product:   mappers.ToDuplicateProductResponse(&products[a]),
+			Duplicate: mappers.ToDuplicateProductResponse(&products[b]),
+		}
+		found[pair{a, b}] = c
+		return c
+	}
+
+	// Products are listed by ID, so a < b keeps the older product first
+	trigrams := make([]trigram.Set, len(products))
+	index := make(map[string][]int)
+	for i := range products {
+		trigrams[i] = trigram.Of(products[i].Name)
+		shared := make(map[int]int)
+		for t := range trigrams[i] {
+			for _, j := range index[t] {
+				shared[j]++
+			}
+			index[t] = append(index[t], i)
+		}
+		for j, count := range shared {
+			similarity := trigram.SimilarityOf(count, len(trigrams[j]), len(trigrams[i]))
+			if similarity >= minSimilarity {
+				candidate(j, i).NameSimilarity = similarity
+			}
+		}
+	}
+
+	bySKU := make(map[string][]int)
+	for i := range products {
+		if sku := normalizeSKU(products[i].SKUValue()); sku != "" {
+			bySKU[sku] = append(bySKU[sku], i)
+		}
+	}
+	for _, group := range bySKU {
+		for x := 0; x < len(group); x++ {
+			for y := x + 1; y < len(group); y++ {
+				c := candidate(group[x], group[y])
+				c.SameSKU = true
+				if c.NameSimilarity == 0 {
+					c.NameSimilarity = trigram.Similarity(trigrams[group[x]], trigrams[group[y]])
+				}
+			}
+		}
+	}
+
+	candidates := make([]dto.DuplicateCandidateResponse, 0, len(found))
+	for _, c := range found {
+		c.SamePrice = c.Product.Price == c.Duplicate.Price
+		c.Score = c.NameSimilarity
+		if c.SameSKU {
+			c.Score = math.Max(c.Score, sameSKUScore)
+		}
+		if c.SamePrice {
+			c.Score = math.Min(c.Score+samePriceBonus, 1)
+		}
+		c.Score = math.Round(c.Score*100) / 100
+		c.NameSimilarity = math.Round(c.NameSimilarity*100) / 100
+		candidates = append(candidates, *c)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		if candidates[i].Product.ID != candidates[j].Product.ID {
+			return candidates[i].Product.ID < candidates[j].Product.ID
+		}
+		return candidates[i].Duplicate.ID < candidates[j].Duplicate.ID
+	})
+	return candidates, nil
+}
+
+// MergeProducts consolidates a duplicate onto the product that survives it and
+// deletes the duplicate. See ProductRepository.Merge for what is moved.
+func (s *ProductDuplicateService) MergeProducts(productID, duplicateID, editorID uint) (*dto.MergeProductsResponse, error) {
+	if productID == duplicateID {
+		return nil, ErrMergeSameProduct
+	}
+	result, err := s.productRepo.Merge(productID, duplicateID, editorID)
+	if err != nil {
+		return nil, err
+	}
+	cache.Store.Delete(cache.ProductKey(productID), cache.ProductKey(duplicateID), cache.CategoriesKey)
+	notifyOutbox()
+
+	return &dto.MergeProductsResponse{
+		ProductID:        productID,
+		DuplicateID:      duplicateID,
+		ReviewsMoved:     result.ReviewsMoved,
+		ReviewsDropped:   result.ReviewsDropped,
+		WishlistsMoved:   result.WishlistsMoved,
+		WishlistsDropped: result.WishlistsDropped,
+		CategoriesAdded:  result.CategoriesAdded,
+	}, nil
+}
+
+// normalizeSKU reduces an SKU to its lowercase letters and digits, so that
+// ABC-123 and abc 123 are the same
+func normalizeSKU(sku string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, sku)
+}
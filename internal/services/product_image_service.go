@@ -0,0 +1,158 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"product-management/pkg/events"
+	"product-management/pkg/storage"
+
+	"gorm.io/gorm"
+)
+
+// MaxProductImages is how many images a product may have
+const MaxProductImages = 20
+
+// productImageURLExpiry is how long a signed image URL handed out by
+// ImageURL stays valid
+const productImageURLExpiry = time.Hour
+
+// productImageTypes maps the accepted image content types to the extension of
+// their files. SVG is left out since it can carry scripts.
+var productImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+var (
+	ErrImageType  = errors.New("image must be a JPEG, PNG, WebP or GIF file")
+	ErrImageOrder = errors.New("image order must list every image of the product once")
+)
+
+// ProductImageService manages product images. Files are kept in object
+// storage under products/{id}/images/ and listed in product_images, which
+// holds their order and alternative text.
+type ProductImageService struct {
+	imageRepo *repositories.ProductImageRepository
+}
+
+// NewProductImageService creates a new ProductImageService instance
+func NewProductImageService() *ProductImageService {
+	return &ProductImageService{imageRepo: repositories.NewProductImageRepository(database.DB)}
+}
+
+// UploadImage stores an image of size bytes read from r and adds it after the
+// product's other images. The type is sniffed from the content rather than
+// taken from the client. A product deleted meanwhile fails with
+// gorm.ErrRecordNotFound.
+func (s *ProductImageService) UploadImage(ctx context.Context, productID uint, r io.Reader, size int64, alt string, uploaderID uint) (*models.ProductImage, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, ErrImageType
+	}
+	contentType := http.DetectContentType(head[:n])
+	extension, ok := productImageTypes[contentType]
+	if !ok {
+		return nil, ErrImageType
+	}
+
+	name, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+	image := &models.ProductImage{
+		ProductID:   productID,
+		Key:         fmt.Sprintf("products/%d/images/%s%s", productID, name, extension),
+		ContentType: contentType,
+		Size:        size,
+		Alt:         alt,
+		UploadedBy:  uploaderID,
+	}
+	if err := storage.Store.Put(ctx, image.Key, io.MultiReader(bytes.NewReader(head[:n]), r), size, contentType); err != nil {
+		return nil, err
+	}
+	if err := s.imageRepo.Create(image, MaxProductImages); err != nil {
+		s.deleteFile(ctx, image.Key)
+		return nil, err
+	}
+
+	s.productChanged(productID)
+	return image, nil
+}
+
+// ReorderImages sets the display order of a product's images. ids must list
+// every image of the product exactly once.
+func (s *ProductImageService) ReorderImages(productID uint, ids []uint) ([]models.ProductImage, error) {
+	images, err := s.imageRepo.ListByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != len(images) {
+		return nil, ErrImageOrder
+	}
+	existing := make(map[uint]bool, len(images))
+	for _, image := range images {
+		existing[image.ID] = true
+	}
+	for _, id := range ids {
+		if !existing[id] {
+			return nil, ErrImageOrder
+		}
+		delete(existing, id)
+	}
+
+	if err := s.imageRepo.Reorder(productID, ids); err != nil {
+		return nil, err
+	}
+	s.productChanged(productID)
+	return s.imageRepo.ListByProduct(productID)
+}
+
+// DeleteImage removes an image of a product and its file
+func (s *ProductImageService) DeleteImage(ctx context.Context, productID, id uint) error {
+	image, err := s.imageRepo.Delete(productID, id)
+	if err != nil {
+		return err
+	}
+	s.deleteFile(ctx, image.Key)
+	s.productChanged(productID)
+	return nil
+}
+
+// ImageURL returns a short-lived signed URL of an image of a product
+func (s *ProductImageService) ImageURL(ctx context.Context, product *models.Product, id uint) (string, error) {
+	for _, image := range product.Images {
+		if image.ID == id {
+			return storage.Store.SignedURL(ctx, image.Key, productImageURLExpiry)
+		}
+	}
+	return "", gorm.ErrRecordNotFound
+}
+
+// deleteFile deletes an image file. A file left behind only takes up space,
+// so failures are logged rather than returned.
+func (s *ProductImageService) deleteFile(ctx context.Context, key string) {
+	if err := storage.Store.Delete(ctx, key); err != nil {
+		log.Printf("Warning: failed to delete product image %s: %v", key, err)
+	}
+}
+
+// productChanged clears the cached product and publishes the change, so its
+// responses carry the new images
+func (s *ProductImageService) productChanged(productID uint) {
+	cache.Store.Delete(cache.ProductKey(productID))
+	events.Publish(events.ProductChanged{ProductID: productID})
+}
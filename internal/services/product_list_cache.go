@@ -0,0 +1,70 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/pkg/cache"
+	"product-management/pkg/events"
+)
+
+// productListQuery identifies a page of a product listing in the cache
+type productListQuery struct {
+	Page       int                 `json:"page"`
+	Limit      int                 `json:"limit"`
+	CategoryID uint                `json:"category_id"`
+	Search     string              `json:"search"`
+	Sort       string              `json:"sort"`
+	Statuses   []string            `json:"statuses"`
+	Country    *string             `json:"country"`
+	Metadata   map[string][]string `json:"metadata"`
+}
+
+// key returns the cache key of the query in a listing generation. Filters are
+// hashed, since searches and metadata values can be long.
+func (q productListQuery) key(generation int64) string {
+	data, _ := json.Marshal(q)
+	sum := sha256.Sum256(data)
+	return cache.ProductListKey(generation, hex.EncodeToString(sum[:16]))
+}
+
+// productListPage is a cached page of a product listing
+type productListPage struct {
+	Products []models.Product `json:"products"`
+	Total    int64            `json:"total"`
+}
+
+// productListGeneration returns the generation product listings are cached
+// under, starting one when there is none
+func productListGeneration() int64 {
+	var generation int64
+	if cache.Store.Get(cache.ProductListGenerationKey, &generation) {
+		return generation
+	}
+	return invalidateProductLists()
+}
+
+// invalidateProductLists starts a new listing generation, so every product
+// listing cached so far is read from the database again, and returns it. The
+// generation outlives the listings cached under it.
+func invalidateProductLists() int64 {
+	generation := time.Now().UnixNano()
+	cache.Store.Set(cache.ProductListGenerationKey, generation, 2*cache.TTL)
+	return generation
+}
+
+// SubscribeProductListInvalidation drops the cached product listings whenever
+// a product or category changes, including through orders, reviews, imports
+// and other writes outside ProductService. Events are relayed by one instance,
+// so this reaches every instance when the cache is shared.
+func SubscribeProductListInvalidation(bus *events.Bus) {
+	bus.Subscribe(events.TopicProductChanged, func(event events.Event) {
+		invalidateProductLists()
+	})
+	bus.Subscribe(events.TopicCategoryChanged, func(event events.Event) {
+		invalidateProductLists()
+	})
+}
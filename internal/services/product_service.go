@@ -1,26 +1,48 @@
 package services
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"product-management/internal/dto"
 	"product-management/internal/models"
 	"product-management/internal/repositories"
+	"product-management/pkg/cache"
 	"product-management/pkg/database"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSKUTaken           = errors.New("sku is already used by another product")
+	ErrInvalidMetadata    = errors.New("invalid metadata")
+	ErrStockAdjustment    = errors.New("sales remove units, and restocks and returns add them")
+	ErrStockAdjustProduct = errors.New("product not found")
 )
 
+// readGroup collapses concurrent cache misses for the same key into a single
+// database query, shared by every service instance
+var readGroup singleflight.Group
+
 // ProductService handles business logic for products
 type ProductService struct {
-	productRepo *repositories.ProductRepository
+	productRepo  *repositories.ProductRepository
+	categoryRepo *repositories.CategoryRepository
 }
 
 // NewProductService creates a new ProductService instance
 func NewProductService() *ProductService {
 	return &ProductService{
-		productRepo: repositories.NewProductRepository(database.DB),
+		productRepo:  repositories.NewProductRepository(database.DB),
+		categoryRepo: repositories.NewCategoryRepository(database.DB),
 	}
 }
 
 // CreateProduct creates a new product with validation
-func (s *ProductService) CreateProduct(product *models.Product, categories []models.Category) error {
+func (s *ProductService) CreateProduct(product *models.Product, categories []models.Category, editorID uint) error {
 	// Validate required fields
 	if product.Name == "" {
 		return errors.New("product name is required")
@@ -35,20 +57,130 @@ func (s *ProductService) CreateProduct(product *models.Product, categories []mod
 		product.Status = models.StatusActive
 	}
 
-	return s.productRepo.Create(product, categories)
+	if err := s.productRepo.Create(product, categories, editorID); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.CategoriesKey)
+	invalidateProductLists()
+	notifyOutbox()
+	return nil
+}
+
+// ResolveCategories checks category IDs for duplicates and loads them, failing
+// if any of them does not exist
+func (s *ProductService) ResolveCategories(categoryIDs []uint) ([]models.Category, error) {
+	seen := make(map[uint]bool, len(categoryIDs))
+	for _, id := range categoryIDs {
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate category ID found: %d", id)
+		}
+		seen[id] = true
+	}
+
+	categories, missing, err := s.categoryRepo.GetByIDs(categoryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch categories: %v", err)
+	}
+	if len(missing) > 0 {
+		ids := make([]string, len(missing))
+		for i, id := range missing {
+			ids[i] = strconv.FormatUint(uint64(id), 10)
+		}
+		return nil, fmt.Errorf("category not found with ID: %s", strings.Join(ids, ", "))
+	}
+
+	return categories, nil
 }
 
-// GetProduct retrieves a product by ID
+// CheckSKU rejects an SKU already used by another product than excludeID.
+// Products without an SKU never conflict.
+func (s *ProductService) CheckSKU(sku string, excludeID uint) error {
+	optional := models.OptionalSKU(sku)
+	if optional == nil {
+		return nil
+	}
+	exists, err := s.productRepo.SKUExists(*optional, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrSKUTaken
+	}
+	return nil
+}
+
+// ValidateMetadata checks product metadata against the schemas of the
+// product's categories, failing with ErrInvalidMetadata
+func (s *ProductService) ValidateMetadata(metadata map[string]interface{}, categories []models.Category) error {
+	if err := models.ValidateMetadata(metadata, categories); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMetadata, err)
+	}
+	return nil
+}
+
+// UpdateMetadata replaces a product's metadata, recording a revision
+func (s *ProductService) UpdateMetadata(productID uint, metadata map[string]interface{}, editorID uint) error {
+	if err := s.productRepo.UpdateMetadata(productID, metadata, editorID); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.ProductKey(productID))
+	invalidateProductLists()
+	notifyOutbox()
+	return nil
+}
+
+// GetProduct retrieves a product by ID, reading through the cache
 func (s *ProductService) GetProduct(id uint) (*models.Product, error) {
-	product, err := s.productRepo.GetByID(id)
+	var cached models.Product
+	if cache.Store.Get(cache.ProductKey(id), &cached) {
+		return &cached, nil
+	}
+
+	value, err, _ := readGroup.Do(cache.ProductKey(id), func() (interface{}, error) {
+		product, err := s.productRepo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if product != nil {
+			cache.Store.Set(cache.ProductKey(id), product, cache.TTL)
+		}
+		return product, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return product, nil
+	return value.(*models.Product), nil
+}
+
+// GetProducts retrieves products by ID, reading through the cache and loading
+// the ones not cached in a single query. IDs that do not exist are left out of
+// the returned map.
+func (s *ProductService) GetProducts(ids []uint) (map[uint]*models.Product, error) {
+	products := make(map[uint]*models.Product, len(ids))
+	var uncached []uint
+	for _, id := range ids {
+		var cached models.Product
+		if cache.Store.Get(cache.ProductKey(id), &cached) {
+			products[id] = &cached
+		} else {
+			uncached = append(uncached, id)
+		}
+	}
+
+	loaded, err := s.productRepo.GetByIDs(uncached)
+	if err != nil {
+		return nil, err
+	}
+	for i := range loaded {
+		product := &loaded[i]
+		cache.Store.Set(cache.ProductKey(product.ID), product, cache.TTL)
+		products[product.ID] = product
+	}
+	return products, nil
 }
 
 // UpdateProduct updates an existing product with validation
-func (s *ProductService) UpdateProduct(product *models.Product, categoryIDs []uint) error {
+func (s *ProductService) UpdateProduct(product *models.Product, categoryIDs []uint, editorID uint) error {
 	// Validate required fields
 	if product.Name == "" {
 		return errors.New("product name is required")
@@ -60,28 +192,126 @@ func (s *ProductService) UpdateProduct(product *models.Product, categoryIDs []ui
 		return errors.New("stock quantity cannot be negative")
 	}
 
-	return s.productRepo.Update(product, categoryIDs)
+	if err := s.productRepo.Update(product, categoryIDs, editorID); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.ProductKey(product.ID), cache.CategoriesKey)
+	invalidateProductLists()
+	notifyOutbox()
+	return nil
 }
 
-// DeleteProduct deletes a product
-func (s *ProductService) DeleteProduct(id uint) error {
-	return s.productRepo.Delete(id)
+// RestoreProduct puts a product's details and categories back as a revision
+// recorded them. The stock quantity stays as it is, since the stock ledger
+// owns it and restoring an old quantity would bring back stock sold since.
+func (s *ProductService) RestoreProduct(product *models.Product, categoryIDs []uint, editorID uint) error {
+	if err := s.productRepo.UpdateDetails(product, categoryIDs, editorID); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.ProductKey(product.ID), cache.CategoriesKey)
+	invalidateProductLists()
+	notifyOutbox()
+	return nil
 }
 
-// ListProducts retrieves a paginated list of products with filters
-func (s *ProductService) ListProducts(page, limit int, categoryID uint, search string, sort string, statuses []string) ([]models.Product, int64, error) {
-	// Validate pagination parameters
-	if page < 1 {
-		page = 1
+// ListRevisions retrieves a paginated list of a product's revisions
+func (s *ProductService) ListRevisions(productID uint, page, limit int) ([]models.ProductRevision, int64, error) {
+	return s.productRepo.ListRevisions(productID, page, limit)
+}
+
+// ListStockMovements retrieves a paginated stock ledger for a product
+func (s *ProductService) ListStockMovements(productID uint, page, limit int) ([]models.StockMovement, int64, error) {
+	return s.productRepo.ListStockMovements(productID, page, limit)
+}
+
+// AdjustStock records a stock movement made by hand, such as a sale in a shop,
+// a restock or a customer return, and moves the product's stock by it
+func (s *ProductService) AdjustStock(productID, actorID uint, req dto.AdjustStockRequest) (*models.StockMovement, error) {
+	source := models.StockMovementSource(req.Reason)
+	switch {
+	case source == models.StockSourceSale && req.Delta > 0,
+		(source == models.StockSourceRestock || source == models.StockSourceReturn) && req.Delta < 0:
+		return nil, ErrStockAdjustment
+	}
+
+	movement := &models.StockMovement{
+		ProductID: productID,
+		Source:    source,
+		Reference: strings.TrimSpace(req.Reference),
+		Delta:     req.Delta,
+		ActorID:   actorID,
+		Note:      strings.TrimSpace(req.Note),
+	}
+	if err := s.productRepo.AdjustStock(movement); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStockAdjustProduct
+		}
+		return nil, err
+	}
+
+	cache.Store.Delete(cache.ProductKey(productID))
+	invalidateProductLists()
+	notifyOutbox()
+	return movement, nil
+}
+
+// GetRevisionSnapshot retrieves the product state recorded by a revision
+func (s *ProductService) GetRevisionSnapshot(productID uint, revision int) (*dto.ProductSnapshot, error) {
+	productRevision, err := s.productRepo.GetRevision(productID, revision)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("revision not found")
+		}
+		return nil, err
+	}
+
+	var snapshot dto.ProductSnapshot
+	if err := json.Unmarshal([]byte(productRevision.Snapshot), &snapshot); err != nil {
+		return nil, err
 	}
-	if limit < 1 {
-		limit = 10
+	return &snapshot, nil
+}
+
+// DeleteProduct deletes a product
+func (s *ProductService) DeleteProduct(id uint) error {
+	if err := s.productRepo.Delete(id); err != nil {
+		return err
 	}
-	if limit > 100 {
-		limit = 100
+	cache.Store.Delete(cache.ProductKey(id), cache.CategoriesKey)
+	invalidateProductLists()
+	notifyOutbox()
+	return nil
+}
+
+// ListProducts retrieves a paginated list of products with filters. A non-nil
+// country limits it to the products available there, and metadata to those
+// with one of the listed values for every key.
+// Pages are read through the cache and dropped whenever a product changes.
+func (s *ProductService) ListProducts(page, limit int, categoryID uint, search string, sort string, statuses []string, country *string, metadata map[string][]string) ([]models.Product, int64, error) {
+	key := productListQuery{
+		Page: page, Limit: limit, CategoryID: categoryID, Search: search, Sort: sort,
+		Statuses: statuses, Country: country, Metadata: metadata,
+	}.key(productListGeneration())
+
+	var cached productListPage
+	if cache.Store.Get(key, &cached) {
+		return cached.Products, cached.Total, nil
 	}
 
-	return s.productRepo.List(page, limit, categoryID, search, sort, statuses)
+	value, err, _ := readGroup.Do(key, func() (interface{}, error) {
+		products, total, err := s.productRepo.List(page, limit, categoryID, search, sort, statuses, country, metadata)
+		if err != nil {
+			return nil, err
+		}
+		listPage := productListPage{Products: products, Total: total}
+		cache.Store.Set(key, listPage, cache.TTL)
+		return listPage, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	listPage := value.(productListPage)
+	return listPage.Products, listPage.Total, nil
 }
 
 // AddToWishlist adds a product to a user's wishlist
@@ -105,28 +335,10 @@ func (s *ProductService) RemoveFromWishlist(userID, productID uint) error {
 
 // GetWishlist retrieves a user's wishlist
 func (s *ProductService) GetWishlist(userID uint, page, limit int) ([]models.Wishlist, int64, error) {
-	// Validate pagination parameters
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 {
-		limit = 10
-	}
-	if limit > 100 {
-		limit = 100
-	}
-
 	return s.productRepo.GetWishlist(userID, page, limit)
 }
 
 // IsProductInWishlist checks if a product is already in the user's wishlist
 func (s *ProductService) IsProductInWishlist(userID, productID uint) (bool, error) {
-	var count int64
-	err := s.productRepo.DB().Model(&models.Wishlist{}).
-		Where("user_id = ? AND product_id = ?", userID, productID).
-		Count(&count).Error
-	if err != nil {
-		return false, err
-	}
-	return count > 0, nil
+	return s.productRepo.ExistsInWishlist(userID, productID)
 }
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"time"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"product-management/pkg/warehouse"
+
+	"gorm.io/gorm"
+)
+
+// viewRankingSize is how many ranked products are cached for trending and
+// related products, so enough are left once those the caller can't see are
+// filtered out
+const viewRankingSize = 100
+
+// ProductViewService records product views and ranks products by them
+type ProductViewService struct {
+	viewRepo       *repositories.ProductViewRepository
+	productService *ProductService
+}
+
+// NewProductViewService creates a new ProductViewService instance
+func NewProductViewService() *ProductViewService {
+	return &ProductViewService{
+		viewRepo:       repositories.NewProductViewRepository(database.DB),
+		productService: NewProductService(),
+	}
+}
+
+// RecordView records a view of a product by a user, or by an anonymous visitor
+// when userID is 0, and queues it for the warehouse
+func (s *ProductViewService) RecordView(productID, userID uint, anonymousID, referrer string) error {
+	view := &models.ProductView{
+		ProductID:   productID,
+		AnonymousID: anonymousID,
+		Referrer:    referrer,
+		ViewedAt:    time.Now(),
+	}
+	if userID != 0 {
+		view.UserID = &userID
+	}
+
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := repositories.NewProductViewRepository(tx).Create(view); err != nil {
+			return err
+		}
+		return queueWarehouseEvent(tx, models.WarehouseProductView, view.ViewedAt, warehouse.Row{
+			"product_id":   productID,
+			"user_id":      userID,
+			"anonymous_id": anonymousID,
+			"referrer":     referrer,
+		})
+	})
+}
+
+// Trending returns up to limit products with the most views over the last
+// days, most viewed first. Products that are not active or not sold in the
+// country are left out; a nil country allows every market. Rankings are
+// cached, so new views count once the cache expires.
+func (s *ProductViewService) Trending(days, limit int, country *string) ([]models.Product, error) {
+	var ranking []repositories.ProductViewCount
+	key := cache.TrendingProductsKey(days)
+	if !cache.Store.Get(key, &ranking) {
+		var err error
+		ranking, err = s.viewRepo.MostViewed(time.Now().AddDate(0, 0, -days), viewRankingSize)
+		if err != nil {
+			return nil, err
+		}
+		cache.Store.Set(key, ranking, cache.TTL)
+	}
+	return s.rankedProducts(ranking, limit, country)
+}
+
+// AlsoViewed returns up to limit other products viewed by the viewers of a
+// product over the last days, those with the most such viewers first, with
+// the same filtering and caching as Trending
+func (s *ProductViewService) AlsoViewed(productID uint, days, limit int, country *string) ([]models.Product, error) {
+	var ranking []repositories.ProductViewCount
+	key := cache.AlsoViewedKey(productID, days)
+	if !cache.Store.Get(key, &ranking) {
+		var err error
+		ranking, err = s.viewRepo.AlsoViewed(productID, time.Now().AddDate(0, 0, -days), viewRankingSize)
+		if err != nil {
+			return nil, err
+		}
+		cache.Store.Set(key, ranking, cache.TTL)
+	}
+	return s.rankedProducts(ranking, limit, country)
+}
+
+// rankedProducts loads the products of a ranking in order, keeping the first
+// limit that are active and sold in the country
+func (s *ProductViewService) rankedProducts(ranking []repositories.ProductViewCount, limit int, country *string) ([]models.Product, error) {
+	ids := make([]uint, len(ranking))
+	for i, count := range ranking {
+		ids[i] = count.ProductID
+	}
+	products, err := s.productService.GetProducts(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := make([]models.Product, 0, limit)
+	for _, id := range ids {
+		if len(ranked) == limit {
+			break
+		}
+		product := products[id]
+		if product == nil || product.Status != models.StatusActive {
+			continue
+		}
+		if country != nil && !product.AvailableIn(*country) {
+			continue
+		}
+		ranked = append(ranked, *product)
+	}
+	return ranked, nil
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"product-management/pkg/events"
+	"product-management/pkg/lock"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPromotionScope    = errors.New("exactly one of product_id and category_id must be set")
+	ErrPromotionSchedule = errors.New("ends_at must be after starts_at and in the future")
+	ErrPromotionDiscount = errors.New("a percent_off discount must be at most 100")
+	ErrPromotionTarget   = errors.New("product or category not found")
+	ErrPromotionEnded    = errors.New("promotion has ended")
+	ErrPromotionConflict = errors.New("promotion overlaps another promotion of the same products")
+)
+
+// promotionPending wakes the scheduler of this instance when a promotion was
+// created or changed
+var promotionPending = make(chan struct{}, 1)
+
+// notifyPromotions tells the scheduler that promotions changed, so those due
+// start or end now rather than at its next poll
+func notifyPromotions() {
+	select {
+	case promotionPending <- struct{}{}:
+	default:
+	}
+}
+
+// PromotionService manages scheduled sales of products and categories. Its
+// scheduler starts and ends them on time, and prices served during an active
+// promotion show the original price next to the promotional one.
+type PromotionService struct {
+	pollInterval  time.Duration
+	promotionRepo *repositories.PromotionRepository
+	productRepo   *repositories.ProductRepository
+	categoryRepo  *repositories.CategoryRepository
+}
+
+// NewPromotionService creates a new PromotionService instance
+func NewPromotionService(pollInterval time.Duration) *PromotionService {
+	return &PromotionService{
+		pollInterval:  pollInterval,
+		promotionRepo: repositories.NewPromotionRepository(database.DB),
+		productRepo:   repositories.NewProductRepository(database.DB),
+		categoryRepo:  repositories.NewCategoryRepository(database.DB),
+	}
+}
+
+// ListPromotions retrieves a paginated list of promotions, latest start first
+func (s *PromotionService) ListPromotions(status models.PromotionStatus, page, limit int) ([]models.Promotion, int64, error) {
+	return s.promotionRepo.List(status, page, limit)
+}
+
+// GetPromotion retrieves a promotion by ID
+func (s *PromotionService) GetPromotion(id uint) (*models.Promotion, error) {
+	return s.promotionRepo.GetByID(id)
+}
+
+// CreatePromotion schedules a promotion, rejecting it when it overlaps another
+// promotion of the same products. The scheduler starts it.
+func (s *PromotionService) CreatePromotion(req dto.PromotionRequest, editorID uint) (*models.Promotion, error) {
+	promotion := &models.Promotion{Status: models.PromotionScheduled}
+	if err := s.apply(promotion, req, editorID); err != nil {
+		return nil, err
+	}
+	if err := s.promotionRepo.Create(promotion, rejectConflicts); err != nil {
+		return nil, err
+	}
+	notifyPromotions()
+	return promotion, nil
+}
+
+// UpdatePromotion replaces the scope, discount and schedule of a promotion
+// that has not ended. The scheduler moves it to the status of its new schedule.
+func (s *PromotionService) UpdatePromotion(id uint, req dto.PromotionRequest, editorID uint) (*models.Promotion, error) {
+	promotion, err := s.promotionRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if promotion.Status == models.PromotionEnded {
+		return nil, ErrPromotionEnded
+	}
+	if err := s.apply(promotion, req, editorID); err != nil {
+		return nil, err
+	}
+	if err := s.promotionRepo.Update(promotion, rejectConflicts); err != nil {
+		return nil, err
+	}
+	if promotion.Status == models.PromotionActive {
+		cache.Store.Delete(cache.PromotionsKey)
+		s.publishChanged(promotion)
+	}
+	notifyPromotions()
+	return promotion, nil
+}
+
+// DeletePromotion removes a promotion, ending it at once when it is active
+func (s *PromotionService) DeletePromotion(id uint) error {
+	promotion, err := s.promotionRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.promotionRepo.Delete(id); err != nil {
+		return err
+	}
+	if promotion.Status == models.PromotionActive {
+		cache.Store.Delete(cache.PromotionsKey)
+		s.publishChanged(promotion)
+	}
+	return nil
+}
+
+// ActivePromotions loads the promotions the scheduler started, reading through
+// the cache. It returns nil when none is active.
+func (s *PromotionService) ActivePromotions() (*Promotions, error) {
+	var active []models.Promotion
+	if !cache.Store.Get(cache.PromotionsKey, &active) {
+		value, err, _ := readGroup.Do(cache.PromotionsKey, func() (interface{}, error) {
+			promotions, err := s.promotionRepo.ListActive()
+			if err != nil {
+				return nil, err
+			}
+			cache.Store.Set(cache.PromotionsKey, promotions, cache.TTL)
+			return promotions, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		active = value.([]models.Promotion)
+	}
+	if len(active) == 0 {
+		return nil, nil
+	}
+	return newPromotions(active), nil
+}
+
+// Start moves due promotions now, then every poll interval and whenever this
+// instance changes promotions, and returns a function that stops it
+func (s *PromotionService) Start() func() {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+		for {
+			if err := s.Run(); err != nil {
+				log.Printf("Warning: promotion scheduler failed: %v", err)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			case <-promotionPending:
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
+
+// Run starts the promotions whose schedule began and ends those whose schedule
+// is over. When several instances run, the one holding the scheduler lock
+// moves them and the others skip their turn.
+func (s *PromotionService) Run() error {
+	held, err := lock.Default.TryLock(context.Background(), "promotion scheduler")
+	if errors.Is(err, lock.ErrLocked) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer held.Release()
+
+	moved, err := s.promotionRepo.Transition(time.Now())
+	if err != nil {
+		return err
+	}
+	if len(moved) == 0 {
+		return nil
+	}
+	cache.Store.Delete(cache.PromotionsKey)
+	for i := range moved {
+		log.Printf("Promotion %d (%s) is now %s", moved[i].ID, moved[i].Name, moved[i].Status)
+		s.publishChanged(&moved[i])
+	}
+	return nil
+}
+
+// publishChanged reports the products of a promotion as changed, since the
+// price shown with them did
+func (s *PromotionService) publishChanged(promotion *models.Promotion) {
+	if promotion.ProductID != nil {
+		events.Publish(events.ProductChanged{ProductID: *promotion.ProductID})
+		return
+	}
+	products, err := s.categoryRepo.GetProductsByCategoryID(*promotion.CategoryID)
+	if err != nil {
+		log.Printf("Warning: failed to load the products of promotion %d: %v", promotion.ID, err)
+		return
+	}
+	for _, product := range products {
+		events.Publish(events.ProductChanged{ProductID: product.ID})
+	}
+}
+
+// apply validates a request and copies it onto a promotion. Conflicts with
+// other promotions are checked when it is saved.
+func (s *PromotionService) apply(promotion *models.Promotion, req dto.PromotionRequest, editorID uint) error {
+	if (req.ProductID == nil) == (req.CategoryID == nil) {
+		return ErrPromotionScope
+	}
+	now := time.Now()
+	startsAt := now
+	if req.StartsAt != nil {
+		startsAt = req.StartsAt.Time
+	}
+	if !req.EndsAt.After(startsAt) || !req.EndsAt.After(now) {
+		return ErrPromotionSchedule
+	}
+	if models.PricingAdjustment(req.Discount) == models.PricingPercentOff && req.Value > 100 {
+		return ErrPromotionDiscount
+	}
+	if req.ProductID != nil {
+		product, err := s.productRepo.GetByID(*req.ProductID)
+		if err != nil {
+			return err
+		}
+		if product == nil {
+			return ErrPromotionTarget
+		}
+	} else if _, err := s.categoryRepo.GetByID(*req.CategoryID); errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrPromotionTarget
+	} else if err != nil {
+		return err
+	}
+
+	promotion.Name = strings.TrimSpace(req.Name)
+	promotion.ProductID = req.ProductID
+	promotion.CategoryID = req.CategoryID
+	promotion.Discount = models.PricingAdjustment(req.Discount)
+	promotion.Value = math.Round(req.Value*100) / 100
+	promotion.StartsAt = startsAt
+	promotion.EndsAt = req.EndsAt.Time
+	promotion.UpdatedBy = editorID
+	return nil
+}
+
+// rejectConflicts fails a promotion save when the promotion overlaps others
+func rejectConflicts(conflicts []uint) error {
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%w: %v", ErrPromotionConflict, conflicts)
+	}
+	return nil
+}
+
+// Promotions finds the active promotion of a product. A nil Promotions has
+// none.
+type Promotions struct {
+	byProduct  map[uint]*models.Promotion
+	byCategory map[uint]*models.Promotion
+}
+
+// newPromotions indexes active promotions by the product or category they cover
+func newPromotions(active []models.Promotion) *Promotions {
+	promotions := &Promotions{byProduct: make(map[uint]*models.Promotion), byCategory: make(map[uint]*models.Promotion)}
+	for i := range active {
+		if active[i].ProductID != nil {
+			promotions.byProduct[*active[i].ProductID] = &active[i]
+		} else if active[i].CategoryID != nil {
+			promotions.byCategory[*active[i].CategoryID] = &active[i]
+		}
+	}
+	return promotions
+}
+
+// For returns the active promotion of a product, preferring one of the product
+// itself over one of its categories, or nil
+func (p *Promotions) For(product pricedProduct) *models.Promotion {
+	if p == nil {
+		return nil
+	}
+	if promotion, ok := p.byProduct[product.id]; ok {
+		return promotion
+	}
+	var found *models.Promotion
+	for _, categoryID := range product.categoryIDs {
+		if promotion, ok := p.byCategory[categoryID]; ok && (found == nil || promotion.ID < found.ID) {
+			found = promotion
+		}
+	}
+	return found
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+)
+
+var (
+	ErrSupplierNameTaken     = errors.New("supplier name already exists")
+	ErrSupplierHasOpenOrders = errors.New("supplier still has open purchase orders")
+	ErrPurchaseOrderProduct  = errors.New("product not found")
+	ErrDuplicateOrderItem    = errors.New("each product may only appear once in a purchase order")
+)
+
+// PurchasingService manages suppliers and the purchase orders that restock
+// products from them
+type PurchasingService struct {
+	supplierRepo      *repositories.SupplierRepository
+	purchaseOrderRepo *repositories.PurchaseOrderRepository
+	productRepo       *repositories.ProductRepository
+}
+
+// NewPurchasingService creates a new PurchasingService instance
+func NewPurchasingService() *PurchasingService {
+	return &PurchasingService{
+		supplierRepo:      repositories.NewSupplierRepository(database.DB),
+		purchaseOrderRepo: repositories.NewPurchaseOrderRepository(database.DB),
+		productRepo:       repositories.NewProductRepository(database.DB),
+	}
+}
+
+// CreateSupplier creates a supplier
+func (s *PurchasingService) CreateSupplier(req dto.SupplierRequest) (*models.Supplier, error) {
+	if err := s.checkSupplierName(req.Name, 0); err != nil {
+		return nil, err
+	}
+	supplier := &models.Supplier{
+		Name:        strings.TrimSpace(req.Name),
+		ContactName: req.ContactName,
+		Email:       req.Email,
+		Phone:       req.Phone,
+		Notes:       req.Notes,
+	}
+	if err := s.supplierRepo.Create(supplier); err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
+
+// UpdateSupplier replaces a supplier's details
+func (s *PurchasingService) UpdateSupplier(id uint, req dto.SupplierRequest) (*models.Supplier, error) {
+	supplier, err := s.supplierRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkSupplierName(req.Name, id); err != nil {
+		return nil, err
+	}
+	supplier.Name = strings.TrimSpace(req.Name)
+	supplier.ContactName = req.ContactName
+	supplier.Email = req.Email
+	supplier.Phone = req.Phone
+	supplier.Notes = req.Notes
+	if err := s.supplierRepo.Update(supplier); err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
+
+// GetSupplier retrieves a supplier by ID
+func (s *PurchasingService) GetSupplier(id uint) (*models.Supplier, error) {
+	return s.supplierRepo.GetByID(id)
+}
+
+// ListSuppliers retrieves every supplier
+func (s *PurchasingService) ListSuppliers() ([]models.Supplier, error) {
+	return s.supplierRepo.List()
+}
+
+// DeleteSupplier deletes a supplier without outstanding deliveries
+func (s *PurchasingService) DeleteSupplier(id uint) error {
+	open, err := s.supplierRepo.HasOpenPurchaseOrders(id)
+	if err != nil {
+		return err
+	}
+	if open {
+		return ErrSupplierHasOpenOrders
+	}
+	return s.supplierRepo.Delete(id)
+}
+
+// CreatePurchaseOrder orders products from a supplier
+func (s *PurchasingService) CreatePurchaseOrder(req dto.CreatePurchaseOrderRequest, createdBy uint) (*models.PurchaseOrder, error) {
+	if _, err := s.supplierRepo.GetByID(req.SupplierID); err != nil {
+		return nil, err
+	}
+
+	purchaseOrder := &models.PurchaseOrder{
+		SupplierID: req.SupplierID,
+		Status:     models.PurchaseOrderOpen,
+		Notes:      req.Notes,
+		CreatedBy:  createdBy,
+		Items:      make([]models.PurchaseOrderItem, len(req.Items)),
+	}
+	if req.ExpectedAt != nil && !req.ExpectedAt.IsZero() {
+		purchaseOrder.ExpectedAt = &req.ExpectedAt.Time
+	}
+	seen := make(map[uint]bool, len(req.Items))
+	for i, item := range req.Items {
+		if seen[item.ProductID] {
+			return nil, ErrDuplicateOrderItem
+		}
+		seen[item.ProductID] = true
+
+		product, err := s.productRepo.GetByID(item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if product == nil {
+			return nil, fmt.Errorf("%w: %d", ErrPurchaseOrderProduct, item.ProductID)
+		}
+		purchaseOrder.Items[i] = models.PurchaseOrderItem{
+			ProductID:       item.ProductID,
+			QuantityOrdered: item.Quantity,
+			UnitCost:        math.Round(item.UnitCost*100) / 100,
+		}
+	}
+
+	if err := s.purchaseOrderRepo.Create(purchaseOrder); err != nil {
+		return nil, err
+	}
+	return s.purchaseOrderRepo.GetByID(purchaseOrder.ID)
+}
+
+// GetPurchaseOrder retrieves a purchase order by ID
+func (s *PurchasingService) GetPurchaseOrder(id uint) (*models.PurchaseOrder, error) {
+	return s.purchaseOrderRepo.GetByID(id)
+}
+
+// ListPurchaseOrders retrieves a paginated list of purchase orders
+func (s *PurchasingService) ListPurchaseOrders(status models.PurchaseOrderStatus, supplierID uint, page, limit int) ([]models.PurchaseOrder, int64, error) {
+	return s.purchaseOrderRepo.List(status, supplierID, page, limit)
+}
+
+// ReceivePurchaseOrder adds a delivery to stock and returns the updated order
+func (s *PurchasingService) ReceivePurchaseOrder(id uint, req dto.ReceivePurchaseOrderRequest, actorID uint) (*models.PurchaseOrder, error) {
+	quantities := make(map[uint]int, len(req.Items))
+	for _, item := range req.Items {
+		quantities[item.ProductID] += item.Quantity
+	}
+
+	changes, err := s.purchaseOrderRepo.Receive(id, quantities, actorID, req.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range changes {
+		cache.Store.Delete(cache.ProductKey(change.ProductID))
+	}
+	notifyOutbox()
+	return s.purchaseOrderRepo.GetByID(id)
+}
+
+// CancelPurchaseOrder closes a purchase order that will not be delivered in full
+func (s *PurchasingService) CancelPurchaseOrder(id uint) (*models.PurchaseOrder, error) {
+	if err := s.purchaseOrderRepo.Cancel(id); err != nil {
+		return nil, err
+	}
+	return s.purchaseOrderRepo.GetByID(id)
+}
+
+// checkSupplierName rejects a name already used by another supplier
+func (s *PurchasingService) checkSupplierName(name string, excludeID uint) error {
+	exists, err := s.supplierRepo.NameExists(strings.TrimSpace(name), excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrSupplierNameTaken
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrQuoteAnswered      = errors.New("quote has already been answered")
+	ErrQuoteNotQuoted     = errors.New("quote is not awaiting a decision")
+	ErrQuoteExpired       = errors.New("quote has expired")
+	ErrQuotePricing       = errors.New("every product of the quote must be priced exactly once")
+	ErrQuoteProduct       = errors.New("product is not available")
+	ErrDuplicateQuoteItem = errors.New("each product may only appear once in a quote")
+	ErrQuoteGiftItem      = errors.New("gift options must name products of the quote, each at most once")
+	ErrQuoteGiftCard      = errors.New("gift card not found")
+)
+
+// QuoteService runs the request-for-quote workflow: customers request prices
+// for products and quantities, admins answer with prices valid until a date,
+// and customers accept or decline. Accepting a quote places it as an order.
+type QuoteService struct {
+	quoteRepo        *repositories.QuoteRepository
+	productRepo      *repositories.ProductRepository
+	priceListService *PriceListService
+}
+
+// NewQuoteService creates a new QuoteService instance
+func NewQuoteService(priceListService *PriceListService) *QuoteService {
+	return &QuoteService{
+		quoteRepo:        repositories.NewQuoteRepository(database.DB),
+		productRepo:      repositories.NewProductRepository(database.DB),
+		priceListService: priceListService,
+	}
+}
+
+// RequestQuote creates a quote for active products available in the customer's
+// country, recording what the customer would pay for each without it and the
+// pricing rule that set that price
+func (s *QuoteService) RequestQuote(userID uint, country string, req dto.CreateQuoteRequest) (*models.Quote, error) {
+	pricing, err := s.priceListService.CustomerPricing(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &models.Quote{
+		UserID:  userID,
+		Status:  models.QuoteRequested,
+		Note:    req.Note,
+		Country: country,
+		Items:   make([]models.QuoteItem, len(req.Items)),
+	}
+	seen := make(map[uint]bool, len(req.Items))
+	for i, item := range req.Items {
+		if seen[item.ProductID] {
+			return nil, ErrDuplicateQuoteItem
+		}
+		seen[item.ProductID] = true
+
+		product, err := s.productRepo.GetByID(item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if product == nil || product.Status != models.StatusActive || !product.AvailableIn(country) {
+			return nil, fmt.Errorf("%w: %d", ErrQuoteProduct, item.ProductID)
+		}
+		unitPrice, rule := pricing.PriceWithRule(product, item.Quantity)
+		quote.Items[i] = models.QuoteItem{
+			ProductID: product.ID,
+			Quantity:  item.Quantity,
+			UnitPrice: unitPrice,
+		}
+		if rule != nil {
+			quote.Items[i].PricingRuleID = &rule.ID
+		}
+	}
+
+	if err := s.quoteRepo.Create(quote); err != nil {
+		return nil, err
+	}
+	return s.quoteRepo.GetByID(quote.ID)
+}
+
+// GetQuote retrieves a quote. A non-zero userID limits it to that user's quotes.
+func (s *QuoteService) GetQuote(id, userID uint) (*models.Quote, error) {
+	quote, err := s.quoteRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if userID != 0 && quote.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return quote, nil
+}
+
+// ListQuotes retrieves a paginated list of quotes. A non-zero userID limits it
+// to that user's quotes.
+func (s *QuoteService) ListQuotes(userID uint, status models.QuoteStatus, page, limit int) ([]models.Quote, int64, error) {
+	return s.quoteRepo.List(userID, status, page, limit)
+}
+
+// RespondToQuote prices every item of a requested quote
+func (s *QuoteService) RespondToQuote(id, adminID uint, req dto.RespondToQuoteRequest) (*models.Quote, error) {
+	prices := make(map[uint]float64, len(req.Items))
+	for _, item := range req.Items {
+		if _, ok := prices[item.ProductID]; ok {
+			return nil, ErrQuotePricing
+		}
+		prices[item.ProductID] = math.Round(item.UnitPrice*100) / 100
+	}
+
+	return s.quoteRepo.Transition(id, func(tx *gorm.DB, quote *models.Quote) error {
+		if quote.Status != models.QuoteRequested {
+			return ErrQuoteAnswered
+		}
+		if len(prices) != len(quote.Items) {
+			return ErrQuotePricing
+		}
+		quoteRepo := s.quoteRepo.WithTx(tx)
+		for _, item := range quote.Items {
+			price, ok := prices[item.ProductID]
+			if !ok {
+				return ErrQuotePricing
+			}
+			if err := quoteRepo.SetQuotedPrice(item.ID, price); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		validUntil := req.ValidUntil.Time
+		quote.Status = models.QuoteQuoted
+		quote.ResponseNote = req.Note
+		quote.ValidUntil = &validUntil
+		quote.RespondedBy = &adminID
+		quote.RespondedAt = &now
+		return nil
+	})
+}
+
+// AcceptQuote accepts a user's quoted quote while it is still valid, storing
+// the values of the checkout form's custom fields on it. They must have been
+// checked by CustomFieldService.CheckValues. Items get the gift options chosen
+// for them, gift wrapping charged at the gift_wrap_fee store setting per unit.
+// A gift card code, when given, must name a usable card; the order placement
+// saga redeems it at payment time before spending store credit.
+func (s *QuoteService) AcceptQuote(id, userID uint, customFields map[string]interface{}, gifts []dto.GiftOptionRequest, giftCardCode string) (*models.Quote, error) {
+	return s.decide(id, userID, models.QuoteAccepted, customFields, gifts, giftCardCode)
+}
+
+// usableGiftCard retrieves a gift card by code, failing unless it can still
+// pay for something
+func usableGiftCard(tx *gorm.DB, code string) (*models.GiftCard, error) {
+	giftCard, err := repositories.NewGiftCardRepository(tx).GetByCode(code)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, ErrQuoteGiftCard
+	case err != nil:
+		return nil, err
+	case giftCard.Expired(time.Now()):
+		return nil, repositories.ErrGiftCardExpired
+	case giftCard.Balance <= 0:
+		return nil, repositories.ErrGiftCardEmpty
+	}
+	return giftCard, nil
+}
+
+// DeclineQuote declines a user's quote, either before or after it was priced
+func (s *QuoteService) DeclineQuote(id, userID uint) (*models.Quote, error) {
+	return s.decide(id, userID, models.QuoteDeclined, nil, nil, "")
+}
+
+// decide records the customer's decision on their quote. Accepting it starts
+// placing it as an order in the same transaction.
+func (s *QuoteService) decide(id, userID uint, status models.QuoteStatus, customFields map[string]interface{}, gifts []dto.GiftOptionRequest, giftCardCode string) (*models.Quote, error) {
+	decided, err := s.quoteRepo.Transition(id, func(tx *gorm.DB, quote *models.Quote) error {
+		if quote.UserID != userID {
+			return gorm.ErrRecordNotFound
+		}
+		now := time.Now()
+		switch {
+		case status == models.QuoteDeclined && quote.Status == models.QuoteRequested:
+		case quote.Status != models.QuoteQuoted:
+			return ErrQuoteNotQuoted
+		case status == models.QuoteAccepted && quote.Expired(now):
+			return ErrQuoteExpired
+		}
+		quote.Status = status
+		quote.DecidedAt = &now
+		if status == models.QuoteAccepted {
+			if err := tx.Model(quote).Select("custom_fields").Updates(&models.Quote{CustomFields: customFields}).Error; err != nil {
+				return err
+			}
+			quote.CustomFields = customFields
+			if err := applyGiftOptions(tx, quote, gifts); err != nil {
+				return err
+			}
+			var giftCardID uint
+			if giftCardCode != "" {
+				giftCard, err := usableGiftCard(tx, NormalizeGiftCardCode(giftCardCode))
+				if err != nil {
+					return err
+				}
+				giftCardID = giftCard.ID
+			}
+			return startOrderPlacement(tx, quote, giftCardID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status == models.QuoteAccepted {
+		notifySagas()
+		notifyOutbox()
+	}
+	return decided, nil
+}
+
+// applyGiftOptions stores the gift options chosen for items of a quote being
+// accepted, pricing gift wrapping at the current fee so later fee changes
+// don't alter the order
+func applyGiftOptions(tx *gorm.DB, quote *models.Quote, gifts []dto.GiftOptionRequest) error {
+	if len(gifts) == 0 {
+		return nil
+	}
+	fee := NewSettingService().Amount(models.SettingGiftWrapFee)
+	seen := make(map[uint]bool, len(gifts))
+	for _, gift := range gifts {
+		i := slices.IndexFunc(quote.Items, func(item models.QuoteItem) bool { return item.ProductID == gift.ProductID })
+		if i < 0 || seen[gift.ProductID] {
+			return ErrQuoteGiftItem
+		}
+		seen[gift.ProductID] = true
+
+		item := &quote.Items[i]
+		item.GiftWrap = gift.GiftWrap
+		item.GiftMessage = strings.TrimSpace(gift.GiftMessage)
+		item.HidePrice = gift.HidePrice
+		item.GiftWrapFee = 0
+		if item.GiftWrap {
+			item.GiftWrapFee = math.Round(fee*float64(item.Quantity)*100) / 100
+		}
+		if err := tx.Model(item).Updates(map[string]interface{}{
+			"gift_wrap":     item.GiftWrap,
+			"gift_message":  item.GiftMessage,
+			"hide_price":    item.HidePrice,
+			"gift_wrap_fee": item.GiftWrapFee,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"product-management/pkg/lock"
+
+	"gorm.io/gorm"
+)
+
+// rebuildBatchSize is how many products are staged at a time, with the run's
+// progress saved after each batch
+const rebuildBatchSize = 500
+
+var (
+	ErrUnknownRebuildTarget = errors.New("unknown rebuild target")
+	ErrRebuildRunning       = errors.New("a rebuild of this target is already running")
+	ErrRebuildRunNotFound   = errors.New("rebuild run not found")
+)
+
+// rebuildTargets are the targets that can be rebuilt, in the order they are listed
+var rebuildTargets = []models.RebuildTarget{models.RebuildSearch, models.RebuildCache}
+
+// RebuildService recomputes derived data in the background while the live
+// data keeps being served, then swaps the result in at once: the product
+// rating summaries search sorts and filters by, and the cached categories
+// with their product counts, hot products and listings
+type RebuildService struct {
+	rebuildRepo     *repositories.RebuildRepository
+	productRepo     *repositories.ProductRepository
+	categoryRepo    *repositories.CategoryRepository
+	categoryService *CategoryService
+	topProducts     int // Highest-rated active products cached by a cache rebuild
+}
+
+// NewRebuildService creates a new RebuildService instance
+func NewRebuildService(topProducts int) *RebuildService {
+	return &RebuildService{
+		rebuildRepo:     repositories.NewRebuildRepository(database.DB),
+		productRepo:     repositories.NewProductRepository(database.DB),
+		categoryRepo:    repositories.NewCategoryRepository(database.DB),
+		categoryService: NewCategoryService(),
+		topProducts:     topProducts,
+	}
+}
+
+// RebuildTargetNames returns the names of the targets that can be rebuilt
+func RebuildTargetNames() []string {
+	names := make([]string, len(rebuildTargets))
+	for i, target := range rebuildTargets {
+		names[i] = string(target)
+	}
+	return names
+}
+
+// Start rebuilds a target in the background on behalf of an admin and returns
+// its run, still running
+func (s *RebuildService) Start(target string, userID uint) (*models.RebuildRun, error) {
+	held, run, err := s.begin(target, &userID)
+	if err != nil {
+		return nil, err
+	}
+	started := *run
+	go func() {
+		defer held.Release()
+		s.rebuild(run, nil)
+		if run.Error != "" {
+			log.Printf("Warning: %s rebuild failed: %v", run.Target, run.Error)
+		}
+	}()
+	return &started, nil
+}
+
+// Run rebuilds a target and waits for it, calling progress after each step
+// with the run so far. It returns the finished run, and its error if it failed.
+func (s *RebuildService) Run(target string, progress func(*models.RebuildRun)) (*models.RebuildRun, error) {
+	held, run, err := s.begin(target, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer held.Release()
+	s.rebuild(run, progress)
+	if run.Error != "" {
+		return run, errors.New(run.Error)
+	}
+	return run, nil
+}
+
+// GetRun retrieves a rebuild run with its progress
+func (s *RebuildService) GetRun(id uint) (*models.RebuildRun, error) {
+	run, err := s.rebuildRepo.GetRun(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRebuildRunNotFound
+	}
+	return run, err
+}
+
+// ListRuns retrieves a paginated list of rebuild runs, newest first
+func (s *RebuildService) ListRuns(page, limit int) ([]models.RebuildRun, int64, error) {
+	return s.rebuildRepo.ListRuns(page, limit)
+}
+
+// begin takes the lock of a target, so a single rebuild of it runs across
+// instances, and records the start of its run, first failing the runs left
+// running by an instance that stopped
+func (s *RebuildService) begin(name string, userID *uint) (lock.Lock, *models.RebuildRun, error) {
+	target := models.RebuildTarget(name)
+	if !isRebuildTarget(target) {
+		return nil, nil, fmt.Errorf("%w %q, expected one of %s", ErrUnknownRebuildTarget, name, strings.Join(RebuildTargetNames(), ", "))
+	}
+	held, err := lock.Default.TryLock(context.Background(), "rebuild:"+name)
+	if errors.Is(err, lock.ErrLocked) {
+		return nil, nil, ErrRebuildRunning
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if err := s.rebuildRepo.FailRunning(target, now); err != nil {
+		held.Release()
+		return nil, nil, err
+	}
+	run := &models.RebuildRun{
+		Target:      target,
+		TriggeredBy: userID,
+		Status:      models.RebuildRunning,
+		StartedAt:   now,
+	}
+	if err := s.rebuildRepo.CreateRun(run); err != nil {
+		held.Release()
+		return nil, nil, err
+	}
+	return held, run, nil
+}
+
+// rebuild runs the rebuild of a run's target and records its outcome
+func (s *RebuildService) rebuild(run *models.RebuildRun, progress func(*models.RebuildRun)) {
+	report := func() error {
+		if progress != nil {
+			progress(run)
+		}
+		return s.rebuildRepo.UpdateProgress(run)
+	}
+
+	var err error
+	switch run.Target {
+	case models.RebuildSearch:
+		err = s.rebuildRatings(run, report)
+	case models.RebuildCache:
+		err = s.rebuildCache(run, report)
+	}
+
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.Status = models.RebuildSucceeded
+	if err != nil {
+		run.Status = models.RebuildFailed
+		run.Error = err.Error()
+	}
+	if err := s.rebuildRepo.FinishRun(run); err != nil {
+		log.Printf("Warning: failed to record %s rebuild run: %v", run.Target, err)
+	}
+}
+
+// rebuildRatings stages the rating summary of every live product in batches,
+// then swaps the staged summaries in. Products stay sorted and filtered by
+// their current summaries until the swap.
+func (s *RebuildService) rebuildRatings(run *models.RebuildRun, report func() error) error {
+	total, err := s.rebuildRepo.CountLiveProducts()
+	if err != nil {
+		return err
+	}
+	run.Total = int(total)
+	if err := report(); err != nil {
+		return err
+	}
+
+	if err := s.rebuildRepo.CreateRatingStaging(run.ID); err != nil {
+		return err
+	}
+	var afterID uint
+	for {
+		lastID, staged, err := s.rebuildRepo.StageRatings(run.ID, afterID, rebuildBatchSize)
+		if err != nil {
+			s.dropStaging(run.ID)
+			return err
+		}
+		if staged == 0 {
+			break
+		}
+		afterID = lastID
+		run.Processed += staged
+		if err := report(); err != nil {
+			s.dropStaging(run.ID)
+			return err
+		}
+	}
+
+	changed, err := s.rebuildRepo.SwapRatings(run.ID, run.StartedAt)
+	if err != nil {
+		s.dropStaging(run.ID)
+		return err
+	}
+	keys := make([]string, len(changed))
+	for i, id := range changed {
+		keys[i] = cache.ProductKey(id)
+	}
+	cache.Store.Delete(keys...)
+	if len(changed) > 0 {
+		invalidateProductLists()
+	}
+	notifyOutbox()
+
+	run.Changed = len(changed)
+	return report()
+}
+
+// dropStaging drops the staging table of a failed run. Failing to is only
+// logged, so the run reports the error that stopped it.
+func (s *RebuildService) dropStaging(runID uint) {
+	if err := s.rebuildRepo.DropRatingStaging(runID); err != nil {
+		log.Printf("Warning: failed to drop the staging table of rebuild run %d: %v", runID, err)
+	}
+}
+
+// rebuildCache reconciles the product counts of categories, recomputes the
+// cached categories and the hot products from the database, overwriting each
+// entry in place so readers never miss, then starts a new product listing
+// generation
+func (s *RebuildService) rebuildCache(run *models.RebuildRun, report func() error) error {
+	if _, err := s.categoryRepo.ReconcileProductCounts(); err != nil {
+		return err
+	}
+	categories, err := s.categoryService.loadCategories()
+	if err != nil {
+		return err
+	}
+	var products []models.Product
+	if s.topProducts > 0 {
+		if products, _, err = s.productRepo.List(1, s.topProducts, 0, "", "rating", []string{string(models.StatusActive)}, nil, nil); err != nil {
+			return err
+		}
+	}
+	// Categories are one entry, and listings one generation
+	run.Total = 1 + len(products) + 1
+	if err := report(); err != nil {
+		return err
+	}
+
+	cache.Store.Set(cache.CategoriesKey, categories, cache.TTL)
+	run.Processed++
+	for i := range products {
+		cache.Store.Set(cache.ProductKey(products[i].ID), &products[i], cache.TTL)
+		run.Processed++
+	}
+	if err := report(); err != nil {
+		return err
+	}
+
+	invalidateProductLists()
+	run.Processed++
+	return report()
+}
+
+// isRebuildTarget reports whether a target can be rebuilt
+func isRebuildTarget(target models.RebuildTarget) bool {
+	for _, t := range rebuildTargets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidReferralCode is returned when a referral code does not belong to any user
+var ErrInvalidReferralCode = errors.New("invalid referral code")
+
+// referralCodeLength is the number of characters in a referral code
+const referralCodeLength = 8
+
+// ReferralService manages referral codes and rewards referrers with store
+// credit. Checkout calls RewardFirstOrder once a referred user's first order
+// is paid.
+type ReferralService struct {
+	userRepo     *repositories.UserRepository
+	giftCardRepo *repositories.GiftCardRepository
+	rewardAmount float64
+}
+
+// NewReferralService creates a new ReferralService instance
+func NewReferralService(rewardAmount float64) *ReferralService {
+	return &ReferralService{
+		userRepo:     repositories.NewUserRepository(database.DB),
+		giftCardRepo: repositories.NewGiftCardRepository(database.DB),
+		rewardAmount: math.Round(rewardAmount*100) / 100,
+	}
+}
+
+// RewardAmount returns the store credit a referrer earns per referred first order
+func (s *ReferralService) RewardAmount() float64 {
+	return s.rewardAmount
+}
+
+// GetReferralCode returns a user's referral code, creating it on first use
+func (s *ReferralService) GetReferralCode(userID uint) (string, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return "", err
+	}
+	if user.ReferralCode != nil {
+		return *user.ReferralCode, nil
+	}
+
+	// A collision with another user's code fails the unique index, so retry
+	// with a fresh code a few times
+	for attempt := 0; attempt < 3; attempt++ {
+		code, err := randomCode(referralCodeLength)
+		if err != nil {
+			return "", err
+		}
+		if err := s.userRepo.SetReferralCode(userID, code); err != nil {
+			continue
+		}
+		// Re-read in case a concurrent request assigned a code first
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return "", err
+		}
+		if user.ReferralCode != nil {
+			return *user.ReferralCode, nil
+		}
+	}
+	return "", errors.New("failed to generate referral code")
+}
+
+// ResolveReferrer returns the user owning a referral code, as typed at sign-up
+func (s *ReferralService) ResolveReferrer(code string) (*models.User, error) {
+	user, err := s.userRepo.GetByReferralCode(strings.ToUpper(strings.TrimSpace(code)))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidReferralCode
+	}
+	return user, err
+}
+
+// ListReferrals retrieves the users who signed up with a user's referral code
+func (s *ReferralService) ListReferrals(userID uint) ([]models.User, error) {
+	return s.userRepo.ListReferrals(userID)
+}
+
+// TotalRewarded returns the store credit a user has earned from referrals
+func (s *ReferralService) TotalRewarded(userID uint) (float64, error) {
+	return s.giftCardRepo.SumStoreCredit(userID, models.StoreCreditSourceReferral)
+}
+
+// RewardFirstOrder grants the referrer of a user store credit for that user's
+// first order. It reports false when the user was not referred or the reward
+// was already given, so calling it for every paid order is safe.
+func (s *ReferralService) RewardFirstOrder(userID uint, orderReference string) (bool, error) {
+	if s.rewardAmount <= 0 {
+		return false, nil
+	}
+
+	rewarded := false
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		userRepo := s.userRepo.WithTx(tx)
+		marked, err := userRepo.MarkReferralRewarded(userID)
+		if err != nil || !marked {
+			return err
+		}
+		user, err := userRepo.GetByID(userID)
+		if err != nil {
+			return err
+		}
+		if err := s.giftCardRepo.WithTx(tx).AddStoreCredit(&models.StoreCreditEntry{
+			UserID:    *user.ReferredByID,
+			Source:    models.StoreCreditSourceReferral,
+			Reference: orderReference,
+			Delta:     s.rewardAmount,
+			ActorID:   userID,
+			Note:      fmt.Sprintf("referral of %s", user.Username),
+		}); err != nil {
+			return err
+		}
+		rewarded = true
+		return nil
+	})
+	return rewarded, err
+}
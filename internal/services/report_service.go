@@ -0,0 +1,253 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/reqctx"
+)
+
+var (
+	// ErrUnknownReport is returned when no report has the requested name
+	ErrUnknownReport = errors.New("report not found")
+	// ErrInvalidReportParam is returned when a report parameter is unknown, missing or malformed
+	ErrInvalidReportParam = errors.New("invalid report parameter")
+)
+
+// Report limits
+const (
+	maxReportRows = 10000
+	reportTimeout = 30 * time.Second
+)
+
+// Report parameter types
+const (
+	reportParamInt  = "int"
+	reportParamDate = "date" // YYYY-MM-DD
+)
+
+// reportParam is a parameter of a report. An omitted optional parameter is
+// bound as NULL, so the SQL supplies its default.
+type reportParam struct {
+	name        string
+	kind        string
+	description string
+	required    bool
+	min, max    int // Bounds of int parameters
+}
+
+// report is a named, parameterized SQL query analysts may run. Parameters are
+// bound as named arguments, never interpolated.
+type report struct {
+	description string
+	params      []reportParam
+	sql         string
+}
+
+// reports are the only queries the reporting API runs. Decimal results are cast
+// to double precision so they encode as JSON numbers.
+var reports = map[string]report{
+	"low_stock": {
+		description: "Active products at or below a stock level, least stocked first",
+		params: []reportParam{
+			{name: "threshold", kind: reportParamInt, description: "Highest stock quantity to include, defaults to 10", min: 0, max: 1000000},
+		},
+		sql: `SELECT p.id, p.name, p.sku, p.stock_quantity, p.price
+			FROM products p
+			WHERE p.deleted_at IS NULL AND p.status = 'active'
+				AND p.stock_quantity <= COALESCE(CAST(@threshold AS integer), 10)
+			ORDER BY p.stock_quantity, p.id`,
+	},
+	"top_rated_products": {
+		description: "Products with the highest average rating",
+		params: []reportParam{
+			{name: "min_reviews", kind: reportParamInt, description: "Reviews a product needs to be ranked, defaults to 3", min: 1, max: 1000000},
+		},
+		sql: `SELECT p.id, p.name, p.rating_average, p.rating_count
+			FROM products p
+			WHERE p.deleted_at IS NULL
+				AND p.rating_count >= COALESCE(CAST(@min_reviews AS integer), 3)
+			ORDER BY p.rating_average DESC, p.rating_count DESC, p.id`,
+	},
+	"review_volume": {
+		description: "Reviews and average rating per day, in UTC",
+		params: []reportParam{
+			{name: "from", kind: reportParamDate, description: "First day, defaults to 29 days before today"},
+			{name: "to", kind: reportParamDate, description: "Last day, defaults to today"},
+		},
+		sql: `SELECT CAST(r.created_at AS date) AS day, COUNT(*) AS review_count, CAST(ROUND(AVG(r.rating), 2) AS double precision) AS average_rating
+			FROM reviews r
+			WHERE r.deleted_at IS NULL
+				AND r.created_at >= COALESCE(CAST(@from AS date), CURRENT_DATE - 29)
+				AND r.created_at < COALESCE(CAST(@to AS date), CURRENT_DATE) + 1
+			GROUP BY day
+			ORDER BY day`,
+	},
+	"user_signups": {
+		description: "New users per day, in UTC",
+		params: []reportParam{
+			{name: "from", kind: reportParamDate, description: "First day, defaults to 29 days before today"},
+			{name: "to", kind: reportParamDate, description: "Last day, defaults to today"},
+		},
+		sql: `SELECT CAST(u.created_at AS date) AS day, COUNT(*) AS signups
+			FROM users u
+			WHERE u.deleted_at IS NULL
+				AND u.created_at >= COALESCE(CAST(@from AS date), CURRENT_DATE - 29)
+				AND u.created_at < COALESCE(CAST(@to AS date), CURRENT_DATE) + 1
+			GROUP BY day
+			ORDER BY day`,
+	},
+	"category_inventory": {
+		description: "Products, active products and units in stock per category",
+		sql: `SELECT c.id, c.name, COUNT(p.id) AS products,
+				COUNT(p.id) FILTER (WHERE p.status = 'active') AS active_products,
+				COALESCE(SUM(p.stock_quantity), 0) AS units_in_stock
+			FROM categories c
+			LEFT JOIN product_categories pc ON pc.category_id = c.id
+			LEFT JOIN products p ON p.id = pc.product_id AND p.deleted_at IS NULL
+			WHERE c.deleted_at IS NULL
+			GROUP BY c.id, c.name
+			ORDER BY c.name`,
+	},
+	"sales_by_country": {
+		description: "Accepted quotes and their value per country the customer requested them from, by decision date in UTC",
+		params: []reportParam{
+			{name: "from", kind: reportParamDate, description: "First day, defaults to 29 days before today"},
+			{name: "to", kind: reportParamDate, description: "Last day, defaults to today"},
+		},
+		sql: `SELECT COALESCE(NULLIF(q.country, ''), 'unknown') AS country, COUNT(DISTINCT q.id) AS accepted_quotes,
+				COALESCE(SUM(i.quantity), 0) AS units,
+				CAST(ROUND(CAST(COALESCE(SUM(i.quoted_price * i.quantity), 0) AS numeric), 2) AS double precision) AS revenue
+			FROM quotes q
+			JOIN quote_items i ON i.quote_id = q.id AND i.deleted_at IS NULL
+			WHERE q.deleted_at IS NULL AND q.status = 'accepted'
+				AND q.decided_at >= COALESCE(CAST(@from AS date), CURRENT_DATE - 29)
+				AND q.decided_at < COALESCE(CAST(@to AS date), CURRENT_DATE) + 1
+			GROUP BY 1
+			ORDER BY revenue DESC, country`,
+	},
+	"open_purchase_orders": {
+		description: "Purchase orders with items still to be delivered, and the outstanding units and cost",
+		sql: `SELECT po.id, s.name AS supplier, po.status, po.expected_at,
+				SUM(i.quantity_ordered - i.quantity_received) AS outstanding_units,
+				CAST(ROUND(CAST(SUM((i.quantity_ordered - i.quantity_received) * i.unit_cost) AS numeric), 2) AS double precision) AS outstanding_cost
+			FROM purchase_orders po
+			JOIN suppliers s ON s.id = po.supplier_id
+			JOIN purchase_order_items i ON i.purchase_order_id = po.id AND i.deleted_at IS NULL
+			WHERE po.deleted_at IS NULL AND po.status IN ('open', 'partial')
+			GROUP BY po.id, s.name
+			ORDER BY po.expected_at NULLS LAST, po.id`,
+	},
+}
+
+// ReportService runs the predefined reports
+type ReportService struct {
+	reportRepo *repositories.ReportRepository
+}
+
+// NewReportService creates a new ReportService instance
+func NewReportService() *ReportService {
+	return &ReportService{
+		reportRepo: repositories.NewReportRepository(database.DB),
+	}
+}
+
+// ListReports describes every report, by name
+func (s *ReportService) ListReports() []dto.ReportDefinitionResponse {
+	names := make([]string, 0, len(reports))
+	for name := range reports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	definitions := make([]dto.ReportDefinitionResponse, len(names))
+	for i, name := range names {
+		definition := dto.ReportDefinitionResponse{
+			Name:        name,
+			Description: reports[name].description,
+			Params:      make([]dto.ReportParamResponse, len(reports[name].params)),
+		}
+		for j, param := range reports[name].params {
+			definition.Params[j] = dto.ReportParamResponse{
+				Name:        param.name,
+				Type:        param.kind,
+				Required:    param.required,
+				Description: param.description,
+			}
+		}
+		definitions[i] = definition
+	}
+	return definitions
+}
+
+// RunReport validates the parameters of a report and runs it. Dates start at
+// midnight in the request's time zone.
+func (s *ReportService) RunReport(rc reqctx.Context, name string, params map[string]string) (*dto.ReportResponse, error) {
+	definition, ok := reports[name]
+	if !ok {
+		return nil, ErrUnknownReport
+	}
+
+	known := make(map[string]bool, len(definition.params))
+	args := make(map[string]interface{}, len(definition.params))
+	for _, param := range definition.params {
+		known[param.name] = true
+		value, err := param.parse(params[param.name], rc.Location)
+		if err != nil {
+			return nil, err
+		}
+		args[param.name] = value
+	}
+	for key := range params {
+		if !known[key] {
+			return nil, fmt.Errorf("%w: %s is not a parameter of %s", ErrInvalidReportParam, key, name)
+		}
+	}
+
+	columns, rows, truncated, err := s.reportRepo.Query(definition.sql, args, maxReportRows, reportTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		rows = [][]interface{}{}
+	}
+	return &dto.ReportResponse{
+		Name:      name,
+		Columns:   columns,
+		Rows:      rows,
+		Truncated: truncated,
+	}, nil
+}
+
+// parse converts the raw value of a parameter, with dates in loc, returning nil
+// when it was omitted
+func (p reportParam) parse(raw string, loc *time.Location) (interface{}, error) {
+	if raw == "" {
+		if p.required {
+			return nil, fmt.Errorf("%w: %s is required", ErrInvalidReportParam, p.name)
+		}
+		return nil, nil
+	}
+
+	switch p.kind {
+	case reportParamInt:
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < p.min || value > p.max {
+			return nil, fmt.Errorf("%w: %s must be an integer from %d to %d", ErrInvalidReportParam, p.name, p.min, p.max)
+		}
+		return value, nil
+	case reportParamDate:
+		value, err := time.ParseInLocation("2006-01-02", raw, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s must be a date (YYYY-MM-DD)", ErrInvalidReportParam, p.name)
+		}
+		return value, nil
+	}
+	return raw, nil
+}
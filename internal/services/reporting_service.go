@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/lock"
+)
+
+// ReportingService refreshes the materialized views of the reporting schema,
+// which heavy analytics read in materialized mode instead of the live tables
+type ReportingService struct {
+	refreshInterval time.Duration
+	reportingRepo   *repositories.ReportingRepository
+}
+
+// NewReportingService creates a new ReportingService instance
+func NewReportingService(refreshInterval time.Duration) *ReportingService {
+	return &ReportingService{
+		refreshInterval: refreshInterval,
+		reportingRepo:   repositories.NewReportingRepository(database.DB, true),
+	}
+}
+
+// Start refreshes the reporting views now and then every refresh interval, and
+// returns a function that stops it
+func (s *ReportingService) Start() func() {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(s.refreshInterval)
+		defer ticker.Stop()
+		for {
+			if err := s.Run(); err != nil {
+				log.Printf("Warning: reporting refresh failed: %v", err)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
+
+// Run refreshes every reporting view. When several instances run, the one
+// holding the refresh lock refreshes them and the others skip their turn.
+func (s *ReportingService) Run() error {
+	held, err := lock.Default.TryLock(context.Background(), "reporting refresh")
+	if errors.Is(err, lock.ErrLocked) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer held.Release()
+
+	// A view failing to refresh keeps its previous rows, and the others are
+	// still refreshed
+	var failed []error
+	for _, view := range database.ReportingViews {
+		if err := s.reportingRepo.Refresh(view.Name); err != nil {
+			failed = append(failed, fmt.Errorf("%s: %w", view.Name, err))
+		}
+	}
+	return errors.Join(failed...)
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+)
+
+// retentionBatchSize is the number of records deleted per statement
+const retentionBatchSize = 1000
+
+// retentionEntity is a table retention rules can apply to, and the timestamp
+// column its records age by
+type retentionEntity struct {
+	table       string
+	column      string
+	description string
+}
+
+// retentionEntities are the only tables retention rules may delete from.
+// Request logs go to stdout rather than the database, and there are no carts,
+// so their retention is left to the log pipeline.
+var retentionEntities = map[string]retentionEntity{
+	"audit_logs": {
+		table: "audit_logs", column: "created_at",
+		description: "Audit log entries, by when they were recorded",
+	},
+	"known_devices": {
+		table: "known_devices", column: "last_seen_at",
+		description: "Devices users signed in from, by their last sign-in. A forgotten device triggers a new-device alert at its next sign-in.",
+	},
+	"webhook_deliveries": {
+		table: "webhook_deliveries", column: "created_at",
+		description: "Webhook delivery attempts, by when they were made",
+	},
+	"refresh_tokens": {
+		table: "refresh_tokens", column: "expires_at",
+		description: "Refresh tokens issued at login and refresh, by when they expire. Expired tokens can no longer be exchanged.",
+	},
+	"revoked_tokens": {
+		table: "revoked_tokens", column: "expires_at",
+		description: "Access tokens revoked at logout, by when they expire. Expired tokens are rejected anyway.",
+	},
+	"warehouse_events": {
+		table: "warehouse_events", column: "exported_at",
+		description: "Analytics events queued for the warehouse, by when they were exported. Events not exported yet are kept.",
+	},
+	"product_views": {
+		table: "product_views", column: "viewed_at",
+		description: "Product views recorded for trending products and conversion analytics, by when they happened",
+	},
+	"experiment_events": {
+		table: "experiment_events", column: "created_at",
+		description: "Exposures and conversions of A/B experiments, by when they were recorded. Results only count the events kept.",
+	},
+	"request_stats": {
+		table: "request_stats", column: "bucket_start",
+		description: "Request statistics of the activity dashboard, by the start of their bucket",
+	},
+	"outbox_events": {
+		table: "outbox_events", column: "published_at",
+		description: "Events published from the outbox, by when they were published. Pending and failed events are kept.",
+	},
+	"stock_movements": {
+		table: "stock_movements", column: "created_at",
+		description: "Stock ledger entries, by when they were recorded. Stock levels are not changed.",
+	},
+	"sync_changes": {
+		table: "sync_changes", column: "created_at",
+		description: "Delta sync change log entries, by when they were recorded. Clients offline for longer refresh fully.",
+	},
+}
+
+// CheckRetentionRules returns an error when a rule names an unknown entity or
+// has no positive age
+func CheckRetentionRules(rules map[string]time.Duration) error {
+	for entity, age := range rules {
+		if _, ok := retentionEntities[entity]; !ok {
+			return fmt.Errorf("unknown retention entity %q, expected one of %s", entity, strings.Join(retentionEntityNames(), ", "))
+		}
+		if age <= 0 {
+			return fmt.Errorf("retention of %s must be positive", entity)
+		}
+	}
+	return nil
+}
+
+// RetentionService deletes records older than the retention rules allow
+type RetentionService struct {
+	rules         map[string]time.Duration
+	interval      time.Duration
+	retentionRepo *repositories.RetentionRepository
+}
+
+// NewRetentionService creates a new RetentionService instance for rules that
+// passed CheckRetentionRules
+func NewRetentionService(rules map[string]time.Duration, interval time.Duration) *RetentionService {
+	return &RetentionService{
+		rules:         rules,
+		interval:      interval,
+		retentionRepo: repositories.NewRetentionRepository(database.DB),
+	}
+}
+
+// Run enforces every retention rule once and records the outcome of each.
+// A failing rule does not stop the others.
+func (s *RetentionService) Run() error {
+	var errs []error
+	for _, name := range retentionEntityNames() {
+		age, ok := s.rules[name]
+		if !ok {
+			continue
+		}
+		entity := retentionEntities[name]
+
+		start := time.Now()
+		deleted, err := s.retentionRepo.DeleteOlderThan(entity.table, entity.column, start.Add(-age), retentionBatchSize)
+		run := &models.RetentionRun{
+			Entity:     name,
+			RanAt:      start,
+			Deleted:    deleted,
+			DurationMs: time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			run.Error = err.Error()
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		} else if deleted > 0 {
+			log.Printf("Retention deleted %d %s older than %v", deleted, name, age)
+		}
+		if err := s.retentionRepo.RecordRun(run); err != nil {
+			errs = append(errs, fmt.Errorf("%s: failed to record run: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Start enforces the retention rules now and then every interval, and returns
+// a function that stops it
+func (s *RetentionService) Start() func() {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			if err := s.Run(); err != nil {
+				log.Printf("Warning: retention cleanup failed: %v", err)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
+
+// GetPolicies describes the retention rule of every entity with its last run
+func (s *RetentionService) GetPolicies() (*dto.RetentionResponse, error) {
+	runs, err := s.retentionRepo.ListRuns()
+	if err != nil {
+		return nil, err
+	}
+	lastRuns := make(map[string]*models.RetentionRun, len(runs))
+	for i := range runs {
+		lastRuns[runs[i].Entity] = &runs[i]
+	}
+
+	names := retentionEntityNames()
+	response := &dto.RetentionResponse{
+		Interval: s.interval.String(),
+		Policies: make([]dto.RetentionPolicyResponse, len(names)),
+	}
+	for i, name := range names {
+		policy := dto.RetentionPolicyResponse{
+			Entity:      name,
+			Description: retentionEntities[name].description,
+		}
+		if age, ok := s.rules[name]; ok {
+			policy.Enabled = true
+			policy.MaxAge = age.String()
+			policy.MaxAgeDays = math.Round(age.Hours()/24*100) / 100
+		}
+		if run := lastRuns[name]; run != nil {
+			policy.LastRun = &dto.RetentionRunResponse{
+				RanAt:        dto.NewTime(run.RanAt),
+				Deleted:      run.Deleted,
+				TotalDeleted: run.TotalDeleted,
+				DurationMs:   run.DurationMs,
+				Error:        run.Error,
+			}
+		}
+		response.Policies[i] = policy
+	}
+	return response, nil
+}
+
+// retentionEntityNames returns the names of the entities retention rules may name, sorted
+func retentionEntityNames() []string {
+	names := make([]string, 0, len(retentionEntities))
+	for name := range retentionEntities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
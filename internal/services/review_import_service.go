@@ -0,0 +1,379 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"product-management/pkg/events"
+
+	"gorm.io/gorm"
+)
+
+// MaxReviewImportRows is the most rows a single review import accepts
+const MaxReviewImportRows = 5000
+
+// importedEmailDomain is reserved by RFC 2606, so mail to placeholder users is never delivered
+const importedEmailDomain = "imported.invalid"
+
+var (
+	ErrInvalidImportSource = errors.New("source may only contain lowercase letters, digits, dashes and underscores, up to 32 characters")
+	ErrInvalidImportFile   = errors.New("invalid import file")
+	ErrEmptyImport         = errors.New("import file has no rows")
+	ErrTooManyImportRows   = fmt.Errorf("import file has more than %d rows", MaxReviewImportRows)
+)
+
+// importSourcePattern matches a valid source platform name, such as shopify
+var importSourcePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// ReviewImportService imports reviews exported from another store platform,
+// attributing them to placeholder accounts for the platform's customers
+type ReviewImportService struct {
+	reviewRepo     *repositories.ReviewRepository
+	userRepo       *repositories.UserRepository
+	productRepo    *repositories.ProductRepository
+	productService *ProductService
+}
+
+// NewReviewImportService creates a new ReviewImportService instance
+func NewReviewImportService() *ReviewImportService {
+	return &ReviewImportService{
+		reviewRepo:     repositories.NewReviewRepository(database.DB),
+		userRepo:       repositories.NewUserRepository(database.DB),
+		productRepo:    repositories.NewProductRepository(database.DB),
+		productService: NewProductService(),
+	}
+}
+
+// importRecord is a row of an import file with any error reading it
+type importRecord struct {
+	row dto.ReviewImportRow
+	err error
+}
+
+// readReviewImport reads the rows of an import file, a JSON array or a CSV
+// file with a header row naming the columns
+func readReviewImport(in io.Reader, isCSV bool) ([]importRecord, error) {
+	var records []importRecord
+	if isCSV {
+		var err error
+		if records, err = readReviewImportCSV(in); err != nil {
+			if errors.Is(err, ErrTooManyImportRows) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %w", ErrInvalidImportFile, err)
+		}
+	} else {
+		var rows []dto.ReviewImportRow
+		if err := json.NewDecoder(in).Decode(&rows); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidImportFile, err)
+		}
+		records = make([]importRecord, len(rows))
+		for i, row := range rows {
+			records[i].row = row
+		}
+	}
+
+	if len(records) == 0 {
+		return nil, ErrEmptyImport
+	}
+	if len(records) > MaxReviewImportRows {
+		return nil, ErrTooManyImportRows
+	}
+	return records, nil
+}
+
+// readReviewImportCSV reads CSV rows by the names in the header. Unknown
+// columns are ignored, so exports can be uploaded as they are once renamed.
+func readReviewImportCSV(in io.Reader) ([]importRecord, error) {
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"external_user_id", "rating"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	var records []importRecord
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == MaxReviewImportRows {
+			return nil, ErrTooManyImportRows
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+
+		record := importRecord{row: dto.ReviewImportRow{
+			SKU:            field("sku"),
+			ExternalUserID: field("external_user_id"),
+			UserName:       field("user_name"),
+			Comment:        field("comment"),
+			CreatedAt:      field("created_at"),
+		}}
+		if rating, err := strconv.Atoi(field("rating")); err == nil {
+			record.row.Rating = rating
+		} else {
+			record.err = fmt.Errorf("invalid rating %q", field("rating"))
+		}
+		if id := field("product_id"); id != "" {
+			if parsed, err := strconv.ParseUint(id, 10, 32); err == nil {
+				record.row.ProductID = uint(parsed)
+			} else {
+				record.err = fmt.Errorf("invalid product_id %q", id)
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// reviewImport is the state of one import run
+type reviewImport struct {
+	source   string
+	dryRun   bool
+	response *dto.ReviewImportResponse
+
+	products map[string]uint // Product ID by "id:N" or "sku:S" reference, 0 when missing
+	users    map[string]uint // Placeholder user ID by username, 0 when not created yet on a dry run
+	reviewed map[string]bool // "username/productID" pairs reviewed by earlier rows
+	changed  map[uint]bool   // Products that got a review
+}
+
+// Import imports a review export from a source platform, a JSON array of rows
+// or a CSV file. Rows are checked and imported one by one: invalid rows are
+// reported in the response and the others are still imported. Each external user gets one placeholder
+// account per source, reused by later imports, so importing the same file
+// again reports its rows as already reviewed instead of duplicating them.
+func (s *ReviewImportService) Import(source string, in io.Reader, isCSV, dryRun bool) (*dto.ReviewImportResponse, error) {
+	if !importSourcePattern.MatchString(source) {
+		return nil, ErrInvalidImportSource
+	}
+	records, err := readReviewImport(in, isCSV)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &reviewImport{
+		source:   source,
+		dryRun:   dryRun,
+		response: &dto.ReviewImportResponse{Source: source, DryRun: dryRun, Rows: len(records), Errors: []dto.ReviewImportError{}},
+		products: make(map[string]uint),
+		users:    make(map[string]uint),
+		reviewed: make(map[string]bool),
+		changed:  make(map[uint]bool),
+	}
+	for i, record := range records {
+		err := record.err
+		if err == nil {
+			err = s.importRow(run, record.row)
+		}
+		if err != nil {
+			run.response.Errors = append(run.response.Errors, dto.ReviewImportError{Row: i + 1, Error: err.Error()})
+			continue
+		}
+		run.response.Imported++
+	}
+
+	for productID := range run.changed {
+		cache.Store.Delete(cache.ProductKey(productID))
+		events.Publish(events.ProductChanged{ProductID: productID})
+	}
+	return run.response, nil
+}
+
+// importRow validates a row and, unless on a dry run, saves its review
+func (s *ReviewImportService) importRow(run *reviewImport, row dto.ReviewImportRow) error {
+	row.ExternalUserID = strings.TrimSpace(row.ExternalUserID)
+	row.UserName = strings.TrimSpace(row.UserName)
+	row.Comment = strings.TrimSpace(row.Comment)
+	switch {
+	case row.ExternalUserID == "":
+		return errors.New("external_user_id is required")
+	case len(row.ExternalUserID) > 255:
+		return errors.New("external_user_id must be at most 255 characters")
+	case len(row.UserName) > 100:
+		return errors.New("user_name must be at most 100 characters")
+	case row.Rating < 1 || row.Rating > 5:
+		return errors.New("rating must be between 1 and 5")
+	case len(row.Comment) > 500:
+		return errors.New("comment must be at most 500 characters")
+	}
+	createdAt, err := parseImportTime(row.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	productID, err := s.resolveProduct(run, row)
+	if err != nil {
+		return err
+	}
+	username := placeholderUsername(run.source, row.ExternalUserID)
+	userID, err := s.resolveUser(run, username, row.UserName)
+	if err != nil {
+		return err
+	}
+
+	pair := fmt.Sprintf("%s/%d", username, productID)
+	if run.reviewed[pair] {
+		return errors.New("the reviewer has another review of this product earlier in the file")
+	}
+	if userID != 0 {
+		_, err := s.reviewRepo.GetByUserAndProduct(userID, productID)
+		if err == nil {
+			return errors.New("the reviewer has already reviewed this product")
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+	}
+	run.reviewed[pair] = true
+	if run.dryRun {
+		return nil
+	}
+
+	review := &models.Review{
+		ProductID: productID,
+		UserID:    userID,
+		Rating:    row.Rating,
+		Comment:   row.Comment,
+		Imported:  true,
+		Source:    run.source,
+	}
+	review.CreatedAt, review.UpdatedAt = createdAt, createdAt
+	if err := s.reviewRepo.Create(review); err != nil {
+		return err
+	}
+	run.changed[productID] = true
+	return nil
+}
+
+// resolveProduct finds the product a row reviews by ID or SKU
+func (s *ReviewImportService) resolveProduct(run *reviewImport, row dto.ReviewImportRow) (uint, error) {
+	var ref string
+	switch {
+	case row.ProductID != 0:
+		ref = fmt.Sprintf("id:%d", row.ProductID)
+	case strings.TrimSpace(row.SKU) != "":
+		ref = "sku:" + strings.TrimSpace(row.SKU)
+	default:
+		return 0, errors.New("product_id or sku is required")
+	}
+
+	productID, ok := run.products[ref]
+	if !ok {
+		var product *models.Product
+		var err error
+		if row.ProductID != 0 {
+			product, err = s.productService.GetProduct(row.ProductID)
+		} else {
+			product, err = s.productRepo.GetBySKU(strings.TrimSpace(row.SKU))
+		}
+		if err != nil {
+			return 0, err
+		}
+		if product != nil {
+			productID = product.ID
+		}
+		run.products[ref] = productID
+	}
+	if productID == 0 {
+		return 0, fmt.Errorf("product %s does not exist", strings.Replace(ref, ":", " ", 1))
+	}
+	return productID, nil
+}
+
+// resolveUser finds or creates the placeholder account of an external user.
+// On a dry run nothing is created and new users are 0.
+func (s *ReviewImportService) resolveUser(run *reviewImport, username, fullName string) (uint, error) {
+	if userID, ok := run.users[username]; ok {
+		return userID, nil
+	}
+
+	user, err := s.userRepo.GetByUsername2(username)
+	if err != nil {
+		return 0, err
+	}
+	if user == nil {
+		run.response.UsersCreated++
+		if run.dryRun {
+			run.users[username] = 0
+			return 0, nil
+		}
+
+		// Nobody knows the random password, so placeholders cannot sign in
+		password := make([]byte, 32)
+		if _, err := rand.Read(password); err != nil {
+			return 0, err
+		}
+		user = &models.User{
+			Username: username,
+			Email:    username + "@" + importedEmailDomain,
+			FullName: fullName,
+			Password: hex.EncodeToString(password),
+			Role:     models.RoleUser,
+		}
+		if err := s.userRepo.Create(user); err != nil {
+			run.response.UsersCreated--
+			return 0, err
+		}
+	}
+	run.users[username] = user.ID
+	return user.ID, nil
+}
+
+// placeholderUsername derives the username of an external user's placeholder
+// account. The ID is hashed so any ID fits and none is exposed.
+func placeholderUsername(source, externalUserID string) string {
+	sum := sha256.Sum256([]byte(externalUserID))
+	return "imported-" + source + "-" + hex.EncodeToString(sum[:8])
+}
+
+// parseImportTime parses when an imported review was written, now when empty
+func parseImportTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Now(), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", value); err != nil {
+			return time.Time{}, fmt.Errorf("invalid created_at %q, expected RFC 3339 or YYYY-MM-DD", value)
+		}
+	}
+	if t.After(time.Now()) {
+		return time.Time{}, errors.New("created_at is in the future")
+	}
+	return t, nil
+}
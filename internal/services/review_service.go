@@ -1,8 +1,13 @@
 package services
 
 import (
+	"math"
+	"product-management/internal/dto"
 	"product-management/internal/models"
 	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/events"
+	"strconv"
 )
 
 // ReviewService handles business logic for reviews
@@ -19,7 +24,12 @@ func NewReviewService(reviewRepo *repositories.ReviewRepository) *ReviewService
 
 // CreateReview creates a new review
 func (s *ReviewService) CreateReview(review *models.Review) error {
-	return s.reviewRepo.Create(review)
+	if err := s.reviewRepo.Create(review); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.ProductKey(review.ProductID))
+	events.Publish(events.ProductChanged{ProductID: review.ProductID})
+	return nil
 }
 
 // GetReviewByID retrieves a review by its ID
@@ -37,6 +47,39 @@ func (s *ReviewService) GetReviewsByUserID(userID uint) ([]models.Review, error)
 	return s.reviewRepo.GetByUserID(userID)
 }
 
+// ListReviewsByUserID retrieves a paginated list of a user's reviews
+func (s *ReviewService) ListReviewsByUserID(userID uint, page, pageSize int, includeHidden bool) ([]models.Review, int64, error) {
+	return s.reviewRepo.ListByUserID(userID, page, pageSize, includeHidden)
+}
+
+// GetUserReviewStats computes the review count, average rating and rating distribution of a user
+func (s *ReviewService) GetUserReviewStats(userID uint, includeHidden bool) (*dto.UserReviewStatsResponse, error) {
+	counts, err := s.reviewRepo.GetUserRatingCounts(userID, includeHidden)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &dto.UserReviewStatsResponse{
+		UserID:             userID,
+		RatingDistribution: make(map[string]int64, 5),
+	}
+	for rating := 1; rating <= 5; rating++ {
+		stats.RatingDistribution[strconv.Itoa(rating)] = 0
+	}
+
+	var ratingSum int64
+	for _, count := range counts {
+		stats.RatingDistribution[strconv.Itoa(count.Rating)] = count.Count
+		stats.ReviewCount += count.Count
+		ratingSum += int64(count.Rating) * count.Count
+	}
+	if stats.ReviewCount > 0 {
+		stats.AverageRating = math.Round(float64(ratingSum)/float64(stats.ReviewCount)*100) / 100
+	}
+
+	return stats, nil
+}
+
 // GetReviewByUserAndProduct retrieves a review by user ID and product ID
 func (s *ReviewService) GetReviewByUserAndProduct(userID, productID uint) (*models.Review, error) {
 	return s.reviewRepo.GetByUserAndProduct(userID, productID)
@@ -44,12 +87,26 @@ func (s *ReviewService) GetReviewByUserAndProduct(userID, productID uint) (*mode
 
 // UpdateReview updates a review
 func (s *ReviewService) UpdateReview(review *models.Review) error {
-	return s.reviewRepo.Update(review)
+	if err := s.reviewRepo.Update(review); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.ProductKey(review.ProductID))
+	events.Publish(events.ProductChanged{ProductID: review.ProductID})
+	return nil
 }
 
 // DeleteReview deletes a review
 func (s *ReviewService) DeleteReview(id uint) error {
-	return s.reviewRepo.Delete(id)
+	review, err := s.reviewRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.reviewRepo.Delete(id); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.ProductKey(review.ProductID))
+	events.Publish(events.ProductChanged{ProductID: review.ProductID})
+	return nil
 }
 
 // GetAverageRating calculates the average rating for a product
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/lock"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// sagaBatchSize is the number of sagas the runner loads at a time
+	sagaBatchSize = 100
+	// sagaMaxBackoff caps the wait between attempts at a step
+	sagaMaxBackoff = time.Hour
+)
+
+// sagaDefinitions are the steps of every saga type, in order
+var sagaDefinitions = map[models.SagaType][]sagaStep{
+	models.SagaOrderPlacement: orderPlacementSteps,
+}
+
+// sagaStatusHooks run within the transaction recording that a saga of a type
+// started compensating or finished, with its new status
+var sagaStatusHooks = map[models.SagaType]func(tx *gorm.DB, saga *models.Saga, status models.SagaStatus) error{
+	models.SagaOrderPlacement: orderPlacementStatusChanged,
+}
+
+// statusHook returns the function recording a saga's move to status within a
+// transaction, doing nothing for types without a hook
+func statusHook(saga *models.Saga, status models.SagaStatus) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		hook, ok := sagaStatusHooks[saga.Type]
+		if !ok {
+			return nil
+		}
+		return hook(tx, saga, status)
+	}
+}
+
+// sagaPending wakes the runner of this instance when a saga was started
+var sagaPending = make(chan struct{}, 1)
+
+// notifySagas tells the runner that sagas were committed, so they run now
+// rather than at its next poll
+func notifySagas() {
+	select {
+	case sagaPending <- struct{}{}:
+	default:
+	}
+}
+
+// sagaStep is a step of a saga type. Its action, and its compensation when the
+// step has anything to undo, run in a transaction that also records their
+// outcome, so their database changes take effect exactly once. Whatever they do
+// outside the database, such as sending notifications, may happen again when an
+// instance stops midway.
+type sagaStep struct {
+	name       string
+	action     func(tx *gorm.DB, saga *models.Saga) error
+	compensate func(tx *gorm.DB, saga *models.Saga) error // Nil when the step has nothing to undo
+	committed  func(saga *models.Saga)                    // Optional, runs once the action or compensation is committed
+}
+
+// sagaAbort is a step failure that retrying cannot fix, such as a product out of
+// stock, so the saga compensates right away
+type sagaAbort struct {
+	err error
+}
+
+func (e *sagaAbort) Error() string { return e.err.Error() }
+func (e *sagaAbort) Unwrap() error { return e.err }
+
+// abortSaga marks a step failure as one retrying cannot fix
+func abortSaga(err error) error {
+	return &sagaAbort{err: err}
+}
+
+// startSaga stores a new saga of a type within tx, so it runs if and only if
+// the change calling for it is committed. Callers notifySagas once committed.
+func startSaga(tx *gorm.DB, sagaType models.SagaType, reference string, data interface{}) error {
+	steps, ok := sagaDefinitions[sagaType]
+	if !ok {
+		return fmt.Errorf("unknown saga type %q", sagaType)
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	saga := &models.Saga{
+		Type:          sagaType,
+		Reference:     reference,
+		Status:        models.SagaRunning,
+		Data:          string(payload),
+		NextAttemptAt: &now,
+		Steps:         make([]models.SagaStep, len(steps)),
+	}
+	for i, step := range steps {
+		saga.Steps[i] = models.SagaStep{Position: i, Name: step.name, Status: models.SagaStepPending}
+	}
+	return repositories.NewSagaRepository(tx).Create(saga)
+}
+
+// decodeSagaData reads the state of a saga into data
+func decodeSagaData(saga *models.Saga, data interface{}) error {
+	return json.Unmarshal([]byte(saga.Data), data)
+}
+
+// encodeSagaData replaces the state of a saga, saved along with the step
+func encodeSagaData(saga *models.Saga, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	saga.Data = string(payload)
+	return nil
+}
+
+// SagaService runs sagas: operations such as placing an order that span
+// stock, payment and notifications. It runs their steps in order, retrying
+// failed ones, and when a step fails for good undoes the completed ones in
+// reverse order with their compensations. Progress is stored after every step,
+// so any instance resumes a saga where another stopped.
+type SagaService struct {
+	pollInterval time.Duration
+	maxAttempts  int
+	sagaRepo     *repositories.SagaRepository
+}
+
+// NewSagaService creates a new SagaService instance
+func NewSagaService(pollInterval time.Duration, maxAttempts int) *SagaService {
+	return &SagaService{
+		pollInterval: pollInterval,
+		maxAttempts:  maxAttempts,
+		sagaRepo:     repositories.NewSagaRepository(database.DB),
+	}
+}
+
+// Start runs due sagas now, then every poll interval and whenever this instance
+// starts new ones, and returns a function that stops it
+func (s *SagaService) Start() func() {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+		for {
+			if err := s.Run(); err != nil {
+				log.Printf("Warning: saga runner failed: %v", err)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			case <-sagaPending:
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
+
+// Run advances every due saga as far as it goes. When several instances run,
+// the one holding the runner lock advances sagas and the others skip their turn.
+func (s *SagaService) Run() error {
+	held, err := lock.Default.TryLock(context.Background(), "saga runner")
+	if errors.Is(err, lock.ErrLocked) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer held.Release()
+
+	for {
+		due, err := s.sagaRepo.ListDue(sagaBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range due {
+			if err := s.advance(&due[i]); err != nil {
+				log.Printf("Warning: failed to advance saga %d (%s %s): %v", due[i].ID, due[i].Type, due[i].Reference, err)
+			}
+		}
+		if len(due) < sagaBatchSize {
+			return nil
+		}
+	}
+}
+
+// advance runs the steps of a saga, or compensates them, until it finishes or
+// waits to retry a failed attempt
+func (s *SagaService) advance(saga *models.Saga) error {
+	steps, ok := sagaDefinitions[saga.Type]
+	if !ok || len(steps) != len(saga.Steps) {
+		return s.finish(saga, models.SagaFailed, fmt.Sprintf("unknown saga type %q", saga.Type))
+	}
+
+	for {
+		var proceed bool
+		var err error
+		switch saga.Status {
+		case models.SagaRunning:
+			i := slices.IndexFunc(saga.Steps, func(step models.SagaStep) bool {
+				return step.Status == models.SagaStepPending
+			})
+			if i < 0 {
+				return s.finish(saga, models.SagaCompleted, "")
+			}
+			proceed, err = s.runStep(saga, &saga.Steps[i], steps[i])
+		case models.SagaCompensating:
+			i := len(saga.Steps) - 1
+			for i >= 0 && (saga.Steps[i].Status != models.SagaStepCompleted || steps[i].compensate == nil) {
+				i--
+			}
+			if i < 0 {
+				return s.finish(saga, models.SagaCompensated, "")
+			}
+			proceed, err = s.compensateStep(saga, &saga.Steps[i], steps[i])
+		default:
+			return nil
+		}
+		if err != nil || !proceed {
+			return err
+		}
+	}
+}
+
+// runStep attempts the action of a step and reports whether the saga can
+// proceed: with the next step, or with compensating when the step failed for good
+func (s *SagaService) runStep(saga *models.Saga, step *models.SagaStep, definition sagaStep) (bool, error) {
+	data := saga.Data
+	actionErr := s.sagaRepo.CompleteStep(saga, step, func(tx *gorm.DB) error {
+		return definition.action(tx, saga)
+	})
+	if actionErr == nil {
+		step.Status = models.SagaStepCompleted
+		saga.Attempts = 0
+		if definition.committed != nil {
+			definition.committed(saga)
+		}
+		return true, nil
+	}
+	saga.Data = data
+
+	var abort *sagaAbort
+	if !errors.As(actionErr, &abort) && saga.Attempts+1 < s.maxAttempts {
+		return false, s.sagaRepo.RecordAttemptFailed(saga, step, actionErr, s.nextAttempt(saga))
+	}
+	if err := s.sagaRepo.StartCompensating(saga, step, actionErr, statusHook(saga, models.SagaCompensating)); err != nil {
+		return false, err
+	}
+	notifyOutbox()
+	step.Status = models.SagaStepFailed
+	saga.Status = models.SagaCompensating
+	saga.Attempts = 0
+	return true, nil
+}
+
+// compensateStep attempts to undo a completed step and reports whether the
+// saga can proceed with the previous one. After the maximum attempts the saga
+// is marked failed for an admin to look into.
+func (s *SagaService) compensateStep(saga *models.Saga, step *models.SagaStep, definition sagaStep) (bool, error) {
+	data := saga.Data
+	compensateErr := s.sagaRepo.CompensateStep(saga, step, func(tx *gorm.DB) error {
+		return definition.compensate(tx, saga)
+	})
+	if compensateErr == nil {
+		step.Status = models.SagaStepCompensated
+		saga.Attempts = 0
+		if definition.committed != nil {
+			definition.committed(saga)
+		}
+		return true, nil
+	}
+	saga.Data = data
+
+	if saga.Attempts+1 < s.maxAttempts {
+		return false, s.sagaRepo.RecordAttemptFailed(saga, step, compensateErr, s.nextAttempt(saga))
+	}
+	log.Printf("Warning: giving up compensating saga %d (%s %s) after %d attempts: %v", saga.ID, saga.Type, saga.Reference, saga.Attempts+1, compensateErr)
+	if err := s.sagaRepo.RecordAttemptFailed(saga, step, compensateErr, time.Now()); err != nil {
+		return false, err
+	}
+	return false, s.finish(saga, models.SagaFailed, fmt.Sprintf("compensating %s: %v", step.Name, compensateErr))
+}
+
+// finish records that a saga stopped with a status, and publishes the events
+// its status hook recorded
+func (s *SagaService) finish(saga *models.Saga, status models.SagaStatus, reason string) error {
+	if err := s.sagaRepo.Finish(saga, status, reason, statusHook(saga, status)); err != nil {
+		return err
+	}
+	notifyOutbox()
+	return nil
+}
+
+// nextAttempt returns when to retry the current step of a saga, with
+// exponential backoff
+func (s *SagaService) nextAttempt(saga *models.Saga) time.Time {
+	backoff := sagaMaxBackoff
+	if saga.Attempts < 12 { // Later attempts would wait longer than the cap anyway
+		backoff = min(time.Second<<saga.Attempts, sagaMaxBackoff)
+	}
+	return time.Now().Add(backoff)
+}
+
+// GetSaga retrieves a saga with its steps
+func (s *SagaService) GetSaga(id uint) (*models.Saga, error) {
+	return s.sagaRepo.GetByID(id)
+}
+
+// ListSagas retrieves a paginated list of sagas with their steps, newest first
+func (s *SagaService) ListSagas(status models.SagaStatus, sagaType models.SagaType, reference string, page, limit int) ([]models.Saga, int64, error) {
+	return s.sagaRepo.List(status, sagaType, reference, page, limit)
+}
+
+// RetrySaga resumes compensating a failed saga with a fresh set of attempts
+// and returns it. It returns gorm.ErrRecordNotFound when no failed saga has the ID.
+func (s *SagaService) RetrySaga(id uint) (*models.Saga, error) {
+	if err := s.sagaRepo.Retry(id); err != nil {
+		return nil, err
+	}
+	notifySagas()
+	return s.sagaRepo.GetByID(id)
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"product-management/pkg/events"
+	"product-management/pkg/notifier"
+)
+
+// ErrIPNotBlocked is returned when unblocking an IP that has no block
+var ErrIPNotBlocked = errors.New("ip is not blocked")
+
+// SecurityPolicy holds the thresholds suspicious activity is detected by
+type SecurityPolicy struct {
+	FailedLoginAccounts int // Distinct accounts failing to sign in from one IP within the window; 0 disables
+	FailedLoginWindow   time.Duration
+	BulkDeleteThreshold int // Products one user deletes within the window; 0 disables
+	BulkDeleteWindow    time.Duration
+	AutoBlock           bool // Block the IP behind failed logins and bulk deletions
+	BlockDuration       time.Duration
+}
+
+// SecurityService detects suspicious patterns in auth and product events,
+// records them as alerts, notifies the admins and optionally blocks the
+// source IP. Activity is counted in memory, so each instance only sees the
+// requests it served.
+type SecurityService struct {
+	policy              SecurityPolicy
+	securityRepo        *repositories.SecurityRepository
+	userRepo            *repositories.UserRepository
+	notificationService *NotificationService
+
+	mu           sync.Mutex
+	failedLogins map[string]map[string]time.Time // Last failure per email, by IP
+	deletions    map[uint][]time.Time            // Deletion times, by user
+	lastSweep    time.Time
+}
+
+// NewSecurityService creates a new SecurityService instance
+func NewSecurityService(policy SecurityPolicy, notificationService *NotificationService) *SecurityService {
+	return &SecurityService{
+		policy:              policy,
+		securityRepo:        repositories.NewSecurityRepository(database.DB),
+		userRepo:            repositories.NewUserRepository(database.DB),
+		notificationService: notificationService,
+		failedLogins:        make(map[string]map[string]time.Time),
+		deletions:           make(map[uint][]time.Time),
+	}
+}
+
+// Subscribe watches failed logins, product deletions and role changes on the bus
+func (s *SecurityService) Subscribe(bus *events.Bus) {
+	bus.Subscribe(events.TopicLoginFailed, func(event events.Event) {
+		s.loginFailed(event.(events.LoginFailed))
+	})
+	bus.Subscribe(events.TopicProductDeleted, func(event events.Event) {
+		s.productDeleted(event.(events.ProductDeleted))
+	})
+	bus.Subscribe(events.TopicRoleChanged, func(event events.Event) {
+		s.roleChanged(event.(events.RoleChanged))
+	})
+}
+
+// loginFailed raises an alert once too many accounts fail to sign in from one IP
+func (s *SecurityService) loginFailed(event events.LoginFailed) {
+	if s.policy.FailedLoginAccounts <= 0 || event.IP == "" {
+		return
+	}
+	now := time.Now()
+
+	s.mu.Lock()
+	s.sweep(now)
+	accounts := s.failedLogins[event.IP]
+	if accounts == nil {
+		accounts = make(map[string]time.Time)
+		s.failedLogins[event.IP] = accounts
+	}
+	accounts[event.Email] = now
+	for email, at := range accounts {
+		if now.Sub(at) > s.policy.FailedLoginWindow {
+			delete(accounts, email)
+		}
+	}
+	count := len(accounts)
+	if count >= s.policy.FailedLoginAccounts {
+		delete(s.failedLogins, event.IP)
+	}
+	s.mu.Unlock()
+
+	if count >= s.policy.FailedLoginAccounts {
+		s.raise(&models.SecurityAlert{
+			Kind: models.SecurityAlertFailedLogins,
+			IP:   event.IP,
+			Details: fmt.Sprintf("%d accounts failed to sign in from IP %s within %v",
+				count, event.IP, s.policy.FailedLoginWindow),
+		}, s.policy.AutoBlock)
+	}
+}
+
+// productDeleted raises an alert once a user deletes too many products
+func (s *SecurityService) productDeleted(event events.ProductDeleted) {
+	if s.policy.BulkDeleteThreshold <= 0 {
+		return
+	}
+	now := time.Now()
+
+	s.mu.Lock()
+	s.sweep(now)
+	recent := s.deletions[event.ActorID][:0]
+	for _, at := range s.deletions[event.ActorID] {
+		if now.Sub(at) <= s.policy.BulkDeleteWindow {
+			recent = append(recent, at)
+		}
+	}
+	recent = append(recent, now)
+	s.deletions[event.ActorID] = recent
+	count := len(recent)
+	if count >= s.policy.BulkDeleteThreshold {
+		delete(s.deletions, event.ActorID)
+	}
+	s.mu.Unlock()
+
+	if count >= s.policy.BulkDeleteThreshold {
+		actorID := event.ActorID
+		s.raise(&models.SecurityAlert{
+			Kind:    models.SecurityAlertBulkDeletion,
+			IP:      event.IP,
+			ActorID: &actorID,
+			Details: fmt.Sprintf("User %d deleted %d products within %v from IP %s",
+				event.ActorID, count, s.policy.BulkDeleteWindow, event.IP),
+		}, s.policy.AutoBlock && event.IP != "")
+	}
+}
+
+// roleChanged raises an alert whenever a user is promoted to admin. The IP is
+// never blocked since the change was made by an admin.
+func (s *SecurityService) roleChanged(event events.RoleChanged) {
+	if event.To != string(models.RoleAdmin) || event.From == event.To {
+		return
+	}
+	actorID := event.ActorID
+	s.raise(&models.SecurityAlert{
+		Kind:    models.SecurityAlertPrivilegeEscalation,
+		IP:      event.IP,
+		ActorID: &actorID,
+		Details: fmt.Sprintf("User %d was promoted from %s to admin by user %d from IP %s",
+			event.UserID, event.From, event.ActorID, event.IP),
+	}, false)
+}
+
+// sweep forgets the activity of sources that went quiet, at most once per
+// window, so counters of one-off IPs and users do not pile up. s.mu must be held.
+func (s *SecurityService) sweep(now time.Time) {
+	window := s.policy.FailedLoginWindow
+	if s.policy.BulkDeleteWindow > window {
+		window = s.policy.BulkDeleteWindow
+	}
+	if now.Sub(s.lastSweep) < window {
+		return
+	}
+	s.lastSweep = now
+
+	for ip, accounts := range s.failedLogins {
+		for email, at := range accounts {
+			if now.Sub(at) > s.policy.FailedLoginWindow {
+				delete(accounts, email)
+			}
+		}
+		if len(accounts) == 0 {
+			delete(s.failedLogins, ip)
+		}
+	}
+	for actorID, times := range s.deletions {
+		if len(times) == 0 || now.Sub(times[len(times)-1]) > s.policy.BulkDeleteWindow {
+			delete(s.deletions, actorID)
+		}
+	}
+}
+
+// raise records an alert, blocks its IP when asked to and notifies every admin
+func (s *SecurityService) raise(alert *models.SecurityAlert, block bool) {
+	log.Printf("Security alert %s: %s", alert.Kind, alert.Details)
+
+	if block {
+		if err := s.block(alert.IP, alert.Details); err != nil {
+			log.Printf("Warning: failed to block IP %s: %v", alert.IP, err)
+		} else {
+			alert.Blocked = true
+		}
+	}
+	if err := s.securityRepo.CreateAlert(alert); err != nil {
+		log.Printf("Warning: failed to record security alert: %v", err)
+	}
+
+	body := alert.Details
+	if alert.Blocked {
+		body += fmt.Sprintf(". The IP is blocked for %v.", s.policy.BlockDuration)
+	}
+	err := s.userRepo.EachUser("", models.RoleAdmin, 100, func(admin models.User) error {
+		s.notificationService.Notify(&admin, models.NotificationSecurityAlert, notifier.Notification{
+			Title: "Suspicious activity detected",
+			Body:  body,
+			Data:  map[string]string{"kind": string(models.NotificationSecurityAlert), "alert": string(alert.Kind)},
+		})
+		return nil
+	})
+	if err != nil {
+		log.Printf("Warning: failed to notify admins of security alert: %v", err)
+	}
+}
+
+// block blocks an IP for the policy's block duration
+func (s *SecurityService) block(ip, reason string) error {
+	if err := s.securityRepo.Block(ip, reason, time.Now().Add(s.policy.BlockDuration)); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.IPBlockKey(ip))
+	return nil
+}
+
+// ListAlerts retrieves a paginated list of security alerts, optionally of one kind
+func (s *SecurityService) ListAlerts(kind string, page, limit int) ([]models.SecurityAlert, int64, error) {
+	return s.securityRepo.ListAlerts(kind, page, limit)
+}
+
+// ListBlocks retrieves the IP blocks in force
+func (s *SecurityService) ListBlocks() ([]models.IPBlock, error) {
+	return s.securityRepo.ListBlocks()
+}
+
+// Unblock lifts the block of an IP
+func (s *SecurityService) Unblock(ip string) error {
+	found, err := s.securityRepo.Unblock(ip)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrIPNotBlocked
+	}
+	cache.Store.Delete(cache.IPBlockKey(ip))
+	return nil
+}
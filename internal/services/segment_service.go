@@ -0,0 +1,193 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/notifier"
+)
+
+var (
+	ErrInvalidSegmentRule = errors.New("invalid segment rule")
+	ErrSegmentNameTaken   = errors.New("segment name already exists")
+)
+
+// segmentNotifyBatchSize is the number of members loaded at a time when notifying a segment
+const segmentNotifyBatchSize = 500
+
+// SegmentService manages customer segments defined by rules over user
+// attributes and targets notifications at their members
+type SegmentService struct {
+	segmentRepo         *repositories.SegmentRepository
+	notificationService *NotificationService
+}
+
+// NewSegmentService creates a new SegmentService instance
+func NewSegmentService(notificationService *NotificationService) *SegmentService {
+	return &SegmentService{
+		segmentRepo:         repositories.NewSegmentRepository(database.DB),
+		notificationService: notificationService,
+	}
+}
+
+// CreateSegment validates the rules and creates a segment
+func (s *SegmentService) CreateSegment(req dto.SegmentRequest, createdBy uint) (*models.Segment, error) {
+	rules, err := parseSegmentRules(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkName(req.Name, 0); err != nil {
+		return nil, err
+	}
+
+	segment := &models.Segment{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Rules:       rules,
+		CreatedBy:   createdBy,
+	}
+	if err := s.segmentRepo.Create(segment); err != nil {
+		return nil, err
+	}
+	return segment, nil
+}
+
+// UpdateSegment replaces a segment's name, description and rules
+func (s *SegmentService) UpdateSegment(id uint, req dto.SegmentRequest) (*models.Segment, error) {
+	segment, err := s.segmentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := parseSegmentRules(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkName(req.Name, id); err != nil {
+		return nil, err
+	}
+
+	segment.Name = strings.TrimSpace(req.Name)
+	segment.Description = req.Description
+	segment.Rules = rules
+	if err := s.segmentRepo.Update(segment); err != nil {
+		return nil, err
+	}
+	return segment, nil
+}
+
+// GetSegment retrieves a segment by ID
+func (s *SegmentService) GetSegment(id uint) (*models.Segment, error) {
+	return s.segmentRepo.GetByID(id)
+}
+
+// ListSegments retrieves every segment
+func (s *SegmentService) ListSegments() ([]models.Segment, error) {
+	return s.segmentRepo.List()
+}
+
+// DeleteSegment deletes a segment
+func (s *SegmentService) DeleteSegment(id uint) error {
+	return s.segmentRepo.Delete(id)
+}
+
+// ListMembers retrieves a paginated list of the users currently in a segment
+func (s *SegmentService) ListMembers(id uint, page, limit int) ([]models.User, int64, error) {
+	segment, err := s.segmentRepo.GetByID(id)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.segmentRepo.ListMembers(segment.Rules, page, limit)
+}
+
+// NotifySegment sends a promotion to every member of a segment in the
+// background and returns the number of members. Members who opted out of
+// promotions are skipped.
+func (s *SegmentService) NotifySegment(id uint, title, message string) (int64, error) {
+	segment, err := s.segmentRepo.GetByID(id)
+	if err != nil {
+		return 0, err
+	}
+	_, total, err := s.segmentRepo.ListMembers(segment.Rules, 1, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	notification := notifier.Notification{
+		Title: title,
+		Body:  message,
+		Data:  map[string]string{"kind": string(models.NotificationPromotion), "segment_id": strconv.FormatUint(uint64(id), 10)},
+	}
+	go func() {
+		for page := 1; ; page++ {
+			members, _, err := s.segmentRepo.ListMembers(segment.Rules, page, segmentNotifyBatchSize)
+			if err != nil {
+				log.Printf("Warning: failed to load members of segment %d: %v", id, err)
+				return
+			}
+			for i := range members {
+				s.notificationService.Notify(&members[i], models.NotificationPromotion, notification)
+			}
+			if len(members) < segmentNotifyBatchSize {
+				return
+			}
+		}
+	}()
+	return total, nil
+}
+
+// checkName rejects a name already used by another segment
+func (s *SegmentService) checkName(name string, excludeID uint) error {
+	exists, err := s.segmentRepo.NameExists(strings.TrimSpace(name), excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrSegmentNameTaken
+	}
+	return nil
+}
+
+// parseSegmentRules validates the rules of a segment request
+func parseSegmentRules(rules []dto.SegmentRule) ([]models.SegmentRule, error) {
+	parsed := make([]models.SegmentRule, len(rules))
+	for i, rule := range rules {
+		field := models.SegmentField(rule.Field)
+		operator := models.SegmentOperator(rule.Operator)
+		value := strings.TrimSpace(rule.Value)
+
+		switch field {
+		case models.SegmentFieldRole:
+			if operator != models.SegmentOperatorEq && operator != models.SegmentOperatorNe {
+				return nil, fmt.Errorf("%w: role only supports eq and ne", ErrInvalidSegmentRule)
+			}
+			if models.Role(value) != models.RoleUser && models.Role(value) != models.RoleAdmin {
+				return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidSegmentRule, value)
+			}
+		case models.SegmentFieldWishlistSize, models.SegmentFieldReviewCount,
+			models.SegmentFieldDaysSinceSignup, models.SegmentFieldDaysSinceLastLogin:
+			switch operator {
+			case models.SegmentOperatorEq, models.SegmentOperatorNe, models.SegmentOperatorGt,
+				models.SegmentOperatorGte, models.SegmentOperatorLt, models.SegmentOperatorLte:
+			default:
+				return nil, fmt.Errorf("%w: unknown operator %q", ErrInvalidSegmentRule, rule.Operator)
+			}
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, fmt.Errorf("%w: %s needs a number, got %q", ErrInvalidSegmentRule, field, value)
+			}
+		case "total_spend", "last_order_date":
+			return nil, fmt.Errorf("%w: %s needs order history, which is not tracked yet", ErrInvalidSegmentRule, field)
+		default:
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidSegmentRule, rule.Field)
+		}
+
+		parsed[i] = models.SegmentRule{Field: field, Operator: operator, Value: value}
+	}
+	return parsed, nil
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"product-management/pkg/lock"
+	"product-management/pkg/mailer"
+	"product-management/pkg/storage"
+)
+
+// selfTestTimeout bounds each check, so an unreachable backend fails the
+// self-test rather than hanging it
+const selfTestTimeout = 10 * time.Second
+
+// selfTestCheck checks one backend. hint tells operators what to look at when
+// it fails.
+type selfTestCheck struct {
+	name  string
+	hint  string
+	check func(ctx context.Context, probe string) error
+}
+
+// SelfTestService checks that every backend the server depends on works, for
+// startup and post-deploy verification
+type SelfTestService struct {
+	checks []selfTestCheck
+}
+
+// NewSelfTestService creates a new SelfTestService instance. The storage
+// backend and mail driver pick the hints of their checks.
+func NewSelfTestService(storageBackend, mailDriver string) *SelfTestService {
+	storageHint := "check that STORAGE_BUCKET exists and that STORAGE_REGION, STORAGE_ENDPOINT, STORAGE_ACCESS_KEY and STORAGE_SECRET_KEY allow writing, reading and deleting in it"
+	if storageBackend == "local" {
+		storageHint = "check that STORAGE_LOCAL_DIR exists and that the server can write to it"
+	}
+	mailHint := "check SMTP_HOST and SMTP_PORT, that the server is reachable from this host, and SMTP_USERNAME and SMTP_PASSWORD"
+	if mailDriver == "log" {
+		mailHint = "check MAIL_DRIVER"
+	}
+
+	return &SelfTestService{checks: []selfTestCheck{
+		{"database", "check DB_HOST, DB_PORT, DB_USER, DB_PASSWORD and DB_NAME, and that Postgres accepts connections from this host", checkDatabase},
+		{"cache", "check CACHE_BACKEND, and CACHE_REDIS_ADDR, CACHE_REDIS_PASSWORD and CACHE_REDIS_DB for the redis backend; the memory backend only fails on a bug or when the server runs out of memory", checkCache},
+		{"storage", storageHint, checkStorage},
+		{"mail", mailHint, func(ctx context.Context, probe string) error { return mailer.Default.Check() }},
+		{"search", "product search queries the products table; run cmd/migrate if it is missing or out of date", checkSearch},
+		{"locks", "check LOCK_BACKEND, and LOCK_REDIS_ADDR and LOCK_REDIS_PASSWORD for the redis backend", checkLocks},
+	}}
+}
+
+// Run runs every check and reports whether they all passed. Checks write
+// their probes under a random name and clean them up.
+func (s *SelfTestService) Run(ctx context.Context) dto.SelfTestResponse {
+	response := dto.SelfTestResponse{OK: true, Checks: make([]dto.SelfTestCheck, len(s.checks))}
+	for i, check := range s.checks {
+		probe, err := newProbe()
+		start := time.Now()
+		if err == nil {
+			checkCtx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+			err = check.check(checkCtx, probe)
+			cancel()
+		}
+		response.Checks[i] = dto.SelfTestCheck{Name: check.name, OK: err == nil, DurationMs: time.Since(start).Milliseconds()}
+		if err != nil {
+			response.OK = false
+			response.Checks[i].Error = err.Error()
+			response.Checks[i].Hint = check.hint
+		}
+	}
+	return response
+}
+
+// newProbe returns a random name for the values the checks write
+func newProbe() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "selftest-" + hex.EncodeToString(buf), nil
+}
+
+func checkDatabase(ctx context.Context, probe string) error {
+	if database.DB == nil {
+		return fmt.Errorf("database is not connected")
+	}
+	var one int
+	if err := database.DB.WithContext(ctx).Raw("SELECT 1").Scan(&one).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func checkCache(ctx context.Context, probe string) error {
+	key := "selftest:" + probe
+	defer cache.Store.Delete(key)
+	cache.Store.Set(key, probe, time.Minute)
+	var value string
+	if !cache.Store.Get(key, &value) || value != probe {
+		return fmt.Errorf("a value written to the cache could not be read back")
+	}
+	return nil
+}
+
+func checkStorage(ctx context.Context, probe string) error {
+	if storage.Store == nil {
+		return fmt.Errorf("storage is not configured")
+	}
+	key := "selftest/" + probe + ".txt"
+	if err := storage.Store.Put(ctx, key, strings.NewReader(probe), int64(len(probe)), "text/plain"); err != nil {
+		return fmt.Errorf("write: %v", err)
+	}
+	reader, err := storage.Store.Get(ctx, key)
+	if err != nil {
+		storage.Store.Delete(ctx, key)
+		return fmt.Errorf("read: %v", err)
+	}
+	content, err := io.ReadAll(reader)
+	reader.Close()
+	if err == nil && !bytes.Equal(content, []byte(probe)) {
+		err = fmt.Errorf("the object read back differs from the one written")
+	}
+	if err != nil {
+		storage.Store.Delete(ctx, key)
+		return fmt.Errorf("read: %v", err)
+	}
+	if err := storage.Store.Delete(ctx, key); err != nil {
+		return fmt.Errorf("delete: %v", err)
+	}
+	return nil
+}
+
+func checkSearch(ctx context.Context, probe string) error {
+	if database.DB == nil {
+		return fmt.Errorf("database is not connected")
+	}
+	_, _, err := repositories.NewProductRepository(database.DB.WithContext(ctx)).List(1, 1, 0, probe, "", nil, nil, nil)
+	return err
+}
+
+func checkLocks(ctx context.Context, probe string) error {
+	held, err := lock.Default.TryLock(ctx, probe)
+	if err != nil {
+		return err
+	}
+	return held.Release()
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cache"
+	"product-management/pkg/database"
+	"product-management/pkg/reqctx"
+)
+
+var (
+	ErrUnknownSetting = errors.New("unknown setting")
+	ErrInvalidSetting = errors.New("invalid setting")
+)
+
+// Types of setting values
+const (
+	SettingText     = "text"     // Up to 100 characters, not empty
+	SettingURL      = "url"      // An http or https URL, or empty
+	SettingEmail    = "email"    // An email address, or empty
+	SettingCurrency = "currency" // A supported ISO 4217 currency code
+	SettingPrefix   = "prefix"   // Up to 10 uppercase letters, digits and dashes
+	SettingAmount   = "amount"   // A non-negative amount with up to two decimals
+)
+
+// settingPrefixPattern matches a valid order number prefix
+var settingPrefixPattern = regexp.MustCompile(`^[A-Z0-9-]{0,10}$`)
+
+// settingDefinition is the type of a store setting and its value until an
+// admin sets one
+type settingDefinition struct {
+	key          models.SettingKey
+	valueType    string
+	defaultValue func() string
+}
+
+// settingDefinitions are the store settings, in the order they are listed
+var settingDefinitions = []settingDefinition{
+	{key: models.SettingStoreName, valueType: SettingText, defaultValue: func() string { return "Product Management" }},
+	{key: models.SettingLogoURL, valueType: SettingURL, defaultValue: func() string { return "" }},
+	{key: models.SettingSupportEmail, valueType: SettingEmail, defaultValue: func() string { return "" }},
+	{key: models.SettingCurrency, valueType: SettingCurrency, defaultValue: func() string { return reqctx.Default.Defaults().Currency }},
+	{key: models.SettingOrderNumberPrefix, valueType: SettingPrefix, defaultValue: func() string { return "ORD-" }},
+	{key: models.SettingGiftWrapFee, valueType: SettingAmount, defaultValue: func() string { return "0" }},
+}
+
+// StoreSetting is a store setting with its value in effect
+type StoreSetting struct {
+	Key     models.SettingKey
+	Type    string
+	Value   string
+	Default string
+	Set     *models.Setting // What an admin set, nil while the default applies
+}
+
+// SettingService manages the store-level settings, such as the store name and
+// currency, that admins change through the API
+type SettingService struct {
+	settingRepo *repositories.SettingRepository
+}
+
+// NewSettingService creates a new SettingService instance
+func NewSettingService() *SettingService {
+	return &SettingService{
+		settingRepo: repositories.NewSettingRepository(database.DB),
+	}
+}
+
+// ListSettings retrieves every store setting with its value in effect
+func (s *SettingService) ListSettings() ([]StoreSetting, error) {
+	stored, err := s.cachedSettings()
+	if err != nil {
+		return nil, err
+	}
+	settings := make([]StoreSetting, len(settingDefinitions))
+	for i, definition := range settingDefinitions {
+		settings[i] = storeSetting(definition, stored)
+	}
+	return settings, nil
+}
+
+// GetSetting retrieves a store setting with its value in effect
+func (s *SettingService) GetSetting(key models.SettingKey) (*StoreSetting, error) {
+	definition, err := findSetting(key)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.cachedSettings()
+	if err != nil {
+		return nil, err
+	}
+	setting := storeSetting(definition, stored)
+	return &setting, nil
+}
+
+// Values returns the value in effect of every store setting
+func (s *SettingService) Values() (map[models.SettingKey]string, error) {
+	settings, err := s.ListSettings()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[models.SettingKey]string, len(settings))
+	for _, setting := range settings {
+		values[setting.Key] = setting.Value
+	}
+	return values, nil
+}
+
+// Value returns the value in effect of a store setting, or its default when
+// the settings can't be read
+func (s *SettingService) Value(key models.SettingKey) string {
+	setting, err := s.GetSetting(key)
+	if err != nil {
+		if definition, err := findSetting(key); err == nil {
+			return definition.defaultValue()
+		}
+		return ""
+	}
+	return setting.Value
+}
+
+// Amount returns the value in effect of an amount setting, or zero when it
+// can't be read
+func (s *SettingService) Amount(key models.SettingKey) float64 {
+	amount, err := strconv.ParseFloat(s.Value(key), 64)
+	if err != nil {
+		return 0
+	}
+	return amount
+}
+
+// UpdateSetting validates and stores the value of a store setting
+func (s *SettingService) UpdateSetting(key models.SettingKey, value string, editorID uint) (*StoreSetting, error) {
+	definition, err := findSetting(key)
+	if err != nil {
+		return nil, err
+	}
+	value, err = validateSetting(definition, value)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.settingRepo.Upsert(&models.Setting{Key: key, Value: value, UpdatedBy: editorID}); err != nil {
+		return nil, err
+	}
+	cache.Store.Delete(cache.SettingsKey)
+	return s.GetSetting(key)
+}
+
+// ResetSetting removes the value set for a store setting, so its default
+// applies again. It returns gorm.ErrRecordNotFound when none was set.
+func (s *SettingService) ResetSetting(key models.SettingKey) error {
+	if _, err := findSetting(key); err != nil {
+		return err
+	}
+	if err := s.settingRepo.Delete(key); err != nil {
+		return err
+	}
+	cache.Store.Delete(cache.SettingsKey)
+	return nil
+}
+
+// cachedSettings reads the values admins set through the cache
+func (s *SettingService) cachedSettings() ([]models.Setting, error) {
+	var cached []models.Setting
+	if cache.Store.Get(cache.SettingsKey, &cached) {
+		return cached, nil
+	}
+
+	value, err, _ := readGroup.Do(cache.SettingsKey, func() (interface{}, error) {
+		settings, err := s.settingRepo.List()
+		if err != nil {
+			return nil, err
+		}
+		cache.Store.Set(cache.SettingsKey, settings, cache.TTL)
+		return settings, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]models.Setting), nil
+}
+
+// storeSetting combines a setting's definition with the value an admin set
+func storeSetting(definition settingDefinition, stored []models.Setting) StoreSetting {
+	setting := StoreSetting{
+		Key:     definition.key,
+		Type:    definition.valueType,
+		Default: definition.defaultValue(),
+	}
+	setting.Value = setting.Default
+	if i := slices.IndexFunc(stored, func(s models.Setting) bool { return s.Key == definition.key }); i >= 0 {
+		setting.Value = stored[i].Value
+		setting.Set = &stored[i]
+	}
+	return setting
+}
+
+// findSetting returns the definition of a store setting
+func findSetting(key models.SettingKey) (settingDefinition, error) {
+	i := slices.IndexFunc(settingDefinitions, func(d settingDefinition) bool { return d.key == key })
+	if i < 0 {
+		return settingDefinition{}, ErrUnknownSetting
+	}
+	return settingDefinitions[i], nil
+}
+
+// validateSetting checks a value against the type of a setting and returns it
+// normalized
+func validateSetting(definition settingDefinition, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s %s", ErrInvalidSetting, definition.key, reason)
+	}
+	switch definition.valueType {
+	case SettingText:
+		if value == "" || utf8.RuneCountInString(value) > 100 {
+			return "", invalid("must be between 1 and 100 characters")
+		}
+	case SettingURL:
+		if value != "" {
+			u, err := url.ParseRequestURI(value)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return "", invalid("must be an http or https URL")
+			}
+		}
+	case SettingEmail:
+		if value != "" {
+			address, err := mail.ParseAddress(value)
+			if err != nil || address.Address != value {
+				return "", invalid("must be an email address")
+			}
+		}
+	case SettingCurrency:
+		code, ok := reqctx.ParseCurrency(value)
+		if !ok || !slices.Contains(reqctx.Default.Currencies(), code) {
+			return "", invalid(fmt.Sprintf("must be one of %s", strings.Join(reqctx.Default.Currencies(), ", ")))
+		}
+		value = code
+	case SettingPrefix:
+		value = strings.ToUpper(value)
+		if !settingPrefixPattern.MatchString(value) {
+			return "", invalid("may only contain up to 10 letters, digits and dashes")
+		}
+	case SettingAmount:
+		amount, err := strconv.ParseFloat(value, 64)
+		if err != nil || amount < 0 || math.IsInf(amount, 0) || math.Round(amount*100) != amount*100 {
+			return "", invalid("must be a non-negative amount with up to two decimals")
+		}
+		value = strconv.FormatFloat(amount, 'f', -1, 64)
+	}
+	return value, nil
+}
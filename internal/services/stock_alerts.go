@@ -0,0 +1,28 @@
+package services
+
+import (
+	"log"
+
+	"product-management/pkg/events"
+	"product-management/pkg/mailer"
+)
+
+// SubscribeLowStockAlerts emails the recipient when a product's stock drops to
+// the threshold or below. Products already below it do not alert again.
+func SubscribeLowStockAlerts(bus *events.Bus, threshold int, recipient string) {
+	bus.Subscribe(events.TopicStockChanged, func(event events.Event) {
+		changed := event.(events.StockChanged)
+		if changed.Previous <= threshold || changed.Quantity > threshold {
+			return
+		}
+		err := mailer.Default.Enqueue(mailer.DefaultTenant, mailer.TemplateLowStock, recipient, map[string]interface{}{
+			"ProductID":   changed.ProductID,
+			"ProductName": changed.ProductName,
+			"Quantity":    changed.Quantity,
+			"Threshold":   threshold,
+		})
+		if err != nil {
+			log.Printf("Warning: failed to queue low stock alert for product %d: %v", changed.ProductID, err)
+		}
+	})
+}
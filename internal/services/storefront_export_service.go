@@ -0,0 +1,308 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/cdn"
+	"product-management/pkg/database"
+	"product-management/pkg/events"
+	"product-management/pkg/storage"
+)
+
+// StorefrontPrefix is the storage key prefix of the storefront bundle
+const StorefrontPrefix = "storefront/"
+
+// storefrontListingsDelay batches the listing rebuilds of changes made close
+// together, such as an import touching many products
+const storefrontListingsDelay = 5 * time.Second
+
+// imageExtensions are the stored objects under products/{id}/ that are
+// exported as product images
+var imageExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".webp": true, ".gif": true, ".avif": true, ".svg": true}
+
+// storefrontMu serializes writes to the bundle, across the service instances
+// of the admin API and of the event subscriber
+var storefrontMu sync.Mutex
+
+// StorefrontExportService writes the active catalog to storage as static JSON
+// files a statically generated storefront can build from:
+//
+//	storefront/manifest.json        when the bundle was generated and what it holds
+//	storefront/products.json        every active product with its images
+//	storefront/categories.json      every category with the IDs of its active products
+//	storefront/products/{id}.json   one active product with its images
+type StorefrontExportService struct {
+	productRepo  *repositories.ProductRepository
+	categoryRepo *repositories.CategoryRepository
+
+	listingsMu      sync.Mutex
+	listingsPending bool
+}
+
+// NewStorefrontExportService creates a new StorefrontExportService instance
+func NewStorefrontExportService() *StorefrontExportService {
+	return &StorefrontExportService{
+		productRepo:  repositories.NewProductRepository(database.DB),
+		categoryRepo: repositories.NewCategoryRepository(database.DB),
+	}
+}
+
+// Export regenerates the whole bundle and deletes the files of products that
+// are no longer active
+func (s *StorefrontExportService) Export(ctx context.Context) (*dto.StorefrontExportResponse, error) {
+	storefrontMu.Lock()
+	defer storefrontMu.Unlock()
+
+	existing, err := storage.Store.List(ctx, StorefrontPrefix+"products/")
+	if err != nil {
+		return nil, err
+	}
+	stale := make(map[string]bool, len(existing))
+	for _, object := range existing {
+		stale[object.Key] = true
+	}
+
+	manifest, err := s.writeListings(ctx, func(product *dto.StorefrontProduct) error {
+		delete(stale, storefrontProductKey(product.ID))
+		return putJSON(ctx, storefrontProductKey(product.ID), product)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	removed := 0
+	for key := range stale {
+		if err := storage.Store.Delete(ctx, key); err != nil {
+			return nil, err
+		}
+		removed++
+	}
+
+	return &dto.StorefrontExportResponse{
+		Prefix:      StorefrontPrefix,
+		GeneratedAt: manifest.GeneratedAt,
+		Products:    manifest.Products,
+		Categories:  manifest.Categories,
+		Removed:     removed,
+	}, nil
+}
+
+// ExportProduct rewrites the file of one product, or deletes it when the
+// product is no longer active. The listings are left to RebuildListings.
+func (s *StorefrontExportService) ExportProduct(ctx context.Context, id uint) error {
+	storefrontMu.Lock()
+	defer storefrontMu.Unlock()
+
+	product, err := s.productRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if product == nil || product.Status != models.StatusActive {
+		return storage.Store.Delete(ctx, storefrontProductKey(id))
+	}
+
+	images, err := listProductImages(ctx, fmt.Sprintf("products/%d/", id))
+	if err != nil {
+		return err
+	}
+	return putJSON(ctx, storefrontProductKey(id), storefrontProduct(product, images[id]))
+}
+
+// RebuildListings rewrites products.json, categories.json and the manifest
+func (s *StorefrontExportService) RebuildListings(ctx context.Context) error {
+	storefrontMu.Lock()
+	defer storefrontMu.Unlock()
+
+	_, err := s.writeListings(ctx, nil)
+	return err
+}
+
+// Subscribe keeps the bundle up to date: a changed product has its file
+// rewritten at once, a renamed category the files of its products, and the
+// listings are rebuilt shortly after.
+func (s *StorefrontExportService) Subscribe(bus *events.Bus) {
+	bus.Subscribe(events.TopicProductChanged, func(event events.Event) {
+		changed := event.(events.ProductChanged)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := s.ExportProduct(ctx, changed.ProductID); err != nil {
+			log.Printf("Warning: failed to export product %d to the storefront bundle: %v", changed.ProductID, err)
+		}
+		s.scheduleListings()
+	})
+	bus.Subscribe(events.TopicCategoryChanged, func(event events.Event) {
+		changed := event.(events.CategoryChanged)
+		if !changed.Deleted {
+			products, err := s.categoryRepo.GetProductsByCategoryID(changed.CategoryID)
+			if err != nil {
+				log.Printf("Warning: failed to load the products of category %d for the storefront bundle: %v", changed.CategoryID, err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			for _, product := range products {
+				if err := s.ExportProduct(ctx, product.ID); err != nil {
+					log.Printf("Warning: failed to export product %d to the storefront bundle: %v", product.ID, err)
+				}
+			}
+			cancel()
+		}
+		s.scheduleListings()
+	})
+}
+
+// scheduleListings rebuilds the listings after storefrontListingsDelay, once
+// for all the changes made in the meantime
+func (s *StorefrontExportService) scheduleListings() {
+	s.listingsMu.Lock()
+	defer s.listingsMu.Unlock()
+	if s.listingsPending {
+		return
+	}
+	s.listingsPending = true
+
+	time.AfterFunc(storefrontListingsDelay, func() {
+		s.listingsMu.Lock()
+		s.listingsPending = false
+		s.listingsMu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		if err := s.RebuildListings(ctx); err != nil {
+			log.Printf("Warning: failed to rebuild the storefront bundle listings: %v", err)
+		}
+	})
+}
+
+// writeListings walks the active products, calling each for every one when it
+// is not nil, then writes the listings and the manifest. The caller holds
+// storefrontMu.
+func (s *StorefrontExportService) writeListings(ctx context.Context, each func(product *dto.StorefrontProduct) error) (*dto.StorefrontManifest, error) {
+	images, err := listProductImages(ctx, "products/")
+	if err != nil {
+		return nil, err
+	}
+
+	products := []dto.StorefrontProduct{}
+	categoryProducts := make(map[uint][]uint)
+	err = s.productRepo.EachProduct(100, func(product models.Product) error {
+		if product.Status != models.StatusActive {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		exported := storefrontProduct(&product, images[product.ID])
+		if each != nil {
+			if err := each(&exported); err != nil {
+				return err
+			}
+		}
+		products = append(products, exported)
+		for _, category := range product.Categories {
+			categoryProducts[category.ID] = append(categoryProducts[category.ID], product.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.categoryRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].ID < categories[j].ID })
+	exportedCategories := make([]dto.StorefrontCategory, len(categories))
+	for i, category := range categories {
+		exportedCategories[i] = dto.StorefrontCategory{
+			ID:          category.ID,
+			Name:        category.Name,
+			Description: category.Description,
+			ProductIDs:  categoryProducts[category.ID],
+		}
+		if exportedCategories[i].ProductIDs == nil {
+			exportedCategories[i].ProductIDs = []uint{}
+		}
+	}
+
+	manifest := &dto.StorefrontManifest{
+		GeneratedAt: dto.NewTime(time.Now()),
+		Products:    len(products),
+		Categories:  len(exportedCategories),
+	}
+	if err := putJSON(ctx, StorefrontPrefix+"products.json", products); err != nil {
+		return nil, err
+	}
+	if err := putJSON(ctx, StorefrontPrefix+"categories.json", exportedCategories); err != nil {
+		return nil, err
+	}
+	// The manifest goes last so that it never announces listings not yet written
+	if err := putJSON(ctx, StorefrontPrefix+"manifest.json", manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// listProductImages returns the images stored under prefix, by product ID and
+// ordered by key. Image keys have the form products/{id}/{name}.
+func listProductImages(ctx context.Context, prefix string) (map[uint][]dto.StorefrontImage, error) {
+	objects, err := storage.Store.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+
+	images := make(map[uint][]dto.StorefrontImage)
+	for _, object := range objects {
+		parts := strings.SplitN(object.Key, "/", 3)
+		if len(parts) != 3 || !imageExtensions[strings.ToLower(path.Ext(object.Key))] {
+			continue
+		}
+		id, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		images[uint(id)] = append(images[uint(id)], dto.StorefrontImage{
+			Key:       object.Key,
+			URL:       cdn.AssetURL(object.Key),
+			Size:      object.Size,
+			UpdatedAt: dto.NewTime(object.LastModified),
+		})
+	}
+	return images, nil
+}
+
+// storefrontProduct converts a product and its images to its bundle form
+func storefrontProduct(product *models.Product, images []dto.StorefrontImage) dto.StorefrontProduct {
+	if images == nil {
+		images = []dto.StorefrontImage{}
+	}
+	return dto.StorefrontProduct{ProductResponse: mappers.ToProductResponse(product), Images: images}
+}
+
+// storefrontProductKey returns the storage key of a product file
+func storefrontProductKey(id uint) string {
+	return fmt.Sprintf("%sproducts/%d.json", StorefrontPrefix, id)
+}
+
+// putJSON stores a value as a JSON file
+func putJSON(ctx context.Context, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return storage.Store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "application/json")
+}
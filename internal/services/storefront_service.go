@@ -0,0 +1,171 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+
+	"product-management/internal/dto"
+	"product-management/internal/mappers"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+)
+
+// StorefrontService assembles what a storefront needs on its first page in one
+// response, so a client renders it without a waterfall of requests
+type StorefrontService struct {
+	productService   *ProductService
+	categoryService  *CategoryService
+	priceListService *PriceListService
+	featuredService  *FeaturedProductService
+	settingService   *SettingService
+	productRepo      *repositories.ProductRepository
+	userRepo         *repositories.UserRepository
+	featuredCount    int
+}
+
+// NewStorefrontService creates a new StorefrontService instance. featuredCount
+// is how many products the bootstrap features.
+func NewStorefrontService(priceListService *PriceListService, featuredService *FeaturedProductService, featuredCount int) *StorefrontService {
+	return &StorefrontService{
+		productService:   NewProductService(),
+		categoryService:  NewCategoryService(),
+		priceListService: priceListService,
+		featuredService:  featuredService,
+		settingService:   NewSettingService(),
+		productRepo:      repositories.NewProductRepository(database.DB),
+		userRepo:         repositories.NewUserRepository(database.DB),
+		featuredCount:    featuredCount,
+	}
+}
+
+// Bootstrap loads the sections of the storefront bootstrap concurrently. A
+// section that fails or panics is left null and reported in Errors, without
+// failing the others. country limits the featured products to a market, nil
+// for all.
+func (s *StorefrontService) Bootstrap(userID uint, country *string) dto.StorefrontBootstrapResponse {
+	var response dto.StorefrontBootstrapResponse
+	sections := map[string]func() error{
+		"categories": func() error {
+			categories, err := s.categoryService.GetAllCategories()
+			if err != nil {
+				return err
+			}
+			response.Categories = append([]dto.CategoryResponse{}, categories...)
+			return nil
+		},
+		"featured_products": func() error {
+			featured, err := s.featuredProducts(userID, country)
+			if err != nil {
+				return err
+			}
+			response.FeaturedProducts = featured
+			return nil
+		},
+		"settings": func() error {
+			values, err := s.settingService.Values()
+			if err != nil {
+				return err
+			}
+			giftWrapFee, _ := strconv.ParseFloat(values[models.SettingGiftWrapFee], 64)
+			response.Settings = &dto.StoreSettings{
+				StoreName:         values[models.SettingStoreName],
+				LogoURL:           values[models.SettingLogoURL],
+				SupportEmail:      values[models.SettingSupportEmail],
+				Currency:          values[models.SettingCurrency],
+				OrderNumberPrefix: values[models.SettingOrderNumberPrefix],
+				GiftWrapFee:       giftWrapFee,
+			}
+			return nil
+		},
+		"user": func() error {
+			user, err := s.userRepo.GetByID(userID)
+			if err != nil {
+				return err
+			}
+			response.User = &dto.StorefrontUserSummary{
+				ID:       user.ID,
+				Username: user.Username,
+				FullName: user.FullName,
+				Role:     string(user.Role),
+			}
+			return nil
+		},
+		"cart_count": func() error {
+			// There are no carts yet, so they are always empty
+			var count int64
+			response.CartCount = &count
+			return nil
+		},
+		"wishlist_count": func() error {
+			count, err := s.productRepo.CountUserWishlistItems(userID)
+			if err != nil {
+				return err
+			}
+			response.WishlistCount = &count
+			return nil
+		},
+	}
+
+	// Each section writes only its own field, so only the errors need a lock
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, load := range sections {
+		wg.Add(1)
+		go func(name string, load func() error) {
+			defer wg.Done()
+			err := func() (err error) {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Errorf("panic: %v", r)
+					}
+				}()
+				return load()
+			}()
+			if err == nil {
+				return
+			}
+			log.Printf("Warning: failed to load storefront bootstrap section %s: %v", name, err)
+			mu.Lock()
+			if response.Errors == nil {
+				response.Errors = make(map[string]string)
+			}
+			response.Errors[name] = err.Error()
+			mu.Unlock()
+		}(name, load)
+	}
+	wg.Wait()
+
+	return response
+}
+
+// featuredProducts returns the live curated featured products sold in the
+// country, priced for the user. While none are live it falls back to the
+// highest-rated active products.
+func (s *StorefrontService) featuredProducts(userID uint, country *string) ([]dto.ProductResponse, error) {
+	if s.featuredCount <= 0 {
+		return []dto.ProductResponse{}, nil
+	}
+	products, err := s.featuredService.LiveProducts(country)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) > s.featuredCount {
+		products = products[:s.featuredCount]
+	}
+	if len(products) == 0 {
+		products, _, err = s.productService.ListProducts(1, s.featuredCount, 0, "", "rating", []string{string(models.StatusActive)}, country, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	pricing, err := s.priceListService.CustomerPricing(userID)
+	if err != nil {
+		return nil, err
+	}
+	responses := mappers.ToProductResponses(products)
+	pricing.Apply(responses)
+	return responses, nil
+}
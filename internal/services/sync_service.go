@@ -0,0 +1,148 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+)
+
+const (
+	// syncBatchSize is the maximum number of change log entries read per request
+	syncBatchSize = 500
+	// syncSettleDelay holds back the newest changes, so a transaction that took
+	// its change ID earlier but committed later is not skipped by a cursor past it
+	syncSettleDelay = 5 * time.Second
+)
+
+// ErrSyncCursorExpired is returned when the changes after a cursor were
+// removed by retention, so the client has to refresh fully
+var ErrSyncCursorExpired = errors.New("sync cursor expired, refresh fully and sync from the returned cursor")
+
+// SyncBatch is the next batch of changes after a cursor. Each record appears
+// once, with its latest change in the batch, loaded as it is now: a record
+// missing from the maps was deleted since.
+type SyncBatch struct {
+	Changes    []models.SyncChange
+	Products   map[uint]*models.Product
+	Categories map[uint]*models.Category
+	Wishlist   map[uint]*models.Wishlist // The user's wishlist items, by product ID
+	Cursor     uint                      // Where the next sync resumes
+	HasMore    bool                      // Whether more changes follow the cursor right away
+}
+
+// SyncService serves the change log offline-capable clients sync the catalog
+// and their own wishlist from, instead of refreshing them fully
+type SyncService struct {
+	syncRepo     *repositories.SyncRepository
+	productRepo  *repositories.ProductRepository
+	categoryRepo *repositories.CategoryRepository
+}
+
+// NewSyncService creates a new SyncService instance
+func NewSyncService() *SyncService {
+	return &SyncService{
+		syncRepo:     repositories.NewSyncRepository(database.DB),
+		productRepo:  repositories.NewProductRepository(database.DB),
+		categoryRepo: repositories.NewCategoryRepository(database.DB),
+	}
+}
+
+// Changes returns the batch of changes a user sees after a cursor. A zero
+// cursor returns no changes, only the cursor a client syncs from after a full
+// refresh. It returns ErrSyncCursorExpired when changes after the cursor were
+// removed by retention.
+func (s *SyncService) Changes(userID, cursor uint) (*SyncBatch, error) {
+	before := time.Now().Add(-syncSettleDelay)
+	batch := &SyncBatch{
+		Products:   map[uint]*models.Product{},
+		Categories: map[uint]*models.Category{},
+		Wishlist:   map[uint]*models.Wishlist{},
+	}
+	if cursor == 0 {
+		latest, err := s.syncRepo.LatestID(before)
+		if err != nil {
+			return nil, err
+		}
+		batch.Cursor = latest
+		return batch, nil
+	}
+
+	oldest, err := s.syncRepo.OldestID()
+	if err != nil {
+		return nil, err
+	}
+	if oldest > cursor+1 {
+		return nil, ErrSyncCursorExpired
+	}
+
+	changes, err := s.syncRepo.ListAfter(cursor, userID, before, syncBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	batch.Cursor = cursor
+	if len(changes) > 0 {
+		batch.Cursor = changes[len(changes)-1].ID
+	}
+	batch.HasMore = len(changes) == syncBatchSize
+	batch.Changes = latestSyncChanges(changes)
+
+	var productIDs, categoryIDs, wishlistIDs []uint
+	for _, change := range batch.Changes {
+		if change.Operation == models.SyncDelete {
+			continue
+		}
+		switch change.EntityType {
+		case models.SyncEntityProduct:
+			productIDs = append(productIDs, change.EntityID)
+		case models.SyncEntityCategory:
+			categoryIDs = append(categoryIDs, change.EntityID)
+		case models.SyncEntityWishlist:
+			wishlistIDs = append(wishlistIDs, change.EntityID)
+		}
+	}
+
+	products, err := s.productRepo.GetByIDs(productIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range products {
+		batch.Products[products[i].ID] = &products[i]
+	}
+	categories, _, err := s.categoryRepo.GetByIDs(categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range categories {
+		batch.Categories[categories[i].ID] = &categories[i]
+	}
+	items, err := s.productRepo.GetWishlistItems(userID, wishlistIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		batch.Wishlist[items[i].ProductID] = &items[i]
+	}
+	return batch, nil
+}
+
+// latestSyncChanges keeps the latest change of each record, in log order
+func latestSyncChanges(changes []models.SyncChange) []models.SyncChange {
+	type key struct {
+		entityType models.SyncEntity
+		entityID   uint
+	}
+	latest := make(map[key]int, len(changes))
+	for i, change := range changes {
+		latest[key{change.EntityType, change.EntityID}] = i
+	}
+	kept := make([]models.SyncChange, 0, len(latest))
+	for i, change := range changes {
+		if latest[key{change.EntityType, change.EntityID}] == i {
+			kept = append(kept, change)
+		}
+	}
+	return kept
+}
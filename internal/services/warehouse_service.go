@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/lock"
+	"product-management/pkg/warehouse"
+
+	"gorm.io/gorm"
+)
+
+// warehouseTimeFormat is how event times are sent to the warehouse
+const warehouseTimeFormat = "2006-01-02 15:04:05.000"
+
+// warehouseTables are the warehouse tables events are exported to, by kind
+var warehouseTables = map[models.WarehouseEventKind]string{
+	models.WarehouseOrder:       "orders",
+	models.WarehouseProductView: "product_views",
+	models.WarehouseSearch:      "searches",
+}
+
+// queueWarehouseEvent queues a row of the warehouse table of its kind for
+// export, within tx when the event reports a change being made. Nothing is
+// queued while the export is off.
+func queueWarehouseEvent(tx *gorm.DB, kind models.WarehouseEventKind, occurredAt time.Time, row warehouse.Row) error {
+	if warehouse.Default == nil {
+		return nil
+	}
+	payload, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	return repositories.NewWarehouseRepository(tx).Queue(&models.WarehouseEvent{
+		Kind:       kind,
+		Payload:    string(payload),
+		OccurredAt: occurredAt,
+	})
+}
+
+// RecordSearch queues a product search for export. Failing to queue it doesn't
+// fail the search.
+func RecordSearch(term string, categoryID uint, results int64, userID uint) {
+	if err := queueWarehouseEvent(database.DB, models.WarehouseSearch, time.Now(), warehouse.Row{
+		"term":        term,
+		"category_id": categoryID,
+		"results":     results,
+		"user_id":     userID,
+	}); err != nil {
+		log.Printf("Warning: failed to queue search %q for the warehouse: %v", term, err)
+	}
+}
+
+// queueWarehouseOrder queues an accepted quote as an order for export
+func queueWarehouseOrder(tx *gorm.DB, quote *models.Quote, orderNumber string) error {
+	productIDs := make([]uint, len(quote.Items))
+	quantities := make([]int, len(quote.Items))
+	unitPrices := make([]float64, len(quote.Items))
+	for i, item := range quote.Items {
+		productIDs[i] = item.ProductID
+		quantities[i] = item.Quantity
+		if item.QuotedPrice != nil {
+			unitPrices[i] = *item.QuotedPrice
+		}
+	}
+	return queueWarehouseEvent(tx, models.WarehouseOrder, *quote.DecidedAt, warehouse.Row{
+		"quote_id":     quote.ID,
+		"order_number": orderNumber,
+		"user_id":      quote.UserID,
+		"country":      quote.Country,
+		"product_id":   productIDs,
+		"quantity":     quantities,
+		"unit_price":   unitPrices,
+		"total":        math.Round(quote.Total()*100) / 100,
+	})
+}
+
+// WarehouseService exports the queued analytics events to the warehouse in
+// batches, so heavy analytics can run there instead of on Postgres
+type WarehouseService struct {
+	store         warehouse.Store
+	batchSize     int
+	pollInterval  time.Duration
+	warehouseRepo *repositories.WarehouseRepository
+}
+
+// NewWarehouseService creates a new WarehouseService instance exporting to store
+func NewWarehouseService(store warehouse.Store, batchSize int, pollInterval time.Duration) *WarehouseService {
+	return &WarehouseService{
+		store:         store,
+		batchSize:     batchSize,
+		pollInterval:  pollInterval,
+		warehouseRepo: repositories.NewWarehouseRepository(database.DB),
+	}
+}
+
+// Migrate creates the warehouse tables that don't exist and adds the columns
+// missing from those that do
+func (s *WarehouseService) Migrate() error {
+	return s.store.Migrate(context.Background(), warehouse.Tables)
+}
+
+// Start exports queued events now and then every poll interval, so events are
+// loaded in batches rather than one at a time, and returns a function that
+// stops it
+func (s *WarehouseService) Start() func() {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+		for {
+			if err := s.Run(); err != nil {
+				log.Printf("Warning: warehouse export failed: %v", err)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
+
+// Run exports every queued event, oldest first, a batch at a time. When
+// several instances run, the one holding the export lock exports and the
+// others skip their turn. A batch is marked exported only once the warehouse
+// took it, so a failed batch is loaded again at the next run; the warehouse
+// tables drop the duplicates.
+func (s *WarehouseService) Run() error {
+	held, err := lock.Default.TryLock(context.Background(), "warehouse export")
+	if errors.Is(err, lock.ErrLocked) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer held.Release()
+
+	for {
+		pending, err := s.warehouseRepo.ListPending(s.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if err := s.export(pending); err != nil {
+			return err
+		}
+		if len(pending) < s.batchSize {
+			return nil
+		}
+	}
+}
+
+// export loads a batch of events into their warehouse tables and marks them
+// exported
+func (s *WarehouseService) export(pending []models.WarehouseEvent) error {
+	rows := make(map[string][]warehouse.Row)
+	ids := make([]uint, len(pending))
+	for i, event := range pending {
+		ids[i] = event.ID
+		table, ok := warehouseTables[event.Kind]
+		var row warehouse.Row
+		if ok {
+			ok = json.Unmarshal([]byte(event.Payload), &row) == nil
+		}
+		if !ok {
+			log.Printf("Warning: skipping warehouse event %d of kind %s with an invalid payload", event.ID, event.Kind)
+			continue
+		}
+		if event.Kind != models.WarehouseOrder {
+			row["event_id"] = event.ID
+		}
+		row["occurred_at"] = event.OccurredAt.UTC().Format(warehouseTimeFormat)
+		rows[table] = append(rows[table], row)
+	}
+
+	ctx := context.Background()
+	for table, tableRows := range rows {
+		if err := s.store.Insert(ctx, table, tableRows); err != nil {
+			return fmt.Errorf("%s: %w", table, err)
+		}
+	}
+	return s.warehouseRepo.MarkExported(ids, time.Now())
+}
+
+// Backfill queues the orders accepted in [from, to) for export, then exports
+// them. Orders exported already are replaced rather than duplicated. Searches
+// can't be backfilled, since only queued ones are kept, and product views
+// would be exported again under new event IDs.
+func (s *WarehouseService) Backfill(from, to time.Time) (int, error) {
+	prefix := NewSettingService().Value(models.SettingOrderNumberPrefix)
+	queued := 0
+	var afterID uint
+	for {
+		quotes, err := s.warehouseRepo.ListAcceptedQuotes(from, to, afterID, s.batchSize)
+		if err != nil {
+			return queued, err
+		}
+		for i := range quotes {
+			if err := queueWarehouseOrder(database.DB, &quotes[i], prefix+strconv.FormatUint(uint64(quotes[i].ID), 10)); err != nil {
+				return queued, err
+			}
+			queued++
+		}
+		if len(quotes) < s.batchSize {
+			break
+		}
+		afterID = quotes[len(quotes)-1].ID
+	}
+	return queued, s.Run()
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"product-management/internal/dto"
+	"product-management/internal/models"
+	"product-management/internal/repositories"
+	"product-management/pkg/database"
+	"product-management/pkg/events"
+	"product-management/pkg/webhook"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAPIKeyNotLinked   = errors.New("API key is not linked to a customer")
+	ErrAPIKeyLinked      = errors.New("API key is already linked to another customer")
+	ErrWebhookProductIDs = errors.New("products not ordered by the API key's customer")
+)
+
+// webhookAttempts is how many times an event is sent before giving up
+const webhookAttempts = 3
+
+// WebhookService manages the webhook subscriptions of API keys and delivers
+// product and order events to them. An API key acts for the customer it is
+// linked to, and only receives events about that customer's orders and the
+// products they ordered.
+type WebhookService struct {
+	webhookRepo *repositories.WebhookRepository
+}
+
+// NewWebhookService creates a new WebhookService instance
+func NewWebhookService() *WebhookService {
+	return &WebhookService{
+		webhookRepo: repositories.NewWebhookRepository(database.DB),
+	}
+}
+
+// CreateSubscription subscribes an API key's endpoint to events with a new
+// secret. The endpoint must be a public https URL, the key must be linked to a
+// customer and the products must be ones the customer ordered.
+func (s *WebhookService) CreateSubscription(apiKeyName string, req dto.CreateWebhookRequest) (*models.WebhookSubscription, error) {
+	if err := webhook.CheckURL(req.URL); err != nil {
+		return nil, err
+	}
+	userID, err := s.webhookRepo.GetLinkedUserID(apiKeyName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAPIKeyNotLinked
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(req.ProductIDs) > 0 {
+		ordered, err := s.webhookRepo.OrderedProductIDs(userID, req.ProductIDs)
+		if err != nil {
+			return nil, err
+		}
+		var unknown []uint
+		for _, id := range req.ProductIDs {
+			if !slices.Contains(ordered, id) && !slices.Contains(unknown, id) {
+				unknown = append(unknown, id)
+			}
+		}
+		if len(unknown) > 0 {
+			return nil, fmt.Errorf("%w: %v", ErrWebhookProductIDs, unknown)
+		}
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+	subscription := &models.WebhookSubscription{
+		APIKeyName: apiKeyName,
+		URL:        req.URL,
+		Secret:     secret,
+		Events:     req.Events,
+		ProductIDs: req.ProductIDs,
+	}
+	if err := s.webhookRepo.Create(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// LinkAPIKey links an API key to the customer it acts for, or unlinks the
+// customer's key when apiKeyName is nil. A key acts for one customer at most.
+func (s *WebhookService) LinkAPIKey(userID uint, apiKeyName *string) error {
+	if apiKeyName != nil {
+		linkedID, err := s.webhookRepo.GetLinkedUserID(*apiKeyName)
+		if err == nil && linkedID != userID {
+			return ErrAPIKeyLinked
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+	}
+	return s.webhookRepo.LinkAPIKey(userID, apiKeyName)
+}
+
+// ListSubscriptions retrieves the subscriptions of an API key
+func (s *WebhookService) ListSubscriptions(apiKeyName string) ([]models.WebhookSubscription, error) {
+	return s.webhookRepo.ListByAPIKey(apiKeyName)
+}
+
+// DeleteSubscription deletes a subscription of an API key
+func (s *WebhookService) DeleteSubscription(apiKeyName string, id uint) error {
+	if _, err := s.webhookRepo.GetByIDForAPIKey(id, apiKeyName); err != nil {
+		return err
+	}
+	return s.webhookRepo.Delete(id)
+}
+
+// ListDeliveries retrieves the delivery log of a subscription of an API key
+func (s *WebhookService) ListDeliveries(apiKeyName string, id uint, page, pageSize int) ([]models.WebhookDelivery, int64, error) {
+	if _, err := s.webhookRepo.GetByIDForAPIKey(id, apiKeyName); err != nil {
+		return nil, 0, err
+	}
+	return s.webhookRepo.ListDeliveries(id, page, pageSize)
+}
+
+// Subscribe delivers product and order events published on the bus to the
+// matching subscriptions
+func (s *WebhookService) Subscribe(bus *events.Bus) {
+	bus.Subscribe(events.TopicProductChanged, func(event events.Event) {
+		changed := event.(events.ProductChanged)
+		s.dispatch(events.TopicProductChanged, changed.ProductID, map[string]interface{}{
+			"product_id": changed.ProductID,
+			"deleted":    changed.Deleted,
+		})
+	})
+	bus.Subscribe(events.TopicStockChanged, func(event events.Event) {
+		changed := event.(events.StockChanged)
+		s.dispatch(events.TopicStockChanged, changed.ProductID, map[string]interface{}{
+			"product_id":        changed.ProductID,
+			"previous_quantity": changed.Previous,
+			"quantity":          changed.Quantity,
+		})
+	})
+	bus.Subscribe(events.TopicOrderStatusChanged, func(event events.Event) {
+		changed := event.(events.OrderStatusChanged)
+		subscriptions, err := s.webhookRepo.ListForCustomerEvent(events.TopicOrderStatusChanged, changed.UserID)
+		if err != nil {
+			log.Printf("Warning: failed to load webhook subscriptions for %s: %v", events.TopicOrderStatusChanged, err)
+			return
+		}
+		for i := range subscriptions {
+			go s.deliver(subscriptions[i], events.TopicOrderStatusChanged, map[string]interface{}{
+				"quote_id":     changed.QuoteID,
+				"order_number": changed.OrderNumber,
+				"status":       changed.Status,
+			})
+		}
+	})
+}
+
+// dispatch sends an event about a product to every subscription that wants it
+// and whose customer ordered the product
+func (s *WebhookService) dispatch(event string, productID uint, data map[string]interface{}) {
+	subscriptions, err := s.webhookRepo.ListForProductEvent(event, productID)
+	if err != nil {
+		log.Printf("Warning: failed to load webhook subscriptions for %s: %v", event, err)
+		return
+	}
+	for i := range subscriptions {
+		if subscriptions[i].Matches(event, productID) {
+			go s.deliver(subscriptions[i], event, data)
+		}
+	}
+}
+
+// deliver sends an event to a subscription, retrying with backoff, and logs every attempt
+func (s *WebhookService) deliver(subscription models.WebhookSubscription, event string, data map[string]interface{}) {
+	eventID, err := webhook.NewEventID()
+	if err != nil {
+		log.Printf("Warning: failed to create webhook event ID: %v", err)
+		return
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"id":         eventID,
+		"event":      event,
+		"created_at": time.Now().UTC().Format(time.RFC3339),
+		"data":       data,
+	})
+	if err != nil {
+		log.Printf("Warning: failed to encode webhook %s: %v", event, err)
+		return
+	}
+
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		status, err := webhook.Send(ctx, subscription.URL, subscription.Secret, event, eventID, body)
+		cancel()
+
+		delivery := &models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			Event:          event,
+			Attempt:        attempt,
+			StatusCode:     status,
+			Succeeded:      err == nil,
+			DurationMs:     time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		if logErr := s.webhookRepo.CreateDelivery(delivery); logErr != nil {
+			log.Printf("Warning: failed to record webhook delivery: %v", logErr)
+		}
+		if err == nil {
+			return
+		}
+		if attempt < webhookAttempts {
+			time.Sleep(time.Duration(attempt*attempt) * 5 * time.Second)
+		}
+	}
+}
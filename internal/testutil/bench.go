@@ -0,0 +1,97 @@
+package testutil
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"product-management/config"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// datasets holds the dataset connections opened by the benchmarks of a test
+// binary, so each dataset is checked and seeded once per run
+var datasets = struct {
+	sync.Mutex
+	open map[string]*gorm.DB
+}{open: make(map[string]*gorm.DB)}
+
+// RunOnDatasets runs a benchmark on each dataset named in BENCH_SIZES, such as
+// "10k,100k" (10k by default), as a sub-benchmark named after its size.
+// Datasets are seeded when missing, or every run with BENCH_RESEED=true. The
+// benchmark is skipped when the configured database can't be reached.
+func RunOnDatasets(b *testing.B, run func(b *testing.B, db *gorm.DB)) {
+	sizes := os.Getenv("BENCH_SIZES")
+	if sizes == "" {
+		sizes = "10k"
+	}
+	for _, size := range strings.Split(sizes, ",") {
+		size = strings.TrimSpace(size)
+		b.Run(size, func(b *testing.B) {
+			db, err := dataset(size)
+			if err != nil {
+				b.Skipf("dataset %s unavailable: %v", size, err)
+			}
+			b.ReportAllocs()
+			run(b, db)
+		})
+	}
+}
+
+// dataset opens a dataset by size name and seeds it on first use
+func dataset(size string) (*gorm.DB, error) {
+	datasets.Lock()
+	defer datasets.Unlock()
+	if db, ok := datasets.open[size]; ok {
+		return db, nil
+	}
+
+	products, ok := DatasetSizes[size]
+	if !ok {
+		return nil, fmt.Errorf("unknown dataset size %q (supported: %s)", size, strings.Join(DatasetSizeNames(), ", "))
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	db, err := OpenDataset(cfg, DatasetSchema(size))
+	if err != nil {
+		return nil, err
+	}
+
+	if reseed, _ := strconv.ParseBool(os.Getenv("BENCH_RESEED")); reseed {
+		err = SeedDataset(db, products)
+	} else {
+		err = EnsureDataset(db, products)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to seed: %v", err)
+	}
+	datasets.open[size] = db
+	return db, nil
+}
+
+// OpenDataset connects to the configured database with search_path set to a
+// dataset schema, creating the schema if needed
+func OpenDataset(cfg *config.Config, schema string) (*gorm.DB, error) {
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable search_path=%s",
+		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName, schema)
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		PrepareStmt: true,
+		Logger:      gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Exec("CREATE SCHEMA IF NOT EXISTS " + schema).Error; err != nil {
+		return nil, err
+	}
+	return db, nil
+}
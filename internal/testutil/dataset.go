@@ -0,0 +1,130 @@
+// Package testutil holds the seeded datasets and helpers used by the benchmark
+// and load-test harness.
+package testutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"product-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// DatasetSizes maps the supported dataset names to their number of products.
+// Every product gets one review, so the review count matches.
+var DatasetSizes = map[string]int{
+	"10k":  10_000,
+	"100k": 100_000,
+	"1m":   1_000_000,
+}
+
+// Dataset shape
+const (
+	datasetUsers      = 1_000
+	datasetCategories = 20
+)
+
+// datasetModels are the tables a dataset populates
+var datasetModels = []interface{}{
+	&models.User{},
+	&models.Product{},
+	&models.Category{},
+	&models.Review{},
+	&models.ProductCategory{},
+}
+
+// DatasetSizeNames returns the supported dataset names, smallest first
+func DatasetSizeNames() []string {
+	names := make([]string, 0, len(DatasetSizes))
+	for name := range DatasetSizes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return DatasetSizes[names[i]] < DatasetSizes[names[j]] })
+	return names
+}
+
+// DatasetSchema returns the Postgres schema holding a dataset, keeping
+// benchmark data away from application tables
+func DatasetSchema(size string) string {
+	return "bench_" + strings.ToLower(size)
+}
+
+// EnsureDataset migrates the dataset tables and seeds them unless they already
+// hold exactly the requested number of products. db must be bound to the
+// dataset schema via search_path.
+func EnsureDataset(db *gorm.DB, products int) error {
+	if err := db.AutoMigrate(datasetModels...); err != nil {
+		return fmt.Errorf("failed to migrate dataset: %v", err)
+	}
+
+	var count int64
+	if err := db.Model(&models.Product{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == int64(products) {
+		return nil
+	}
+
+	return SeedDataset(db, products)
+}
+
+// SeedDataset replaces the dataset tables with generated rows. Rows are built
+// server-side with generate_series so even the 1M dataset seeds in seconds.
+func SeedDataset(db *gorm.DB, products int) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []struct {
+			sql  string
+			args []interface{}
+		}{
+			{sql: "TRUNCATE TABLE product_categories, reviews, categories, products, users RESTART IDENTITY CASCADE"},
+			{
+				sql: `INSERT INTO users (username, email, full_name, password, role, last_login, created_at, updated_at)
+					SELECT 'bench_user_' || g, 'bench_user_' || g || '@example.com', 'Bench User ' || g, 'not-a-password', 'user', NOW(), NOW(), NOW()
+					FROM generate_series(1, ?) AS g`,
+				args: []interface{}{datasetUsers},
+			},
+			{
+				sql: `INSERT INTO categories (name, description, created_at, updated_at)
+					SELECT 'Category ' || g, 'Benchmark category ' || g, NOW(), NOW()
+					FROM generate_series(1, ?) AS g`,
+				args: []interface{}{datasetCategories},
+			},
+			{
+				sql: `INSERT INTO products (name, description, price, stock_quantity, status, created_at, updated_at)
+					SELECT 'Product ' || g, 'Benchmark product number ' || g || ' with a searchable description',
+						(g % 1000) + 0.99, g % 500,
+						CASE WHEN g % 10 = 0 THEN 'inactive' ELSE 'active' END,
+						NOW() - (g || ' minutes')::interval, NOW()
+					FROM generate_series(1, ?) AS g`,
+				args: []interface{}{products},
+			},
+			{
+				sql: `INSERT INTO product_categories (product_id, category_id, created_at, updated_at)
+					SELECT id, (id % ?) + 1, NOW(), NOW() FROM products`,
+				args: []interface{}{datasetCategories},
+			},
+			{
+				sql: `INSERT INTO reviews (product_id, user_id, rating, comment, created_at, updated_at)
+					SELECT id, (id % ?) + 1, (id % 5) + 1, 'Benchmark review for product ' || id, created_at, NOW()
+					FROM products`,
+				args: []interface{}{datasetUsers},
+			},
+			{
+				sql: `UPDATE products SET rating_average = (id % 5) + 1, rating_count = 1`,
+			},
+			{
+				sql: `UPDATE categories c
+					SET products_count = (SELECT COUNT(*) FROM product_categories pc WHERE pc.category_id = c.id)`,
+			},
+		}
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement.sql, statement.args...).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Exec("ANALYZE").Error
+	})
+}
@@ -0,0 +1,62 @@
+// k6 load-test profile for the hot read endpoints.
+//
+//   k6 run -e BASE_URL=http://localhost:8080 internal/testutil/loadtest/k6.js
+//
+// Credentials default to the seeded admin account.
+import http from 'k6/http';
+import { check, sleep } from 'k6';
+
+const BASE_URL = __ENV.BASE_URL || 'http://localhost:8080';
+const API = `${BASE_URL}/api/v1`;
+
+export const options = {
+  scenarios: {
+    browse: {
+      executor: 'ramping-vus',
+      startVUs: 0,
+      stages: [
+        { duration: '30s', target: 50 },
+        { duration: '1m', target: 50 },
+        { duration: '15s', target: 0 },
+      ],
+    },
+  },
+  thresholds: {
+    http_req_failed: ['rate<0.01'],
+    'http_req_duration{endpoint:products}': ['p(95)<300'],
+    'http_req_duration{endpoint:product}': ['p(95)<150'],
+    'http_req_duration{endpoint:reviews}': ['p(95)<300'],
+  },
+};
+
+export function setup() {
+  const res = http.post(`${API}/auth/login`, JSON.stringify({
+    email: __ENV.EMAIL || 'admin@soa.com',
+    password: __ENV.PASSWORD || 'password123',
+  }), { headers: { 'Content-Type': 'application/json' } });
+  check(res, { 'logged in': (r) => r.status === 200 });
+  return { token: res.json('data.access_token') };
+}
+
+export default function (data) {
+  const params = (endpoint) => ({
+    headers: { Authorization: `Bearer ${data.token}` },
+    tags: { endpoint },
+  });
+
+  const page = Math.floor(Math.random() * 20) + 1;
+  const list = http.get(`${API}/products?page=${page}&page_size=20&sort=rating`, params('products'));
+  check(list, { 'products 200': (r) => r.status === 200 });
+
+  const items = list.status === 200 ? list.json('items') : [];
+  if (items && items.length > 0) {
+    const product = items[Math.floor(Math.random() * items.length)];
+    const res = http.get(`${API}/products/${product.id}`, params('product'));
+    check(res, { 'product 200': (r) => r.status === 200 });
+  }
+
+  const reviews = http.get(`${API}/reviews/?page=${page}&page_size=20&sort_by=created_at&order=desc`, params('reviews'));
+  check(reviews, { 'reviews 200': (r) => r.status === 200 });
+
+  sleep(1);
+}
@@ -1,6 +1,9 @@
 package types
 
-import "product-management/internal/models"
+import (
+	"product-management/internal/dto"
+	"product-management/pkg/utils"
+)
 
 // APIResponse represents a standard API response
 type APIResponse struct {
@@ -10,28 +13,40 @@ type APIResponse struct {
 	Data    interface{} `json:"data,omitempty"`    // Response data
 }
 
+// DataResponse is an APIResponse with a concrete data type, used to document response schemas
+type DataResponse[T any] struct {
+	Success bool   `json:"success"`           // Whether the request was successful
+	Message string `json:"message,omitempty"` // Optional message
+	Error   string `json:"error,omitempty"`   // Error message if success is false
+	Data    T      `json:"data,omitempty"`    // Response data
+}
+
 // PaginatedResponse represents a paginated response
 type PaginatedResponse struct {
-	Items      interface{} `json:"items"`       // List of items
-	Total      int64       `json:"total"`       // Total number of items
-	Page       int         `json:"page"`        // Current page number
-	PageSize   int         `json:"page_size"`   // Number of items per page
-	TotalPages int         `json:"total_pages"` // Total number of pages
+	Items           interface{} `json:"items"`             // List of items
+	Total           int64       `json:"total"`             // Total number of items
+	Page            int         `json:"page"`              // Current page number
+	PageSize        int         `json:"page_size"`         // Number of items per page
+	TotalPages      int         `json:"total_pages"`       // Total number of pages
+	DefaultPageSize int         `json:"default_page_size"` // Page size used when none is requested
+	MaxPageSize     int         `json:"max_page_size"`     // Largest page size allowed for this endpoint
 }
 
 // NewPaginatedResponse creates a new paginated response
-func NewPaginatedResponse(items interface{}, total int64, page, pageSize int) PaginatedResponse {
-	totalPages := (int(total) + pageSize - 1) / pageSize
+func NewPaginatedResponse(items interface{}, total int64, pagination utils.PaginationParams) PaginatedResponse {
+	totalPages := (int(total) + pagination.Limit - 1) / pagination.Limit
 	if totalPages < 1 {
 		totalPages = 1
 	}
 
 	return PaginatedResponse{
-		Items:      items,
-		Total:      total,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalPages: totalPages,
+		Items:           items,
+		Total:           total,
+		Page:            pagination.Page,
+		PageSize:        pagination.Limit,
+		TotalPages:      totalPages,
+		DefaultPageSize: pagination.DefaultLimit,
+		MaxPageSize:     pagination.MaxLimit,
 	}
 }
 
@@ -42,6 +57,24 @@ type ErrorResponse struct {
 	Description string `json:"description,omitempty"` // Detailed error description
 }
 
+// ProblemDetails represents an RFC 7807 application/problem+json error response
+type ProblemDetails struct {
+	Type     string       `json:"type"`               // URI identifying the problem type, about:blank when the status says it all
+	Title    string       `json:"title"`              // Summary of the problem type
+	Status   int          `json:"status"`             // HTTP status code
+	Detail   string       `json:"detail,omitempty"`   // Explanation of this occurrence of the problem
+	Instance string       `json:"instance,omitempty"` // Path of the request that failed
+	Code     string       `json:"code,omitempty"`     // Error code for client handling
+	Errors   []FieldError `json:"errors,omitempty"`   // Invalid request fields
+	Data     interface{}  `json:"data,omitempty"`     // Data the error response carried, such as failed self-test checks
+}
+
+// FieldError represents an invalid field of a request body
+type FieldError struct {
+	Field   string `json:"field"`   // Field path as in the request body, e.g. categories[0]
+	Message string `json:"message"` // What is wrong with it
+}
+
 // SuccessResponse represents a success response with a message
 type SuccessResponse struct {
 	Message string `json:"message"` // Success message
@@ -50,31 +83,45 @@ type SuccessResponse struct {
 // ProductListResponse represents a paginated list of products
 type ProductListResponse struct {
 	PaginatedResponse
-	Items []models.Product `json:"items"` // Override Items with specific type
+	Items []dto.ProductResponse `json:"items"` // Override Items with specific type
 }
 
 // WishlistResponse represents a paginated list of wishlist items
 type WishlistResponse struct {
 	PaginatedResponse
-	Items []models.Wishlist `json:"items"` // Override Items with specific type
+	Items []dto.WishlistItemResponse `json:"items"` // Override Items with specific type
+}
+
+// UserListResponse represents a paginated list of users
+type UserListResponse struct {
+	PaginatedResponse
+	Items []dto.UserResponse `json:"items"` // Override Items with specific type
 }
 
 // NewProductListResponse creates a new product list response
-func NewProductListResponse(products []models.Product, total int64, page, pageSize int) ProductListResponse {
+func NewProductListResponse(products []dto.ProductResponse, total int64, pagination utils.PaginationParams) ProductListResponse {
 	return ProductListResponse{
-		PaginatedResponse: NewPaginatedResponse(products, total, page, pageSize),
+		PaginatedResponse: NewPaginatedResponse(products, total, pagination),
 		Items:             products,
 	}
 }
 
 // NewWishlistResponse creates a new wishlist response
-func NewWishlistResponse(wishlist []models.Wishlist, total int64, page, pageSize int) WishlistResponse {
+func NewWishlistResponse(wishlist []dto.WishlistItemResponse, total int64, pagination utils.PaginationParams) WishlistResponse {
 	return WishlistResponse{
-		PaginatedResponse: NewPaginatedResponse(wishlist, total, page, pageSize),
+		PaginatedResponse: NewPaginatedResponse(wishlist, total, pagination),
 		Items:             wishlist,
 	}
 }
 
+// NewUserListResponse creates a new user list response
+func NewUserListResponse(users []dto.UserResponse, total int64, pagination utils.PaginationParams) UserListResponse {
+	return UserListResponse{
+		PaginatedResponse: NewPaginatedResponse(users, total, pagination),
+		Items:             users,
+	}
+}
+
 // CategoryDistributionResponse represents the response for category distribution
 type CategoryDistributionResponse struct {
 	Name         string `json:"name"`
@@ -83,7 +130,7 @@ type CategoryDistributionResponse struct {
 
 // LoginResponse represents the response for login
 type LoginResponse struct {
-	AccessToken  string      `json:"access_token"`
-	RefreshToken string      `json:"refresh_token"`
-	User         interface{} `json:"user"`
+	AccessToken  string         `json:"access_token"`
+	RefreshToken string         `json:"refresh_token"`
+	User         dto.UserOutput `json:"user"`
 }
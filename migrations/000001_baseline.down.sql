@@ -0,0 +1,50 @@
+-- Drops every table of the baseline, and the reporting views built on them
+DROP TABLE IF EXISTS "custom_fields" CASCADE;
+DROP TABLE IF EXISTS "product_images" CASCADE;
+DROP TABLE IF EXISTS "experiment_events" CASCADE;
+DROP TABLE IF EXISTS "experiment_variants" CASCADE;
+DROP TABLE IF EXISTS "experiments" CASCADE;
+DROP TABLE IF EXISTS "product_views" CASCADE;
+DROP TABLE IF EXISTS "warehouse_events" CASCADE;
+DROP TABLE IF EXISTS "revoked_tokens" CASCADE;
+DROP TABLE IF EXISTS "refresh_tokens" CASCADE;
+DROP TABLE IF EXISTS "promotions" CASCADE;
+DROP TABLE IF EXISTS "pricing_rules" CASCADE;
+DROP TABLE IF EXISTS "settings" CASCADE;
+DROP TABLE IF EXISTS "sync_changes" CASCADE;
+DROP TABLE IF EXISTS "saga_steps" CASCADE;
+DROP TABLE IF EXISTS "sagas" CASCADE;
+DROP TABLE IF EXISTS "outbox_events" CASCADE;
+DROP TABLE IF EXISTS "connector_sync_runs" CASCADE;
+DROP TABLE IF EXISTS "content_blocks" CASCADE;
+DROP TABLE IF EXISTS "featured_products" CASCADE;
+DROP TABLE IF EXISTS "purchase_order_items" CASCADE;
+DROP TABLE IF EXISTS "purchase_orders" CASCADE;
+DROP TABLE IF EXISTS "suppliers" CASCADE;
+DROP TABLE IF EXISTS "quote_items" CASCADE;
+DROP TABLE IF EXISTS "quotes" CASCADE;
+DROP TABLE IF EXISTS "price_list_items" CASCADE;
+DROP TABLE IF EXISTS "price_lists" CASCADE;
+DROP TABLE IF EXISTS "segments" CASCADE;
+DROP TABLE IF EXISTS "store_credit_entries" CASCADE;
+DROP TABLE IF EXISTS "gift_cards" CASCADE;
+DROP TABLE IF EXISTS "webhook_deliveries" CASCADE;
+DROP TABLE IF EXISTS "webhook_subscriptions" CASCADE;
+DROP TABLE IF EXISTS "known_devices" CASCADE;
+DROP TABLE IF EXISTS "notification_settings" CASCADE;
+DROP TABLE IF EXISTS "email_suppressions" CASCADE;
+DROP TABLE IF EXISTS "request_stats" CASCADE;
+DROP TABLE IF EXISTS "ip_blocks" CASCADE;
+DROP TABLE IF EXISTS "security_alerts" CASCADE;
+DROP TABLE IF EXISTS "retention_runs" CASCADE;
+DROP TABLE IF EXISTS "audit_logs" CASCADE;
+DROP TABLE IF EXISTS "stock_movements" CASCADE;
+DROP TABLE IF EXISTS "category_revisions" CASCADE;
+DROP TABLE IF EXISTS "product_revisions" CASCADE;
+DROP TABLE IF EXISTS "product_change_requests" CASCADE;
+DROP TABLE IF EXISTS "wishlists" CASCADE;
+DROP TABLE IF EXISTS "reviews" CASCADE;
+DROP TABLE IF EXISTS "product_categories" CASCADE;
+DROP TABLE IF EXISTS "categories" CASCADE;
+DROP TABLE IF EXISTS "products" CASCADE;
+DROP TABLE IF EXISTS "users" CASCADE;
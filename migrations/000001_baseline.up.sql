@@ -0,0 +1,826 @@
+-- The schema as GORM's AutoMigrate created it before versioned migrations.
+-- Every statement is guarded by IF NOT EXISTS, so databases created by
+-- AutoMigrate adopt this version without changes.
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "username" text NOT NULL,
+    "email" text NOT NULL,
+    "full_name" text,
+    "password" text NOT NULL,
+    "role" varchar(10) DEFAULT 'user',
+    "last_login" timestamptz,
+    "token_version" bigint NOT NULL DEFAULT 0,
+    "referral_code" varchar(12),
+    "referred_by_id" bigint,
+    "referral_rewarded_at" timestamptz,
+    "price_list_id" bigint,
+    "anonymized_at" timestamptz,
+    "custom_fields" jsonb,
+    "locale" varchar(16),
+    "currency" varchar(3),
+    "timezone" varchar(64),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_users_username" UNIQUE ("username"),
+    CONSTRAINT "uni_users_email" UNIQUE ("email")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_price_list_id" ON "users" ("price_list_id");
+CREATE INDEX IF NOT EXISTS "idx_users_referred_by_id" ON "users" ("referred_by_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_referral_code" ON "users" ("referral_code");
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "products" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" text NOT NULL,
+    "description" text,
+    "sku" varchar(64),
+    "price" decimal NOT NULL,
+    "stock_quantity" bigint NOT NULL DEFAULT 0,
+    "status" text DEFAULT 'active',
+    "rating_average" decimal NOT NULL DEFAULT 0,
+    "rating_count" bigint NOT NULL DEFAULT 0,
+    "allowed_countries" jsonb,
+    "blocked_countries" jsonb,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_products_metadata" ON "products" USING gin("metadata");
+CREATE INDEX IF NOT EXISTS "idx_products_rating_average" ON "products" ("rating_average");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_products_sku" ON "products" ("sku");
+CREATE INDEX IF NOT EXISTS "idx_products_deleted_at" ON "products" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "categories" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" text NOT NULL,
+    "description" text,
+    "metadata_schema" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_categories_deleted_at" ON "categories" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "product_categories" (
+    "product_id" bigint,
+    "category_id" bigint,
+    "created_at" timestamptz DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("product_id","category_id"),
+    CONSTRAINT "fk_product_categories_category" FOREIGN KEY ("category_id") REFERENCES "categories"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_product_categories_product" FOREIGN KEY ("product_id") REFERENCES "products"("id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "reviews" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "product_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "rating" bigint NOT NULL,
+    "comment" text,
+    "imported" boolean NOT NULL DEFAULT false,
+    "source" varchar(32),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_products_reviews" FOREIGN KEY ("product_id") REFERENCES "products"("id"),
+    CONSTRAINT "fk_users_reviews" FOREIGN KEY ("user_id") REFERENCES "users"("id"),
+    CONSTRAINT "chk_reviews_rating" CHECK (rating >= 1 AND rating <= 5)
+);
+CREATE INDEX IF NOT EXISTS "idx_reviews_deleted_at" ON "reviews" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "wishlists" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint NOT NULL,
+    "product_id" bigint NOT NULL,
+    "added_at" timestamptz DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_products_wishlists" FOREIGN KEY ("product_id") REFERENCES "products"("id"),
+    CONSTRAINT "fk_wishlists_user" FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_wishlists_deleted_at" ON "wishlists" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "product_change_requests" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "product_id" bigint NOT NULL,
+    "requested_by" bigint NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'pending',
+    "proposed" jsonb NOT NULL,
+    "diff" jsonb NOT NULL,
+    "reviewed_by" bigint,
+    "reviewed_at" timestamptz,
+    "review_note" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_product_change_requests_product" FOREIGN KEY ("product_id") REFERENCES "products"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_product_change_requests_status" ON "product_change_requests" ("status");
+CREATE INDEX IF NOT EXISTS "idx_product_change_requests_requested_by" ON "product_change_requests" ("requested_by");
+CREATE INDEX IF NOT EXISTS "idx_product_change_requests_product_id" ON "product_change_requests" ("product_id");
+CREATE INDEX IF NOT EXISTS "idx_product_change_requests_deleted_at" ON "product_change_requests" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "product_revisions" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "product_id" bigint NOT NULL,
+    "revision" bigint NOT NULL,
+    "snapshot" jsonb NOT NULL,
+    "edited_by" bigint NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_product_revisions_product" FOREIGN KEY ("product_id") REFERENCES "products"("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_product_revision" ON "product_revisions" ("product_id","revision");
+CREATE INDEX IF NOT EXISTS "idx_product_revisions_deleted_at" ON "product_revisions" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "category_revisions" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "category_id" bigint NOT NULL,
+    "revision" bigint NOT NULL,
+    "snapshot" jsonb NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_category_revision" ON "category_revisions" ("category_id","revision");
+CREATE INDEX IF NOT EXISTS "idx_category_revisions_deleted_at" ON "category_revisions" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "stock_movements" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "product_id" bigint NOT NULL,
+    "source" varchar(20) NOT NULL,
+    "reference" text,
+    "delta" bigint NOT NULL,
+    "resulting_quantity" bigint NOT NULL,
+    "actor_id" bigint,
+    "note" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_stock_movements_product" FOREIGN KEY ("product_id") REFERENCES "products"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_stock_movements_product_id" ON "stock_movements" ("product_id");
+CREATE INDEX IF NOT EXISTS "idx_stock_movements_deleted_at" ON "stock_movements" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "audit_logs" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "actor_id" bigint NOT NULL,
+    "action" varchar(50) NOT NULL,
+    "details" text,
+    "country" varchar(2),
+    "city" varchar(100),
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_deleted_at" ON "audit_logs" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_action" ON "audit_logs" ("action");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_actor_id" ON "audit_logs" ("actor_id");
+
+CREATE TABLE IF NOT EXISTS "retention_runs" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "entity" varchar(50) NOT NULL,
+    "ran_at" timestamptz NOT NULL,
+    "deleted" bigint NOT NULL,
+    "total_deleted" bigint NOT NULL,
+    "duration_ms" bigint,
+    "error" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_retention_runs_deleted_at" ON "retention_runs" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_retention_runs_entity" ON "retention_runs" ("entity");
+
+CREATE TABLE IF NOT EXISTS "security_alerts" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "kind" varchar(30) NOT NULL,
+    "ip" varchar(45),
+    "actor_id" bigint,
+    "details" text,
+    "blocked" boolean NOT NULL DEFAULT false,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_security_alerts_actor_id" ON "security_alerts" ("actor_id");
+CREATE INDEX IF NOT EXISTS "idx_security_alerts_ip" ON "security_alerts" ("ip");
+CREATE INDEX IF NOT EXISTS "idx_security_alerts_kind" ON "security_alerts" ("kind");
+CREATE INDEX IF NOT EXISTS "idx_security_alerts_deleted_at" ON "security_alerts" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "ip_blocks" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "ip" varchar(45) NOT NULL,
+    "reason" text,
+    "expires_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_ip_blocks_deleted_at" ON "ip_blocks" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_ip_blocks_expires_at" ON "ip_blocks" ("expires_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_ip_blocks_ip" ON "ip_blocks" ("ip");
+
+CREATE TABLE IF NOT EXISTS "request_stats" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "bucket_start" timestamptz NOT NULL,
+    "method" varchar(10) NOT NULL,
+    "route" varchar(255) NOT NULL,
+    "status" bigint NOT NULL,
+    "count" bigint NOT NULL,
+    "total_ms" decimal NOT NULL,
+    "max_ms" decimal NOT NULL,
+    "latency_buckets" jsonb NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_request_stats_route" ON "request_stats" ("route");
+CREATE INDEX IF NOT EXISTS "idx_request_stats_bucket_start" ON "request_stats" ("bucket_start");
+CREATE INDEX IF NOT EXISTS "idx_request_stats_deleted_at" ON "request_stats" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "email_suppressions" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "email" varchar(255) NOT NULL,
+    "reason" text,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_email_suppressions_email" ON "email_suppressions" ("email");
+CREATE INDEX IF NOT EXISTS "idx_email_suppressions_deleted_at" ON "email_suppressions" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "notification_settings" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint NOT NULL,
+    "security_alerts_email" boolean NOT NULL,
+    "security_alerts_sms" boolean NOT NULL,
+    "security_alerts_push" boolean NOT NULL,
+    "order_updates_email" boolean NOT NULL,
+    "order_updates_sms" boolean NOT NULL,
+    "order_updates_push" boolean NOT NULL,
+    "phone_number" text,
+    "push_token" text,
+    "digest_unsubscribed" boolean NOT NULL DEFAULT false,
+    "promotions_opted_out" boolean NOT NULL DEFAULT false,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_notification_settings_user_id" ON "notification_settings" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_notification_settings_deleted_at" ON "notification_settings" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "known_devices" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint NOT NULL,
+    "fingerprint" varchar(64) NOT NULL,
+    "user_agent" text,
+    "last_ip" varchar(45),
+    "last_country" varchar(2),
+    "last_city" varchar(100),
+    "last_seen_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_known_devices_user_fingerprint" ON "known_devices" ("user_id","fingerprint");
+CREATE INDEX IF NOT EXISTS "idx_known_devices_deleted_at" ON "known_devices" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "webhook_subscriptions" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "api_key_name" varchar(100) NOT NULL,
+    "url" text NOT NULL,
+    "secret" varchar(100) NOT NULL,
+    "events" jsonb NOT NULL,
+    "product_ids" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_api_key_name" ON "webhook_subscriptions" ("api_key_name");
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_deleted_at" ON "webhook_subscriptions" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "subscription_id" bigint NOT NULL,
+    "event_id" varchar(36) NOT NULL,
+    "event" varchar(50) NOT NULL,
+    "attempt" bigint NOT NULL,
+    "status_code" bigint,
+    "succeeded" boolean NOT NULL,
+    "error" text,
+    "duration_ms" bigint,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_event_id" ON "webhook_deliveries" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_subscription_id" ON "webhook_deliveries" ("subscription_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_deleted_at" ON "webhook_deliveries" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "gift_cards" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "code" varchar(19) NOT NULL,
+    "initial_balance" decimal NOT NULL,
+    "balance" decimal NOT NULL,
+    "expires_at" timestamptz,
+    "issued_by" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_gift_cards_code" ON "gift_cards" ("code");
+CREATE INDEX IF NOT EXISTS "idx_gift_cards_deleted_at" ON "gift_cards" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "store_credit_entries" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint NOT NULL,
+    "source" varchar(20) NOT NULL,
+    "reference" text,
+    "delta" decimal NOT NULL,
+    "resulting_balance" decimal NOT NULL,
+    "actor_id" bigint,
+    "note" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_store_credit_entries_user_id" ON "store_credit_entries" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_store_credit_entries_deleted_at" ON "store_credit_entries" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "segments" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(100) NOT NULL,
+    "description" text,
+    "rules" jsonb NOT NULL,
+    "created_by" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_segments_name" ON "segments" ("name");
+CREATE INDEX IF NOT EXISTS "idx_segments_deleted_at" ON "segments" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "price_lists" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(100) NOT NULL,
+    "description" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_price_lists_deleted_at" ON "price_lists" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_price_lists_name" ON "price_lists" ("name");
+
+CREATE TABLE IF NOT EXISTS "price_list_items" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "price_list_id" bigint NOT NULL,
+    "product_id" bigint NOT NULL,
+    "min_quantity" bigint NOT NULL DEFAULT 1,
+    "price" decimal NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_price_lists_items" FOREIGN KEY ("price_list_id") REFERENCES "price_lists"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_price_list_items_product_id" ON "price_list_items" ("product_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_price_list_item" ON "price_list_items" ("price_list_id","product_id","min_quantity");
+CREATE INDEX IF NOT EXISTS "idx_price_list_items_deleted_at" ON "price_list_items" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "quotes" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'requested',
+    "note" text,
+    "country" varchar(2),
+    "response_note" text,
+    "valid_until" timestamptz,
+    "responded_by" bigint,
+    "responded_at" timestamptz,
+    "decided_at" timestamptz,
+    "custom_fields" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_quotes_user_id" ON "quotes" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_quotes_deleted_at" ON "quotes" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_quotes_country" ON "quotes" ("country");
+CREATE INDEX IF NOT EXISTS "idx_quotes_status" ON "quotes" ("status");
+
+CREATE TABLE IF NOT EXISTS "quote_items" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "quote_id" bigint NOT NULL,
+    "product_id" bigint NOT NULL,
+    "quantity" bigint NOT NULL,
+    "unit_price" decimal NOT NULL,
+    "pricing_rule_id" bigint,
+    "quoted_price" decimal,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_quote_items_product" FOREIGN KEY ("product_id") REFERENCES "products"("id"),
+    CONSTRAINT "fk_quotes_items" FOREIGN KEY ("quote_id") REFERENCES "quotes"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_quote_items_pricing_rule_id" ON "quote_items" ("pricing_rule_id");
+CREATE INDEX IF NOT EXISTS "idx_quote_items_quote_id" ON "quote_items" ("quote_id");
+CREATE INDEX IF NOT EXISTS "idx_quote_items_deleted_at" ON "quote_items" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "suppliers" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(100) NOT NULL,
+    "contact_name" varchar(100),
+    "email" varchar(255),
+    "phone" varchar(30),
+    "notes" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_suppliers_deleted_at" ON "suppliers" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_suppliers_name" ON "suppliers" ("name");
+
+CREATE TABLE IF NOT EXISTS "purchase_orders" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "supplier_id" bigint NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'open',
+    "expected_at" timestamptz,
+    "notes" text,
+    "created_by" bigint NOT NULL,
+    "closed_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_purchase_orders_supplier" FOREIGN KEY ("supplier_id") REFERENCES "suppliers"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_purchase_orders_supplier_id" ON "purchase_orders" ("supplier_id");
+CREATE INDEX IF NOT EXISTS "idx_purchase_orders_deleted_at" ON "purchase_orders" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_purchase_orders_status" ON "purchase_orders" ("status");
+
+CREATE TABLE IF NOT EXISTS "purchase_order_items" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "purchase_order_id" bigint NOT NULL,
+    "product_id" bigint NOT NULL,
+    "quantity_ordered" bigint NOT NULL,
+    "quantity_received" bigint NOT NULL DEFAULT 0,
+    "unit_cost" decimal NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_purchase_order_items_product" FOREIGN KEY ("product_id") REFERENCES "products"("id"),
+    CONSTRAINT "fk_purchase_orders_items" FOREIGN KEY ("purchase_order_id") REFERENCES "purchase_orders"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_purchase_order_items_product_id" ON "purchase_order_items" ("product_id");
+CREATE INDEX IF NOT EXISTS "idx_purchase_order_items_purchase_order_id" ON "purchase_order_items" ("purchase_order_id");
+CREATE INDEX IF NOT EXISTS "idx_purchase_order_items_deleted_at" ON "purchase_order_items" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "featured_products" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "product_id" bigint NOT NULL,
+    "position" bigint NOT NULL DEFAULT 0,
+    "starts_at" timestamptz,
+    "ends_at" timestamptz,
+    "created_by" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_featured_products_position" ON "featured_products" ("position");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_featured_products_product_id" ON "featured_products" ("product_id");
+CREATE INDEX IF NOT EXISTS "idx_featured_products_deleted_at" ON "featured_products" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "content_blocks" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "key" varchar(100) NOT NULL,
+    "title" varchar(200),
+    "body" text,
+    "image_url" varchar(500),
+    "starts_at" timestamptz,
+    "ends_at" timestamptz,
+    "updated_by" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_content_blocks_key" ON "content_blocks" ("key");
+CREATE INDEX IF NOT EXISTS "idx_content_blocks_deleted_at" ON "content_blocks" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "connector_sync_runs" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "connector" varchar(50) NOT NULL,
+    "triggered_by" bigint,
+    "status" varchar(20) NOT NULL DEFAULT 'running',
+    "started_at" timestamptz NOT NULL,
+    "finished_at" timestamptz,
+    "products_pulled" bigint NOT NULL DEFAULT 0,
+    "products_created" bigint NOT NULL DEFAULT 0,
+    "products_updated" bigint NOT NULL DEFAULT 0,
+    "stock_pulled" bigint NOT NULL DEFAULT 0,
+    "stock_updated" bigint NOT NULL DEFAULT 0,
+    "skipped" bigint NOT NULL DEFAULT 0,
+    "orders_pushed" bigint NOT NULL DEFAULT 0,
+    "orders_through" timestamptz,
+    "error" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_connector_sync_runs_started_at" ON "connector_sync_runs" ("started_at");
+CREATE INDEX IF NOT EXISTS "idx_connector_sync_runs_connector" ON "connector_sync_runs" ("connector");
+CREATE INDEX IF NOT EXISTS "idx_connector_sync_runs_deleted_at" ON "connector_sync_runs" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "outbox_events" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "topic" varchar(100) NOT NULL,
+    "payload" text NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'pending',
+    "next_attempt_at" timestamptz NOT NULL,
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "last_error" text,
+    "published_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_due" ON "outbox_events" ("status","next_attempt_at");
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_topic" ON "outbox_events" ("topic");
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_deleted_at" ON "outbox_events" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "sagas" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "type" varchar(50) NOT NULL,
+    "reference" text,
+    "status" varchar(20) NOT NULL DEFAULT 'running',
+    "data" text NOT NULL,
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamptz,
+    "error" text,
+    "finished_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_sagas_reference" ON "sagas" ("reference");
+CREATE INDEX IF NOT EXISTS "idx_sagas_type" ON "sagas" ("type");
+CREATE INDEX IF NOT EXISTS "idx_sagas_deleted_at" ON "sagas" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_sagas_due" ON "sagas" ("status","next_attempt_at");
+
+CREATE TABLE IF NOT EXISTS "saga_steps" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "saga_id" bigint NOT NULL,
+    "position" bigint NOT NULL,
+    "name" varchar(50) NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'pending',
+    "error" text,
+    "completed_at" timestamptz,
+    "compensated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_sagas_steps" FOREIGN KEY ("saga_id") REFERENCES "sagas"("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_saga_steps_position" ON "saga_steps" ("saga_id","position");
+CREATE INDEX IF NOT EXISTS "idx_saga_steps_deleted_at" ON "saga_steps" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "sync_changes" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "entity_type" varchar(20) NOT NULL,
+    "entity_id" bigint NOT NULL,
+    "user_id" bigint,
+    "operation" varchar(20) NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_sync_changes_user_id" ON "sync_changes" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_sync_changes_deleted_at" ON "sync_changes" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "settings" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "key" varchar(100) NOT NULL,
+    "value" text NOT NULL,
+    "updated_by" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_settings_key" ON "settings" ("key");
+CREATE INDEX IF NOT EXISTS "idx_settings_deleted_at" ON "settings" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "pricing_rules" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(100) NOT NULL,
+    "description" text,
+    "priority" bigint NOT NULL DEFAULT 0,
+    "enabled" boolean NOT NULL,
+    "starts_at" timestamptz,
+    "ends_at" timestamptz,
+    "category_id" bigint,
+    "min_stock" bigint,
+    "no_sales_days" bigint,
+    "adjustment" varchar(20) NOT NULL,
+    "value" decimal NOT NULL,
+    "updated_by" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_pricing_rules_category_id" ON "pricing_rules" ("category_id");
+CREATE INDEX IF NOT EXISTS "idx_pricing_rules_deleted_at" ON "pricing_rules" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "promotions" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(100) NOT NULL,
+    "product_id" bigint,
+    "category_id" bigint,
+    "discount" varchar(20) NOT NULL,
+    "value" decimal NOT NULL,
+    "starts_at" timestamptz NOT NULL,
+    "ends_at" timestamptz NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'scheduled',
+    "updated_by" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_promotions_product_id" ON "promotions" ("product_id");
+CREATE INDEX IF NOT EXISTS "idx_promotions_deleted_at" ON "promotions" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_promotions_status" ON "promotions" ("status");
+CREATE INDEX IF NOT EXISTS "idx_promotions_ends_at" ON "promotions" ("ends_at");
+CREATE INDEX IF NOT EXISTS "idx_promotions_starts_at" ON "promotions" ("starts_at");
+CREATE INDEX IF NOT EXISTS "idx_promotions_category_id" ON "promotions" ("category_id");
+
+CREATE TABLE IF NOT EXISTS "refresh_tokens" (
+    "id" bigserial,
+    "token_id" varchar(32) NOT NULL,
+    "user_id" bigint NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "used_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_refresh_tokens_token_id" ON "refresh_tokens" ("token_id");
+CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_expires_at" ON "refresh_tokens" ("expires_at");
+CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_user_id" ON "refresh_tokens" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "revoked_tokens" (
+    "id" bigserial,
+    "token_id" varchar(32) NOT NULL,
+    "user_id" bigint NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_revoked_tokens_expires_at" ON "revoked_tokens" ("expires_at");
+CREATE INDEX IF NOT EXISTS "idx_revoked_tokens_user_id" ON "revoked_tokens" ("user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_revoked_tokens_token_id" ON "revoked_tokens" ("token_id");
+
+CREATE TABLE IF NOT EXISTS "warehouse_events" (
+    "id" bigserial,
+    "kind" varchar(30) NOT NULL,
+    "payload" text NOT NULL,
+    "occurred_at" timestamptz NOT NULL,
+    "exported_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_warehouse_events_exported_at" ON "warehouse_events" ("exported_at");
+
+CREATE TABLE IF NOT EXISTS "product_views" (
+    "id" bigserial,
+    "product_id" bigint NOT NULL,
+    "user_id" bigint,
+    "anonymous_id" varchar(64),
+    "referrer" varchar(500),
+    "viewed_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_product_views_product_id" ON "product_views" ("product_id");
+CREATE INDEX IF NOT EXISTS "idx_product_views_viewed_at" ON "product_views" ("viewed_at");
+CREATE INDEX IF NOT EXISTS "idx_product_views_user_id" ON "product_views" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "experiments" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "key" varchar(100) NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "description" text,
+    "traffic" bigint NOT NULL DEFAULT 100,
+    "status" varchar(20) NOT NULL DEFAULT 'draft',
+    "started_at" timestamptz,
+    "stopped_at" timestamptz,
+    "updated_by" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_experiments_status" ON "experiments" ("status");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_experiments_key" ON "experiments" ("key");
+CREATE INDEX IF NOT EXISTS "idx_experiments_deleted_at" ON "experiments" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "experiment_variants" (
+    "id" bigserial,
+    "experiment_id" bigint NOT NULL,
+    "key" varchar(50) NOT NULL,
+    "weight" bigint NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_experiments_variants" FOREIGN KEY ("experiment_id") REFERENCES "experiments"("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_experiment_variant" ON "experiment_variants" ("experiment_id","key");
+
+CREATE TABLE IF NOT EXISTS "experiment_events" (
+    "id" bigserial,
+    "experiment_id" bigint NOT NULL,
+    "variant" varchar(50) NOT NULL,
+    "subject" varchar(80) NOT NULL,
+    "kind" varchar(20) NOT NULL,
+    "goal" varchar(50),
+    "value" decimal NOT NULL DEFAULT 0,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_experiment_events_created_at" ON "experiment_events" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_experiment_event" ON "experiment_events" ("experiment_id","subject");
+
+CREATE TABLE IF NOT EXISTS "product_images" (
+    "id" bigserial,
+    "product_id" bigint NOT NULL,
+    "key" varchar(255) NOT NULL,
+    "content_type" varchar(50) NOT NULL,
+    "size" bigint NOT NULL,
+    "alt" varchar(255),
+    "position" bigint NOT NULL DEFAULT 0,
+    "uploaded_by" bigint NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_products_images" FOREIGN KEY ("product_id") REFERENCES "products"("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_product_images_key" ON "product_images" ("key");
+CREATE INDEX IF NOT EXISTS "idx_product_images_product_id" ON "product_images" ("product_id");
+
+CREATE TABLE IF NOT EXISTS "custom_fields" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "form" varchar(20) NOT NULL,
+    "key" varchar(50) NOT NULL,
+    "label" varchar(100) NOT NULL,
+    "help_text" varchar(255),
+    "type" varchar(20) NOT NULL,
+    "required" boolean NOT NULL DEFAULT false,
+    "options" jsonb,
+    "pattern" varchar(255),
+    "min_length" bigint,
+    "max_length" bigint,
+    "min" decimal,
+    "max" decimal,
+    "position" bigint NOT NULL DEFAULT 0,
+    "updated_by" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_custom_fields_key" ON "custom_fields" ("form","key");
+CREATE INDEX IF NOT EXISTS "idx_custom_fields_deleted_at" ON "custom_fields" ("deleted_at");
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "rebuild_runs" CASCADE;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS "rebuild_runs" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "target" varchar(20) NOT NULL,
+    "triggered_by" bigint,
+    "status" varchar(20) NOT NULL DEFAULT 'running',
+    "started_at" timestamptz NOT NULL,
+    "finished_at" timestamptz,
+    "total" bigint NOT NULL DEFAULT 0,
+    "processed" bigint NOT NULL DEFAULT 0,
+    "changed" bigint NOT NULL DEFAULT 0,
+    "error" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_rebuild_runs_started_at" ON "rebuild_runs" ("started_at");
+CREATE INDEX IF NOT EXISTS "idx_rebuild_runs_target" ON "rebuild_runs" ("target");
+CREATE INDEX IF NOT EXISTS "idx_rebuild_runs_deleted_at" ON "rebuild_runs" ("deleted_at");
@@ -0,0 +1,5 @@
+-- Product counts of categories, kept up to date with product_categories
+ALTER TABLE "categories" ADD COLUMN IF NOT EXISTS "products_count" bigint NOT NULL DEFAULT 0;
+UPDATE "categories" c SET "products_count" = (
+    SELECT COUNT(*) FROM "product_categories" pc WHERE pc."category_id" = c."id"
+);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "shipments" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "quote_id" bigint NOT NULL,
+    "carrier" varchar(50) NOT NULL,
+    "tracking_number" varchar(100) NOT NULL,
+    "tracking_url" varchar(500),
+    "shipped_at" timestamptz NOT NULL,
+    "recorded_by" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_shipments_quote_id" ON "shipments" ("quote_id");
+CREATE INDEX IF NOT EXISTS "idx_shipments_deleted_at" ON "shipments" ("deleted_at");
@@ -0,0 +1,4 @@
+ALTER TABLE "quote_items" DROP COLUMN IF EXISTS "gift_wrap_fee";
+ALTER TABLE "quote_items" DROP COLUMN IF EXISTS "hide_price";
+ALTER TABLE "quote_items" DROP COLUMN IF EXISTS "gift_message";
+ALTER TABLE "quote_items" DROP COLUMN IF EXISTS "gift_wrap";
@@ -0,0 +1,5 @@
+-- Gift options of quote items, chosen when the quote is accepted
+ALTER TABLE "quote_items" ADD COLUMN IF NOT EXISTS "gift_wrap" boolean NOT NULL DEFAULT false;
+ALTER TABLE "quote_items" ADD COLUMN IF NOT EXISTS "gift_message" varchar(500);
+ALTER TABLE "quote_items" ADD COLUMN IF NOT EXISTS "hide_price" boolean NOT NULL DEFAULT false;
+ALTER TABLE "quote_items" ADD COLUMN IF NOT EXISTS "gift_wrap_fee" decimal NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "shipment_items";
+DROP INDEX IF EXISTS "idx_products_location";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "location";
@@ -0,0 +1,29 @@
+-- Warehouse location of products, which pick lists are grouped by
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "location" varchar(50);
+CREATE INDEX IF NOT EXISTS "idx_products_location" ON "products" ("location");
+
+-- What each shipment contains, for its packing slip and the units left to pick
+CREATE TABLE IF NOT EXISTS "shipment_items" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "shipment_id" bigint NOT NULL,
+    "quote_item_id" bigint NOT NULL,
+    "quantity" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_shipment_items_shipment_id" ON "shipment_items" ("shipment_id");
+CREATE INDEX IF NOT EXISTS "idx_shipment_items_quote_item_id" ON "shipment_items" ("quote_item_id");
+CREATE INDEX IF NOT EXISTS "idx_shipment_items_deleted_at" ON "shipment_items" ("deleted_at");
+
+-- Shipments recorded before their contents were tracked are taken to hold the
+-- whole order, in the first shipment of each
+INSERT INTO "shipment_items" ("created_at", "updated_at", "shipment_id", "quote_item_id", "quantity")
+SELECT now(), now(), s."id", qi."id", qi."quantity"
+FROM (
+    SELECT DISTINCT ON ("quote_id") "id", "quote_id" FROM "shipments"
+    WHERE "deleted_at" IS NULL
+    ORDER BY "quote_id", "shipped_at", "id"
+) s
+JOIN "quote_items" qi ON qi."quote_id" = s."quote_id" AND qi."deleted_at" IS NULL;
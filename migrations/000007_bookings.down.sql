@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "bookings";
+DROP TABLE IF EXISTS "availability_slots";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "bookable";
@@ -0,0 +1,36 @@
+-- Booking mode of products, reserved for availability slots
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "bookable" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS "availability_slots" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "product_id" bigint NOT NULL,
+    "starts_at" timestamptz NOT NULL,
+    "ends_at" timestamptz NOT NULL,
+    "capacity" bigint NOT NULL,
+    "booked" bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_availability_slots_booked" CHECK ("booked" >= 0 AND "booked" <= "capacity")
+);
+CREATE INDEX IF NOT EXISTS "idx_availability_slots_product_starts" ON "availability_slots" ("product_id", "starts_at");
+CREATE INDEX IF NOT EXISTS "idx_availability_slots_deleted_at" ON "availability_slots" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "bookings" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "slot_id" bigint NOT NULL,
+    "product_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "quantity" bigint NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'confirmed',
+    "cancelled_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_bookings_slot_id" ON "bookings" ("slot_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_product_id" ON "bookings" ("product_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_user_id" ON "bookings" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_deleted_at" ON "bookings" ("deleted_at");
@@ -0,0 +1,29 @@
+-- Ledger of gift card balance changes
+CREATE TABLE IF NOT EXISTS "gift_card_entries" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "gift_card_id" bigint NOT NULL,
+    "kind" varchar(20) NOT NULL,
+    "reference" text,
+    "delta" decimal NOT NULL,
+    "resulting_balance" decimal NOT NULL,
+    "actor_id" bigint,
+    "note" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_gift_card_entries_gift_card_id" ON "gift_card_entries" ("gift_card_id");
+CREATE INDEX IF NOT EXISTS "idx_gift_card_entries_reference" ON "gift_card_entries" ("reference");
+CREATE INDEX IF NOT EXISTS "idx_gift_card_entries_deleted_at" ON "gift_card_entries" ("deleted_at");
+
+-- Cards issued before the ledger start it with their issue
+INSERT INTO "gift_card_entries" ("created_at", "updated_at", "gift_card_id", "kind", "delta", "resulting_balance", "actor_id")
+SELECT "created_at", "created_at", "id", 'issue', "initial_balance", "initial_balance", "issued_by"
+FROM "gift_cards"
+WHERE NOT EXISTS (SELECT 1 FROM "gift_card_entries" WHERE "gift_card_entries"."gift_card_id" = "gift_cards"."id");
+INSERT INTO "gift_card_entries" ("created_at", "updated_at", "gift_card_id", "kind", "delta", "resulting_balance", "note")
+SELECT "updated_at", "updated_at", "id", 'redeem', "balance" - "initial_balance", "balance", 'redeemed before the ledger'
+FROM "gift_cards"
+WHERE "balance" <> "initial_balance"
+  AND NOT EXISTS (SELECT 1 FROM "gift_card_entries" WHERE "gift_card_entries"."gift_card_id" = "gift_cards"."id" AND "kind" = 'redeem');
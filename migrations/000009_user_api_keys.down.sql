@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_users_api_key_name";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "api_key_name";
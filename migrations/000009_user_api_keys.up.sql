@@ -0,0 +1,3 @@
+-- Customer account an integration API key acts for, which scopes its webhooks
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "api_key_name" varchar(100);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_api_key_name" ON "users" ("api_key_name");
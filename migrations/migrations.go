@@ -0,0 +1,11 @@
+// Package migrations embeds the versioned SQL migrations of the schema, so the
+// server and cmd/migrate carry them. pkg/database applies them with
+// golang-migrate.
+package migrations
+
+import "embed"
+
+// Files holds the up and down files of every migration
+//
+//go:embed *.sql
+var Files embed.FS
@@ -0,0 +1,127 @@
+// Package barcode encodes text as Code 128 barcodes and QR codes and renders
+// them as PNG or SVG images for label printing.
+package barcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	boombuler "github.com/boombuler/barcode"
+)
+
+var (
+	// ErrInvalidCharacter is returned when the text contains a character the
+	// symbology cannot encode
+	ErrInvalidCharacter = errors.New("text contains characters that cannot be encoded")
+	// ErrTooLong is returned when the text does not fit in the largest supported symbol
+	ErrTooLong = errors.New("text is too long to encode")
+)
+
+// linearHeight is the bar height of linear barcodes, in modules
+const linearHeight = 50
+
+// Symbol is an encoded barcode as a grid of modules, true being dark. Linear
+// barcodes have a single row that is stretched to the bar height when rendered.
+type Symbol struct {
+	Modules   [][]bool
+	QuietZone int // Light modules required on every side
+}
+
+// modules reads the modules of an encoded barcode, one row per pixel row
+func modules(code boombuler.Barcode) [][]bool {
+	bounds := code.Bounds()
+	rows := make([][]bool, bounds.Dy())
+	for y := range rows {
+		rows[y] = make([]bool, bounds.Dx())
+		for x := range rows[y] {
+			gray := color.GrayModel.Convert(code.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray)
+			rows[y][x] = gray.Y < 0x80
+		}
+	}
+	return rows
+}
+
+// width and height return the size of the symbol in modules, including the quiet zone
+func (s *Symbol) width() int {
+	return len(s.Modules[0]) + 2*s.QuietZone
+}
+
+func (s *Symbol) height() int {
+	if len(s.Modules) == 1 {
+		return linearHeight + 2*s.QuietZone
+	}
+	return len(s.Modules) + 2*s.QuietZone
+}
+
+// dark reports whether the module at x, y of the rendered image, quiet zone
+// included, is dark
+func (s *Symbol) dark(x, y int) bool {
+	x -= s.QuietZone
+	y -= s.QuietZone
+	if len(s.Modules) == 1 {
+		if y < 0 || y >= linearHeight {
+			return false
+		}
+		y = 0
+	}
+	if y < 0 || y >= len(s.Modules) || x < 0 || x >= len(s.Modules[y]) {
+		return false
+	}
+	return s.Modules[y][x]
+}
+
+// PNG renders the symbol with each module scale pixels wide
+func (s *Symbol) PNG(scale int) ([]byte, error) {
+	width, height := s.width(), s.height()
+	img := image.NewPaletted(image.Rect(0, 0, width*scale, height*scale), color.Palette{color.White, color.Black})
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if !s.dark(x, y) {
+				continue
+			}
+			for py := y * scale; py < (y+1)*scale; py++ {
+				row := img.Pix[py*img.Stride:]
+				for px := x * scale; px < (x+1)*scale; px++ {
+					row[px] = 1
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG renders the symbol with each module scale user units wide. Runs of dark
+// modules in a row are drawn as a single rectangle.
+func (s *Symbol) SVG(scale int) []byte {
+	width, height := s.width(), s.height()
+	var path strings.Builder
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; {
+			if !s.dark(x, y) {
+				x++
+				continue
+			}
+			start := x
+			for x < width && s.dark(x, y) {
+				x++
+			}
+			fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", start, y, x-start, x-start)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		width*scale, height*scale, width, height)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#fff"/><path d="%s" fill="#000"/></svg>`, width, height, path.String())
+	return buf.Bytes()
+}
@@ -0,0 +1,26 @@
+package barcode
+
+import (
+	"fmt"
+
+	"github.com/boombuler/barcode/code128"
+)
+
+// Code128 encodes printable ASCII text as a Code 128 barcode. Runs of digits
+// are packed two per symbol using code set C.
+func Code128(text string) (*Symbol, error) {
+	if text == "" {
+		return nil, ErrInvalidCharacter
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] < ' ' || text[i] > '~' {
+			return nil, ErrInvalidCharacter
+		}
+	}
+
+	code, err := code128.Encode(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCharacter, err)
+	}
+	return &Symbol{Modules: modules(code), QuietZone: 10}, nil
+}
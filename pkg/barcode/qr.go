@@ -0,0 +1,17 @@
+package barcode
+
+import (
+	"fmt"
+
+	"github.com/boombuler/barcode/qr"
+)
+
+// QR encodes text as a QR code with error correction level M, using the
+// smallest version and the most compact mode it fits in
+func QR(text string) (*Symbol, error) {
+	code, err := qr.Encode(text, qr.M, qr.Auto)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTooLong, err)
+	}
+	return &Symbol{Modules: modules(code), QuietZone: 4}, nil
+}
@@ -0,0 +1,111 @@
+// Package botguard tells bots from people submitting public forms: a honeypot
+// field people never see, a signed form token proving the form stayed open for
+// a plausible time, and an optional CAPTCHA.
+package botguard
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Checks a route can require
+const (
+	CheckHoneypot = "honeypot" // The honeypot field must be left empty
+	CheckTiming   = "timing"   // A form token must be sent, neither too fresh nor too old
+	CheckCaptcha  = "captcha"  // A CAPTCHA response must be sent and pass verification
+)
+
+// Request fields carrying the form token and CAPTCHA response. Like the
+// honeypot field, they are removed from the body before it is bound.
+const (
+	FormTokenField = "form_token"
+	CaptchaField   = "captcha_token"
+)
+
+// IsCheck reports whether name is a known check
+func IsCheck(name string) bool {
+	switch name {
+	case CheckHoneypot, CheckTiming, CheckCaptcha:
+		return true
+	}
+	return false
+}
+
+// Guard holds the checks of each route and what they need
+type Guard struct {
+	Routes         map[string][]string // Checks by route name
+	HoneypotField  string
+	Tokens         *FormTokens
+	Captcha        Captcha // Nil when no CAPTCHA provider is configured
+	CaptchaSiteKey string  // Site key clients render the CAPTCHA widget with
+}
+
+// Default is the guard of the public forms, nil when not configured
+var Default *Guard
+
+// Checks returns the checks of a route
+func (g *Guard) Checks(route string) []string {
+	if g == nil {
+		return nil
+	}
+	return g.Routes[route]
+}
+
+// Errors returned for form tokens. They are logged as bot signals and never
+// shown to the client.
+var (
+	ErrInvalidFormToken = errors.New("invalid form token")
+	ErrFormTooFast      = errors.New("form submitted too fast")
+	ErrFormExpired      = errors.New("form token expired")
+)
+
+// FormTokens issues and checks the tokens a form is fetched with, which record
+// when it was opened
+type FormTokens struct {
+	key    []byte
+	minAge time.Duration
+	maxAge time.Duration
+}
+
+// NewFormTokens creates form tokens signed with key, accepted between minAge
+// and maxAge after they were issued
+func NewFormTokens(key string, minAge, maxAge time.Duration) *FormTokens {
+	return &FormTokens{key: []byte(key), minAge: minAge, maxAge: maxAge}
+}
+
+// Issue returns a token recording the current time
+func (t *FormTokens) Issue() string {
+	issuedAt := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	return issuedAt + "." + t.sign(issuedAt)
+}
+
+// Check verifies a token and its age
+func (t *FormTokens) Check(token string) error {
+	issuedAt, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(t.sign(issuedAt))) {
+		return ErrInvalidFormToken
+	}
+	millis, err := strconv.ParseInt(issuedAt, 10, 64)
+	if err != nil {
+		return ErrInvalidFormToken
+	}
+	age := time.Since(time.UnixMilli(millis))
+	if age < t.minAge {
+		return ErrFormTooFast
+	}
+	if age > t.maxAge {
+		return ErrFormExpired
+	}
+	return nil
+}
+
+func (t *FormTokens) sign(issuedAt string) string {
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write([]byte("form-token:" + issuedAt))
+	return hex.EncodeToString(mac.Sum(nil))
+}
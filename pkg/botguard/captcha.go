@@ -0,0 +1,91 @@
+package botguard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrCaptchaFailed is returned when the provider rejects a CAPTCHA response
+var ErrCaptchaFailed = errors.New("captcha verification failed")
+
+// Captcha verifies the response token a CAPTCHA widget produced in the browser
+type Captcha interface {
+	// Verify returns ErrCaptchaFailed when the response is rejected, and
+	// another error when the provider could not be asked
+	Verify(ctx context.Context, response, remoteIP string) error
+}
+
+// Verification endpoints of the supported providers
+const (
+	hCaptchaURL  = "https://api.hcaptcha.com/siteverify"
+	turnstileURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+)
+
+// NewCaptcha creates the verifier of a CAPTCHA provider ("hcaptcha" or
+// "turnstile"). It returns nil when no provider is configured.
+func NewCaptcha(provider, secret string) (Captcha, error) {
+	switch provider {
+	case "", "none":
+		return nil, nil
+	case "hcaptcha":
+		return &SiteVerify{client: &http.Client{Timeout: 10 * time.Second}, url: hCaptchaURL, secret: secret}, nil
+	case "turnstile":
+		return &SiteVerify{client: &http.Client{Timeout: 10 * time.Second}, url: turnstileURL, secret: secret}, nil
+	default:
+		return nil, fmt.Errorf("unknown CAPTCHA provider %q", provider)
+	}
+}
+
+// SiteVerify verifies responses with a siteverify endpoint, the API hCaptcha
+// and Cloudflare Turnstile share
+type SiteVerify struct {
+	client *http.Client
+	url    string
+	secret string
+}
+
+// Verify posts the response to the provider and reads its verdict
+func (v *SiteVerify) Verify(ctx context.Context, response, remoteIP string) error {
+	form := url.Values{
+		"secret":   {v.secret},
+		"response": {response},
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("captcha verification returned %s", resp.Status)
+	}
+
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if !result.Success {
+		if len(result.ErrorCodes) > 0 {
+			return fmt.Errorf("%w: %s", ErrCaptchaFailed, strings.Join(result.ErrorCodes, ", "))
+		}
+		return ErrCaptchaFailed
+	}
+	return nil
+}
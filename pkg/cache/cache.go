@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Cache stores JSON-serializable values by key with a time-to-live
+type Cache interface {
+	// Get decodes the cached value into dest and reports whether the key was found
+	Get(key string, dest interface{}) bool
+	// Set stores a value for the given duration
+	Set(key string, value interface{}, ttl time.Duration)
+	// Delete removes the given keys
+	Delete(keys ...string)
+}
+
+// Store is the global cache instance. It only caches within this process
+// until the server replaces it with a shared backend.
+var Store Cache = NewMemoryCache(DefaultMemoryEntries)
+
+// New creates the cache of a backend: memory (this process only, holding up to
+// memoryEntries entries) or redis (at redisAddr, shared by every instance using it)
+func New(backend string, memoryEntries int, redisAddr, redisPassword string, redisDB int, prefix string) (Cache, error) {
+	switch backend {
+	case "memory":
+		return NewMemoryCache(memoryEntries), nil
+	case "redis":
+		if redisAddr == "" {
+			return nil, errors.New("the redis cache backend needs CACHE_REDIS_ADDR")
+		}
+		return NewRedisCache(redisAddr, redisPassword, redisDB, prefix), nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q", backend)
+	}
+}
+
+// TTL is the default lifetime of cached entries
+var TTL = 5 * time.Minute
+
+// Cache keys
+const (
+	CategoriesKey       = "categories:all"
+	FeaturedProductsKey = "featured_products:all"
+	SettingsKey         = "settings:all"
+	PricingRulesKey     = "pricing_rules:enabled"
+	PromotionsKey       = "promotions:active"
+	ExperimentsKey      = "experiments:running"
+
+	// ProductListGenerationKey holds the generation product listings are
+	// cached under. Replacing it makes every cached listing unreachable at
+	// once, since listings can't be enumerated to delete them.
+	ProductListGenerationKey = "products:list_generation"
+)
+
+// TokenVersionKey returns the cache key of a user's token version
+func TokenVersionKey(userID uint) string {
+	return fmt.Sprintf("user:%d:token_version", userID)
+}
+
+// RevokedTokenKey returns the cache key of whether an access token was revoked
+func RevokedTokenKey(tokenID string) string {
+	return "revoked_token:" + tokenID
+}
+
+// TrendingProductsKey returns the cache key of the products ranked by views
+// over the last days
+func TrendingProductsKey(days int) string {
+	return fmt.Sprintf("trending_products:%d", days)
+}
+
+// AlsoViewedKey returns the cache key of the products ranked by how many
+// viewers of a product viewed them over the last days
+func AlsoViewedKey(productID uint, days int) string {
+	return fmt.Sprintf("product:%d:also_viewed:%d", productID, days)
+}
+
+// UserPriceListKey returns the cache key of the price list assigned to a user
+func UserPriceListKey(userID uint) string {
+	return fmt.Sprintf("user:%d:price_list", userID)
+}
+
+// UserPreferencesKey returns the cache key of a user's locale, currency and
+// time zone preferences
+func UserPreferencesKey(userID uint) string {
+	return fmt.Sprintf("user:%d:preferences", userID)
+}
+
+// PriceListKey returns the cache key of the items of a price list
+func PriceListKey(id uint) string {
+	return fmt.Sprintf("price_list:%d", id)
+}
+
+// ProductKey returns the cache key of a single product
+func ProductKey(id uint) string {
+	return fmt.Sprintf("product:%d", id)
+}
+
+// ProductListKey returns the cache key of a page of products in a listing
+// generation, where query identifies the filters, sort and page
+func ProductListKey(generation int64, query string) string {
+	return fmt.Sprintf("products:list:%d:%s", generation, query)
+}
+
+// ContentBlockKey returns the cache key of a content block by its key
+func ContentBlockKey(key string) string {
+	return "content_block:" + key
+}
+
+// FormSchemaKey returns the cache key of the custom fields of a form
+func FormSchemaKey(form string) string {
+	return "form_schema:" + form
+}
+
+// IPBlockKey returns the cache key of whether an IP address is blocked
+func IPBlockKey(ip string) string {
+	return "ip_block:" + ip
+}
+
+// MarketPricesKey returns the cache key of the competitor prices a provider
+// returned for a SKU
+func MarketPricesKey(provider, sku string) string {
+	return "market_prices:" + provider + ":" + sku
+}
@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+)
+
+// DefaultMemoryEntries is how many entries a MemoryCache holds when created
+// without a valid size
+const DefaultMemoryEntries = 10000
+
+type memoryEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process Cache implementation holding a bounded number
+// of entries. Once full, storing an entry evicts the least recently used one,
+// and expired entries are deleted when read, so memory stays bounded however
+// many keys are written.
+type MemoryCache struct {
+	mu      sync.Mutex // Reads also update the recency of entries
+	entries *simplelru.LRU[string, memoryEntry]
+}
+
+// NewMemoryCache creates an empty in-memory cache of at most maxEntries
+// entries, or DefaultMemoryEntries when maxEntries isn't positive
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMemoryEntries
+	}
+	entries, err := simplelru.NewLRU[string, memoryEntry](maxEntries, nil)
+	if err != nil {
+		// Only returned for a size that isn't positive
+		panic(err)
+	}
+	return &MemoryCache{entries: entries}
+}
+
+// Get decodes the cached value into dest and reports whether the key was found
+func (c *MemoryCache) Get(key string, dest interface{}) bool {
+	c.mu.Lock()
+	entry, ok := c.entries.Get(key)
+	if ok && time.Now().After(entry.expiresAt) {
+		c.entries.Remove(key)
+		ok = false
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(entry.data, dest); err != nil {
+		log.Printf("Warning: failed to decode cache entry %s: %v", key, err)
+		return false
+	}
+	return true
+}
+
+// Set stores a value for the given duration
+func (c *MemoryCache) Set(key string, value interface{}, ttl time.Duration) {
+	// Values are stored serialized so callers can't mutate cached data
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("Warning: failed to encode cache entry %s: %v", key, err)
+		return
+	}
+
+	c.mu.Lock()
+	c.entries.Add(key, memoryEntry{data: data, expiresAt: time.Now().Add(ttl)})
+	c.mu.Unlock()
+}
+
+// Delete removes the given keys
+func (c *MemoryCache) Delete(keys ...string) {
+	c.mu.Lock()
+	for _, key := range keys {
+		c.entries.Remove(key)
+	}
+	c.mu.Unlock()
+}
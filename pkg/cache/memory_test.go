@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+// TestMemoryCacheDeletesExpired checks that reading an expired entry deletes it
+func TestMemoryCacheDeletesExpired(t *testing.T) {
+	c := NewMemoryCache(10)
+	c.Set("key", 1, -time.Second)
+
+	var dest int
+	if c.Get("key", &dest) {
+		t.Errorf("Get found expired value %d", dest)
+	}
+	if n := c.entries.Len(); n != 0 {
+		t.Errorf("%d entries left after reading an expired one, want 0", n)
+	}
+}
+
+// TestMemoryCacheEvictsLeastRecentlyUsed checks that a full cache evicts the
+// entry read least recently
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewMemoryCache(2)
+	c.Set("a", 1, time.Minute)
+	c.Set("b", 2, time.Minute)
+	var dest int
+	c.Get("a", &dest)
+	c.Set("c", 3, time.Minute)
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if got := c.Get(key, &dest); got != want {
+			t.Errorf("Get(%q) found = %v, want %v", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache is a Cache shared by every instance using the same Redis server,
+// so a write on one instance invalidates the entry for all of them. Values are
+// stored as JSON with an expiry. Redis failures are logged and read as misses,
+// so the service keeps working from the database while Redis is down.
+type RedisCache struct {
+	client *redis.Client
+	prefix string // Prepended to every key, so environments can share a server
+}
+
+// NewRedisCache creates a cache on the Redis server at addr, e.g.
+// localhost:6379, in database db, with every key prefixed by prefix. Commands
+// use the client's default timeouts of 3s to send a command and 3s to read its
+// reply, which leave room for the largest listing pages.
+func NewRedisCache(addr, password string, db int, prefix string) *RedisCache {
+	return &RedisCache{
+		client: redis.NewClient(&redis.Options{
+			Addr:     addr,
+			Password: password,
+			DB:       db,
+		}),
+		prefix: prefix,
+	}
+}
+
+// Get decodes the cached value into dest and reports whether the key was found
+func (c *RedisCache) Get(key string, dest interface{}) bool {
+	data, err := c.client.Get(context.Background(), c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return false
+	}
+	if err != nil {
+		log.Printf("Warning: failed to read cache entry %s: %v", key, err)
+		return false
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		log.Printf("Warning: failed to decode cache entry %s: %v", key, err)
+		return false
+	}
+	return true
+}
+
+// Set stores a value for the given duration
+func (c *RedisCache) Set(key string, value interface{}, ttl time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("Warning: failed to encode cache entry %s: %v", key, err)
+		return
+	}
+	if ttl < time.Millisecond {
+		ttl = time.Millisecond
+	}
+	if err := c.client.Set(context.Background(), c.prefix+key, data, ttl).Err(); err != nil {
+		log.Printf("Warning: failed to write cache entry %s: %v", key, err)
+	}
+}
+
+// Delete removes the given keys. A failure leaves them cached until they
+// expire, so it is logged.
+func (c *RedisCache) Delete(keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = c.prefix + key
+	}
+	if err := c.client.Del(context.Background(), prefixed...).Err(); err != nil {
+		log.Printf("Warning: failed to delete cache entries %s: %v", strings.Join(keys, ", "), err)
+	}
+}
@@ -0,0 +1,26 @@
+package cache
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// TestRedisCacheDownIsMiss checks that a Redis server that refuses
+// connections reads as a cache miss rather than failing the request
+func TestRedisCacheDownIsMiss(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	c := NewRedisCache(addr, "", 0, "test:")
+	c.Set("key", map[string]int{"value": 1}, time.Minute)
+	var dest map[string]int
+	if c.Get("key", &dest) {
+		t.Errorf("Get found %v on a server that is down", dest)
+	}
+	c.Delete("key")
+}
@@ -0,0 +1,74 @@
+// Package cdn builds CDN asset URLs, tags responses with cache tags and purges
+// those tags from Cloudflare or Fastly when the tagged content changes.
+package cdn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"product-management/pkg/events"
+)
+
+// Cache tags
+const ProductsTag = "products"
+
+// ProductTag returns the cache tag of a single product
+func ProductTag(id uint) string {
+	return fmt.Sprintf("product-%d", id)
+}
+
+// BaseURL is the public URL of the CDN serving stored assets, empty when assets
+// are served directly
+var BaseURL string
+
+// AssetURL returns the URL of a stored asset such as "products/42/cover.jpg"
+// through the CDN
+func AssetURL(key string) string {
+	if BaseURL == "" {
+		return "/" + strings.TrimPrefix(key, "/")
+	}
+	return strings.TrimSuffix(BaseURL, "/") + "/" + strings.TrimPrefix(key, "/")
+}
+
+// SetTags tags a response for the CDN. Fastly reads Surrogate-Key and
+// Cloudflare reads Cache-Tag.
+func SetTags(header http.Header, tags ...string) {
+	header.Set("Surrogate-Key", strings.Join(tags, " "))
+	header.Set("Cache-Tag", strings.Join(tags, ","))
+}
+
+// Purger evicts tagged responses from a CDN
+type Purger interface {
+	Purge(ctx context.Context, tags ...string) error
+}
+
+// New creates the purger of a CDN provider ("cloudflare" or "fastly"). It
+// returns nil when no provider is configured.
+func New(provider, zoneID, apiToken string) (Purger, error) {
+	switch provider {
+	case "", "none":
+		return nil, nil
+	case "cloudflare":
+		return &Cloudflare{client: &http.Client{Timeout: 10 * time.Second}, zoneID: zoneID, apiToken: apiToken}, nil
+	case "fastly":
+		return &Fastly{client: &http.Client{Timeout: 10 * time.Second}, serviceID: zoneID, apiToken: apiToken}, nil
+	default:
+		return nil, fmt.Errorf("unknown CDN provider %q", provider)
+	}
+}
+
+// SubscribePurges purges the tags of changed products from the CDN
+func SubscribePurges(bus *events.Bus, purger Purger) {
+	bus.Subscribe(events.TopicProductChanged, func(event events.Event) {
+		changed := event.(events.ProductChanged)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := purger.Purge(ctx, ProductTag(changed.ProductID), ProductsTag); err != nil {
+			log.Printf("Warning: failed to purge CDN cache for product %d: %v", changed.ProductID, err)
+		}
+	})
+}
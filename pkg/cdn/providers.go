@@ -0,0 +1,67 @@
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Cloudflare purges cache tags of a zone through the Cloudflare API
+type Cloudflare struct {
+	client   *http.Client
+	zoneID   string
+	apiToken string
+}
+
+// Purge evicts the responses carrying any of the tags
+func (c *Cloudflare) Purge(ctx context.Context, tags ...string) error {
+	body, err := json.Marshal(map[string][]string{"tags": tags})
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("https://api.cloudflare.com/client/v4/zones/%s/purge_cache", c.zoneID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	req.Header.Set("Content-Type", "application/json")
+	return send(c.client, req)
+}
+
+// Fastly purges surrogate keys of a service through the Fastly API
+type Fastly struct {
+	client    *http.Client
+	serviceID string
+	apiToken  string
+}
+
+// Purge evicts the responses carrying any of the surrogate keys
+func (f *Fastly) Purge(ctx context.Context, tags ...string) error {
+	url := fmt.Sprintf("https://api.fastly.com/service/%s/purge", f.serviceID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Fastly-Key", f.apiToken)
+	req.Header.Set("Surrogate-Key", strings.Join(tags, " "))
+	return send(f.client, req)
+}
+
+// send performs a purge request and turns error responses into errors
+func send(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("purge failed with %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Package connectors syncs the catalog with legacy systems such as ERPs and
+// warehouse software: products and stock levels are pulled from them and
+// orders are pushed back. Each system is reached through a Connector built
+// from its configuration.
+package connectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrUnsupported is returned by a connector for data it is not configured to sync
+var ErrUnsupported = errors.New("not supported by this connector")
+
+// Product is a product as a legacy system describes it, matched by SKU
+type Product struct {
+	SKU         string  `json:"sku"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price"`
+}
+
+// StockLevel is the quantity a legacy system has in stock of a SKU
+type StockLevel struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+// Order is an order pushed to a legacy system
+type Order struct {
+	Reference     string      `json:"reference"`
+	CustomerEmail string      `json:"customer_email"`
+	PlacedAt      time.Time   `json:"placed_at"`
+	Lines         []OrderLine `json:"lines"`
+}
+
+// OrderLine is a product and quantity of an order at the price agreed on
+type OrderLine struct {
+	SKU       string  `json:"sku"`
+	Quantity  int     `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+}
+
+// Connector syncs with one legacy system. Methods return ErrUnsupported for
+// data the connector is not configured to sync.
+type Connector interface {
+	// PullProducts returns every product the system knows
+	PullProducts(ctx context.Context) ([]Product, error)
+	// PullStock returns the current stock level of every product the system holds
+	PullStock(ctx context.Context) ([]StockLevel, error)
+	// PushOrders sends orders to the system
+	PushOrders(ctx context.Context, orders []Order) error
+}
+
+// Config configures a connector
+type Config struct {
+	Name     string            // Identifies the connector in logs and the admin API
+	Type     string            // Implementation: rest or sftp-csv
+	Interval time.Duration     // How often it syncs
+	Settings map[string]string // Settings of the implementation, e.g. base_url
+}
+
+// namePattern matches a valid connector name, which is also part of the names
+// of its environment variables
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// builders create the connector of each type from its settings
+var builders = map[string]func(settings map[string]string) (Connector, error){
+	"rest":     newREST,
+	"sftp-csv": newSFTPCSV,
+}
+
+// Types returns the connector types, sorted
+func Types() []string {
+	types := make([]string, 0, len(builders))
+	for name := range builders {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// New creates the connector of a configuration, failing when its settings are
+// incomplete. It does not connect to the system.
+func New(cfg Config) (Connector, error) {
+	if !namePattern.MatchString(cfg.Name) {
+		return nil, fmt.Errorf("invalid connector name %q, expected lowercase letters, digits and underscores", cfg.Name)
+	}
+	build, ok := builders[cfg.Type]
+	if !ok {
+		return nil, fmt.Errorf("connector %s: unknown type %q, expected one of %s", cfg.Name, cfg.Type, strings.Join(Types(), ", "))
+	}
+	if cfg.Interval <= 0 {
+		return nil, fmt.Errorf("connector %s: interval must be positive", cfg.Name)
+	}
+	connector, err := build(cfg.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("connector %s: %w", cfg.Name, err)
+	}
+	return connector, nil
+}
+
+// required returns a setting, failing when it is empty
+func required(settings map[string]string, key string) (string, error) {
+	value := strings.TrimSpace(settings[key])
+	if value == "" {
+		return "", fmt.Errorf("missing setting %s", key)
+	}
+	return value, nil
+}
@@ -0,0 +1,126 @@
+package connectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxResponseSize is the largest response a REST connector reads
+const maxResponseSize = 64 << 20
+
+// REST syncs with a system exposing JSON over HTTP. Products and stock levels
+// are fetched with GET as JSON arrays, and orders are sent with POST as one.
+type REST struct {
+	client       *http.Client
+	baseURL      string
+	token        string // Sent as a bearer token when set
+	productsPath string // Empty when products are not pulled
+	stockPath    string // Empty when stock is not pulled
+	ordersPath   string // Empty when orders are not pushed
+}
+
+// newREST creates a REST connector from the settings base_url, token and
+// products_path, stock_path and orders_path. The paths default to /products,
+// /stock and /orders; "none" turns one off.
+func newREST(settings map[string]string) (Connector, error) {
+	baseURL, err := required(settings, "base_url")
+	if err != nil {
+		return nil, err
+	}
+	if parsed, err := url.Parse(baseURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid base_url %q", baseURL)
+	}
+
+	path := func(key, defaultPath string) string {
+		value := strings.TrimSpace(settings[key])
+		switch value {
+		case "":
+			return defaultPath
+		case "none":
+			return ""
+		}
+		return "/" + strings.TrimPrefix(value, "/")
+	}
+	return &REST{
+		client:       &http.Client{Timeout: time.Minute},
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		token:        settings["token"],
+		productsPath: path("products_path", "/products"),
+		stockPath:    path("stock_path", "/stock"),
+		ordersPath:   path("orders_path", "/orders"),
+	}, nil
+}
+
+// PullProducts fetches the products
+func (r *REST) PullProducts(ctx context.Context) ([]Product, error) {
+	if r.productsPath == "" {
+		return nil, ErrUnsupported
+	}
+	var products []Product
+	return products, r.do(ctx, http.MethodGet, r.productsPath, nil, &products)
+}
+
+// PullStock fetches the stock levels
+func (r *REST) PullStock(ctx context.Context) ([]StockLevel, error) {
+	if r.stockPath == "" {
+		return nil, ErrUnsupported
+	}
+	var levels []StockLevel
+	return levels, r.do(ctx, http.MethodGet, r.stockPath, nil, &levels)
+}
+
+// PushOrders sends the orders
+func (r *REST) PushOrders(ctx context.Context, orders []Order) error {
+	if r.ordersPath == "" {
+		return ErrUnsupported
+	}
+	return r.do(ctx, http.MethodPost, r.ordersPath, orders, nil)
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response
+// into out when it is not nil
+func (r *REST) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, r.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s returned %s: %s", method, path, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(out); err != nil {
+		return fmt.Errorf("%s %s: invalid response: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package connectors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// sftpMaxFile is the largest file read
+	sftpMaxFile = 64 << 20
+	// sftpTimeout bounds connecting and the SSH handshake
+	sftpTimeout = 30 * time.Second
+)
+
+// sftpClient reads and writes whole files over an SFTP session
+type sftpClient struct {
+	conn   *ssh.Client
+	client *sftp.Client
+}
+
+// dialSFTP connects to an SSH server and starts its sftp subsystem. Closing
+// ctx closes the connection.
+func dialSFTP(ctx context.Context, addr string, config *ssh.ClientConfig) (*sftpClient, error) {
+	dialer := net.Dialer{Timeout: config.Timeout}
+	raw, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	sshConn, channels, requests, err := ssh.NewClientConn(raw, addr, config)
+	if err != nil {
+		raw.Close()
+		return nil, err
+	}
+	conn := ssh.NewClient(sshConn, channels, requests)
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to start sftp: %w", err)
+	}
+	return &sftpClient{conn: conn, client: client}, nil
+}
+
+// Close ends the session and the connection
+func (c *sftpClient) Close() error {
+	c.client.Close()
+	return c.conn.Close()
+}
+
+// ReadFile reads a whole remote file
+func (c *sftpClient) ReadFile(path string) ([]byte, error) {
+	file, err := c.client.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, sftpMaxFile+1))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	if len(data) > sftpMaxFile {
+		return nil, fmt.Errorf("read %s: file is larger than %d bytes", path, sftpMaxFile)
+	}
+	return data, nil
+}
+
+// WriteFile writes a remote file under a temporary name and renames it into
+// place, so readers never see it half written. Servers supporting POSIX
+// renames replace an existing file; others fail the rename when it exists.
+func (c *sftpClient) WriteFile(path string, data []byte) error {
+	temporary := path + ".part"
+	file, err := c.client.OpenFile(temporary, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", temporary, err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("write %s: %w", temporary, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", temporary, err)
+	}
+
+	rename := c.client.Rename
+	if _, ok := c.client.HasExtension("posix-rename@openssh.com"); ok {
+		rename = c.client.PosixRename
+	}
+	if err := rename(temporary, path); err != nil {
+		return fmt.Errorf("rename %s: %w", temporary, err)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package connectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SFTPCSV syncs with a system exchanging CSV files on an SFTP server, the way
+// many legacy ERPs do. It reads a products file and a stock file, and writes
+// each batch of orders as a new file in a directory.
+type SFTPCSV struct {
+	addr         string
+	config       *ssh.ClientConfig
+	productsFile string // Columns sku,name,description,price; empty when products are not pulled
+	stockFile    string // Columns sku,quantity; empty when stock is not pulled
+	ordersDir    string // Empty when orders are not pushed
+}
+
+// newSFTPCSV creates an SFTP connector from the settings host (host or
+// host:port), user, password or private_key_file, host_key (the server's
+// public key as in authorized_keys, e.g. "ssh-ed25519 AAAA..."), and
+// products_file, stock_file and orders_dir, each optional
+func newSFTPCSV(settings map[string]string) (Connector, error) {
+	host, err := required(settings, "host")
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "22")
+	}
+	user, err := required(settings, "user")
+	if err != nil {
+		return nil, err
+	}
+	hostKey, err := required(settings, "host_key")
+	if err != nil {
+		return nil, err
+	}
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid host_key: %w", err)
+	}
+
+	var auth []ssh.AuthMethod
+	if keyFile := settings["private_key_file"]; keyFile != "" {
+		pem, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private_key_file: %w", err)
+		}
+		signer, err := ssh.ParsePrivateKey(pem)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private_key_file: %w", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if password := settings["password"]; password != "" {
+		auth = append(auth, ssh.Password(password))
+	}
+	if len(auth) == 0 {
+		return nil, fmt.Errorf("missing setting password or private_key_file")
+	}
+
+	connector := &SFTPCSV{
+		addr: host,
+		config: &ssh.ClientConfig{
+			User:            user,
+			Auth:            auth,
+			HostKeyCallback: ssh.FixedHostKey(publicKey),
+			Timeout:         sftpTimeout,
+		},
+		productsFile: strings.TrimSpace(settings["products_file"]),
+		stockFile:    strings.TrimSpace(settings["stock_file"]),
+		ordersDir:    strings.TrimSpace(settings["orders_dir"]),
+	}
+	if connector.productsFile == "" && connector.stockFile == "" && connector.ordersDir == "" {
+		return nil, fmt.Errorf("set at least one of products_file, stock_file and orders_dir")
+	}
+	return connector, nil
+}
+
+// PullProducts reads the products file
+func (s *SFTPCSV) PullProducts(ctx context.Context) ([]Product, error) {
+	if s.productsFile == "" {
+		return nil, ErrUnsupported
+	}
+	rows, err := s.readCSV(ctx, s.productsFile, "sku", "name", "price")
+	if err != nil {
+		return nil, err
+	}
+	products := make([]Product, 0, len(rows))
+	for i, row := range rows {
+		price, err := strconv.ParseFloat(row["price"], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: invalid price %q", s.productsFile, i+2, row["price"])
+		}
+		products = append(products, Product{SKU: row["sku"], Name: row["name"], Description: row["description"], Price: price})
+	}
+	return products, nil
+}
+
+// PullStock reads the stock file
+func (s *SFTPCSV) PullStock(ctx context.Context) ([]StockLevel, error) {
+	if s.stockFile == "" {
+		return nil, ErrUnsupported
+	}
+	rows, err := s.readCSV(ctx, s.stockFile, "sku", "quantity")
+	if err != nil {
+		return nil, err
+	}
+	levels := make([]StockLevel, 0, len(rows))
+	for i, row := range rows {
+		quantity, err := strconv.Atoi(row["quantity"])
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: invalid quantity %q", s.stockFile, i+2, row["quantity"])
+		}
+		levels = append(levels, StockLevel{SKU: row["sku"], Quantity: quantity})
+	}
+	return levels, nil
+}
+
+// PushOrders writes the orders to a new file in the orders directory, one row
+// per order line with the columns reference,placed_at,customer_email,sku,
+// quantity,unit_price
+func (s *SFTPCSV) PushOrders(ctx context.Context, orders []Order) error {
+	if s.ordersDir == "" {
+		return ErrUnsupported
+	}
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{"reference", "placed_at", "customer_email", "sku", "quantity", "unit_price"})
+	for _, order := range orders {
+		for _, line := range order.Lines {
+			writer.Write([]string{
+				order.Reference,
+				order.PlacedAt.UTC().Format(time.RFC3339),
+				order.CustomerEmail,
+				line.SKU,
+				strconv.Itoa(line.Quantity),
+				strconv.FormatFloat(line.UnitPrice, 'f', 2, 64),
+			})
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	client, err := dialSFTP(ctx, s.addr, s.config)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	name := path.Join(s.ordersDir, "orders-"+time.Now().UTC().Format("20060102-150405")+".csv")
+	return client.WriteFile(name, buf.Bytes())
+}
+
+// readCSV downloads a CSV file and returns its rows as maps of the header's
+// column names, failing when a required column is missing
+func (s *SFTPCSV) readCSV(ctx context.Context, file string, columns ...string) ([]map[string]string, error) {
+	client, err := dialSFTP(ctx, s.addr, s.config)
+	if err != nil {
+		return nil, err
+	}
+	data, err := client.ReadFile(file)
+	client.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s: missing header", file)
+	}
+	header := records[0]
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+	for _, column := range columns {
+		if !contains(header, column) {
+			return nil, fmt.Errorf("%s: missing column %q", file, column)
+		}
+	}
+
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, name := range header {
+			if i < len(record) {
+				row[name] = strings.TrimSpace(record[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// contains reports whether a list has a value
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
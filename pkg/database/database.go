@@ -1,23 +1,44 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
 	"product-management/config"
-	"product-management/internal/models"
+	"product-management/pkg/lock"
 	"strconv"
 	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 // DB is the global database instance
 var DB *gorm.DB
 
+// SandboxSchema is the Postgres schema used when sandbox mode is enabled
+const SandboxSchema = "sandbox"
+
 const maxRetries = 5
 const retryDelay = 3 * time.Second
 
+// MigrationLock is the advisory lock migrations run under, so instances
+// starting together migrate one after the other
+const MigrationLock = "migrations"
+
+// migrationLockTimeout bounds the wait for another instance's migrations
+const migrationLockTimeout = 5 * time.Minute
+
+// Connection pool settings
+const (
+	maxIdleConns    = 10
+	maxOpenConns    = 100
+	connMaxLifetime = time.Hour
+	connMaxIdleTime = 30 * time.Minute
+)
+
 // Connect establishes a connection to the database with retry
 func Connect(cfg *config.Config) error {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
@@ -26,6 +47,10 @@ func Connect(cfg *config.Config) error {
 		cfg.DBUser,
 		cfg.DBPassword,
 		cfg.DBName)
+	if cfg.SandboxMode {
+		// Keep sandbox tables isolated from production data
+		dsn += " search_path=" + SandboxSchema
+	}
 
 	var err error
 
@@ -33,6 +58,15 @@ func Connect(cfg *config.Config) error {
 		// Configure connection pooling
 		dbConfig := &gorm.Config{
 			PrepareStmt: true, // Enable prepared statement cache
+			// Log and keep samples of queries slower than the threshold
+			Logger: &slowQueryLogger{
+				Interface: logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
+					SlowThreshold: cfg.DBSlowQueryThreshold,
+					LogLevel:      logger.Warn,
+					Colorful:      true,
+				}),
+				threshold: cfg.DBSlowQueryThreshold,
+			},
 		}
 
 		// Open database connection with pooling
@@ -45,10 +79,10 @@ func Connect(cfg *config.Config) error {
 			}
 
 			// Set connection pool settings
-			sqlDB.SetMaxIdleConns(10)                  // Maximum number of idle connections
-			sqlDB.SetMaxOpenConns(100)                 // Maximum number of open connections
-			sqlDB.SetConnMaxLifetime(time.Hour)        // Maximum lifetime of a connection
-			sqlDB.SetConnMaxIdleTime(30 * time.Minute) // Maximum idle time of a connection
+			sqlDB.SetMaxIdleConns(maxIdleConns)       // Maximum number of idle connections
+			sqlDB.SetMaxOpenConns(maxOpenConns)       // Maximum number of open connections
+			sqlDB.SetConnMaxLifetime(connMaxLifetime) // Maximum lifetime of a connection
+			sqlDB.SetConnMaxIdleTime(connMaxIdleTime) // Maximum idle time of a connection
 
 			// Check if DB is actually alive
 			if err := sqlDB.Ping(); err == nil {
@@ -65,17 +99,26 @@ func Connect(cfg *config.Config) error {
 		return fmt.Errorf("failed to connect to database after %d attempts: %v", maxRetries, err)
 	}
 
-	// Auto migrate models
-	err = DB.AutoMigrate(
-		&models.User{},
-		&models.Product{},
-		&models.Category{},
-		&models.Review{},
-		&models.Wishlist{},
-		&models.ProductCategory{},
-	)
+	if cfg.SandboxMode {
+		if err := DB.Exec("CREATE SCHEMA IF NOT EXISTS " + SandboxSchema).Error; err != nil {
+			return fmt.Errorf("failed to create sandbox schema: %v", err)
+		}
+		ReportingSchema = SandboxSchema + "_reporting"
+	}
+
+	// Apply pending migrations
+	ctx, cancel := context.WithTimeout(context.Background(), migrationLockTimeout)
+	defer cancel()
+	migrationLock, err := lock.Acquire(ctx, lock.NewPostgres(DB), MigrationLock)
 	if err != nil {
-		return fmt.Errorf("failed to auto migrate: %v", err)
+		return fmt.Errorf("failed to lock migrations: %v", err)
+	}
+	defer migrationLock.Release()
+	if err := MigrateSchema(dsn); err != nil {
+		return err
+	}
+	if err := MigrateReporting(DB); err != nil {
+		return err
 	}
 
 	log.Println("✅ Database connection established and migrations completed")
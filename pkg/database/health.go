@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	pingTimeout       = 5 * time.Second
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// HealthStatus describes the result of the most recent database health check
+type HealthStatus struct {
+	Healthy             bool      `json:"healthy"`
+	LastCheck           time.Time `json:"last_check"`
+	LastError           string    `json:"last_error,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	Reconnects          int64     `json:"reconnects"`
+}
+
+var (
+	healthMu sync.RWMutex
+	health   = HealthStatus{Healthy: true}
+)
+
+// Health returns the current database health status
+func Health() HealthStatus {
+	healthMu.RLock()
+	defer healthMu.RUnlock()
+	return health
+}
+
+// PoolStats returns the connection pool statistics of the database
+func PoolStats() (sql.DBStats, error) {
+	if DB == nil {
+		return sql.DBStats{}, errors.New("database is not initialized")
+	}
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return sql.DBStats{}, err
+	}
+	return sqlDB.Stats(), nil
+}
+
+// StartHealthMonitor pings the database every interval. When a ping fails it keeps
+// retrying with exponential backoff and, once the database is reachable again,
+// drops stale pooled connections and prepared statements. Call the returned
+// function to stop the monitor.
+func StartHealthMonitor(interval time.Duration) func() {
+	stop := make(chan struct{})
+	var once sync.Once
+
+	go func() {
+		delay := interval
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(delay):
+			}
+
+			if checkHealth() {
+				delay = interval
+				continue
+			}
+
+			// Back off exponentially while the database is down
+			failures := Health().ConsecutiveFailures
+			delay = minReconnectDelay << uint(failures-1)
+			if delay <= 0 || delay > maxReconnectDelay {
+				delay = maxReconnectDelay
+			}
+		}
+	}()
+
+	return func() {
+		once.Do(func() { close(stop) })
+	}
+}
+
+// checkHealth pings the database and updates the health status
+func checkHealth() bool {
+	sqlDB, err := DB.DB()
+	if err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+		err = sqlDB.PingContext(ctx)
+		cancel()
+	}
+
+	healthMu.Lock()
+	defer healthMu.Unlock()
+
+	health.LastCheck = time.Now()
+	if err != nil {
+		health.Healthy = false
+		health.LastError = err.Error()
+		health.ConsecutiveFailures++
+		log.Printf("⚠️ Database health check failed (%d consecutive): %v", health.ConsecutiveFailures, err)
+		return false
+	}
+
+	if !health.Healthy {
+		resetConnections(sqlDB)
+		health.Reconnects++
+		log.Printf("✅ Database connection recovered after %d failed checks", health.ConsecutiveFailures)
+	}
+	health.Healthy = true
+	health.LastError = ""
+	health.ConsecutiveFailures = 0
+	return true
+}
+
+// resetConnections discards idle connections and cached prepared statements
+// that may point at a database session that no longer exists
+func resetConnections(sqlDB *sql.DB) {
+	sqlDB.SetMaxIdleConns(0)
+	sqlDB.SetMaxIdleConns(maxIdleConns)
+
+	if preparedStmtDB, ok := DB.ConnPool.(*gorm.PreparedStmtDB); ok {
+		preparedStmtDB.Reset()
+	}
+}
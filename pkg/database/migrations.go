@@ -0,0 +1,179 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+
+	"product-management/migrations"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/jackc/pgx/v5/stdlib" // Registers the pgx database/sql driver
+)
+
+// ErrIrreversible is returned when rolling back a migration without a down file
+var ErrIrreversible = errors.New("migration has no down file")
+
+// Migration is a version of the schema
+type Migration struct {
+	Version uint
+	Name    string
+}
+
+// NewMigrator creates the migrator of the schema's versioned migrations. It
+// opens its own connections to dsn, which Close releases.
+func NewMigrator(dsn string) (*migrate.Migrate, error) {
+	src, err := iofs.New(migrations.Files, ".")
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, err
+	}
+	driver, err := pgx.WithInstance(sqlDB, &pgx.Config{})
+	if err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+	migrator, err := migrate.NewWithInstance("iofs", src, "pgx", driver)
+	if err != nil {
+		driver.Close()
+		return nil, err
+	}
+	migrator.Log = migrationLogger{}
+	return migrator, nil
+}
+
+// Migrations lists the versioned migrations by ascending version
+func Migrations() ([]Migration, error) {
+	src, err := iofs.New(migrations.Files, ".")
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	var list []Migration
+	version, err := src.First()
+	for err == nil {
+		name, readErr := readUp(src, version)
+		if readErr != nil {
+			return nil, readErr
+		}
+		list = append(list, Migration{Version: version, Name: name})
+		version, err = src.Next(version)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return list, nil
+}
+
+// MigrateSchema applies the migrations the database doesn't have yet. Callers
+// hold MigrationLock.
+func MigrateSchema(dsn string) error {
+	migrator, err := NewMigrator(dsn)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %v", err)
+	}
+	defer migrator.Close()
+
+	if err := migrator.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to migrate: %v", err)
+	}
+	return nil
+}
+
+// RollbackSchema rolls back the last steps migrations applied, or as many as
+// there are, and returns how many it rolled back. golang-migrate steps over a
+// migration without a down file while still lowering the version, so this
+// refuses to start when one of them has none. Callers hold MigrationLock.
+func RollbackSchema(dsn string, steps int) (int, error) {
+	migrator, err := NewMigrator(dsn)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load migrations: %v", err)
+	}
+	defer migrator.Close()
+
+	version, dirty, err := migrator.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, migrate.ErrDirty{Version: int(version)}
+	}
+
+	src, err := iofs.New(migrations.Files, ".")
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+	n, err := rollbackSteps(src, version, steps)
+	if err != nil || n == 0 {
+		return 0, err
+	}
+	if err := migrator.Steps(-n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// rollbackSteps returns how many of the last steps migrations up to version
+// can be rolled back, or ErrIrreversible naming the first one without a down
+// file
+func rollbackSteps(src source.Driver, version uint, steps int) (int, error) {
+	n := 0
+	for n < steps {
+		r, name, err := src.ReadDown(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			if name, err = readUp(src, version); err != nil {
+				return 0, err
+			}
+			return 0, fmt.Errorf("%d_%s: %w", version, name, ErrIrreversible)
+		}
+		if err != nil {
+			return 0, err
+		}
+		r.Close()
+		n++
+
+		previous, err := src.Prev(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = previous
+	}
+	return n, nil
+}
+
+// readUp returns the name of a migration from its up file
+func readUp(src source.Driver, version uint) (string, error) {
+	r, name, err := src.ReadUp(version)
+	if err != nil {
+		return "", err
+	}
+	r.Close()
+	return name, nil
+}
+
+// migrationLogger logs each migration applied or rolled back
+type migrationLogger struct{}
+
+func (migrationLogger) Printf(format string, v ...interface{}) {
+	log.Printf("Migration "+format, v...)
+}
+
+func (migrationLogger) Verbose() bool {
+	return false
+}
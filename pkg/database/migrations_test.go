@@ -0,0 +1,61 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// TestMigrationsAreNumbered checks that the embedded migrations are numbered
+// from 1 without gaps
+func TestMigrationsAreNumbered(t *testing.T) {
+	list, err := Migrations()
+	if err != nil {
+		t.Fatalf("failed to list migrations: %v", err)
+	}
+	if len(list) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+	for i, migration := range list {
+		if migration.Version != uint(i+1) {
+			t.Errorf("migration %d_%s: want version %d", migration.Version, migration.Name, i+1)
+		}
+	}
+}
+
+func TestRollbackSteps(t *testing.T) {
+	src, err := iofs.New(fstest.MapFS{
+		"000001_create.up.sql":   {Data: []byte("CREATE TABLE a (id int);")},
+		"000001_create.down.sql": {Data: []byte("DROP TABLE a;")},
+		"000002_purge.up.sql":    {Data: []byte("DELETE FROM a;")},
+		"000003_index.up.sql":    {Data: []byte("CREATE INDEX a_id ON a (id);")},
+		"000003_index.down.sql":  {Data: []byte("DROP INDEX a_id;")},
+	}, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+
+	tests := []struct {
+		name    string
+		version uint
+		steps   int
+		want    int
+		wantErr error
+	}{
+		{"last reversible", 3, 1, 1, nil},
+		{"stops at irreversible", 3, 2, 0, ErrIrreversible},
+		{"irreversible current", 2, 1, 0, ErrIrreversible},
+		{"capped at first", 1, 5, 1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rollbackSteps(src, tt.version, tt.steps)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("rollbackSteps(%d, %d) = %d, %v; want %d, %v", tt.version, tt.steps, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ReportingSchema holds the materialized views heavy analytics read instead of
+// the tables orders and reviews are written to. Sandbox mode keeps its own.
+var ReportingSchema = "reporting"
+
+// ReportingView is a materialized view of the reporting schema. Its key
+// columns identify a row, so it can be refreshed without blocking readers.
+type ReportingView struct {
+	Name  string
+	Query string
+	Key   []string
+}
+
+// ReportingViews are the materialized views of the reporting schema. Days are
+// UTC days, so the views don't depend on the time zone of the session
+// refreshing them.
+var ReportingViews = []ReportingView{
+	{
+		Name: "sales_by_day",
+		Query: `SELECT CAST(q.decided_at AT TIME ZONE 'UTC' AS date) AS day, COUNT(DISTINCT q.id) AS accepted_quotes,
+				SUM(i.quantity) AS units,
+				CAST(ROUND(CAST(SUM(COALESCE(i.quoted_price, 0) * i.quantity) AS numeric), 2) AS double precision) AS revenue
+			FROM quotes q
+			JOIN quote_items i ON i.quote_id = q.id AND i.deleted_at IS NULL
+			WHERE q.deleted_at IS NULL AND q.status = 'accepted' AND q.decided_at IS NOT NULL
+			GROUP BY 1`,
+		Key: []string{"day"},
+	},
+	{
+		Name: "category_revenue",
+		Query: `SELECT CAST(q.decided_at AT TIME ZONE 'UTC' AS date) AS day, pc.category_id, SUM(i.quantity) AS units,
+				CAST(ROUND(CAST(SUM(COALESCE(i.quoted_price, 0) * i.quantity) AS numeric), 2) AS double precision) AS revenue
+			FROM quotes q
+			JOIN quote_items i ON i.quote_id = q.id AND i.deleted_at IS NULL
+			JOIN product_categories pc ON pc.product_id = i.product_id
+			WHERE q.deleted_at IS NULL AND q.status = 'accepted' AND q.decided_at IS NOT NULL
+			GROUP BY 1, 2`,
+		Key: []string{"day", "category_id"},
+	},
+	{
+		Name: "rating_trends",
+		Query: `SELECT CAST(r.created_at AT TIME ZONE 'UTC' AS date) AS day, r.product_id, COUNT(*) AS review_count,
+				SUM(r.rating) AS rating_sum
+			FROM reviews r
+			WHERE r.deleted_at IS NULL
+			GROUP BY 1, 2`,
+		Key: []string{"day", "product_id"},
+	},
+}
+
+// MigrateReporting creates the reporting schema and those of its materialized
+// views that don't exist yet, populated with the current data
+func MigrateReporting(db *gorm.DB) error {
+	if err := db.Exec("CREATE SCHEMA IF NOT EXISTS " + ReportingSchema).Error; err != nil {
+		return fmt.Errorf("failed to create reporting schema: %v", err)
+	}
+	for _, view := range ReportingViews {
+		name := ReportingSchema + "." + view.Name
+		if err := db.Exec(fmt.Sprintf("CREATE MATERIALIZED VIEW IF NOT EXISTS %s AS %s", name, view.Query)).Error; err != nil {
+			return fmt.Errorf("failed to create materialized view %s: %v", name, err)
+		}
+		if err := db.Exec(fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s_key ON %s (%s)",
+			view.Name, name, strings.Join(view.Key, ", "))).Error; err != nil {
+			return fmt.Errorf("failed to index materialized view %s: %v", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	applog "product-management/pkg/logger"
+
+	"gorm.io/gorm/logger"
+)
+
+// slowQueryCapacity is the number of slow queries kept for sampling
+const slowQueryCapacity = 100
+
+// slowQueryMaxSQL truncates long statements such as batch inserts
+const slowQueryMaxSQL = 500
+
+// SlowQuery is a statement that took at least the slow query threshold
+type SlowQuery struct {
+	SQL      string
+	Duration time.Duration
+	Rows     int64
+	At       time.Time
+}
+
+// slowQueries holds the last slow queries of this instance, oldest overwritten first
+var slowQueries = struct {
+	sync.Mutex
+	entries []SlowQuery
+	next    int
+}{}
+
+// SlowQueries returns up to limit of the slowest queries recorded since a time,
+// slowest first
+func SlowQueries(since time.Time, limit int) []SlowQuery {
+	slowQueries.Lock()
+	var result []SlowQuery
+	for _, query := range slowQueries.entries {
+		if !query.At.Before(since) {
+			result = append(result, query)
+		}
+	}
+	slowQueries.Unlock()
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Duration > result[j].Duration })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+func recordSlowQuery(query SlowQuery) {
+	slowQueries.Lock()
+	defer slowQueries.Unlock()
+	if len(slowQueries.entries) < slowQueryCapacity {
+		slowQueries.entries = append(slowQueries.entries, query)
+		return
+	}
+	slowQueries.entries[slowQueries.next] = query
+	slowQueries.next = (slowQueries.next + 1) % slowQueryCapacity
+}
+
+// slowQueryLogger records the queries slower than threshold for sampling and
+// otherwise logs like the logger it wraps
+type slowQueryLogger struct {
+	logger.Interface
+	threshold time.Duration
+}
+
+// LogMode keeps the recording when GORM changes the log level
+func (l *slowQueryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &slowQueryLogger{Interface: l.Interface.LogMode(level), threshold: l.threshold}
+}
+
+// Trace records slow queries before logging them, and logs every query while
+// verbose logging is on
+func (l *slowQueryLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if elapsed := time.Since(begin); l.threshold > 0 && elapsed >= l.threshold {
+		sql, rows := fc()
+		if len(sql) > slowQueryMaxSQL {
+			sql = sql[:slowQueryMaxSQL] + "..."
+		}
+		recordSlowQuery(SlowQuery{SQL: sql, Duration: elapsed, Rows: rows, At: begin})
+	}
+	if applog.Verbose() {
+		l.Interface.LogMode(logger.Info).Trace(ctx, begin, fc, err)
+		return
+	}
+	l.Interface.Trace(ctx, begin, fc, err)
+}
@@ -0,0 +1,17 @@
+package events
+
+// TopicConfigChanged is published when a reload changed the runtime
+// configuration of this instance
+const TopicConfigChanged = "config.changed"
+
+// ConfigChanged reports the configuration variables a reload changed and what
+// triggered it, e.g. SIGHUP or the admin API
+type ConfigChanged struct {
+	Keys   []string
+	Source string
+}
+
+// Topic returns TopicConfigChanged
+func (ConfigChanged) Topic() string {
+	return TopicConfigChanged
+}
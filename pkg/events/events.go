@@ -0,0 +1,66 @@
+// Package events is an in-process event bus. Services publish what changed and
+// subscribers such as cache purgers react without the services knowing them.
+package events
+
+import (
+	"log"
+	"sync"
+)
+
+// Event is something that happened, delivered to the subscribers of its topic
+type Event interface {
+	Topic() string
+}
+
+// Handler reacts to an event
+type Handler func(Event)
+
+// Bus delivers published events to the handlers subscribed to their topic
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// NewBus creates an empty bus
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers a handler for a topic
+func (b *Bus) Subscribe(topic string, handler Handler) {
+	b.mu.Lock()
+	b.handlers[topic] = append(b.handlers[topic], handler)
+	b.mu.Unlock()
+}
+
+// Publish delivers an event to every handler of its topic. Handlers run in the
+// background so publishers never wait on them, and a panicking handler is logged.
+func (b *Bus) Publish(event Event) {
+	b.mu.RLock()
+	handlers := b.handlers[event.Topic()]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		go func(handler Handler) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Warning: handler for %s panicked: %v", event.Topic(), r)
+				}
+			}()
+			handler(event)
+		}(handler)
+	}
+}
+
+// Default is the global event bus
+var Default = NewBus()
+
+// Subscribe registers a handler for a topic on the default bus
+func Subscribe(topic string, handler Handler) {
+	Default.Subscribe(topic, handler)
+}
+
+// Publish delivers an event on the default bus
+func Publish(event Event) {
+	Default.Publish(event)
+}
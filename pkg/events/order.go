@@ -0,0 +1,19 @@
+package events
+
+// TopicOrderStatusChanged is published when the order placed from a quote
+// moves to another status of its tracking page
+const TopicOrderStatusChanged = "order.status_changed"
+
+// OrderStatusChanged reports the status an order moved to: processing,
+// confirmed, shipped or cancelled
+type OrderStatusChanged struct {
+	QuoteID     uint
+	UserID      uint // Customer who placed the order
+	OrderNumber string
+	Status      string
+}
+
+// Topic returns TopicOrderStatusChanged
+func (OrderStatusChanged) Topic() string {
+	return TopicOrderStatusChanged
+}
@@ -0,0 +1,67 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Publisher sends stored events on to their consumers. The outbox relay
+// publishes through one, so a message broker can take the place of the bus.
+type Publisher interface {
+	Send(ctx context.Context, topic string, payload []byte) error
+}
+
+// decoders rebuild the events that can be stored in the outbox, by topic
+var decoders = map[string]func(payload []byte) (Event, error){
+	TopicProductChanged:     decoder[ProductChanged](),
+	TopicStockChanged:       decoder[StockChanged](),
+	TopicCategoryChanged:    decoder[CategoryChanged](),
+	TopicLoginFailed:        decoder[LoginFailed](),
+	TopicRoleChanged:        decoder[RoleChanged](),
+	TopicProductDeleted:     decoder[ProductDeleted](),
+	TopicOrderStatusChanged: decoder[OrderStatusChanged](),
+}
+
+// decoder returns a function decoding the JSON payload of an event of type T
+func decoder[T Event]() func(payload []byte) (Event, error) {
+	return func(payload []byte) (Event, error) {
+		var event T
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, err
+		}
+		return event, nil
+	}
+}
+
+// Encode returns the JSON payload an event is stored with, failing for events
+// Decode cannot rebuild
+func Encode(event Event) ([]byte, error) {
+	if _, ok := decoders[event.Topic()]; !ok {
+		return nil, fmt.Errorf("event %s cannot be stored", event.Topic())
+	}
+	return json.Marshal(event)
+}
+
+// Decode rebuilds a stored event from its topic and payload
+func Decode(topic string, payload []byte) (Event, error) {
+	decode, ok := decoders[topic]
+	if !ok {
+		return nil, fmt.Errorf("unknown event topic %q", topic)
+	}
+	event, err := decode(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s payload: %w", topic, err)
+	}
+	return event, nil
+}
+
+// Send decodes a stored event and publishes it on the bus
+func (b *Bus) Send(ctx context.Context, topic string, payload []byte) error {
+	event, err := Decode(topic, payload)
+	if err != nil {
+		return err
+	}
+	b.Publish(event)
+	return nil
+}
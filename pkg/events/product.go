@@ -0,0 +1,47 @@
+package events
+
+// TopicProductChanged is published when a product or anything shown with it,
+// such as its categories or rating, changes
+const TopicProductChanged = "product.changed"
+
+// ProductChanged reports a created, updated or deleted product
+type ProductChanged struct {
+	ProductID uint
+	Deleted   bool
+}
+
+// Topic returns TopicProductChanged
+func (ProductChanged) Topic() string {
+	return TopicProductChanged
+}
+
+// TopicStockChanged is published when a product's stock quantity changes
+const TopicStockChanged = "product.stock_changed"
+
+// StockChanged reports a product's stock quantity before and after a change
+type StockChanged struct {
+	ProductID   uint
+	ProductName string
+	Previous    int
+	Quantity    int
+}
+
+// Topic returns TopicStockChanged
+func (StockChanged) Topic() string {
+	return TopicStockChanged
+}
+
+// TopicCategoryChanged is published when a category is created, renamed or
+// deleted. Changes to which products it holds publish ProductChanged instead.
+const TopicCategoryChanged = "category.changed"
+
+// CategoryChanged reports a created, updated or deleted category
+type CategoryChanged struct {
+	CategoryID uint
+	Deleted    bool
+}
+
+// Topic returns TopicCategoryChanged
+func (CategoryChanged) Topic() string {
+	return TopicCategoryChanged
+}
@@ -0,0 +1,49 @@
+package events
+
+// TopicLoginFailed is published when a sign-in attempt is rejected
+const TopicLoginFailed = "auth.login_failed"
+
+// LoginFailed reports a rejected sign-in attempt and where it came from
+type LoginFailed struct {
+	Email string
+	IP    string
+}
+
+// Topic returns TopicLoginFailed
+func (LoginFailed) Topic() string {
+	return TopicLoginFailed
+}
+
+// TopicRoleChanged is published when an admin changes a user's role
+const TopicRoleChanged = "auth.role_changed"
+
+// RoleChanged reports a user's role before and after a change, and who made it
+type RoleChanged struct {
+	ActorID uint
+	UserID  uint
+	From    string
+	To      string
+	IP      string
+}
+
+// Topic returns TopicRoleChanged
+func (RoleChanged) Topic() string {
+	return TopicRoleChanged
+}
+
+// TopicProductDeleted is published when a user deletes a product through the
+// API. ProductChanged is published as well, for subscribers that only care
+// about the catalog.
+const TopicProductDeleted = "product.deleted_by_user"
+
+// ProductDeleted reports a product deleted by a user, and where from
+type ProductDeleted struct {
+	ActorID   uint
+	ProductID uint
+	IP        string
+}
+
+// Topic returns TopicProductDeleted
+func (ProductDeleted) Topic() string {
+	return TopicProductDeleted
+}
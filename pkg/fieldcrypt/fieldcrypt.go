@@ -0,0 +1,214 @@
+// Package fieldcrypt encrypts sensitive database fields with AES-256-GCM in the
+// application, so they can't be read from the database, its replicas or its
+// backups without the keys. Fields opt in with the GORM tag
+// `serializer:encrypted`.
+package fieldcrypt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm/schema"
+)
+
+// prefix marks an encrypted value, stored as "enc:<key ID>:<base64 of nonce and ciphertext>".
+// Values without it are plaintext written before encryption was enabled.
+const prefix = "enc:"
+
+var (
+	// ErrUnknownKey is returned for a value encrypted with a key the keyring doesn't have
+	ErrUnknownKey = errors.New("value is encrypted with an unknown key")
+	// ErrNoKeys is returned when reading an encrypted value without any keys configured
+	ErrNoKeys = errors.New("value is encrypted but no encryption keys are configured")
+)
+
+// KeyProvider supplies the data keys, for example keys unwrapped by a KMS
+type KeyProvider interface {
+	// Keys returns every 32-byte key by ID and the ID of the one new values are encrypted with
+	Keys(ctx context.Context) (map[string][]byte, string, error)
+}
+
+// StaticKeys provides keys given in the configuration, base64-encoded
+type StaticKeys struct {
+	Encoded map[string]string
+	Active  string // May be omitted when there is a single key
+}
+
+// Keys decodes the configured keys
+func (s StaticKeys) Keys(ctx context.Context) (map[string][]byte, string, error) {
+	keys := make(map[string][]byte, len(s.Encoded))
+	for id, encoded := range s.Encoded {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, "", fmt.Errorf("key %s is not base64: %v", id, err)
+		}
+		keys[id] = key
+	}
+	active := s.Active
+	if active == "" && len(keys) == 1 {
+		for id := range keys {
+			active = id
+		}
+	}
+	return keys, active, nil
+}
+
+// Keyring encrypts values with its active key and decrypts values encrypted
+// with any of its keys
+type Keyring struct {
+	active string
+	aeads  map[string]cipher.AEAD
+}
+
+// New loads the keys of a provider
+func New(ctx context.Context, provider KeyProvider) (*Keyring, error) {
+	keys, active, err := provider.Keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := keys[active]; !ok {
+		return nil, fmt.Errorf("active key %q is not one of the keys", active)
+	}
+
+	k := &Keyring{active: active, aeads: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid key ID %q", id)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("key %s must be 32 bytes, got %d", id, len(key))
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		if k.aeads[id], err = cipher.NewGCM(block); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// NewStatic loads keys given in the configuration, returning nil when there are none
+func NewStatic(encoded map[string]string, active string) (*Keyring, error) {
+	if len(encoded) == 0 {
+		return nil, nil
+	}
+	return New(context.Background(), StaticKeys{Encoded: encoded, Active: active})
+}
+
+// Default encrypts the fields of every model. When nil, new values are stored
+// as plaintext.
+var Default *Keyring
+
+// ActiveKey returns the ID of the key new values are encrypted with
+func (k *Keyring) ActiveKey() string {
+	if k == nil {
+		return ""
+	}
+	return k.active
+}
+
+// Encrypt encrypts a value with the active key. Empty values are stored as
+// they are, so emptiness can still be queried, and without a keyring every
+// value is.
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	if k == nil || plaintext == "" {
+		return plaintext, nil
+	}
+	aead := k.aeads[k.active]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	// The key ID is authenticated, so a value can't be relabeled to another key
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(k.active))
+	return prefix + k.active + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value, returning plaintext values as they are
+func (k *Keyring) Decrypt(value string) (string, error) {
+	id, payload, encrypted := parseValue(value)
+	if !encrypted {
+		return value, nil
+	}
+	if k == nil {
+		return "", ErrNoKeys
+	}
+	aead, ok := k.aeads[id]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownKey, id)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", errors.New("malformed encrypted value")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(id))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value with key %s: %v", id, err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation reports whether a stored value is plaintext or encrypted with
+// a key other than the active one
+func (k *Keyring) NeedsRotation(value string) bool {
+	if k == nil || value == "" {
+		return false
+	}
+	id, _, encrypted := parseValue(value)
+	return !encrypted || id != k.active
+}
+
+// parseValue splits an encrypted value into its key ID and payload
+func parseValue(value string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(value, prefix)
+	if !ok {
+		return "", "", false
+	}
+	id, payload, ok := strings.Cut(rest, ":")
+	return id, payload, ok
+}
+
+// Serializer is the GORM serializer of string fields tagged `serializer:encrypted`,
+// which encrypts and decrypts them with Default
+type Serializer struct{}
+
+// Scan decrypts a value read from the database
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch v := dbValue.(type) {
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	case nil:
+	default:
+		return fmt.Errorf("fieldcrypt: unsupported value %T for %s", dbValue, field.Name)
+	}
+	plaintext, err := Default.Decrypt(value)
+	if err != nil {
+		return fmt.Errorf("fieldcrypt: %s: %w", field.Name, err)
+	}
+	return field.Set(ctx, dst, plaintext)
+}
+
+// Value encrypts a value written to the database
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("fieldcrypt: %s is not a string field", field.Name)
+	}
+	return Default.Encrypt(value)
+}
+
+func init() {
+	schema.RegisterSerializer("encrypted", Serializer{})
+}
@@ -0,0 +1,95 @@
+// Package geoip looks up the location of IP addresses in a MaxMind DB file,
+// such as GeoLite2-Country or GeoIP2-City, loaded into memory so lookups never
+// leave the process.
+package geoip
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// ErrInvalidDatabase is returned for a file that is not a valid MaxMind DB
+var ErrInvalidDatabase = errors.New("invalid MaxMind DB file")
+
+// Location is what the database knows about an address
+type Location struct {
+	Country string // ISO 3166-1 alpha-2 code, e.g. "VN"
+	City    string // English name, only in city databases
+}
+
+// String formats the location as "City, CC", or just the country code when
+// the city is not known
+func (l Location) String() string {
+	if l.City == "" || l.Country == "" {
+		return l.Country
+	}
+	return l.City + ", " + l.Country
+}
+
+// record holds the fields of a country or city record that Location uses
+type record struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+}
+
+// Reader looks up addresses in a MaxMind DB file
+type Reader struct {
+	db *maxminddb.Reader
+}
+
+// Open reads a MaxMind DB file into memory
+func Open(path string) (*Reader, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(file)
+}
+
+// New parses a MaxMind DB file held in memory
+func New(file []byte) (*Reader, error) {
+	db, err := maxminddb.FromBytes(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDatabase, err)
+	}
+	return &Reader{db: db}, nil
+}
+
+// Lookup returns the location of an address, or nil when the database has no
+// entry for it
+func (r *Reader) Lookup(ip net.IP) (*Location, error) {
+	var result record
+	_, ok, err := r.db.LookupNetwork(ip, &result)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &Location{Country: result.Country.ISOCode, City: result.City.Names["en"]}, nil
+}
+
+// Locate returns the location of an address, or an empty one when it is not
+// known
+func (r *Reader) Locate(address string) Location {
+	ip := net.ParseIP(address)
+	if r == nil || ip == nil {
+		return Location{}
+	}
+	location, err := r.Lookup(ip)
+	if err != nil || location == nil {
+		return Location{}
+	}
+	return *location
+}
+
+// Country returns the ISO code of the country of an address, or an empty
+// string when it is not known
+func (r *Reader) Country(address string) string {
+	return r.Locate(address).Country
+}
@@ -0,0 +1,249 @@
+// Package jsonschema derives JSON schemas from Go types using their json tags
+// and validates decoded JSON payloads against them with
+// santhosh-tekuri/jsonschema.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	validator "github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Schema is the subset of JSON Schema needed to describe API payloads
+type Schema struct {
+	SchemaURI            string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"` // false or *Schema
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Types lists the JSON types a value may have. A single type is encoded as a plain string.
+type Types []string
+
+// MarshalJSON encodes a single type as a string and several as an array
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// Describer is implemented by types whose JSON encoding differs from their Go
+// structure, such as types with a custom MarshalJSON
+type Describer interface {
+	JSONSchema() *Schema
+}
+
+var (
+	describerType   = reflect.TypeOf((*Describer)(nil)).Elem()
+	timeType        = reflect.TypeOf(time.Time{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	packagePath     = regexp.MustCompile(`[\w.-]+/`)
+	invalidDefChars = regexp.MustCompile(`[^A-Za-z0-9_.]+`)
+)
+
+// Generate builds the schema of the value's type. Named struct types are
+// emitted once under $defs and referenced, so recursive models are supported.
+func Generate(value interface{}) *Schema {
+	g := &generator{defs: make(map[string]*Schema)}
+	root := g.schemaFor(reflect.TypeOf(value))
+	root.SchemaURI = "https://json-schema.org/draft/2020-12/schema"
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+	return root
+}
+
+type generator struct {
+	defs map[string]*Schema
+}
+
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	switch {
+	case t.Kind() != reflect.Interface && t.Kind() != reflect.Ptr && t.Implements(describerType):
+		return reflect.Zero(t).Interface().(Describer).JSONSchema()
+	case t == timeType:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullable(g.schemaFor(t.Elem()))
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Slice, reflect.Array:
+		// nil slices encode as null
+		return &Schema{Type: Types{"array", "null"}, Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: Types{"object", "null"}, AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := defName(t)
+		if _, ok := g.defs[name]; !ok {
+			g.defs[name] = nil // reserve the name before recursing
+			g.defs[name] = g.structSchema(t)
+		}
+		return &Schema{Ref: "#/$defs/" + name}
+	default:
+		// interface{} and anything else accepts any value
+		return &Schema{}
+	}
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:                 Types{"object"},
+		Properties:           make(map[string]*Schema),
+		AdditionalProperties: false,
+	}
+	g.addFields(schema, t)
+	sort.Strings(schema.Required)
+	return schema
+}
+
+func (g *generator) addFields(schema *Schema, t reflect.Type) {
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// Embedded structs without a json name are flattened like encoding/json does,
+		// after the outer fields so those take precedence
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				embedded = append(embedded, fieldType)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, exists := schema.Properties[name]; exists {
+			continue
+		}
+
+		schema.Properties[name] = g.schemaFor(field.Type)
+		if !strings.Contains(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	for _, fieldType := range embedded {
+		g.addFields(schema, fieldType)
+	}
+}
+
+// defName turns a Go type into a stable $defs key, e.g. dto.ProductResponse
+func defName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	// Generic instantiations carry full package paths, e.g. DataResponse[product-management/internal/dto.UserResponse]
+	name := strings.ReplaceAll(t.Name(), "[]", "List_")
+	name = packagePath.ReplaceAllString(name, "")
+	name = invalidDefChars.ReplaceAllString(name, "_")
+	name = strings.Trim(name, "_")
+	if pkg == "" {
+		return name
+	}
+	return pkg + "." + name
+}
+
+func nullable(schema *Schema) *Schema {
+	if schema.Ref != "" {
+		// A type next to $ref would also apply to the referenced object
+		return &Schema{AnyOf: []*Schema{schema, {Type: Types{"null"}}}}
+	}
+	if len(schema.Type) == 0 {
+		// Untyped schemas accept any value, null included
+		return schema
+	}
+	for _, t := range schema.Type {
+		if t == "null" {
+			return schema
+		}
+	}
+	schema.Type = append(schema.Type, "null")
+	return schema
+}
+
+// Validate checks a decoded JSON value against the schema and returns every violation
+func Validate(schema *Schema, value interface{}) []string {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return []string{fmt.Sprintf("$: invalid schema: %v", err)}
+	}
+	compiler := validator.NewCompiler()
+	compiler.AssertFormat = true
+	if err := compiler.AddResource(schemaURL, bytes.NewReader(data)); err != nil {
+		return []string{fmt.Sprintf("$: invalid schema: %v", err)}
+	}
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return []string{fmt.Sprintf("$: invalid schema: %v", err)}
+	}
+
+	err = compiled.Validate(value)
+	var invalid *validator.ValidationError
+	if !errors.As(err, &invalid) {
+		if err != nil {
+			return []string{"$: " + err.Error()}
+		}
+		return nil
+	}
+	var violations []string
+	collect(invalid, &violations)
+	return violations
+}
+
+// schemaURL identifies the schema being validated within the compiler
+const schemaURL = "schema.json"
+
+// collect adds the leaf errors of a validation error, which name the field
+// and the keyword that failed, as "$/path: message"
+func collect(err *validator.ValidationError, violations *[]string) {
+	if len(err.Causes) == 0 {
+		*violations = append(*violations, "$"+err.InstanceLocation+": "+err.Message)
+		return
+	}
+	for _, cause := range err.Causes {
+		collect(cause, violations)
+	}
+}
@@ -0,0 +1,43 @@
+package lock
+
+import (
+	"context"
+	"sync"
+)
+
+// Local holds locks within this process, for a single instance
+type Local struct {
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+// NewLocal creates an in-process locker
+func NewLocal() *Local {
+	return &Local{held: make(map[string]bool)}
+}
+
+// TryLock takes the named lock if no goroutine of this process holds it
+func (l *Local) TryLock(ctx context.Context, name string) (Lock, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held[name] {
+		return nil, ErrLocked
+	}
+	l.held[name] = true
+	return &localLock{locker: l, name: name}, nil
+}
+
+type localLock struct {
+	locker *Local
+	name   string
+	once   sync.Once
+}
+
+func (l *localLock) Release() error {
+	l.once.Do(func() {
+		l.locker.mu.Lock()
+		delete(l.locker.held, l.name)
+		l.locker.mu.Unlock()
+	})
+	return nil
+}
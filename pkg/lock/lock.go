@@ -0,0 +1,90 @@
+// Package lock coordinates instances of the service with named locks, so that
+// replicas starting or ticking together don't run the same work twice.
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrLocked is returned by TryLock when another holder has the lock
+var ErrLocked = errors.New("lock is held elsewhere")
+
+// pollInterval is how often Acquire retries a held lock
+const pollInterval = 500 * time.Millisecond
+
+// Locker takes named locks shared by every instance using the same backend
+type Locker interface {
+	// TryLock takes the named lock if it is free, or returns ErrLocked. The
+	// lock is held until it is released or the instance holding it dies.
+	TryLock(ctx context.Context, name string) (Lock, error)
+}
+
+// Lock is a held lock
+type Lock interface {
+	// Release frees the lock for other holders
+	Release() error
+}
+
+// Default is the locker of the service. It only coordinates within this
+// process until the server replaces it with a shared backend.
+var Default Locker = NewLocal()
+
+// New creates the locker of a backend: postgres (advisory locks on db),
+// redis (at redisAddr) or local (this process only)
+func New(backend string, db *gorm.DB, redisAddr, redisPassword string) (Locker, error) {
+	switch backend {
+	case "postgres":
+		return NewPostgres(db), nil
+	case "redis":
+		if redisAddr == "" {
+			return nil, errors.New("the redis lock backend needs LOCK_REDIS_ADDR")
+		}
+		return NewRedis(redisAddr, redisPassword), nil
+	case "local":
+		return NewLocal(), nil
+	default:
+		return nil, fmt.Errorf("unknown lock backend %q", backend)
+	}
+}
+
+// Acquire waits for the named lock until it is taken or ctx is done
+func Acquire(ctx context.Context, locker Locker, name string) (Lock, error) {
+	for {
+		held, err := locker.TryLock(ctx, name)
+		if !errors.Is(err, ErrLocked) {
+			return held, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for lock %s: %w", name, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// Exclusive runs fn if this instance takes the named lock and reports whether
+// it did. Instances that find the lock held skip fn. The lock is kept for hold
+// after fn returns, so the other instances skip their runs until then rather
+// than repeating the work a moment later.
+func Exclusive(ctx context.Context, locker Locker, name string, hold time.Duration, fn func()) (bool, error) {
+	held, err := locker.TryLock(ctx, name)
+	if errors.Is(err, ErrLocked) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	fn()
+	time.AfterFunc(hold, func() {
+		if err := held.Release(); err != nil {
+			log.Printf("Warning: failed to release lock %s: %v", name, err)
+		}
+	})
+	return true, nil
+}
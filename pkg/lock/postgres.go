@@ -0,0 +1,70 @@
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+
+	"gorm.io/gorm"
+)
+
+// Postgres holds locks as session-level advisory locks. Each held lock pins a
+// pooled connection, and Postgres frees the lock if that connection drops.
+type Postgres struct {
+	db *gorm.DB
+}
+
+// NewPostgres creates a locker on the database
+func NewPostgres(db *gorm.DB) *Postgres {
+	return &Postgres{db: db}
+}
+
+// advisoryKey maps a lock name to the 64-bit key of an advisory lock
+func advisoryKey(name string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+	return int64(hash.Sum64())
+}
+
+// TryLock takes the named advisory lock if no session holds it
+func (p *Postgres) TryLock(ctx context.Context, name string) (Lock, error) {
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a connection for lock %s: %v", name, err)
+	}
+
+	key := advisoryKey(name)
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to take lock %s: %v", name, err)
+	}
+	if !acquired {
+		conn.Close()
+		return nil, ErrLocked
+	}
+	return &postgresLock{conn: conn, key: key}, nil
+}
+
+type postgresLock struct {
+	conn *sql.Conn
+	key  int64
+}
+
+// Release unlocks and returns the connection to the pool. Should the unlock
+// fail, the connection is discarded instead, which frees the lock as well.
+func (l *postgresLock) Release() error {
+	var released bool
+	err := l.conn.QueryRowContext(context.Background(), "SELECT pg_advisory_unlock($1)", l.key).Scan(&released)
+	if err != nil {
+		l.conn.Raw(func(driverConn interface{}) error { return driver.ErrBadConn })
+	}
+	l.conn.Close()
+	return err
+}
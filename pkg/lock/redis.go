@@ -0,0 +1,112 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis lock leases
+const (
+	// redisLease is how long a lock outlives an instance that dies holding it
+	redisLease = 30 * time.Second
+	// redisTimeout bounds every command
+	redisTimeout = 5 * time.Second
+)
+
+// Scripts that only touch a lock while it still carries the holder's token
+var (
+	redisReleaseScript = redis.NewScript(`if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`)
+	redisExtendScript  = redis.NewScript(`if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("pexpire", KEYS[1], ARGV[2]) else return 0 end`)
+)
+
+// Redis holds locks as keys set with NX and a lease that is extended while
+// they are held
+type Redis struct {
+	client *redis.Client
+}
+
+// NewRedis creates a locker on the Redis server at addr, e.g. localhost:6379
+func NewRedis(addr, password string) *Redis {
+	return &Redis{client: redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+	})}
+}
+
+// redisKey returns the key of a lock
+func redisKey(name string) string {
+	return "lock:" + name
+}
+
+// TryLock takes the named lock if no holder has its key
+func (r *Redis) TryLock(ctx context.Context, name string) (Lock, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(buf)
+
+	ctx, cancel := context.WithTimeout(ctx, redisTimeout)
+	defer cancel()
+	taken, err := r.client.SetNX(ctx, redisKey(name), token, redisLease).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to take lock %s: %v", name, err)
+	}
+	if !taken {
+		return nil, ErrLocked
+	}
+
+	held := &redisLock{client: r.client, name: name, token: token, stop: make(chan struct{})}
+	go held.extend()
+	return held, nil
+}
+
+type redisLock struct {
+	client *redis.Client
+	name   string
+	token  string
+	stop   chan struct{}
+	once   sync.Once
+}
+
+// extend renews the lease until the lock is released
+func (l *redisLock) extend() {
+	ticker := time.NewTicker(redisLease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+		extended, err := redisExtendScript.Run(ctx, l.client, []string{redisKey(l.name)}, l.token, redisLease.Milliseconds()).Int64()
+		cancel()
+		if err != nil {
+			log.Printf("Warning: failed to extend lock %s: %v", l.name, err)
+			continue
+		}
+		if extended == 0 {
+			log.Printf("Warning: lock %s expired while held", l.name)
+			return
+		}
+	}
+}
+
+func (l *redisLock) Release() error {
+	var err error
+	l.once.Do(func() {
+		close(l.stop)
+		ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+		defer cancel()
+		err = redisReleaseScript.Run(ctx, l.client, []string{redisKey(l.name)}, l.token).Err()
+	})
+	return err
+}
@@ -0,0 +1,28 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+// TestRedisDownFails checks that a Redis server that refuses connections fails
+// TryLock rather than reporting the lock as held elsewhere
+func TestRedisDownFails(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	held, err := NewRedis(addr, "").TryLock(context.Background(), "test")
+	if err == nil {
+		held.Release()
+		t.Fatal("TryLock took a lock on a server that is down")
+	}
+	if errors.Is(err, ErrLocked) {
+		t.Errorf("TryLock = %v, want a connection error", err)
+	}
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// verbose is the time verbose logging ends, zero when it is off
+var verbose struct {
+	sync.Mutex
+	until time.Time
+	timer *time.Timer
+}
+
+// baseLevel is the level logged at while verbose logging is off, guarded by
+// the verbose lock
+var baseLevel = logrus.InfoLevel
+
+// SetLevel sets the level logged at while verbose logging is off. While it is
+// on, the level applies once it ends.
+func SetLevel(level logrus.Level) {
+	verbose.Lock()
+	defer verbose.Unlock()
+	baseLevel = level
+	if verbose.until.IsZero() {
+		Log.SetLevel(level)
+	}
+}
+
+// SetVerbose logs at debug level until the given time, after which the level
+// goes back to the one set by SetLevel. A time that is not in the future turns
+// it off.
+func SetVerbose(until time.Time) {
+	verbose.Lock()
+	defer verbose.Unlock()
+	if verbose.timer != nil {
+		verbose.timer.Stop()
+		verbose.timer = nil
+	}
+	if !until.After(time.Now()) {
+		verbose.until = time.Time{}
+		Log.SetLevel(baseLevel)
+		return
+	}
+	verbose.until = until
+	Log.SetLevel(max(logrus.DebugLevel, baseLevel))
+	verbose.timer = time.AfterFunc(time.Until(until), func() {
+		verbose.Lock()
+		defer verbose.Unlock()
+		// A later call may have extended it while this timer fired
+		if verbose.until.Equal(until) {
+			verbose.until = time.Time{}
+			Log.SetLevel(baseLevel)
+		}
+	})
+}
+
+// VerboseUntil returns the time verbose logging ends, or zero when it is off
+func VerboseUntil() time.Time {
+	verbose.Lock()
+	defer verbose.Unlock()
+	return verbose.until
+}
+
+// Verbose reports whether verbose logging is on
+func Verbose() bool {
+	return Log.IsLevelEnabled(logrus.DebugLevel)
+}
@@ -0,0 +1,188 @@
+// Package mailer renders templated HTML and text emails and sends them from a
+// background queue, retrying transient failures and skipping suppressed addresses.
+package mailer
+
+import (
+	"errors"
+	"log"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull is returned when the queue cannot take another message
+var ErrQueueFull = errors.New("mail queue is full")
+
+// SuppressionList holds addresses that must not be emailed, such as addresses
+// that bounced permanently
+type SuppressionList interface {
+	IsSuppressed(email string) (bool, error)
+	Suppress(email, reason string) error
+}
+
+// Mailer renders messages and sends them through a queue
+type Mailer struct {
+	sender       Sender
+	templates    *Templates
+	suppressions SuppressionList
+	from         string
+	maxAttempts  int
+	jobs         chan job
+	pending      sync.WaitGroup // Queued messages not yet sent or given up on
+}
+
+type job struct {
+	message Message
+	attempt int
+}
+
+// Default is the global mailer. A nil Mailer drops every message, so code that
+// sends mail also runs in tools that never configure one.
+var Default *Mailer
+
+// New creates a mailer holding up to queueSize messages. Each message is tried
+// at most maxAttempts times.
+func New(sender Sender, templates *Templates, suppressions SuppressionList, from string, queueSize, maxAttempts int) *Mailer {
+	return &Mailer{
+		sender:       sender,
+		templates:    templates,
+		suppressions: suppressions,
+		from:         from,
+		maxAttempts:  maxAttempts,
+		jobs:         make(chan job, queueSize),
+	}
+}
+
+// Check verifies that the sender can deliver messages
+func (m *Mailer) Check() error {
+	if m == nil {
+		return errors.New("mail is not configured")
+	}
+	return m.sender.Check()
+}
+
+// Enqueue renders a template for a tenant and queues the message to the address.
+// Rendering errors are returned immediately; sending happens in the background.
+func (m *Mailer) Enqueue(tenant, template, to string, data interface{}) error {
+	if m == nil {
+		return nil
+	}
+	message, err := m.templates.Render(tenant, template, data)
+	if err != nil {
+		return err
+	}
+	message.From = m.from
+	message.To = to
+
+	m.pending.Add(1)
+	select {
+	case m.jobs <- job{message: *message}:
+		return nil
+	default:
+		m.pending.Done()
+		return ErrQueueFull
+	}
+}
+
+// Wait blocks until every queued message was sent or given up on. Workers must
+// be running, so tools that send mail and exit call it before stopping them.
+func (m *Mailer) Wait() {
+	if m == nil {
+		return
+	}
+	m.pending.Wait()
+}
+
+// Start runs workers sending queued messages. It returns a function that stops
+// them after their current message.
+func (m *Mailer) Start(workers int) func() {
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				case j := <-m.jobs:
+					m.deliver(j, stop)
+				}
+			}
+		}()
+	}
+	return func() {
+		close(stop)
+		wg.Wait()
+	}
+}
+
+// deliver sends a message, requeueing it with exponential backoff after a
+// transient failure and suppressing the address after a permanent rejection
+func (m *Mailer) deliver(j job, stop <-chan struct{}) {
+	requeued := false
+	defer func() {
+		if !requeued {
+			m.pending.Done()
+		}
+	}()
+
+	to := j.message.To
+	if m.suppressions != nil {
+		suppressed, err := m.suppressions.IsSuppressed(to)
+		if err != nil {
+			log.Printf("Warning: failed to check suppression of %s: %v", to, err)
+		} else if suppressed {
+			log.Printf("Skipping %q to suppressed address %s", j.message.Subject, to)
+			return
+		}
+	}
+
+	err := m.sender.Send(j.message)
+	if err == nil {
+		return
+	}
+
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) && smtpErr.Code >= 500 {
+		log.Printf("Warning: %q to %s rejected: %v", j.message.Subject, to, err)
+		if mailboxUnavailable(smtpErr.Code) && m.suppressions != nil {
+			if err := m.suppressions.Suppress(to, smtpErr.Error()); err != nil {
+				log.Printf("Warning: failed to suppress %s: %v", to, err)
+			}
+		}
+		return
+	}
+
+	j.attempt++
+	if j.attempt >= m.maxAttempts {
+		log.Printf("Warning: giving up on %q to %s after %d attempts: %v", j.message.Subject, to, j.attempt, err)
+		return
+	}
+	log.Printf("Warning: failed to send %q to %s, retrying: %v", j.message.Subject, to, err)
+
+	select {
+	case <-stop:
+		return
+	case <-time.After(time.Second << (j.attempt - 1)):
+	}
+	select {
+	case m.jobs <- j:
+		requeued = true
+	default:
+		log.Printf("Warning: dropping %q to %s: %v", j.message.Subject, to, ErrQueueFull)
+	}
+}
+
+// mailboxUnavailable reports whether an SMTP code means the recipient does not
+// exist, as opposed to the message being refused
+func mailboxUnavailable(code int) bool {
+	return code == 550 || code == 551 || code == 553
+}
+
+// NormalizeAddress lowercases and trims an address for suppression lookups
+func NormalizeAddress(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
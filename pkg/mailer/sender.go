@@ -0,0 +1,141 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+)
+
+// Message is a rendered email
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Sender delivers a message
+type Sender interface {
+	Send(message Message) error
+	// Check verifies that messages can be sent, without sending one
+	Check() error
+}
+
+// NewSender creates the sender of a driver ("log" or "smtp")
+func NewSender(driver, host string, port int, username, password string) (Sender, error) {
+	switch driver {
+	case "log":
+		return LogSender{}, nil
+	case "smtp":
+		return &SMTP{addr: net.JoinHostPort(host, strconv.Itoa(port)), host: host, username: username, password: password}, nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", driver)
+	}
+}
+
+// LogSender writes messages to the log instead of sending them, for development
+type LogSender struct{}
+
+// Send logs the message
+func (LogSender) Send(message Message) error {
+	log.Printf("Mail to %s: %s\n%s", message.To, message.Subject, message.Text)
+	return nil
+}
+
+// Check always succeeds, the log being the destination
+func (LogSender) Check() error {
+	return nil
+}
+
+// SMTP sends messages through an SMTP server, using STARTTLS when offered
+type SMTP struct {
+	addr     string
+	host     string
+	username string
+	password string
+}
+
+// Send delivers the message as multipart/alternative with text and HTML parts
+func (s *SMTP) Send(message Message) error {
+	body, err := encode(message)
+	if err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+	return smtp.SendMail(s.addr, auth, message.From, []string{message.To}, body)
+}
+
+// checkTimeout bounds Check, which unlike a background send has someone waiting
+const checkTimeout = 10 * time.Second
+
+// Check connects to the server and signs in as Send does, then quits
+func (s *SMTP) Check() error {
+	conn, err := net.DialTimeout("tcp", s.addr, checkTimeout)
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(checkTimeout))
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+	if err := client.Hello("localhost"); err != nil {
+		return err
+	}
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("starttls: %v", err)
+		}
+	}
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("auth: %v", err)
+		}
+	}
+	return client.Quit()
+}
+
+// encode builds the MIME representation of a message
+func encode(message Message) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", message.Text},
+		{"text/html; charset=utf-8", message.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "From: %s\r\n", message.From)
+	fmt.Fprintf(&out, "To: %s\r\n", message.To)
+	fmt.Fprintf(&out, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&out, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&out, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&out, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	out.Write(body.Bytes())
+	return out.Bytes(), nil
+}
@@ -0,0 +1,102 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+)
+
+// DefaultTenant selects the built-in templates without overrides
+const DefaultTenant = ""
+
+// Template names
+const (
+	TemplateWelcome           = "welcome"
+	TemplateVerification      = "verification"
+	TemplatePasswordReset     = "password_reset"
+	TemplateOrderConfirmation = "order_confirmation"
+	TemplateLowStock          = "low_stock"
+	TemplateNotification      = "notification"
+	TemplateWeeklyDigest      = "weekly_digest"
+	TemplateWeeklyReport      = "weekly_report"
+	TemplateLatencyBudget     = "latency_budget"
+)
+
+//go:embed templates
+var embedded embed.FS
+
+// Templates renders emails from the embedded templates. Each template is a pair
+// of files, name.html and name.txt; the text file also defines the "subject"
+// block. A tenant overrides either file by placing its own copy in
+// overrideDir/tenant/.
+type Templates struct {
+	overrideDir string
+}
+
+// NewTemplates creates templates with tenant overrides read from overrideDir,
+// or none when it is empty
+func NewTemplates(overrideDir string) *Templates {
+	return &Templates{overrideDir: overrideDir}
+}
+
+// Render renders the named template of a tenant with data
+func (t *Templates) Render(tenant, name string, data interface{}) (*Message, error) {
+	textSource, err := t.read(tenant, name+".txt")
+	if err != nil {
+		return nil, err
+	}
+	htmlSource, err := t.read(tenant, name+".html")
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := texttemplate.New(name).Option("missingkey=error").Parse(textSource)
+	if err != nil {
+		return nil, err
+	}
+	var subject, textBody bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := text.Execute(&textBody, data); err != nil {
+		return nil, err
+	}
+
+	html, err := htmltemplate.New(name).Option("missingkey=error").Parse(htmlSource)
+	if err != nil {
+		return nil, err
+	}
+	var htmlBody bytes.Buffer
+	if err := html.Execute(&htmlBody, data); err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(textBody.String()) + "\n",
+		HTML:    htmlBody.String(),
+	}, nil
+}
+
+// read returns a tenant's override of a template file, or the embedded one
+func (t *Templates) read(tenant, file string) (string, error) {
+	if t.overrideDir != "" && tenant != DefaultTenant && !strings.ContainsAny(tenant, `/\.`) {
+		content, err := os.ReadFile(filepath.Join(t.overrideDir, tenant, file))
+		if err == nil {
+			return string(content), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+	}
+	content, err := fs.ReadFile(embedded, "templates/"+file)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
@@ -0,0 +1,106 @@
+// Package marketprices fetches the prices competitors sell a product at from
+// external price comparison APIs. Each API is reached through a Provider built
+// from its configuration, and calls to it are kept within its rate limit.
+package marketprices
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"product-management/pkg/resilience"
+)
+
+// Quote is the price a competitor sells a SKU at
+type Quote struct {
+	Competitor string    `json:"competitor"`
+	Price      float64   `json:"price"`
+	Currency   string    `json:"currency"`
+	URL        string    `json:"url"`
+	InStock    *bool     `json:"in_stock"` // Nil when the API doesn't tell
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+// Provider fetches competitor prices from one price comparison API
+type Provider interface {
+	// Fetch returns the competitor prices of a SKU, empty when none is known
+	Fetch(ctx context.Context, sku string) ([]Quote, error)
+}
+
+// Config configures a provider
+type Config struct {
+	Name     string            // Identifies the provider in logs and the admin API
+	Type     string            // Implementation: rest
+	Limits   resilience.Policy // Rate limit of the API and when to stop calling it
+	Settings map[string]string // Settings of the implementation, e.g. base_url
+}
+
+// Guarded is a provider whose calls are rate limited and suspended while the
+// API keeps failing
+type Guarded struct {
+	Name     string
+	provider Provider
+	guard    *resilience.Guard
+}
+
+// Fetch returns the competitor prices of a SKU once the rate limit allows
+func (g *Guarded) Fetch(ctx context.Context, sku string) ([]Quote, error) {
+	var quotes []Quote
+	err := g.guard.Do(ctx, func(ctx context.Context) error {
+		var err error
+		quotes, err = g.provider.Fetch(ctx, sku)
+		return err
+	})
+	return quotes, err
+}
+
+// namePattern matches a valid provider name, which is also part of the names
+// of its environment variables
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// builders create the provider of each type from its settings
+var builders = map[string]func(settings map[string]string) (Provider, error){
+	"rest": newREST,
+}
+
+// Types returns the provider types, sorted
+func Types() []string {
+	types := make([]string, 0, len(builders))
+	for name := range builders {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// New creates the guarded provider of a configuration, failing when its
+// settings are incomplete. It does not call the API.
+func New(cfg Config) (*Guarded, error) {
+	if !namePattern.MatchString(cfg.Name) {
+		return nil, fmt.Errorf("invalid market price provider name %q, expected lowercase letters, digits and underscores", cfg.Name)
+	}
+	build, ok := builders[cfg.Type]
+	if !ok {
+		return nil, fmt.Errorf("market price provider %s: unknown type %q, expected one of %s", cfg.Name, cfg.Type, strings.Join(Types(), ", "))
+	}
+	if cfg.Limits.Rate < 0 || (cfg.Limits.Rate > 0 && cfg.Limits.Window <= 0) {
+		return nil, fmt.Errorf("market price provider %s: rate limit must be positive over a positive window", cfg.Name)
+	}
+	provider, err := build(cfg.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("market price provider %s: %w", cfg.Name, err)
+	}
+	return &Guarded{Name: cfg.Name, provider: provider, guard: resilience.NewGuard(cfg.Limits)}, nil
+}
+
+// required returns a setting, failing when it is empty
+func required(settings map[string]string, key string) (string, error) {
+	value := strings.TrimSpace(settings[key])
+	if value == "" {
+		return "", fmt.Errorf("missing setting %s", key)
+	}
+	return value, nil
+}
@@ -0,0 +1,80 @@
+package marketprices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxResponseSize is the largest response a REST provider reads
+const maxResponseSize = 4 << 20
+
+// REST fetches competitor prices from an API answering GET requests for a SKU
+// with a JSON array of quotes
+type REST struct {
+	client  *http.Client
+	baseURL string
+	path    string // Contains {sku}, replaced by the escaped SKU
+	token   string // Sent as a bearer token when set
+}
+
+// newREST creates a REST provider from the settings base_url, token and path,
+// which defaults to /prices/{sku}
+func newREST(settings map[string]string) (Provider, error) {
+	baseURL, err := required(settings, "base_url")
+	if err != nil {
+		return nil, err
+	}
+	if parsed, err := url.Parse(baseURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid base_url %q", baseURL)
+	}
+	path := strings.TrimSpace(settings["path"])
+	if path == "" {
+		path = "/prices/{sku}"
+	}
+	if !strings.Contains(path, "{sku}") {
+		return nil, fmt.Errorf("invalid path %q, expected it to contain {sku}", path)
+	}
+	return &REST{
+		client:  &http.Client{Timeout: 10 * time.Second},
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		path:    "/" + strings.TrimPrefix(path, "/"),
+		token:   settings["token"],
+	}, nil
+}
+
+// Fetch requests the quotes of a SKU. A 404 means no competitor sells it.
+func (r *REST) Fetch(ctx context.Context, sku string) ([]Quote, error) {
+	path := strings.ReplaceAll(r.path, "{sku}", url.PathEscape(sku))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return []Quote{}, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("GET %s returned %s: %s", path, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	var quotes []Quote
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&quotes); err != nil {
+		return nil, fmt.Errorf("GET %s: invalid response: %w", path, err)
+	}
+	return quotes, nil
+}
@@ -0,0 +1,56 @@
+// Package mtls configures listeners that require clients to present a
+// certificate signed by a trusted CA, and derives the client's identity from
+// that certificate.
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+)
+
+// ServerConfig returns a TLS configuration that only accepts clients with a
+// certificate signed by one of the CAs in the PEM file
+func ServerConfig(clientCAFile string) (*tls.Config, error) {
+	pem, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no CA certificates found in " + clientCAFile)
+	}
+	return &tls.Config{
+		ClientCAs:  pool,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
+// Identity returns the name a client certificate identifies its holder by: its
+// first URI SAN, such as a SPIFFE ID, else its common name, else its first DNS SAN
+func Identity(cert *x509.Certificate) string {
+	if len(cert.URIs) > 0 {
+		return cert.URIs[0].String()
+	}
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	return ""
+}
+
+// Names returns every name in a client certificate: URI SANs, the common name and DNS SANs
+func Names(cert *x509.Certificate) []string {
+	names := make([]string, 0, len(cert.URIs)+len(cert.DNSNames)+1)
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+	if cert.Subject.CommonName != "" {
+		names = append(names, cert.Subject.CommonName)
+	}
+	return append(names, cert.DNSNames...)
+}
@@ -0,0 +1,145 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+
+// FCM sends push notifications through the Firebase Cloud Messaging HTTP v1
+// API, authenticating as a service account
+type FCM struct {
+	client      *http.Client
+	projectID   string
+	clientEmail string
+	privateKey  *rsa.PrivateKey
+	tokenURI    string
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewFCM creates a push notifier from the JSON key file of a service account
+// allowed to send messages for the Firebase project
+func NewFCM(projectID, credentialsFile string) (*FCM, error) {
+	data, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, err
+	}
+	var credentials struct {
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+		TokenURI    string `json:"token_uri"`
+	}
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return nil, fmt.Errorf("invalid service account file: %v", err)
+	}
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(credentials.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid service account key: %v", err)
+	}
+	if credentials.TokenURI == "" {
+		credentials.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+	return &FCM{
+		client:      &http.Client{Timeout: 10 * time.Second},
+		projectID:   projectID,
+		clientEmail: credentials.ClientEmail,
+		privateKey:  privateKey,
+		tokenURI:    credentials.TokenURI,
+	}, nil
+}
+
+// Notify pushes the notification to a device registration token
+func (f *FCM) Notify(ctx context.Context, to string, notification Notification) error {
+	accessToken, err := f.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"message": map[string]interface{}{
+			"token": to,
+			"notification": map[string]string{
+				"title": notification.Title,
+				"body":  notification.Body,
+			},
+			"data": notification.Data,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("https://fcm.googleapis.com/v1/projects/%s/messages:send", f.projectID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+	return send(f.client, req)
+}
+
+// token returns an OAuth access token, exchanging a signed service account
+// assertion for a new one shortly before the current one expires
+func (f *FCM) token(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.accessToken != "" && time.Now().Before(f.expiresAt.Add(-time.Minute)) {
+		return f.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   f.clientEmail,
+		"scope": fcmScope,
+		"aud":   f.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(f.privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("service account token request failed with %s", resp.Status)
+	}
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+
+	f.accessToken = result.AccessToken
+	f.expiresAt = now.Add(time.Duration(result.ExpiresIn) * time.Second)
+	return f.accessToken, nil
+}
@@ -0,0 +1,46 @@
+// Package notifier delivers short notifications over channels other than
+// email: SMS through Twilio and push through Firebase Cloud Messaging.
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Notification is a short message. SMS sends only the body, so it must make
+// sense without the title.
+type Notification struct {
+	Title string
+	Body  string
+	Data  map[string]string // Extra key/value pairs delivered with push notifications
+}
+
+// Notifier delivers a notification to an address of its channel, such as a
+// phone number or a device token
+type Notifier interface {
+	Notify(ctx context.Context, to string, notification Notification) error
+}
+
+// SMS and Push are the global notifiers of each channel, nil when the channel
+// is not configured
+var (
+	SMS  Notifier
+	Push Notifier
+)
+
+// send performs a provider request and turns error responses into errors
+func send(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("notification failed with %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
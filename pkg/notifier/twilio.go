@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Twilio sends SMS through the Twilio Messages API
+type Twilio struct {
+	client     *http.Client
+	accountSID string
+	authToken  string
+	from       string
+}
+
+// NewTwilio creates an SMS notifier sending from a Twilio phone number
+func NewTwilio(accountSID, authToken, from string) *Twilio {
+	return &Twilio{
+		client:     &http.Client{Timeout: 10 * time.Second},
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+	}
+}
+
+// Notify texts the notification body to an E.164 phone number
+func (t *Twilio) Notify(ctx context.Context, to string, notification Notification) error {
+	form := url.Values{
+		"To":   {to},
+		"From": {t.from},
+		"Body": {notification.Body},
+	}
+	endpoint := fmt.Sprintf("https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json", t.accountSID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(t.accountSID, t.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return send(t.client, req)
+}
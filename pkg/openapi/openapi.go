@@ -0,0 +1,292 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Convert translates a Swagger 2.0 document (as produced by swag) into an OpenAPI 3.0 document
+func Convert(swagger []byte) ([]byte, error) {
+	var src map[string]interface{}
+	if err := json.Unmarshal(swagger, &src); err != nil {
+		return nil, fmt.Errorf("failed to parse swagger document: %v", err)
+	}
+
+	doc := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info":    src["info"],
+		"servers": servers(src),
+		"paths":   map[string]interface{}{},
+	}
+
+	components := map[string]interface{}{}
+	if definitions, ok := src["definitions"]; ok {
+		components["schemas"] = definitions
+	}
+	if securityDefinitions, ok := src["securityDefinitions"].(map[string]interface{}); ok {
+		components["securitySchemes"] = securitySchemes(securityDefinitions)
+	}
+	doc["components"] = components
+
+	produces := stringSlice(src["produces"])
+	consumes := stringSlice(src["consumes"])
+
+	if paths, ok := src["paths"].(map[string]interface{}); ok {
+		converted := doc["paths"].(map[string]interface{})
+		for path, item := range paths {
+			operations, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			convertedItem := map[string]interface{}{}
+			for method, op := range operations {
+				operation, ok := op.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				convertedItem[method] = convertOperation(operation, consumes, produces)
+			}
+			converted[path] = convertedItem
+		}
+	}
+
+	out, err := json.MarshalIndent(rewriteRefs(doc), "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// servers builds the OpenAPI servers list from host, basePath and schemes
+func servers(src map[string]interface{}) []map[string]interface{} {
+	host, _ := src["host"].(string)
+	basePath, _ := src["basePath"].(string)
+	schemes := stringSlice(src["schemes"])
+	if len(schemes) == 0 {
+		schemes = []string{"http"}
+	}
+
+	var result []map[string]interface{}
+	for _, scheme := range schemes {
+		if scheme == "" {
+			continue
+		}
+		url := basePath
+		if host != "" {
+			url = scheme + "://" + host + basePath
+		}
+		result = append(result, map[string]interface{}{"url": url})
+	}
+	if len(result) == 0 {
+		result = append(result, map[string]interface{}{"url": basePath})
+	}
+	return result
+}
+
+// securitySchemes converts Swagger 2 security definitions to OpenAPI 3 security schemes
+func securitySchemes(definitions map[string]interface{}) map[string]interface{} {
+	schemes := map[string]interface{}{}
+	for name, def := range definitions {
+		definition, ok := def.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		scheme := map[string]interface{}{}
+		for key, value := range definition {
+			scheme[key] = value
+		}
+		if definition["type"] == "apiKey" && strings.EqualFold(fmt.Sprint(definition["name"]), "Authorization") {
+			// The API expects "Bearer <token>", which OpenAPI 3 models as HTTP bearer auth
+			scheme = map[string]interface{}{
+				"type":         "http",
+				"scheme":       "bearer",
+				"bearerFormat": "JWT",
+			}
+			if description, ok := definition["description"]; ok {
+				scheme["description"] = description
+			}
+		}
+		schemes[name] = scheme
+	}
+	return schemes
+}
+
+// convertOperation moves body/formData parameters into a requestBody and wraps response schemas in content
+func convertOperation(operation map[string]interface{}, consumes, produces []string) map[string]interface{} {
+	result := map[string]interface{}{}
+	for key, value := range operation {
+		switch key {
+		case "parameters", "responses", "consumes", "produces":
+		default:
+			result[key] = value
+		}
+	}
+
+	if opConsumes := stringSlice(operation["consumes"]); len(opConsumes) > 0 {
+		consumes = opConsumes
+	}
+	if len(consumes) == 0 {
+		consumes = []string{"application/json"}
+	}
+	if opProduces := stringSlice(operation["produces"]); len(opProduces) > 0 {
+		produces = opProduces
+	}
+	if len(produces) == 0 {
+		produces = []string{"application/json"}
+	}
+
+	var parameters []interface{}
+	formProperties := map[string]interface{}{}
+	var formRequired []interface{}
+	if params, ok := operation["parameters"].([]interface{}); ok {
+		for _, p := range params {
+			param, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			switch param["in"] {
+			case "body":
+				requestBody := map[string]interface{}{
+					"content": contentFor(consumes, param["schema"]),
+				}
+				if description, ok := param["description"]; ok {
+					requestBody["description"] = description
+				}
+				if required, ok := param["required"]; ok {
+					requestBody["required"] = required
+				}
+				result["requestBody"] = requestBody
+			case "formData":
+				formProperties[fmt.Sprint(param["name"])] = parameterSchema(param)
+				if required, _ := param["required"].(bool); required {
+					formRequired = append(formRequired, param["name"])
+				}
+			default:
+				parameters = append(parameters, convertParameter(param))
+			}
+		}
+	}
+	if len(formProperties) > 0 {
+		schema := map[string]interface{}{"type": "object", "properties": formProperties}
+		if len(formRequired) > 0 {
+			schema["required"] = formRequired
+		}
+		result["requestBody"] = map[string]interface{}{
+			"content": contentFor(consumes, schema),
+		}
+	}
+	if len(parameters) > 0 {
+		result["parameters"] = parameters
+	}
+
+	responses := map[string]interface{}{}
+	if srcResponses, ok := operation["responses"].(map[string]interface{}); ok {
+		for code, r := range srcResponses {
+			response, ok := r.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			converted := map[string]interface{}{}
+			description, _ := response["description"].(string)
+			converted["description"] = description
+			if schema, ok := response["schema"]; ok {
+				converted["content"] = contentFor(produces, schema)
+			}
+			if headers, ok := response["headers"].(map[string]interface{}); ok {
+				convertedHeaders := map[string]interface{}{}
+				for name, h := range headers {
+					if header, ok := h.(map[string]interface{}); ok {
+						convertedHeaders[name] = map[string]interface{}{"schema": header}
+					}
+				}
+				converted["headers"] = convertedHeaders
+			}
+			responses[code] = converted
+		}
+	}
+	result["responses"] = responses
+
+	return result
+}
+
+// convertParameter wraps the type information of a non-body parameter in a schema
+func convertParameter(param map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, key := range []string{"name", "in", "description", "required"} {
+		if value, ok := param[key]; ok {
+			result[key] = value
+		}
+	}
+	result["schema"] = parameterSchema(param)
+	if param["type"] == "array" && param["collectionFormat"] == "multi" {
+		result["explode"] = true
+	}
+	return result
+}
+
+// parameterSchema extracts the schema-related keys of a Swagger 2 parameter
+func parameterSchema(param map[string]interface{}) map[string]interface{} {
+	schema := map[string]interface{}{}
+	for _, key := range []string{"type", "format", "items", "enum", "default", "minimum", "maximum"} {
+		if value, ok := param[key]; ok {
+			schema[key] = value
+		}
+	}
+	if schema["type"] == "file" {
+		schema["type"] = "string"
+		schema["format"] = "binary"
+	}
+	return schema
+}
+
+// contentFor builds an OpenAPI 3 content map for the given media types
+func contentFor(mediaTypes []string, schema interface{}) map[string]interface{} {
+	content := map[string]interface{}{}
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = map[string]interface{}{"schema": schema}
+	}
+	return content
+}
+
+// rewriteRefs points every $ref at components/schemas instead of definitions
+func rewriteRefs(node interface{}) interface{} {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				value[key] = strings.Replace(ref, "#/definitions/", "#/components/schemas/", 1)
+				continue
+			}
+			value[key] = rewriteRefs(child)
+		}
+		return value
+	case []interface{}:
+		for i, child := range value {
+			value[i] = rewriteRefs(child)
+		}
+		return value
+	case []map[string]interface{}:
+		for i, child := range value {
+			value[i] = rewriteRefs(child).(map[string]interface{})
+		}
+		return value
+	default:
+		return node
+	}
+}
+
+// stringSlice converts a decoded JSON array into a slice of strings
+func stringSlice(value interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
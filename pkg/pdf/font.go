@@ -0,0 +1,76 @@
+package pdf
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Font is one of the standard fonts every PDF reader provides
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+// styles holds the gofpdf style of each font of the Helvetica family
+var styles = [...]string{
+	Helvetica:     "",
+	HelveticaBold: "B",
+}
+
+// metrics measures text outside of any document. gofpdf only measures in the
+// current font of a document, so the document is shared under a lock.
+var metrics = struct {
+	sync.Mutex
+	pdf    *gofpdf.Fpdf
+	encode func(string) string
+}{pdf: newFpdf(A4Width, A4Height)}
+
+func init() {
+	metrics.encode = metrics.pdf.UnicodeTranslatorFromDescriptor("")
+}
+
+func setFont(pdf *gofpdf.Fpdf, font Font, size float64) {
+	pdf.SetFont("Helvetica", styles[font], size)
+}
+
+// TextWidth returns the width of text in points as it is drawn by Page.Text
+func TextWidth(font Font, size float64, text string) float64 {
+	metrics.Lock()
+	defer metrics.Unlock()
+	setFont(metrics.pdf, font, size)
+	return metrics.pdf.GetStringWidth(metrics.encode(latin1(text)))
+}
+
+// Wrap breaks text into lines no wider than width, splitting at spaces and
+// within words longer than a line
+func Wrap(font Font, size float64, text string, width float64) []string {
+	metrics.Lock()
+	defer metrics.Unlock()
+	setFont(metrics.pdf, font, size)
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		// Latin-1 runes index the glyph widths of the standard fonts directly
+		split := metrics.pdf.SplitText(latin1(paragraph), width)
+		if len(split) == 0 {
+			split = []string{""}
+		}
+		lines = append(lines, split...)
+	}
+	return lines
+}
+
+// Truncate shortens text with an ellipsis to fit width
+func Truncate(font Font, size float64, text string, width float64) string {
+	if TextWidth(font, size, text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && TextWidth(font, size, string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimSpace(string(runes)) + "..."
+}
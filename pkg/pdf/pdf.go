@@ -0,0 +1,102 @@
+// Package pdf writes simple PDF documents of text and filled rectangles using
+// the standard Helvetica fonts, for printable product sheets and labels. It is
+// a thin layer over gofpdf with coordinates from the bottom left corner.
+package pdf
+
+import (
+	"bytes"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Page sizes in points
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+	MM       = 72 / 25.4 // Points per millimeter
+)
+
+// Document is a PDF document whose pages all have the same size
+type Document struct {
+	pdf    *gofpdf.Fpdf
+	height float64
+	encode func(string) string
+}
+
+// New creates an empty document with pages width by height points
+func New(width, height float64) *Document {
+	document := newFpdf(width, height)
+	return &Document{pdf: document, height: height, encode: document.UnicodeTranslatorFromDescriptor("")}
+}
+
+// newFpdf creates a gofpdf document measured in points without margins or
+// automatic page breaks
+func newFpdf(width, height float64) *gofpdf.Fpdf {
+	document := gofpdf.NewCustom(&gofpdf.InitType{UnitStr: "pt", Size: gofpdf.SizeType{Wd: width, Ht: height}})
+	document.SetMargins(0, 0, 0)
+	document.SetCellMargin(0)
+	document.SetAutoPageBreak(false, 0)
+	return document
+}
+
+// Page is a page of a document. Coordinates are in points from the bottom
+// left corner. Only the last page added can be drawn on.
+type Page struct {
+	document *Document
+}
+
+// AddPage appends a blank page to the document
+func (d *Document) AddPage() *Page {
+	d.pdf.AddPage()
+	return &Page{document: d}
+}
+
+// Text draws a line of text with its baseline starting at x, y. Characters
+// outside Latin-1 are replaced with a question mark.
+func (p *Page) Text(x, y float64, font Font, size float64, text string) {
+	d := p.document
+	setFont(d.pdf, font, size)
+	d.pdf.Text(x, d.height-y, d.encode(latin1(text)))
+}
+
+// Rect fills a rectangle with its bottom left corner at x, y in a gray level
+// from 0 (black) to 1 (white)
+func (p *Page) Rect(x, y, width, height float64, gray float64) {
+	d := p.document
+	level := int(gray*255 + 0.5)
+	d.pdf.SetFillColor(level, level, level)
+	d.pdf.Rect(x, d.height-y-height, width, height, "F")
+}
+
+// Line draws a line from x1, y1 to x2, y2
+func (p *Page) Line(x1, y1, x2, y2, lineWidth float64) {
+	d := p.document
+	d.pdf.SetDrawColor(0, 0, 0)
+	d.pdf.SetLineWidth(lineWidth)
+	d.pdf.Line(x1, d.height-y1, x2, d.height-y2)
+}
+
+// Bytes writes the document
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := d.pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// latin1 replaces control characters with spaces and characters the
+// WinAnsiEncoding of the standard fonts cannot show with a question mark
+func latin1(text string) string {
+	runes := []rune(text)
+	for i, r := range runes {
+		switch {
+		case r < ' ':
+			runes[i] = ' '
+		case r < 0x7f || (r >= 0xa0 && r <= 0xff):
+		default:
+			runes[i] = '?'
+		}
+	}
+	return string(runes)
+}
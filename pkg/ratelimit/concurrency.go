@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// ErrOverloaded is returned when a request can't get a slot in time
+var ErrOverloaded = errors.New("too many requests in flight")
+
+// ConcurrencyStats is a snapshot of a Concurrency limiter
+type ConcurrencyStats struct {
+	InFlight int64 // Requests holding a slot
+	Queued   int64 // Requests waiting for a slot
+	Shed     int64 // Requests rejected since startup
+}
+
+// Concurrency bounds the number of requests processed at once. Requests over
+// the limit wait in a bounded queue for up to a timeout and are shed after that,
+// so a spike fails fast instead of slowing every request down.
+type Concurrency struct {
+	slots      chan struct{}
+	queueDepth int64
+	timeout    time.Duration
+
+	queued atomic.Int64
+	shed   atomic.Int64
+}
+
+// NewConcurrency creates a limiter admitting maxInFlight requests at once with
+// up to queueDepth more waiting at most timeout each. A maxInFlight of zero or
+// less disables the limit.
+func NewConcurrency(maxInFlight, queueDepth int, timeout time.Duration) *Concurrency {
+	c := &Concurrency{queueDepth: int64(queueDepth), timeout: timeout}
+	if maxInFlight > 0 {
+		c.slots = make(chan struct{}, maxInFlight)
+	}
+	return c
+}
+
+// Timeout returns how long a request may wait in the queue
+func (c *Concurrency) Timeout() time.Duration {
+	return c.timeout
+}
+
+// Acquire waits for a slot and returns the function releasing it. It fails with
+// ErrOverloaded when the queue is full or the wait times out.
+func (c *Concurrency) Acquire(ctx context.Context) (func(), error) {
+	if c.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+		return c.release, nil
+	default:
+	}
+
+	if c.queued.Add(1) > c.queueDepth {
+		c.queued.Add(-1)
+		c.shed.Add(1)
+		return nil, ErrOverloaded
+	}
+	defer c.queued.Add(-1)
+
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+
+	select {
+	case c.slots <- struct{}{}:
+		return c.release, nil
+	case <-timer.C:
+		c.shed.Add(1)
+		return nil, ErrOverloaded
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Stats returns the current in-flight, queued and shed request counts
+func (c *Concurrency) Stats() ConcurrencyStats {
+	return ConcurrencyStats{
+		InFlight: int64(len(c.slots)),
+		Queued:   c.queued.Load(),
+		Shed:     c.shed.Load(),
+	}
+}
+
+func (c *Concurrency) release() {
+	<-c.slots
+}
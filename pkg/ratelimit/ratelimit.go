@@ -0,0 +1,170 @@
+// Package ratelimit implements in-process, fixed-window request quotas per
+// principal and route group, with limits chosen by the principal's tier.
+package ratelimit
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Tiers of principals, from the lowest default quota to the highest
+const (
+	TierAnonymous = "anonymous"
+	TierUser      = "user"
+	TierVendor    = "vendor"
+	TierAdmin     = "admin"
+	TierAPIKey    = "api_key"
+)
+
+// APIKey is a key granted its own quota
+type APIKey struct {
+	Name  string
+	Limit int
+}
+
+// Policy chooses the quota of a principal in a route group
+type Policy struct {
+	Tiers   map[string]int    // Requests per window for each tier
+	Groups  map[string]int    // Per route group overrides, keyed "group.tier"
+	APIKeys map[string]APIKey // Keys with their own quota, keyed by the key itself
+}
+
+// Limit returns the quota of a tier in a route group. Zero means unlimited.
+func (p Policy) Limit(group, tier string) int {
+	if limit, ok := p.Groups[group+"."+tier]; ok {
+		return limit
+	}
+	return p.Tiers[tier]
+}
+
+// Usage reports how much of its quota a principal used in a route group
+type Usage struct {
+	Group     string
+	Principal string
+	Tier      string
+	Limit     int
+	Used      int
+	ResetAt   time.Time
+}
+
+// Remaining returns the requests left in the current window
+func (u Usage) Remaining() int {
+	if u.Used >= u.Limit {
+		return 0
+	}
+	return u.Limit - u.Used
+}
+
+type bucketKey struct {
+	group     string
+	principal string
+}
+
+type bucket struct {
+	tier    string
+	limit   int
+	used    int
+	resetAt time.Time
+}
+
+// Limiter counts requests per principal and route group in fixed windows
+type Limiter struct {
+	mu        sync.Mutex
+	window    time.Duration
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+}
+
+// New creates a limiter with the given window length
+func New(window time.Duration) *Limiter {
+	return &Limiter{
+		window:    window,
+		buckets:   make(map[bucketKey]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow records a request of a principal in a route group and reports whether
+// it fits in the quota
+func (l *Limiter) Allow(group, principal, tier string, limit int) (Usage, bool) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	key := bucketKey{group: group, principal: principal}
+	b, ok := l.buckets[key]
+	if !ok || !now.Before(b.resetAt) {
+		b = &bucket{resetAt: now.Add(l.window)}
+		l.buckets[key] = b
+	}
+	b.tier = tier
+	b.limit = limit
+
+	allowed := b.used < limit
+	if allowed {
+		b.used++
+	}
+	return usage(key, b), allowed
+}
+
+// Usage returns the current usage of a principal in every route group it used
+func (l *Limiter) Usage(principal string) []Usage {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var usages []Usage
+	for key, b := range l.buckets {
+		if key.principal == principal && now.Before(b.resetAt) {
+			usages = append(usages, usage(key, b))
+		}
+	}
+	sort.Slice(usages, func(i, j int) bool { return usages[i].Group < usages[j].Group })
+	return usages
+}
+
+// Reset clears the quota usage of a principal in every route group and
+// returns the number of groups that were reset
+func (l *Limiter) Reset(principal string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	reset := 0
+	for key := range l.buckets {
+		if key.principal == principal {
+			delete(l.buckets, key)
+			reset++
+		}
+	}
+	return reset
+}
+
+// sweep drops expired buckets at most once per window so one-off principals
+// don't accumulate. The caller must hold the lock.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	for key, b := range l.buckets {
+		if !now.Before(b.resetAt) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+func usage(key bucketKey, b *bucket) Usage {
+	return Usage{
+		Group:     key.group,
+		Principal: key.principal,
+		Tier:      b.tier,
+		Limit:     b.limit,
+		Used:      b.used,
+		ResetAt:   b.resetAt,
+	}
+}
@@ -0,0 +1,292 @@
+// Package reqctx resolves the locale, currency and time zone a request is
+// served in, so services price, format and aggregate for the caller without
+// taking each of them as a separate parameter.
+package reqctx
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Context is the locale, currency and time zone of a request
+type Context struct {
+	Locale   string         // BCP 47 language tag, e.g. vi-VN
+	Currency string         // ISO 4217 code prices are shown in, e.g. VND
+	Rate     float64        // Units of Currency per unit of the base currency
+	Location *time.Location // Time zone dates are read and grouped in
+}
+
+// Timezone returns the IANA name of the time zone
+func (c Context) Timezone() string {
+	return c.Location.String()
+}
+
+// Convert converts an amount in the base currency to the context's currency,
+// rounded to cents
+func (c Context) Convert(amount float64) float64 {
+	return math.Round(amount*c.Rate*100) / 100
+}
+
+// FormatPrice formats an amount in the base currency for display, converted
+// to the context's currency with the decimal and grouping separators of its
+// language, e.g. 1,299.99 USD or 1.299,99 EUR
+func (c Context) FormatPrice(amount float64) string {
+	decimal, group := ".", ","
+	if commaDecimalLanguages[language(c.Locale)] {
+		decimal, group = ",", "."
+	}
+	text := strconv.FormatFloat(math.Abs(c.Convert(amount)), 'f', 2, 64)
+	whole, cents := text[:len(text)-3], text[len(text)-2:]
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteString(group)
+		}
+		grouped.WriteRune(digit)
+	}
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	return sign + grouped.String() + decimal + cents + " " + c.Currency
+}
+
+// FormatDate formats the calendar day of a time in the context's time zone
+func (c Context) FormatDate(t time.Time) string {
+	return t.In(c.Location).Format(time.DateOnly)
+}
+
+// commaDecimalLanguages write amounts with a decimal comma and dots between thousands
+var commaDecimalLanguages = map[string]bool{
+	"de": true, "es": true, "fr": true, "id": true, "it": true, "nl": true,
+	"pt": true, "ru": true, "tr": true, "vi": true,
+}
+
+// Preferences are the choices saved in a user's profile, empty where the user
+// has none
+type Preferences struct {
+	Locale   string `json:"locale"`
+	Currency string `json:"currency"`
+	Timezone string `json:"timezone"`
+}
+
+// Resolver resolves request contexts from the configured defaults, supported
+// locales and exchange rates
+type Resolver struct {
+	defaults Context
+	locales  []string
+	rates    map[string]float64
+}
+
+// Default is the resolver configured at startup
+var Default = mustResolver(NewResolver("en-US", nil, "USD", nil, "UTC"))
+
+// NewResolver creates a resolver. Locales are the supported BCP 47 tags, the
+// default one included when missing. Rates give the units of every other
+// supported currency per unit of the base currency prices are stored in.
+func NewResolver(defaultLocale string, locales []string, baseCurrency string, rates map[string]float64, defaultTimezone string) (*Resolver, error) {
+	locale, ok := ParseLocale(defaultLocale)
+	if !ok {
+		return nil, fmt.Errorf("invalid locale %q", defaultLocale)
+	}
+	currency, ok := ParseCurrency(baseCurrency)
+	if !ok {
+		return nil, fmt.Errorf("invalid currency %q", baseCurrency)
+	}
+	location, err := time.LoadLocation(defaultTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q", defaultTimezone)
+	}
+
+	resolver := &Resolver{
+		defaults: Context{Locale: locale, Currency: currency, Rate: 1, Location: location},
+		locales:  []string{locale},
+		rates:    map[string]float64{currency: 1},
+	}
+	for _, tag := range locales {
+		parsed, ok := ParseLocale(tag)
+		if !ok {
+			return nil, fmt.Errorf("invalid locale %q", tag)
+		}
+		if parsed != locale {
+			resolver.locales = append(resolver.locales, parsed)
+		}
+	}
+	for code, rate := range rates {
+		parsed, ok := ParseCurrency(code)
+		if !ok {
+			return nil, fmt.Errorf("invalid currency %q", code)
+		}
+		if rate <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate of %s: must be positive", parsed)
+		}
+		if parsed != currency {
+			resolver.rates[parsed] = rate
+		}
+	}
+	return resolver, nil
+}
+
+// mustResolver panics when the built-in default resolver is invalid
+func mustResolver(resolver *Resolver, err error) *Resolver {
+	if err != nil {
+		panic(err)
+	}
+	return resolver
+}
+
+// Defaults returns the context of requests with no preferences
+func (r *Resolver) Defaults() Context {
+	return r.defaults
+}
+
+// Currencies returns the supported currency codes, sorted
+func (r *Resolver) Currencies() []string {
+	codes := make([]string, 0, len(r.rates))
+	for code := range r.rates {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Validate normalizes saved preferences, returning an error naming the first
+// one that is not supported. Empty preferences stay empty.
+func (r *Resolver) Validate(preferences Preferences) (Preferences, error) {
+	if preferences.Locale != "" {
+		locale, ok := r.matchLocale(preferences.Locale)
+		if !ok {
+			return preferences, fmt.Errorf("unsupported locale %q, expected one of %s", preferences.Locale, strings.Join(r.locales, ", "))
+		}
+		preferences.Locale = locale
+	}
+	if preferences.Currency != "" {
+		currency, ok := ParseCurrency(preferences.Currency)
+		if _, supported := r.rates[currency]; !ok || !supported {
+			return preferences, fmt.Errorf("unsupported currency %q, expected one of %s", preferences.Currency, strings.Join(r.Currencies(), ", "))
+		}
+		preferences.Currency = currency
+	}
+	if preferences.Timezone != "" {
+		if _, err := time.LoadLocation(preferences.Timezone); err != nil {
+			return preferences, fmt.Errorf("unknown time zone %q", preferences.Timezone)
+		}
+	}
+	return preferences, nil
+}
+
+// Resolve builds a request context. Each of its parts comes from the request
+// when set there, then from the user's saved preferences, then from the
+// defaults. requested holds the X-Currency and X-Timezone headers, validated
+// with Validate, and the raw Accept-Language header, whose best supported
+// language wins.
+func (r *Resolver) Resolve(requested, saved Preferences) Context {
+	context := r.defaults
+	if locale, ok := r.preferredLocale(requested.Locale); ok {
+		context.Locale = locale
+	} else if locale, ok := r.matchLocale(saved.Locale); ok {
+		context.Locale = locale
+	}
+	for _, currency := range []string{requested.Currency, saved.Currency} {
+		if rate, ok := r.rates[currency]; ok && currency != "" {
+			context.Currency, context.Rate = currency, rate
+			break
+		}
+	}
+	for _, timezone := range []string{requested.Timezone, saved.Timezone} {
+		if timezone == "" {
+			continue
+		}
+		if location, err := time.LoadLocation(timezone); err == nil {
+			context.Location = location
+			break
+		}
+	}
+	return context
+}
+
+// preferredLocale picks the supported locale best matching an Accept-Language
+// header, honoring its quality values
+func (r *Resolver) preferredLocale(header string) (string, bool) {
+	best, bestQuality := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= bestQuality {
+			continue
+		}
+		if locale, ok := r.matchLocale(tag); ok {
+			best, bestQuality = locale, quality
+		}
+	}
+	return best, best != ""
+}
+
+// matchLocale returns the supported locale equal to a tag or, failing that,
+// the first one of the same language
+func (r *Resolver) matchLocale(tag string) (string, bool) {
+	parsed, ok := ParseLocale(tag)
+	if !ok {
+		return "", false
+	}
+	for _, locale := range r.locales {
+		if locale == parsed {
+			return locale, true
+		}
+	}
+	for _, locale := range r.locales {
+		if language(locale) == language(parsed) {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// ParseLocale normalizes a BCP 47 language tag such as vi_vn to vi-VN,
+// reporting whether it is well formed. Only the language and an optional
+// region are kept.
+func ParseLocale(tag string) (string, bool) {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	lang := strings.ToLower(parts[0])
+	if len(lang) < 2 || len(lang) > 3 || !isLetters(lang) {
+		return "", false
+	}
+	if len(parts) > 1 && len(parts[1]) == 2 && isLetters(parts[1]) {
+		return lang + "-" + strings.ToUpper(parts[1]), true
+	}
+	return lang, true
+}
+
+// ParseCurrency normalizes an ISO 4217 code to upper case, reporting whether
+// it is well formed
+func ParseCurrency(code string) (string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return code, len(code) == 3 && isLetters(code)
+}
+
+// language returns the language part of a normalized locale
+func language(locale string) string {
+	lang, _, _ := strings.Cut(locale, "-")
+	return lang
+}
+
+// isLetters reports whether s holds only ASCII letters
+func isLetters(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+// Package reqstats aggregates API request counts, status codes and latencies
+// in memory per time bucket, so they can be stored as a handful of rows
+// instead of one per request.
+package reqstats
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// LatencyBounds are the upper bounds, in milliseconds, of the latency
+// histogram buckets. A last bucket counts the slower requests.
+var LatencyBounds = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// Key identifies the requests of one route and status in one bucket
+type Key struct {
+	Bucket time.Time // Start of the time bucket, in UTC
+	Method string
+	Route  string // Route pattern such as /api/v1/products/:id
+	Status int
+}
+
+// Stat is the aggregate of the requests of a key
+type Stat struct {
+	Key
+	Count     int64
+	TotalMs   float64
+	MaxMs     float64
+	Histogram []int64 // Request counts per LatencyBounds bucket, plus the overflow bucket
+}
+
+// Recorder aggregates requests into time buckets of a fixed size
+type Recorder struct {
+	bucket time.Duration
+	mu     sync.Mutex
+	stats  map[Key]*Stat
+}
+
+// New creates a recorder with buckets of the given size
+func New(bucket time.Duration) *Recorder {
+	return &Recorder{bucket: bucket, stats: make(map[Key]*Stat)}
+}
+
+// Bucket returns the size of the recorder's time buckets
+func (r *Recorder) Bucket() time.Duration {
+	return r.bucket
+}
+
+// Record adds a request to the bucket it completed in
+func (r *Recorder) Record(method, route string, status int, duration time.Duration) {
+	ms := float64(duration) / float64(time.Millisecond)
+	key := Key{Bucket: time.Now().UTC().Truncate(r.bucket), Method: method, Route: route, Status: status}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stat, ok := r.stats[key]
+	if !ok {
+		stat = &Stat{Key: key, Histogram: make([]int64, len(LatencyBounds)+1)}
+		r.stats[key] = stat
+	}
+	stat.Count++
+	stat.TotalMs += ms
+	if ms > stat.MaxMs {
+		stat.MaxMs = ms
+	}
+	stat.Histogram[sort.SearchFloat64s(LatencyBounds, ms)]++
+}
+
+// Drain removes and returns the stats of the buckets that started before
+// before. A zero time drains every bucket, including the current one.
+func (r *Recorder) Drain(before time.Time) []Stat {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var drained []Stat
+	for key, stat := range r.stats {
+		if before.IsZero() || key.Bucket.Before(before) {
+			drained = append(drained, *stat)
+			delete(r.stats, key)
+		}
+	}
+	return drained
+}
+
+// Merge adds the counts of src to dst, which must have the same length
+func Merge(dst, src []int64) {
+	for i := range dst {
+		if i < len(src) {
+			dst[i] += src[i]
+		}
+	}
+}
+
+// Quantile estimates the q-quantile (0 to 1) of the latencies of a histogram,
+// interpolating linearly within the bucket it falls in. The overflow bucket
+// has no upper bound, so max, the slowest latency seen, is used instead.
+func Quantile(histogram []int64, max, q float64) float64 {
+	var total int64
+	for _, count := range histogram {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+
+	rank := q * float64(total)
+	var seen int64
+	for i, count := range histogram {
+		if count == 0 || float64(seen+count) < rank {
+			seen += count
+			continue
+		}
+		lower := 0.0
+		if i > 0 {
+			lower = LatencyBounds[i-1]
+		}
+		upper := max
+		if i < len(LatencyBounds) && LatencyBounds[i] < max {
+			upper = LatencyBounds[i]
+		}
+		if upper < lower {
+			return upper
+		}
+		return lower + (upper-lower)*(rank-float64(seen))/float64(count)
+	}
+	return max
+}
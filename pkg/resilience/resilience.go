@@ -0,0 +1,115 @@
+// Package resilience guards calls to external services: a rate limiter keeps
+// them within the quota the service allows, and a circuit breaker stops
+// calling a service that keeps failing until it had time to recover.
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrRateLimited is returned when a call would exceed the rate limit
+	// before the context is done
+	ErrRateLimited = errors.New("rate limit of the external service reached")
+	// ErrCircuitOpen is returned while calls are suspended after repeated failures
+	ErrCircuitOpen = errors.New("external service is failing, calls are suspended")
+)
+
+// Policy configures a Guard
+type Policy struct {
+	Rate             int           // Calls allowed per window, 0 for no limit
+	Window           time.Duration // Window of the rate
+	FailureThreshold int           // Consecutive failures that open the circuit, 0 never opens it
+	Cooldown         time.Duration // How long the circuit stays open before a call is tried again
+}
+
+// Guard rate limits calls to an external service and opens its circuit after
+// repeated failures. It is safe for concurrent use.
+type Guard struct {
+	policy Policy
+
+	mu        sync.Mutex
+	tokens    float64   // Calls that can be made at once
+	refilled  time.Time // When tokens were last refilled
+	failures  int       // Consecutive failures
+	openUntil time.Time // Calls are suspended until then
+}
+
+// NewGuard creates a guard with a full rate limit allowance
+func NewGuard(policy Policy) *Guard {
+	return &Guard{policy: policy, tokens: float64(policy.Rate), refilled: time.Now()}
+}
+
+// Do calls fn once the rate limit allows, waiting at most until ctx is done,
+// and records whether it failed. It returns ErrRateLimited when the wait
+// would outlast ctx, and ErrCircuitOpen while the circuit is open.
+func (g *Guard) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := g.acquire(ctx); err != nil {
+		return err
+	}
+	err := fn(ctx)
+	g.record(err)
+	return err
+}
+
+// acquire waits for a call to be allowed
+func (g *Guard) acquire(ctx context.Context) error {
+	g.mu.Lock()
+	now := time.Now()
+	if now.Before(g.openUntil) {
+		g.mu.Unlock()
+		return ErrCircuitOpen
+	}
+	if g.policy.Rate <= 0 || g.policy.Window <= 0 {
+		g.mu.Unlock()
+		return nil
+	}
+
+	perToken := g.policy.Window / time.Duration(g.policy.Rate)
+	g.tokens = min(float64(g.policy.Rate), g.tokens+float64(now.Sub(g.refilled))/float64(perToken))
+	g.refilled = now
+	g.tokens--
+	wait := time.Duration(0)
+	if g.tokens < 0 {
+		wait = time.Duration(-g.tokens * float64(perToken))
+	}
+	if deadline, ok := ctx.Deadline(); ok && now.Add(wait).After(deadline) {
+		g.tokens++ // The call is not made, so it keeps its allowance
+		g.mu.Unlock()
+		return ErrRateLimited
+	}
+	g.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ErrRateLimited
+	}
+}
+
+// record counts consecutive failures and opens the circuit at the threshold
+func (g *Guard) record(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	switch {
+	case errors.Is(err, context.Canceled):
+		return // The caller gave up, which says nothing about the service
+	case err == nil:
+		g.failures = 0
+		return
+	}
+	g.failures++
+	if g.policy.FailureThreshold > 0 && g.failures >= g.policy.FailureThreshold {
+		g.openUntil = time.Now().Add(g.policy.Cooldown)
+		g.failures = 0
+	}
+}
@@ -0,0 +1,87 @@
+// Package scheduler runs jobs on a calendar schedule, such as weekly digests,
+// rather than on a fixed interval.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"product-management/pkg/lock"
+)
+
+// runLockHold is how long an instance keeps a job's lock after running it, so
+// instances whose clocks lag behind skip the run instead of repeating it
+const runLockHold = 5 * time.Minute
+
+// Schedule returns the next run time strictly after a given time
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// Weekly runs once a week on a weekday at a time of day, in UTC
+type Weekly struct {
+	Day time.Weekday
+	At  time.Duration // Offset from midnight
+}
+
+// ParseWeekly parses a schedule such as "monday 08:00"
+func ParseWeekly(value string) (Weekly, error) {
+	dayName, clock, ok := strings.Cut(strings.TrimSpace(value), " ")
+	if !ok {
+		return Weekly{}, fmt.Errorf("expected \"<weekday> <HH:MM>\", got %q", value)
+	}
+	at, err := time.Parse("15:04", strings.TrimSpace(clock))
+	if err != nil {
+		return Weekly{}, fmt.Errorf("invalid time of day %q", clock)
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), dayName) {
+			return Weekly{Day: day, At: time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute}, nil
+		}
+	}
+	return Weekly{}, fmt.Errorf("invalid weekday %q", dayName)
+}
+
+// Next returns the first occurrence of the weekday and time after the given time
+func (w Weekly) Next(after time.Time) time.Time {
+	after = after.UTC()
+	midnight := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, time.UTC)
+	days := (int(w.Day) - int(after.Weekday()) + 7) % 7
+	next := midnight.AddDate(0, 0, days).Add(w.At)
+	if !next.After(after) {
+		next = next.AddDate(0, 0, 7)
+	}
+	return next
+}
+
+// Start runs the job at every time of the schedule and returns a function that
+// stops it. The job receives its scheduled time. When several instances run
+// the schedule, the first to take the job's lock.Default lock runs it and the
+// others skip it. Failures are logged and the job runs again at its next time.
+func Start(name string, schedule Schedule, job func(at time.Time) error) func() {
+	stop := make(chan struct{})
+	go func() {
+		for {
+			next := schedule.Next(time.Now())
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-stop:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			_, err := lock.Exclusive(context.Background(), lock.Default, "scheduler:"+name, runLockHold, func() {
+				if err := job(next); err != nil {
+					log.Printf("Warning: scheduled job %s failed: %v", name, err)
+				}
+			})
+			if err != nil {
+				log.Printf("Warning: failed to lock scheduled job %s: %v", name, err)
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
@@ -155,6 +155,12 @@ func SeedProducts(db *gorm.DB) error {
 		}
 	}
 
+	// Count the products linked above
+	if err := db.Exec(`UPDATE categories c
+		SET products_count = (SELECT COUNT(*) FROM product_categories pc WHERE pc.category_id = c.id)`).Error; err != nil {
+		return err
+	}
+
 	log.Println("Successfully seeded products and categories")
 	return nil
 }
@@ -0,0 +1,86 @@
+package seeder
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"product-management/pkg/lock"
+
+	"gorm.io/gorm"
+)
+
+// keptTables are the tables a sandbox reset leaves alone: the versions of
+// the migrations applied, so the schema isn't migrated again
+var keptTables = []string{"schema_migrations"}
+
+// ResetSandbox wipes every table of the sandbox schema and seeds it again
+func ResetSandbox(db *gorm.DB) error {
+	var all []string
+	if err := db.Raw("SELECT tablename FROM pg_tables WHERE schemaname = current_schema() ORDER BY tablename").
+		Scan(&all).Error; err != nil {
+		return err
+	}
+	tables := sandboxTables(all)
+	if len(tables) == 0 {
+		return fmt.Errorf("no tables to reset in the current schema")
+	}
+
+	if err := db.Exec(fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", strings.Join(tables, ", "))).Error; err != nil {
+		return err
+	}
+	if err := SeedProducts(db); err != nil {
+		return err
+	}
+	return SeedUsers(db)
+}
+
+// sandboxTables returns the quoted names of the tables a reset truncates,
+// which is all of them but keptTables
+func sandboxTables(all []string) []string {
+	tables := make([]string, 0, len(all))
+	for _, table := range all {
+		if !slices.Contains(keptTables, table) {
+			tables = append(tables, `"`+strings.ReplaceAll(table, `"`, `""`)+`"`)
+		}
+	}
+	return tables
+}
+
+// StartSandboxReset resets the sandbox data every interval. With several
+// instances, the first to reset keeps the lock for most of the interval, so
+// the others skip their turn. Call the returned function to stop it.
+func StartSandboxReset(db *gorm.DB, interval time.Duration) func() {
+	stop := make(chan struct{})
+	var once sync.Once
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				_, err := lock.Exclusive(context.Background(), lock.Default, "sandbox_reset", interval-interval/10, func() {
+					if err := ResetSandbox(db); err != nil {
+						log.Printf("Warning: Failed to reset sandbox data: %v", err)
+						return
+					}
+					log.Println("Sandbox data reset")
+				})
+				if err != nil {
+					log.Printf("Warning: Failed to lock sandbox reset: %v", err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		once.Do(func() { close(stop) })
+	}
+}
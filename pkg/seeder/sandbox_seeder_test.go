@@ -0,0 +1,44 @@
+package seeder
+
+import (
+	"io/fs"
+	"regexp"
+	"slices"
+	"testing"
+
+	"product-management/migrations"
+)
+
+var createTable = regexp.MustCompile(`CREATE TABLE IF NOT EXISTS "([a-z_]+)"`)
+
+// TestSandboxTablesCoverMigrations checks that a reset truncates every table
+// the migrations create, and leaves the migration versions alone
+func TestSandboxTablesCoverMigrations(t *testing.T) {
+	files, err := fs.Glob(migrations.Files, "*.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var migrated []string
+	for _, name := range files {
+		data, err := fs.ReadFile(migrations.Files, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, match := range createTable.FindAllStringSubmatch(string(data), -1) {
+			migrated = append(migrated, match[1])
+		}
+	}
+	if len(migrated) == 0 {
+		t.Fatal("no tables found in the migrations")
+	}
+
+	tables := sandboxTables(append(slices.Clone(migrated), "schema_migrations"))
+	for _, table := range migrated {
+		if !slices.Contains(tables, `"`+table+`"`) {
+			t.Errorf("table %s is not reset", table)
+		}
+	}
+	if slices.Contains(tables, `"schema_migrations"`) {
+		t.Error("schema_migrations is reset")
+	}
+}
@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalPathPrefix is the URL path under which the server serves local objects
+const LocalPathPrefix = "/files/"
+
+// Local stores objects as files under a root directory. Its signed URLs point
+// at the server's file route and carry an HMAC of the key and expiry.
+type Local struct {
+	root       string
+	publicURL  string
+	signingKey []byte
+}
+
+// NewLocal creates a local storage rooted at dir, creating it if needed
+func NewLocal(dir, publicURL string, signingKey []byte) (*Local, error) {
+	if len(signingKey) == 0 {
+		return nil, errors.New("local storage requires a signing key")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Local{root: dir, publicURL: strings.TrimSuffix(publicURL, "/"), signingKey: signingKey}, nil
+}
+
+// Put writes the object to a temporary file and renames it into place, so
+// readers never see a partial object
+func (l *Local) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get opens the object file
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+// Delete removes the object file
+func (l *Local) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List walks the root directory for files whose key starts with prefix
+func (l *Local) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	err := filepath.WalkDir(l.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+		rel, err := filepath.Rel(l.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, Object{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		return nil
+	})
+	return objects, err
+}
+
+// SignedURL returns a URL on the server's file route valid until expiry
+func (l *Local) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if !validKey(key) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	query := url.Values{"expires": {expires}, "signature": {l.sign(key, expires)}}
+	return l.publicURL + LocalPathPrefix + escapePath(key) + "?" + query.Encode(), nil
+}
+
+// Verify checks the expiry and signature of a signed URL's parameters
+func (l *Local) Verify(key, expires, signature string) error {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return errors.New("invalid expiry")
+	}
+	if time.Now().Unix() > unix {
+		return errors.New("signed URL has expired")
+	}
+	if !hmac.Equal([]byte(signature), []byte(l.sign(key, expires))) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func (l *Local) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, l.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path maps a key to its file, rejecting keys that would escape the root
+func (l *Local) path(key string) (string, error) {
+	if !validKey(key) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return filepath.Join(l.root, filepath.FromSlash(key)), nil
+}
+
+// escapePath escapes each segment of a slash-separated key
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// escape percent-encodes everything but the RFC 3986 unreserved characters
+func escape(value string) string {
+	var b strings.Builder
+	for _, c := range []byte(value) {
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3 stores objects in an S3 bucket through the AWS SDK. It also serves S3
+// compatible services such as MinIO (with a custom endpoint) and Google Cloud
+// Storage (see NewGCS).
+type S3 struct {
+	client  *s3.Client
+	presign *s3.PresignClient
+	bucket  string
+}
+
+// NewS3 creates an S3 storage for a bucket. An empty endpoint uses AWS with
+// virtual-hosted bucket URLs; a custom endpoint uses path-style URLs.
+func NewS3(bucket, region, endpoint, accessKey, secretKey string) *S3 {
+	options := s3.Options{
+		Region:      region,
+		Credentials: credentials.NewStaticCredentialsProvider(accessKey, secretKey, ""),
+		HTTPClient:  &http.Client{Timeout: 5 * time.Minute},
+		// Services other than AWS reject the newer default checksums
+		RequestChecksumCalculation: aws.RequestChecksumCalculationWhenRequired,
+		ResponseChecksumValidation: aws.ResponseChecksumValidationWhenRequired,
+	}
+	if endpoint != "" {
+		options.BaseEndpoint = aws.String(endpoint)
+		options.UsePathStyle = true
+	}
+	client := s3.New(options)
+	return &S3{client: client, presign: s3.NewPresignClient(client), bucket: bucket}
+}
+
+// NewGCS creates a Google Cloud Storage storage for a bucket. It uses the
+// S3-compatible XML API and therefore needs an HMAC key of a service account.
+func NewGCS(bucket, accessKey, secretKey string) *S3 {
+	return NewS3(bucket, "auto", "https://storage.googleapis.com", accessKey, secretKey)
+}
+
+// Put uploads the object. Content of unknown size is buffered first because
+// the API requires a content length.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if !validKey(key) {
+		return fmt.Errorf("invalid object key %q", key)
+	}
+	if size < 0 {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		r, size = bytes.NewReader(data), int64(len(data))
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		Body:          r,
+		ContentLength: aws.Int64(size),
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+	_, err := s.client.PutObject(ctx, input)
+	return err
+}
+
+// Get downloads the object
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if !validKey(key) {
+		return nil, fmt.Errorf("invalid object key %q", key)
+	}
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(key)})
+	if isNotFound(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return output.Body, nil
+}
+
+// Delete removes the object
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if !validKey(key) {
+		return fmt.Errorf("invalid object key %q", key)
+	}
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(key)})
+	if isNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// List pages through the objects under prefix
+func (s *S3) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	pages := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, content := range page.Contents {
+			objects = append(objects, Object{
+				Key:          aws.ToString(content.Key),
+				Size:         aws.ToInt64(content.Size),
+				LastModified: aws.ToTime(content.LastModified),
+			})
+		}
+	}
+	return objects, nil
+}
+
+// SignedURL returns a presigned GET URL. SigV4 limits expiry to seven days.
+func (s *S3) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if !validKey(key) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	request, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", err
+	}
+	return request.URL, nil
+}
+
+// isNotFound reports whether err is a missing object or bucket response
+func isNotFound(err error) bool {
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return true
+	}
+	var response *awshttp.ResponseError
+	return errors.As(err, &response) && response.HTTPStatusCode() == http.StatusNotFound
+}
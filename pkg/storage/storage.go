@@ -0,0 +1,119 @@
+// Package storage stores binary objects such as product and review images,
+// exports, invoices and backups behind one interface, backed by the local disk,
+// Amazon S3 or Google Cloud Storage depending on configuration.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"product-management/config"
+)
+
+// ErrNotFound is returned when an object does not exist
+var ErrNotFound = errors.New("object not found")
+
+// Object describes a stored object
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// Storage stores objects by key. Keys are slash-separated paths such as
+// "products/42/cover.jpg".
+type Storage interface {
+	// Put stores the content of r under key. size is the content length, or -1 if unknown.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the object stored under key
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+	// List returns the objects whose key starts with prefix
+	List(ctx context.Context, prefix string) ([]Object, error)
+	// SignedURL returns a URL granting read access to the object until it expires
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
+
+// Store is the global storage instance
+var Store Storage
+
+// Open creates the storage backend selected by the configuration
+func Open(cfg *config.Config) (Storage, error) {
+	switch cfg.StorageBackend {
+	case "local":
+		return NewLocal(cfg.StorageLocalDir, cfg.StoragePublicURL, []byte(cfg.StorageSigningKey))
+	case "s3":
+		return NewS3(cfg.StorageBucket, cfg.StorageRegion, cfg.StorageEndpoint, cfg.StorageAccessKey, cfg.StorageSecretKey), nil
+	case "gcs":
+		return NewGCS(cfg.StorageBucket, cfg.StorageAccessKey, cfg.StorageSecretKey), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.StorageBackend)
+	}
+}
+
+// DeleteOlderThan deletes the objects under prefix last modified more than age
+// ago and returns how many were deleted
+func DeleteOlderThan(ctx context.Context, s Storage, prefix string, age time.Duration) (int, error) {
+	objects, err := s.List(ctx, prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := time.Now().Add(-age)
+	deleted := 0
+	for _, object := range objects {
+		if object.LastModified.After(cutoff) {
+			continue
+		}
+		if err := s.Delete(ctx, object.Key); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// StartLifecycle applies the retention rules, a maximum age per key prefix,
+// every interval and returns a function that stops it
+func StartLifecycle(s Storage, rules map[string]time.Duration, interval time.Duration) func() {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			for prefix, age := range rules {
+				deleted, err := DeleteOlderThan(context.Background(), s, prefix, age)
+				if err != nil {
+					log.Printf("Warning: storage lifecycle failed for %q: %v", prefix, err)
+				} else if deleted > 0 {
+					log.Printf("Storage lifecycle deleted %d objects under %q", deleted, prefix)
+				}
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
+
+// validKey reports whether a key is a clean relative path
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+// Package trigram measures how similar two strings are by the three-letter
+// sequences they share, the way Postgres' pg_trgm extension does, so that
+// near-identical names such as "iPhone 15 Pro" and "IPhone-15 pro" match.
+package trigram
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Set is the set of trigrams of a string
+type Set map[string]struct{}
+
+// Of returns the trigrams of s. Like pg_trgm, it lowercases s, splits it into
+// words of letters and digits, and pads each word with two spaces in front and
+// one behind, so words contribute their first letters and their end.
+func Of(s string) Set {
+	set := make(Set)
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			set[string(runes[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
+
+// Similarity returns the share of trigrams two sets have in common, from 0 for
+// none to 1 for the same set
+func Similarity(a, b Set) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for trigram := range a {
+		if _, ok := b[trigram]; ok {
+			shared++
+		}
+	}
+	return SimilarityOf(shared, len(a), len(b))
+}
+
+// SimilarityOf returns the similarity of two sets of sizes a and b that share
+// shared trigrams, for callers that counted them through an index
+func SimilarityOf(shared, a, b int) float64 {
+	if shared == 0 {
+		return 0
+	}
+	return float64(shared) / float64(a+b-shared)
+}
@@ -4,36 +4,82 @@ import (
 	"strconv"
 )
 
+// PaginationConfig holds the default and maximum page sizes
+type PaginationConfig struct {
+	DefaultLimit       int            // Page size used when the client does not provide one
+	MaxLimit           int            // Largest page size allowed unless overridden per endpoint
+	MaxLimitByEndpoint map[string]int // Per-endpoint maximum page sizes
+}
+
+// paginationConfig is the active pagination configuration
+var paginationConfig = PaginationConfig{
+	DefaultLimit: 10,
+	MaxLimit:     100,
+}
+
+// SetPaginationConfig replaces the active pagination configuration.
+// Zero values keep the built-in defaults.
+func SetPaginationConfig(cfg PaginationConfig) {
+	if cfg.DefaultLimit > 0 {
+		paginationConfig.DefaultLimit = cfg.DefaultLimit
+	}
+	if cfg.MaxLimit > 0 {
+		paginationConfig.MaxLimit = cfg.MaxLimit
+	}
+	paginationConfig.MaxLimitByEndpoint = cfg.MaxLimitByEndpoint
+}
+
 // PaginationParams represents the pagination parameters from request
 type PaginationParams struct {
-	Page  int
-	Limit int
+	Page         int
+	Limit        int
+	DefaultLimit int // Effective default page size for the endpoint
+	MaxLimit     int // Effective maximum page size for the endpoint
 }
 
-// ParsePaginationParams parses pagination parameters from request query
-// Returns default values if parameters are invalid or not provided
-func ParsePaginationParams(pageStr, limitStr string) PaginationParams {
-	// Parse page number
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		page = 1
+// Offset returns the number of items to skip for the current page
+func (p PaginationParams) Offset() int {
+	return CalculateOffset(p.Page, p.Limit)
+}
+
+// NormalizePagination applies the configured defaults and maximum for an endpoint.
+// Missing or invalid values fall back to defaults and oversized pages are capped.
+func NormalizePagination(endpoint string, page, limit int) PaginationParams {
+	maxLimit := paginationConfig.MaxLimit
+	if endpointMax, ok := paginationConfig.MaxLimitByEndpoint[endpoint]; ok && endpointMax > 0 {
+		maxLimit = endpointMax
+	}
+	defaultLimit := paginationConfig.DefaultLimit
+	if defaultLimit > maxLimit {
+		defaultLimit = maxLimit
 	}
 
-	// Parse limit
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 {
-		limit = 10
+	if page < 1 {
+		page = 1
 	}
-	if limit > 100 {
-		limit = 100
+	if limit < 1 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
 	}
 
 	return PaginationParams{
-		Page:  page,
-		Limit: limit,
+		Page:         page,
+		Limit:        limit,
+		DefaultLimit: defaultLimit,
+		MaxLimit:     maxLimit,
 	}
 }
 
+// ParsePaginationParams parses pagination parameters from request query
+// Returns default values if parameters are invalid or not provided
+func ParsePaginationParams(endpoint, pageStr, limitStr string) PaginationParams {
+	page, _ := strconv.Atoi(pageStr)
+	limit, _ := strconv.Atoi(limitStr)
+	return NormalizePagination(endpoint, page, limit)
+}
+
 // PaginationResponse represents the pagination response structure
 type PaginationResponse struct {
 	Data       interface{} `json:"data"`
@@ -0,0 +1,99 @@
+package warehouse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ClickHouse loads rows into ClickHouse through its HTTP interface
+type ClickHouse struct {
+	client   *http.Client
+	url      string
+	database string
+	username string
+	password string
+}
+
+// NewClickHouse creates a store loading into a database of the ClickHouse
+// server at url, such as http://localhost:8123
+func NewClickHouse(url, database, username, password string, timeout time.Duration) *ClickHouse {
+	return &ClickHouse{
+		client:   &http.Client{Timeout: timeout},
+		url:      strings.TrimRight(url, "/"),
+		database: database,
+		username: username,
+		password: password,
+	}
+}
+
+// Migrate creates the database and the tables that don't exist, and adds the
+// columns missing from those that do. Tables are ReplacingMergeTrees ordered
+// by their key, so duplicate rows are merged away.
+func (c *ClickHouse) Migrate(ctx context.Context, tables []Table) error {
+	if err := c.exec(ctx, "CREATE DATABASE IF NOT EXISTS "+c.database, nil); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		columns := make([]string, len(table.Columns))
+		for i, column := range table.Columns {
+			columns[i] = column.Name + " " + column.Type
+		}
+		create := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (%s) ENGINE = ReplacingMergeTree ORDER BY (%s)",
+			c.database, table.Name, strings.Join(columns, ", "), strings.Join(table.Key, ", "))
+		if err := c.exec(ctx, create, nil); err != nil {
+			return fmt.Errorf("%s: %w", table.Name, err)
+		}
+		for _, column := range columns {
+			alter := fmt.Sprintf("ALTER TABLE %s.%s ADD COLUMN IF NOT EXISTS %s", c.database, table.Name, column)
+			if err := c.exec(ctx, alter, nil); err != nil {
+				return fmt.Errorf("%s: %w", table.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Insert loads rows into a table as JSON lines
+func (c *ClickHouse) Insert(ctx context.Context, table string, rows []Row) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, row := range rows {
+		if err := encoder.Encode(row); err != nil {
+			return err
+		}
+	}
+	return c.exec(ctx, fmt.Sprintf("INSERT INTO %s.%s FORMAT JSONEachRow", c.database, table), &body)
+}
+
+// exec runs a statement, sending data as the body of the request when given
+func (c *ClickHouse) exec(ctx context.Context, query string, data io.Reader) error {
+	endpoint := c.url + "/?" + url.Values{"query": {query}, "date_time_input_format": {"best_effort"}}.Encode()
+	if data == nil {
+		data = http.NoBody
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, data)
+	if err != nil {
+		return err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("clickhouse returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Package warehouse loads analytics events into an analytical store, so heavy
+// analytics can run there instead of on the transactional database
+package warehouse
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Column is a column of a warehouse table, with its type in the store's SQL
+type Column struct {
+	Name string
+	Type string
+}
+
+// Table is a warehouse table. Rows with the same key are duplicates of each
+// other, so a batch loaded twice, or a backfill overlapping exported events,
+// counts once.
+type Table struct {
+	Name    string
+	Columns []Column
+	Key     []string
+}
+
+// Row is a row of a warehouse table, by column name
+type Row map[string]interface{}
+
+// Store is an analytical store, such as ClickHouse or BigQuery
+type Store interface {
+	// Migrate creates the tables that don't exist and adds the columns
+	// missing from those that do
+	Migrate(ctx context.Context, tables []Table) error
+	// Insert loads a batch of rows into a table
+	Insert(ctx context.Context, table string, rows []Row) error
+}
+
+// Default is the store events are exported to, nil when the export is off
+var Default Store
+
+// Tables are the tables events are exported to. Times are UTC.
+var Tables = []Table{
+	{
+		Name: "orders",
+		Columns: []Column{
+			{Name: "quote_id", Type: "UInt64"},
+			{Name: "order_number", Type: "String"},
+			{Name: "user_id", Type: "UInt64"},
+			{Name: "country", Type: "String"},
+			{Name: "product_id", Type: "Array(UInt64)"},
+			{Name: "quantity", Type: "Array(Int64)"},
+			{Name: "unit_price", Type: "Array(Float64)"},
+			{Name: "total", Type: "Float64"},
+			{Name: "occurred_at", Type: "DateTime64(3, 'UTC')"},
+		},
+		Key: []string{"quote_id"},
+	},
+	{
+		Name: "product_views",
+		Columns: []Column{
+			{Name: "event_id", Type: "UInt64"},
+			{Name: "product_id", Type: "UInt64"},
+			{Name: "user_id", Type: "UInt64"},
+			{Name: "anonymous_id", Type: "String"},
+			{Name: "referrer", Type: "String"},
+			{Name: "occurred_at", Type: "DateTime64(3, 'UTC')"},
+		},
+		Key: []string{"event_id"},
+	},
+	{
+		Name: "searches",
+		Columns: []Column{
+			{Name: "event_id", Type: "UInt64"},
+			{Name: "term", Type: "String"},
+			{Name: "category_id", Type: "UInt64"},
+			{Name: "results", Type: "UInt64"},
+			{Name: "user_id", Type: "UInt64"},
+			{Name: "occurred_at", Type: "DateTime64(3, 'UTC')"},
+		},
+		Key: []string{"event_id"},
+	},
+}
+
+// Open returns the store of a driver: "log" or "clickhouse". BigQuery and
+// other stores can be added behind the Store interface.
+func Open(driver, url, database, username, password string, timeout time.Duration) (Store, error) {
+	switch driver {
+	case "log":
+		return LogStore{}, nil
+	case "clickhouse":
+		if url == "" {
+			return nil, fmt.Errorf("WAREHOUSE_URL is required for the clickhouse driver")
+		}
+		return NewClickHouse(url, database, username, password, timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown warehouse driver %q", driver)
+	}
+}
+
+// LogStore logs batches instead of loading them, for development
+type LogStore struct{}
+
+// Migrate does nothing, the log having no schema
+func (LogStore) Migrate(ctx context.Context, tables []Table) error {
+	return nil
+}
+
+// Insert logs the size of the batch
+func (LogStore) Insert(ctx context.Context, table string, rows []Row) error {
+	log.Printf("Warehouse: %d rows into %s", len(rows), table)
+	return nil
+}
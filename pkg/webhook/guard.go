@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenURL is returned for endpoints deliveries may not be sent to
+var ErrForbiddenURL = errors.New("webhook URL not allowed")
+
+// client only connects to public addresses, checked after DNS resolution so a
+// name cannot be pointed at an internal host, and never follows redirects,
+// which could lead anywhere
+var client = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				addr, err := netip.ParseAddrPort(address)
+				if err != nil {
+					return err
+				}
+				if !publicAddr(addr.Addr()) {
+					return fmt.Errorf("%w: %s is not a public address", ErrForbiddenURL, addr.Addr())
+				}
+				return nil
+			},
+		}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+		MaxIdleConnsPerHost: 4,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// CheckURL rejects endpoints that are not https or name a host that is not
+// public. Hosts given by name are checked again when a delivery connects.
+func CheckURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrForbiddenURL, err)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("%w: scheme must be https", ErrForbiddenURL)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return fmt.Errorf("%w: missing host", ErrForbiddenURL)
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s is not a public host", ErrForbiddenURL, host)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !publicAddr(addr) {
+		return fmt.Errorf("%w: %s is not a public address", ErrForbiddenURL, host)
+	}
+	return nil
+}
+
+// publicAddr reports whether an address may be reached by deliveries, ruling
+// out loopback, private, link-local (cloud metadata included), shared and
+// multicast ranges
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, internal to providers
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestCheckURL(t *testing.T) {
+	cases := []struct {
+		url     string
+		allowed bool
+	}{
+		{"https://erp.example.com/hooks/catalog", true},
+		{"https://203.0.113.7:8443/hooks", true},
+		{"http://erp.example.com/hooks", false},
+		{"ftp://erp.example.com/hooks", false},
+		{"https://localhost/hooks", false},
+		{"https://api.localhost/hooks", false},
+		{"https://127.0.0.1/hooks", false},
+		{"https://10.1.2.3/hooks", false},
+		{"https://192.168.0.10/hooks", false},
+		{"https://169.254.169.254/latest/meta-data", false},
+		{"https://[::1]/hooks", false},
+		{"https://[fd00::1]/hooks", false},
+		{"https://[::ffff:127.0.0.1]/hooks", false},
+		{"https://0.0.0.0/hooks", false},
+	}
+	for _, tc := range cases {
+		err := CheckURL(tc.url)
+		if tc.allowed && err != nil {
+			t.Errorf("%s rejected: %v", tc.url, err)
+		}
+		if !tc.allowed && !errors.Is(err, ErrForbiddenURL) {
+			t.Errorf("%s allowed, want ErrForbiddenURL", tc.url)
+		}
+	}
+}
+
+func TestPublicAddr(t *testing.T) {
+	for _, addr := range []string{"100.64.0.1", "172.16.5.4", "fe80::1", "224.0.0.1"} {
+		if publicAddr(netip.MustParseAddr(addr)) {
+			t.Errorf("%s treated as public", addr)
+		}
+	}
+	if !publicAddr(netip.MustParseAddr("8.8.8.8")) {
+		t.Errorf("8.8.8.8 treated as internal")
+	}
+}
@@ -0,0 +1,81 @@
+// Package webhook signs and sends webhook deliveries. Receivers verify the
+// X-Webhook-Signature header, "t=<unix time>,v1=<hex HMAC-SHA256>", computed
+// over "<unix time>.<body>" with the subscription secret.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers of a delivery
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	EventIDHeader   = "X-Webhook-Event-ID"
+)
+
+// NewSecret generates a subscription secret
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// NewEventID generates a random identifier shared by the retries of an event,
+// so receivers can drop duplicates
+func NewEventID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// Sign returns the signature header value of a body sent at a time
+func Sign(secret string, at time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts a signed JSON body to url and returns the response status. Any
+// status outside 2xx, redirects included, is returned as an error along with
+// the status. Endpoints rejected by CheckURL are never contacted.
+func Send(ctx context.Context, url, secret, event, eventID string, body []byte) (int, error) {
+	if err := CheckURL(url); err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(EventIDHeader, eventID)
+	req.Header.Set(SignatureHeader, Sign(secret, time.Now(), body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}